@@ -241,6 +241,15 @@ func (p *PayoutScheduleParams) AppendTo(body *form.Values, keyParts []string) {
 	}
 }
 
+// DecodeFrom implements custom decoding logic for PayoutScheduleParams that
+// undoes its AppendTo.
+func (p *PayoutScheduleParams) DecodeFrom(body *form.Values, keyParts []string) error {
+	if takeFormValue(body, append(keyParts, "delay_days"), "minimum") {
+		p.DelayDaysMinimum = Bool(true)
+	}
+	return nil
+}
+
 // AccountParams are the parameters allowed during account creation/updates.
 type AccountParams struct {
 	Params                `form:"*" json:"*"`
@@ -316,6 +325,17 @@ func (p *AccountExternalAccountParams) AppendTo(body *form.Values, keyParts []st
 	}
 }
 
+// DecodeFrom implements custom decoding logic for
+// AccountExternalAccountParams that undoes its AppendTo.
+func (p *AccountExternalAccountParams) DecodeFrom(body *form.Values, keyParts []string) error {
+	if token := body.Get(form.FormatKey(keyParts)); len(token) > 0 {
+		body.Del(form.FormatKey(keyParts))
+		p.Token = String(token[0])
+	}
+	takeFormValue(body, append(keyParts, "object"), "bank_account")
+	return nil
+}
+
 // AccountBusinessProfile represents optional information related to the business.
 type AccountBusinessProfile struct {
 	MCC                string   `json:"mcc"`
@@ -465,6 +485,9 @@ type Account struct {
 	Settings         *AccountSettings        `json:"settings"`
 	TOSAcceptance    *AccountTOSAcceptance   `json:"tos_acceptance"`
 	Type             AccountType             `json:"type"`
+
+	Extra    map[string]json.RawMessage `json:"-"`
+	expanded bool
 }
 
 // UnmarshalJSON handles deserialization of an account.
@@ -483,6 +506,7 @@ func (a *Account) UnmarshalJSON(data []byte) error {
 	}
 
 	*a = Account(v)
+	a.expanded = true
 	return nil
 }
 
@@ -516,6 +540,8 @@ type ExternalAccount struct {
 
 	ID   string              `json:"id"`
 	Type ExternalAccountType `json:"object"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // UnmarshalJSON implements Unmarshaler.UnmarshalJSON.
@@ -0,0 +1,132 @@
+package stripe
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+
+	"github.com/stripe/stripe-go/form"
+)
+
+// AccountBackend is a Backend that makes every request on behalf of a
+// connected account by injecting the `Stripe-Account` header, which saves
+// Connect platforms from having to set StripeAccount on every params struct.
+//
+// It's usually created through `WithAccount` on client.API or on any
+// resource client rather than directly.
+type AccountBackend struct {
+	// Backend is the backend that requests are forwarded to.
+	Backend Backend
+
+	// StripeAccount is the ID of the connected account that requests are
+	// made on behalf of.
+	StripeAccount string
+
+	// Strict makes requests fail when a params struct explicitly sets a
+	// StripeAccount that's different from the one that the backend is scoped
+	// to. When false, an explicit StripeAccount on params takes precedence.
+	Strict bool
+}
+
+// NewAccountBackend returns a Backend that makes every request through the
+// given backend on behalf of a connected account.
+func NewAccountBackend(backend Backend, stripeAccount string) *AccountBackend {
+	return &AccountBackend{Backend: backend, StripeAccount: stripeAccount}
+}
+
+// Call is the Backend.Call implementation for AccountBackend.
+func (b *AccountBackend) Call(method, path, key string, params ParamsContainer, v interface{}) error {
+	// See the comment on BackendImplementation.Call for why reflect is
+	// needed to check for nil.
+	var reflectValue reflect.Value
+	if params != nil {
+		reflectValue = reflect.ValueOf(params)
+	}
+	if !reflectValue.IsValid() || reflectValue.Kind() != reflect.Ptr || reflectValue.IsNil() {
+		scoped, err := b.scopeParams(nil)
+		if err != nil {
+			return err
+		}
+		return b.Backend.Call(method, path, key, scoped, v)
+	}
+
+	scoped, err := b.scopeParams(params.GetParams())
+	if err != nil {
+		return err
+	}
+
+	// The request is forwarded through Call rather than encoded here so that
+	// backends wrapped underneath keep their Call behavior. It's made with a
+	// copy of the params because they belong to the caller.
+	copied := reflect.New(reflectValue.Elem().Type())
+	copied.Elem().Set(reflectValue.Elem())
+	scopedParams := copied.Interface().(ParamsContainer)
+	if setter, ok := scopedParams.(stripeAccountSetter); ok {
+		setter.SetStripeAccount(*scoped.StripeAccount)
+	} else {
+		scopedParams.GetParams().StripeAccount = scoped.StripeAccount
+	}
+
+	return b.Backend.Call(method, path, key, scopedParams, v)
+}
+
+// CallMultipart is the Backend.CallMultipart implementation for
+// AccountBackend.
+func (b *AccountBackend) CallMultipart(method, path, key, boundary string, body *bytes.Buffer, params *Params, v interface{}) error {
+	scoped, err := b.scopeParams(params)
+	if err != nil {
+		return err
+	}
+
+	return b.Backend.CallMultipart(method, path, key, boundary, body, scoped, v)
+}
+
+// CallRaw is the Backend.CallRaw implementation for AccountBackend.
+func (b *AccountBackend) CallRaw(method, path, key string, body *form.Values, params *Params, v interface{}) error {
+	scoped, err := b.scopeParams(params)
+	if err != nil {
+		return err
+	}
+
+	return b.Backend.CallRaw(method, path, key, body, scoped, v)
+}
+
+// SetMaxNetworkRetries sets max number of retries on failed requests of the
+// underlying backend.
+func (b *AccountBackend) SetMaxNetworkRetries(maxNetworkRetries int) {
+	b.Backend.SetMaxNetworkRetries(maxNetworkRetries)
+}
+
+// Unwrap returns the backend that requests are forwarded to.
+func (b *AccountBackend) Unwrap() Backend {
+	return b.Backend
+}
+
+// scopeParams returns a copy of params with StripeAccount set to the scoped
+// account. The original is left untouched because it belongs to the caller
+// and may be reused across differently scoped clients.
+func (b *AccountBackend) scopeParams(params *Params) (*Params, error) {
+	scoped := &Params{}
+	if params != nil {
+		*scoped = *params
+	}
+
+	if scoped.StripeAccount != nil && *scoped.StripeAccount != b.StripeAccount {
+		if b.Strict {
+			return nil, fmt.Errorf(
+				"params StripeAccount %q conflicts with client scoped to account %q",
+				*scoped.StripeAccount, b.StripeAccount)
+		}
+		return scoped, nil
+	}
+
+	scoped.StripeAccount = String(b.StripeAccount)
+	return scoped, nil
+}
+
+// stripeAccountSetter is implemented by params embedding Params or
+// ListParams. The latter don't return their own Params from GetParams, so
+// StripeAccount is set through it instead.
+type stripeAccountSetter interface {
+	SetStripeAccount(val string)
+}
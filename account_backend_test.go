@@ -0,0 +1,126 @@
+package stripe
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+	"github.com/stripe/stripe-go/form"
+)
+
+func newAccountTestServer(t *testing.T, accounts *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*accounts = append(*accounts, r.Header.Get("Stripe-Account"))
+		w.Write([]byte(`{}`))
+	}))
+}
+
+func TestAccountBackend(t *testing.T) {
+	var accounts []string
+	testServer := newAccountTestServer(t, &accounts)
+	defer testServer.Close()
+
+	backend := NewAccountBackend(GetBackendWithConfig(
+		APIBackend,
+		&BackendConfig{URL: testServer.URL},
+	), "acct_123")
+
+	// Call
+	params := &CustomerParams{}
+	err := backend.Call(http.MethodPost, "/v1/customers", "sk_test_123", params, nil)
+	assert.NoError(t, err)
+
+	// The caller's params are left untouched.
+	assert.Nil(t, params.StripeAccount)
+
+	// Call without params
+	err = backend.Call(http.MethodGet, "/v1/customers", "sk_test_123", nil, nil)
+	assert.NoError(t, err)
+
+	// CallRaw, as used by list iterators
+	err = backend.CallRaw(http.MethodGet, "/v1/customers", "sk_test_123", &form.Values{}, &Params{}, nil)
+	assert.NoError(t, err)
+
+	// CallMultipart, as used by file uploads
+	err = backend.CallMultipart(http.MethodPost, "/v1/files", "sk_test_123", "boundary",
+		bytes.NewBufferString(""), nil, nil)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"acct_123", "acct_123", "acct_123", "acct_123"}, accounts)
+}
+
+func TestAccountBackend_ExplicitAccount(t *testing.T) {
+	var accounts []string
+	testServer := newAccountTestServer(t, &accounts)
+	defer testServer.Close()
+
+	backend := NewAccountBackend(GetBackendWithConfig(
+		APIBackend,
+		&BackendConfig{URL: testServer.URL},
+	), "acct_123")
+
+	params := &CustomerParams{}
+	params.SetStripeAccount("acct_456")
+	err := backend.Call(http.MethodPost, "/v1/customers", "sk_test_123", params, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"acct_456"}, accounts)
+
+	// Strict mode refuses to send the request
+	backend.Strict = true
+	err = backend.Call(http.MethodPost, "/v1/customers", "sk_test_123", params, nil)
+	assert.Error(t, err)
+	assert.Equal(t, 1, len(accounts))
+
+	// But an explicit account that matches the scope is fine
+	params.SetStripeAccount("acct_123")
+	err = backend.Call(http.MethodPost, "/v1/customers", "sk_test_123", params, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"acct_456", "acct_123"}, accounts)
+}
+
+// callRecorder is a Backend recording which of its methods requests came
+// through before forwarding them.
+type callRecorder struct {
+	Backend
+	methods []string
+	params  []ParamsContainer
+}
+
+func (b *callRecorder) Call(method, path, key string, params ParamsContainer, v interface{}) error {
+	b.methods = append(b.methods, "Call")
+	b.params = append(b.params, params)
+	return b.Backend.Call(method, path, key, params, v)
+}
+
+func (b *callRecorder) CallRaw(method, path, key string, body *form.Values, params *Params, v interface{}) error {
+	b.methods = append(b.methods, "CallRaw")
+	return b.Backend.CallRaw(method, path, key, body, params, v)
+}
+
+func TestAccountBackend_ForwardsCall(t *testing.T) {
+	var accounts []string
+	testServer := newAccountTestServer(t, &accounts)
+	defer testServer.Close()
+
+	recorder := &callRecorder{Backend: GetBackendWithConfig(
+		APIBackend,
+		&BackendConfig{URL: testServer.URL},
+	)}
+	backend := NewAccountBackend(recorder, "acct_123")
+
+	params := &CustomerParams{Email: String("foo@example.com")}
+	err := backend.Call(http.MethodPost, "/v1/customers", "sk_test_123", params, nil)
+	assert.NoError(t, err)
+	err = backend.Call(http.MethodGet, "/v1/customers", "sk_test_123", &CustomerListParams{}, nil)
+	assert.NoError(t, err)
+
+	// Wrapped backends get a scoped copy of the params through Call
+	assert.Equal(t, []string{"Call", "Call"}, recorder.methods)
+	scoped := recorder.params[0].(*CustomerParams)
+	assert.Equal(t, "acct_123", *scoped.StripeAccount)
+	assert.Equal(t, "foo@example.com", *scoped.Email)
+	assert.Nil(t, params.StripeAccount)
+	assert.Equal(t, []string{"acct_123", "acct_123"}, accounts)
+}
@@ -1,5 +1,7 @@
 package stripe
 
+import "encoding/json"
+
 // AccountLinkType is the type of an account link.
 type AccountLinkType string
 
@@ -35,4 +37,6 @@ type AccountLink struct {
 	ExpiresAt int64  `json:"expires_at"`
 	Object    string `json:"object"`
 	URL       string `json:"url"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
@@ -0,0 +1,118 @@
+package analytics
+
+import (
+	"sort"
+	"time"
+
+	stripe "github.com/stripe/stripe-go"
+)
+
+//
+// Public types
+//
+
+// Cohort is the MRR and retention of the customers who started their first
+// subscription in a currency in the same month.
+type Cohort struct {
+	// Currency is the currency of the MRR.
+	Currency stripe.Currency
+
+	// Customers is the number of customers in the cohort.
+	Customers int
+
+	// MRR is the total MRR of the customers of the cohort, in the smallest
+	// unit of Currency.
+	MRR int64
+
+	// Month is the Unix time of the first day of the month, in UTC, in which
+	// the customers of the cohort started their first subscription.
+	Month int64
+
+	// Retained is the number of customers of the cohort who still bring
+	// revenue.
+	Retained int
+}
+
+//
+// Public functions
+//
+
+// Cohorts groups the customers of a snapshot by the month in which they
+// started their first subscription in each currency, and returns the MRR and
+// retention of each group, sorted by month and currency.
+//
+// Customers only count as churned if their canceled subscriptions are part
+// of the snapshot, so it should be loaded from subscriptions listed with a
+// status of "all". Comparing the cohorts of successive snapshots shows how
+// the revenue of each cohort evolves.
+func Cohorts(s *Snapshot) []*Cohort {
+	starts := make(map[customerCurrency]int64)
+	for _, mrr := range s.Subscriptions {
+		if mrr.Start == 0 {
+			continue
+		}
+		key := customerCurrency{currency: mrr.Currency, customer: mrr.Customer}
+		if start, ok := starts[key]; !ok || mrr.Start < start {
+			starts[key] = mrr.Start
+		}
+	}
+
+	totals := s.customerMRR()
+	cohorts := make(map[cohortKey]*Cohort)
+	for key, start := range starts {
+		month := cohortKey{currency: key.currency, month: startOfMonth(start)}
+		cohort, ok := cohorts[month]
+		if !ok {
+			cohort = &Cohort{Currency: month.currency, Month: month.month}
+			cohorts[month] = cohort
+		}
+
+		cohort.Customers++
+		cohort.MRR += totals[key]
+		if totals[key] > 0 {
+			cohort.Retained++
+		}
+	}
+
+	var sorted []*Cohort
+	for _, cohort := range cohorts {
+		sorted = append(sorted, cohort)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Month != sorted[j].Month {
+			return sorted[i].Month < sorted[j].Month
+		}
+		return sorted[i].Currency < sorted[j].Currency
+	})
+	return sorted
+}
+
+// Retention returns the fraction of the customers of the cohort who still
+// bring revenue.
+func (c *Cohort) Retention() float64 {
+	if c.Customers == 0 {
+		return 0
+	}
+	return float64(c.Retained) / float64(c.Customers)
+}
+
+//
+// Private types
+//
+
+// cohortKey identifies the cohort of a month in a currency.
+type cohortKey struct {
+	currency stripe.Currency
+	month    int64
+}
+
+//
+// Private functions
+//
+
+// startOfMonth returns the Unix time of the first day of the month of a
+// Unix time, in UTC.
+func startOfMonth(t int64) int64 {
+	date := time.Unix(t, 0).UTC()
+	return time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC).Unix()
+}
@@ -0,0 +1,41 @@
+package analytics
+
+import (
+	"testing"
+	"time"
+
+	assert "github.com/stretchr/testify/require"
+	stripe "github.com/stripe/stripe-go"
+)
+
+func TestCohorts(t *testing.T) {
+	january := time.Date(2019, time.January, 1, 0, 0, 0, 0, time.UTC).Unix()
+	february := time.Date(2019, time.February, 1, 0, 0, 0, 0, time.UTC).Unix()
+
+	started := func(id, customer string, quantity, start int64) *stripe.Subscription {
+		s := newSubscription(id, customer, monthlyPlan, quantity)
+		s.StartDate = start
+		return s
+	}
+	canceled := started("sub_3", "cus_2", 1, january+86400)
+	canceled.Status = stripe.SubscriptionStatusCanceled
+	created := newSubscription("sub_5", "cus_4", monthlyPlan, 1)
+	created.Created = february + 86400
+
+	snapshot := NewSnapshot([]*stripe.Subscription{
+		started("sub_1", "cus_1", 1, january),
+		started("sub_2", "cus_1", 2, february+3600),
+		canceled,
+		started("sub_4", "cus_3", 3, february-1),
+		created,
+		newSubscription("sub_6", "cus_5", monthlyPlan, 1),
+	}, time.Unix(1550000000, 0))
+
+	cohorts := Cohorts(snapshot)
+	assert.Equal(t, []*Cohort{
+		{Currency: stripe.CurrencyUSD, Customers: 3, MRR: 6000, Month: january, Retained: 2},
+		{Currency: stripe.CurrencyUSD, Customers: 1, MRR: 1000, Month: february, Retained: 1},
+	}, cohorts)
+	assert.InDelta(t, 2.0/3, cohorts[0].Retention(), 0.0001)
+	assert.Equal(t, 1.0, cohorts[1].Retention())
+}
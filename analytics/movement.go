@@ -0,0 +1,284 @@
+package analytics
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	stripe "github.com/stripe/stripe-go"
+)
+
+//
+// Public constants
+//
+
+// MovementType is the category of a change in the MRR of a customer.
+type MovementType string
+
+// List of values that MovementType can take.
+const (
+	MovementTypeChurn        MovementType = "churn"
+	MovementTypeContraction  MovementType = "contraction"
+	MovementTypeExpansion    MovementType = "expansion"
+	MovementTypeNew          MovementType = "new"
+	MovementTypeReactivation MovementType = "reactivation"
+)
+
+//
+// Public types
+//
+
+// Movement is a change in the MRR of a customer.
+type Movement struct {
+	// Amount is the change in MRR, which is negative for churn and
+	// contraction.
+	Amount int64
+
+	// At is the Unix time of the change: the time of the later snapshot for
+	// movements between snapshots, or the creation time of the event.
+	At int64
+
+	// Currency is the currency of the MRR.
+	Currency stripe.Currency
+
+	// Customer is the ID of the customer.
+	Customer string
+
+	// Type is the category of the change.
+	Type MovementType
+}
+
+// MovementSummary is the total of movements in a currency by category.
+type MovementSummary struct {
+	Churn        int64
+	Contraction  int64
+	Expansion    int64
+	New          int64
+	Reactivation int64
+}
+
+// NetNew returns the net new MRR of the movements, which is the total of all
+// their categories.
+func (s *MovementSummary) NetNew() int64 {
+	return s.New + s.Reactivation + s.Expansion + s.Contraction + s.Churn
+}
+
+// Tracker keeps the MRR of subscriptions current by applying
+// customer.subscription.* events to a snapshot, and reports the movements
+// they cause. Unlike movements between two snapshots, movements reported by
+// a tracker tell customers that come back after churning apart from new
+// ones.
+//
+// Subscription events don't expand the customer, so a tracker keeps
+// applying the customer discounts of its snapshot to the subscriptions of
+// those customers. Changes to customer discounts are picked up from the next
+// snapshot.
+type Tracker struct {
+	mu       sync.Mutex
+	churned  map[customerCurrency]bool
+	snapshot *Snapshot
+
+	// updated is the creation time of the last event applied to each
+	// subscription, so that events received out of order are ignored.
+	updated map[string]int64
+}
+
+//
+// Public functions
+//
+
+// Compare returns the movements in MRR of each customer between two
+// snapshots, sorted by customer. Customers whose MRR went up from zero are
+// counted as new.
+func Compare(from, to *Snapshot) []*Movement {
+	before := from.customerMRR()
+	after := to.customerMRR()
+
+	var movements []*Movement
+	for key, mrr := range after {
+		if movement := newMovement(key, before[key], mrr, false, to.At); movement != nil {
+			movements = append(movements, movement)
+		}
+	}
+	for key, mrr := range before {
+		if _, ok := after[key]; !ok {
+			if movement := newMovement(key, mrr, 0, false, to.At); movement != nil {
+				movements = append(movements, movement)
+			}
+		}
+	}
+
+	sortMovements(movements)
+	return movements
+}
+
+// Summarize totals movements by currency and category.
+func Summarize(movements []*Movement) map[stripe.Currency]*MovementSummary {
+	summaries := make(map[stripe.Currency]*MovementSummary)
+	for _, movement := range movements {
+		summary, ok := summaries[movement.Currency]
+		if !ok {
+			summary = &MovementSummary{}
+			summaries[movement.Currency] = summary
+		}
+
+		switch movement.Type {
+		case MovementTypeChurn:
+			summary.Churn += movement.Amount
+		case MovementTypeContraction:
+			summary.Contraction += movement.Amount
+		case MovementTypeExpansion:
+			summary.Expansion += movement.Amount
+		case MovementTypeNew:
+			summary.New += movement.Amount
+		case MovementTypeReactivation:
+			summary.Reactivation += movement.Amount
+		}
+	}
+	return summaries
+}
+
+// NewTracker returns a tracker starting from the given snapshot, which it
+// takes ownership of.
+func NewTracker(snapshot *Snapshot) *Tracker {
+	return &Tracker{
+		churned:  make(map[customerCurrency]bool),
+		snapshot: snapshot,
+		updated:  make(map[string]int64),
+	}
+}
+
+// HandleEvent applies a customer.subscription.created, updated or deleted
+// event, and returns the movement it caused, or nil if the customer's MRR
+// didn't change. Other events are ignored.
+//
+// The MRR of the subscription is computed as of the event's creation time.
+// Events older than the last event applied to the same subscription are
+// ignored.
+func (t *Tracker) HandleEvent(event *stripe.Event) (*Movement, error) {
+	if !strings.HasPrefix(event.Type, "customer.subscription.") || event.Data == nil {
+		return nil, nil
+	}
+
+	subscription := &stripe.Subscription{}
+	if err := json.Unmarshal(event.Data.Raw, subscription); err != nil {
+		return nil, err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if event.Created < t.updated[subscription.ID] {
+		return nil, nil
+	}
+	t.updated[subscription.ID] = event.Created
+
+	if subscription.Customer != nil && subscription.Customer.Discount == nil {
+		subscription.Customer.Discount = t.customerDiscount(subscription.Customer.ID)
+	}
+	mrr := newSubscriptionMRR(subscription, time.Unix(event.Created, 0))
+	if event.Type == "customer.subscription.deleted" {
+		mrr.MRR = 0
+	}
+
+	previous, ok := t.snapshot.Subscriptions[subscription.ID]
+	if ok && mrr.Currency == "" {
+		mrr.Currency = previous.Currency
+	}
+	if ok && mrr.Start == 0 {
+		mrr.Start = previous.Start
+	}
+	key := customerCurrency{currency: mrr.Currency, customer: mrr.Customer}
+
+	before := t.customerTotal(key)
+	t.snapshot.Subscriptions[subscription.ID] = mrr
+	after := t.customerTotal(key)
+
+	movement := newMovement(key, before, after, t.churned[key], event.Created)
+	if movement != nil && movement.Type == MovementTypeChurn {
+		t.churned[key] = true
+	}
+	return movement, nil
+}
+
+// Snapshot returns a copy of the current snapshot of the tracker, taken at
+// the given time.
+func (t *Tracker) Snapshot(at time.Time) *Snapshot {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	snapshot := &Snapshot{
+		At:            at.Unix(),
+		Subscriptions: make(map[string]*SubscriptionMRR, len(t.snapshot.Subscriptions)),
+	}
+	for id, mrr := range t.snapshot.Subscriptions {
+		mrr := *mrr
+		snapshot.Subscriptions[id] = &mrr
+	}
+	return snapshot
+}
+
+//
+// Private functions
+//
+
+// customerDiscount returns the customer discount applied to the
+// subscriptions of a customer, or nil if there's none.
+func (t *Tracker) customerDiscount(customer string) *stripe.Discount {
+	for _, mrr := range t.snapshot.Subscriptions {
+		if mrr.Customer == customer && mrr.CustomerDiscount != nil {
+			return mrr.CustomerDiscount
+		}
+	}
+	return nil
+}
+
+// customerTotal returns the total MRR of a customer in a currency.
+func (t *Tracker) customerTotal(key customerCurrency) int64 {
+	var total int64
+	for _, mrr := range t.snapshot.Subscriptions {
+		if mrr.Customer == key.customer && mrr.Currency == key.currency {
+			total += mrr.MRR
+		}
+	}
+	return total
+}
+
+// newMovement returns the movement of a customer's MRR from before to
+// after, or nil if it didn't change.
+func newMovement(key customerCurrency, before, after int64, churned bool, at int64) *Movement {
+	if before == after {
+		return nil
+	}
+
+	movement := &Movement{
+		Amount:   after - before,
+		At:       at,
+		Currency: key.currency,
+		Customer: key.customer,
+	}
+	switch {
+	case before == 0 && churned:
+		movement.Type = MovementTypeReactivation
+	case before == 0:
+		movement.Type = MovementTypeNew
+	case after == 0:
+		movement.Type = MovementTypeChurn
+	case after > before:
+		movement.Type = MovementTypeExpansion
+	default:
+		movement.Type = MovementTypeContraction
+	}
+	return movement
+}
+
+func sortMovements(movements []*Movement) {
+	sort.Slice(movements, func(i, j int) bool {
+		if movements[i].Customer != movements[j].Customer {
+			return movements[i].Customer < movements[j].Customer
+		}
+		return movements[i].Currency < movements[j].Currency
+	})
+}
@@ -0,0 +1,125 @@
+package analytics
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	assert "github.com/stretchr/testify/require"
+	stripe "github.com/stripe/stripe-go"
+)
+
+var monthlyPlan = &stripe.Plan{Amount: 1000, Currency: stripe.CurrencyUSD, Interval: stripe.PlanIntervalMonth}
+
+func subscriptionEvent(t *testing.T, eventType string, created int64, s *stripe.Subscription) *stripe.Event {
+	raw, err := json.Marshal(map[string]interface{}{
+		"customer": s.Customer.ID,
+		"id":       s.ID,
+		"items":    s.Items,
+		"status":   s.Status,
+	})
+	assert.NoError(t, err)
+	return &stripe.Event{Created: created, Data: &stripe.EventData{Raw: raw}, Type: eventType}
+}
+
+func TestCompare(t *testing.T) {
+	from := NewSnapshot([]*stripe.Subscription{
+		newSubscription("sub_1", "cus_1", monthlyPlan, 1),
+		newSubscription("sub_2", "cus_2", monthlyPlan, 2),
+		newSubscription("sub_3", "cus_3", monthlyPlan, 3),
+		newSubscription("sub_4", "cus_4", monthlyPlan, 1),
+	}, time.Unix(1500000000, 0))
+
+	canceled := newSubscription("sub_4", "cus_4", monthlyPlan, 1)
+	canceled.Status = stripe.SubscriptionStatusCanceled
+	to := NewSnapshot([]*stripe.Subscription{
+		newSubscription("sub_2", "cus_2", monthlyPlan, 3),
+		newSubscription("sub_3", "cus_3", monthlyPlan, 1),
+		canceled,
+		newSubscription("sub_5", "cus_5", monthlyPlan, 1),
+	}, time.Unix(1500086400, 0))
+
+	movements := Compare(from, to)
+	assert.Equal(t, 5, len(movements))
+	assert.Equal(t, &Movement{Amount: -1000, At: 1500086400, Currency: stripe.CurrencyUSD,
+		Customer: "cus_1", Type: MovementTypeChurn}, movements[0])
+	assert.Equal(t, MovementTypeExpansion, movements[1].Type)
+	assert.Equal(t, int64(1000), movements[1].Amount)
+	assert.Equal(t, MovementTypeContraction, movements[2].Type)
+	assert.Equal(t, int64(-2000), movements[2].Amount)
+	assert.Equal(t, MovementTypeChurn, movements[3].Type)
+	assert.Equal(t, MovementTypeNew, movements[4].Type)
+
+	summary := Summarize(movements)[stripe.CurrencyUSD]
+	assert.Equal(t, &MovementSummary{Churn: -2000, Contraction: -2000, Expansion: 1000, New: 1000}, summary)
+	assert.Equal(t, int64(-2000), summary.NetNew())
+}
+
+func TestTracker(t *testing.T) {
+	tracker := NewTracker(NewSnapshot([]*stripe.Subscription{
+		newSubscription("sub_1", "cus_1", monthlyPlan, 1),
+	}, time.Unix(1500000000, 0)))
+
+	// Upgrading a subscription is an expansion
+	movement, err := tracker.HandleEvent(subscriptionEvent(t, "customer.subscription.updated", 1500000100,
+		newSubscription("sub_1", "cus_1", monthlyPlan, 2)))
+	assert.NoError(t, err)
+	assert.Equal(t, MovementTypeExpansion, movement.Type)
+	assert.Equal(t, int64(1000), movement.Amount)
+
+	// Events older than the last one applied are ignored
+	movement, err = tracker.HandleEvent(subscriptionEvent(t, "customer.subscription.updated", 1500000050,
+		newSubscription("sub_1", "cus_1", monthlyPlan, 5)))
+	assert.NoError(t, err)
+	assert.Nil(t, movement)
+
+	// A customer that churns and comes back is reactivated
+	movement, err = tracker.HandleEvent(subscriptionEvent(t, "customer.subscription.deleted", 1500000200,
+		newSubscription("sub_1", "cus_1", monthlyPlan, 2)))
+	assert.NoError(t, err)
+	assert.Equal(t, MovementTypeChurn, movement.Type)
+	assert.Equal(t, int64(-2000), movement.Amount)
+
+	movement, err = tracker.HandleEvent(subscriptionEvent(t, "customer.subscription.created", 1500000300,
+		newSubscription("sub_2", "cus_1", monthlyPlan, 1)))
+	assert.NoError(t, err)
+	assert.Equal(t, MovementTypeReactivation, movement.Type)
+	assert.Equal(t, int64(1500000300), movement.At)
+
+	// A trialing subscription brings no revenue yet
+	trialing := newSubscription("sub_3", "cus_3", monthlyPlan, 1)
+	trialing.Status = stripe.SubscriptionStatusTrialing
+	movement, err = tracker.HandleEvent(subscriptionEvent(t, "customer.subscription.created", 1500000400, trialing))
+	assert.NoError(t, err)
+	assert.Nil(t, movement)
+
+	movement, err = tracker.HandleEvent(&stripe.Event{Type: "invoice.paid"})
+	assert.NoError(t, err)
+	assert.Nil(t, movement)
+
+	snapshot := tracker.Snapshot(time.Unix(1500000500, 0))
+	assert.Equal(t, map[stripe.Currency]int64{stripe.CurrencyUSD: 1000}, snapshot.MRR())
+}
+
+func TestTracker_CustomerDiscount(t *testing.T) {
+	discount := &stripe.Discount{Coupon: &stripe.Coupon{Duration: stripe.CouponDurationForever, PercentOff: 50}}
+	s := newSubscription("sub_1", "cus_1", monthlyPlan, 2)
+	s.Customer.Discount = discount
+	tracker := NewTracker(NewSnapshot([]*stripe.Subscription{s}, time.Unix(1500000000, 0)))
+
+	// The customer isn't expanded in events, but its discount still applies
+	movement, err := tracker.HandleEvent(subscriptionEvent(t, "customer.subscription.updated", 1500000100,
+		newSubscription("sub_1", "cus_1", monthlyPlan, 2)))
+	assert.NoError(t, err)
+	assert.Nil(t, movement)
+
+	movement, err = tracker.HandleEvent(subscriptionEvent(t, "customer.subscription.created", 1500000200,
+		newSubscription("sub_2", "cus_1", monthlyPlan, 1)))
+	assert.NoError(t, err)
+	assert.Equal(t, MovementTypeExpansion, movement.Type)
+	assert.Equal(t, int64(500), movement.Amount)
+
+	snapshot := tracker.Snapshot(time.Unix(1500000300, 0))
+	assert.Equal(t, discount, snapshot.Subscriptions["sub_2"].CustomerDiscount)
+	assert.Equal(t, map[stripe.Currency]int64{stripe.CurrencyUSD: 1500}, snapshot.MRR())
+}
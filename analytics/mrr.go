@@ -0,0 +1,337 @@
+// Package analytics computes subscription metrics like monthly recurring
+// revenue (MRR), its movements and its breakdown by cohort from
+// subscriptions and their events.
+package analytics
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	stripe "github.com/stripe/stripe-go"
+	"github.com/stripe/stripe-go/sub"
+)
+
+//
+// Public types
+//
+
+// Converter converts amounts to a single currency with the rates of a
+// stripe.ExchangeRate, as returned by exchangerate.Get.
+type Converter struct {
+	base  stripe.Currency
+	rates map[stripe.Currency]float64
+}
+
+// Snapshot is the MRR of a set of subscriptions at a point in time.
+type Snapshot struct {
+	// At is the Unix time of the snapshot, which determines which discounts
+	// are applied.
+	At int64
+
+	// Subscriptions is the MRR of every subscription of the snapshot, by
+	// subscription ID. Subscriptions which don't bring revenue, like trialing
+	// or canceled ones, have an MRR of zero.
+	Subscriptions map[string]*SubscriptionMRR
+}
+
+// SubscriptionMRR is the MRR of a subscription.
+type SubscriptionMRR struct {
+	// Currency is the currency of the subscription.
+	Currency stripe.Currency
+
+	// Customer is the ID of the subscription's customer.
+	Customer string
+
+	// CustomerDiscount is the discount of the customer that was applied to
+	// the subscription, if any. Trackers keep applying it to the
+	// subscriptions of the customer, since the customer isn't expanded in
+	// subscription events.
+	CustomerDiscount *stripe.Discount
+
+	// MRR is the monthly recurring revenue of the subscription, after
+	// discounts, in the smallest unit of Currency.
+	MRR int64
+
+	// Start is the Unix time at which the subscription started.
+	Start int64
+
+	// Subscription is the ID of the subscription.
+	Subscription string
+}
+
+//
+// Public functions
+//
+
+// NewConverter returns a converter to the base currency of the given
+// exchange rate.
+func NewConverter(rate *stripe.ExchangeRate) *Converter {
+	return &Converter{base: stripe.Currency(rate.ID), rates: rate.Rates}
+}
+
+// Base returns the currency that amounts are converted to.
+func (c *Converter) Base() stripe.Currency {
+	return c.base
+}
+
+// Convert converts an amount in the smallest unit of a currency to the
+// smallest unit of the base currency, rounded to the nearest unit.
+func (c *Converter) Convert(amount int64, currency stripe.Currency) (int64, error) {
+	if currency == c.base {
+		return amount, nil
+	}
+
+	rate, ok := c.rates[currency]
+	if !ok || rate <= 0 {
+		return 0, fmt.Errorf("no exchange rate from %s to %s", c.base, currency)
+	}
+
+	scale := math.Pow10(stripe.CurrencyDecimals(c.base) - stripe.CurrencyDecimals(currency))
+	return stripe.RoundAmount(float64(amount) / rate * scale), nil
+}
+
+// MonthlyRevenue returns the MRR of a subscription at a point in time, in
+// the smallest unit of its currency.
+//
+// Only active and past due subscriptions bring revenue. The amount of each
+// of their items is normalized to a month: a yearly plan brings a twelfth of
+// its amount, a weekly plan 52 twelfths, and so on. Metered plans are left
+// out, since their amount isn't known in advance.
+//
+// Forever and repeating coupons which are active at that point in time are
+// applied, from the subscription's discount or from its customer's when the
+// customer is expanded. Coupons that apply once aren't, since they don't
+// recur.
+func MonthlyRevenue(s *stripe.Subscription, at time.Time) int64 {
+	if s.Status != stripe.SubscriptionStatusActive && s.Status != stripe.SubscriptionStatusPastDue {
+		return 0
+	}
+	if s.Items == nil {
+		return 0
+	}
+
+	var monthly float64
+	var months float64
+	for _, item := range s.Items.Data {
+		if item.Plan == nil || item.Plan.UsageType == stripe.PlanUsageTypeMetered {
+			continue
+		}
+		monthly += planAmount(item.Plan, item.Quantity) / planMonths(item.Plan)
+		months = planMonths(item.Plan)
+	}
+
+	discount := s.Discount
+	if discount == nil && s.Customer != nil {
+		discount = s.Customer.Discount
+	}
+	if discount != nil && discountActive(discount, at) {
+		coupon := discount.Coupon
+		switch {
+		case coupon.PercentOff > 0:
+			monthly *= 1 - coupon.PercentOff/100
+		case coupon.AmountOff > 0 && months > 0:
+			monthly -= float64(coupon.AmountOff) / months
+		}
+	}
+
+	if monthly < 0 {
+		return 0
+	}
+	return stripe.RoundAmount(monthly)
+}
+
+// NewSnapshot computes the MRR of the given subscriptions at a point in time.
+func NewSnapshot(subscriptions []*stripe.Subscription, at time.Time) *Snapshot {
+	s := &Snapshot{
+		At:            at.Unix(),
+		Subscriptions: make(map[string]*SubscriptionMRR),
+	}
+	for _, subscription := range subscriptions {
+		s.Subscriptions[subscription.ID] = newSubscriptionMRR(subscription, at)
+	}
+	return s
+}
+
+// LoadSnapshot computes the MRR at a point in time of the subscriptions
+// listed by an iterator, like the one returned by sub.List. Customer
+// discounts are only taken into account when the customers are expanded,
+// with "data.customer".
+func LoadSnapshot(i *sub.Iter, at time.Time) (*Snapshot, error) {
+	var subscriptions []*stripe.Subscription
+	for i.Next() {
+		subscriptions = append(subscriptions, i.Subscription())
+	}
+	if err := i.Err(); err != nil {
+		return nil, err
+	}
+	return NewSnapshot(subscriptions, at), nil
+}
+
+// ConvertedMRR returns the total MRR of the snapshot converted to the base
+// currency of a converter.
+func (s *Snapshot) ConvertedMRR(c *Converter) (int64, error) {
+	var total int64
+	for currency, mrr := range s.MRR() {
+		converted, err := c.Convert(mrr, currency)
+		if err != nil {
+			return 0, err
+		}
+		total += converted
+	}
+	return total, nil
+}
+
+// Currencies returns the currencies that the snapshot has revenue in,
+// sorted.
+func (s *Snapshot) Currencies() []stripe.Currency {
+	var currencies []stripe.Currency
+	for currency := range s.MRR() {
+		currencies = append(currencies, currency)
+	}
+	sort.Slice(currencies, func(i, j int) bool { return currencies[i] < currencies[j] })
+	return currencies
+}
+
+// MRR returns the total MRR of the snapshot in each currency.
+func (s *Snapshot) MRR() map[stripe.Currency]int64 {
+	totals := make(map[stripe.Currency]int64)
+	for _, mrr := range s.Subscriptions {
+		if mrr.MRR != 0 {
+			totals[mrr.Currency] += mrr.MRR
+		}
+	}
+	return totals
+}
+
+//
+// Private types
+//
+
+// customerCurrency identifies the revenue of a customer in a currency.
+type customerCurrency struct {
+	currency stripe.Currency
+	customer string
+}
+
+//
+// Private functions
+//
+
+// customerMRR returns the total MRR of each customer and currency.
+func (s *Snapshot) customerMRR() map[customerCurrency]int64 {
+	totals := make(map[customerCurrency]int64)
+	for _, mrr := range s.Subscriptions {
+		key := customerCurrency{currency: mrr.Currency, customer: mrr.Customer}
+		totals[key] += mrr.MRR
+	}
+	return totals
+}
+
+func discountActive(discount *stripe.Discount, at time.Time) bool {
+	if discount.Coupon == nil || discount.Coupon.Duration == stripe.CouponDurationOnce {
+		return false
+	}
+	if discount.Start != 0 && at.Unix() < discount.Start {
+		return false
+	}
+	return discount.End == 0 || at.Unix() < discount.End
+}
+
+func newSubscriptionMRR(s *stripe.Subscription, at time.Time) *SubscriptionMRR {
+	mrr := &SubscriptionMRR{
+		MRR:          MonthlyRevenue(s, at),
+		Start:        s.StartDate,
+		Subscription: s.ID,
+	}
+	if mrr.Start == 0 {
+		mrr.Start = s.Created
+	}
+	if s.Customer != nil {
+		mrr.Customer = s.Customer.ID
+		if s.Discount == nil {
+			mrr.CustomerDiscount = s.Customer.Discount
+		}
+	}
+	if s.Items != nil {
+		for _, item := range s.Items.Data {
+			if item.Plan != nil {
+				mrr.Currency = item.Plan.Currency
+				break
+			}
+		}
+	}
+	return mrr
+}
+
+// planAmount returns the amount that a plan charges per interval for a
+// quantity, in the smallest unit of its currency.
+func planAmount(plan *stripe.Plan, quantity int64) float64 {
+	if plan.BillingScheme != stripe.PlanBillingSchemeTiered {
+		amount := plan.AmountDecimal
+		if amount == 0 {
+			amount = float64(plan.Amount)
+		}
+		return amount * float64(quantity)
+	}
+
+	var total float64
+	var previousUpTo int64
+	for _, tier := range plan.Tiers {
+		inTier := tier.UpTo == 0 || quantity <= tier.UpTo
+
+		if stripe.PlanTiersMode(plan.TiersMode) == stripe.PlanTiersModeVolume {
+			if inTier {
+				return float64(quantity)*tierUnitAmount(tier) + tierFlatAmount(tier)
+			}
+			continue
+		}
+
+		units := quantity - previousUpTo
+		if !inTier {
+			units = tier.UpTo - previousUpTo
+		}
+		if units > 0 {
+			total += float64(units)*tierUnitAmount(tier) + tierFlatAmount(tier)
+		}
+		if inTier {
+			break
+		}
+		previousUpTo = tier.UpTo
+	}
+	return total
+}
+
+// planMonths returns the number of months between two billings of a plan.
+func planMonths(plan *stripe.Plan) float64 {
+	count := float64(plan.IntervalCount)
+	if count == 0 {
+		count = 1
+	}
+
+	switch plan.Interval {
+	case stripe.PlanIntervalDay:
+		return count * 12 / 365
+	case stripe.PlanIntervalWeek:
+		return count * 12 / 52
+	case stripe.PlanIntervalYear:
+		return count * 12
+	default:
+		return count
+	}
+}
+
+func tierFlatAmount(tier *stripe.PlanTier) float64 {
+	if tier.FlatAmountDecimal != 0 {
+		return tier.FlatAmountDecimal
+	}
+	return float64(tier.FlatAmount)
+}
+
+func tierUnitAmount(tier *stripe.PlanTier) float64 {
+	if tier.UnitAmountDecimal != 0 {
+		return tier.UnitAmountDecimal
+	}
+	return float64(tier.UnitAmount)
+}
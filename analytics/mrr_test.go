@@ -0,0 +1,117 @@
+package analytics
+
+import (
+	"testing"
+	"time"
+
+	assert "github.com/stretchr/testify/require"
+	stripe "github.com/stripe/stripe-go"
+)
+
+func newSubscription(id, customer string, plan *stripe.Plan, quantity int64) *stripe.Subscription {
+	return &stripe.Subscription{
+		Customer: &stripe.Customer{ID: customer},
+		ID:       id,
+		Items: &stripe.SubscriptionItemList{Data: []*stripe.SubscriptionItem{
+			{Plan: plan, Quantity: quantity},
+		}},
+		Status: stripe.SubscriptionStatusActive,
+	}
+}
+
+func TestMonthlyRevenue(t *testing.T) {
+	at := time.Unix(1500000000, 0)
+
+	testCases := []struct {
+		plan *stripe.Plan
+		mrr  int64
+	}{
+		{&stripe.Plan{Amount: 1000, Interval: stripe.PlanIntervalMonth}, 2000},
+		{&stripe.Plan{Amount: 1000, Interval: stripe.PlanIntervalMonth, IntervalCount: 3}, 667},
+		{&stripe.Plan{Amount: 12000, Interval: stripe.PlanIntervalYear}, 2000},
+		{&stripe.Plan{Amount: 300, Interval: stripe.PlanIntervalWeek, IntervalCount: 2}, 1300},
+		{&stripe.Plan{Amount: 100, Interval: stripe.PlanIntervalDay}, 6083},
+		{&stripe.Plan{Amount: 1000, Interval: stripe.PlanIntervalMonth, UsageType: stripe.PlanUsageTypeMetered}, 0},
+		{&stripe.Plan{AmountDecimal: 0.5, Interval: stripe.PlanIntervalMonth}, 1},
+	}
+	for _, testCase := range testCases {
+		s := newSubscription("sub_123", "cus_123", testCase.plan, 2)
+		assert.Equal(t, testCase.mrr, MonthlyRevenue(s, at))
+	}
+
+	// Subscriptions that aren't active or past due don't bring revenue
+	s := newSubscription("sub_123", "cus_123", &stripe.Plan{Amount: 1000, Interval: stripe.PlanIntervalMonth}, 1)
+	s.Status = stripe.SubscriptionStatusTrialing
+	assert.Equal(t, int64(0), MonthlyRevenue(s, at))
+}
+
+func TestMonthlyRevenue_Tiered(t *testing.T) {
+	tiers := []*stripe.PlanTier{
+		{UnitAmount: 1000, UpTo: 5},
+		{FlatAmount: 500, UnitAmount: 800, UpTo: 10},
+		{UnitAmount: 500},
+	}
+	at := time.Unix(1500000000, 0)
+
+	graduated := &stripe.Plan{BillingScheme: stripe.PlanBillingSchemeTiered, Interval: stripe.PlanIntervalMonth,
+		Tiers: tiers, TiersMode: string(stripe.PlanTiersModeGraduated)}
+	assert.Equal(t, int64(3000), MonthlyRevenue(newSubscription("sub_123", "cus_123", graduated, 3), at))
+	assert.Equal(t, int64(5000+500+4000+1000), MonthlyRevenue(newSubscription("sub_123", "cus_123", graduated, 12), at))
+
+	volume := &stripe.Plan{BillingScheme: stripe.PlanBillingSchemeTiered, Interval: stripe.PlanIntervalMonth,
+		Tiers: tiers, TiersMode: string(stripe.PlanTiersModeVolume)}
+	assert.Equal(t, int64(500+800*7), MonthlyRevenue(newSubscription("sub_123", "cus_123", volume, 7), at))
+	assert.Equal(t, int64(500*12), MonthlyRevenue(newSubscription("sub_123", "cus_123", volume, 12), at))
+}
+
+func TestMonthlyRevenue_Discounts(t *testing.T) {
+	plan := &stripe.Plan{Amount: 12000, Interval: stripe.PlanIntervalYear}
+	at := time.Unix(1500000000, 0)
+
+	s := newSubscription("sub_123", "cus_123", plan, 1)
+	s.Discount = &stripe.Discount{Coupon: &stripe.Coupon{Duration: stripe.CouponDurationForever, PercentOff: 25}}
+	assert.Equal(t, int64(750), MonthlyRevenue(s, at))
+
+	// Amounts off are taken off every invoice, so once a year here
+	s.Discount = &stripe.Discount{Coupon: &stripe.Coupon{Duration: stripe.CouponDurationForever, AmountOff: 1200}}
+	assert.Equal(t, int64(900), MonthlyRevenue(s, at))
+
+	// Discounts that ended or that apply once are left out
+	s.Discount = &stripe.Discount{
+		Coupon: &stripe.Coupon{Duration: stripe.CouponDurationRepeating, PercentOff: 50},
+		End:    at.Unix(),
+	}
+	assert.Equal(t, int64(1000), MonthlyRevenue(s, at))
+	s.Discount = &stripe.Discount{Coupon: &stripe.Coupon{Duration: stripe.CouponDurationOnce, PercentOff: 50}}
+	assert.Equal(t, int64(1000), MonthlyRevenue(s, at))
+
+	// The customer's discount applies when the subscription has none
+	s.Discount = nil
+	s.Customer.Discount = &stripe.Discount{Coupon: &stripe.Coupon{Duration: stripe.CouponDurationForever, PercentOff: 10}}
+	assert.Equal(t, int64(900), MonthlyRevenue(s, at))
+}
+
+func TestSnapshot(t *testing.T) {
+	usd := &stripe.Plan{Amount: 1000, Currency: stripe.CurrencyUSD, Interval: stripe.PlanIntervalMonth}
+	jpy := &stripe.Plan{Amount: 1100, Currency: stripe.CurrencyJPY, Interval: stripe.PlanIntervalMonth}
+
+	snapshot := NewSnapshot([]*stripe.Subscription{
+		newSubscription("sub_1", "cus_1", usd, 1),
+		newSubscription("sub_2", "cus_2", usd, 2),
+		newSubscription("sub_3", "cus_3", jpy, 1),
+	}, time.Unix(1500000000, 0))
+
+	assert.Equal(t, map[stripe.Currency]int64{stripe.CurrencyUSD: 3000, stripe.CurrencyJPY: 1100}, snapshot.MRR())
+	assert.Equal(t, []stripe.Currency{stripe.CurrencyJPY, stripe.CurrencyUSD}, snapshot.Currencies())
+
+	converter := NewConverter(&stripe.ExchangeRate{
+		ID:    "usd",
+		Rates: map[stripe.Currency]float64{stripe.CurrencyJPY: 110},
+	})
+	total, err := snapshot.ConvertedMRR(converter)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3000+1000), total)
+
+	_, err = snapshot.ConvertedMRR(NewConverter(&stripe.ExchangeRate{ID: "usd"}))
+	assert.Error(t, err)
+}
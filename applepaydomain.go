@@ -1,5 +1,7 @@
 package stripe
 
+import "encoding/json"
+
 // ApplePayDomainParams is the set of parameters that can be used when creating an ApplePayDomain object.
 type ApplePayDomainParams struct {
 	Params     `form:"*" json:"*"`
@@ -13,6 +15,8 @@ type ApplePayDomain struct {
 	DomainName string `json:"domain_name"`
 	ID         string `json:"id"`
 	Livemode   bool   `json:"livemode"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // ApplePayDomainListParams are the parameters allowed during ApplePayDomain listing.
@@ -6,6 +6,9 @@ import "encoding/json"
 type Application struct {
 	ID   string `json:"id"`
 	Name string `json:"name"`
+
+	Extra    map[string]json.RawMessage `json:"-"`
+	expanded bool
 }
 
 // UnmarshalJSON handles deserialization of an Application.
@@ -24,5 +27,6 @@ func (a *Application) UnmarshalJSON(data []byte) error {
 	}
 
 	*a = Application(v)
+	a.expanded = true
 	return nil
 }
@@ -35,6 +35,25 @@ func (i *Iter) BalanceTransaction() *stripe.BalanceTransaction {
 	return i.Current().(*stripe.BalanceTransaction)
 }
 
+// WithAccount returns a copy of the client that makes every request on behalf
+// of the given connected account.
+func (c Client) WithAccount(account string) *Client {
+	return &Client{B: stripe.NewAccountBackend(c.B, account), Key: c.Key}
+}
+
+// WithAccountStrict is the same as WithAccount except that requests fail with
+// an error instead of being sent if params explicitly set a StripeAccount
+// that's different from the given one.
+func (c Client) WithAccountStrict(account string) *Client {
+	return &Client{B: &stripe.AccountBackend{Backend: c.B, StripeAccount: account, Strict: true}, Key: c.Key}
+}
+
+// WithKeyProvider returns a copy of the client that resolves the API key to
+// use from the given provider for every request.
+func (c Client) WithKeyProvider(provider stripe.KeyProvider) *Client {
+	return &Client{B: stripe.NewKeyProviderBackend(c.B, provider), Key: c.Key}
+}
+
 func getC() Client {
 	return Client{stripe.GetBackend(stripe.APIBackend), stripe.Key}
 }
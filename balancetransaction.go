@@ -101,6 +101,9 @@ type BalanceTransactionSource struct {
 	Reversal             *Reversal                    `json:"-"`
 	Transfer             *Transfer                    `json:"-"`
 	Type                 BalanceTransactionSourceType `json:"object"`
+
+	Extra    map[string]json.RawMessage `json:"-"`
+	expanded bool
 }
 
 // BalanceTransactionParams is the set of parameters that can be used when retrieving a transaction.
@@ -141,6 +144,9 @@ type BalanceTransaction struct {
 	Source            *BalanceTransactionSource           `json:"source"`
 	Status            BalanceTransactionStatus            `json:"status"`
 	Type              BalanceTransactionType              `json:"type"`
+
+	Extra    map[string]json.RawMessage `json:"-"`
+	expanded bool
 }
 
 // BalanceTransactionList is a list of transactions as returned from a list endpoint.
@@ -174,6 +180,7 @@ func (t *BalanceTransaction) UnmarshalJSON(data []byte) error {
 	}
 
 	*t = BalanceTransaction(v)
+	t.expanded = true
 	return nil
 }
 
@@ -194,6 +201,7 @@ func (s *BalanceTransactionSource) UnmarshalJSON(data []byte) error {
 
 	var err error
 	*s = BalanceTransactionSource(v)
+	s.expanded = true
 
 	switch s.Type {
 	case BalanceTransactionSourceTypeApplicationFee:
@@ -139,6 +139,13 @@ func (p *BankAccountListParams) AppendTo(body *form.Values, keyParts []string) {
 	body.Add(form.FormatKey(append(keyParts, "object")), "bank_account")
 }
 
+// DecodeFrom implements custom decoding logic for BankAccountListParams that
+// undoes its AppendTo.
+func (p *BankAccountListParams) DecodeFrom(body *form.Values, keyParts []string) error {
+	takeFormValue(body, append(keyParts, "object"), "bank_account")
+	return nil
+}
+
 // BankAccount represents a Stripe bank account.
 type BankAccount struct {
 	Account            *Account                     `json:"account"`
@@ -156,6 +163,9 @@ type BankAccount struct {
 	Metadata           map[string]string            `json:"metadata"`
 	RoutingNumber      string                       `json:"routing_number"`
 	Status             BankAccountStatus            `json:"status"`
+
+	Extra    map[string]json.RawMessage `json:"-"`
+	expanded bool
 }
 
 // BankAccountList is a list object for bank accounts.
@@ -180,5 +190,6 @@ func (b *BankAccount) UnmarshalJSON(data []byte) error {
 	}
 
 	*b = BankAccount(v)
+	b.expanded = true
 	return nil
 }
@@ -35,6 +35,9 @@ type BitcoinReceiver struct {
 	RefundAddress         string                  `json:"refund_address"`
 	RejectTransactions    bool                    `json:"reject_transactions"`
 	Transactions          *BitcoinTransactionList `json:"transactions"`
+
+	Extra    map[string]json.RawMessage `json:"-"`
+	expanded bool
 }
 
 // BitcoinReceiverList is a list of bitcoin receivers as retrieved from a list endpoint.
@@ -59,5 +62,6 @@ func (r *BitcoinReceiver) UnmarshalJSON(data []byte) error {
 	}
 
 	*r = BitcoinReceiver(v)
+	r.expanded = true
 	return nil
 }
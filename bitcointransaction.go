@@ -27,6 +27,9 @@ type BitcoinTransaction struct {
 	Customer      string   `json:"customer"`
 	ID            string   `json:"id"`
 	Receiver      string   `json:"receiver"`
+
+	Extra    map[string]json.RawMessage `json:"-"`
+	expanded bool
 }
 
 // UnmarshalJSON handles deserialization of a BitcoinTransaction.
@@ -45,5 +48,6 @@ func (bt *BitcoinTransaction) UnmarshalJSON(data []byte) error {
 	}
 
 	*bt = BitcoinTransaction(v)
+	bt.expanded = true
 	return nil
 }
@@ -61,6 +61,9 @@ type Capability struct {
 	RequestedAt  int64                   `json:"requested_at"`
 	Requirements *CapabilityRequirements `json:"requirements"`
 	Status       CapabilityStatus        `json:"status"`
+
+	Extra    map[string]json.RawMessage `json:"-"`
+	expanded bool
 }
 
 // CapabilityList is a list of capabilities as retrieved from a list endpoint.
@@ -85,5 +88,6 @@ func (c *Capability) UnmarshalJSON(data []byte) error {
 	}
 
 	*c = Capability(v)
+	c.expanded = true
 	return nil
 }
@@ -194,6 +194,14 @@ func (p *CardListParams) AppendTo(body *form.Values, keyParts []string) {
 	}
 }
 
+// DecodeFrom implements custom decoding logic for CardListParams that undoes
+// its AppendTo. The account or customer that the cards belong to isn't part of
+// the encoded parameters, so it can't be recovered.
+func (p *CardListParams) DecodeFrom(body *form.Values, keyParts []string) error {
+	takeFormValue(body, append(keyParts, "object"), "card")
+	return nil
+}
+
 // Card is the resource representing a Stripe credit/debit card.
 // For more details see https://stripe.com/docs/api#cards.
 type Card struct {
@@ -245,6 +253,9 @@ type Card struct {
 	Recipient          *Recipient             `json:"recipient"`
 	ThreeDSecure       *ThreeDSecure          `json:"three_d_secure"`
 	TokenizationMethod CardTokenizationMethod `json:"tokenization_method"`
+
+	Extra    map[string]json.RawMessage `json:"-"`
+	expanded bool
 }
 
 // CardList is a list object for cards.
@@ -269,5 +280,6 @@ func (c *Card) UnmarshalJSON(data []byte) error {
 	}
 
 	*c = Card(v)
+	c.expanded = true
 	return nil
 }
@@ -0,0 +1,542 @@
+package catalog
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	stripe "github.com/stripe/stripe-go"
+	"github.com/stripe/stripe-go/coupon"
+	"github.com/stripe/stripe-go/plan"
+	"github.com/stripe/stripe-go/product"
+	"github.com/stripe/stripe-go/taxrate"
+)
+
+//
+// Public types
+//
+
+// Cache keeps the products, plans, coupons and tax rates of an account in
+// memory, so that they can be looked up without making requests. It's loaded
+// through the list endpoints, and kept current by the coupon.*, plan.*,
+// product.* and tax_rate.* events passed to HandleEvent.
+//
+// Lookups by ID read through: objects missing from the cache are retrieved
+// and added to it. When a TTL is configured, the cache is also reloaded by
+// the first lookup made after it expired, in case events were missed. The
+// objects returned are shared and must not be modified.
+//
+// A Cache is safe for concurrent use.
+type Cache struct {
+	// Counters are accessed atomically and kept first for alignment.
+	hits               int64
+	misses             int64
+	reloads            int64
+	revalidationErrors int64
+
+	config CacheConfig
+
+	// loadMu is held while loading, so that a single lookup reloads an
+	// expired cache.
+	loadMu sync.Mutex
+
+	mu          sync.RWMutex
+	data        *cacheData
+	lastEventAt time.Time
+	loadedAt    time.Time
+
+	// loading is set while the cache is being loaded, during which the
+	// events applied to it are also kept in pending to be replayed onto the
+	// newly loaded data.
+	loading bool
+	pending []*cacheEvent
+}
+
+// CacheConfig is used to configure a new Cache.
+type CacheConfig struct {
+	// Backend is the backend used to make requests.
+	//
+	// If left unset, the API backend returned by stripe.GetBackend is used.
+	Backend stripe.Backend
+
+	// Clock is used to tell when the cache expires.
+	//
+	// If left unset, stripe.DefaultClock is used.
+	Clock stripe.Clock
+
+	// Key is the API key used to make requests.
+	//
+	// If left unset, stripe.Key is used.
+	Key string
+
+	// TTL is how long the cache is used after being loaded before it's
+	// reloaded. Events keep it current in between, so it only matters when
+	// events are missed.
+	//
+	// If left unset, the cache is only loaded by Load.
+	TTL time.Duration
+}
+
+// CacheStats are metrics about the use and freshness of a Cache.
+type CacheStats struct {
+	// Hits is the number of lookups by ID answered from the cache.
+	Hits int64
+
+	// LastEventAt is the creation time of the last event applied to the
+	// cache, or the zero time if none was.
+	LastEventAt time.Time
+
+	// LoadedAt is the time the cache was last loaded, or the zero time if it
+	// never was.
+	LoadedAt time.Time
+
+	// Misses is the number of lookups by ID which required a request.
+	Misses int64
+
+	// Reloads is the number of times the cache was loaded.
+	Reloads int64
+
+	// RevalidationErrors is the number of times reloading an expired cache
+	// failed, in which case the expired cache kept being used.
+	RevalidationErrors int64
+
+	// Staleness is the time elapsed since the cache was last loaded.
+	Staleness time.Duration
+}
+
+// HitRate returns the share of lookups by ID answered from the cache, from
+// 0 to 1.
+func (s *CacheStats) HitRate() float64 {
+	if s.Hits+s.Misses == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Hits+s.Misses)
+}
+
+//
+// Public functions
+//
+
+// NewCache returns a new empty cache with the given configuration. It's
+// meant to be loaded with Load before being used.
+func NewCache(config *CacheConfig) *Cache {
+	c := &Cache{data: newCacheData(&Catalog{})}
+	if config != nil {
+		c.config = *config
+	}
+	if c.config.Backend == nil {
+		c.config.Backend = stripe.GetBackend(stripe.APIBackend)
+	}
+	if c.config.Clock == nil {
+		c.config.Clock = stripe.DefaultClock
+	}
+	if c.config.Key == "" {
+		c.config.Key = stripe.Key
+	}
+	return c
+}
+
+// Coupon returns the coupon with the given ID.
+func (c *Cache) Coupon(id string) (*stripe.Coupon, error) {
+	c.revalidate()
+
+	c.mu.RLock()
+	cached, ok := c.data.coupons[id]
+	c.mu.RUnlock()
+	if ok {
+		atomic.AddInt64(&c.hits, 1)
+		return cached, nil
+	}
+
+	atomic.AddInt64(&c.misses, 1)
+	retrieved, err := coupon.Client{B: c.config.Backend, Key: c.config.Key}.Get(id, nil)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	if _, ok := c.data.coupons[id]; !ok {
+		c.data.coupons[id] = retrieved
+	}
+	c.mu.Unlock()
+	return retrieved, nil
+}
+
+// HandleEvent applies a coupon.*, plan.*, product.* or tax_rate.* event to
+// the cache. Other events are ignored, and so are events older than the
+// cache or than the last event applied to the same object.
+func (c *Cache) HandleEvent(event *stripe.Event) error {
+	if event.Data == nil {
+		return nil
+	}
+
+	var kind ObjectKind
+	var object interface{}
+	switch {
+	case strings.HasPrefix(event.Type, "coupon."):
+		kind, object = ObjectKindCoupon, &stripe.Coupon{}
+	case strings.HasPrefix(event.Type, "plan."):
+		kind, object = ObjectKindPlan, &stripe.Plan{}
+	case strings.HasPrefix(event.Type, "product."):
+		kind, object = ObjectKindProduct, &stripe.Product{}
+	case strings.HasPrefix(event.Type, "tax_rate."):
+		kind, object = ObjectKindTaxRate, &stripe.TaxRate{}
+	default:
+		return nil
+	}
+	if err := json.Unmarshal(event.Data.Raw, object); err != nil {
+		return err
+	}
+	deleted := strings.HasSuffix(event.Type, ".deleted")
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Objects loaded after an event was created are at least as recent as
+	// the event
+	if !c.loadedAt.IsZero() && event.Created < c.loadedAt.Unix() {
+		return nil
+	}
+	if c.loading {
+		c.pending = append(c.pending, &cacheEvent{
+			created: event.Created,
+			deleted: deleted,
+			kind:    kind,
+			object:  object,
+		})
+	}
+	if !c.data.set(kind, object, deleted, event.Created) {
+		return nil
+	}
+	if created := time.Unix(event.Created, 0); created.After(c.lastEventAt) {
+		c.lastEventAt = created
+	}
+	return nil
+}
+
+// Load loads all the products, plans, coupons and tax rates of the account,
+// and replaces the content of the cache with them.
+func (c *Cache) Load() error {
+	c.loadMu.Lock()
+	defer c.loadMu.Unlock()
+	return c.load()
+}
+
+// Plan returns the plan with the given ID.
+func (c *Cache) Plan(id string) (*stripe.Plan, error) {
+	c.revalidate()
+
+	c.mu.RLock()
+	cached, ok := c.data.plans[id]
+	c.mu.RUnlock()
+	if ok {
+		atomic.AddInt64(&c.hits, 1)
+		return cached, nil
+	}
+
+	atomic.AddInt64(&c.misses, 1)
+	retrieved, err := plan.Client{B: c.config.Backend, Key: c.config.Key}.Get(id, nil)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	if _, ok := c.data.plans[id]; !ok {
+		c.data.plans[id] = retrieved
+	}
+	c.mu.Unlock()
+	return retrieved, nil
+}
+
+// PlansByMetadata returns the cached plans whose metadata has the given
+// value for the given key, sorted by ID.
+func (c *Cache) PlansByMetadata(key, value string) []*stripe.Plan {
+	c.revalidate()
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var plans []*stripe.Plan
+	for _, p := range c.data.plans {
+		if v, ok := p.Metadata[key]; ok && v == value {
+			plans = append(plans, p)
+		}
+	}
+	sortPlans(plans)
+	return plans
+}
+
+// PlansByProduct returns the cached plans of the given product, active or
+// not, sorted by ID.
+func (c *Cache) PlansByProduct(product string) []*stripe.Plan {
+	c.revalidate()
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var plans []*stripe.Plan
+	for _, p := range c.data.plans {
+		if p.Product != nil && p.Product.ID == product {
+			plans = append(plans, p)
+		}
+	}
+	sortPlans(plans)
+	return plans
+}
+
+// Product returns the product with the given ID.
+func (c *Cache) Product(id string) (*stripe.Product, error) {
+	c.revalidate()
+
+	c.mu.RLock()
+	cached, ok := c.data.products[id]
+	c.mu.RUnlock()
+	if ok {
+		atomic.AddInt64(&c.hits, 1)
+		return cached, nil
+	}
+
+	atomic.AddInt64(&c.misses, 1)
+	retrieved, err := product.Client{B: c.config.Backend, Key: c.config.Key}.Get(id, nil)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	if _, ok := c.data.products[id]; !ok {
+		c.data.products[id] = retrieved
+	}
+	c.mu.Unlock()
+	return retrieved, nil
+}
+
+// ProductsByMetadata returns the cached products whose metadata has the
+// given value for the given key, sorted by ID.
+func (c *Cache) ProductsByMetadata(key, value string) []*stripe.Product {
+	c.revalidate()
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var products []*stripe.Product
+	for _, p := range c.data.products {
+		if v, ok := p.Metadata[key]; ok && v == value {
+			products = append(products, p)
+		}
+	}
+	sort.Slice(products, func(i, j int) bool {
+		return products[i].ID < products[j].ID
+	})
+	return products
+}
+
+// Stats returns metrics about the use and freshness of the cache.
+func (c *Cache) Stats() *CacheStats {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	stats := &CacheStats{
+		Hits:               atomic.LoadInt64(&c.hits),
+		LastEventAt:        c.lastEventAt,
+		LoadedAt:           c.loadedAt,
+		Misses:             atomic.LoadInt64(&c.misses),
+		Reloads:            atomic.LoadInt64(&c.reloads),
+		RevalidationErrors: atomic.LoadInt64(&c.revalidationErrors),
+	}
+	if !c.loadedAt.IsZero() {
+		stats.Staleness = c.config.Clock.Now().Sub(c.loadedAt)
+	}
+	return stats
+}
+
+// TaxRate returns the tax rate with the given ID.
+func (c *Cache) TaxRate(id string) (*stripe.TaxRate, error) {
+	c.revalidate()
+
+	c.mu.RLock()
+	cached, ok := c.data.taxRates[id]
+	c.mu.RUnlock()
+	if ok {
+		atomic.AddInt64(&c.hits, 1)
+		return cached, nil
+	}
+
+	atomic.AddInt64(&c.misses, 1)
+	retrieved, err := taxrate.Client{B: c.config.Backend, Key: c.config.Key}.Get(id, nil)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	if _, ok := c.data.taxRates[id]; !ok {
+		c.data.taxRates[id] = retrieved
+	}
+	c.mu.Unlock()
+	return retrieved, nil
+}
+
+//
+// Private types
+//
+
+// cacheData is the content of a Cache.
+type cacheData struct {
+	coupons  map[string]*stripe.Coupon
+	plans    map[string]*stripe.Plan
+	products map[string]*stripe.Product
+	taxRates map[string]*stripe.TaxRate
+
+	// updated is the creation time of the last event applied to each object,
+	// by kind and ID, so that events received out of order are ignored.
+	updated map[string]int64
+}
+
+// cacheEvent is an event applied to a Cache while it was being loaded.
+type cacheEvent struct {
+	created int64
+	deleted bool
+	kind    ObjectKind
+	object  interface{}
+}
+
+//
+// Private functions
+//
+
+// expired returns whether the cache was loaded longer than its TTL ago.
+func (c *Cache) expired() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return !c.loadedAt.IsZero() && c.config.Clock.Now().Sub(c.loadedAt) >= c.config.TTL
+}
+
+// load loads the cache. Events handled while the objects are being listed
+// are replayed onto them, unless they were created before the load started,
+// in which case the objects listed are at least as recent.
+func (c *Cache) load() error {
+	c.mu.Lock()
+	c.loading = true
+	c.mu.Unlock()
+
+	loadedAt := c.config.Clock.Now()
+	account, err := loadCatalog(c.config.Backend, c.config.Key)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	pending := c.pending
+	c.loading = false
+	c.pending = nil
+	if err != nil {
+		return err
+	}
+
+	data := newCacheData(account)
+	for _, e := range pending {
+		if e.created >= loadedAt.Unix() {
+			data.set(e.kind, e.object, e.deleted, e.created)
+		}
+	}
+	c.data = data
+	c.loadedAt = loadedAt
+	atomic.AddInt64(&c.reloads, 1)
+	return nil
+}
+
+// revalidate reloads the cache if it expired. Errors are only counted, so
+// that the expired cache keeps being used until a reload succeeds.
+func (c *Cache) revalidate() {
+	if c.config.TTL == 0 || !c.expired() {
+		return
+	}
+
+	c.loadMu.Lock()
+	defer c.loadMu.Unlock()
+
+	// Another lookup may have reloaded the cache while waiting for the lock
+	if !c.expired() {
+		return
+	}
+	if err := c.load(); err != nil {
+		atomic.AddInt64(&c.revalidationErrors, 1)
+	}
+}
+
+func newCacheData(account *Catalog) *cacheData {
+	data := &cacheData{
+		coupons:  make(map[string]*stripe.Coupon),
+		plans:    make(map[string]*stripe.Plan),
+		products: make(map[string]*stripe.Product),
+		taxRates: make(map[string]*stripe.TaxRate),
+		updated:  make(map[string]int64),
+	}
+	for _, c := range account.Coupons {
+		data.coupons[c.ID] = c
+	}
+	for _, p := range account.Plans {
+		data.plans[p.ID] = p
+	}
+	for _, p := range account.Products {
+		data.products[p.ID] = p
+	}
+	for _, t := range account.TaxRates {
+		data.taxRates[t.ID] = t
+	}
+	return data
+}
+
+// set stores or deletes an object as of the given event creation time, and
+// returns whether it did, which it doesn't for events older than the last
+// one applied to the object.
+func (d *cacheData) set(kind ObjectKind, object interface{}, deleted bool, created int64) bool {
+	var id string
+	switch o := object.(type) {
+	case *stripe.Coupon:
+		id = o.ID
+	case *stripe.Plan:
+		id = o.ID
+	case *stripe.Product:
+		id = o.ID
+	case *stripe.TaxRate:
+		id = o.ID
+	}
+
+	key := string(kind) + ":" + id
+	if created < d.updated[key] {
+		return false
+	}
+	d.updated[key] = created
+
+	switch o := object.(type) {
+	case *stripe.Coupon:
+		if deleted {
+			delete(d.coupons, id)
+		} else {
+			d.coupons[id] = o
+		}
+	case *stripe.Plan:
+		if deleted {
+			delete(d.plans, id)
+		} else {
+			d.plans[id] = o
+		}
+	case *stripe.Product:
+		if deleted {
+			delete(d.products, id)
+		} else {
+			d.products[id] = o
+		}
+	case *stripe.TaxRate:
+		if deleted {
+			delete(d.taxRates, id)
+		} else {
+			d.taxRates[id] = o
+		}
+	}
+	return true
+}
+
+func sortPlans(plans []*stripe.Plan) {
+	sort.Slice(plans, func(i, j int) bool {
+		return plans[i].ID < plans[j].ID
+	})
+}
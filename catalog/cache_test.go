@@ -0,0 +1,200 @@
+package catalog
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	assert "github.com/stretchr/testify/require"
+	stripe "github.com/stripe/stripe-go"
+	"github.com/stripe/stripe-go/form"
+	stripetesting "github.com/stripe/stripe-go/testing"
+)
+
+func objectEvent(t *testing.T, eventType string, created int64, object interface{}) *stripe.Event {
+	raw, err := json.Marshal(object)
+	assert.NoError(t, err)
+	return &stripe.Event{Created: created, Data: &stripe.EventData{Raw: raw}, Type: eventType}
+}
+
+func TestCache(t *testing.T) {
+	backend := &catalogBackend{account: newAccount()}
+	clock := stripetesting.NewClock(time.Unix(1500000000, 0))
+	cache := NewCache(&CacheConfig{Backend: backend, Clock: clock, Key: "sk_test_123"})
+	assert.NoError(t, cache.Load())
+
+	p, err := cache.Plan("plan_1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Pro", p.Nickname)
+
+	product, err := cache.Product("prod_1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Pro", product.Name)
+
+	_, err = cache.Coupon("co_1")
+	assert.NoError(t, err)
+	_, err = cache.TaxRate("txr_1")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(backend.calls))
+
+	// Objects missing from the cache are retrieved once
+	for i := 0; i < 2; i++ {
+		p, err = cache.Plan("plan_new")
+		assert.NoError(t, err)
+		assert.Equal(t, "plan_new", p.ID)
+	}
+	assert.Equal(t, []string{"GET /v1/plans/plan_new"}, backend.calls)
+
+	plans := cache.PlansByProduct("prod_1")
+	assert.Equal(t, 2, len(plans))
+	assert.Equal(t, "plan_1", plans[0].ID)
+	assert.Equal(t, "plan_2", plans[1].ID)
+
+	plans = cache.PlansByMetadata("catalog_key", "basic-monthly")
+	assert.Equal(t, 1, len(plans))
+	assert.Equal(t, "plan_2", plans[0].ID)
+	assert.Equal(t, 1, len(cache.ProductsByMetadata("catalog_key", "pro")))
+	assert.Equal(t, 0, len(cache.ProductsByMetadata("catalog_key", "team")))
+
+	clock.Add(time.Minute)
+	stats := cache.Stats()
+	assert.Equal(t, int64(5), stats.Hits)
+	assert.Equal(t, int64(1), stats.Misses)
+	assert.Equal(t, 5.0/6, stats.HitRate())
+	assert.Equal(t, int64(1), stats.Reloads)
+	assert.Equal(t, time.Minute, stats.Staleness)
+}
+
+func TestCache_HandleEvent(t *testing.T) {
+	backend := &catalogBackend{account: newAccount()}
+	clock := stripetesting.NewClock(time.Unix(1500000000, 0))
+	cache := NewCache(&CacheConfig{Backend: backend, Clock: clock, Key: "sk_test_123"})
+	assert.NoError(t, cache.Load())
+
+	assert.NoError(t, cache.HandleEvent(objectEvent(t, "product.updated", 1500000100,
+		&stripe.Product{ID: "prod_1", Name: "Professional"})))
+	product, err := cache.Product("prod_1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Professional", product.Name)
+
+	// Events older than the last one applied to the object, or than the
+	// cache itself, are ignored
+	assert.NoError(t, cache.HandleEvent(objectEvent(t, "product.updated", 1500000050,
+		&stripe.Product{ID: "prod_1", Name: "Pro"})))
+	assert.NoError(t, cache.HandleEvent(objectEvent(t, "plan.updated", 1499999999,
+		&stripe.Plan{ID: "plan_1", Nickname: "Old"})))
+	product, err = cache.Product("prod_1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Professional", product.Name)
+	p, err := cache.Plan("plan_1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Pro", p.Nickname)
+
+	assert.NoError(t, cache.HandleEvent(objectEvent(t, "plan.created", 1500000200,
+		&stripe.Plan{ID: "plan_3", Product: &stripe.Product{ID: "prod_1"}})))
+	assert.NoError(t, cache.HandleEvent(objectEvent(t, "plan.deleted", 1500000200,
+		&stripe.Plan{ID: "plan_2", Product: &stripe.Product{ID: "prod_1"}})))
+	plans := cache.PlansByProduct("prod_1")
+	assert.Equal(t, 2, len(plans))
+	assert.Equal(t, "plan_1", plans[0].ID)
+	assert.Equal(t, "plan_3", plans[1].ID)
+
+	assert.NoError(t, cache.HandleEvent(objectEvent(t, "tax_rate.updated", 1500000300,
+		&stripe.TaxRate{ID: "txr_1", Percentage: 19})))
+	taxRate, err := cache.TaxRate("txr_1")
+	assert.NoError(t, err)
+	assert.Equal(t, 19.0, taxRate.Percentage)
+
+	assert.NoError(t, cache.HandleEvent(&stripe.Event{Type: "invoice.paid"}))
+	assert.Equal(t, 0, len(backend.calls))
+	assert.Equal(t, time.Unix(1500000300, 0), cache.Stats().LastEventAt)
+}
+
+func TestCache_Revalidation(t *testing.T) {
+	backend := &catalogBackend{account: newAccount()}
+	clock := stripetesting.NewClock(time.Unix(1500000000, 0))
+	cache := NewCache(&CacheConfig{Backend: backend, Clock: clock, Key: "sk_test_123", TTL: time.Hour})
+	assert.NoError(t, cache.Load())
+
+	// A missed event is picked up once the cache expires
+	backend.account.Products[0] = &stripe.Product{ID: "prod_1", Name: "Professional"}
+	clock.Add(59 * time.Minute)
+	product, err := cache.Product("prod_1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Pro", product.Name)
+
+	clock.Add(time.Minute)
+	product, err = cache.Product("prod_1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Professional", product.Name)
+	assert.Equal(t, int64(2), cache.Stats().Reloads)
+	assert.Equal(t, time.Duration(0), cache.Stats().Staleness)
+
+	// The expired cache keeps being used when it can't be reloaded
+	backend.listErr = errors.New("unavailable")
+	clock.Add(2 * time.Hour)
+	product, err = cache.Product("prod_1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Professional", product.Name)
+
+	stats := cache.Stats()
+	assert.Equal(t, int64(1), stats.RevalidationErrors)
+	assert.Equal(t, 2*time.Hour, stats.Staleness)
+}
+
+// blockingBackend is a catalogBackend whose first list blocks until
+// released, after signaling that it started.
+type blockingBackend struct {
+	*catalogBackend
+	listing chan struct{}
+	release chan struct{}
+	once    sync.Once
+}
+
+func (b *blockingBackend) CallRaw(method, path, key string, body *form.Values, params *stripe.Params, v interface{}) error {
+	b.once.Do(func() {
+		close(b.listing)
+		<-b.release
+	})
+	return b.catalogBackend.CallRaw(method, path, key, body, params, v)
+}
+
+func TestCache_HandleEventWhileLoading(t *testing.T) {
+	backend := &catalogBackend{account: newAccount()}
+	clock := stripetesting.NewClock(time.Unix(1500000000, 0))
+	cache := NewCache(&CacheConfig{Backend: backend, Clock: clock, Key: "sk_test_123"})
+	assert.NoError(t, cache.Load())
+
+	blocking := &blockingBackend{
+		catalogBackend: backend,
+		listing:        make(chan struct{}),
+		release:        make(chan struct{}),
+	}
+	cache.config.Backend = blocking
+	clock.Add(100 * time.Second)
+
+	done := make(chan error)
+	go func() {
+		done <- cache.Load()
+	}()
+	<-blocking.listing
+
+	// An event created after the load started is kept, but one created
+	// before it is superseded by the objects listed
+	assert.NoError(t, cache.HandleEvent(objectEvent(t, "product.updated", 1500000150,
+		&stripe.Product{ID: "prod_1", Name: "Professional"})))
+	assert.NoError(t, cache.HandleEvent(objectEvent(t, "plan.updated", 1500000050,
+		&stripe.Plan{ID: "plan_1", Nickname: "Old"})))
+	close(blocking.release)
+	assert.NoError(t, <-done)
+
+	product, err := cache.Product("prod_1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Professional", product.Name)
+	p, err := cache.Plan("plan_1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Pro", p.Nickname)
+	assert.Equal(t, int64(2), cache.Stats().Reloads)
+}
@@ -0,0 +1,539 @@
+package catalog
+
+import (
+	"fmt"
+
+	stripe "github.com/stripe/stripe-go"
+)
+
+//
+// Private types
+//
+
+// diffFunc compares an object of the catalog with an existing one, and
+// returns the names of the fields that differ and can be updated, and of
+// those that differ but can't be.
+type diffFunc func(desired, existing *object) (updated, immutable []string)
+
+// object is an object of the catalog or of the account of any kind.
+type object struct {
+	active   bool
+	id       string
+	metadata map[string]string
+	value    interface{}
+}
+
+// objectIndex indexes existing objects by ID, and active ones by the value
+// of their metadata key.
+type objectIndex struct {
+	byID     map[string]*object
+	byKey    map[string][]*object
+	matchKey string
+}
+
+//
+// Private functions
+//
+
+func newObjectIndex(objects []*object, matchKey string) *objectIndex {
+	index := &objectIndex{
+		byID:     make(map[string]*object),
+		byKey:    make(map[string][]*object),
+		matchKey: matchKey,
+	}
+	for _, o := range objects {
+		index.byID[o.id] = o
+		if matchKey != "" && o.active && o.metadata[matchKey] != "" {
+			index.byKey[o.metadata[matchKey]] = append(index.byKey[o.metadata[matchKey]], o)
+		}
+	}
+	return index
+}
+
+// match returns the existing object matching an object of the catalog, or
+// nil if there's none.
+func (i *objectIndex) match(desired *object, ref string) (*object, error) {
+	if desired.id != "" {
+		return i.byID[desired.id], nil
+	}
+
+	matches := i.byKey[ref]
+	if len(matches) > 1 {
+		return nil, fmt.Errorf("%d active objects have %s metadata %s", len(matches), i.matchKey, ref)
+	}
+	if len(matches) == 0 {
+		return nil, nil
+	}
+	return matches[0], nil
+}
+
+func couponObjects(coupons []*stripe.Coupon) []*object {
+	var objects []*object
+	for _, c := range coupons {
+		// Coupons can't be archived, and the ones being replaced lose their
+		// metadata key instead
+		objects = append(objects, &object{active: true, id: c.ID, metadata: c.Metadata, value: c})
+	}
+	return objects
+}
+
+func diffCoupon(desiredObject, existingObject *object) (updated, immutable []string) {
+	desired := desiredObject.value.(*stripe.Coupon)
+	existing := existingObject.value.(*stripe.Coupon)
+
+	if metadataDiffers(desired.Metadata, existing.Metadata) {
+		updated = append(updated, "metadata")
+	}
+	if desired.Name != existing.Name {
+		updated = append(updated, "name")
+	}
+
+	if desired.AmountOff != existing.AmountOff {
+		immutable = append(immutable, "amount_off")
+	}
+	if desired.AmountOff > 0 && desired.Currency != existing.Currency {
+		immutable = append(immutable, "currency")
+	}
+	if desired.Duration != existing.Duration {
+		immutable = append(immutable, "duration")
+	}
+	if desired.DurationInMonths != existing.DurationInMonths {
+		immutable = append(immutable, "duration_in_months")
+	}
+	if desired.MaxRedemptions != existing.MaxRedemptions {
+		immutable = append(immutable, "max_redemptions")
+	}
+	if desired.PercentOff != existing.PercentOff {
+		immutable = append(immutable, "percent_off")
+	}
+	if desired.RedeemBy != existing.RedeemBy {
+		immutable = append(immutable, "redeem_by")
+	}
+	return updated, immutable
+}
+
+// diffPlan compares plans, with the products of the catalog resolved to
+// their IDs.
+func (p *SyncPlan) diffPlan(desiredObject, existingObject *object) (updated, immutable []string) {
+	desired := desiredObject.value.(*stripe.Plan)
+	existing := existingObject.value.(*stripe.Plan)
+
+	if !existing.Active {
+		updated = append(updated, "active")
+	}
+	if metadataDiffers(desired.Metadata, existing.Metadata) {
+		updated = append(updated, "metadata")
+	}
+	if desired.Nickname != existing.Nickname {
+		updated = append(updated, "nickname")
+	}
+	productID, _ := p.productID(desired.Product.ID)
+	if productID == "" || existing.Product == nil || existing.Product.ID != productID {
+		updated = append(updated, "product")
+	}
+	if desired.TrialPeriodDays != existing.TrialPeriodDays {
+		updated = append(updated, "trial_period_days")
+	}
+
+	if desired.AggregateUsage != "" && desired.AggregateUsage != existing.AggregateUsage {
+		immutable = append(immutable, "aggregate_usage")
+	}
+	if desired.BillingScheme != stripe.PlanBillingSchemeTiered &&
+		amountDiffers(desired.Amount, desired.AmountDecimal, existing.Amount, existing.AmountDecimal) {
+		immutable = append(immutable, "amount")
+	}
+	if withDefault(string(desired.BillingScheme), string(stripe.PlanBillingSchemePerUnit)) !=
+		withDefault(string(existing.BillingScheme), string(stripe.PlanBillingSchemePerUnit)) {
+		immutable = append(immutable, "billing_scheme")
+	}
+	if desired.Currency != existing.Currency {
+		immutable = append(immutable, "currency")
+	}
+	if desired.Interval != existing.Interval {
+		immutable = append(immutable, "interval")
+	}
+	if intervalCount(desired.IntervalCount) != intervalCount(existing.IntervalCount) {
+		immutable = append(immutable, "interval_count")
+	}
+	if tiersDiffer(desired.Tiers, existing.Tiers) {
+		immutable = append(immutable, "tiers")
+	}
+	if desired.TiersMode != existing.TiersMode {
+		immutable = append(immutable, "tiers_mode")
+	}
+	if transformUsageDiffers(desired.TransformUsage, existing.TransformUsage) {
+		immutable = append(immutable, "transform_usage")
+	}
+	if withDefault(string(desired.UsageType), string(stripe.PlanUsageTypeLicensed)) !=
+		withDefault(string(existing.UsageType), string(stripe.PlanUsageTypeLicensed)) {
+		immutable = append(immutable, "usage_type")
+	}
+	return updated, immutable
+}
+
+func diffProduct(desiredObject, existingObject *object) (updated, immutable []string) {
+	desired := desiredObject.value.(*stripe.Product)
+	existing := existingObject.value.(*stripe.Product)
+
+	if !existing.Active {
+		updated = append(updated, "active")
+	}
+	if stringsDiffer(desired.Attributes, existing.Attributes) {
+		updated = append(updated, "attributes")
+	}
+	if desired.Description != existing.Description {
+		updated = append(updated, "description")
+	}
+	if stringsDiffer(desired.Images, existing.Images) {
+		updated = append(updated, "images")
+	}
+	if metadataDiffers(desired.Metadata, existing.Metadata) {
+		updated = append(updated, "metadata")
+	}
+	if desired.Name != existing.Name {
+		updated = append(updated, "name")
+	}
+	if desired.StatementDescriptor != existing.StatementDescriptor {
+		updated = append(updated, "statement_descriptor")
+	}
+	if desired.UnitLabel != existing.UnitLabel {
+		updated = append(updated, "unit_label")
+	}
+	if desired.URL != existing.URL {
+		updated = append(updated, "url")
+	}
+
+	if withDefault(string(desired.Type), string(stripe.ProductTypeService)) !=
+		withDefault(string(existing.Type), string(stripe.ProductTypeService)) {
+		immutable = append(immutable, "type")
+	}
+	return updated, immutable
+}
+
+func diffTaxRate(desiredObject, existingObject *object) (updated, immutable []string) {
+	desired := desiredObject.value.(*stripe.TaxRate)
+	existing := existingObject.value.(*stripe.TaxRate)
+
+	if !existing.Active {
+		updated = append(updated, "active")
+	}
+	if desired.Description != existing.Description {
+		updated = append(updated, "description")
+	}
+	if desired.DisplayName != existing.DisplayName {
+		updated = append(updated, "display_name")
+	}
+	if desired.Jurisdiction != existing.Jurisdiction {
+		updated = append(updated, "jurisdiction")
+	}
+	if metadataDiffers(desired.Metadata, existing.Metadata) {
+		updated = append(updated, "metadata")
+	}
+
+	if desired.Inclusive != existing.Inclusive {
+		immutable = append(immutable, "inclusive")
+	}
+	if desired.Percentage != existing.Percentage {
+		immutable = append(immutable, "percentage")
+	}
+	return updated, immutable
+}
+
+// amountDiffers compares amounts given either as an integer or as a
+// decimal. The decimal amount of the desired object is only compared when
+// it's set, since the API returns both.
+func amountDiffers(desired int64, desiredDecimal float64, existing int64, existingDecimal float64) bool {
+	if desiredDecimal != 0 {
+		return desiredDecimal != existingDecimal
+	}
+	return desired != existing
+}
+
+func intervalCount(count int64) int64 {
+	if count == 0 {
+		return 1
+	}
+	return count
+}
+
+// metadataDiffers returns whether any of the desired metadata keys has
+// another value in the existing metadata. Other existing keys are ignored.
+func metadataDiffers(desired, existing map[string]string) bool {
+	for key, value := range desired {
+		if existing[key] != value {
+			return true
+		}
+	}
+	return false
+}
+
+func newCouponParams(c *stripe.Coupon) *stripe.CouponParams {
+	params := &stripe.CouponParams{
+		Duration:         stripe.String(string(c.Duration)),
+		DurationInMonths: optionalInt64(c.DurationInMonths),
+		ID:               optionalString(c.ID),
+		MaxRedemptions:   optionalInt64(c.MaxRedemptions),
+		Name:             optionalString(c.Name),
+		RedeemBy:         optionalInt64(c.RedeemBy),
+	}
+	if c.AmountOff > 0 {
+		params.AmountOff = stripe.Int64(c.AmountOff)
+		params.Currency = stripe.String(string(c.Currency))
+	} else {
+		params.PercentOff = stripe.Float64(c.PercentOff)
+	}
+	params.Metadata = c.Metadata
+	return params
+}
+
+func newPlanParams(p *stripe.Plan, productID string) *stripe.PlanParams {
+	params := &stripe.PlanParams{
+		AggregateUsage:  optionalString(p.AggregateUsage),
+		BillingScheme:   optionalString(string(p.BillingScheme)),
+		Currency:        stripe.String(string(p.Currency)),
+		ID:              optionalString(p.ID),
+		Interval:        stripe.String(string(p.Interval)),
+		IntervalCount:   optionalInt64(p.IntervalCount),
+		Nickname:        optionalString(p.Nickname),
+		ProductID:       stripe.String(productID),
+		TiersMode:       optionalString(p.TiersMode),
+		TrialPeriodDays: optionalInt64(p.TrialPeriodDays),
+		UsageType:       optionalString(string(p.UsageType)),
+	}
+
+	switch {
+	case p.BillingScheme == stripe.PlanBillingSchemeTiered:
+	case p.AmountDecimal != 0:
+		params.AmountDecimal = stripe.Float64(p.AmountDecimal)
+	default:
+		params.Amount = stripe.Int64(p.Amount)
+	}
+
+	for _, tier := range p.Tiers {
+		tierParams := &stripe.PlanTierParams{}
+		if tier.FlatAmountDecimal != 0 {
+			tierParams.FlatAmountDecimal = stripe.Float64(tier.FlatAmountDecimal)
+		} else {
+			tierParams.FlatAmount = stripe.Int64(tier.FlatAmount)
+		}
+		if tier.UnitAmountDecimal != 0 {
+			tierParams.UnitAmountDecimal = stripe.Float64(tier.UnitAmountDecimal)
+		} else {
+			tierParams.UnitAmount = stripe.Int64(tier.UnitAmount)
+		}
+		if tier.UpTo == 0 {
+			tierParams.UpToInf = stripe.Bool(true)
+		} else {
+			tierParams.UpTo = stripe.Int64(tier.UpTo)
+		}
+		params.Tiers = append(params.Tiers, tierParams)
+	}
+
+	if p.TransformUsage != nil {
+		params.TransformUsage = &stripe.PlanTransformUsageParams{
+			DivideBy: stripe.Int64(p.TransformUsage.DivideBy),
+			Round:    stripe.String(string(p.TransformUsage.Round)),
+		}
+	}
+
+	params.Metadata = p.Metadata
+	return params
+}
+
+func newProductParams(p *stripe.Product) *stripe.ProductParams {
+	params := &stripe.ProductParams{
+		Description:         optionalString(p.Description),
+		ID:                  optionalString(p.ID),
+		Name:                stripe.String(p.Name),
+		StatementDescriptor: optionalString(p.StatementDescriptor),
+		Type:                optionalString(string(p.Type)),
+		UnitLabel:           optionalString(p.UnitLabel),
+		URL:                 optionalString(p.URL),
+	}
+	if len(p.Attributes) > 0 {
+		params.Attributes = stripe.StringSlice(p.Attributes)
+	}
+	if len(p.Images) > 0 {
+		params.Images = stripe.StringSlice(p.Images)
+	}
+	params.Metadata = p.Metadata
+	return params
+}
+
+func newTaxRateParams(t *stripe.TaxRate) *stripe.TaxRateParams {
+	params := &stripe.TaxRateParams{
+		Description:  optionalString(t.Description),
+		DisplayName:  stripe.String(t.DisplayName),
+		Inclusive:    stripe.Bool(t.Inclusive),
+		Jurisdiction: optionalString(t.Jurisdiction),
+		Percentage:   stripe.Float64(t.Percentage),
+	}
+	params.Metadata = t.Metadata
+	return params
+}
+
+func optionalInt64(v int64) *int64 {
+	if v == 0 {
+		return nil
+	}
+	return stripe.Int64(v)
+}
+
+func optionalString(v string) *string {
+	if v == "" {
+		return nil
+	}
+	return stripe.String(v)
+}
+
+func planObjects(plans []*stripe.Plan) []*object {
+	var objects []*object
+	for _, p := range plans {
+		objects = append(objects, &object{active: p.Active, id: p.ID, metadata: p.Metadata, value: p})
+	}
+	return objects
+}
+
+func productObjects(products []*stripe.Product) []*object {
+	var objects []*object
+	for _, p := range products {
+		objects = append(objects, &object{active: p.Active, id: p.ID, metadata: p.Metadata, value: p})
+	}
+	return objects
+}
+
+func stringsDiffer(desired, existing []string) bool {
+	if len(desired) != len(existing) {
+		return true
+	}
+	for i := range desired {
+		if desired[i] != existing[i] {
+			return true
+		}
+	}
+	return false
+}
+
+func taxRateObjects(taxRates []*stripe.TaxRate) []*object {
+	var objects []*object
+	for _, t := range taxRates {
+		objects = append(objects, &object{active: t.Active, id: t.ID, metadata: t.Metadata, value: t})
+	}
+	return objects
+}
+
+func tiersDiffer(desired, existing []*stripe.PlanTier) bool {
+	if len(desired) != len(existing) {
+		return true
+	}
+	for i := range desired {
+		if desired[i].UpTo != existing[i].UpTo ||
+			amountDiffers(desired[i].FlatAmount, desired[i].FlatAmountDecimal,
+				existing[i].FlatAmount, existing[i].FlatAmountDecimal) ||
+			amountDiffers(desired[i].UnitAmount, desired[i].UnitAmountDecimal,
+				existing[i].UnitAmount, existing[i].UnitAmountDecimal) {
+			return true
+		}
+	}
+	return false
+}
+
+func transformUsageDiffers(desired, existing *stripe.PlanTransformUsage) bool {
+	if desired == nil || existing == nil {
+		return desired != existing
+	}
+	return *desired != *existing
+}
+
+// updateCouponParams returns the parameters updating the given fields of a
+// coupon.
+func updateCouponParams(c *stripe.Coupon, fields []string) *stripe.CouponParams {
+	params := &stripe.CouponParams{}
+	for _, field := range fields {
+		switch field {
+		case "metadata":
+			params.Metadata = c.Metadata
+		case "name":
+			params.Name = stripe.String(c.Name)
+		}
+	}
+	return params
+}
+
+// updatePlanParams returns the parameters updating the given fields of a
+// plan.
+func updatePlanParams(p *stripe.Plan, productID string, fields []string) *stripe.PlanParams {
+	params := &stripe.PlanParams{}
+	for _, field := range fields {
+		switch field {
+		case "active":
+			params.Active = stripe.Bool(true)
+		case "metadata":
+			params.Metadata = p.Metadata
+		case "nickname":
+			params.Nickname = stripe.String(p.Nickname)
+		case "product":
+			params.ProductID = stripe.String(productID)
+		case "trial_period_days":
+			params.TrialPeriodDays = stripe.Int64(p.TrialPeriodDays)
+		}
+	}
+	return params
+}
+
+// updateProductParams returns the parameters updating the given fields of a
+// product.
+func updateProductParams(p *stripe.Product, fields []string) *stripe.ProductParams {
+	params := &stripe.ProductParams{}
+	for _, field := range fields {
+		switch field {
+		case "active":
+			params.Active = stripe.Bool(true)
+		case "attributes":
+			params.Attributes = stripe.StringSlice(p.Attributes)
+		case "description":
+			params.Description = stripe.String(p.Description)
+		case "images":
+			params.Images = stripe.StringSlice(p.Images)
+		case "metadata":
+			params.Metadata = p.Metadata
+		case "name":
+			params.Name = stripe.String(p.Name)
+		case "statement_descriptor":
+			params.StatementDescriptor = stripe.String(p.StatementDescriptor)
+		case "unit_label":
+			params.UnitLabel = stripe.String(p.UnitLabel)
+		case "url":
+			params.URL = stripe.String(p.URL)
+		}
+	}
+	return params
+}
+
+// updateTaxRateParams returns the parameters updating the given fields of a
+// tax rate.
+func updateTaxRateParams(t *stripe.TaxRate, fields []string) *stripe.TaxRateParams {
+	params := &stripe.TaxRateParams{}
+	for _, field := range fields {
+		switch field {
+		case "active":
+			params.Active = stripe.Bool(true)
+		case "description":
+			params.Description = stripe.String(t.Description)
+		case "display_name":
+			params.DisplayName = stripe.String(t.DisplayName)
+		case "jurisdiction":
+			params.Jurisdiction = stripe.String(t.Jurisdiction)
+		case "metadata":
+			params.Metadata = t.Metadata
+		}
+	}
+	return params
+}
+
+func withDefault(v, defaultValue string) string {
+	if v == "" {
+		return defaultValue
+	}
+	return v
+}
@@ -0,0 +1,655 @@
+// Package catalog keeps the products, plans, coupons and tax rates of an
+// account in sync with a catalog described declaratively, like in a file kept
+// under version control.
+package catalog
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	stripe "github.com/stripe/stripe-go"
+	"github.com/stripe/stripe-go/coupon"
+	"github.com/stripe/stripe-go/plan"
+	"github.com/stripe/stripe-go/product"
+	"github.com/stripe/stripe-go/sub"
+	"github.com/stripe/stripe-go/taxrate"
+)
+
+//
+// Public constants
+//
+
+// ChangeType is the type of a change made to an object of the account.
+type ChangeType string
+
+// List of values that ChangeType can take.
+const (
+	ChangeTypeArchive ChangeType = "archive"
+	ChangeTypeCreate  ChangeType = "create"
+	ChangeTypeDelete  ChangeType = "delete"
+	ChangeTypeReplace ChangeType = "replace"
+	ChangeTypeUpdate  ChangeType = "update"
+)
+
+// ObjectKind is the kind of an object of a catalog.
+type ObjectKind string
+
+// List of values that ObjectKind can take.
+const (
+	ObjectKindCoupon  ObjectKind = "coupon"
+	ObjectKindPlan    ObjectKind = "plan"
+	ObjectKindProduct ObjectKind = "product"
+	ObjectKindTaxRate ObjectKind = "tax_rate"
+)
+
+//
+// Public types
+//
+
+// Catalog is the desired state of the products, plans, coupons and tax rates
+// of an account. It can be decoded from JSON, or from YAML with a decoder
+// honoring JSON tags.
+//
+// Objects are identified either by their ID, or by the value of the
+// metadata key configured as SyncerConfig.MatchKey, in which case their ID
+// is generated by Stripe. The Active field of objects is ignored, since all
+// the objects of a catalog are active. The Product of a plan refers to a
+// product of the catalog by its ID or by the value of its metadata key, or
+// to a product of the account by its ID.
+type Catalog struct {
+	Coupons  []*stripe.Coupon  `json:"coupons"`
+	Plans    []*stripe.Plan    `json:"plans"`
+	Products []*stripe.Product `json:"products"`
+	TaxRates []*stripe.TaxRate `json:"tax_rates"`
+}
+
+// Change is a change to make to an object of the account to bring it in
+// sync with the catalog.
+type Change struct {
+	// Applied is whether the change was applied by Syncer.Apply.
+	Applied bool
+
+	// Desired is the object of the catalog, for changes other than archives
+	// and deletions. It's a *stripe.Coupon, *stripe.Plan, *stripe.Product or
+	// *stripe.TaxRate depending on Kind.
+	Desired interface{}
+
+	// Existing is the object of the account, for changes other than
+	// creations. Its type is the same as Desired's.
+	Existing interface{}
+
+	// Fields are the names of the fields that differ, for updates and
+	// replacements.
+	Fields []string
+
+	// ID is the ID of the object changed. For creations and replacements,
+	// it's only known once the change is applied.
+	ID string
+
+	// Kind is the kind of the object changed.
+	Kind ObjectKind
+
+	// Ref identifies the object in the catalog: its ID, or the value of its
+	// metadata key.
+	Ref string
+
+	// Type is the type of the change. Replacements create an object and
+	// then remove the existing one like pruning does, except that redeemed
+	// coupons are kept but lose their metadata key.
+	Type ChangeType
+
+	// replacement is the ID of the object created by a replacement.
+	replacement string
+}
+
+// String describes the change on a single line.
+func (c *Change) String() string {
+	symbols := map[ChangeType]string{
+		ChangeTypeArchive: "-",
+		ChangeTypeCreate:  "+",
+		ChangeTypeDelete:  "-",
+		ChangeTypeReplace: "-/+",
+		ChangeTypeUpdate:  "~",
+	}
+	s := fmt.Sprintf("%s %s %s (%s", symbols[c.Type], c.Kind, c.Ref, c.Type)
+	if len(c.Fields) > 0 {
+		s += ": " + strings.Join(c.Fields, ", ")
+	}
+	return s + ")"
+}
+
+// SyncPlan is the list of changes bringing an account in sync with a
+// catalog, as computed by Syncer.Plan.
+type SyncPlan struct {
+	// Changes are the changes to make, in the order they're applied: tax
+	// rates, coupons, products and plans are created, updated and replaced
+	// first, and then plans, products, coupons and tax rates are pruned.
+	Changes []*Change
+
+	// productAliases are the references of the products of the catalog by
+	// the value of their metadata key, for those with an ID.
+	productAliases map[string]string
+
+	// products are the IDs of the products of the catalog by reference, or
+	// empty for products which are yet to be created.
+	products map[string]string
+}
+
+// String describes the changes of the plan, one per line.
+func (p *SyncPlan) String() string {
+	if len(p.Changes) == 0 {
+		return "No changes\n"
+	}
+	var buf bytes.Buffer
+	for _, change := range p.Changes {
+		buf.WriteString(change.String())
+		buf.WriteString("\n")
+	}
+	return buf.String()
+}
+
+// Syncer brings the products, plans, coupons and tax rates of an account in
+// sync with a catalog, in two steps: Plan computes the changes to make
+// without making any request other than listing objects, which makes it a
+// dry run, and Apply makes them.
+//
+// Some fields can't be updated, like the amount and interval of a plan or
+// the percentage of a tax rate. When they change, objects identified by a
+// metadata key are replaced: a new object is created, and the existing one is
+// archived, or deleted when it's a plan without subscriptions or a coupon
+// that was never redeemed. Objects identified by their ID can't be replaced
+// since their ID is taken, so changing those fields is an error.
+//
+// Objects with active subscriptions are never deleted: plans are only
+// deleted when they have no subscription other than canceled ones, which is
+// checked again right before deleting them, and products are only archived.
+type Syncer struct {
+	config SyncerConfig
+}
+
+// SyncerConfig is used to configure a new Syncer.
+type SyncerConfig struct {
+	// Backend is the backend used to make requests.
+	//
+	// If left unset, the API backend returned by stripe.GetBackend is used.
+	Backend stripe.Backend
+
+	// Key is the API key used to make requests.
+	//
+	// If left unset, stripe.Key is used.
+	Key string
+
+	// MatchKey is the metadata key identifying the objects of the catalog
+	// which don't have an ID. Objects of the account are matched to them by
+	// the value of this key, among the active ones.
+	//
+	// If left unset, all objects of the catalog must have an ID.
+	MatchKey string
+
+	// Prune removes the objects of the account which aren't in the catalog:
+	// products, plans and tax rates are archived, but plans without
+	// subscriptions and coupons that were never redeemed are deleted.
+	// Redeemed coupons are left alone.
+	//
+	// When MatchKey is set, only objects with a value for it are pruned, so
+	// that objects managed outside of the catalog are left alone. Otherwise,
+	// every object of the account that isn't in the catalog is pruned.
+	Prune bool
+}
+
+//
+// Public functions
+//
+
+// NewSyncer returns a new syncer with the given configuration.
+func NewSyncer(config *SyncerConfig) *Syncer {
+	s := &Syncer{}
+	if config != nil {
+		s.config = *config
+	}
+	if s.config.Backend == nil {
+		s.config.Backend = stripe.GetBackend(stripe.APIBackend)
+	}
+	if s.config.Key == "" {
+		s.config.Key = stripe.Key
+	}
+	return s
+}
+
+// Plan lists the objects of the account and returns the changes bringing
+// them in sync with the catalog. It doesn't change anything.
+func (s *Syncer) Plan(catalog *Catalog) (*SyncPlan, error) {
+	accountCatalog, err := loadCatalog(s.config.Backend, s.config.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &SyncPlan{}
+
+	taxRates, taxRatePrunes, _, err := s.reconcile(ObjectKindTaxRate,
+		taxRateObjects(catalog.TaxRates), taxRateObjects(accountCatalog.TaxRates), diffTaxRate)
+	if err != nil {
+		return nil, err
+	}
+
+	coupons, couponPrunes, _, err := s.reconcile(ObjectKindCoupon,
+		couponObjects(catalog.Coupons), couponObjects(accountCatalog.Coupons), diffCoupon)
+	if err != nil {
+		return nil, err
+	}
+
+	products, productPrunes, refs, err := s.reconcile(ObjectKindProduct,
+		productObjects(catalog.Products), productObjects(accountCatalog.Products), diffProduct)
+	if err != nil {
+		return nil, err
+	}
+	p.products = refs
+	p.productAliases = make(map[string]string)
+	for _, desired := range catalog.Products {
+		if key := desired.Metadata[s.config.MatchKey]; desired.ID != "" && s.config.MatchKey != "" && key != "" {
+			p.productAliases[key] = desired.ID
+		}
+	}
+	for _, existing := range accountCatalog.Products {
+		if _, ok := p.products[existing.ID]; !ok {
+			p.products[existing.ID] = existing.ID
+		}
+	}
+
+	for i, desired := range catalog.Plans {
+		if desired.Product == nil {
+			return nil, fmt.Errorf("plan %d of the catalog has no product", i)
+		}
+		if _, ok := p.productID(desired.Product.ID); !ok {
+			return nil, fmt.Errorf("plan %d of the catalog has unknown product %s", i, desired.Product.ID)
+		}
+	}
+	plans, planPrunes, _, err := s.reconcile(ObjectKindPlan,
+		planObjects(catalog.Plans), planObjects(accountCatalog.Plans), p.diffPlan)
+	if err != nil {
+		return nil, err
+	}
+
+	p.Changes = append(p.Changes, taxRates...)
+	p.Changes = append(p.Changes, coupons...)
+	p.Changes = append(p.Changes, products...)
+	p.Changes = append(p.Changes, plans...)
+
+	var prunes []*Change
+	prunes = append(prunes, planPrunes...)
+	prunes = append(prunes, productPrunes...)
+	prunes = append(prunes, couponPrunes...)
+	prunes = append(prunes, taxRatePrunes...)
+	for _, change := range prunes {
+		change.Type, err = s.removal(change.Kind, change.Existing)
+		if err != nil {
+			return nil, err
+		}
+		if change.Type != "" {
+			p.Changes = append(p.Changes, change)
+		}
+	}
+	return p, nil
+}
+
+// Apply makes the changes of a plan in order, and stops at the first error.
+// Changes made before the error are marked as applied, and calling Apply
+// again with the same plan resumes after them.
+//
+// The account may have changed since the plan was computed, in which case
+// requests can fail, like when creating an object whose ID was taken since.
+// Plans with subscriptions are never deleted though: they're archived
+// instead if they have subscriptions by the time they're deleted.
+func (s *Syncer) Apply(p *SyncPlan) error {
+	for _, change := range p.Changes {
+		if change.Applied {
+			continue
+		}
+		if err := s.apply(p, change); err != nil {
+			return err
+		}
+		change.Applied = true
+	}
+	return nil
+}
+
+//
+// Private functions
+//
+
+// apply makes a change.
+func (s *Syncer) apply(p *SyncPlan, change *Change) error {
+	switch change.Type {
+	case ChangeTypeArchive, ChangeTypeDelete:
+		return s.remove(change, false)
+
+	case ChangeTypeCreate:
+		id, err := s.create(p, change.Kind, change.Desired)
+		if err != nil {
+			return err
+		}
+		change.ID = id
+
+	case ChangeTypeReplace:
+		// The new object is remembered before the existing one is removed,
+		// so that a failed removal is retried without creating another
+		// object
+		if change.replacement == "" {
+			id, err := s.create(p, change.Kind, change.Desired)
+			if err != nil {
+				return err
+			}
+			change.replacement = id
+		}
+		if err := s.remove(change, true); err != nil {
+			return err
+		}
+		change.ID = change.replacement
+
+	case ChangeTypeUpdate:
+		return s.update(p, change)
+
+	default:
+		return fmt.Errorf("unknown change type %s", change.Type)
+	}
+
+	if change.Kind == ObjectKindProduct {
+		p.products[change.Ref] = change.ID
+	}
+	return nil
+}
+
+// create creates an object of the catalog and returns its ID.
+func (s *Syncer) create(p *SyncPlan, kind ObjectKind, desired interface{}) (string, error) {
+	switch kind {
+	case ObjectKindCoupon:
+		created, err := coupon.Client{B: s.config.Backend, Key: s.config.Key}.New(
+			newCouponParams(desired.(*stripe.Coupon)))
+		if err != nil {
+			return "", err
+		}
+		return created.ID, nil
+
+	case ObjectKindPlan:
+		desiredPlan := desired.(*stripe.Plan)
+		created, err := plan.Client{B: s.config.Backend, Key: s.config.Key}.New(
+			newPlanParams(desiredPlan, planProductID(p, desiredPlan)))
+		if err != nil {
+			return "", err
+		}
+		return created.ID, nil
+
+	case ObjectKindProduct:
+		created, err := product.Client{B: s.config.Backend, Key: s.config.Key}.New(
+			newProductParams(desired.(*stripe.Product)))
+		if err != nil {
+			return "", err
+		}
+		return created.ID, nil
+
+	case ObjectKindTaxRate:
+		created, err := taxrate.Client{B: s.config.Backend, Key: s.config.Key}.New(
+			newTaxRateParams(desired.(*stripe.TaxRate)))
+		if err != nil {
+			return "", err
+		}
+		return created.ID, nil
+	}
+	return "", fmt.Errorf("unknown object kind %s", kind)
+}
+
+// inUse returns whether a plan has subscriptions other than canceled ones.
+func (s *Syncer) inUse(id string) (bool, error) {
+	params := &stripe.SubscriptionListParams{Plan: id}
+	params.Limit = stripe.Int64(1)
+	i := sub.Client{B: s.config.Backend, Key: s.config.Key}.List(params)
+	if i.Next() {
+		return true, nil
+	}
+	return false, i.Err()
+}
+
+// loadCatalog lists the objects of an account.
+func loadCatalog(backend stripe.Backend, key string) (*Catalog, error) {
+	c := &Catalog{}
+
+	coupons := coupon.Client{B: backend, Key: key}.List(&stripe.CouponListParams{})
+	for coupons.Next() {
+		c.Coupons = append(c.Coupons, coupons.Coupon())
+	}
+	if err := coupons.Err(); err != nil {
+		return nil, err
+	}
+
+	plans := plan.Client{B: backend, Key: key}.List(&stripe.PlanListParams{})
+	for plans.Next() {
+		c.Plans = append(c.Plans, plans.Plan())
+	}
+	if err := plans.Err(); err != nil {
+		return nil, err
+	}
+
+	products := product.Client{B: backend, Key: key}.List(&stripe.ProductListParams{})
+	for products.Next() {
+		c.Products = append(c.Products, products.Product())
+	}
+	if err := products.Err(); err != nil {
+		return nil, err
+	}
+
+	taxRates := taxrate.Client{B: backend, Key: key}.List(&stripe.TaxRateListParams{})
+	for taxRates.Next() {
+		c.TaxRates = append(c.TaxRates, taxRates.TaxRate())
+	}
+	if err := taxRates.Err(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// planProductID returns the ID of the product of a plan of the catalog.
+func planProductID(p *SyncPlan, desired *stripe.Plan) string {
+	id, _ := p.productID(desired.Product.ID)
+	return id
+}
+
+// productID returns the ID of the product a plan of the catalog refers to,
+// and whether it's known.
+func (p *SyncPlan) productID(ref string) (string, bool) {
+	if alias, ok := p.productAliases[ref]; ok {
+		ref = alias
+	}
+	id, ok := p.products[ref]
+	return id, ok
+}
+
+// reconcile matches the objects of the catalog of a kind with the existing
+// ones, and returns the changes to make to the existing objects, the
+// existing objects to prune, and the IDs of the objects of the catalog by
+// reference, which are empty for objects to create.
+func (s *Syncer) reconcile(kind ObjectKind, desired, existing []*object, diff diffFunc) ([]*Change, []*Change, map[string]string, error) {
+	index := newObjectIndex(existing, s.config.MatchKey)
+	refs := make(map[string]string)
+	matched := make(map[string]bool)
+
+	var changes []*Change
+	for i, d := range desired {
+		ref := d.id
+		if ref == "" && s.config.MatchKey != "" {
+			ref = d.metadata[s.config.MatchKey]
+		}
+		if ref == "" {
+			if s.config.MatchKey == "" {
+				return nil, nil, nil, fmt.Errorf("%s %d of the catalog has no ID", kind, i)
+			}
+			return nil, nil, nil, fmt.Errorf("%s %d of the catalog has no ID nor %s metadata", kind, i,
+				s.config.MatchKey)
+		}
+		if _, ok := refs[ref]; ok {
+			return nil, nil, nil, fmt.Errorf("%s %s appears twice in the catalog", kind, ref)
+		}
+
+		e, err := index.match(d, ref)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("%s %s: %v", kind, ref, err)
+		}
+		if e == nil {
+			if kind == ObjectKindTaxRate && d.id != "" {
+				return nil, nil, nil, fmt.Errorf("tax rate %s doesn't exist, and tax rates can't be created with an ID", ref)
+			}
+			refs[ref] = ""
+			changes = append(changes, &Change{Desired: d.value, Kind: kind, Ref: ref, Type: ChangeTypeCreate})
+			continue
+		}
+		matched[e.id] = true
+
+		updated, immutable := diff(d, e)
+		switch {
+		case len(immutable) > 0 && d.id != "":
+			return nil, nil, nil, fmt.Errorf("%s %s: %s can't be changed on an object identified by its ID",
+				kind, ref, strings.Join(immutable, ", "))
+		case len(immutable) > 0:
+			refs[ref] = ""
+			changes = append(changes, &Change{Desired: d.value, Existing: e.value, Fields: immutable,
+				ID: e.id, Kind: kind, Ref: ref, Type: ChangeTypeReplace})
+		case len(updated) > 0:
+			refs[ref] = e.id
+			changes = append(changes, &Change{Desired: d.value, Existing: e.value, Fields: updated,
+				ID: e.id, Kind: kind, Ref: ref, Type: ChangeTypeUpdate})
+		default:
+			refs[ref] = e.id
+		}
+	}
+
+	var prunes []*Change
+	if s.config.Prune {
+		for _, e := range existing {
+			if matched[e.id] {
+				continue
+			}
+			ref := e.id
+			if s.config.MatchKey != "" {
+				if e.metadata[s.config.MatchKey] == "" {
+					continue
+				}
+				ref = e.metadata[s.config.MatchKey]
+			}
+			prunes = append(prunes, &Change{Existing: e.value, ID: e.id, Kind: kind, Ref: ref})
+		}
+	}
+	return changes, prunes, refs, nil
+}
+
+// remove archives or deletes the existing object of a change. Plans planned
+// for deletion are archived instead if they're in use by then, and redeemed
+// coupons being replaced lose their metadata key.
+func (s *Syncer) remove(change *Change, replacing bool) error {
+	switch change.Kind {
+	case ObjectKindCoupon:
+		existing := change.Existing.(*stripe.Coupon)
+		c := coupon.Client{B: s.config.Backend, Key: s.config.Key}
+		if existing.TimesRedeemed > 0 {
+			if !replacing {
+				return nil
+			}
+			params := &stripe.CouponParams{}
+			params.AddMetadata(s.config.MatchKey, "")
+			_, err := c.Update(existing.ID, params)
+			return err
+		}
+		_, err := c.Del(existing.ID, nil)
+		return err
+
+	case ObjectKindPlan:
+		c := plan.Client{B: s.config.Backend, Key: s.config.Key}
+		id := change.Existing.(*stripe.Plan).ID
+		used := change.Type == ChangeTypeArchive
+		if !used {
+			var err error
+			if used, err = s.inUse(id); err != nil {
+				return err
+			}
+		}
+		if used {
+			if !replacing {
+				change.Type = ChangeTypeArchive
+			}
+			_, err := c.Update(id, &stripe.PlanParams{Active: stripe.Bool(false)})
+			return err
+		}
+		_, err := c.Del(id, nil)
+		return err
+
+	case ObjectKindProduct:
+		_, err := product.Client{B: s.config.Backend, Key: s.config.Key}.Update(
+			change.Existing.(*stripe.Product).ID, &stripe.ProductParams{Active: stripe.Bool(false)})
+		return err
+
+	case ObjectKindTaxRate:
+		_, err := taxrate.Client{B: s.config.Backend, Key: s.config.Key}.Update(
+			change.Existing.(*stripe.TaxRate).ID, &stripe.TaxRateParams{Active: stripe.Bool(false)})
+		return err
+	}
+	return fmt.Errorf("unknown object kind %s", change.Kind)
+}
+
+// removal returns how an existing object not in the catalog is pruned, or
+// an empty change type if it's left alone.
+func (s *Syncer) removal(kind ObjectKind, existing interface{}) (ChangeType, error) {
+	switch kind {
+	case ObjectKindCoupon:
+		if existing.(*stripe.Coupon).TimesRedeemed > 0 {
+			return "", nil
+		}
+		return ChangeTypeDelete, nil
+
+	case ObjectKindPlan:
+		existingPlan := existing.(*stripe.Plan)
+		used, err := s.inUse(existingPlan.ID)
+		if err != nil {
+			return "", err
+		}
+		switch {
+		case !used:
+			return ChangeTypeDelete, nil
+		case existingPlan.Active:
+			return ChangeTypeArchive, nil
+		}
+
+	case ObjectKindProduct:
+		if existing.(*stripe.Product).Active {
+			return ChangeTypeArchive, nil
+		}
+
+	case ObjectKindTaxRate:
+		if existing.(*stripe.TaxRate).Active {
+			return ChangeTypeArchive, nil
+		}
+	}
+	return "", nil
+}
+
+// update makes the updates of a change.
+func (s *Syncer) update(p *SyncPlan, change *Change) error {
+	var err error
+	switch change.Kind {
+	case ObjectKindCoupon:
+		_, err = coupon.Client{B: s.config.Backend, Key: s.config.Key}.Update(change.ID,
+			updateCouponParams(change.Desired.(*stripe.Coupon), change.Fields))
+	case ObjectKindPlan:
+		desired := change.Desired.(*stripe.Plan)
+		_, err = plan.Client{B: s.config.Backend, Key: s.config.Key}.Update(change.ID,
+			updatePlanParams(desired, planProductID(p, desired), change.Fields))
+	case ObjectKindProduct:
+		_, err = product.Client{B: s.config.Backend, Key: s.config.Key}.Update(change.ID,
+			updateProductParams(change.Desired.(*stripe.Product), change.Fields))
+	case ObjectKindTaxRate:
+		_, err = taxrate.Client{B: s.config.Backend, Key: s.config.Key}.Update(change.ID,
+			updateTaxRateParams(change.Desired.(*stripe.TaxRate), change.Fields))
+	default:
+		err = fmt.Errorf("unknown object kind %s", change.Kind)
+	}
+	return err
+}
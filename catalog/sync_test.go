@@ -0,0 +1,261 @@
+package catalog
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+	stripe "github.com/stripe/stripe-go"
+	"github.com/stripe/stripe-go/form"
+	stripetesting "github.com/stripe/stripe-go/testing"
+)
+
+// catalogBackend is a Backend serving the objects of an account, which
+// records the requests made through it other than lists. Objects retrieved
+// by ID always exist.
+type catalogBackend struct {
+	stripetesting.Backend
+
+	account    *Catalog
+	listErr    error
+	subscribed map[string]bool
+
+	calls  []string
+	params []stripe.ParamsContainer
+}
+
+func (b *catalogBackend) Call(method, path, key string, params stripe.ParamsContainer, v interface{}) error {
+	b.calls = append(b.calls, method+" "+path)
+	b.params = append(b.params, params)
+
+	id := fmt.Sprintf("new_%d", len(b.calls))
+	switch {
+	case method == http.MethodGet:
+		id = path[strings.LastIndex(path, "/")+1:]
+	case method != http.MethodPost || strings.Count(path, "/") != 2:
+		return nil
+	}
+	switch object := v.(type) {
+	case *stripe.Coupon:
+		object.ID = id
+	case *stripe.Plan:
+		object.ID = id
+	case *stripe.Product:
+		object.ID = id
+	case *stripe.TaxRate:
+		object.ID = id
+	}
+	return nil
+}
+
+func (b *catalogBackend) CallRaw(method, path, key string, body *form.Values, params *stripe.Params, v interface{}) error {
+	if b.listErr != nil {
+		return b.listErr
+	}
+	switch list := v.(type) {
+	case *stripe.CouponList:
+		list.Data = b.account.Coupons
+	case *stripe.PlanList:
+		list.Data = b.account.Plans
+	case *stripe.ProductList:
+		list.Data = b.account.Products
+	case *stripe.TaxRateList:
+		list.Data = b.account.TaxRates
+	case *stripe.SubscriptionList:
+		if plan := body.Get("plan"); len(plan) > 0 && b.subscribed[plan[0]] {
+			list.Data = []*stripe.Subscription{{ID: "sub_123"}}
+		}
+	}
+	return nil
+}
+
+func keyed(key string) map[string]string {
+	return map[string]string{"catalog_key": key}
+}
+
+func newAccount() *Catalog {
+	return &Catalog{
+		Coupons: []*stripe.Coupon{
+			{Duration: stripe.CouponDurationOnce, ID: "co_1", Metadata: keyed("launch"), PercentOff: 20},
+		},
+		Plans: []*stripe.Plan{
+			{Active: true, Amount: 1000, Currency: stripe.CurrencyUSD, ID: "plan_1", Interval: stripe.PlanIntervalMonth,
+				IntervalCount: 1, Metadata: keyed("pro-monthly"), Nickname: "Pro", Product: &stripe.Product{ID: "prod_1"}},
+			{Active: true, Amount: 500, Currency: stripe.CurrencyUSD, ID: "plan_2", Interval: stripe.PlanIntervalMonth,
+				IntervalCount: 1, Metadata: keyed("basic-monthly"), Product: &stripe.Product{ID: "prod_1"}},
+		},
+		Products: []*stripe.Product{
+			{Active: true, ID: "prod_1", Metadata: keyed("pro"), Name: "Pro", Type: stripe.ProductTypeService},
+		},
+		TaxRates: []*stripe.TaxRate{
+			{Active: true, DisplayName: "VAT", ID: "txr_1", Metadata: keyed("vat"), Percentage: 20},
+		},
+	}
+}
+
+func TestSyncer(t *testing.T) {
+	backend := &catalogBackend{account: newAccount()}
+	syncer := NewSyncer(&SyncerConfig{Backend: backend, Key: "sk_test_123", MatchKey: "catalog_key"})
+
+	p, err := syncer.Plan(&Catalog{
+		Coupons: []*stripe.Coupon{
+			{Duration: stripe.CouponDurationOnce, Metadata: keyed("launch"), Name: "Launch", PercentOff: 20},
+		},
+		Plans: []*stripe.Plan{
+			{Amount: 1200, Currency: stripe.CurrencyUSD, Interval: stripe.PlanIntervalMonth,
+				Metadata: keyed("pro-monthly"), Nickname: "Pro", Product: &stripe.Product{ID: "pro"}},
+			{Amount: 500, Currency: stripe.CurrencyUSD, Interval: stripe.PlanIntervalMonth,
+				Metadata: keyed("basic-monthly"), Product: &stripe.Product{ID: "pro"}},
+			{Amount: 10000, Currency: stripe.CurrencyUSD, Interval: stripe.PlanIntervalYear,
+				Metadata: keyed("pro-yearly"), Product: &stripe.Product{ID: "pro"}},
+		},
+		Products: []*stripe.Product{
+			{Metadata: keyed("pro"), Name: "Pro"},
+		},
+		TaxRates: []*stripe.TaxRate{
+			{DisplayName: "VAT", Metadata: keyed("vat"), Percentage: 21},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, ""+
+		"-/+ tax_rate vat (replace: percentage)\n"+
+		"~ coupon launch (update: name)\n"+
+		"-/+ plan pro-monthly (replace: amount)\n"+
+		"+ plan pro-yearly (create)\n", p.String())
+
+	// Planning doesn't change anything
+	assert.Equal(t, 0, len(backend.calls))
+
+	backend.subscribed = map[string]bool{"plan_1": true}
+	assert.NoError(t, syncer.Apply(p))
+	assert.Equal(t, []string{
+		"POST /v1/tax_rates",
+		"POST /v1/tax_rates/txr_1",
+		"POST /v1/coupons/co_1",
+		"POST /v1/plans",
+		"POST /v1/plans/plan_1",
+		"POST /v1/plans",
+	}, filterCalls(backend.calls, "POST"))
+
+	taxRateParams := backend.params[0].(*stripe.TaxRateParams)
+	assert.Equal(t, 21.0, *taxRateParams.Percentage)
+	assert.Equal(t, keyed("vat"), taxRateParams.Metadata)
+	assert.False(t, *backend.params[1].(*stripe.TaxRateParams).Active)
+	assert.Equal(t, "Launch", *backend.params[2].(*stripe.CouponParams).Name)
+
+	// The replaced plan has subscriptions, so it's archived
+	planParams := backend.params[3].(*stripe.PlanParams)
+	assert.Equal(t, int64(1200), *planParams.Amount)
+	assert.Equal(t, "prod_1", *planParams.ProductID)
+	assert.False(t, *backend.params[4].(*stripe.PlanParams).Active)
+
+	for _, change := range p.Changes {
+		assert.True(t, change.Applied)
+	}
+	assert.Equal(t, "new_1", p.Changes[0].ID)
+
+	// Applying again does nothing
+	backend.calls = nil
+	assert.NoError(t, syncer.Apply(p))
+	assert.Equal(t, 0, len(backend.calls))
+}
+
+func TestSyncer_NewProduct(t *testing.T) {
+	backend := &catalogBackend{account: newAccount()}
+	syncer := NewSyncer(&SyncerConfig{Backend: backend, Key: "sk_test_123", MatchKey: "catalog_key"})
+
+	p, err := syncer.Plan(&Catalog{
+		Plans: []*stripe.Plan{
+			{Amount: 1000, Currency: stripe.CurrencyUSD, Interval: stripe.PlanIntervalMonth,
+				Metadata: keyed("pro-monthly"), Nickname: "Pro", Product: &stripe.Product{ID: "team"}},
+		},
+		Products: []*stripe.Product{
+			{Metadata: keyed("pro"), Name: "Pro"},
+			{ID: "prod_team", Metadata: keyed("team"), Name: "Team", Type: stripe.ProductTypeService},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, ""+
+		"+ product prod_team (create)\n"+
+		"~ plan pro-monthly (update: product)\n", p.String())
+
+	backend.calls = nil
+	backend.params = nil
+	assert.NoError(t, syncer.Apply(p))
+	assert.Equal(t, []string{"POST /v1/products", "POST /v1/plans/plan_1"}, backend.calls)
+	assert.Equal(t, "prod_team", *backend.params[0].(*stripe.ProductParams).ID)
+	assert.Equal(t, "new_1", *backend.params[1].(*stripe.PlanParams).ProductID)
+}
+
+func TestSyncer_Prune(t *testing.T) {
+	account := newAccount()
+	account.Plans = append(account.Plans, &stripe.Plan{Active: true, ID: "plan_3", Product: &stripe.Product{ID: "prod_1"}})
+	account.Coupons = append(account.Coupons,
+		&stripe.Coupon{ID: "co_2", Metadata: keyed("summer"), TimesRedeemed: 3})
+	backend := &catalogBackend{account: account, subscribed: map[string]bool{"plan_1": true}}
+	syncer := NewSyncer(&SyncerConfig{Backend: backend, Key: "sk_test_123", MatchKey: "catalog_key", Prune: true})
+
+	p, err := syncer.Plan(&Catalog{
+		Products: []*stripe.Product{{Metadata: keyed("pro"), Name: "Pro"}},
+	})
+	assert.NoError(t, err)
+
+	// Objects without a metadata key aren't managed by the catalog, and
+	// redeemed coupons are never deleted
+	assert.Equal(t, ""+
+		"- plan pro-monthly (archive)\n"+
+		"- plan basic-monthly (delete)\n"+
+		"- coupon launch (delete)\n"+
+		"- tax_rate vat (archive)\n", p.String())
+
+	// Plans that got subscriptions since they were planned are archived
+	// instead of deleted
+	backend.calls = nil
+	backend.subscribed["plan_2"] = true
+	assert.NoError(t, syncer.Apply(p))
+	assert.Equal(t, []string{
+		"POST /v1/plans/plan_1",
+		"POST /v1/plans/plan_2",
+		"DELETE /v1/coupons/co_1",
+		"POST /v1/tax_rates/txr_1",
+	}, filterCalls(backend.calls, "POST", "DELETE"))
+	assert.Equal(t, ChangeTypeArchive, p.Changes[1].Type)
+}
+
+func TestSyncer_Invalid(t *testing.T) {
+	backend := &catalogBackend{account: newAccount()}
+	syncer := NewSyncer(&SyncerConfig{Backend: backend, Key: "sk_test_123", MatchKey: "catalog_key"})
+
+	catalogs := []*Catalog{
+		// Objects need an ID or a metadata key
+		{Products: []*stripe.Product{{Name: "Pro"}}},
+		// References must be unique
+		{Products: []*stripe.Product{{Metadata: keyed("pro")}, {Metadata: keyed("pro")}}},
+		// Plans need a known product
+		{Plans: []*stripe.Plan{{Metadata: keyed("pro-monthly"), Product: &stripe.Product{ID: "unknown"}}}},
+		// Immutable fields of objects with an ID can't change
+		{Plans: []*stripe.Plan{{Amount: 2000, Currency: stripe.CurrencyUSD, ID: "plan_1",
+			Interval: stripe.PlanIntervalMonth, Product: &stripe.Product{ID: "prod_1"}}}},
+		// Tax rates can't be created with an ID
+		{TaxRates: []*stripe.TaxRate{{ID: "txr_new", Percentage: 10}}},
+	}
+	for _, catalog := range catalogs {
+		_, err := syncer.Plan(catalog)
+		assert.Error(t, err)
+	}
+}
+
+// filterCalls returns the calls made with the given methods.
+func filterCalls(calls []string, methods ...string) []string {
+	var filtered []string
+	for _, call := range calls {
+		for _, method := range methods {
+			if strings.HasPrefix(call, method+" ") {
+				filtered = append(filtered, call)
+			}
+		}
+	}
+	return filtered
+}
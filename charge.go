@@ -503,6 +503,9 @@ type Charge struct {
 	Transfer                  *Transfer                   `json:"transfer"`
 	TransferData              *ChargeTransferData         `json:"transfer_data"`
 	TransferGroup             string                      `json:"transfer_group"`
+
+	Extra    map[string]json.RawMessage `json:"-"`
+	expanded bool
 }
 
 // UnmarshalJSON handles deserialization of a charge.
@@ -521,6 +524,7 @@ func (c *Charge) UnmarshalJSON(data []byte) error {
 	}
 
 	*c = Charge(v)
+	c.expanded = true
 	return nil
 }
 
@@ -541,6 +545,9 @@ type ChargeOutcomeRule struct {
 	Action    string `json:"action"`
 	ID        string `json:"id"`
 	Predicate string `json:"predicate"`
+
+	Extra    map[string]json.RawMessage `json:"-"`
+	expanded bool
 }
 
 // ChargeOutcome is the charge's outcome that details whether a payment
@@ -582,5 +589,6 @@ func (c *ChargeOutcomeRule) UnmarshalJSON(data []byte) error {
 	}
 
 	*c = ChargeOutcomeRule(v)
+	c.expanded = true
 	return nil
 }
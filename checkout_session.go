@@ -154,6 +154,9 @@ type CheckoutSession struct {
 	Subscription       *Subscription                 `json:"subscription"`
 	SubmitType         CheckoutSessionSubmitType     `json:"submit_type"`
 	SuccessURL         string                        `json:"success_url"`
+
+	Extra    map[string]json.RawMessage `json:"-"`
+	expanded bool
 }
 
 // UnmarshalJSON handles deserialization of a checkout session.
@@ -172,5 +175,6 @@ func (p *CheckoutSession) UnmarshalJSON(data []byte) error {
 	}
 
 	*p = CheckoutSession(v)
+	p.expanded = true
 	return nil
 }
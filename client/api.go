@@ -229,19 +229,21 @@ type API struct {
 	UsageRecordSummaries *usagerecordsummary.Client
 	// WebhookEndpoints is the client used to invoke usage record related APIs.
 	WebhookEndpoints *webhookendpoint.Client
+
+	backends *stripe.Backends
+	key      string
 }
 
 // Init initializes the Stripe client with the appropriate secret key
 // as well as providing the ability to override the backend as needed.
 func (a *API) Init(key string, backends *stripe.Backends) {
 	if backends == nil {
-		backends = &stripe.Backends{
-			API:     stripe.GetBackend(stripe.APIBackend),
-			Connect: stripe.GetBackend(stripe.ConnectBackend),
-			Uploads: stripe.GetBackend(stripe.UploadsBackend),
-		}
+		backends = defaultBackends()
 	}
 
+	a.backends = backends
+	a.key = key
+
 	a.Account = &account.Client{B: backends.API, Key: key}
 	a.ApplePayDomains = &applepaydomain.Client{B: backends.API, Key: key}
 	a.AccountLinks = &accountlink.Client{B: backends.API, Key: key}
@@ -318,6 +320,56 @@ func (a *API) Init(key string, backends *stripe.Backends) {
 	a.WebhookEndpoints = &webhookendpoint.Client{B: backends.API, Key: key}
 }
 
+// InitWithKeyProvider initializes the Stripe client so that the API key for
+// every request is resolved from the given provider, as well as providing the
+// ability to override the backend as needed.
+func (a *API) InitWithKeyProvider(provider stripe.KeyProvider, backends *stripe.Backends) {
+	if backends == nil {
+		backends = defaultBackends()
+	}
+
+	a.Init("", &stripe.Backends{
+		API:     stripe.NewKeyProviderBackend(backends.API, provider),
+		Connect: stripe.NewKeyProviderBackend(backends.Connect, provider),
+		Uploads: stripe.NewKeyProviderBackend(backends.Uploads, provider),
+	})
+}
+
+// WithAccount returns a copy of the client that makes every request on behalf
+// of the given connected account by sending the `Stripe-Account` header. An
+// explicit StripeAccount set on params takes precedence.
+//
+// The Connect backend, which OAuth requests go through, is left unscoped:
+// exchanging, refreshing and revoking tokens are always done by the platform
+// on its own behalf, and Stripe rejects them when they're made on behalf of a
+// connected account.
+func (a *API) WithAccount(account string) *API {
+	return a.withAccount(account, false)
+}
+
+// WithAccountStrict is the same as WithAccount except that requests fail with
+// an error instead of being sent if params explicitly set a StripeAccount
+// that's different from the given one. Like with WithAccount, OAuth requests
+// are left unscoped.
+func (a *API) WithAccountStrict(account string) *API {
+	return a.withAccount(account, true)
+}
+
+func (a *API) withAccount(account string, strict bool) *API {
+	backends := a.backends
+	if backends == nil {
+		backends = defaultBackends()
+	}
+
+	scoped := API{}
+	scoped.Init(a.key, &stripe.Backends{
+		API:     &stripe.AccountBackend{Backend: backends.API, StripeAccount: account, Strict: strict},
+		Connect: backends.Connect,
+		Uploads: &stripe.AccountBackend{Backend: backends.Uploads, StripeAccount: account, Strict: strict},
+	})
+	return &scoped
+}
+
 // New creates a new Stripe client with the appropriate secret key
 // as well as providing the ability to override the backends as needed.
 func New(key string, backends *stripe.Backends) *API {
@@ -325,3 +377,20 @@ func New(key string, backends *stripe.Backends) *API {
 	api.Init(key, backends)
 	return &api
 }
+
+// NewWithKeyProvider creates a new Stripe client that resolves the API key
+// for every request from the given provider, as well as providing the ability
+// to override the backends as needed.
+func NewWithKeyProvider(provider stripe.KeyProvider, backends *stripe.Backends) *API {
+	api := API{}
+	api.InitWithKeyProvider(provider, backends)
+	return &api
+}
+
+func defaultBackends() *stripe.Backends {
+	return &stripe.Backends{
+		API:     stripe.GetBackend(stripe.APIBackend),
+		Connect: stripe.GetBackend(stripe.ConnectBackend),
+		Uploads: stripe.GetBackend(stripe.UploadsBackend),
+	}
+}
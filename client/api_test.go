@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	assert "github.com/stretchr/testify/require"
+	stripe "github.com/stripe/stripe-go"
 )
 
 func TestAPIInit(t *testing.T) {
@@ -16,3 +17,38 @@ func TestAPINew(t *testing.T) {
 	api := New("sk_test_123", nil)
 	assert.Equal(t, "sk_test_123", api.Charges.Key)
 }
+
+func TestAPIWithAccount(t *testing.T) {
+	api := New("sk_test_123", nil)
+	scoped := api.WithAccount("acct_123")
+
+	assert.Equal(t, "sk_test_123", scoped.Charges.Key)
+
+	backend := scoped.Charges.B.(*stripe.AccountBackend)
+	assert.Equal(t, "acct_123", backend.StripeAccount)
+	assert.False(t, backend.Strict)
+	assert.Equal(t, api.Charges.B, backend.Backend)
+
+	// Files go through the uploads backend
+	assert.Equal(t, api.Files.B, scoped.Files.B.(*stripe.AccountBackend).Backend)
+
+	// OAuth is never scoped
+	assert.Equal(t, api.OAuth.B, scoped.OAuth.B)
+}
+
+func TestAPIWithAccountStrict(t *testing.T) {
+	scoped := New("sk_test_123", nil).WithAccountStrict("acct_123")
+	assert.True(t, scoped.Charges.B.(*stripe.AccountBackend).Strict)
+
+	charges := New("sk_test_123", nil).Charges.WithAccountStrict("acct_123")
+	assert.True(t, charges.B.(*stripe.AccountBackend).Strict)
+}
+
+func TestAPINewWithKeyProvider(t *testing.T) {
+	provider := stripe.StaticKeyProvider("sk_test_123")
+	api := NewWithKeyProvider(provider, nil)
+
+	assert.Equal(t, provider, api.Charges.B.(*stripe.KeyProviderBackend).KeyProvider)
+	assert.Equal(t, provider, api.Files.B.(*stripe.KeyProviderBackend).KeyProvider)
+	assert.Equal(t, provider, api.OAuth.B.(*stripe.KeyProviderBackend).KeyProvider)
+}
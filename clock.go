@@ -0,0 +1,68 @@
+package stripe
+
+import (
+	"time"
+)
+
+//
+// Public types
+//
+
+// Clock is a source of the current time and a way of waiting. The library
+// uses one wherever its behavior depends on time, like generating
+// idempotency keys, checking the tolerance of webhook signatures and sleeping
+// between retries, so that this behavior can be tested deterministically by
+// replacing it.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+
+	// Sleep pauses the current goroutine for at least the given duration.
+	Sleep(d time.Duration)
+}
+
+//
+// Public variables
+//
+
+// DefaultClock is the clock used by package-level functions like
+// NewIdempotencyKey, by the webhook package and by backends configured
+// without a clock of their own. It uses the system's time.
+var DefaultClock Clock = systemClock{}
+
+//
+// Private types
+//
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) Sleep(d time.Duration) {
+	time.Sleep(d)
+}
+
+//
+// Private functions
+//
+
+// unixTime converts a Unix timestamp of the API to a time.Time, returning the
+// zero time for a zero timestamp, which the API uses for timestamps that
+// aren't set.
+func unixTime(timestamp int64) time.Time {
+	if timestamp == 0 {
+		return time.Time{}
+	}
+	return time.Unix(timestamp, 0)
+}
+
+// unixCeil converts a time.Time to the first Unix timestamp not before it.
+func unixCeil(t time.Time) int64 {
+	timestamp := t.Unix()
+	if t.Nanosecond() > 0 {
+		timestamp++
+	}
+	return timestamp
+}
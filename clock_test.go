@@ -0,0 +1,79 @@
+package stripe
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	assert "github.com/stretchr/testify/require"
+)
+
+// testClock is a Clock whose time only advances when it sleeps.
+type testClock struct {
+	now    time.Time
+	sleeps []time.Duration
+}
+
+func (c *testClock) Now() time.Time {
+	return c.now
+}
+
+func (c *testClock) Sleep(d time.Duration) {
+	c.now = c.now.Add(d)
+	c.sleeps = append(c.sleeps, d)
+}
+
+func TestNewIdempotencyKey_Clock(t *testing.T) {
+	defer func(original Clock) { DefaultClock = original }(DefaultClock)
+	DefaultClock = &testClock{now: time.Unix(1500000000, 0)}
+
+	key := NewIdempotencyKey()
+	assert.True(t, strings.HasPrefix(key, "1500000000000000000_"))
+}
+
+func TestDo_RetryClock(t *testing.T) {
+	requestNum := 0
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestNum++
+		if requestNum < 3 {
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"error":{"message":"Conflict (this should be retried)."}}`))
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer testServer.Close()
+
+	clock := &testClock{now: time.Unix(1500000000, 0)}
+	backend := GetBackendWithConfig(APIBackend, &BackendConfig{
+		Clock:             clock,
+		MaxNetworkRetries: 5,
+		URL:               testServer.URL,
+	})
+
+	err := backend.Call(http.MethodGet, "/v1/hello", "sk_test_123", nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, requestNum)
+
+	// The backend slept between retries without pausing the test
+	assert.Equal(t, 2, len(clock.sleeps))
+	for _, sleep := range clock.sleeps {
+		assert.True(t, sleep >= minNetworkRetriesDelay)
+	}
+}
+
+func TestTimestamps(t *testing.T) {
+	charge := &Charge{Created: 1500000000}
+	assert.Equal(t, time.Unix(1500000000, 0), charge.CreatedTime())
+
+	// Timestamps that aren't set are the zero time
+	sub := &Subscription{}
+	assert.True(t, sub.CanceledAtTime().IsZero())
+
+	params := &UsageRecordParams{Timestamp: Timestamp(time.Unix(1500000000, 0))}
+	assert.Equal(t, int64(1500000000), *params.Timestamp)
+	assert.Equal(t, time.Unix(1500000000, 0), TimestampValue(params.Timestamp))
+	assert.True(t, TimestampValue(nil).IsZero())
+}
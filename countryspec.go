@@ -1,5 +1,7 @@
 package stripe
 
+import "encoding/json"
+
 // Country is the list of supported countries
 type Country string
 
@@ -20,6 +22,8 @@ type CountrySpec struct {
 	SupportedPaymentMethods        []string                                        `json:"supported_payment_methods"`
 	SupportedTransferCountries     []string                                        `json:"supported_transfer_countries"`
 	VerificationFields             map[AccountBusinessType]*VerificationFieldsList `json:"verification_fields"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // CountrySpecParams are the parameters allowed during CountrySpec retrieval.
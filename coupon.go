@@ -53,6 +53,9 @@ type Coupon struct {
 	RedeemBy         int64             `json:"redeem_by"`
 	TimesRedeemed    int64             `json:"times_redeemed"`
 	Valid            bool              `json:"valid"`
+
+	Extra    map[string]json.RawMessage `json:"-"`
+	expanded bool
 }
 
 // CouponList is a list of coupons as retrieved from a list endpoint.
@@ -77,5 +80,6 @@ func (c *Coupon) UnmarshalJSON(data []byte) error {
 	}
 
 	*c = Coupon(v)
+	c.expanded = true
 	return nil
 }
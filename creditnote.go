@@ -152,6 +152,9 @@ type CreditNote struct {
 	Total                      int64                       `json:"total"`
 	Type                       CreditNoteType              `json:"type"`
 	VoidedAt                   int64                       `json:"voided_at"`
+
+	Extra    map[string]json.RawMessage `json:"-"`
+	expanded bool
 }
 
 // CreditNoteLineItem is the resource representing a Stripe credit note line item.
@@ -170,6 +173,8 @@ type CreditNoteLineItem struct {
 	Type              CreditNoteLineItemType `json:"type"`
 	UnitAmount        int64                  `json:"unit_amount"`
 	UnitAmountDecimal float64                `json:"unit_amount_decimal,string"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // CreditNoteList is a list of credit notes as retrieved from a list endpoint.
@@ -200,5 +205,6 @@ func (i *CreditNote) UnmarshalJSON(data []byte) error {
 	}
 
 	*i = CreditNote(v)
+	i.expanded = true
 	return nil
 }
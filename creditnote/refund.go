@@ -0,0 +1,362 @@
+package creditnote
+
+import (
+	"fmt"
+
+	stripe "github.com/stripe/stripe-go"
+	"github.com/stripe/stripe-go/invoice"
+)
+
+//
+// Public constants
+//
+
+// RefundMethod is how the amount of a credit note is given back to the
+// customer.
+type RefundMethod string
+
+// List of values that RefundMethod can take.
+const (
+	RefundMethodCustomerBalance RefundMethod = "customer_balance"
+	RefundMethodRefund          RefundMethod = "refund"
+)
+
+//
+// Public types
+//
+
+// RefundAudit is the record of a refund planned by PlanRefund and executed
+// by ExecuteRefund, whether it succeeded or not. It's meant to be stored as
+// is, and can be encoded to JSON.
+type RefundAudit struct {
+	// Created is the Unix time at which the credit note was created.
+	Created int64 `json:"created"`
+
+	// CreditAmount is the amount credited to the customer's balance.
+	CreditAmount int64 `json:"credit_amount"`
+
+	// CreditNote is the ID of the credit note, unless it couldn't be created.
+	CreditNote string `json:"credit_note"`
+
+	// Currency is the currency of the invoice.
+	Currency stripe.Currency `json:"currency"`
+
+	// Customer is the ID of the customer.
+	Customer string `json:"customer"`
+
+	// Error is the message of the error that prevented the credit note from
+	// being created, if any. Nothing was refunded or credited when it's set.
+	Error string `json:"error"`
+
+	// IdempotencyKey is the idempotency key the credit note was created
+	// with.
+	IdempotencyKey string `json:"idempotency_key"`
+
+	// Invoice is the ID of the invoice.
+	Invoice string `json:"invoice"`
+
+	// Lines are the line items of the credit note.
+	Lines []*stripe.CreditNoteLineItem `json:"lines"`
+
+	// Refund is the ID of the refund created along with the credit note, if
+	// any.
+	Refund string `json:"refund"`
+
+	// RefundAmount is the amount refunded to the payment method of the
+	// invoice.
+	RefundAmount int64 `json:"refund_amount"`
+
+	// Total is the total of the credit note.
+	Total int64 `json:"total"`
+}
+
+// RefundLine is the part of an invoice line item to refund.
+type RefundLine struct {
+	// InvoiceLineItem is the ID of the invoice line item.
+	InvoiceLineItem string
+
+	// Quantity is the quantity of the line item to refund.
+	Quantity int64
+}
+
+// RefundPlan is a refund computed by PlanRefund, which ExecuteRefund carries
+// out.
+type RefundPlan struct {
+	// CreditAmount is the part of the credit note's total credited to the
+	// customer's balance.
+	CreditAmount int64
+
+	// IdempotencyKey is the idempotency key the credit note is created with.
+	// It's derived from the amounts already credited on the invoice, so that
+	// executing a plan twice creates a single credit note, and that a plan
+	// can't be executed once the invoice has been credited by another one.
+	IdempotencyKey string
+
+	// Invoice is the invoice being refunded, with its charge expanded.
+	Invoice *stripe.Invoice
+
+	// Lines are the line items of the previewed credit note.
+	Lines []*stripe.CreditNoteLineItem
+
+	// Preview is the previewed credit note.
+	Preview *stripe.CreditNote
+
+	// RefundAmount is the part of the credit note's total refunded to the
+	// payment method of the invoice.
+	RefundAmount int64
+
+	// Request is the refund requested.
+	Request *RefundRequest
+}
+
+// Params returns the parameters creating the credit note of the plan.
+func (p *RefundPlan) Params() *stripe.CreditNoteParams {
+	params := &stripe.CreditNoteParams{
+		Amount:  optionalInt64(p.Request.Amount),
+		Invoice: stripe.String(p.Invoice.ID),
+		Lines:   p.Request.lines(),
+		Memo:    optionalString(p.Request.Memo),
+		Reason:  optionalString(string(p.Request.Reason)),
+	}
+
+	if p.Invoice.Status == stripe.InvoiceStatusPaid {
+		params.CreditAmount = stripe.Int64(p.CreditAmount)
+		params.RefundAmount = stripe.Int64(p.RefundAmount)
+	}
+	params.SetIdempotencyKey(p.IdempotencyKey)
+	return params
+}
+
+// RefundPolicy decides how the amount of a credit note on a paid invoice is
+// given back to the customer.
+type RefundPolicy struct {
+	// Method is how the amount is given back. When refunding, the part of the
+	// amount that can't be refunded, like when the invoice was paid out of
+	// band or its charge was already refunded, is credited to the customer's
+	// balance instead.
+	//
+	// Defaults to RefundMethodRefund.
+	Method RefundMethod
+
+	// MinimumRefund is the smallest amount refunded to the payment method.
+	// Smaller amounts are credited to the customer's balance instead.
+	MinimumRefund int64
+}
+
+// RefundRequest is a refund of an invoice, either of an amount or of parts
+// of its line items.
+type RefundRequest struct {
+	// Amount is the amount to refund, including tax. It can't be set along
+	// with Lines.
+	Amount int64
+
+	// Lines are the parts of line items to refund. They can't be set along
+	// with Amount.
+	Lines []*RefundLine
+
+	// Memo is the memo of the credit note.
+	Memo string
+
+	// Policy decides how the amount is given back when the invoice is paid.
+	// Credit notes on open invoices reduce the amount due instead.
+	//
+	// If left unset, the amount is refunded.
+	Policy *RefundPolicy
+
+	// Reason is the reason of the credit note.
+	Reason stripe.CreditNoteReason
+}
+
+//
+// Public functions
+//
+
+// PlanRefund previews the credit note refunding part of an invoice, and
+// splits its total between a refund and a credit to the customer's balance
+// according to the policy of the request.
+func PlanRefund(invoice string, request *RefundRequest) (*RefundPlan, error) {
+	return getC().PlanRefund(invoice, request)
+}
+
+// PlanRefund previews the credit note refunding part of an invoice, and
+// splits its total between a refund and a credit to the customer's balance
+// according to the policy of the request.
+func (c Client) PlanRefund(id string, request *RefundRequest) (*RefundPlan, error) {
+	if err := request.validate(); err != nil {
+		return nil, err
+	}
+
+	invoiceParams := &stripe.InvoiceParams{}
+	invoiceParams.AddExpand("charge")
+	inv, err := invoice.Client{B: c.B, Key: c.Key}.Get(id, invoiceParams)
+	if err != nil {
+		return nil, err
+	}
+	if inv.Status != stripe.InvoiceStatusOpen && inv.Status != stripe.InvoiceStatusPaid {
+		return nil, stripe.NewInvalidRequestError("", "invoice",
+			fmt.Sprintf("invoice %s is %s, only open and paid invoices can be refunded", inv.ID, inv.Status))
+	}
+
+	preview, err := c.Preview(&stripe.CreditNotePreviewParams{
+		Amount:  optionalInt64(request.Amount),
+		Invoice: stripe.String(inv.ID),
+		Lines:   request.lines(),
+		Memo:    optionalString(request.Memo),
+		Reason:  optionalString(string(request.Reason)),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var lines []*stripe.CreditNoteLineItem
+	i := c.ListPreviewLines(&stripe.CreditNoteLineItemListPreviewParams{
+		Amount:  optionalInt64(request.Amount),
+		Invoice: stripe.String(inv.ID),
+		Lines:   request.lines(),
+		Memo:    optionalString(request.Memo),
+		Reason:  optionalString(string(request.Reason)),
+	})
+	for i.Next() {
+		lines = append(lines, i.CreditNoteLineItem())
+	}
+	if err := i.Err(); err != nil {
+		return nil, err
+	}
+
+	plan := &RefundPlan{
+		IdempotencyKey: stripe.DeterministicIdempotencyKey("refund-"+inv.ID,
+			inv.PrePaymentCreditNotesAmount+inv.PostPaymentCreditNotesAmount),
+		Invoice: inv,
+		Lines:   lines,
+		Preview: preview,
+		Request: request,
+	}
+	if inv.Status == stripe.InvoiceStatusPaid {
+		plan.RefundAmount, plan.CreditAmount = split(preview.Total, inv.Charge, request.Policy)
+	}
+	return plan, nil
+}
+
+// ExecuteRefund creates the credit note of a plan, which refunds and credits
+// the customer's balance as planned in a single request, and returns the
+// audit record of the refund. The audit record is returned along with the
+// error when the credit note couldn't be created.
+func ExecuteRefund(plan *RefundPlan) (*RefundAudit, error) {
+	return getC().ExecuteRefund(plan)
+}
+
+// ExecuteRefund creates the credit note of a plan, which refunds and credits
+// the customer's balance as planned in a single request, and returns the
+// audit record of the refund. The audit record is returned along with the
+// error when the credit note couldn't be created.
+func (c Client) ExecuteRefund(plan *RefundPlan) (*RefundAudit, error) {
+	audit := &RefundAudit{
+		CreditAmount:   plan.CreditAmount,
+		Currency:       plan.Invoice.Currency,
+		IdempotencyKey: plan.IdempotencyKey,
+		Invoice:        plan.Invoice.ID,
+		Lines:          plan.Lines,
+		RefundAmount:   plan.RefundAmount,
+		Total:          plan.Preview.Total,
+	}
+	if plan.Invoice.Customer != nil {
+		audit.Customer = plan.Invoice.Customer.ID
+	}
+
+	creditNote, err := c.New(plan.Params())
+	if err != nil {
+		audit.Error = err.Error()
+		return audit, err
+	}
+
+	audit.CreditNote = creditNote.ID
+	audit.Created = creditNote.Created
+	audit.Total = creditNote.Total
+	if creditNote.Refund != nil {
+		audit.Refund = creditNote.Refund.ID
+	}
+	if creditNote.Lines != nil {
+		audit.Lines = creditNote.Lines.Data
+	}
+	return audit, nil
+}
+
+//
+// Private functions
+//
+
+// lines returns the parameters of the credit note's lines.
+func (r *RefundRequest) lines() []*stripe.CreditNoteLineParams {
+	var lines []*stripe.CreditNoteLineParams
+	for _, line := range r.Lines {
+		lines = append(lines, &stripe.CreditNoteLineParams{
+			InvoiceLineItem: stripe.String(line.InvoiceLineItem),
+			Quantity:        stripe.Int64(line.Quantity),
+			Type:            stripe.String(string(stripe.CreditNoteLineItemTypeInvoiceLineItem)),
+		})
+	}
+	return lines
+}
+
+func (r *RefundRequest) validate() error {
+	switch {
+	case r.Amount < 0:
+		return stripe.NewInvalidRequestError("", "amount", "amount can't be negative")
+	case r.Amount > 0 && len(r.Lines) > 0:
+		return stripe.NewInvalidRequestError("", "amount", "amount and lines can't both be set")
+	case r.Amount == 0 && len(r.Lines) == 0:
+		return stripe.NewInvalidRequestError("", "amount", "either amount or lines must be set")
+	}
+
+	for i, line := range r.Lines {
+		if line.InvoiceLineItem == "" {
+			return stripe.NewInvalidRequestError("", fmt.Sprintf("lines[%d][invoice_line_item]", i),
+				"invoice line item must be set")
+		}
+		if line.Quantity <= 0 {
+			return stripe.NewInvalidRequestError("", fmt.Sprintf("lines[%d][quantity]", i), "quantity must be positive")
+		}
+	}
+	return nil
+}
+
+// optionalInt64 returns a pointer to v, or nil if v is zero.
+func optionalInt64(v int64) *int64 {
+	if v == 0 {
+		return nil
+	}
+	return stripe.Int64(v)
+}
+
+// optionalString returns a pointer to v, or nil if v is empty.
+func optionalString(v string) *string {
+	if v == "" {
+		return nil
+	}
+	return stripe.String(v)
+}
+
+// split returns how much of a total is refunded to a charge and credited to
+// the customer's balance under a policy.
+func split(total int64, charge *stripe.Charge, policy *RefundPolicy) (refund, credit int64) {
+	if policy == nil {
+		policy = &RefundPolicy{}
+	}
+	if policy.Method == RefundMethodCustomerBalance {
+		return 0, total
+	}
+
+	var refundable int64
+	if charge != nil {
+		refundable = charge.Amount - charge.AmountRefunded
+	}
+
+	refund = total
+	if refund > refundable {
+		refund = refundable
+	}
+	if refund < policy.MinimumRefund || refund < 0 {
+		refund = 0
+	}
+	return refund, total - refund
+}
@@ -0,0 +1,177 @@
+package creditnote
+
+import (
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+	stripe "github.com/stripe/stripe-go"
+	"github.com/stripe/stripe-go/form"
+	stripetesting "github.com/stripe/stripe-go/testing"
+)
+
+// refundBackend is a Backend serving an invoice and the preview of a credit
+// note on it, which records the credit notes created through it.
+type refundBackend struct {
+	stripetesting.Backend
+
+	invoice *stripe.Invoice
+	newErr  error
+	total   int64
+
+	created []*stripe.CreditNoteParams
+}
+
+func (b *refundBackend) Call(method, path, key string, params stripe.ParamsContainer, v interface{}) error {
+	switch path {
+	case "/v1/invoices/in_123":
+		*v.(*stripe.Invoice) = *b.invoice
+	case "/v1/credit_notes/preview":
+		*v.(*stripe.CreditNote) = stripe.CreditNote{Total: b.total}
+	case "/v1/credit_notes":
+		if b.newErr != nil {
+			return b.newErr
+		}
+		creditNote := v.(*stripe.CreditNote)
+		creditNote.ID = "cn_123"
+		creditNote.Created = 1500000000
+		creditNote.Total = b.total
+		if refundAmount := params.(*stripe.CreditNoteParams).RefundAmount; refundAmount != nil && *refundAmount > 0 {
+			creditNote.Refund = &stripe.Refund{ID: "re_123"}
+		}
+		b.created = append(b.created, params.(*stripe.CreditNoteParams))
+	}
+	return nil
+}
+
+func (b *refundBackend) CallRaw(method, path, key string, body *form.Values, params *stripe.Params, v interface{}) error {
+	v.(*stripe.CreditNoteLineItemList).Data = []*stripe.CreditNoteLineItem{
+		{Amount: b.total, InvoiceLineItem: "il_123", Quantity: 1},
+	}
+	return nil
+}
+
+func paidInvoice(amountRefunded int64) *stripe.Invoice {
+	return &stripe.Invoice{
+		Charge:                       &stripe.Charge{Amount: 5000, AmountRefunded: amountRefunded},
+		Currency:                     stripe.CurrencyUSD,
+		Customer:                     &stripe.Customer{ID: "cus_123"},
+		ID:                           "in_123",
+		PostPaymentCreditNotesAmount: amountRefunded,
+		Status:                       stripe.InvoiceStatusPaid,
+	}
+}
+
+func TestPlanRefund(t *testing.T) {
+	backend := &refundBackend{invoice: paidInvoice(0), total: 1500}
+	c := Client{B: backend, Key: "sk_test_123"}
+
+	plan, err := c.PlanRefund("in_123", &RefundRequest{
+		Lines:  []*RefundLine{{InvoiceLineItem: "il_123", Quantity: 1}},
+		Reason: stripe.CreditNoteReasonProductUnsatisfactory,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1500), plan.RefundAmount)
+	assert.Equal(t, int64(0), plan.CreditAmount)
+	assert.Equal(t, 1, len(plan.Lines))
+	assert.Equal(t, "refund-in_123-0", plan.IdempotencyKey)
+
+	params := plan.Params()
+	assert.Nil(t, params.Amount)
+	assert.Equal(t, "il_123", stripe.StringValue(params.Lines[0].InvoiceLineItem))
+	assert.Equal(t, "invoice_line_item", stripe.StringValue(params.Lines[0].Type))
+	assert.Equal(t, "product_unsatisfactory", stripe.StringValue(params.Reason))
+	assert.Equal(t, int64(1500), stripe.Int64Value(params.RefundAmount))
+	assert.Equal(t, "refund-in_123-0", stripe.StringValue(params.IdempotencyKey))
+}
+
+func TestPlanRefund_Policy(t *testing.T) {
+	// What can't be refunded to the charge is credited to the balance
+	backend := &refundBackend{invoice: paidInvoice(4000), total: 1500}
+	c := Client{B: backend, Key: "sk_test_123"}
+	plan, err := c.PlanRefund("in_123", &RefundRequest{Amount: 1500})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1000), plan.RefundAmount)
+	assert.Equal(t, int64(500), plan.CreditAmount)
+	assert.Equal(t, "refund-in_123-4000", plan.IdempotencyKey)
+
+	plan, err = c.PlanRefund("in_123", &RefundRequest{Amount: 1500, Policy: &RefundPolicy{MinimumRefund: 2000}})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), plan.RefundAmount)
+	assert.Equal(t, int64(1500), plan.CreditAmount)
+
+	backend.invoice = paidInvoice(0)
+	plan, err = c.PlanRefund("in_123", &RefundRequest{
+		Amount: 1500,
+		Policy: &RefundPolicy{Method: RefundMethodCustomerBalance},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), plan.RefundAmount)
+	assert.Equal(t, int64(1500), plan.CreditAmount)
+
+	// Credit notes on open invoices reduce the amount due
+	backend.invoice = &stripe.Invoice{ID: "in_123", Status: stripe.InvoiceStatusOpen}
+	plan, err = c.PlanRefund("in_123", &RefundRequest{Amount: 1500})
+	assert.NoError(t, err)
+	params := plan.Params()
+	assert.Nil(t, params.RefundAmount)
+	assert.Nil(t, params.CreditAmount)
+}
+
+func TestPlanRefund_Invalid(t *testing.T) {
+	backend := &refundBackend{invoice: paidInvoice(0), total: 1500}
+	c := Client{B: backend, Key: "sk_test_123"}
+
+	requests := []*RefundRequest{
+		{},
+		{Amount: -100},
+		{Amount: 100, Lines: []*RefundLine{{InvoiceLineItem: "il_123", Quantity: 1}}},
+		{Lines: []*RefundLine{{InvoiceLineItem: "il_123"}}},
+	}
+	for _, request := range requests {
+		_, err := c.PlanRefund("in_123", request)
+		assert.Error(t, err)
+	}
+
+	_, err := c.PlanRefund("in_123", &RefundRequest{Lines: []*RefundLine{{Quantity: 1}}})
+	assert.Equal(t, "lines[0][invoice_line_item]", err.(*stripe.Error).Param)
+	assert.IsType(t, &stripe.InvalidRequestError{}, err.(*stripe.Error).Err)
+
+	backend.invoice = &stripe.Invoice{ID: "in_123", Status: stripe.InvoiceStatusVoid}
+	_, err = c.PlanRefund("in_123", &RefundRequest{Amount: 100})
+	assert.Error(t, err)
+}
+
+func TestExecuteRefund(t *testing.T) {
+	backend := &refundBackend{invoice: paidInvoice(4000), total: 1500}
+	c := Client{B: backend, Key: "sk_test_123"}
+
+	plan, err := c.PlanRefund("in_123", &RefundRequest{Amount: 1500})
+	assert.NoError(t, err)
+
+	audit, err := c.ExecuteRefund(plan)
+	assert.NoError(t, err)
+	assert.Equal(t, &RefundAudit{
+		Created:        1500000000,
+		CreditAmount:   500,
+		CreditNote:     "cn_123",
+		Currency:       stripe.CurrencyUSD,
+		Customer:       "cus_123",
+		IdempotencyKey: "refund-in_123-4000",
+		Invoice:        "in_123",
+		Lines:          plan.Lines,
+		Refund:         "re_123",
+		RefundAmount:   1000,
+		Total:          1500,
+	}, audit)
+
+	assert.Equal(t, 1, len(backend.created))
+	assert.Equal(t, int64(1000), stripe.Int64Value(backend.created[0].RefundAmount))
+	assert.Equal(t, int64(500), stripe.Int64Value(backend.created[0].CreditAmount))
+
+	// Failures are recorded too
+	backend.newErr = &stripe.Error{Msg: "refund failed", Type: stripe.ErrorTypeInvalidRequest}
+	audit, err = c.ExecuteRefund(plan)
+	assert.Error(t, err)
+	assert.Equal(t, "", audit.CreditNote)
+	assert.Equal(t, err.Error(), audit.Error)
+}
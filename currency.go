@@ -20,6 +20,7 @@ const (
 	CurrencyBBD Currency = "bbd" // Barbadian Dollar
 	CurrencyBDT Currency = "bdt" // Bangladeshi Taka
 	CurrencyBGN Currency = "bgn" // Bulgarian Lev
+	CurrencyBHD Currency = "bhd" // Bahraini Dinar
 	CurrencyBIF Currency = "bif" // Burundian Franc
 	CurrencyBMD Currency = "bmd" // Bermudian Dollar
 	CurrencyBND Currency = "bnd" // Brunei Dollar
@@ -64,12 +65,14 @@ const (
 	CurrencyINR Currency = "inr" // Indian Rupee
 	CurrencyISK Currency = "isk" // Icelandic Króna
 	CurrencyJMD Currency = "jmd" // Jamaican Dollar
+	CurrencyJOD Currency = "jod" // Jordanian Dinar
 	CurrencyJPY Currency = "jpy" // Japanese Yen
 	CurrencyKES Currency = "kes" // Kenyan Shilling
 	CurrencyKGS Currency = "kgs" // Kyrgyzstani Som
 	CurrencyKHR Currency = "khr" // Cambodian Riel
 	CurrencyKMF Currency = "kmf" // Comorian Franc
 	CurrencyKRW Currency = "krw" // South Korean Won
+	CurrencyKWD Currency = "kwd" // Kuwaiti Dinar
 	CurrencyKYD Currency = "kyd" // Cayman Islands Dollar
 	CurrencyKZT Currency = "kzt" // Kazakhstani Tenge
 	CurrencyLAK Currency = "lak" // Lao Kip
@@ -98,6 +101,7 @@ const (
 	CurrencyNOK Currency = "nok" // Norwegian Krone
 	CurrencyNPR Currency = "npr" // Nepalese Rupee
 	CurrencyNZD Currency = "nzd" // New Zealand Dollar
+	CurrencyOMR Currency = "omr" // Omani Rial
 	CurrencyPAB Currency = "pab" // Panamanian Balboa
 	CurrencyPEN Currency = "pen" // Peruvian Nuevo Sol
 	CurrencyPGK Currency = "pgk" // Papua New Guinean Kina
@@ -124,6 +128,7 @@ const (
 	CurrencySZL Currency = "szl" // Swazi Lilangeni
 	CurrencyTHB Currency = "thb" // Thai Baht
 	CurrencyTJS Currency = "tjs" // Tajikistani Somoni
+	CurrencyTND Currency = "tnd" // Tunisian Dinar
 	CurrencyTOP Currency = "top" // Tongan Paʻanga
 	CurrencyTRY Currency = "try" // Turkish Lira
 	CurrencyTTD Currency = "ttd" // Trinidad and Tobago Dollar
@@ -114,6 +114,9 @@ type Customer struct {
 	Subscriptions    *SubscriptionList        `json:"subscriptions"`
 	TaxExempt        CustomerTaxExempt        `json:"tax_exempt"`
 	TaxIDs           *TaxIDList               `json:"tax_ids"`
+
+	Extra    map[string]json.RawMessage `json:"-"`
+	expanded bool
 }
 
 // CustomerInvoiceCustomField represents a custom field associated with the customer's invoices.
@@ -159,5 +162,6 @@ func (c *Customer) UnmarshalJSON(data []byte) error {
 	}
 
 	*c = Customer(v)
+	c.expanded = true
 	return nil
 }
@@ -52,6 +52,9 @@ type CustomerBalanceTransaction struct {
 	Metadata      map[string]string              `json:"metadata"`
 	Object        string                         `json:"object"`
 	Type          CustomerBalanceTransactionType `json:"type"`
+
+	Extra    map[string]json.RawMessage `json:"-"`
+	expanded bool
 }
 
 // CustomerBalanceTransactionList is a list of customer balance transactions as retrieved from a
@@ -77,5 +80,6 @@ func (c *CustomerBalanceTransaction) UnmarshalJSON(data []byte) error {
 	}
 
 	*c = CustomerBalanceTransaction(v)
+	c.expanded = true
 	return nil
 }
@@ -101,6 +101,9 @@ type Dispute struct {
 	PaymentIntent       *PaymentIntent        `json:"payment_intent"`
 	Reason              DisputeReason         `json:"reason"`
 	Status              DisputeStatus         `json:"status"`
+
+	Extra    map[string]json.RawMessage `json:"-"`
+	expanded bool
 }
 
 // DisputeList is a list of disputes as retrieved from a list endpoint.
@@ -168,5 +171,6 @@ func (d *Dispute) UnmarshalJSON(data []byte) error {
 	}
 
 	*d = Dispute(v)
+	d.expanded = true
 	return nil
 }
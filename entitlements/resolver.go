@@ -0,0 +1,469 @@
+// Package entitlements resolves the features customers are entitled to from
+// the metadata of the products they're subscribed to, and keeps them in a
+// store that can be queried without making requests.
+package entitlements
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	stripe "github.com/stripe/stripe-go"
+	"github.com/stripe/stripe-go/product"
+	"github.com/stripe/stripe-go/sub"
+)
+
+//
+// Public constants
+//
+
+// DefaultMetadataKey is the product metadata key listing the features of a
+// product unless configured otherwise.
+const DefaultMetadataKey = "entitlements"
+
+//
+// Public types
+//
+
+// CustomerEntitlements are the grants of a customer's subscriptions.
+type CustomerEntitlements struct {
+	// Customer is the ID of the customer.
+	Customer string `json:"customer"`
+
+	// Grants are the grants of the customer's subscriptions, by subscription
+	// ID. Subscriptions that ended keep a grant without features, so that
+	// events received out of order can be ignored.
+	Grants map[string]*Grant `json:"grants"`
+}
+
+// Features returns the features granted to the customer at the given time,
+// sorted.
+func (e *CustomerEntitlements) Features(at time.Time) []string {
+	seen := make(map[string]bool)
+	var features []string
+	for _, grant := range e.Grants {
+		if !grant.Valid(at) {
+			continue
+		}
+		for _, feature := range grant.Features {
+			if !seen[feature] {
+				seen[feature] = true
+				features = append(features, feature)
+			}
+		}
+	}
+	sort.Strings(features)
+	return features
+}
+
+// Grant is the features a subscription grants, and until when.
+type Grant struct {
+	// Features are the features of the subscription's products.
+	Features []string `json:"features"`
+
+	// Status is the status of the subscription.
+	Status stripe.SubscriptionStatus `json:"status"`
+
+	// Subscription is the ID of the subscription.
+	Subscription string `json:"subscription"`
+
+	// Until is the Unix time at which the grant ends, like the end of the
+	// current period of a subscription canceled at period end, or zero if
+	// it lasts until the subscription changes.
+	Until int64 `json:"until"`
+
+	// UpdatedAt is the creation time of the event the grant was computed
+	// from, or the time it was refreshed from the subscription.
+	UpdatedAt int64 `json:"updated_at"`
+}
+
+// Valid returns whether the grant applies at the given time.
+func (g *Grant) Valid(at time.Time) bool {
+	return len(g.Features) > 0 && (g.Until == 0 || at.Unix() < g.Until)
+}
+
+// MemoryStore is a Store keeping entitlements in memory.
+type MemoryStore struct {
+	mu           sync.Mutex
+	entitlements map[string][]byte
+}
+
+// Get returns the entitlements of a customer, or nil if there are none.
+func (s *MemoryStore) Get(customer string) (*CustomerEntitlements, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Entitlements are kept encoded so that callers can't modify them
+	data, ok := s.entitlements[customer]
+	if !ok {
+		return nil, nil
+	}
+	entitlements := &CustomerEntitlements{}
+	if err := json.Unmarshal(data, entitlements); err != nil {
+		return nil, err
+	}
+	return entitlements, nil
+}
+
+// Put stores the entitlements of a customer.
+func (s *MemoryStore) Put(entitlements *CustomerEntitlements) error {
+	data, err := json.Marshal(entitlements)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entitlements[entitlements.Customer] = data
+	return nil
+}
+
+// ProductSource retrieves products by ID. A *catalog.Cache can be used to
+// avoid requests.
+type ProductSource interface {
+	Product(id string) (*stripe.Product, error)
+}
+
+// Resolver resolves the features customers are entitled to from the
+// metadata of the products of their subscriptions. Each product lists its
+// features under the configured metadata key, separated by commas, like
+// "exports,api_access".
+//
+// The grants of customers are computed from their subscriptions by Refresh,
+// and kept current by the customer.subscription.* events passed to
+// HandleEvent. Features and Entitled then query the store without making
+// requests.
+//
+// Subscriptions grant their features when they're active or trialing, and
+// until the end of their current period when they're canceled at period
+// end. Past due subscriptions keep their features for the configured grace
+// period. Unpaid, incomplete, paused and ended subscriptions grant none.
+// Subscriptions whose payment collection is paused keep their features,
+// unless RevokeWhenCollectionPaused is set.
+type Resolver struct {
+	config ResolverConfig
+
+	// mu serializes updates to the store, which read and then write the
+	// entitlements of a customer.
+	mu sync.Mutex
+}
+
+// ResolverConfig is used to configure a new Resolver.
+type ResolverConfig struct {
+	// Backend is the backend used to list subscriptions and retrieve
+	// products.
+	//
+	// If left unset, the API backend returned by stripe.GetBackend is used.
+	Backend stripe.Backend
+
+	// Clock is used to tell whether grants still apply.
+	//
+	// If left unset, stripe.DefaultClock is used.
+	Clock stripe.Clock
+
+	// GracePeriod is how long past due subscriptions keep their features
+	// after the start of the period whose invoice wasn't paid.
+	//
+	// If left unset, past due subscriptions keep their features until Stripe
+	// stops retrying their invoice and cancels them or marks them unpaid.
+	GracePeriod time.Duration
+
+	// Key is the API key used to make requests.
+	//
+	// If left unset, stripe.Key is used.
+	Key string
+
+	// MetadataKey is the product metadata key listing the features of a
+	// product.
+	//
+	// Defaults to DefaultMetadataKey.
+	MetadataKey string
+
+	// Products is where products are retrieved from, since subscription
+	// events don't include them.
+	//
+	// If left unset, products are retrieved from the API every time.
+	Products ProductSource
+
+	// RevokeWhenCollectionPaused makes subscriptions whose payment collection
+	// is paused grant no features, for businesses which pause collection to
+	// suspend service rather than to offer it for free.
+	//
+	// This version of the API only returns pause_collection as an unknown
+	// field, so subscriptions listed by Refresh only have it when Backend
+	// has KeepUnknownFields set on its BackendConfig. Events always do.
+	//
+	// If left unset, paused collection doesn't affect features.
+	RevokeWhenCollectionPaused bool
+
+	// Store is where entitlements are kept. It's meant to be shared with
+	// the code checking entitlements.
+	//
+	// If left unset, a MemoryStore is used.
+	Store Store
+}
+
+// Store keeps the entitlements of customers. Implementations must be safe
+// for concurrent use.
+type Store interface {
+	// Get returns the entitlements of a customer, or nil if there are none.
+	Get(customer string) (*CustomerEntitlements, error)
+
+	// Put stores the entitlements of a customer, replacing the previous
+	// ones.
+	Put(entitlements *CustomerEntitlements) error
+}
+
+//
+// Public functions
+//
+
+// NewMemoryStore returns a new empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{entitlements: make(map[string][]byte)}
+}
+
+// NewResolver returns a new resolver with the given configuration.
+func NewResolver(config *ResolverConfig) *Resolver {
+	r := &Resolver{}
+	if config != nil {
+		r.config = *config
+	}
+	if r.config.Backend == nil {
+		r.config.Backend = stripe.GetBackend(stripe.APIBackend)
+	}
+	if r.config.Clock == nil {
+		r.config.Clock = stripe.DefaultClock
+	}
+	if r.config.Key == "" {
+		r.config.Key = stripe.Key
+	}
+	if r.config.MetadataKey == "" {
+		r.config.MetadataKey = DefaultMetadataKey
+	}
+	if r.config.Products == nil {
+		r.config.Products = productClient{product.Client{B: r.config.Backend, Key: r.config.Key}}
+	}
+	if r.config.Store == nil {
+		r.config.Store = NewMemoryStore()
+	}
+	return r
+}
+
+// Entitled returns whether a customer is entitled to a feature now.
+func (r *Resolver) Entitled(customer, feature string) (bool, error) {
+	features, err := r.Features(customer)
+	if err != nil {
+		return false, err
+	}
+	for _, f := range features {
+		if f == feature {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Features returns the features a customer is entitled to now, sorted.
+func (r *Resolver) Features(customer string) ([]string, error) {
+	entitlements, err := r.config.Store.Get(customer)
+	if err != nil || entitlements == nil {
+		return nil, err
+	}
+	return entitlements.Features(r.config.Clock.Now()), nil
+}
+
+// HandleEvent applies a customer.subscription.* event to the entitlements
+// of the subscription's customer. Other events are ignored, and so are
+// events older than the last one applied to the same subscription.
+func (r *Resolver) HandleEvent(event *stripe.Event) error {
+	if !strings.HasPrefix(event.Type, "customer.subscription.") || event.Data == nil {
+		return nil
+	}
+
+	// Paused collection is only available as an unknown field, which
+	// stripe.Unmarshal keeps
+	subscription := &stripe.Subscription{}
+	if err := stripe.Unmarshal(event.Data.Raw, subscription); err != nil {
+		return err
+	}
+	if subscription.Customer == nil {
+		return fmt.Errorf("subscription %s has no customer", subscription.ID)
+	}
+	if event.Type == "customer.subscription.deleted" {
+		subscription.Status = stripe.SubscriptionStatusCanceled
+	}
+
+	grant, err := r.newGrant(subscription, event.Created)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entitlements, err := r.get(subscription.Customer.ID)
+	if err != nil {
+		return err
+	}
+	if previous, ok := entitlements.Grants[subscription.ID]; ok && event.Created < previous.UpdatedAt {
+		return nil
+	}
+	entitlements.Grants[subscription.ID] = grant
+	return r.config.Store.Put(entitlements)
+}
+
+// Refresh recomputes the entitlements of a customer from all of its
+// subscriptions, which is meant to be done when a customer is first seen or
+// when events may have been missed. Grants from events created after the
+// refresh started, which HandleEvent may apply while subscriptions are being
+// listed, are kept.
+func (r *Resolver) Refresh(customer string) error {
+	now := r.config.Clock.Now().Unix()
+	entitlements := &CustomerEntitlements{Customer: customer, Grants: make(map[string]*Grant)}
+
+	params := &stripe.SubscriptionListParams{
+		Customer: customer,
+		Status:   string(stripe.SubscriptionStatusAll),
+	}
+	i := sub.Client{B: r.config.Backend, Key: r.config.Key}.List(params)
+	for i.Next() {
+		grant, err := r.newGrant(i.Subscription(), now)
+		if err != nil {
+			return err
+		}
+		entitlements.Grants[grant.Subscription] = grant
+	}
+	if err := i.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, err := r.get(customer)
+	if err != nil {
+		return err
+	}
+	for id, grant := range existing.Grants {
+		if grant.UpdatedAt > now {
+			entitlements.Grants[id] = grant
+		}
+	}
+	return r.config.Store.Put(entitlements)
+}
+
+//
+// Private types
+//
+
+// productClient is a ProductSource retrieving products from the API.
+type productClient struct {
+	client product.Client
+}
+
+func (c productClient) Product(id string) (*stripe.Product, error) {
+	return c.client.Get(id, nil)
+}
+
+//
+// Private functions
+//
+
+// features returns the features listed in the metadata of a product.
+func (r *Resolver) features(p *stripe.Product) []string {
+	var features []string
+	for _, feature := range strings.Split(p.Metadata[r.config.MetadataKey], ",") {
+		if feature = strings.TrimSpace(feature); feature != "" {
+			features = append(features, feature)
+		}
+	}
+	return features
+}
+
+// get returns the entitlements of a customer from the store, or empty ones.
+func (r *Resolver) get(customer string) (*CustomerEntitlements, error) {
+	entitlements, err := r.config.Store.Get(customer)
+	if err != nil {
+		return nil, err
+	}
+	if entitlements == nil {
+		entitlements = &CustomerEntitlements{Customer: customer}
+	}
+	if entitlements.Grants == nil {
+		entitlements.Grants = make(map[string]*Grant)
+	}
+	return entitlements, nil
+}
+
+// newGrant computes the grant of a subscription.
+func (r *Resolver) newGrant(s *stripe.Subscription, updatedAt int64) (*Grant, error) {
+	grant := &Grant{Status: s.Status, Subscription: s.ID, UpdatedAt: updatedAt}
+
+	switch s.Status {
+	case stripe.SubscriptionStatusActive, stripe.SubscriptionStatusTrialing:
+	case stripe.SubscriptionStatusPastDue:
+		if r.config.GracePeriod > 0 {
+			grant.Until = s.CurrentPeriodStart + int64(r.config.GracePeriod/time.Second)
+		}
+	default:
+		return grant, nil
+	}
+	if r.config.RevokeWhenCollectionPaused && collectionPaused(s) {
+		return grant, nil
+	}
+
+	if s.CancelAtPeriodEnd && (grant.Until == 0 || s.CurrentPeriodEnd < grant.Until) {
+		grant.Until = s.CurrentPeriodEnd
+	}
+	if s.CancelAt != 0 && (grant.Until == 0 || s.CancelAt < grant.Until) {
+		grant.Until = s.CancelAt
+	}
+
+	seen := make(map[string]bool)
+	for _, p := range subscriptionPlans(s) {
+		if p.Product == nil || seen[p.Product.ID] {
+			continue
+		}
+		seen[p.Product.ID] = true
+
+		// Products are only expanded when they have metadata
+		prod := p.Product
+		if prod.Metadata == nil {
+			var err error
+			if prod, err = r.config.Products.Product(p.Product.ID); err != nil {
+				return nil, err
+			}
+		}
+		grant.Features = append(grant.Features, r.features(prod)...)
+	}
+	sort.Strings(grant.Features)
+	return grant, nil
+}
+
+// collectionPaused returns whether the collection of a subscription is
+// paused, which this version of the API only returns as an unknown field.
+func collectionPaused(s *stripe.Subscription) bool {
+	raw, ok := s.Extra["pause_collection"]
+	return ok && string(raw) != "null"
+}
+
+// subscriptionPlans returns the plans of a subscription's items, or its
+// plan for subscriptions without items.
+func subscriptionPlans(s *stripe.Subscription) []*stripe.Plan {
+	var plans []*stripe.Plan
+	if s.Items != nil {
+		for _, item := range s.Items.Data {
+			if item.Plan != nil {
+				plans = append(plans, item.Plan)
+			}
+		}
+	}
+	if len(plans) == 0 && s.Plan != nil {
+		plans = append(plans, s.Plan)
+	}
+	return plans
+}
@@ -0,0 +1,241 @@
+package entitlements
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	assert "github.com/stretchr/testify/require"
+	stripe "github.com/stripe/stripe-go"
+	"github.com/stripe/stripe-go/form"
+	stripetesting "github.com/stripe/stripe-go/testing"
+)
+
+// subscriptionBackend is a Backend serving products and the subscriptions
+// of customers, which records the requests made through it other than lists.
+type subscriptionBackend struct {
+	stripetesting.Backend
+
+	products      map[string]*stripe.Product
+	subscriptions []*stripe.Subscription
+
+	// listing is called when subscriptions are listed, if set.
+	listing func()
+
+	calls []string
+}
+
+func (b *subscriptionBackend) Call(method, path, key string, params stripe.ParamsContainer, v interface{}) error {
+	b.calls = append(b.calls, method+" "+path)
+	if p, ok := v.(*stripe.Product); ok && method == http.MethodGet {
+		*p = *b.products[path[strings.LastIndex(path, "/")+1:]]
+	}
+	return nil
+}
+
+func (b *subscriptionBackend) CallRaw(method, path, key string, body *form.Values, params *stripe.Params, v interface{}) error {
+	if list, ok := v.(*stripe.SubscriptionList); ok {
+		if b.listing != nil {
+			b.listing()
+		}
+		customer := body.Get("customer")
+		for _, s := range b.subscriptions {
+			if len(customer) > 0 && s.Customer.ID == customer[0] {
+				list.Data = append(list.Data, s)
+			}
+		}
+	}
+	return nil
+}
+
+func newBackend() *subscriptionBackend {
+	return &subscriptionBackend{products: map[string]*stripe.Product{
+		"prod_basic": {ID: "prod_basic", Metadata: map[string]string{"entitlements": "exports"}},
+		"prod_pro":   {ID: "prod_pro", Metadata: map[string]string{"entitlements": "exports, api_access"}},
+		"prod_seats": {ID: "prod_seats", Metadata: map[string]string{}},
+	}}
+}
+
+func newSubscription(id string, status stripe.SubscriptionStatus, products ...string) *stripe.Subscription {
+	s := &stripe.Subscription{
+		CurrentPeriodEnd:   1502592000,
+		CurrentPeriodStart: 1500000000,
+		Customer:           &stripe.Customer{ID: "cus_123"},
+		ID:                 id,
+		Items:              &stripe.SubscriptionItemList{},
+		Status:             status,
+	}
+	for _, p := range products {
+		s.Items.Data = append(s.Items.Data, &stripe.SubscriptionItem{
+			Plan: &stripe.Plan{Product: &stripe.Product{ID: p}},
+		})
+	}
+	return s
+}
+
+func subscriptionEvent(t *testing.T, eventType string, created int64, s *stripe.Subscription) *stripe.Event {
+	raw, err := json.Marshal(s)
+	assert.NoError(t, err)
+	return &stripe.Event{Created: created, Data: &stripe.EventData{Raw: raw}, Type: eventType}
+}
+
+func TestResolver_HandleEvent(t *testing.T) {
+	backend := newBackend()
+	clock := stripetesting.NewClock(time.Unix(1500000000, 0))
+	store := NewMemoryStore()
+	resolver := NewResolver(&ResolverConfig{Backend: backend, Clock: clock, Key: "sk_test_123", Store: store})
+
+	features, err := resolver.Features("cus_123")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(features))
+
+	assert.NoError(t, resolver.HandleEvent(subscriptionEvent(t, "customer.subscription.created", 1500000000,
+		newSubscription("sub_1", stripe.SubscriptionStatusTrialing, "prod_pro", "prod_seats"))))
+	assert.NoError(t, resolver.HandleEvent(subscriptionEvent(t, "customer.subscription.created", 1500000000,
+		newSubscription("sub_2", stripe.SubscriptionStatusIncomplete, "prod_basic"))))
+	features, err = resolver.Features("cus_123")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"api_access", "exports"}, features)
+	assert.Equal(t, []string{"GET /v1/products/prod_pro", "GET /v1/products/prod_seats"}, backend.calls)
+
+	// Events older than the last one applied to the subscription are ignored
+	assert.NoError(t, resolver.HandleEvent(subscriptionEvent(t, "customer.subscription.deleted", 1500000100,
+		newSubscription("sub_1", stripe.SubscriptionStatusActive, "prod_pro"))))
+	assert.NoError(t, resolver.HandleEvent(subscriptionEvent(t, "customer.subscription.updated", 1500000050,
+		newSubscription("sub_1", stripe.SubscriptionStatusActive, "prod_pro"))))
+	assert.NoError(t, resolver.HandleEvent(subscriptionEvent(t, "customer.subscription.updated", 1500000200,
+		newSubscription("sub_2", stripe.SubscriptionStatusActive, "prod_basic"))))
+	features, err = resolver.Features("cus_123")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"exports"}, features)
+
+	entitlements, err := store.Get("cus_123")
+	assert.NoError(t, err)
+	assert.Equal(t, stripe.SubscriptionStatusCanceled, entitlements.Grants["sub_1"].Status)
+	assert.Equal(t, int64(1500000200), entitlements.Grants["sub_2"].UpdatedAt)
+
+	entitled, err := resolver.Entitled("cus_123", "api_access")
+	assert.NoError(t, err)
+	assert.False(t, entitled)
+
+	assert.NoError(t, resolver.HandleEvent(&stripe.Event{Type: "invoice.paid"}))
+}
+
+func TestResolver_States(t *testing.T) {
+	backend := newBackend()
+	clock := stripetesting.NewClock(time.Unix(1500000000, 0))
+	resolver := NewResolver(&ResolverConfig{
+		Backend:     backend,
+		Clock:       clock,
+		GracePeriod: 3 * 24 * time.Hour,
+		Key:         "sk_test_123",
+	})
+
+	canceled := newSubscription("sub_1", stripe.SubscriptionStatusActive, "prod_basic")
+	canceled.CancelAtPeriodEnd = true
+	pastDue := newSubscription("sub_2", stripe.SubscriptionStatusPastDue, "prod_pro")
+	paused := newSubscription("sub_3", "paused", "prod_pro")
+	paused.Customer = &stripe.Customer{ID: "cus_456"}
+	unpaid := newSubscription("sub_4", stripe.SubscriptionStatusUnpaid, "prod_pro")
+	unpaid.Customer = &stripe.Customer{ID: "cus_456"}
+	for _, s := range []*stripe.Subscription{canceled, pastDue, paused, unpaid} {
+		assert.NoError(t, resolver.HandleEvent(subscriptionEvent(t, "customer.subscription.updated", 1500000000, s)))
+	}
+
+	features, err := resolver.Features("cus_123")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"api_access", "exports"}, features)
+	features, err = resolver.Features("cus_456")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(features))
+
+	// The grace period of past due subscriptions ends before the period
+	clock.Sleep(3 * 24 * time.Hour)
+	features, err = resolver.Features("cus_123")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"exports"}, features)
+
+	// Subscriptions canceled at period end keep their features until then
+	clock.Set(time.Unix(1502591999, 0))
+	entitled, err := resolver.Entitled("cus_123", "exports")
+	assert.NoError(t, err)
+	assert.True(t, entitled)
+	clock.Sleep(time.Second)
+	entitled, err = resolver.Entitled("cus_123", "exports")
+	assert.NoError(t, err)
+	assert.False(t, entitled)
+}
+
+func TestResolver_CollectionPaused(t *testing.T) {
+	subscription := newSubscription("sub_1", stripe.SubscriptionStatusActive, "prod_basic")
+
+	// Collection being paused is only known from the raw event
+	event := subscriptionEvent(t, "customer.subscription.updated", 1500000100, subscription)
+	event.Data.Raw = bytes.Replace(event.Data.Raw, []byte(`{`),
+		[]byte(`{"pause_collection":{"behavior":"void"},`), 1)
+
+	for _, revoke := range []bool{false, true} {
+		resolver := NewResolver(&ResolverConfig{
+			Backend:                    newBackend(),
+			Clock:                      stripetesting.NewClock(time.Unix(1500000000, 0)),
+			Key:                        "sk_test_123",
+			RevokeWhenCollectionPaused: revoke,
+		})
+		assert.NoError(t, resolver.HandleEvent(event))
+
+		entitled, err := resolver.Entitled("cus_123", "exports")
+		assert.NoError(t, err)
+		assert.Equal(t, !revoke, entitled)
+	}
+}
+
+func TestResolver_Refresh(t *testing.T) {
+	backend := newBackend()
+	backend.subscriptions = []*stripe.Subscription{
+		newSubscription("sub_1", stripe.SubscriptionStatusActive, "prod_basic"),
+		newSubscription("sub_2", stripe.SubscriptionStatusCanceled, "prod_pro"),
+	}
+	clock := stripetesting.NewClock(time.Unix(1500000000, 0))
+	store := NewMemoryStore()
+	resolver := NewResolver(&ResolverConfig{Backend: backend, Clock: clock, Key: "sk_test_123", Store: store})
+
+	assert.NoError(t, resolver.HandleEvent(subscriptionEvent(t, "customer.subscription.created", 1499999000,
+		newSubscription("sub_3", stripe.SubscriptionStatusActive, "prod_pro"))))
+	assert.NoError(t, resolver.Refresh("cus_123"))
+
+	// Subscriptions that don't exist anymore are dropped
+	features, err := resolver.Features("cus_123")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"exports"}, features)
+
+	entitlements, err := store.Get("cus_123")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(entitlements.Grants))
+	assert.Equal(t, int64(1500000000), entitlements.Grants["sub_2"].UpdatedAt)
+
+	// Events from before the refresh are ignored
+	assert.NoError(t, resolver.HandleEvent(subscriptionEvent(t, "customer.subscription.updated", 1499999500,
+		newSubscription("sub_1", stripe.SubscriptionStatusActive, "prod_pro"))))
+	features, err = resolver.Features("cus_123")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"exports"}, features)
+
+	// But events applied while subscriptions are being listed are kept
+	clock.Add(time.Minute)
+	backend.listing = func() {
+		assert.NoError(t, resolver.HandleEvent(subscriptionEvent(t, "customer.subscription.updated", 1500000100,
+			newSubscription("sub_1", stripe.SubscriptionStatusActive, "prod_pro"))))
+	}
+	assert.NoError(t, resolver.Refresh("cus_123"))
+	features, err = resolver.Features("cus_123")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"api_access", "exports"}, features)
+
+	entitlements, err = store.Get("cus_123")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1500000100), entitlements.Grants["sub_1"].UpdatedAt)
+	assert.Equal(t, int64(1500000060), entitlements.Grants["sub_2"].UpdatedAt)
+}
@@ -0,0 +1,170 @@
+// Code generated by scripts/generate_enum_values. DO NOT EDIT.
+
+package stripe
+
+// enumValues are the values of the constants of each of the package's
+// string types, keyed by the name of the type.
+var enumValues = map[string][]string{
+	"AccountBusinessType":                           {"company", "government_entity", "individual", "non_profit"},
+	"AccountCapability":                             {"card_payments", "legacy_payments", "transfers"},
+	"AccountCapabilityStatus":                       {"active", "inactive", "pending"},
+	"AccountCompanyStructure":                       {"government_instrumentality", "governmental_unit", "incorporated_non_profit", "multi_member_llc", "private_corporation", "private_partnership", "public_corporation", "public_partnership", "tax_exempt_government_instrumentality", "unincorporated_association", "unincorporated_non_profit"},
+	"AccountCompanyVerificationDocumentDetailsCode": {"document_corrupt", "document_failed_copy", "document_failed_other", "document_failed_test_mode", "document_fraudulent", "document_invalid", "document_manipulated", "document_not_readable", "document_not_uploaded", "document_too_large"},
+	"AccountLinkCollect":                            {"currently_due", "eventually_due"},
+	"AccountLinkType":                               {"custom_account_update", "custom_account_verification"},
+	"AccountRejectReason":                           {"fraud", "other", "terms_of_service"},
+	"AccountRequirementsDisabledReason":             {"fields_needed", "listed", "other", "rejected.fraud", "rejected.listed", "rejected.other", "rejected.terms_of_service", "under_review"},
+	"AccountType":                                   {"custom", "express", "standard"},
+	"BalanceSourceType":                             {"alipay_account", "bank_account", "bitcoin_receiver", "card", "fpx"},
+	"BalanceTransactionReportingCategory":           {"advance", "advance_funding", "charge", "charge_failure", "connect_collection_transfer", "connect_reserved_funds", "dispute", "dispute_reversal", "fee", "issuing_authorization_hold", "issuing_authorization_release", "issuing_transaction", "other_adjustment", "partial_capture_reversal", "payout", "payout_reversal", "platform_earning", "platform_earning_refund", "refund", "refund_failure", "risk_reserved_funds", "tax", "topup", "topup_reversal", "transfer", "transfer_reversal"},
+	"BalanceTransactionSourceType":                  {"application_fee", "charge", "dispute", "issuing.authorization", "issuing.transaction", "payout", "recipient_transfer", "refund", "reversal", "transfer"},
+	"BalanceTransactionStatus":                      {"available", "pending"},
+	"BalanceTransactionType":                        {"adjustment", "application_fee", "application_fee_refund", "charge", "issuing_authorization_hold", "issuing_authorization_release", "issuing_transaction", "payment", "payment_failure_refund", "payment_refund", "payout", "payout_cancel", "payout_failure", "recipient_transfer", "recipient_transfer_cancel", "recipient_transfer_failure", "refund", "stripe_fee", "transfer", "transfer_refund"},
+	"BankAccountAccountHolderType":                  {"company", "individual"},
+	"BankAccountStatus":                             {"errored", "new", "validated", "verification_failed", "verified"},
+	"CapabilityDisabledReason":                      {"pending.onboarding", "pending.review", "rejected.listed", "rejected.other", "rejected_fraud", "requirement.fields_needed"},
+	"CapabilityStatus":                              {"active", "inactive", "pending", "unrequested"},
+	"CardAvailablePayoutMethod":                     {"Instant", "Standard"},
+	"CardBrand":                                     {"American Express", "Diners Club", "Discover", "JCB", "MasterCard", "UnionPay", "Unknown", "Visa"},
+	"CardFunding":                                   {"credit", "debit", "prepaid", "unknown"},
+	"CardTokenizationMethod":                        {"android_pay", "apple_pay"},
+	"CardVerification":                              {"fail", "pass", "unavailable", "unchecked"},
+	"ChargeFraudStripeReport":                       {"fraudulent"},
+	"ChargeFraudUserReport":                         {"fraudulent", "safe"},
+	"ChargePaymentMethodDetailsType":                {"ach_credit_transfer", "ach_debit", "acss_debit", "alipay", "au_becs_debit", "bancontact", "bitcoin", "card", "card_present", "eps", "fpx", "giropay", "ideal", "klarna", "multibanco", "p24", "sepa_debit", "sofort", "stripe_account", "wechat"},
+	"CheckoutSessionDisplayItemType":                {"custom", "plan", "sku"},
+	"CheckoutSessionMode":                           {"payment", "setup", "subscription"},
+	"CheckoutSessionSubmitType":                     {"auto", "book", "donate", "pay"},
+	"CouponDuration":                                {"forever", "once", "repeating"},
+	"CreditNoteLineItemType":                        {"custom_line_item", "invoice_line_item"},
+	"CreditNoteReason":                              {"duplicate", "fraudulent", "order_change", "product_unsatisfactory"},
+	"CreditNoteStatus":                              {"issued", "void"},
+	"CreditNoteType":                                {"post_payment", "pre_payment"},
+	"Currency":                                      {"aed", "afn", "all", "amd", "ang", "aoa", "ars", "aud", "awg", "azn", "bam", "bbd", "bdt", "bgn", "bhd", "bif", "bmd", "bnd", "bob", "brl", "bsd", "bwp", "bzd", "cad", "cdf", "chf", "clp", "cny", "cop", "crc", "cve", "czk", "djf", "dkk", "dop", "dzd", "eek", "egp", "etb", "eur", "fjd", "fkp", "gbp", "gel", "gip", "gmd", "gnf", "gtq", "gyd", "hkd", "hnl", "hrk", "htg", "huf", "idr", "ils", "inr", "isk", "jmd", "jod", "jpy", "kes", "kgs", "khr", "kmf", "krw", "kwd", "kyd", "kzt", "lak", "lbp", "lkr", "lrd", "lsl", "ltl", "lvl", "mad", "mdl", "mga", "mkd", "mnt", "mop", "mro", "mur", "mvr", "mwk", "mxn", "myr", "mzn", "nad", "ngn", "nio", "nok", "npr", "nzd", "omr", "pab", "pen", "pgk", "php", "pkr", "pln", "pyg", "qar", "ron", "rsd", "rub", "rwf", "sar", "sbd", "scr", "sek", "sgd", "shp", "sll", "sos", "srd", "std", "svc", "szl", "thb", "tjs", "tnd", "top", "try", "ttd", "twd", "tzs", "uah", "ugx", "usd", "uyu", "uzs", "vef", "vnd", "vuv", "wst", "xaf", "xcd", "xof", "xpf", "yer", "zar", "zmw"},
+	"CustomerBalanceTransactionType":                {"adjustment", "applied_to_invoice", "credit_note", "initial", "invoice_too_large", "invoice_too_small", "unspent_receiver_credit"},
+	"CustomerTaxExempt":                             {"exempt", "none", "reverse"},
+	"DeclineCode":                                   {"approve_with_id", "authentication_required", "call_issuer", "card_not_supported", "card_velocity_exceeded", "currency_not_supported", "do_not_honor", "do_not_try_again", "duplicate_transaction", "expired_card", "fraudulent", "generic_decline", "incorrect_cvc", "incorrect_number", "incorrect_pin", "incorrect_zip", "insufficient_funds", "invalid_account", "invalid_amount", "invalid_cvc", "invalid_expiry_year", "invalid_number", "invalid_pin", "issuer_not_available", "lost_card", "merchant_blacklist", "new_account_information_available", "no_action_taken", "not_permitted", "pickup_card", "pin_try_exceeded", "processing_error", "reenter_transaction", "restricted_card", "revocation_of_all_authorizations", "revocation_of_authorization", "security_violation", "service_not_allowed", "stolen_card", "stop_payment_order", "testmode_decline", "transaction_not_allowed", "try_again_later", "withdrawal_count_limit_exceeded"},
+	"DisputeReason":                                 {"credit_not_processed", "duplicate", "fraudulent", "general", "product_not_received", "product_unacceptable", "subscription_canceled", "unrecognized"},
+	"DisputeStatus":                                 {"charge_refunded", "lost", "needs_response", "under_review", "warning_closed", "warning_needs_response", "warning_under_review", "won"},
+	"ErrorCode":                                     {"account_already_exists", "account_country_invalid_address", "account_invalid", "account_number_invalid", "alipay_upgrade_required", "amount_too_large", "amount_too_small", "api_key_expired", "authentication_required", "balance_insufficient", "bank_account_exists", "bank_account_unusable", "bank_account_unverified", "bitcoin_upgrade_required", "card_declined", "charge_already_captured", "charge_already_refunded", "charge_disputed", "charge_exceeds_source_limit", "charge_expired_for_capture", "country_unsupported", "coupon_expired", "customer_max_subscriptions", "email_invalid", "expired_card", "idempotency_key_in_use", "incorrect_address", "incorrect_cvc", "incorrect_number", "incorrect_zip", "instant_payouts_unsupported", "invalid_card_type", "invalid_charge_amount", "invalid_cvc", "invalid_expiry_month", "invalid_expiry_year", "invalid_number", "invalid_source_usage", "invalid_swipe_data", "invoice_no_customer_line_items", "invoice_no_subscription_line_items", "invoice_not_editable", "invoice_upcoming_none", "livemode_mismatch", "lock_timeout", "missing", "not_allowed_on_standard_account", "order_creation_failed", "order_required_settings", "order_status_invalid", "order_upstream_timeout", "out_of_inventory", "parameter_invalid_empty", "parameter_invalid_integer", "parameter_invalid_string_blank", "parameter_invalid_string_empty", "parameter_missing", "parameter_unknown", "parameters_exclusive", "payment_intent_authentication_failure", "payment_intent_incompatible_payment_method", "payment_intent_invalid_parameter", "payment_intent_payment_attempt_failed", "payment_intent_unexpected_state", "payment_method_unactivated", "payment_method_unexpected_state", "payouts_not_allowed", "platform_api_key_expired", "postal_code_invalid", "processing_error", "product_inactive", "rate_limit", "resource_already_exists", "resource_missing", "routing_number_invalid", "secret_key_required", "sepa_unsupported_account", "setup_attempt_failed", "setup_intent_authentication_failure", "setup_intent_unexpected_state", "shipping_calculation_failed", "sku_inactive", "state_unsupported", "tax_id_invalid", "taxes_calculation_failed", "testmode_charges_only", "tls_version_unsupported", "token_already_used", "token_in_use", "transfers_not_allowed", "upstream_order_creation_failed", "url_invalid"},
+	"ErrorType":                                     {"api_connection_error", "api_error", "authentication_error", "card_error", "invalid_request_error", "more_permissions_required", "rate_limit_error"},
+	"ExternalAccountType":                           {"bank_account", "card"},
+	"FilePurpose":                                   {"additional_verification", "business_icon", "business_logo", "customer_signature", "dispute_evidence", "finance_report_run", "founders_stock_document", "identity_document", "pci_document", "sigma_scheduled_query", "tax_document_user_upload"},
+	"IdentityVerificationStatus":                    {"pending", "unverified", "verified"},
+	"InvoiceBillingReason":                          {"manual", "subscription", "subscription_create", "subscription_cycle", "subscription_threshold", "subscription_update", "upcoming"},
+	"InvoiceCollectionMethod":                       {"charge_automatically", "send_invoice"},
+	"InvoiceLineType":                               {"invoiceitem", "subscription"},
+	"InvoiceStatus":                                 {"draft", "open", "paid", "uncollectible", "void"},
+	"IssuingAuthorizationAuthorizationMethod":       {"chip", "contactless", "keyed_in", "online", "swipe"},
+	"IssuingAuthorizationRequestHistoryReason":      {"authorization_controls", "card_active", "card_inactive", "insufficient_funds", "webhook_approved", "webhook_declined", "webhook_timeout"},
+	"IssuingAuthorizationRequestHistoryViolatedAuthorizationControlEntity": {"account", "card", "cardholder"},
+	"IssuingAuthorizationRequestHistoryViolatedAuthorizationControlName":   {"allowed_categories", "blocked_categories", "max_amount", "max_approvals", "spending_limits"},
+	"IssuingAuthorizationStatus":                                           {"closed", "pending", "reversed"},
+	"IssuingAuthorizationVerificationDataAuthentication":                   {"exempt", "failure", "none", "success"},
+	"IssuingAuthorizationVerificationDataCheck":                            {"match", "mismatch", "not_provided"},
+	"IssuingAuthorizationWalletProviderType":                               {"apple_pay", "google_pay", "samsung_pay"},
+	"IssuingCardPINStatus":                                                 {"active", "blocked"},
+	"IssuingCardReplacementReason":                                         {"damage", "expiration", "loss", "theft"},
+	"IssuingCardShippingSpeed":                                             {"express", "overnight", "standard"},
+	"IssuingCardShippingStatus":                                            {"delivered", "failure", "pending", "returned", "shipped"},
+	"IssuingCardShippingType":                                              {"bulk", "individual"},
+	"IssuingCardStatus":                                                    {"active", "canceled", "inactive", "pending"},
+	"IssuingCardType":                                                      {"physical", "virtual"},
+	"IssuingCardholderRequirementsDisabledReason":                          {"listed", "rejected.listed", "under_review"},
+	"IssuingCardholderStatus":                                              {"active", "inactive", "pending"},
+	"IssuingCardholderType":                                                {"business_entity", "individual"},
+	"IssuingDisputeReason":                                                 {"fraudulent", "other"},
+	"IssuingDisputeStatus":                                                 {"lost", "under_review", "unsubmitted", "won"},
+	"IssuingSpendingLimitInterval":                                         {"all_time", "daily", "monthly", "per_authorization", "weekly", "yearly"},
+	"IssuingTransactionType":                                               {"capture", "cash_withdrawal", "refund", "refund_reversal"},
+	"KeyType":                                                              {"publishable", "restricted", "secret"},
+	"MandateCustomerAcceptanceType":                                        {"offline", "online"},
+	"MandateStatus":                                                        {"active", "inactive", "pending"},
+	"MandateType":                                                          {"multi_use", "single_use"},
+	"OAuthGrantType":                                                       {"authorization_code", "refresh_token"},
+	"OAuthScopeType":                                                       {"read_only", "read_write"},
+	"OAuthStripeUserBusinessType":                                          {"corporation", "llc", "non_profit", "partnership", "sole_prop"},
+	"OAuthStripeUserGender":                                                {"female", "male"},
+	"OAuthTokenType":                                                       {"bearer"},
+	"OrderDeliveryEstimateType":                                            {"exact", "range"},
+	"OrderItemParentType":                                                  {"coupon", "discount", "shipping", "sku", "tax"},
+	"OrderItemType":                                                        {"coupon", "discount", "shipping", "sku", "tax"},
+	"OrderStatus":                                                          {"canceled", "created", "fulfilled", "paid", "returned"},
+	"PaymentIntentCancellationReason":                                      {"abandoned", "automatic", "duplicate", "failed_invoice", "fraudulent", "requested_by_customer", "void_invoice"},
+	"PaymentIntentCaptureMethod":                                           {"automatic", "manual"},
+	"PaymentIntentConfirmationMethod":                                      {"automatic", "manual"},
+	"PaymentIntentNextActionType":                                          {"redirect_to_url"},
+	"PaymentIntentOffSession":                                              {"one_off", "recurring"},
+	"PaymentIntentPaymentMethodOptionsCardInstallmentsPlanInterval":        {"month"},
+	"PaymentIntentPaymentMethodOptionsCardInstallmentsPlanType":            {"fixed_count"},
+	"PaymentIntentPaymentMethodOptionsCardRequestThreeDSecure":             {"any", "automatic"},
+	"PaymentIntentSetupFutureUsage":                                        {"off_session", "on_session"},
+	"PaymentIntentStatus":                                                  {"canceled", "processing", "requires_action", "requires_capture", "requires_confirmation", "requires_payment_method", "succeeded"},
+	"PaymentMethodCardBrand":                                               {"amex", "diners", "discover", "jcb", "mastercard", "unionpay", "unknown", "visa"},
+	"PaymentMethodCardNetwork":                                             {"amex", "diners", "discover", "interac", "jcb", "mastercard", "unionpay", "unknown", "visa"},
+	"PaymentMethodCardWalletType":                                          {"amex_express_checkout", "apple_pay", "google_pay", "masterpass", "samsung_pay", "visa_checkout"},
+	"PaymentMethodFPXAccountHolderType":                                    {"company", "individual"},
+	"PaymentMethodType":                                                    {"au_becs_debit", "card", "card_present", "fpx", "ideal", "sepa_debit"},
+	"PaymentSourceType":                                                    {"account", "bank_account", "bitcoin_receiver", "card", "source"},
+	"PayoutDestinationType":                                                {"bank_account", "card"},
+	"PayoutFailureCode":                                                    {"account_closed", "account_frozen", "bank_account_restricted", "bank_ownership_changed", "could_not_process", "debit_not_authorized", "insufficient_funds", "invalid_account_number", "invalid_currency", "no_account"},
+	"PayoutInterval":                                                       {"daily", "manual", "monthly", "weekly"},
+	"PayoutMethodType":                                                     {"instant", "standard"},
+	"PayoutSourceType":                                                     {"alipay_account", "bank_account", "bitcoin_receiver", "card", "fpx"},
+	"PayoutStatus":                                                         {"canceled", "failed", "in_transit", "paid", "pending"},
+	"PayoutType":                                                           {"bank_account", "card"},
+	"PersonVerificationDetailsCode":                                        {"failed_keyed_identity", "failed_other", "scan_name_mismatch"},
+	"PlanAggregateUsage":                                                   {"last_during_period", "last_ever", "max", "sum"},
+	"PlanBillingScheme":                                                    {"per_unit", "tiered"},
+	"PlanInterval":                                                         {"day", "month", "week", "year"},
+	"PlanTiersMode":                                                        {"graduated", "volume"},
+	"PlanTransformUsageRound":                                              {"down", "up"},
+	"PlanUsageType":                                                        {"licensed", "metered"},
+	"ProductType":                                                          {"good", "service"},
+	"RadarEarlyFraudWarningFraudType":                                      {"card_never_received", "fraudulent_card_application", "made_with_counterfeit_card", "made_with_lost_card", "made_with_stolen_card", "misc", "unauthorized_use_of_card"},
+	"RadarValueListItemType":                                               {"card_bin", "card_fingerprint", "case_sensitive_string", "country", "email", "ip_address", "string"},
+	"RecipientTransferDestinationType":                                     {"bank_account", "card"},
+	"RecipientTransferFailureCode":                                         {"account_closed", "account_frozen", "bank_account_restricted", "bank_ownership_changed", "could_not_process", "debit_not_authorized", "insufficient_funds", "invalid_account_number", "invalid_currency", "no_account"},
+	"RecipientTransferMethodType":                                          {"instant", "standard"},
+	"RecipientTransferSourceType":                                          {"alipay_account", "bank_account", "bitcoin_receiver", "card"},
+	"RecipientTransferStatus":                                              {"failed", "in_transit", "paid", "pending"},
+	"RecipientTransferType":                                                {"bank_account", "card"},
+	"RecipientType":                                                        {"corporation", "individual"},
+	"RefundFailureReason":                                                  {"expired_or_canceled_card", "lost_or_stolen_card", "unknown"},
+	"RefundReason":                                                         {"duplicate", "expired_uncaptured_charge", "fraudulent", "requested_by_customer"},
+	"RefundStatus":                                                         {"canceled", "failed", "pending", "succeeded"},
+	"ReportRunStatus":                                                      {"failed", "pending", "succeeded"},
+	"ReviewReasonType":                                                     {"approved", "disputed", "manual", "refunded", "refunded_as_fraud", "rule"},
+	"SKUInventoryType":                                                     {"bucket", "finite", "infinite"},
+	"SKUInventoryValue":                                                    {"in_stock", "limited", "out_of_stock"},
+	"SetupIntentCancellationReason":                                        {"abandoned", "failed_invoice", "fraudulent", "requested_by_customer"},
+	"SetupIntentNextActionType":                                            {"redirect_to_url"},
+	"SetupIntentPaymentMethodOptionsCardRequestThreeDSecure":               {"any", "automatic"},
+	"SetupIntentStatus":                                                    {"canceled", "processing", "requires_action", "requires_confirmation", "requires_payment_method", "succeeded"},
+	"SetupIntentUsage":                                                     {"off_session", "on_session"},
+	"SigmaScheduledQueryRunStatus":                                         {"canceled", "completed", "failed", "timed_out"},
+	"SourceCodeVerificationFlowStatus":                                     {"failed", "pending", "succeeded"},
+	"SourceFlow":                                                           {"code_verification", "none", "receiver", "redirect"},
+	"SourceMandateAcceptanceStatus":                                        {"accepted", "refused"},
+	"SourceMandateNotificationMethod":                                      {"email", "manual", "none"},
+	"SourceRedirectFlowFailureReason":                                      {"declined", "processing_error", "user_abort"},
+	"SourceRedirectFlowStatus":                                             {"failed", "not_required", "pending", "succeeded"},
+	"SourceRefundAttributesMethod":                                         {"email", "manual"},
+	"SourceRefundAttributesStatus":                                         {"available", "missing", "requested"},
+	"SourceSourceOrderItemType":                                            {"discount", "shipping", "sku", "tax"},
+	"SourceStatus":                                                         {"canceled", "chargeable", "consumed", "failed", "pending"},
+	"SourceUsage":                                                          {"reusable", "single_use"},
+	"SubscriptionCollectionMethod":                                         {"charge_automatically", "send_invoice"},
+	"SubscriptionPaymentBehavior":                                          {"allow_incomplete", "error_if_incomplete", "pending_if_incomplete"},
+	"SubscriptionPendingInvoiceItemIntervalInterval":                       {"day", "month", "week", "year"},
+	"SubscriptionProrationBehavior":                                        {"always_invoice", "create_prorations", "none"},
+	"SubscriptionScheduleEndBehavior":                                      {"cancel", "release"},
+	"SubscriptionScheduleStatus":                                           {"active", "canceled", "completed", "not_started", "released"},
+	"SubscriptionStatus":                                                   {"active", "all", "canceled", "incomplete", "incomplete_expired", "past_due", "trialing", "unpaid"},
+	"SupportedBackend":                                                     {"api", "connect", "uploads"},
+	"TaxIDType":                                                            {"au_abn", "ca_bn", "ch_vat", "es_cif", "eu_vat", "hk_br", "in_gst", "mx_rfc", "no_vat", "nz_gst", "ru_inn", "sg_uen", "th_vat", "tw_vat", "unknown", "za_vat"},
+	"TaxIDVerificationStatus":                                              {"pending", "unavailable", "unverified", "verified"},
+	"TokenType":                                                            {"account", "bank_account", "card", "pii"},
+	"TransferSourceType":                                                   {"alipay_account", "bank_account", "bitcoin_receiver", "card", "fpx"},
+	"VerificationDocumentDetailsCode":                                      {"document_corrupt", "document_failed_copy", "document_failed_greyscale", "document_failed_other", "document_failed_test_mode", "document_fraudulent", "document_id_country_not_supported", "document_id_type_not_supported", "document_manipulated", "document_missing_back", "document_missing_front", "document_not_readable", "document_not_uploaded", "document_too_large"},
+}
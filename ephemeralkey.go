@@ -31,6 +31,8 @@ type EphemeralKey struct {
 	// from the version of these bindings, we can still pass back a compatible
 	// key.
 	RawJSON []byte `json:"-"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // UnmarshalJSON handles deserialization of an EphemeralKey.
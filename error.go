@@ -295,3 +295,18 @@ type rawErrorInternal struct {
 	*Error
 	DeclineCode *DeclineCode `json:"decline_code,omitempty"`
 }
+
+// NewInvalidRequestError produces an error shaped like an invalid request
+// error from the API for a problem that was detected without making a request,
+// so that it can be handled the same way. Like errors from the API, its Err
+// is an *InvalidRequestError. Code may be left empty.
+func NewInvalidRequestError(code ErrorCode, param, msg string) *Error {
+	stripeErr := &Error{
+		Code:  code,
+		Msg:   msg,
+		Param: param,
+		Type:  ErrorTypeInvalidRequest,
+	}
+	stripeErr.Err = &InvalidRequestError{stripeErr: stripeErr}
+	return stripeErr
+}
@@ -17,6 +17,8 @@ type Event struct {
 	PendingWebhooks int64         `json:"pending_webhooks"`
 	Request         *EventRequest `json:"request"`
 	Type            string        `json:"type"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // EventRequest contains information on a request that created an event.
@@ -1,10 +1,14 @@
 package stripe
 
+import "encoding/json"
+
 // ExchangeRate is the resource representing the currency exchange rates at
 // a given time.
 type ExchangeRate struct {
 	ID    string               `json:"id"`
 	Rates map[Currency]float64 `json:"rates"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // ExchangeRateParams is the set of parameters that can be used when retrieving
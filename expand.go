@@ -0,0 +1,74 @@
+package stripe
+
+import (
+	"fmt"
+	"net/http"
+)
+
+//
+// Public types
+//
+
+// ExpandPath is the path of a field to expand in an API response, like
+// "customer.default_source".
+//
+// Rather than spelling out paths as strings, use the generated values rooted
+// at variables like ChargeExpand, which only contain paths that exist:
+//
+//	params := &stripe.ChargeParams{}
+//	params.AddExpandPaths(stripe.ChargeExpand.Customer.DefaultSource)
+type ExpandPath string
+
+// InList returns the path of the same field in each object returned by a list
+// endpoint, which is the path prefixed with "data.".
+func (p ExpandPath) InList() ExpandPath {
+	return "data." + p
+}
+
+// Path returns the path itself. It exists so that the generated paths, which
+// embed an ExpandPath, implement Expansion.
+func (p ExpandPath) Path() ExpandPath {
+	return p
+}
+
+// Expansion is the interface implemented by ExpandPath and the generated
+// paths embedding one.
+type Expansion interface {
+	Path() ExpandPath
+}
+
+//
+// Private functions
+//
+
+// expandable is implemented by API resources which can either be expanded or
+// be returned as only their ID.
+type expandable interface {
+	IsExpanded() bool
+}
+
+// fetchExpandable retrieves the object at path, formatted with the given ID,
+// into v unless it was already expanded. The request is made with the given
+// key and params, so that it's made like the one which returned v, like on
+// behalf of the same connected account.
+func fetchExpandable(backend Backend, key string, params *Params, v expandable, path, id string) error {
+	if v.IsExpanded() {
+		return nil
+	}
+
+	if id == "" {
+		return fmt.Errorf("stripe: cannot fetch %T without an ID", v)
+	}
+
+	if backend == nil {
+		backend = GetBackend(APIBackend)
+	}
+	if key == "" {
+		key = Key
+	}
+	if params == nil {
+		params = &Params{}
+	}
+
+	return backend.Call(http.MethodGet, FormatURLPath(path, id), key, params, v)
+}
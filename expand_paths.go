@@ -0,0 +1,3700 @@
+// Code generated by scripts/generate_expand_paths. DO NOT EDIT.
+
+package stripe
+
+// IsExpanded returns whether the account was returned as a full
+// object, rather than only its ID.
+func (a *Account) IsExpanded() bool {
+	return a != nil && a.expanded
+}
+
+// IsExpanded returns whether the application was returned as a full
+// object, rather than only its ID.
+func (a *Application) IsExpanded() bool {
+	return a != nil && a.expanded
+}
+
+// IsExpanded returns whether the application fee was returned as a full
+// object, rather than only its ID.
+func (f *ApplicationFee) IsExpanded() bool {
+	return f != nil && f.expanded
+}
+
+// Fetch retrieves the full application fee into itself unless it was
+// already expanded, with the given key and params. The default API backend
+// is used when backend is nil, and stripe.Key when key is empty.
+func (f *ApplicationFee) Fetch(backend Backend, key string, params *Params) error {
+	return fetchExpandable(backend, key, params, f, "/v1/application_fees/%s", f.ID)
+}
+
+// IsExpanded returns whether the balance transaction was returned as a full
+// object, rather than only its ID.
+func (t *BalanceTransaction) IsExpanded() bool {
+	return t != nil && t.expanded
+}
+
+// Fetch retrieves the full balance transaction into itself unless it was
+// already expanded, with the given key and params. The default API backend
+// is used when backend is nil, and stripe.Key when key is empty.
+func (t *BalanceTransaction) Fetch(backend Backend, key string, params *Params) error {
+	return fetchExpandable(backend, key, params, t, "/v1/balance_transactions/%s", t.ID)
+}
+
+// IsExpanded returns whether the balance transaction source was returned as a full
+// object, rather than only its ID.
+func (s *BalanceTransactionSource) IsExpanded() bool {
+	return s != nil && s.expanded
+}
+
+// IsExpanded returns whether the bank account was returned as a full
+// object, rather than only its ID.
+func (b *BankAccount) IsExpanded() bool {
+	return b != nil && b.expanded
+}
+
+// IsExpanded returns whether the bitcoin receiver was returned as a full
+// object, rather than only its ID.
+func (r *BitcoinReceiver) IsExpanded() bool {
+	return r != nil && r.expanded
+}
+
+// IsExpanded returns whether the bitcoin transaction was returned as a full
+// object, rather than only its ID.
+func (bt *BitcoinTransaction) IsExpanded() bool {
+	return bt != nil && bt.expanded
+}
+
+// IsExpanded returns whether the capability was returned as a full
+// object, rather than only its ID.
+func (c *Capability) IsExpanded() bool {
+	return c != nil && c.expanded
+}
+
+// IsExpanded returns whether the card was returned as a full
+// object, rather than only its ID.
+func (c *Card) IsExpanded() bool {
+	return c != nil && c.expanded
+}
+
+// IsExpanded returns whether the charge was returned as a full
+// object, rather than only its ID.
+func (c *Charge) IsExpanded() bool {
+	return c != nil && c.expanded
+}
+
+// Fetch retrieves the full charge into itself unless it was
+// already expanded, with the given key and params. The default API backend
+// is used when backend is nil, and stripe.Key when key is empty.
+func (c *Charge) Fetch(backend Backend, key string, params *Params) error {
+	return fetchExpandable(backend, key, params, c, "/v1/charges/%s", c.ID)
+}
+
+// IsExpanded returns whether the charge outcome rule was returned as a full
+// object, rather than only its ID.
+func (c *ChargeOutcomeRule) IsExpanded() bool {
+	return c != nil && c.expanded
+}
+
+// IsExpanded returns whether the checkout session was returned as a full
+// object, rather than only its ID.
+func (p *CheckoutSession) IsExpanded() bool {
+	return p != nil && p.expanded
+}
+
+// Fetch retrieves the full checkout session into itself unless it was
+// already expanded, with the given key and params. The default API backend
+// is used when backend is nil, and stripe.Key when key is empty.
+func (p *CheckoutSession) Fetch(backend Backend, key string, params *Params) error {
+	return fetchExpandable(backend, key, params, p, "/v1/checkout/sessions/%s", p.ID)
+}
+
+// IsExpanded returns whether the coupon was returned as a full
+// object, rather than only its ID.
+func (c *Coupon) IsExpanded() bool {
+	return c != nil && c.expanded
+}
+
+// Fetch retrieves the full coupon into itself unless it was
+// already expanded, with the given key and params. The default API backend
+// is used when backend is nil, and stripe.Key when key is empty.
+func (c *Coupon) Fetch(backend Backend, key string, params *Params) error {
+	return fetchExpandable(backend, key, params, c, "/v1/coupons/%s", c.ID)
+}
+
+// IsExpanded returns whether the credit note was returned as a full
+// object, rather than only its ID.
+func (i *CreditNote) IsExpanded() bool {
+	return i != nil && i.expanded
+}
+
+// Fetch retrieves the full credit note into itself unless it was
+// already expanded, with the given key and params. The default API backend
+// is used when backend is nil, and stripe.Key when key is empty.
+func (i *CreditNote) Fetch(backend Backend, key string, params *Params) error {
+	return fetchExpandable(backend, key, params, i, "/v1/credit_notes/%s", i.ID)
+}
+
+// IsExpanded returns whether the customer was returned as a full
+// object, rather than only its ID.
+func (c *Customer) IsExpanded() bool {
+	return c != nil && c.expanded
+}
+
+// Fetch retrieves the full customer into itself unless it was
+// already expanded, with the given key and params. The default API backend
+// is used when backend is nil, and stripe.Key when key is empty.
+func (c *Customer) Fetch(backend Backend, key string, params *Params) error {
+	return fetchExpandable(backend, key, params, c, "/v1/customers/%s", c.ID)
+}
+
+// IsExpanded returns whether the customer balance transaction was returned as a full
+// object, rather than only its ID.
+func (c *CustomerBalanceTransaction) IsExpanded() bool {
+	return c != nil && c.expanded
+}
+
+// IsExpanded returns whether the dispute was returned as a full
+// object, rather than only its ID.
+func (d *Dispute) IsExpanded() bool {
+	return d != nil && d.expanded
+}
+
+// Fetch retrieves the full dispute into itself unless it was
+// already expanded, with the given key and params. The default API backend
+// is used when backend is nil, and stripe.Key when key is empty.
+func (d *Dispute) Fetch(backend Backend, key string, params *Params) error {
+	return fetchExpandable(backend, key, params, d, "/v1/disputes/%s", d.ID)
+}
+
+// IsExpanded returns whether the fee refund was returned as a full
+// object, rather than only its ID.
+func (r *FeeRefund) IsExpanded() bool {
+	return r != nil && r.expanded
+}
+
+// IsExpanded returns whether the file was returned as a full
+// object, rather than only its ID.
+func (f *File) IsExpanded() bool {
+	return f != nil && f.expanded
+}
+
+// Fetch retrieves the full file into itself unless it was
+// already expanded, with the given key and params. The default API backend
+// is used when backend is nil, and stripe.Key when key is empty.
+func (f *File) Fetch(backend Backend, key string, params *Params) error {
+	return fetchExpandable(backend, key, params, f, "/v1/files/%s", f.ID)
+}
+
+// IsExpanded returns whether the file link was returned as a full
+// object, rather than only its ID.
+func (c *FileLink) IsExpanded() bool {
+	return c != nil && c.expanded
+}
+
+// Fetch retrieves the full file link into itself unless it was
+// already expanded, with the given key and params. The default API backend
+// is used when backend is nil, and stripe.Key when key is empty.
+func (c *FileLink) Fetch(backend Backend, key string, params *Params) error {
+	return fetchExpandable(backend, key, params, c, "/v1/file_links/%s", c.ID)
+}
+
+// IsExpanded returns whether the invoice was returned as a full
+// object, rather than only its ID.
+func (i *Invoice) IsExpanded() bool {
+	return i != nil && i.expanded
+}
+
+// Fetch retrieves the full invoice into itself unless it was
+// already expanded, with the given key and params. The default API backend
+// is used when backend is nil, and stripe.Key when key is empty.
+func (i *Invoice) Fetch(backend Backend, key string, params *Params) error {
+	return fetchExpandable(backend, key, params, i, "/v1/invoices/%s", i.ID)
+}
+
+// IsExpanded returns whether the invoice item was returned as a full
+// object, rather than only its ID.
+func (i *InvoiceItem) IsExpanded() bool {
+	return i != nil && i.expanded
+}
+
+// Fetch retrieves the full invoice item into itself unless it was
+// already expanded, with the given key and params. The default API backend
+// is used when backend is nil, and stripe.Key when key is empty.
+func (i *InvoiceItem) Fetch(backend Backend, key string, params *Params) error {
+	return fetchExpandable(backend, key, params, i, "/v1/invoiceitems/%s", i.ID)
+}
+
+// IsExpanded returns whether the issuing authorization was returned as a full
+// object, rather than only its ID.
+func (i *IssuingAuthorization) IsExpanded() bool {
+	return i != nil && i.expanded
+}
+
+// Fetch retrieves the full issuing authorization into itself unless it was
+// already expanded, with the given key and params. The default API backend
+// is used when backend is nil, and stripe.Key when key is empty.
+func (i *IssuingAuthorization) Fetch(backend Backend, key string, params *Params) error {
+	return fetchExpandable(backend, key, params, i, "/v1/issuing/authorizations/%s", i.ID)
+}
+
+// IsExpanded returns whether the issuing card was returned as a full
+// object, rather than only its ID.
+func (i *IssuingCard) IsExpanded() bool {
+	return i != nil && i.expanded
+}
+
+// Fetch retrieves the full issuing card into itself unless it was
+// already expanded, with the given key and params. The default API backend
+// is used when backend is nil, and stripe.Key when key is empty.
+func (i *IssuingCard) Fetch(backend Backend, key string, params *Params) error {
+	return fetchExpandable(backend, key, params, i, "/v1/issuing/cards/%s", i.ID)
+}
+
+// IsExpanded returns whether the issuing cardholder was returned as a full
+// object, rather than only its ID.
+func (i *IssuingCardholder) IsExpanded() bool {
+	return i != nil && i.expanded
+}
+
+// Fetch retrieves the full issuing cardholder into itself unless it was
+// already expanded, with the given key and params. The default API backend
+// is used when backend is nil, and stripe.Key when key is empty.
+func (i *IssuingCardholder) Fetch(backend Backend, key string, params *Params) error {
+	return fetchExpandable(backend, key, params, i, "/v1/issuing/cardholders/%s", i.ID)
+}
+
+// IsExpanded returns whether the issuing dispute was returned as a full
+// object, rather than only its ID.
+func (i *IssuingDispute) IsExpanded() bool {
+	return i != nil && i.expanded
+}
+
+// Fetch retrieves the full issuing dispute into itself unless it was
+// already expanded, with the given key and params. The default API backend
+// is used when backend is nil, and stripe.Key when key is empty.
+func (i *IssuingDispute) Fetch(backend Backend, key string, params *Params) error {
+	return fetchExpandable(backend, key, params, i, "/v1/issuing/disputes/%s", i.ID)
+}
+
+// IsExpanded returns whether the issuing transaction was returned as a full
+// object, rather than only its ID.
+func (i *IssuingTransaction) IsExpanded() bool {
+	return i != nil && i.expanded
+}
+
+// Fetch retrieves the full issuing transaction into itself unless it was
+// already expanded, with the given key and params. The default API backend
+// is used when backend is nil, and stripe.Key when key is empty.
+func (i *IssuingTransaction) Fetch(backend Backend, key string, params *Params) error {
+	return fetchExpandable(backend, key, params, i, "/v1/issuing/transactions/%s", i.ID)
+}
+
+// IsExpanded returns whether the mandate was returned as a full
+// object, rather than only its ID.
+func (i *Mandate) IsExpanded() bool {
+	return i != nil && i.expanded
+}
+
+// Fetch retrieves the full mandate into itself unless it was
+// already expanded, with the given key and params. The default API backend
+// is used when backend is nil, and stripe.Key when key is empty.
+func (i *Mandate) Fetch(backend Backend, key string, params *Params) error {
+	return fetchExpandable(backend, key, params, i, "/v1/mandates/%s", i.ID)
+}
+
+// IsExpanded returns whether the order was returned as a full
+// object, rather than only its ID.
+func (o *Order) IsExpanded() bool {
+	return o != nil && o.expanded
+}
+
+// Fetch retrieves the full order into itself unless it was
+// already expanded, with the given key and params. The default API backend
+// is used when backend is nil, and stripe.Key when key is empty.
+func (o *Order) Fetch(backend Backend, key string, params *Params) error {
+	return fetchExpandable(backend, key, params, o, "/v1/orders/%s", o.ID)
+}
+
+// IsExpanded returns whether the order item parent was returned as a full
+// object, rather than only its ID.
+func (p *OrderItemParent) IsExpanded() bool {
+	return p != nil && p.expanded
+}
+
+// IsExpanded returns whether the order return was returned as a full
+// object, rather than only its ID.
+func (r *OrderReturn) IsExpanded() bool {
+	return r != nil && r.expanded
+}
+
+// Fetch retrieves the full order return into itself unless it was
+// already expanded, with the given key and params. The default API backend
+// is used when backend is nil, and stripe.Key when key is empty.
+func (r *OrderReturn) Fetch(backend Backend, key string, params *Params) error {
+	return fetchExpandable(backend, key, params, r, "/v1/order_returns/%s", r.ID)
+}
+
+// IsExpanded returns whether the payment intent was returned as a full
+// object, rather than only its ID.
+func (p *PaymentIntent) IsExpanded() bool {
+	return p != nil && p.expanded
+}
+
+// Fetch retrieves the full payment intent into itself unless it was
+// already expanded, with the given key and params. The default API backend
+// is used when backend is nil, and stripe.Key when key is empty.
+func (p *PaymentIntent) Fetch(backend Backend, key string, params *Params) error {
+	return fetchExpandable(backend, key, params, p, "/v1/payment_intents/%s", p.ID)
+}
+
+// IsExpanded returns whether the payment method was returned as a full
+// object, rather than only its ID.
+func (i *PaymentMethod) IsExpanded() bool {
+	return i != nil && i.expanded
+}
+
+// Fetch retrieves the full payment method into itself unless it was
+// already expanded, with the given key and params. The default API backend
+// is used when backend is nil, and stripe.Key when key is empty.
+func (i *PaymentMethod) Fetch(backend Backend, key string, params *Params) error {
+	return fetchExpandable(backend, key, params, i, "/v1/payment_methods/%s", i.ID)
+}
+
+// IsExpanded returns whether the payment source was returned as a full
+// object, rather than only its ID.
+func (s *PaymentSource) IsExpanded() bool {
+	return s != nil && s.expanded
+}
+
+// IsExpanded returns whether the payout was returned as a full
+// object, rather than only its ID.
+func (p *Payout) IsExpanded() bool {
+	return p != nil && p.expanded
+}
+
+// Fetch retrieves the full payout into itself unless it was
+// already expanded, with the given key and params. The default API backend
+// is used when backend is nil, and stripe.Key when key is empty.
+func (p *Payout) Fetch(backend Backend, key string, params *Params) error {
+	return fetchExpandable(backend, key, params, p, "/v1/payouts/%s", p.ID)
+}
+
+// IsExpanded returns whether the payout destination was returned as a full
+// object, rather than only its ID.
+func (d *PayoutDestination) IsExpanded() bool {
+	return d != nil && d.expanded
+}
+
+// IsExpanded returns whether the person was returned as a full
+// object, rather than only its ID.
+func (c *Person) IsExpanded() bool {
+	return c != nil && c.expanded
+}
+
+// IsExpanded returns whether the plan was returned as a full
+// object, rather than only its ID.
+func (s *Plan) IsExpanded() bool {
+	return s != nil && s.expanded
+}
+
+// Fetch retrieves the full plan into itself unless it was
+// already expanded, with the given key and params. The default API backend
+// is used when backend is nil, and stripe.Key when key is empty.
+func (s *Plan) Fetch(backend Backend, key string, params *Params) error {
+	return fetchExpandable(backend, key, params, s, "/v1/plans/%s", s.ID)
+}
+
+// IsExpanded returns whether the product was returned as a full
+// object, rather than only its ID.
+func (p *Product) IsExpanded() bool {
+	return p != nil && p.expanded
+}
+
+// Fetch retrieves the full product into itself unless it was
+// already expanded, with the given key and params. The default API backend
+// is used when backend is nil, and stripe.Key when key is empty.
+func (p *Product) Fetch(backend Backend, key string, params *Params) error {
+	return fetchExpandable(backend, key, params, p, "/v1/products/%s", p.ID)
+}
+
+// IsExpanded returns whether the recipient was returned as a full
+// object, rather than only its ID.
+func (r *Recipient) IsExpanded() bool {
+	return r != nil && r.expanded
+}
+
+// Fetch retrieves the full recipient into itself unless it was
+// already expanded, with the given key and params. The default API backend
+// is used when backend is nil, and stripe.Key when key is empty.
+func (r *Recipient) Fetch(backend Backend, key string, params *Params) error {
+	return fetchExpandable(backend, key, params, r, "/v1/recipients/%s", r.ID)
+}
+
+// IsExpanded returns whether the recipient transfer was returned as a full
+// object, rather than only its ID.
+func (t *RecipientTransfer) IsExpanded() bool {
+	return t != nil && t.expanded
+}
+
+// IsExpanded returns whether the recipient transfer destination was returned as a full
+// object, rather than only its ID.
+func (d *RecipientTransferDestination) IsExpanded() bool {
+	return d != nil && d.expanded
+}
+
+// IsExpanded returns whether the refund was returned as a full
+// object, rather than only its ID.
+func (r *Refund) IsExpanded() bool {
+	return r != nil && r.expanded
+}
+
+// Fetch retrieves the full refund into itself unless it was
+// already expanded, with the given key and params. The default API backend
+// is used when backend is nil, and stripe.Key when key is empty.
+func (r *Refund) Fetch(backend Backend, key string, params *Params) error {
+	return fetchExpandable(backend, key, params, r, "/v1/refunds/%s", r.ID)
+}
+
+// IsExpanded returns whether the reversal was returned as a full
+// object, rather than only its ID.
+func (r *Reversal) IsExpanded() bool {
+	return r != nil && r.expanded
+}
+
+// IsExpanded returns whether the review was returned as a full
+// object, rather than only its ID.
+func (r *Review) IsExpanded() bool {
+	return r != nil && r.expanded
+}
+
+// Fetch retrieves the full review into itself unless it was
+// already expanded, with the given key and params. The default API backend
+// is used when backend is nil, and stripe.Key when key is empty.
+func (r *Review) Fetch(backend Backend, key string, params *Params) error {
+	return fetchExpandable(backend, key, params, r, "/v1/reviews/%s", r.ID)
+}
+
+// IsExpanded returns whether the SKU was returned as a full
+// object, rather than only its ID.
+func (s *SKU) IsExpanded() bool {
+	return s != nil && s.expanded
+}
+
+// Fetch retrieves the full SKU into itself unless it was
+// already expanded, with the given key and params. The default API backend
+// is used when backend is nil, and stripe.Key when key is empty.
+func (s *SKU) Fetch(backend Backend, key string, params *Params) error {
+	return fetchExpandable(backend, key, params, s, "/v1/skus/%s", s.ID)
+}
+
+// IsExpanded returns whether the setup intent was returned as a full
+// object, rather than only its ID.
+func (p *SetupIntent) IsExpanded() bool {
+	return p != nil && p.expanded
+}
+
+// Fetch retrieves the full setup intent into itself unless it was
+// already expanded, with the given key and params. The default API backend
+// is used when backend is nil, and stripe.Key when key is empty.
+func (p *SetupIntent) Fetch(backend Backend, key string, params *Params) error {
+	return fetchExpandable(backend, key, params, p, "/v1/setup_intents/%s", p.ID)
+}
+
+// IsExpanded returns whether the sigma scheduled query run was returned as a full
+// object, rather than only its ID.
+func (i *SigmaScheduledQueryRun) IsExpanded() bool {
+	return i != nil && i.expanded
+}
+
+// Fetch retrieves the full sigma scheduled query run into itself unless it was
+// already expanded, with the given key and params. The default API backend
+// is used when backend is nil, and stripe.Key when key is empty.
+func (i *SigmaScheduledQueryRun) Fetch(backend Backend, key string, params *Params) error {
+	return fetchExpandable(backend, key, params, i, "/v1/sigma/scheduled_query_runs/%s", i.ID)
+}
+
+// IsExpanded returns whether the subscription was returned as a full
+// object, rather than only its ID.
+func (s *Subscription) IsExpanded() bool {
+	return s != nil && s.expanded
+}
+
+// Fetch retrieves the full subscription into itself unless it was
+// already expanded, with the given key and params. The default API backend
+// is used when backend is nil, and stripe.Key when key is empty.
+func (s *Subscription) Fetch(backend Backend, key string, params *Params) error {
+	return fetchExpandable(backend, key, params, s, "/v1/subscriptions/%s", s.ID)
+}
+
+// IsExpanded returns whether the subscription schedule was returned as a full
+// object, rather than only its ID.
+func (s *SubscriptionSchedule) IsExpanded() bool {
+	return s != nil && s.expanded
+}
+
+// Fetch retrieves the full subscription schedule into itself unless it was
+// already expanded, with the given key and params. The default API backend
+// is used when backend is nil, and stripe.Key when key is empty.
+func (s *SubscriptionSchedule) Fetch(backend Backend, key string, params *Params) error {
+	return fetchExpandable(backend, key, params, s, "/v1/subscription_schedules/%s", s.ID)
+}
+
+// IsExpanded returns whether the tax ID was returned as a full
+// object, rather than only its ID.
+func (c *TaxID) IsExpanded() bool {
+	return c != nil && c.expanded
+}
+
+// IsExpanded returns whether the tax rate was returned as a full
+// object, rather than only its ID.
+func (c *TaxRate) IsExpanded() bool {
+	return c != nil && c.expanded
+}
+
+// Fetch retrieves the full tax rate into itself unless it was
+// already expanded, with the given key and params. The default API backend
+// is used when backend is nil, and stripe.Key when key is empty.
+func (c *TaxRate) Fetch(backend Backend, key string, params *Params) error {
+	return fetchExpandable(backend, key, params, c, "/v1/tax_rates/%s", c.ID)
+}
+
+// IsExpanded returns whether the transfer was returned as a full
+// object, rather than only its ID.
+func (t *Transfer) IsExpanded() bool {
+	return t != nil && t.expanded
+}
+
+// Fetch retrieves the full transfer into itself unless it was
+// already expanded, with the given key and params. The default API backend
+// is used when backend is nil, and stripe.Key when key is empty.
+func (t *Transfer) Fetch(backend Backend, key string, params *Params) error {
+	return fetchExpandable(backend, key, params, t, "/v1/transfers/%s", t.ID)
+}
+
+// IsExpanded returns whether the transfer destination was returned as a full
+// object, rather than only its ID.
+func (d *TransferDestination) IsExpanded() bool {
+	return d != nil && d.expanded
+}
+
+// IsExpanded returns whether the webhook endpoint was returned as a full
+// object, rather than only its ID.
+func (c *WebhookEndpoint) IsExpanded() bool {
+	return c != nil && c.expanded
+}
+
+// Fetch retrieves the full webhook endpoint into itself unless it was
+// already expanded, with the given key and params. The default API backend
+// is used when backend is nil, and stripe.Key when key is empty.
+func (c *WebhookEndpoint) Fetch(backend Backend, key string, params *Params) error {
+	return fetchExpandable(backend, key, params, c, "/v1/webhook_endpoints/%s", c.ID)
+}
+
+// AccountExpandPaths are the paths of the fields that can be expanded in
+// an account.
+type AccountExpandPaths struct {
+	Company    AccountCompanyExpandPaths1
+	Individual PersonExpandPaths1
+	Settings   AccountSettingsExpandPaths1
+}
+
+// AccountExpand contains the paths of the fields that can be expanded in
+// an account.
+var AccountExpand = AccountExpandPaths{
+	Company:    newAccountCompanyExpandPaths1("company"),
+	Individual: newPersonExpandPaths1("individual"),
+	Settings:   newAccountSettingsExpandPaths1("settings"),
+}
+
+// ApplicationFeeExpandPaths are the paths of the fields that can be expanded in
+// an application fee.
+type ApplicationFeeExpandPaths struct {
+	Account                AccountExpandPaths1
+	BalanceTransaction     BalanceTransactionExpandPaths1
+	Charge                 ChargeExpandPaths1
+	OriginatingTransaction ChargeExpandPaths1
+}
+
+// ApplicationFeeExpand contains the paths of the fields that can be expanded in
+// an application fee.
+var ApplicationFeeExpand = ApplicationFeeExpandPaths{
+	Account:                newAccountExpandPaths1("account"),
+	BalanceTransaction:     newBalanceTransactionExpandPaths1("balance_transaction"),
+	Charge:                 newChargeExpandPaths1("charge"),
+	OriginatingTransaction: newChargeExpandPaths1("originating_transaction"),
+}
+
+// BalanceTransactionExpandPaths are the paths of the fields that can be expanded in
+// a balance transaction.
+type BalanceTransactionExpandPaths struct {
+	Source ExpandPath
+}
+
+// BalanceTransactionExpand contains the paths of the fields that can be expanded in
+// a balance transaction.
+var BalanceTransactionExpand = BalanceTransactionExpandPaths{
+	Source: "source",
+}
+
+// BankAccountExpandPaths are the paths of the fields that can be expanded in
+// a bank account.
+type BankAccountExpandPaths struct {
+	Account  AccountExpandPaths1
+	Customer CustomerExpandPaths1
+}
+
+// BankAccountExpand contains the paths of the fields that can be expanded in
+// a bank account.
+var BankAccountExpand = BankAccountExpandPaths{
+	Account:  newAccountExpandPaths1("account"),
+	Customer: newCustomerExpandPaths1("customer"),
+}
+
+// CapabilityExpandPaths are the paths of the fields that can be expanded in
+// a capability.
+type CapabilityExpandPaths struct {
+	Account AccountExpandPaths1
+}
+
+// CapabilityExpand contains the paths of the fields that can be expanded in
+// a capability.
+var CapabilityExpand = CapabilityExpandPaths{
+	Account: newAccountExpandPaths1("account"),
+}
+
+// CardExpandPaths are the paths of the fields that can be expanded in
+// a card.
+type CardExpandPaths struct {
+	Customer     CustomerExpandPaths1
+	Recipient    RecipientExpandPaths1
+	ThreeDSecure ThreeDSecureExpandPaths1
+}
+
+// CardExpand contains the paths of the fields that can be expanded in
+// a card.
+var CardExpand = CardExpandPaths{
+	Customer:     newCustomerExpandPaths1("customer"),
+	Recipient:    newRecipientExpandPaths1("recipient"),
+	ThreeDSecure: newThreeDSecureExpandPaths1("three_d_secure"),
+}
+
+// ChargeExpandPaths are the paths of the fields that can be expanded in
+// a charge.
+type ChargeExpandPaths struct {
+	Application        ExpandPath
+	ApplicationFee     ApplicationFeeExpandPaths1
+	BalanceTransaction BalanceTransactionExpandPaths1
+	Customer           CustomerExpandPaths1
+	Destination        AccountExpandPaths1
+	Dispute            DisputeExpandPaths1
+	Invoice            InvoiceExpandPaths1
+	OnBehalfOf         AccountExpandPaths1
+	Outcome            ChargeOutcomeExpandPaths1
+	Review             ReviewExpandPaths1
+	Source             ExpandPath
+	SourceTransfer     TransferExpandPaths1
+	Transfer           TransferExpandPaths1
+	TransferData       ChargeTransferDataExpandPaths1
+}
+
+// ChargeExpand contains the paths of the fields that can be expanded in
+// a charge.
+var ChargeExpand = ChargeExpandPaths{
+	Application:        "application",
+	ApplicationFee:     newApplicationFeeExpandPaths1("application_fee"),
+	BalanceTransaction: newBalanceTransactionExpandPaths1("balance_transaction"),
+	Customer:           newCustomerExpandPaths1("customer"),
+	Destination:        newAccountExpandPaths1("destination"),
+	Dispute:            newDisputeExpandPaths1("dispute"),
+	Invoice:            newInvoiceExpandPaths1("invoice"),
+	OnBehalfOf:         newAccountExpandPaths1("on_behalf_of"),
+	Outcome:            newChargeOutcomeExpandPaths1("outcome"),
+	Review:             newReviewExpandPaths1("review"),
+	Source:             "source",
+	SourceTransfer:     newTransferExpandPaths1("source_transfer"),
+	Transfer:           newTransferExpandPaths1("transfer"),
+	TransferData:       newChargeTransferDataExpandPaths1("transfer_data"),
+}
+
+// CheckoutSessionExpandPaths are the paths of the fields that can be expanded in
+// a checkout session.
+type CheckoutSessionExpandPaths struct {
+	Customer      CustomerExpandPaths1
+	PaymentIntent PaymentIntentExpandPaths1
+	SetupIntent   SetupIntentExpandPaths1
+	Subscription  SubscriptionExpandPaths1
+}
+
+// CheckoutSessionExpand contains the paths of the fields that can be expanded in
+// a checkout session.
+var CheckoutSessionExpand = CheckoutSessionExpandPaths{
+	Customer:      newCustomerExpandPaths1("customer"),
+	PaymentIntent: newPaymentIntentExpandPaths1("payment_intent"),
+	SetupIntent:   newSetupIntentExpandPaths1("setup_intent"),
+	Subscription:  newSubscriptionExpandPaths1("subscription"),
+}
+
+// CreditNoteExpandPaths are the paths of the fields that can be expanded in
+// a credit note.
+type CreditNoteExpandPaths struct {
+	Customer                   CustomerExpandPaths1
+	CustomerBalanceTransaction CustomerBalanceTransactionExpandPaths1
+	Invoice                    InvoiceExpandPaths1
+	Refund                     RefundExpandPaths1
+}
+
+// CreditNoteExpand contains the paths of the fields that can be expanded in
+// a credit note.
+var CreditNoteExpand = CreditNoteExpandPaths{
+	Customer:                   newCustomerExpandPaths1("customer"),
+	CustomerBalanceTransaction: newCustomerBalanceTransactionExpandPaths1("customer_balance_transaction"),
+	Invoice:                    newInvoiceExpandPaths1("invoice"),
+	Refund:                     newRefundExpandPaths1("refund"),
+}
+
+// CustomerExpandPaths are the paths of the fields that can be expanded in
+// a customer.
+type CustomerExpandPaths struct {
+	DefaultSource   ExpandPath
+	Discount        DiscountExpandPaths1
+	InvoiceSettings CustomerInvoiceSettingsExpandPaths1
+}
+
+// CustomerExpand contains the paths of the fields that can be expanded in
+// a customer.
+var CustomerExpand = CustomerExpandPaths{
+	DefaultSource:   "default_source",
+	Discount:        newDiscountExpandPaths1("discount"),
+	InvoiceSettings: newCustomerInvoiceSettingsExpandPaths1("invoice_settings"),
+}
+
+// CustomerBalanceTransactionExpandPaths are the paths of the fields that can be expanded in
+// a customer balance transaction.
+type CustomerBalanceTransactionExpandPaths struct {
+	CreditNote CreditNoteExpandPaths1
+	Customer   CustomerExpandPaths1
+	Invoice    InvoiceExpandPaths1
+}
+
+// CustomerBalanceTransactionExpand contains the paths of the fields that can be expanded in
+// a customer balance transaction.
+var CustomerBalanceTransactionExpand = CustomerBalanceTransactionExpandPaths{
+	CreditNote: newCreditNoteExpandPaths1("credit_note"),
+	Customer:   newCustomerExpandPaths1("customer"),
+	Invoice:    newInvoiceExpandPaths1("invoice"),
+}
+
+// DisputeExpandPaths are the paths of the fields that can be expanded in
+// a dispute.
+type DisputeExpandPaths struct {
+	Charge        ChargeExpandPaths1
+	Evidence      DisputeEvidenceExpandPaths1
+	PaymentIntent PaymentIntentExpandPaths1
+}
+
+// DisputeExpand contains the paths of the fields that can be expanded in
+// a dispute.
+var DisputeExpand = DisputeExpandPaths{
+	Charge:        newChargeExpandPaths1("charge"),
+	Evidence:      newDisputeEvidenceExpandPaths1("evidence"),
+	PaymentIntent: newPaymentIntentExpandPaths1("payment_intent"),
+}
+
+// FeeRefundExpandPaths are the paths of the fields that can be expanded in
+// a fee refund.
+type FeeRefundExpandPaths struct {
+	BalanceTransaction BalanceTransactionExpandPaths1
+	Fee                ApplicationFeeExpandPaths1
+}
+
+// FeeRefundExpand contains the paths of the fields that can be expanded in
+// a fee refund.
+var FeeRefundExpand = FeeRefundExpandPaths{
+	BalanceTransaction: newBalanceTransactionExpandPaths1("balance_transaction"),
+	Fee:                newApplicationFeeExpandPaths1("fee"),
+}
+
+// FileLinkExpandPaths are the paths of the fields that can be expanded in
+// a file link.
+type FileLinkExpandPaths struct {
+	File ExpandPath
+}
+
+// FileLinkExpand contains the paths of the fields that can be expanded in
+// a file link.
+var FileLinkExpand = FileLinkExpandPaths{
+	File: "file",
+}
+
+// InvoiceExpandPaths are the paths of the fields that can be expanded in
+// an invoice.
+type InvoiceExpandPaths struct {
+	Charge               ChargeExpandPaths1
+	Customer             CustomerExpandPaths1
+	DefaultPaymentMethod PaymentMethodExpandPaths1
+	DefaultSource        ExpandPath
+	Discount             DiscountExpandPaths1
+	PaymentIntent        PaymentIntentExpandPaths1
+	TransferData         InvoiceTransferDataExpandPaths1
+}
+
+// InvoiceExpand contains the paths of the fields that can be expanded in
+// an invoice.
+var InvoiceExpand = InvoiceExpandPaths{
+	Charge:               newChargeExpandPaths1("charge"),
+	Customer:             newCustomerExpandPaths1("customer"),
+	DefaultPaymentMethod: newPaymentMethodExpandPaths1("default_payment_method"),
+	DefaultSource:        "default_source",
+	Discount:             newDiscountExpandPaths1("discount"),
+	PaymentIntent:        newPaymentIntentExpandPaths1("payment_intent"),
+	TransferData:         newInvoiceTransferDataExpandPaths1("transfer_data"),
+}
+
+// InvoiceItemExpandPaths are the paths of the fields that can be expanded in
+// an invoice item.
+type InvoiceItemExpandPaths struct {
+	Customer     CustomerExpandPaths1
+	Invoice      InvoiceExpandPaths1
+	Plan         PlanExpandPaths1
+	Subscription SubscriptionExpandPaths1
+}
+
+// InvoiceItemExpand contains the paths of the fields that can be expanded in
+// an invoice item.
+var InvoiceItemExpand = InvoiceItemExpandPaths{
+	Customer:     newCustomerExpandPaths1("customer"),
+	Invoice:      newInvoiceExpandPaths1("invoice"),
+	Plan:         newPlanExpandPaths1("plan"),
+	Subscription: newSubscriptionExpandPaths1("subscription"),
+}
+
+// IssuingAuthorizationExpandPaths are the paths of the fields that can be expanded in
+// an issuing authorization.
+type IssuingAuthorizationExpandPaths struct {
+	Card       IssuingCardExpandPaths1
+	Cardholder ExpandPath
+}
+
+// IssuingAuthorizationExpand contains the paths of the fields that can be expanded in
+// an issuing authorization.
+var IssuingAuthorizationExpand = IssuingAuthorizationExpandPaths{
+	Card:       newIssuingCardExpandPaths1("card"),
+	Cardholder: "cardholder",
+}
+
+// IssuingCardExpandPaths are the paths of the fields that can be expanded in
+// an issuing card.
+type IssuingCardExpandPaths struct {
+	Cardholder     ExpandPath
+	ReplacementFor IssuingCardExpandPaths1
+}
+
+// IssuingCardExpand contains the paths of the fields that can be expanded in
+// an issuing card.
+var IssuingCardExpand = IssuingCardExpandPaths{
+	Cardholder:     "cardholder",
+	ReplacementFor: newIssuingCardExpandPaths1("replacement_for"),
+}
+
+// IssuingCardholderExpandPaths are the paths of the fields that can be expanded in
+// an issuing cardholder.
+type IssuingCardholderExpandPaths struct {
+	Individual IssuingCardholderIndividualExpandPaths1
+}
+
+// IssuingCardholderExpand contains the paths of the fields that can be expanded in
+// an issuing cardholder.
+var IssuingCardholderExpand = IssuingCardholderExpandPaths{
+	Individual: newIssuingCardholderIndividualExpandPaths1("individual"),
+}
+
+// IssuingDisputeExpandPaths are the paths of the fields that can be expanded in
+// an issuing dispute.
+type IssuingDisputeExpandPaths struct {
+	Evidence    IssuingDisputeEvidenceExpandPaths1
+	Transaction IssuingTransactionExpandPaths1
+}
+
+// IssuingDisputeExpand contains the paths of the fields that can be expanded in
+// an issuing dispute.
+var IssuingDisputeExpand = IssuingDisputeExpandPaths{
+	Evidence:    newIssuingDisputeEvidenceExpandPaths1("evidence"),
+	Transaction: newIssuingTransactionExpandPaths1("transaction"),
+}
+
+// IssuingTransactionExpandPaths are the paths of the fields that can be expanded in
+// an issuing transaction.
+type IssuingTransactionExpandPaths struct {
+	Authorization      IssuingAuthorizationExpandPaths1
+	BalanceTransaction BalanceTransactionExpandPaths1
+	Card               IssuingCardExpandPaths1
+	Cardholder         ExpandPath
+	Dispute            IssuingDisputeExpandPaths1
+}
+
+// IssuingTransactionExpand contains the paths of the fields that can be expanded in
+// an issuing transaction.
+var IssuingTransactionExpand = IssuingTransactionExpandPaths{
+	Authorization:      newIssuingAuthorizationExpandPaths1("authorization"),
+	BalanceTransaction: newBalanceTransactionExpandPaths1("balance_transaction"),
+	Card:               newIssuingCardExpandPaths1("card"),
+	Cardholder:         "cardholder",
+	Dispute:            newIssuingDisputeExpandPaths1("dispute"),
+}
+
+// MandateExpandPaths are the paths of the fields that can be expanded in
+// a mandate.
+type MandateExpandPaths struct {
+	PaymentMethod PaymentMethodExpandPaths1
+}
+
+// MandateExpand contains the paths of the fields that can be expanded in
+// a mandate.
+var MandateExpand = MandateExpandPaths{
+	PaymentMethod: newPaymentMethodExpandPaths1("payment_method"),
+}
+
+// OrderExpandPaths are the paths of the fields that can be expanded in
+// an order.
+type OrderExpandPaths struct {
+	Charge   ChargeExpandPaths1
+	Customer CustomerExpandPaths1
+}
+
+// OrderExpand contains the paths of the fields that can be expanded in
+// an order.
+var OrderExpand = OrderExpandPaths{
+	Charge:   newChargeExpandPaths1("charge"),
+	Customer: newCustomerExpandPaths1("customer"),
+}
+
+// OrderReturnExpandPaths are the paths of the fields that can be expanded in
+// an order return.
+type OrderReturnExpandPaths struct {
+	Order  OrderExpandPaths1
+	Refund RefundExpandPaths1
+}
+
+// OrderReturnExpand contains the paths of the fields that can be expanded in
+// an order return.
+var OrderReturnExpand = OrderReturnExpandPaths{
+	Order:  newOrderExpandPaths1("order"),
+	Refund: newRefundExpandPaths1("refund"),
+}
+
+// PaymentIntentExpandPaths are the paths of the fields that can be expanded in
+// a payment intent.
+type PaymentIntentExpandPaths struct {
+	Application      ExpandPath
+	Customer         CustomerExpandPaths1
+	Invoice          InvoiceExpandPaths1
+	LastPaymentError ErrorExpandPaths1
+	OnBehalfOf       AccountExpandPaths1
+	PaymentMethod    PaymentMethodExpandPaths1
+	Review           ReviewExpandPaths1
+	Source           ExpandPath
+	TransferData     PaymentIntentTransferDataExpandPaths1
+}
+
+// PaymentIntentExpand contains the paths of the fields that can be expanded in
+// a payment intent.
+var PaymentIntentExpand = PaymentIntentExpandPaths{
+	Application:      "application",
+	Customer:         newCustomerExpandPaths1("customer"),
+	Invoice:          newInvoiceExpandPaths1("invoice"),
+	LastPaymentError: newErrorExpandPaths1("last_payment_error"),
+	OnBehalfOf:       newAccountExpandPaths1("on_behalf_of"),
+	PaymentMethod:    newPaymentMethodExpandPaths1("payment_method"),
+	Review:           newReviewExpandPaths1("review"),
+	Source:           "source",
+	TransferData:     newPaymentIntentTransferDataExpandPaths1("transfer_data"),
+}
+
+// PaymentMethodExpandPaths are the paths of the fields that can be expanded in
+// a payment method.
+type PaymentMethodExpandPaths struct {
+	Customer CustomerExpandPaths1
+}
+
+// PaymentMethodExpand contains the paths of the fields that can be expanded in
+// a payment method.
+var PaymentMethodExpand = PaymentMethodExpandPaths{
+	Customer: newCustomerExpandPaths1("customer"),
+}
+
+// PayoutExpandPaths are the paths of the fields that can be expanded in
+// a payout.
+type PayoutExpandPaths struct {
+	BalanceTransaction        BalanceTransactionExpandPaths1
+	BankAccount               BankAccountExpandPaths1
+	Card                      CardExpandPaths1
+	Destination               ExpandPath
+	FailureBalanceTransaction BalanceTransactionExpandPaths1
+}
+
+// PayoutExpand contains the paths of the fields that can be expanded in
+// a payout.
+var PayoutExpand = PayoutExpandPaths{
+	BalanceTransaction:        newBalanceTransactionExpandPaths1("balance_transaction"),
+	BankAccount:               newBankAccountExpandPaths1("bank_account"),
+	Card:                      newCardExpandPaths1("card"),
+	Destination:               "destination",
+	FailureBalanceTransaction: newBalanceTransactionExpandPaths1("failure_balance_transaction"),
+}
+
+// PersonExpandPaths are the paths of the fields that can be expanded in
+// a person.
+type PersonExpandPaths struct {
+	Verification PersonVerificationExpandPaths1
+}
+
+// PersonExpand contains the paths of the fields that can be expanded in
+// a person.
+var PersonExpand = PersonExpandPaths{
+	Verification: newPersonVerificationExpandPaths1("verification"),
+}
+
+// PlanExpandPaths are the paths of the fields that can be expanded in
+// a plan.
+type PlanExpandPaths struct {
+	Product ExpandPath
+}
+
+// PlanExpand contains the paths of the fields that can be expanded in
+// a plan.
+var PlanExpand = PlanExpandPaths{
+	Product: "product",
+}
+
+// RecipientExpandPaths are the paths of the fields that can be expanded in
+// a recipient.
+type RecipientExpandPaths struct {
+	ActiveAccount BankAccountExpandPaths1
+	DefaultCard   CardExpandPaths1
+	MigratedTo    AccountExpandPaths1
+}
+
+// RecipientExpand contains the paths of the fields that can be expanded in
+// a recipient.
+var RecipientExpand = RecipientExpandPaths{
+	ActiveAccount: newBankAccountExpandPaths1("active_account"),
+	DefaultCard:   newCardExpandPaths1("default_card"),
+	MigratedTo:    newAccountExpandPaths1("migrated_to"),
+}
+
+// RecipientTransferExpandPaths are the paths of the fields that can be expanded in
+// a recipient transfer.
+type RecipientTransferExpandPaths struct {
+	BalanceTransaction BalanceTransactionExpandPaths1
+	BankAccount        BankAccountExpandPaths1
+	Card               CardExpandPaths1
+	Recipient          RecipientExpandPaths1
+	SourceTransaction  ExpandPath
+}
+
+// RecipientTransferExpand contains the paths of the fields that can be expanded in
+// a recipient transfer.
+var RecipientTransferExpand = RecipientTransferExpandPaths{
+	BalanceTransaction: newBalanceTransactionExpandPaths1("balance_transaction"),
+	BankAccount:        newBankAccountExpandPaths1("bank_account"),
+	Card:               newCardExpandPaths1("card"),
+	Recipient:          newRecipientExpandPaths1("recipient"),
+	SourceTransaction:  "source_transaction",
+}
+
+// RefundExpandPaths are the paths of the fields that can be expanded in
+// a refund.
+type RefundExpandPaths struct {
+	BalanceTransaction        BalanceTransactionExpandPaths1
+	Charge                    ChargeExpandPaths1
+	FailureBalanceTransaction BalanceTransactionExpandPaths1
+	PaymentIntent             PaymentIntentExpandPaths1
+	SourceTransferReversal    ReversalExpandPaths1
+	TransferReversal          ReversalExpandPaths1
+}
+
+// RefundExpand contains the paths of the fields that can be expanded in
+// a refund.
+var RefundExpand = RefundExpandPaths{
+	BalanceTransaction:        newBalanceTransactionExpandPaths1("balance_transaction"),
+	Charge:                    newChargeExpandPaths1("charge"),
+	FailureBalanceTransaction: newBalanceTransactionExpandPaths1("failure_balance_transaction"),
+	PaymentIntent:             newPaymentIntentExpandPaths1("payment_intent"),
+	SourceTransferReversal:    newReversalExpandPaths1("source_transfer_reversal"),
+	TransferReversal:          newReversalExpandPaths1("transfer_reversal"),
+}
+
+// ReversalExpandPaths are the paths of the fields that can be expanded in
+// a reversal.
+type ReversalExpandPaths struct {
+	BalanceTransaction       BalanceTransactionExpandPaths1
+	DestinationPaymentRefund RefundExpandPaths1
+	SourceRefund             RefundExpandPaths1
+}
+
+// ReversalExpand contains the paths of the fields that can be expanded in
+// a reversal.
+var ReversalExpand = ReversalExpandPaths{
+	BalanceTransaction:       newBalanceTransactionExpandPaths1("balance_transaction"),
+	DestinationPaymentRefund: newRefundExpandPaths1("destination_payment_refund"),
+	SourceRefund:             newRefundExpandPaths1("source_refund"),
+}
+
+// ReviewExpandPaths are the paths of the fields that can be expanded in
+// a review.
+type ReviewExpandPaths struct {
+	Charge        ChargeExpandPaths1
+	PaymentIntent PaymentIntentExpandPaths1
+}
+
+// ReviewExpand contains the paths of the fields that can be expanded in
+// a review.
+var ReviewExpand = ReviewExpandPaths{
+	Charge:        newChargeExpandPaths1("charge"),
+	PaymentIntent: newPaymentIntentExpandPaths1("payment_intent"),
+}
+
+// SKUExpandPaths are the paths of the fields that can be expanded in
+// a SKU.
+type SKUExpandPaths struct {
+	Product ExpandPath
+}
+
+// SKUExpand contains the paths of the fields that can be expanded in
+// a SKU.
+var SKUExpand = SKUExpandPaths{
+	Product: "product",
+}
+
+// SetupIntentExpandPaths are the paths of the fields that can be expanded in
+// a setup intent.
+type SetupIntentExpandPaths struct {
+	Application      ExpandPath
+	Customer         CustomerExpandPaths1
+	LastSetupError   ErrorExpandPaths1
+	Mandate          MandateExpandPaths1
+	OnBehalfOf       AccountExpandPaths1
+	PaymentMethod    PaymentMethodExpandPaths1
+	SingleUseMandate MandateExpandPaths1
+}
+
+// SetupIntentExpand contains the paths of the fields that can be expanded in
+// a setup intent.
+var SetupIntentExpand = SetupIntentExpandPaths{
+	Application:      "application",
+	Customer:         newCustomerExpandPaths1("customer"),
+	LastSetupError:   newErrorExpandPaths1("last_setup_error"),
+	Mandate:          newMandateExpandPaths1("mandate"),
+	OnBehalfOf:       newAccountExpandPaths1("on_behalf_of"),
+	PaymentMethod:    newPaymentMethodExpandPaths1("payment_method"),
+	SingleUseMandate: newMandateExpandPaths1("single_use_mandate"),
+}
+
+// SigmaScheduledQueryRunExpandPaths are the paths of the fields that can be expanded in
+// a sigma scheduled query run.
+type SigmaScheduledQueryRunExpandPaths struct {
+	File ExpandPath
+}
+
+// SigmaScheduledQueryRunExpand contains the paths of the fields that can be expanded in
+// a sigma scheduled query run.
+var SigmaScheduledQueryRunExpand = SigmaScheduledQueryRunExpandPaths{
+	File: "file",
+}
+
+// SubscriptionExpandPaths are the paths of the fields that can be expanded in
+// a subscription.
+type SubscriptionExpandPaths struct {
+	Customer             CustomerExpandPaths1
+	DefaultPaymentMethod PaymentMethodExpandPaths1
+	DefaultSource        ExpandPath
+	Discount             DiscountExpandPaths1
+	LatestInvoice        InvoiceExpandPaths1
+	OnBehalfOf           AccountExpandPaths1
+	PendingSetupIntent   SetupIntentExpandPaths1
+	Plan                 PlanExpandPaths1
+	Schedule             SubscriptionScheduleExpandPaths1
+	TransferData         SubscriptionTransferDataExpandPaths1
+}
+
+// SubscriptionExpand contains the paths of the fields that can be expanded in
+// a subscription.
+var SubscriptionExpand = SubscriptionExpandPaths{
+	Customer:             newCustomerExpandPaths1("customer"),
+	DefaultPaymentMethod: newPaymentMethodExpandPaths1("default_payment_method"),
+	DefaultSource:        "default_source",
+	Discount:             newDiscountExpandPaths1("discount"),
+	LatestInvoice:        newInvoiceExpandPaths1("latest_invoice"),
+	OnBehalfOf:           newAccountExpandPaths1("on_behalf_of"),
+	PendingSetupIntent:   newSetupIntentExpandPaths1("pending_setup_intent"),
+	Plan:                 newPlanExpandPaths1("plan"),
+	Schedule:             newSubscriptionScheduleExpandPaths1("schedule"),
+	TransferData:         newSubscriptionTransferDataExpandPaths1("transfer_data"),
+}
+
+// SubscriptionScheduleExpandPaths are the paths of the fields that can be expanded in
+// a subscription schedule.
+type SubscriptionScheduleExpandPaths struct {
+	Customer             CustomerExpandPaths1
+	DefaultSettings      SubscriptionScheduleDefaultSettingsExpandPaths1
+	ReleasedSubscription SubscriptionExpandPaths1
+	Subscription         SubscriptionExpandPaths1
+}
+
+// SubscriptionScheduleExpand contains the paths of the fields that can be expanded in
+// a subscription schedule.
+var SubscriptionScheduleExpand = SubscriptionScheduleExpandPaths{
+	Customer:             newCustomerExpandPaths1("customer"),
+	DefaultSettings:      newSubscriptionScheduleDefaultSettingsExpandPaths1("default_settings"),
+	ReleasedSubscription: newSubscriptionExpandPaths1("released_subscription"),
+	Subscription:         newSubscriptionExpandPaths1("subscription"),
+}
+
+// TaxIDExpandPaths are the paths of the fields that can be expanded in
+// a tax ID.
+type TaxIDExpandPaths struct {
+	Customer CustomerExpandPaths1
+}
+
+// TaxIDExpand contains the paths of the fields that can be expanded in
+// a tax ID.
+var TaxIDExpand = TaxIDExpandPaths{
+	Customer: newCustomerExpandPaths1("customer"),
+}
+
+// TransferExpandPaths are the paths of the fields that can be expanded in
+// a transfer.
+type TransferExpandPaths struct {
+	BalanceTransaction BalanceTransactionExpandPaths1
+	Destination        ExpandPath
+	DestinationPayment ChargeExpandPaths1
+	SourceTransaction  ExpandPath
+}
+
+// TransferExpand contains the paths of the fields that can be expanded in
+// a transfer.
+var TransferExpand = TransferExpandPaths{
+	BalanceTransaction: newBalanceTransactionExpandPaths1("balance_transaction"),
+	Destination:        "destination",
+	DestinationPayment: newChargeExpandPaths1("destination_payment"),
+	SourceTransaction:  "source_transaction",
+}
+
+// AccountCompanyExpandPaths1 are the paths of the expandable fields of an account company
+// at level 1 of a path.
+type AccountCompanyExpandPaths1 struct {
+	Verification AccountCompanyVerificationExpandPaths2
+}
+
+func newAccountCompanyExpandPaths1(path ExpandPath) AccountCompanyExpandPaths1 {
+	return AccountCompanyExpandPaths1{
+		Verification: newAccountCompanyVerificationExpandPaths2(path + ".verification"),
+	}
+}
+
+// PersonExpandPaths1 is the path of an expandable person at level 1 of
+// a path, along with the paths of its own expandable fields.
+type PersonExpandPaths1 struct {
+	ExpandPath
+
+	Verification PersonVerificationExpandPaths2
+}
+
+func newPersonExpandPaths1(path ExpandPath) PersonExpandPaths1 {
+	return PersonExpandPaths1{
+		ExpandPath:   path,
+		Verification: newPersonVerificationExpandPaths2(path + ".verification"),
+	}
+}
+
+// AccountSettingsExpandPaths1 are the paths of the expandable fields of an account settings
+// at level 1 of a path.
+type AccountSettingsExpandPaths1 struct {
+	Branding AccountSettingsBrandingExpandPaths2
+}
+
+func newAccountSettingsExpandPaths1(path ExpandPath) AccountSettingsExpandPaths1 {
+	return AccountSettingsExpandPaths1{
+		Branding: newAccountSettingsBrandingExpandPaths2(path + ".branding"),
+	}
+}
+
+// AccountExpandPaths1 is the path of an expandable account at level 1 of
+// a path, along with the paths of its own expandable fields.
+type AccountExpandPaths1 struct {
+	ExpandPath
+
+	Individual ExpandPath
+	Settings   AccountSettingsExpandPaths2
+}
+
+func newAccountExpandPaths1(path ExpandPath) AccountExpandPaths1 {
+	return AccountExpandPaths1{
+		ExpandPath: path,
+		Individual: path + ".individual",
+		Settings:   newAccountSettingsExpandPaths2(path + ".settings"),
+	}
+}
+
+// BalanceTransactionExpandPaths1 is the path of an expandable balance transaction at level 1 of
+// a path, along with the paths of its own expandable fields.
+type BalanceTransactionExpandPaths1 struct {
+	ExpandPath
+
+	Source ExpandPath
+}
+
+func newBalanceTransactionExpandPaths1(path ExpandPath) BalanceTransactionExpandPaths1 {
+	return BalanceTransactionExpandPaths1{
+		ExpandPath: path,
+		Source:     path + ".source",
+	}
+}
+
+// ChargeExpandPaths1 is the path of an expandable charge at level 1 of
+// a path, along with the paths of its own expandable fields.
+type ChargeExpandPaths1 struct {
+	ExpandPath
+
+	Application        ExpandPath
+	ApplicationFee     ApplicationFeeExpandPaths2
+	BalanceTransaction BalanceTransactionExpandPaths2
+	Customer           CustomerExpandPaths2
+	Destination        AccountExpandPaths2
+	Dispute            DisputeExpandPaths2
+	Invoice            InvoiceExpandPaths2
+	OnBehalfOf         AccountExpandPaths2
+	Outcome            ChargeOutcomeExpandPaths2
+	Review             ReviewExpandPaths2
+	Source             ExpandPath
+	SourceTransfer     TransferExpandPaths2
+	Transfer           TransferExpandPaths2
+	TransferData       ChargeTransferDataExpandPaths2
+}
+
+func newChargeExpandPaths1(path ExpandPath) ChargeExpandPaths1 {
+	return ChargeExpandPaths1{
+		ExpandPath:         path,
+		Application:        path + ".application",
+		ApplicationFee:     newApplicationFeeExpandPaths2(path + ".application_fee"),
+		BalanceTransaction: newBalanceTransactionExpandPaths2(path + ".balance_transaction"),
+		Customer:           newCustomerExpandPaths2(path + ".customer"),
+		Destination:        newAccountExpandPaths2(path + ".destination"),
+		Dispute:            newDisputeExpandPaths2(path + ".dispute"),
+		Invoice:            newInvoiceExpandPaths2(path + ".invoice"),
+		OnBehalfOf:         newAccountExpandPaths2(path + ".on_behalf_of"),
+		Outcome:            newChargeOutcomeExpandPaths2(path + ".outcome"),
+		Review:             newReviewExpandPaths2(path + ".review"),
+		Source:             path + ".source",
+		SourceTransfer:     newTransferExpandPaths2(path + ".source_transfer"),
+		Transfer:           newTransferExpandPaths2(path + ".transfer"),
+		TransferData:       newChargeTransferDataExpandPaths2(path + ".transfer_data"),
+	}
+}
+
+// CustomerExpandPaths1 is the path of an expandable customer at level 1 of
+// a path, along with the paths of its own expandable fields.
+type CustomerExpandPaths1 struct {
+	ExpandPath
+
+	DefaultSource   ExpandPath
+	Discount        DiscountExpandPaths2
+	InvoiceSettings CustomerInvoiceSettingsExpandPaths2
+}
+
+func newCustomerExpandPaths1(path ExpandPath) CustomerExpandPaths1 {
+	return CustomerExpandPaths1{
+		ExpandPath:      path,
+		DefaultSource:   path + ".default_source",
+		Discount:        newDiscountExpandPaths2(path + ".discount"),
+		InvoiceSettings: newCustomerInvoiceSettingsExpandPaths2(path + ".invoice_settings"),
+	}
+}
+
+// RecipientExpandPaths1 is the path of an expandable recipient at level 1 of
+// a path, along with the paths of its own expandable fields.
+type RecipientExpandPaths1 struct {
+	ExpandPath
+
+	ActiveAccount BankAccountExpandPaths2
+	DefaultCard   CardExpandPaths2
+	MigratedTo    AccountExpandPaths2
+}
+
+func newRecipientExpandPaths1(path ExpandPath) RecipientExpandPaths1 {
+	return RecipientExpandPaths1{
+		ExpandPath:    path,
+		ActiveAccount: newBankAccountExpandPaths2(path + ".active_account"),
+		DefaultCard:   newCardExpandPaths2(path + ".default_card"),
+		MigratedTo:    newAccountExpandPaths2(path + ".migrated_to"),
+	}
+}
+
+// ThreeDSecureExpandPaths1 are the paths of the expandable fields of a three d secure
+// at level 1 of a path.
+type ThreeDSecureExpandPaths1 struct {
+	Card CardExpandPaths2
+}
+
+func newThreeDSecureExpandPaths1(path ExpandPath) ThreeDSecureExpandPaths1 {
+	return ThreeDSecureExpandPaths1{
+		Card: newCardExpandPaths2(path + ".card"),
+	}
+}
+
+// ApplicationFeeExpandPaths1 is the path of an expandable application fee at level 1 of
+// a path, along with the paths of its own expandable fields.
+type ApplicationFeeExpandPaths1 struct {
+	ExpandPath
+
+	Account                AccountExpandPaths2
+	BalanceTransaction     BalanceTransactionExpandPaths2
+	Charge                 ChargeExpandPaths2
+	OriginatingTransaction ChargeExpandPaths2
+}
+
+func newApplicationFeeExpandPaths1(path ExpandPath) ApplicationFeeExpandPaths1 {
+	return ApplicationFeeExpandPaths1{
+		ExpandPath:             path,
+		Account:                newAccountExpandPaths2(path + ".account"),
+		BalanceTransaction:     newBalanceTransactionExpandPaths2(path + ".balance_transaction"),
+		Charge:                 newChargeExpandPaths2(path + ".charge"),
+		OriginatingTransaction: newChargeExpandPaths2(path + ".originating_transaction"),
+	}
+}
+
+// DisputeExpandPaths1 is the path of an expandable dispute at level 1 of
+// a path, along with the paths of its own expandable fields.
+type DisputeExpandPaths1 struct {
+	ExpandPath
+
+	Charge        ChargeExpandPaths2
+	Evidence      DisputeEvidenceExpandPaths2
+	PaymentIntent PaymentIntentExpandPaths2
+}
+
+func newDisputeExpandPaths1(path ExpandPath) DisputeExpandPaths1 {
+	return DisputeExpandPaths1{
+		ExpandPath:    path,
+		Charge:        newChargeExpandPaths2(path + ".charge"),
+		Evidence:      newDisputeEvidenceExpandPaths2(path + ".evidence"),
+		PaymentIntent: newPaymentIntentExpandPaths2(path + ".payment_intent"),
+	}
+}
+
+// InvoiceExpandPaths1 is the path of an expandable invoice at level 1 of
+// a path, along with the paths of its own expandable fields.
+type InvoiceExpandPaths1 struct {
+	ExpandPath
+
+	Charge               ChargeExpandPaths2
+	Customer             CustomerExpandPaths2
+	DefaultPaymentMethod PaymentMethodExpandPaths2
+	DefaultSource        ExpandPath
+	Discount             DiscountExpandPaths2
+	PaymentIntent        PaymentIntentExpandPaths2
+	TransferData         InvoiceTransferDataExpandPaths2
+}
+
+func newInvoiceExpandPaths1(path ExpandPath) InvoiceExpandPaths1 {
+	return InvoiceExpandPaths1{
+		ExpandPath:           path,
+		Charge:               newChargeExpandPaths2(path + ".charge"),
+		Customer:             newCustomerExpandPaths2(path + ".customer"),
+		DefaultPaymentMethod: newPaymentMethodExpandPaths2(path + ".default_payment_method"),
+		DefaultSource:        path + ".default_source",
+		Discount:             newDiscountExpandPaths2(path + ".discount"),
+		PaymentIntent:        newPaymentIntentExpandPaths2(path + ".payment_intent"),
+		TransferData:         newInvoiceTransferDataExpandPaths2(path + ".transfer_data"),
+	}
+}
+
+// ChargeOutcomeExpandPaths1 are the paths of the expandable fields of a charge outcome
+// at level 1 of a path.
+type ChargeOutcomeExpandPaths1 struct {
+	Rule ExpandPath
+}
+
+func newChargeOutcomeExpandPaths1(path ExpandPath) ChargeOutcomeExpandPaths1 {
+	return ChargeOutcomeExpandPaths1{
+		Rule: path + ".rule",
+	}
+}
+
+// ReviewExpandPaths1 is the path of an expandable review at level 1 of
+// a path, along with the paths of its own expandable fields.
+type ReviewExpandPaths1 struct {
+	ExpandPath
+
+	Charge        ChargeExpandPaths2
+	PaymentIntent PaymentIntentExpandPaths2
+}
+
+func newReviewExpandPaths1(path ExpandPath) ReviewExpandPaths1 {
+	return ReviewExpandPaths1{
+		ExpandPath:    path,
+		Charge:        newChargeExpandPaths2(path + ".charge"),
+		PaymentIntent: newPaymentIntentExpandPaths2(path + ".payment_intent"),
+	}
+}
+
+// TransferExpandPaths1 is the path of an expandable transfer at level 1 of
+// a path, along with the paths of its own expandable fields.
+type TransferExpandPaths1 struct {
+	ExpandPath
+
+	BalanceTransaction BalanceTransactionExpandPaths2
+	Destination        ExpandPath
+	DestinationPayment ChargeExpandPaths2
+	SourceTransaction  ExpandPath
+}
+
+func newTransferExpandPaths1(path ExpandPath) TransferExpandPaths1 {
+	return TransferExpandPaths1{
+		ExpandPath:         path,
+		BalanceTransaction: newBalanceTransactionExpandPaths2(path + ".balance_transaction"),
+		Destination:        path + ".destination",
+		DestinationPayment: newChargeExpandPaths2(path + ".destination_payment"),
+		SourceTransaction:  path + ".source_transaction",
+	}
+}
+
+// ChargeTransferDataExpandPaths1 are the paths of the expandable fields of a charge transfer data
+// at level 1 of a path.
+type ChargeTransferDataExpandPaths1 struct {
+	Destination AccountExpandPaths2
+}
+
+func newChargeTransferDataExpandPaths1(path ExpandPath) ChargeTransferDataExpandPaths1 {
+	return ChargeTransferDataExpandPaths1{
+		Destination: newAccountExpandPaths2(path + ".destination"),
+	}
+}
+
+// PaymentIntentExpandPaths1 is the path of an expandable payment intent at level 1 of
+// a path, along with the paths of its own expandable fields.
+type PaymentIntentExpandPaths1 struct {
+	ExpandPath
+
+	Application      ExpandPath
+	Customer         CustomerExpandPaths2
+	Invoice          InvoiceExpandPaths2
+	LastPaymentError ErrorExpandPaths2
+	OnBehalfOf       AccountExpandPaths2
+	PaymentMethod    PaymentMethodExpandPaths2
+	Review           ReviewExpandPaths2
+	Source           ExpandPath
+	TransferData     PaymentIntentTransferDataExpandPaths2
+}
+
+func newPaymentIntentExpandPaths1(path ExpandPath) PaymentIntentExpandPaths1 {
+	return PaymentIntentExpandPaths1{
+		ExpandPath:       path,
+		Application:      path + ".application",
+		Customer:         newCustomerExpandPaths2(path + ".customer"),
+		Invoice:          newInvoiceExpandPaths2(path + ".invoice"),
+		LastPaymentError: newErrorExpandPaths2(path + ".last_payment_error"),
+		OnBehalfOf:       newAccountExpandPaths2(path + ".on_behalf_of"),
+		PaymentMethod:    newPaymentMethodExpandPaths2(path + ".payment_method"),
+		Review:           newReviewExpandPaths2(path + ".review"),
+		Source:           path + ".source",
+		TransferData:     newPaymentIntentTransferDataExpandPaths2(path + ".transfer_data"),
+	}
+}
+
+// SetupIntentExpandPaths1 is the path of an expandable setup intent at level 1 of
+// a path, along with the paths of its own expandable fields.
+type SetupIntentExpandPaths1 struct {
+	ExpandPath
+
+	Application      ExpandPath
+	Customer         CustomerExpandPaths2
+	LastSetupError   ErrorExpandPaths2
+	Mandate          MandateExpandPaths2
+	OnBehalfOf       AccountExpandPaths2
+	PaymentMethod    PaymentMethodExpandPaths2
+	SingleUseMandate MandateExpandPaths2
+}
+
+func newSetupIntentExpandPaths1(path ExpandPath) SetupIntentExpandPaths1 {
+	return SetupIntentExpandPaths1{
+		ExpandPath:       path,
+		Application:      path + ".application",
+		Customer:         newCustomerExpandPaths2(path + ".customer"),
+		LastSetupError:   newErrorExpandPaths2(path + ".last_setup_error"),
+		Mandate:          newMandateExpandPaths2(path + ".mandate"),
+		OnBehalfOf:       newAccountExpandPaths2(path + ".on_behalf_of"),
+		PaymentMethod:    newPaymentMethodExpandPaths2(path + ".payment_method"),
+		SingleUseMandate: newMandateExpandPaths2(path + ".single_use_mandate"),
+	}
+}
+
+// SubscriptionExpandPaths1 is the path of an expandable subscription at level 1 of
+// a path, along with the paths of its own expandable fields.
+type SubscriptionExpandPaths1 struct {
+	ExpandPath
+
+	Customer             CustomerExpandPaths2
+	DefaultPaymentMethod PaymentMethodExpandPaths2
+	DefaultSource        ExpandPath
+	Discount             DiscountExpandPaths2
+	LatestInvoice        InvoiceExpandPaths2
+	OnBehalfOf           AccountExpandPaths2
+	PendingSetupIntent   SetupIntentExpandPaths2
+	Plan                 PlanExpandPaths2
+	Schedule             SubscriptionScheduleExpandPaths2
+	TransferData         SubscriptionTransferDataExpandPaths2
+}
+
+func newSubscriptionExpandPaths1(path ExpandPath) SubscriptionExpandPaths1 {
+	return SubscriptionExpandPaths1{
+		ExpandPath:           path,
+		Customer:             newCustomerExpandPaths2(path + ".customer"),
+		DefaultPaymentMethod: newPaymentMethodExpandPaths2(path + ".default_payment_method"),
+		DefaultSource:        path + ".default_source",
+		Discount:             newDiscountExpandPaths2(path + ".discount"),
+		LatestInvoice:        newInvoiceExpandPaths2(path + ".latest_invoice"),
+		OnBehalfOf:           newAccountExpandPaths2(path + ".on_behalf_of"),
+		PendingSetupIntent:   newSetupIntentExpandPaths2(path + ".pending_setup_intent"),
+		Plan:                 newPlanExpandPaths2(path + ".plan"),
+		Schedule:             newSubscriptionScheduleExpandPaths2(path + ".schedule"),
+		TransferData:         newSubscriptionTransferDataExpandPaths2(path + ".transfer_data"),
+	}
+}
+
+// CustomerBalanceTransactionExpandPaths1 is the path of an expandable customer balance transaction at level 1 of
+// a path, along with the paths of its own expandable fields.
+type CustomerBalanceTransactionExpandPaths1 struct {
+	ExpandPath
+
+	CreditNote CreditNoteExpandPaths2
+	Customer   CustomerExpandPaths2
+	Invoice    InvoiceExpandPaths2
+}
+
+func newCustomerBalanceTransactionExpandPaths1(path ExpandPath) CustomerBalanceTransactionExpandPaths1 {
+	return CustomerBalanceTransactionExpandPaths1{
+		ExpandPath: path,
+		CreditNote: newCreditNoteExpandPaths2(path + ".credit_note"),
+		Customer:   newCustomerExpandPaths2(path + ".customer"),
+		Invoice:    newInvoiceExpandPaths2(path + ".invoice"),
+	}
+}
+
+// RefundExpandPaths1 is the path of an expandable refund at level 1 of
+// a path, along with the paths of its own expandable fields.
+type RefundExpandPaths1 struct {
+	ExpandPath
+
+	BalanceTransaction        BalanceTransactionExpandPaths2
+	Charge                    ChargeExpandPaths2
+	FailureBalanceTransaction BalanceTransactionExpandPaths2
+	PaymentIntent             PaymentIntentExpandPaths2
+	SourceTransferReversal    ReversalExpandPaths2
+	TransferReversal          ReversalExpandPaths2
+}
+
+func newRefundExpandPaths1(path ExpandPath) RefundExpandPaths1 {
+	return RefundExpandPaths1{
+		ExpandPath:                path,
+		BalanceTransaction:        newBalanceTransactionExpandPaths2(path + ".balance_transaction"),
+		Charge:                    newChargeExpandPaths2(path + ".charge"),
+		FailureBalanceTransaction: newBalanceTransactionExpandPaths2(path + ".failure_balance_transaction"),
+		PaymentIntent:             newPaymentIntentExpandPaths2(path + ".payment_intent"),
+		SourceTransferReversal:    newReversalExpandPaths2(path + ".source_transfer_reversal"),
+		TransferReversal:          newReversalExpandPaths2(path + ".transfer_reversal"),
+	}
+}
+
+// DiscountExpandPaths1 are the paths of the expandable fields of a discount
+// at level 1 of a path.
+type DiscountExpandPaths1 struct {
+	Coupon ExpandPath
+}
+
+func newDiscountExpandPaths1(path ExpandPath) DiscountExpandPaths1 {
+	return DiscountExpandPaths1{
+		Coupon: path + ".coupon",
+	}
+}
+
+// CustomerInvoiceSettingsExpandPaths1 are the paths of the expandable fields of a customer invoice settings
+// at level 1 of a path.
+type CustomerInvoiceSettingsExpandPaths1 struct {
+	DefaultPaymentMethod PaymentMethodExpandPaths2
+}
+
+func newCustomerInvoiceSettingsExpandPaths1(path ExpandPath) CustomerInvoiceSettingsExpandPaths1 {
+	return CustomerInvoiceSettingsExpandPaths1{
+		DefaultPaymentMethod: newPaymentMethodExpandPaths2(path + ".default_payment_method"),
+	}
+}
+
+// CreditNoteExpandPaths1 is the path of an expandable credit note at level 1 of
+// a path, along with the paths of its own expandable fields.
+type CreditNoteExpandPaths1 struct {
+	ExpandPath
+
+	Customer                   CustomerExpandPaths2
+	CustomerBalanceTransaction CustomerBalanceTransactionExpandPaths2
+	Invoice                    InvoiceExpandPaths2
+	Refund                     RefundExpandPaths2
+}
+
+func newCreditNoteExpandPaths1(path ExpandPath) CreditNoteExpandPaths1 {
+	return CreditNoteExpandPaths1{
+		ExpandPath:                 path,
+		Customer:                   newCustomerExpandPaths2(path + ".customer"),
+		CustomerBalanceTransaction: newCustomerBalanceTransactionExpandPaths2(path + ".customer_balance_transaction"),
+		Invoice:                    newInvoiceExpandPaths2(path + ".invoice"),
+		Refund:                     newRefundExpandPaths2(path + ".refund"),
+	}
+}
+
+// DisputeEvidenceExpandPaths1 are the paths of the expandable fields of a dispute evidence
+// at level 1 of a path.
+type DisputeEvidenceExpandPaths1 struct {
+	CancellationPolicy           ExpandPath
+	CustomerCommunication        ExpandPath
+	CustomerSignature            ExpandPath
+	DuplicateChargeDocumentation ExpandPath
+	Receipt                      ExpandPath
+	RefundPolicy                 ExpandPath
+	ServiceDocumentation         ExpandPath
+	ShippingDocumentation        ExpandPath
+	UncategorizedFile            ExpandPath
+}
+
+func newDisputeEvidenceExpandPaths1(path ExpandPath) DisputeEvidenceExpandPaths1 {
+	return DisputeEvidenceExpandPaths1{
+		CancellationPolicy:           path + ".cancellation_policy",
+		CustomerCommunication:        path + ".customer_communication",
+		CustomerSignature:            path + ".customer_signature",
+		DuplicateChargeDocumentation: path + ".duplicate_charge_documentation",
+		Receipt:                      path + ".receipt",
+		RefundPolicy:                 path + ".refund_policy",
+		ServiceDocumentation:         path + ".service_documentation",
+		ShippingDocumentation:        path + ".shipping_documentation",
+		UncategorizedFile:            path + ".uncategorized_file",
+	}
+}
+
+// PaymentMethodExpandPaths1 is the path of an expandable payment method at level 1 of
+// a path, along with the paths of its own expandable fields.
+type PaymentMethodExpandPaths1 struct {
+	ExpandPath
+
+	Customer CustomerExpandPaths2
+}
+
+func newPaymentMethodExpandPaths1(path ExpandPath) PaymentMethodExpandPaths1 {
+	return PaymentMethodExpandPaths1{
+		ExpandPath: path,
+		Customer:   newCustomerExpandPaths2(path + ".customer"),
+	}
+}
+
+// InvoiceTransferDataExpandPaths1 are the paths of the expandable fields of an invoice transfer data
+// at level 1 of a path.
+type InvoiceTransferDataExpandPaths1 struct {
+	Destination AccountExpandPaths2
+}
+
+func newInvoiceTransferDataExpandPaths1(path ExpandPath) InvoiceTransferDataExpandPaths1 {
+	return InvoiceTransferDataExpandPaths1{
+		Destination: newAccountExpandPaths2(path + ".destination"),
+	}
+}
+
+// PlanExpandPaths1 is the path of an expandable plan at level 1 of
+// a path, along with the paths of its own expandable fields.
+type PlanExpandPaths1 struct {
+	ExpandPath
+
+	Product ExpandPath
+}
+
+func newPlanExpandPaths1(path ExpandPath) PlanExpandPaths1 {
+	return PlanExpandPaths1{
+		ExpandPath: path,
+		Product:    path + ".product",
+	}
+}
+
+// IssuingCardExpandPaths1 is the path of an expandable issuing card at level 1 of
+// a path, along with the paths of its own expandable fields.
+type IssuingCardExpandPaths1 struct {
+	ExpandPath
+
+	Cardholder     ExpandPath
+	ReplacementFor IssuingCardExpandPaths2
+}
+
+func newIssuingCardExpandPaths1(path ExpandPath) IssuingCardExpandPaths1 {
+	return IssuingCardExpandPaths1{
+		ExpandPath:     path,
+		Cardholder:     path + ".cardholder",
+		ReplacementFor: newIssuingCardExpandPaths2(path + ".replacement_for"),
+	}
+}
+
+// IssuingCardholderIndividualExpandPaths1 are the paths of the expandable fields of an issuing cardholder individual
+// at level 1 of a path.
+type IssuingCardholderIndividualExpandPaths1 struct {
+	Verification IssuingCardholderIndividualVerificationExpandPaths2
+}
+
+func newIssuingCardholderIndividualExpandPaths1(path ExpandPath) IssuingCardholderIndividualExpandPaths1 {
+	return IssuingCardholderIndividualExpandPaths1{
+		Verification: newIssuingCardholderIndividualVerificationExpandPaths2(path + ".verification"),
+	}
+}
+
+// IssuingDisputeEvidenceExpandPaths1 are the paths of the expandable fields of an issuing dispute evidence
+// at level 1 of a path.
+type IssuingDisputeEvidenceExpandPaths1 struct {
+	Fraudulent IssuingDisputeEvidenceFraudulentExpandPaths2
+	Other      IssuingDisputeEvidenceOtherExpandPaths2
+}
+
+func newIssuingDisputeEvidenceExpandPaths1(path ExpandPath) IssuingDisputeEvidenceExpandPaths1 {
+	return IssuingDisputeEvidenceExpandPaths1{
+		Fraudulent: newIssuingDisputeEvidenceFraudulentExpandPaths2(path + ".fraudulent"),
+		Other:      newIssuingDisputeEvidenceOtherExpandPaths2(path + ".other"),
+	}
+}
+
+// IssuingTransactionExpandPaths1 is the path of an expandable issuing transaction at level 1 of
+// a path, along with the paths of its own expandable fields.
+type IssuingTransactionExpandPaths1 struct {
+	ExpandPath
+
+	Authorization      IssuingAuthorizationExpandPaths2
+	BalanceTransaction BalanceTransactionExpandPaths2
+	Card               IssuingCardExpandPaths2
+	Cardholder         ExpandPath
+	Dispute            IssuingDisputeExpandPaths2
+}
+
+func newIssuingTransactionExpandPaths1(path ExpandPath) IssuingTransactionExpandPaths1 {
+	return IssuingTransactionExpandPaths1{
+		ExpandPath:         path,
+		Authorization:      newIssuingAuthorizationExpandPaths2(path + ".authorization"),
+		BalanceTransaction: newBalanceTransactionExpandPaths2(path + ".balance_transaction"),
+		Card:               newIssuingCardExpandPaths2(path + ".card"),
+		Cardholder:         path + ".cardholder",
+		Dispute:            newIssuingDisputeExpandPaths2(path + ".dispute"),
+	}
+}
+
+// IssuingAuthorizationExpandPaths1 is the path of an expandable issuing authorization at level 1 of
+// a path, along with the paths of its own expandable fields.
+type IssuingAuthorizationExpandPaths1 struct {
+	ExpandPath
+
+	Card       IssuingCardExpandPaths2
+	Cardholder ExpandPath
+}
+
+func newIssuingAuthorizationExpandPaths1(path ExpandPath) IssuingAuthorizationExpandPaths1 {
+	return IssuingAuthorizationExpandPaths1{
+		ExpandPath: path,
+		Card:       newIssuingCardExpandPaths2(path + ".card"),
+		Cardholder: path + ".cardholder",
+	}
+}
+
+// IssuingDisputeExpandPaths1 is the path of an expandable issuing dispute at level 1 of
+// a path, along with the paths of its own expandable fields.
+type IssuingDisputeExpandPaths1 struct {
+	ExpandPath
+
+	Evidence    IssuingDisputeEvidenceExpandPaths2
+	Transaction IssuingTransactionExpandPaths2
+}
+
+func newIssuingDisputeExpandPaths1(path ExpandPath) IssuingDisputeExpandPaths1 {
+	return IssuingDisputeExpandPaths1{
+		ExpandPath:  path,
+		Evidence:    newIssuingDisputeEvidenceExpandPaths2(path + ".evidence"),
+		Transaction: newIssuingTransactionExpandPaths2(path + ".transaction"),
+	}
+}
+
+// OrderExpandPaths1 is the path of an expandable order at level 1 of
+// a path, along with the paths of its own expandable fields.
+type OrderExpandPaths1 struct {
+	ExpandPath
+
+	Charge   ChargeExpandPaths2
+	Customer CustomerExpandPaths2
+}
+
+func newOrderExpandPaths1(path ExpandPath) OrderExpandPaths1 {
+	return OrderExpandPaths1{
+		ExpandPath: path,
+		Charge:     newChargeExpandPaths2(path + ".charge"),
+		Customer:   newCustomerExpandPaths2(path + ".customer"),
+	}
+}
+
+// ErrorExpandPaths1 are the paths of the expandable fields of an error
+// at level 1 of a path.
+type ErrorExpandPaths1 struct {
+	PaymentIntent PaymentIntentExpandPaths2
+	PaymentMethod PaymentMethodExpandPaths2
+	SetupIntent   SetupIntentExpandPaths2
+	Source        ExpandPath
+}
+
+func newErrorExpandPaths1(path ExpandPath) ErrorExpandPaths1 {
+	return ErrorExpandPaths1{
+		PaymentIntent: newPaymentIntentExpandPaths2(path + ".payment_intent"),
+		PaymentMethod: newPaymentMethodExpandPaths2(path + ".payment_method"),
+		SetupIntent:   newSetupIntentExpandPaths2(path + ".setup_intent"),
+		Source:        path + ".source",
+	}
+}
+
+// PaymentIntentTransferDataExpandPaths1 are the paths of the expandable fields of a payment intent transfer data
+// at level 1 of a path.
+type PaymentIntentTransferDataExpandPaths1 struct {
+	Destination AccountExpandPaths2
+}
+
+func newPaymentIntentTransferDataExpandPaths1(path ExpandPath) PaymentIntentTransferDataExpandPaths1 {
+	return PaymentIntentTransferDataExpandPaths1{
+		Destination: newAccountExpandPaths2(path + ".destination"),
+	}
+}
+
+// BankAccountExpandPaths1 is the path of an expandable bank account at level 1 of
+// a path, along with the paths of its own expandable fields.
+type BankAccountExpandPaths1 struct {
+	ExpandPath
+
+	Account  AccountExpandPaths2
+	Customer CustomerExpandPaths2
+}
+
+func newBankAccountExpandPaths1(path ExpandPath) BankAccountExpandPaths1 {
+	return BankAccountExpandPaths1{
+		ExpandPath: path,
+		Account:    newAccountExpandPaths2(path + ".account"),
+		Customer:   newCustomerExpandPaths2(path + ".customer"),
+	}
+}
+
+// CardExpandPaths1 is the path of an expandable card at level 1 of
+// a path, along with the paths of its own expandable fields.
+type CardExpandPaths1 struct {
+	ExpandPath
+
+	Customer     CustomerExpandPaths2
+	Recipient    RecipientExpandPaths2
+	ThreeDSecure ThreeDSecureExpandPaths2
+}
+
+func newCardExpandPaths1(path ExpandPath) CardExpandPaths1 {
+	return CardExpandPaths1{
+		ExpandPath:   path,
+		Customer:     newCustomerExpandPaths2(path + ".customer"),
+		Recipient:    newRecipientExpandPaths2(path + ".recipient"),
+		ThreeDSecure: newThreeDSecureExpandPaths2(path + ".three_d_secure"),
+	}
+}
+
+// PersonVerificationExpandPaths1 are the paths of the expandable fields of a person verification
+// at level 1 of a path.
+type PersonVerificationExpandPaths1 struct {
+	AdditionalDocument PersonVerificationDocumentExpandPaths2
+	Document           PersonVerificationDocumentExpandPaths2
+}
+
+func newPersonVerificationExpandPaths1(path ExpandPath) PersonVerificationExpandPaths1 {
+	return PersonVerificationExpandPaths1{
+		AdditionalDocument: newPersonVerificationDocumentExpandPaths2(path + ".additional_document"),
+		Document:           newPersonVerificationDocumentExpandPaths2(path + ".document"),
+	}
+}
+
+// ReversalExpandPaths1 is the path of an expandable reversal at level 1 of
+// a path, along with the paths of its own expandable fields.
+type ReversalExpandPaths1 struct {
+	ExpandPath
+
+	BalanceTransaction       BalanceTransactionExpandPaths2
+	DestinationPaymentRefund RefundExpandPaths2
+	SourceRefund             RefundExpandPaths2
+}
+
+func newReversalExpandPaths1(path ExpandPath) ReversalExpandPaths1 {
+	return ReversalExpandPaths1{
+		ExpandPath:               path,
+		BalanceTransaction:       newBalanceTransactionExpandPaths2(path + ".balance_transaction"),
+		DestinationPaymentRefund: newRefundExpandPaths2(path + ".destination_payment_refund"),
+		SourceRefund:             newRefundExpandPaths2(path + ".source_refund"),
+	}
+}
+
+// MandateExpandPaths1 is the path of an expandable mandate at level 1 of
+// a path, along with the paths of its own expandable fields.
+type MandateExpandPaths1 struct {
+	ExpandPath
+
+	PaymentMethod PaymentMethodExpandPaths2
+}
+
+func newMandateExpandPaths1(path ExpandPath) MandateExpandPaths1 {
+	return MandateExpandPaths1{
+		ExpandPath:    path,
+		PaymentMethod: newPaymentMethodExpandPaths2(path + ".payment_method"),
+	}
+}
+
+// SubscriptionScheduleExpandPaths1 is the path of an expandable subscription schedule at level 1 of
+// a path, along with the paths of its own expandable fields.
+type SubscriptionScheduleExpandPaths1 struct {
+	ExpandPath
+
+	Customer             CustomerExpandPaths2
+	DefaultSettings      SubscriptionScheduleDefaultSettingsExpandPaths2
+	ReleasedSubscription SubscriptionExpandPaths2
+	Subscription         SubscriptionExpandPaths2
+}
+
+func newSubscriptionScheduleExpandPaths1(path ExpandPath) SubscriptionScheduleExpandPaths1 {
+	return SubscriptionScheduleExpandPaths1{
+		ExpandPath:           path,
+		Customer:             newCustomerExpandPaths2(path + ".customer"),
+		DefaultSettings:      newSubscriptionScheduleDefaultSettingsExpandPaths2(path + ".default_settings"),
+		ReleasedSubscription: newSubscriptionExpandPaths2(path + ".released_subscription"),
+		Subscription:         newSubscriptionExpandPaths2(path + ".subscription"),
+	}
+}
+
+// SubscriptionTransferDataExpandPaths1 are the paths of the expandable fields of a subscription transfer data
+// at level 1 of a path.
+type SubscriptionTransferDataExpandPaths1 struct {
+	Destination AccountExpandPaths2
+}
+
+func newSubscriptionTransferDataExpandPaths1(path ExpandPath) SubscriptionTransferDataExpandPaths1 {
+	return SubscriptionTransferDataExpandPaths1{
+		Destination: newAccountExpandPaths2(path + ".destination"),
+	}
+}
+
+// SubscriptionScheduleDefaultSettingsExpandPaths1 are the paths of the expandable fields of a subscription schedule default settings
+// at level 1 of a path.
+type SubscriptionScheduleDefaultSettingsExpandPaths1 struct {
+	DefaultPaymentMethod PaymentMethodExpandPaths2
+}
+
+func newSubscriptionScheduleDefaultSettingsExpandPaths1(path ExpandPath) SubscriptionScheduleDefaultSettingsExpandPaths1 {
+	return SubscriptionScheduleDefaultSettingsExpandPaths1{
+		DefaultPaymentMethod: newPaymentMethodExpandPaths2(path + ".default_payment_method"),
+	}
+}
+
+// AccountCompanyVerificationExpandPaths2 are the paths of the expandable fields of an account company verification
+// at level 2 of a path.
+type AccountCompanyVerificationExpandPaths2 struct {
+	Document AccountCompanyVerificationDocumentExpandPaths3
+}
+
+func newAccountCompanyVerificationExpandPaths2(path ExpandPath) AccountCompanyVerificationExpandPaths2 {
+	return AccountCompanyVerificationExpandPaths2{
+		Document: newAccountCompanyVerificationDocumentExpandPaths3(path + ".document"),
+	}
+}
+
+// PersonVerificationExpandPaths2 are the paths of the expandable fields of a person verification
+// at level 2 of a path.
+type PersonVerificationExpandPaths2 struct {
+	AdditionalDocument PersonVerificationDocumentExpandPaths3
+	Document           PersonVerificationDocumentExpandPaths3
+}
+
+func newPersonVerificationExpandPaths2(path ExpandPath) PersonVerificationExpandPaths2 {
+	return PersonVerificationExpandPaths2{
+		AdditionalDocument: newPersonVerificationDocumentExpandPaths3(path + ".additional_document"),
+		Document:           newPersonVerificationDocumentExpandPaths3(path + ".document"),
+	}
+}
+
+// AccountSettingsBrandingExpandPaths2 are the paths of the expandable fields of an account settings branding
+// at level 2 of a path.
+type AccountSettingsBrandingExpandPaths2 struct {
+	Icon ExpandPath
+	Logo ExpandPath
+}
+
+func newAccountSettingsBrandingExpandPaths2(path ExpandPath) AccountSettingsBrandingExpandPaths2 {
+	return AccountSettingsBrandingExpandPaths2{
+		Icon: path + ".icon",
+		Logo: path + ".logo",
+	}
+}
+
+// AccountSettingsExpandPaths2 are the paths of the expandable fields of an account settings
+// at level 2 of a path.
+type AccountSettingsExpandPaths2 struct {
+	Branding AccountSettingsBrandingExpandPaths3
+}
+
+func newAccountSettingsExpandPaths2(path ExpandPath) AccountSettingsExpandPaths2 {
+	return AccountSettingsExpandPaths2{
+		Branding: newAccountSettingsBrandingExpandPaths3(path + ".branding"),
+	}
+}
+
+// ApplicationFeeExpandPaths2 is the path of an expandable application fee at level 2 of
+// a path, along with the paths of its own expandable fields.
+type ApplicationFeeExpandPaths2 struct {
+	ExpandPath
+
+	Account                AccountExpandPaths3
+	BalanceTransaction     BalanceTransactionExpandPaths3
+	Charge                 ChargeExpandPaths3
+	OriginatingTransaction ChargeExpandPaths3
+}
+
+func newApplicationFeeExpandPaths2(path ExpandPath) ApplicationFeeExpandPaths2 {
+	return ApplicationFeeExpandPaths2{
+		ExpandPath:             path,
+		Account:                newAccountExpandPaths3(path + ".account"),
+		BalanceTransaction:     newBalanceTransactionExpandPaths3(path + ".balance_transaction"),
+		Charge:                 newChargeExpandPaths3(path + ".charge"),
+		OriginatingTransaction: newChargeExpandPaths3(path + ".originating_transaction"),
+	}
+}
+
+// BalanceTransactionExpandPaths2 is the path of an expandable balance transaction at level 2 of
+// a path, along with the paths of its own expandable fields.
+type BalanceTransactionExpandPaths2 struct {
+	ExpandPath
+
+	Source ExpandPath
+}
+
+func newBalanceTransactionExpandPaths2(path ExpandPath) BalanceTransactionExpandPaths2 {
+	return BalanceTransactionExpandPaths2{
+		ExpandPath: path,
+		Source:     path + ".source",
+	}
+}
+
+// CustomerExpandPaths2 is the path of an expandable customer at level 2 of
+// a path, along with the paths of its own expandable fields.
+type CustomerExpandPaths2 struct {
+	ExpandPath
+
+	DefaultSource   ExpandPath
+	Discount        DiscountExpandPaths3
+	InvoiceSettings CustomerInvoiceSettingsExpandPaths3
+}
+
+func newCustomerExpandPaths2(path ExpandPath) CustomerExpandPaths2 {
+	return CustomerExpandPaths2{
+		ExpandPath:      path,
+		DefaultSource:   path + ".default_source",
+		Discount:        newDiscountExpandPaths3(path + ".discount"),
+		InvoiceSettings: newCustomerInvoiceSettingsExpandPaths3(path + ".invoice_settings"),
+	}
+}
+
+// AccountExpandPaths2 is the path of an expandable account at level 2 of
+// a path, along with the paths of its own expandable fields.
+type AccountExpandPaths2 struct {
+	ExpandPath
+
+	Individual ExpandPath
+}
+
+func newAccountExpandPaths2(path ExpandPath) AccountExpandPaths2 {
+	return AccountExpandPaths2{
+		ExpandPath: path,
+		Individual: path + ".individual",
+	}
+}
+
+// DisputeExpandPaths2 is the path of an expandable dispute at level 2 of
+// a path, along with the paths of its own expandable fields.
+type DisputeExpandPaths2 struct {
+	ExpandPath
+
+	Charge        ChargeExpandPaths3
+	Evidence      DisputeEvidenceExpandPaths3
+	PaymentIntent PaymentIntentExpandPaths3
+}
+
+func newDisputeExpandPaths2(path ExpandPath) DisputeExpandPaths2 {
+	return DisputeExpandPaths2{
+		ExpandPath:    path,
+		Charge:        newChargeExpandPaths3(path + ".charge"),
+		Evidence:      newDisputeEvidenceExpandPaths3(path + ".evidence"),
+		PaymentIntent: newPaymentIntentExpandPaths3(path + ".payment_intent"),
+	}
+}
+
+// InvoiceExpandPaths2 is the path of an expandable invoice at level 2 of
+// a path, along with the paths of its own expandable fields.
+type InvoiceExpandPaths2 struct {
+	ExpandPath
+
+	Charge               ChargeExpandPaths3
+	Customer             CustomerExpandPaths3
+	DefaultPaymentMethod PaymentMethodExpandPaths3
+	DefaultSource        ExpandPath
+	Discount             DiscountExpandPaths3
+	PaymentIntent        PaymentIntentExpandPaths3
+	TransferData         InvoiceTransferDataExpandPaths3
+}
+
+func newInvoiceExpandPaths2(path ExpandPath) InvoiceExpandPaths2 {
+	return InvoiceExpandPaths2{
+		ExpandPath:           path,
+		Charge:               newChargeExpandPaths3(path + ".charge"),
+		Customer:             newCustomerExpandPaths3(path + ".customer"),
+		DefaultPaymentMethod: newPaymentMethodExpandPaths3(path + ".default_payment_method"),
+		DefaultSource:        path + ".default_source",
+		Discount:             newDiscountExpandPaths3(path + ".discount"),
+		PaymentIntent:        newPaymentIntentExpandPaths3(path + ".payment_intent"),
+		TransferData:         newInvoiceTransferDataExpandPaths3(path + ".transfer_data"),
+	}
+}
+
+// ChargeOutcomeExpandPaths2 are the paths of the expandable fields of a charge outcome
+// at level 2 of a path.
+type ChargeOutcomeExpandPaths2 struct {
+	Rule ExpandPath
+}
+
+func newChargeOutcomeExpandPaths2(path ExpandPath) ChargeOutcomeExpandPaths2 {
+	return ChargeOutcomeExpandPaths2{
+		Rule: path + ".rule",
+	}
+}
+
+// ReviewExpandPaths2 is the path of an expandable review at level 2 of
+// a path, along with the paths of its own expandable fields.
+type ReviewExpandPaths2 struct {
+	ExpandPath
+
+	Charge        ChargeExpandPaths3
+	PaymentIntent PaymentIntentExpandPaths3
+}
+
+func newReviewExpandPaths2(path ExpandPath) ReviewExpandPaths2 {
+	return ReviewExpandPaths2{
+		ExpandPath:    path,
+		Charge:        newChargeExpandPaths3(path + ".charge"),
+		PaymentIntent: newPaymentIntentExpandPaths3(path + ".payment_intent"),
+	}
+}
+
+// TransferExpandPaths2 is the path of an expandable transfer at level 2 of
+// a path, along with the paths of its own expandable fields.
+type TransferExpandPaths2 struct {
+	ExpandPath
+
+	BalanceTransaction BalanceTransactionExpandPaths3
+	Destination        ExpandPath
+	DestinationPayment ChargeExpandPaths3
+	SourceTransaction  ExpandPath
+}
+
+func newTransferExpandPaths2(path ExpandPath) TransferExpandPaths2 {
+	return TransferExpandPaths2{
+		ExpandPath:         path,
+		BalanceTransaction: newBalanceTransactionExpandPaths3(path + ".balance_transaction"),
+		Destination:        path + ".destination",
+		DestinationPayment: newChargeExpandPaths3(path + ".destination_payment"),
+		SourceTransaction:  path + ".source_transaction",
+	}
+}
+
+// ChargeTransferDataExpandPaths2 are the paths of the expandable fields of a charge transfer data
+// at level 2 of a path.
+type ChargeTransferDataExpandPaths2 struct {
+	Destination AccountExpandPaths3
+}
+
+func newChargeTransferDataExpandPaths2(path ExpandPath) ChargeTransferDataExpandPaths2 {
+	return ChargeTransferDataExpandPaths2{
+		Destination: newAccountExpandPaths3(path + ".destination"),
+	}
+}
+
+// DiscountExpandPaths2 are the paths of the expandable fields of a discount
+// at level 2 of a path.
+type DiscountExpandPaths2 struct {
+	Coupon ExpandPath
+}
+
+func newDiscountExpandPaths2(path ExpandPath) DiscountExpandPaths2 {
+	return DiscountExpandPaths2{
+		Coupon: path + ".coupon",
+	}
+}
+
+// CustomerInvoiceSettingsExpandPaths2 are the paths of the expandable fields of a customer invoice settings
+// at level 2 of a path.
+type CustomerInvoiceSettingsExpandPaths2 struct {
+	DefaultPaymentMethod PaymentMethodExpandPaths3
+}
+
+func newCustomerInvoiceSettingsExpandPaths2(path ExpandPath) CustomerInvoiceSettingsExpandPaths2 {
+	return CustomerInvoiceSettingsExpandPaths2{
+		DefaultPaymentMethod: newPaymentMethodExpandPaths3(path + ".default_payment_method"),
+	}
+}
+
+// BankAccountExpandPaths2 is the path of an expandable bank account at level 2 of
+// a path, along with the paths of its own expandable fields.
+type BankAccountExpandPaths2 struct {
+	ExpandPath
+
+	Account  AccountExpandPaths3
+	Customer CustomerExpandPaths3
+}
+
+func newBankAccountExpandPaths2(path ExpandPath) BankAccountExpandPaths2 {
+	return BankAccountExpandPaths2{
+		ExpandPath: path,
+		Account:    newAccountExpandPaths3(path + ".account"),
+		Customer:   newCustomerExpandPaths3(path + ".customer"),
+	}
+}
+
+// CardExpandPaths2 is the path of an expandable card at level 2 of
+// a path, along with the paths of its own expandable fields.
+type CardExpandPaths2 struct {
+	ExpandPath
+
+	Customer     CustomerExpandPaths3
+	Recipient    RecipientExpandPaths3
+	ThreeDSecure ThreeDSecureExpandPaths3
+}
+
+func newCardExpandPaths2(path ExpandPath) CardExpandPaths2 {
+	return CardExpandPaths2{
+		ExpandPath:   path,
+		Customer:     newCustomerExpandPaths3(path + ".customer"),
+		Recipient:    newRecipientExpandPaths3(path + ".recipient"),
+		ThreeDSecure: newThreeDSecureExpandPaths3(path + ".three_d_secure"),
+	}
+}
+
+// ChargeExpandPaths2 is the path of an expandable charge at level 2 of
+// a path, along with the paths of its own expandable fields.
+type ChargeExpandPaths2 struct {
+	ExpandPath
+
+	Application        ExpandPath
+	ApplicationFee     ApplicationFeeExpandPaths3
+	BalanceTransaction BalanceTransactionExpandPaths3
+	Customer           CustomerExpandPaths3
+	Destination        AccountExpandPaths3
+	Dispute            DisputeExpandPaths3
+	Invoice            InvoiceExpandPaths3
+	OnBehalfOf         AccountExpandPaths3
+	Outcome            ChargeOutcomeExpandPaths3
+	Review             ReviewExpandPaths3
+	Source             ExpandPath
+	SourceTransfer     TransferExpandPaths3
+	Transfer           TransferExpandPaths3
+	TransferData       ChargeTransferDataExpandPaths3
+}
+
+func newChargeExpandPaths2(path ExpandPath) ChargeExpandPaths2 {
+	return ChargeExpandPaths2{
+		ExpandPath:         path,
+		Application:        path + ".application",
+		ApplicationFee:     newApplicationFeeExpandPaths3(path + ".application_fee"),
+		BalanceTransaction: newBalanceTransactionExpandPaths3(path + ".balance_transaction"),
+		Customer:           newCustomerExpandPaths3(path + ".customer"),
+		Destination:        newAccountExpandPaths3(path + ".destination"),
+		Dispute:            newDisputeExpandPaths3(path + ".dispute"),
+		Invoice:            newInvoiceExpandPaths3(path + ".invoice"),
+		OnBehalfOf:         newAccountExpandPaths3(path + ".on_behalf_of"),
+		Outcome:            newChargeOutcomeExpandPaths3(path + ".outcome"),
+		Review:             newReviewExpandPaths3(path + ".review"),
+		Source:             path + ".source",
+		SourceTransfer:     newTransferExpandPaths3(path + ".source_transfer"),
+		Transfer:           newTransferExpandPaths3(path + ".transfer"),
+		TransferData:       newChargeTransferDataExpandPaths3(path + ".transfer_data"),
+	}
+}
+
+// DisputeEvidenceExpandPaths2 are the paths of the expandable fields of a dispute evidence
+// at level 2 of a path.
+type DisputeEvidenceExpandPaths2 struct {
+	CancellationPolicy           ExpandPath
+	CustomerCommunication        ExpandPath
+	CustomerSignature            ExpandPath
+	DuplicateChargeDocumentation ExpandPath
+	Receipt                      ExpandPath
+	RefundPolicy                 ExpandPath
+	ServiceDocumentation         ExpandPath
+	ShippingDocumentation        ExpandPath
+	UncategorizedFile            ExpandPath
+}
+
+func newDisputeEvidenceExpandPaths2(path ExpandPath) DisputeEvidenceExpandPaths2 {
+	return DisputeEvidenceExpandPaths2{
+		CancellationPolicy:           path + ".cancellation_policy",
+		CustomerCommunication:        path + ".customer_communication",
+		CustomerSignature:            path + ".customer_signature",
+		DuplicateChargeDocumentation: path + ".duplicate_charge_documentation",
+		Receipt:                      path + ".receipt",
+		RefundPolicy:                 path + ".refund_policy",
+		ServiceDocumentation:         path + ".service_documentation",
+		ShippingDocumentation:        path + ".shipping_documentation",
+		UncategorizedFile:            path + ".uncategorized_file",
+	}
+}
+
+// PaymentIntentExpandPaths2 is the path of an expandable payment intent at level 2 of
+// a path, along with the paths of its own expandable fields.
+type PaymentIntentExpandPaths2 struct {
+	ExpandPath
+
+	Application      ExpandPath
+	Customer         CustomerExpandPaths3
+	Invoice          InvoiceExpandPaths3
+	LastPaymentError ErrorExpandPaths3
+	OnBehalfOf       AccountExpandPaths3
+	PaymentMethod    PaymentMethodExpandPaths3
+	Review           ReviewExpandPaths3
+	Source           ExpandPath
+	TransferData     PaymentIntentTransferDataExpandPaths3
+}
+
+func newPaymentIntentExpandPaths2(path ExpandPath) PaymentIntentExpandPaths2 {
+	return PaymentIntentExpandPaths2{
+		ExpandPath:       path,
+		Application:      path + ".application",
+		Customer:         newCustomerExpandPaths3(path + ".customer"),
+		Invoice:          newInvoiceExpandPaths3(path + ".invoice"),
+		LastPaymentError: newErrorExpandPaths3(path + ".last_payment_error"),
+		OnBehalfOf:       newAccountExpandPaths3(path + ".on_behalf_of"),
+		PaymentMethod:    newPaymentMethodExpandPaths3(path + ".payment_method"),
+		Review:           newReviewExpandPaths3(path + ".review"),
+		Source:           path + ".source",
+		TransferData:     newPaymentIntentTransferDataExpandPaths3(path + ".transfer_data"),
+	}
+}
+
+// PaymentMethodExpandPaths2 is the path of an expandable payment method at level 2 of
+// a path, along with the paths of its own expandable fields.
+type PaymentMethodExpandPaths2 struct {
+	ExpandPath
+
+	Customer CustomerExpandPaths3
+}
+
+func newPaymentMethodExpandPaths2(path ExpandPath) PaymentMethodExpandPaths2 {
+	return PaymentMethodExpandPaths2{
+		ExpandPath: path,
+		Customer:   newCustomerExpandPaths3(path + ".customer"),
+	}
+}
+
+// InvoiceTransferDataExpandPaths2 are the paths of the expandable fields of an invoice transfer data
+// at level 2 of a path.
+type InvoiceTransferDataExpandPaths2 struct {
+	Destination AccountExpandPaths3
+}
+
+func newInvoiceTransferDataExpandPaths2(path ExpandPath) InvoiceTransferDataExpandPaths2 {
+	return InvoiceTransferDataExpandPaths2{
+		Destination: newAccountExpandPaths3(path + ".destination"),
+	}
+}
+
+// ErrorExpandPaths2 are the paths of the expandable fields of an error
+// at level 2 of a path.
+type ErrorExpandPaths2 struct {
+	PaymentIntent PaymentIntentExpandPaths3
+	PaymentMethod PaymentMethodExpandPaths3
+	SetupIntent   SetupIntentExpandPaths3
+	Source        ExpandPath
+}
+
+func newErrorExpandPaths2(path ExpandPath) ErrorExpandPaths2 {
+	return ErrorExpandPaths2{
+		PaymentIntent: newPaymentIntentExpandPaths3(path + ".payment_intent"),
+		PaymentMethod: newPaymentMethodExpandPaths3(path + ".payment_method"),
+		SetupIntent:   newSetupIntentExpandPaths3(path + ".setup_intent"),
+		Source:        path + ".source",
+	}
+}
+
+// PaymentIntentTransferDataExpandPaths2 are the paths of the expandable fields of a payment intent transfer data
+// at level 2 of a path.
+type PaymentIntentTransferDataExpandPaths2 struct {
+	Destination AccountExpandPaths3
+}
+
+func newPaymentIntentTransferDataExpandPaths2(path ExpandPath) PaymentIntentTransferDataExpandPaths2 {
+	return PaymentIntentTransferDataExpandPaths2{
+		Destination: newAccountExpandPaths3(path + ".destination"),
+	}
+}
+
+// MandateExpandPaths2 is the path of an expandable mandate at level 2 of
+// a path, along with the paths of its own expandable fields.
+type MandateExpandPaths2 struct {
+	ExpandPath
+
+	PaymentMethod PaymentMethodExpandPaths3
+}
+
+func newMandateExpandPaths2(path ExpandPath) MandateExpandPaths2 {
+	return MandateExpandPaths2{
+		ExpandPath:    path,
+		PaymentMethod: newPaymentMethodExpandPaths3(path + ".payment_method"),
+	}
+}
+
+// SetupIntentExpandPaths2 is the path of an expandable setup intent at level 2 of
+// a path, along with the paths of its own expandable fields.
+type SetupIntentExpandPaths2 struct {
+	ExpandPath
+
+	Application      ExpandPath
+	Customer         CustomerExpandPaths3
+	LastSetupError   ErrorExpandPaths3
+	Mandate          MandateExpandPaths3
+	OnBehalfOf       AccountExpandPaths3
+	PaymentMethod    PaymentMethodExpandPaths3
+	SingleUseMandate MandateExpandPaths3
+}
+
+func newSetupIntentExpandPaths2(path ExpandPath) SetupIntentExpandPaths2 {
+	return SetupIntentExpandPaths2{
+		ExpandPath:       path,
+		Application:      path + ".application",
+		Customer:         newCustomerExpandPaths3(path + ".customer"),
+		LastSetupError:   newErrorExpandPaths3(path + ".last_setup_error"),
+		Mandate:          newMandateExpandPaths3(path + ".mandate"),
+		OnBehalfOf:       newAccountExpandPaths3(path + ".on_behalf_of"),
+		PaymentMethod:    newPaymentMethodExpandPaths3(path + ".payment_method"),
+		SingleUseMandate: newMandateExpandPaths3(path + ".single_use_mandate"),
+	}
+}
+
+// PlanExpandPaths2 is the path of an expandable plan at level 2 of
+// a path, along with the paths of its own expandable fields.
+type PlanExpandPaths2 struct {
+	ExpandPath
+
+	Product ExpandPath
+}
+
+func newPlanExpandPaths2(path ExpandPath) PlanExpandPaths2 {
+	return PlanExpandPaths2{
+		ExpandPath: path,
+		Product:    path + ".product",
+	}
+}
+
+// SubscriptionScheduleExpandPaths2 is the path of an expandable subscription schedule at level 2 of
+// a path, along with the paths of its own expandable fields.
+type SubscriptionScheduleExpandPaths2 struct {
+	ExpandPath
+
+	Customer             CustomerExpandPaths3
+	DefaultSettings      SubscriptionScheduleDefaultSettingsExpandPaths3
+	ReleasedSubscription SubscriptionExpandPaths3
+	Subscription         SubscriptionExpandPaths3
+}
+
+func newSubscriptionScheduleExpandPaths2(path ExpandPath) SubscriptionScheduleExpandPaths2 {
+	return SubscriptionScheduleExpandPaths2{
+		ExpandPath:           path,
+		Customer:             newCustomerExpandPaths3(path + ".customer"),
+		DefaultSettings:      newSubscriptionScheduleDefaultSettingsExpandPaths3(path + ".default_settings"),
+		ReleasedSubscription: newSubscriptionExpandPaths3(path + ".released_subscription"),
+		Subscription:         newSubscriptionExpandPaths3(path + ".subscription"),
+	}
+}
+
+// SubscriptionTransferDataExpandPaths2 are the paths of the expandable fields of a subscription transfer data
+// at level 2 of a path.
+type SubscriptionTransferDataExpandPaths2 struct {
+	Destination AccountExpandPaths3
+}
+
+func newSubscriptionTransferDataExpandPaths2(path ExpandPath) SubscriptionTransferDataExpandPaths2 {
+	return SubscriptionTransferDataExpandPaths2{
+		Destination: newAccountExpandPaths3(path + ".destination"),
+	}
+}
+
+// CreditNoteExpandPaths2 is the path of an expandable credit note at level 2 of
+// a path, along with the paths of its own expandable fields.
+type CreditNoteExpandPaths2 struct {
+	ExpandPath
+
+	Customer                   CustomerExpandPaths3
+	CustomerBalanceTransaction CustomerBalanceTransactionExpandPaths3
+	Invoice                    InvoiceExpandPaths3
+	Refund                     RefundExpandPaths3
+}
+
+func newCreditNoteExpandPaths2(path ExpandPath) CreditNoteExpandPaths2 {
+	return CreditNoteExpandPaths2{
+		ExpandPath:                 path,
+		Customer:                   newCustomerExpandPaths3(path + ".customer"),
+		CustomerBalanceTransaction: newCustomerBalanceTransactionExpandPaths3(path + ".customer_balance_transaction"),
+		Invoice:                    newInvoiceExpandPaths3(path + ".invoice"),
+		Refund:                     newRefundExpandPaths3(path + ".refund"),
+	}
+}
+
+// ReversalExpandPaths2 is the path of an expandable reversal at level 2 of
+// a path, along with the paths of its own expandable fields.
+type ReversalExpandPaths2 struct {
+	ExpandPath
+
+	BalanceTransaction       BalanceTransactionExpandPaths3
+	DestinationPaymentRefund RefundExpandPaths3
+	SourceRefund             RefundExpandPaths3
+}
+
+func newReversalExpandPaths2(path ExpandPath) ReversalExpandPaths2 {
+	return ReversalExpandPaths2{
+		ExpandPath:               path,
+		BalanceTransaction:       newBalanceTransactionExpandPaths3(path + ".balance_transaction"),
+		DestinationPaymentRefund: newRefundExpandPaths3(path + ".destination_payment_refund"),
+		SourceRefund:             newRefundExpandPaths3(path + ".source_refund"),
+	}
+}
+
+// CustomerBalanceTransactionExpandPaths2 is the path of an expandable customer balance transaction at level 2 of
+// a path, along with the paths of its own expandable fields.
+type CustomerBalanceTransactionExpandPaths2 struct {
+	ExpandPath
+
+	CreditNote CreditNoteExpandPaths3
+	Customer   CustomerExpandPaths3
+	Invoice    InvoiceExpandPaths3
+}
+
+func newCustomerBalanceTransactionExpandPaths2(path ExpandPath) CustomerBalanceTransactionExpandPaths2 {
+	return CustomerBalanceTransactionExpandPaths2{
+		ExpandPath: path,
+		CreditNote: newCreditNoteExpandPaths3(path + ".credit_note"),
+		Customer:   newCustomerExpandPaths3(path + ".customer"),
+		Invoice:    newInvoiceExpandPaths3(path + ".invoice"),
+	}
+}
+
+// RefundExpandPaths2 is the path of an expandable refund at level 2 of
+// a path, along with the paths of its own expandable fields.
+type RefundExpandPaths2 struct {
+	ExpandPath
+
+	BalanceTransaction        BalanceTransactionExpandPaths3
+	Charge                    ChargeExpandPaths3
+	FailureBalanceTransaction BalanceTransactionExpandPaths3
+	PaymentIntent             PaymentIntentExpandPaths3
+	SourceTransferReversal    ReversalExpandPaths3
+	TransferReversal          ReversalExpandPaths3
+}
+
+func newRefundExpandPaths2(path ExpandPath) RefundExpandPaths2 {
+	return RefundExpandPaths2{
+		ExpandPath:                path,
+		BalanceTransaction:        newBalanceTransactionExpandPaths3(path + ".balance_transaction"),
+		Charge:                    newChargeExpandPaths3(path + ".charge"),
+		FailureBalanceTransaction: newBalanceTransactionExpandPaths3(path + ".failure_balance_transaction"),
+		PaymentIntent:             newPaymentIntentExpandPaths3(path + ".payment_intent"),
+		SourceTransferReversal:    newReversalExpandPaths3(path + ".source_transfer_reversal"),
+		TransferReversal:          newReversalExpandPaths3(path + ".transfer_reversal"),
+	}
+}
+
+// IssuingCardExpandPaths2 is the path of an expandable issuing card at level 2 of
+// a path, along with the paths of its own expandable fields.
+type IssuingCardExpandPaths2 struct {
+	ExpandPath
+
+	Cardholder     ExpandPath
+	ReplacementFor IssuingCardExpandPaths3
+}
+
+func newIssuingCardExpandPaths2(path ExpandPath) IssuingCardExpandPaths2 {
+	return IssuingCardExpandPaths2{
+		ExpandPath:     path,
+		Cardholder:     path + ".cardholder",
+		ReplacementFor: newIssuingCardExpandPaths3(path + ".replacement_for"),
+	}
+}
+
+// IssuingCardholderIndividualVerificationExpandPaths2 are the paths of the expandable fields of an issuing cardholder individual verification
+// at level 2 of a path.
+type IssuingCardholderIndividualVerificationExpandPaths2 struct {
+	Document IssuingCardholderIndividualVerificationDocumentExpandPaths3
+}
+
+func newIssuingCardholderIndividualVerificationExpandPaths2(path ExpandPath) IssuingCardholderIndividualVerificationExpandPaths2 {
+	return IssuingCardholderIndividualVerificationExpandPaths2{
+		Document: newIssuingCardholderIndividualVerificationDocumentExpandPaths3(path + ".document"),
+	}
+}
+
+// IssuingDisputeEvidenceFraudulentExpandPaths2 are the paths of the expandable fields of an issuing dispute evidence fraudulent
+// at level 2 of a path.
+type IssuingDisputeEvidenceFraudulentExpandPaths2 struct {
+	UncategorizedFile ExpandPath
+}
+
+func newIssuingDisputeEvidenceFraudulentExpandPaths2(path ExpandPath) IssuingDisputeEvidenceFraudulentExpandPaths2 {
+	return IssuingDisputeEvidenceFraudulentExpandPaths2{
+		UncategorizedFile: path + ".uncategorized_file",
+	}
+}
+
+// IssuingDisputeEvidenceOtherExpandPaths2 are the paths of the expandable fields of an issuing dispute evidence other
+// at level 2 of a path.
+type IssuingDisputeEvidenceOtherExpandPaths2 struct {
+	UncategorizedFile ExpandPath
+}
+
+func newIssuingDisputeEvidenceOtherExpandPaths2(path ExpandPath) IssuingDisputeEvidenceOtherExpandPaths2 {
+	return IssuingDisputeEvidenceOtherExpandPaths2{
+		UncategorizedFile: path + ".uncategorized_file",
+	}
+}
+
+// IssuingAuthorizationExpandPaths2 is the path of an expandable issuing authorization at level 2 of
+// a path, along with the paths of its own expandable fields.
+type IssuingAuthorizationExpandPaths2 struct {
+	ExpandPath
+
+	Card       IssuingCardExpandPaths3
+	Cardholder ExpandPath
+}
+
+func newIssuingAuthorizationExpandPaths2(path ExpandPath) IssuingAuthorizationExpandPaths2 {
+	return IssuingAuthorizationExpandPaths2{
+		ExpandPath: path,
+		Card:       newIssuingCardExpandPaths3(path + ".card"),
+		Cardholder: path + ".cardholder",
+	}
+}
+
+// IssuingDisputeExpandPaths2 is the path of an expandable issuing dispute at level 2 of
+// a path, along with the paths of its own expandable fields.
+type IssuingDisputeExpandPaths2 struct {
+	ExpandPath
+
+	Transaction IssuingTransactionExpandPaths3
+}
+
+func newIssuingDisputeExpandPaths2(path ExpandPath) IssuingDisputeExpandPaths2 {
+	return IssuingDisputeExpandPaths2{
+		ExpandPath:  path,
+		Transaction: newIssuingTransactionExpandPaths3(path + ".transaction"),
+	}
+}
+
+// IssuingDisputeEvidenceExpandPaths2 are the paths of the expandable fields of an issuing dispute evidence
+// at level 2 of a path.
+type IssuingDisputeEvidenceExpandPaths2 struct {
+	Fraudulent IssuingDisputeEvidenceFraudulentExpandPaths3
+	Other      IssuingDisputeEvidenceOtherExpandPaths3
+}
+
+func newIssuingDisputeEvidenceExpandPaths2(path ExpandPath) IssuingDisputeEvidenceExpandPaths2 {
+	return IssuingDisputeEvidenceExpandPaths2{
+		Fraudulent: newIssuingDisputeEvidenceFraudulentExpandPaths3(path + ".fraudulent"),
+		Other:      newIssuingDisputeEvidenceOtherExpandPaths3(path + ".other"),
+	}
+}
+
+// IssuingTransactionExpandPaths2 is the path of an expandable issuing transaction at level 2 of
+// a path, along with the paths of its own expandable fields.
+type IssuingTransactionExpandPaths2 struct {
+	ExpandPath
+
+	Authorization      IssuingAuthorizationExpandPaths3
+	BalanceTransaction BalanceTransactionExpandPaths3
+	Card               IssuingCardExpandPaths3
+	Cardholder         ExpandPath
+	Dispute            IssuingDisputeExpandPaths3
+}
+
+func newIssuingTransactionExpandPaths2(path ExpandPath) IssuingTransactionExpandPaths2 {
+	return IssuingTransactionExpandPaths2{
+		ExpandPath:         path,
+		Authorization:      newIssuingAuthorizationExpandPaths3(path + ".authorization"),
+		BalanceTransaction: newBalanceTransactionExpandPaths3(path + ".balance_transaction"),
+		Card:               newIssuingCardExpandPaths3(path + ".card"),
+		Cardholder:         path + ".cardholder",
+		Dispute:            newIssuingDisputeExpandPaths3(path + ".dispute"),
+	}
+}
+
+// RecipientExpandPaths2 is the path of an expandable recipient at level 2 of
+// a path, along with the paths of its own expandable fields.
+type RecipientExpandPaths2 struct {
+	ExpandPath
+
+	ActiveAccount BankAccountExpandPaths3
+	DefaultCard   CardExpandPaths3
+	MigratedTo    AccountExpandPaths3
+}
+
+func newRecipientExpandPaths2(path ExpandPath) RecipientExpandPaths2 {
+	return RecipientExpandPaths2{
+		ExpandPath:    path,
+		ActiveAccount: newBankAccountExpandPaths3(path + ".active_account"),
+		DefaultCard:   newCardExpandPaths3(path + ".default_card"),
+		MigratedTo:    newAccountExpandPaths3(path + ".migrated_to"),
+	}
+}
+
+// ThreeDSecureExpandPaths2 are the paths of the expandable fields of a three d secure
+// at level 2 of a path.
+type ThreeDSecureExpandPaths2 struct {
+	Card CardExpandPaths3
+}
+
+func newThreeDSecureExpandPaths2(path ExpandPath) ThreeDSecureExpandPaths2 {
+	return ThreeDSecureExpandPaths2{
+		Card: newCardExpandPaths3(path + ".card"),
+	}
+}
+
+// PersonVerificationDocumentExpandPaths2 are the paths of the expandable fields of a person verification document
+// at level 2 of a path.
+type PersonVerificationDocumentExpandPaths2 struct {
+	Back  ExpandPath
+	Front ExpandPath
+}
+
+func newPersonVerificationDocumentExpandPaths2(path ExpandPath) PersonVerificationDocumentExpandPaths2 {
+	return PersonVerificationDocumentExpandPaths2{
+		Back:  path + ".back",
+		Front: path + ".front",
+	}
+}
+
+// SubscriptionScheduleDefaultSettingsExpandPaths2 are the paths of the expandable fields of a subscription schedule default settings
+// at level 2 of a path.
+type SubscriptionScheduleDefaultSettingsExpandPaths2 struct {
+	DefaultPaymentMethod PaymentMethodExpandPaths3
+}
+
+func newSubscriptionScheduleDefaultSettingsExpandPaths2(path ExpandPath) SubscriptionScheduleDefaultSettingsExpandPaths2 {
+	return SubscriptionScheduleDefaultSettingsExpandPaths2{
+		DefaultPaymentMethod: newPaymentMethodExpandPaths3(path + ".default_payment_method"),
+	}
+}
+
+// SubscriptionExpandPaths2 is the path of an expandable subscription at level 2 of
+// a path, along with the paths of its own expandable fields.
+type SubscriptionExpandPaths2 struct {
+	ExpandPath
+
+	Customer             CustomerExpandPaths3
+	DefaultPaymentMethod PaymentMethodExpandPaths3
+	DefaultSource        ExpandPath
+	Discount             DiscountExpandPaths3
+	LatestInvoice        InvoiceExpandPaths3
+	OnBehalfOf           AccountExpandPaths3
+	PendingSetupIntent   SetupIntentExpandPaths3
+	Plan                 PlanExpandPaths3
+	Schedule             SubscriptionScheduleExpandPaths3
+	TransferData         SubscriptionTransferDataExpandPaths3
+}
+
+func newSubscriptionExpandPaths2(path ExpandPath) SubscriptionExpandPaths2 {
+	return SubscriptionExpandPaths2{
+		ExpandPath:           path,
+		Customer:             newCustomerExpandPaths3(path + ".customer"),
+		DefaultPaymentMethod: newPaymentMethodExpandPaths3(path + ".default_payment_method"),
+		DefaultSource:        path + ".default_source",
+		Discount:             newDiscountExpandPaths3(path + ".discount"),
+		LatestInvoice:        newInvoiceExpandPaths3(path + ".latest_invoice"),
+		OnBehalfOf:           newAccountExpandPaths3(path + ".on_behalf_of"),
+		PendingSetupIntent:   newSetupIntentExpandPaths3(path + ".pending_setup_intent"),
+		Plan:                 newPlanExpandPaths3(path + ".plan"),
+		Schedule:             newSubscriptionScheduleExpandPaths3(path + ".schedule"),
+		TransferData:         newSubscriptionTransferDataExpandPaths3(path + ".transfer_data"),
+	}
+}
+
+// AccountCompanyVerificationDocumentExpandPaths3 are the paths of the expandable fields of an account company verification document
+// at level 3 of a path.
+type AccountCompanyVerificationDocumentExpandPaths3 struct {
+	Back  ExpandPath
+	Front ExpandPath
+}
+
+func newAccountCompanyVerificationDocumentExpandPaths3(path ExpandPath) AccountCompanyVerificationDocumentExpandPaths3 {
+	return AccountCompanyVerificationDocumentExpandPaths3{
+		Back:  path + ".back",
+		Front: path + ".front",
+	}
+}
+
+// PersonVerificationDocumentExpandPaths3 are the paths of the expandable fields of a person verification document
+// at level 3 of a path.
+type PersonVerificationDocumentExpandPaths3 struct {
+	Back  ExpandPath
+	Front ExpandPath
+}
+
+func newPersonVerificationDocumentExpandPaths3(path ExpandPath) PersonVerificationDocumentExpandPaths3 {
+	return PersonVerificationDocumentExpandPaths3{
+		Back:  path + ".back",
+		Front: path + ".front",
+	}
+}
+
+// AccountSettingsBrandingExpandPaths3 are the paths of the expandable fields of an account settings branding
+// at level 3 of a path.
+type AccountSettingsBrandingExpandPaths3 struct {
+	Icon ExpandPath
+	Logo ExpandPath
+}
+
+func newAccountSettingsBrandingExpandPaths3(path ExpandPath) AccountSettingsBrandingExpandPaths3 {
+	return AccountSettingsBrandingExpandPaths3{
+		Icon: path + ".icon",
+		Logo: path + ".logo",
+	}
+}
+
+// AccountExpandPaths3 is the path of an expandable account at level 3 of
+// a path, along with the paths of its own expandable fields.
+type AccountExpandPaths3 struct {
+	ExpandPath
+
+	Individual ExpandPath
+}
+
+func newAccountExpandPaths3(path ExpandPath) AccountExpandPaths3 {
+	return AccountExpandPaths3{
+		ExpandPath: path,
+		Individual: path + ".individual",
+	}
+}
+
+// BalanceTransactionExpandPaths3 is the path of an expandable balance transaction at level 3 of
+// a path, along with the paths of its own expandable fields.
+type BalanceTransactionExpandPaths3 struct {
+	ExpandPath
+
+	Source ExpandPath
+}
+
+func newBalanceTransactionExpandPaths3(path ExpandPath) BalanceTransactionExpandPaths3 {
+	return BalanceTransactionExpandPaths3{
+		ExpandPath: path,
+		Source:     path + ".source",
+	}
+}
+
+// ChargeExpandPaths3 is the path of an expandable charge at level 3 of
+// a path, along with the paths of its own expandable fields.
+type ChargeExpandPaths3 struct {
+	ExpandPath
+
+	Application        ExpandPath
+	ApplicationFee     ExpandPath
+	BalanceTransaction ExpandPath
+	Customer           ExpandPath
+	Destination        ExpandPath
+	Dispute            ExpandPath
+	Invoice            ExpandPath
+	OnBehalfOf         ExpandPath
+	Review             ExpandPath
+	Source             ExpandPath
+	SourceTransfer     ExpandPath
+	Transfer           ExpandPath
+}
+
+func newChargeExpandPaths3(path ExpandPath) ChargeExpandPaths3 {
+	return ChargeExpandPaths3{
+		ExpandPath:         path,
+		Application:        path + ".application",
+		ApplicationFee:     path + ".application_fee",
+		BalanceTransaction: path + ".balance_transaction",
+		Customer:           path + ".customer",
+		Destination:        path + ".destination",
+		Dispute:            path + ".dispute",
+		Invoice:            path + ".invoice",
+		OnBehalfOf:         path + ".on_behalf_of",
+		Review:             path + ".review",
+		Source:             path + ".source",
+		SourceTransfer:     path + ".source_transfer",
+		Transfer:           path + ".transfer",
+	}
+}
+
+// DiscountExpandPaths3 are the paths of the expandable fields of a discount
+// at level 3 of a path.
+type DiscountExpandPaths3 struct {
+	Coupon ExpandPath
+}
+
+func newDiscountExpandPaths3(path ExpandPath) DiscountExpandPaths3 {
+	return DiscountExpandPaths3{
+		Coupon: path + ".coupon",
+	}
+}
+
+// CustomerInvoiceSettingsExpandPaths3 are the paths of the expandable fields of a customer invoice settings
+// at level 3 of a path.
+type CustomerInvoiceSettingsExpandPaths3 struct {
+	DefaultPaymentMethod ExpandPath
+}
+
+func newCustomerInvoiceSettingsExpandPaths3(path ExpandPath) CustomerInvoiceSettingsExpandPaths3 {
+	return CustomerInvoiceSettingsExpandPaths3{
+		DefaultPaymentMethod: path + ".default_payment_method",
+	}
+}
+
+// DisputeEvidenceExpandPaths3 are the paths of the expandable fields of a dispute evidence
+// at level 3 of a path.
+type DisputeEvidenceExpandPaths3 struct {
+	CancellationPolicy           ExpandPath
+	CustomerCommunication        ExpandPath
+	CustomerSignature            ExpandPath
+	DuplicateChargeDocumentation ExpandPath
+	Receipt                      ExpandPath
+	RefundPolicy                 ExpandPath
+	ServiceDocumentation         ExpandPath
+	ShippingDocumentation        ExpandPath
+	UncategorizedFile            ExpandPath
+}
+
+func newDisputeEvidenceExpandPaths3(path ExpandPath) DisputeEvidenceExpandPaths3 {
+	return DisputeEvidenceExpandPaths3{
+		CancellationPolicy:           path + ".cancellation_policy",
+		CustomerCommunication:        path + ".customer_communication",
+		CustomerSignature:            path + ".customer_signature",
+		DuplicateChargeDocumentation: path + ".duplicate_charge_documentation",
+		Receipt:                      path + ".receipt",
+		RefundPolicy:                 path + ".refund_policy",
+		ServiceDocumentation:         path + ".service_documentation",
+		ShippingDocumentation:        path + ".shipping_documentation",
+		UncategorizedFile:            path + ".uncategorized_file",
+	}
+}
+
+// PaymentIntentExpandPaths3 is the path of an expandable payment intent at level 3 of
+// a path, along with the paths of its own expandable fields.
+type PaymentIntentExpandPaths3 struct {
+	ExpandPath
+
+	Application   ExpandPath
+	Customer      ExpandPath
+	Invoice       ExpandPath
+	OnBehalfOf    ExpandPath
+	PaymentMethod ExpandPath
+	Review        ExpandPath
+	Source        ExpandPath
+}
+
+func newPaymentIntentExpandPaths3(path ExpandPath) PaymentIntentExpandPaths3 {
+	return PaymentIntentExpandPaths3{
+		ExpandPath:    path,
+		Application:   path + ".application",
+		Customer:      path + ".customer",
+		Invoice:       path + ".invoice",
+		OnBehalfOf:    path + ".on_behalf_of",
+		PaymentMethod: path + ".payment_method",
+		Review:        path + ".review",
+		Source:        path + ".source",
+	}
+}
+
+// CustomerExpandPaths3 is the path of an expandable customer at level 3 of
+// a path, along with the paths of its own expandable fields.
+type CustomerExpandPaths3 struct {
+	ExpandPath
+
+	DefaultSource ExpandPath
+}
+
+func newCustomerExpandPaths3(path ExpandPath) CustomerExpandPaths3 {
+	return CustomerExpandPaths3{
+		ExpandPath:    path,
+		DefaultSource: path + ".default_source",
+	}
+}
+
+// PaymentMethodExpandPaths3 is the path of an expandable payment method at level 3 of
+// a path, along with the paths of its own expandable fields.
+type PaymentMethodExpandPaths3 struct {
+	ExpandPath
+
+	Customer ExpandPath
+}
+
+func newPaymentMethodExpandPaths3(path ExpandPath) PaymentMethodExpandPaths3 {
+	return PaymentMethodExpandPaths3{
+		ExpandPath: path,
+		Customer:   path + ".customer",
+	}
+}
+
+// InvoiceTransferDataExpandPaths3 are the paths of the expandable fields of an invoice transfer data
+// at level 3 of a path.
+type InvoiceTransferDataExpandPaths3 struct {
+	Destination ExpandPath
+}
+
+func newInvoiceTransferDataExpandPaths3(path ExpandPath) InvoiceTransferDataExpandPaths3 {
+	return InvoiceTransferDataExpandPaths3{
+		Destination: path + ".destination",
+	}
+}
+
+// RecipientExpandPaths3 is the path of an expandable recipient at level 3 of
+// a path, along with the paths of its own expandable fields.
+type RecipientExpandPaths3 struct {
+	ExpandPath
+
+	ActiveAccount ExpandPath
+	DefaultCard   ExpandPath
+	MigratedTo    ExpandPath
+}
+
+func newRecipientExpandPaths3(path ExpandPath) RecipientExpandPaths3 {
+	return RecipientExpandPaths3{
+		ExpandPath:    path,
+		ActiveAccount: path + ".active_account",
+		DefaultCard:   path + ".default_card",
+		MigratedTo:    path + ".migrated_to",
+	}
+}
+
+// ThreeDSecureExpandPaths3 are the paths of the expandable fields of a three d secure
+// at level 3 of a path.
+type ThreeDSecureExpandPaths3 struct {
+	Card ExpandPath
+}
+
+func newThreeDSecureExpandPaths3(path ExpandPath) ThreeDSecureExpandPaths3 {
+	return ThreeDSecureExpandPaths3{
+		Card: path + ".card",
+	}
+}
+
+// ApplicationFeeExpandPaths3 is the path of an expandable application fee at level 3 of
+// a path, along with the paths of its own expandable fields.
+type ApplicationFeeExpandPaths3 struct {
+	ExpandPath
+
+	Account                ExpandPath
+	BalanceTransaction     ExpandPath
+	Charge                 ExpandPath
+	OriginatingTransaction ExpandPath
+}
+
+func newApplicationFeeExpandPaths3(path ExpandPath) ApplicationFeeExpandPaths3 {
+	return ApplicationFeeExpandPaths3{
+		ExpandPath:             path,
+		Account:                path + ".account",
+		BalanceTransaction:     path + ".balance_transaction",
+		Charge:                 path + ".charge",
+		OriginatingTransaction: path + ".originating_transaction",
+	}
+}
+
+// DisputeExpandPaths3 is the path of an expandable dispute at level 3 of
+// a path, along with the paths of its own expandable fields.
+type DisputeExpandPaths3 struct {
+	ExpandPath
+
+	Charge        ExpandPath
+	PaymentIntent ExpandPath
+}
+
+func newDisputeExpandPaths3(path ExpandPath) DisputeExpandPaths3 {
+	return DisputeExpandPaths3{
+		ExpandPath:    path,
+		Charge:        path + ".charge",
+		PaymentIntent: path + ".payment_intent",
+	}
+}
+
+// InvoiceExpandPaths3 is the path of an expandable invoice at level 3 of
+// a path, along with the paths of its own expandable fields.
+type InvoiceExpandPaths3 struct {
+	ExpandPath
+
+	Charge               ExpandPath
+	Customer             ExpandPath
+	DefaultPaymentMethod ExpandPath
+	DefaultSource        ExpandPath
+	PaymentIntent        ExpandPath
+}
+
+func newInvoiceExpandPaths3(path ExpandPath) InvoiceExpandPaths3 {
+	return InvoiceExpandPaths3{
+		ExpandPath:           path,
+		Charge:               path + ".charge",
+		Customer:             path + ".customer",
+		DefaultPaymentMethod: path + ".default_payment_method",
+		DefaultSource:        path + ".default_source",
+		PaymentIntent:        path + ".payment_intent",
+	}
+}
+
+// ChargeOutcomeExpandPaths3 are the paths of the expandable fields of a charge outcome
+// at level 3 of a path.
+type ChargeOutcomeExpandPaths3 struct {
+	Rule ExpandPath
+}
+
+func newChargeOutcomeExpandPaths3(path ExpandPath) ChargeOutcomeExpandPaths3 {
+	return ChargeOutcomeExpandPaths3{
+		Rule: path + ".rule",
+	}
+}
+
+// ReviewExpandPaths3 is the path of an expandable review at level 3 of
+// a path, along with the paths of its own expandable fields.
+type ReviewExpandPaths3 struct {
+	ExpandPath
+
+	Charge        ExpandPath
+	PaymentIntent ExpandPath
+}
+
+func newReviewExpandPaths3(path ExpandPath) ReviewExpandPaths3 {
+	return ReviewExpandPaths3{
+		ExpandPath:    path,
+		Charge:        path + ".charge",
+		PaymentIntent: path + ".payment_intent",
+	}
+}
+
+// TransferExpandPaths3 is the path of an expandable transfer at level 3 of
+// a path, along with the paths of its own expandable fields.
+type TransferExpandPaths3 struct {
+	ExpandPath
+
+	BalanceTransaction ExpandPath
+	Destination        ExpandPath
+	DestinationPayment ExpandPath
+	SourceTransaction  ExpandPath
+}
+
+func newTransferExpandPaths3(path ExpandPath) TransferExpandPaths3 {
+	return TransferExpandPaths3{
+		ExpandPath:         path,
+		BalanceTransaction: path + ".balance_transaction",
+		Destination:        path + ".destination",
+		DestinationPayment: path + ".destination_payment",
+		SourceTransaction:  path + ".source_transaction",
+	}
+}
+
+// ChargeTransferDataExpandPaths3 are the paths of the expandable fields of a charge transfer data
+// at level 3 of a path.
+type ChargeTransferDataExpandPaths3 struct {
+	Destination ExpandPath
+}
+
+func newChargeTransferDataExpandPaths3(path ExpandPath) ChargeTransferDataExpandPaths3 {
+	return ChargeTransferDataExpandPaths3{
+		Destination: path + ".destination",
+	}
+}
+
+// ErrorExpandPaths3 are the paths of the expandable fields of an error
+// at level 3 of a path.
+type ErrorExpandPaths3 struct {
+	PaymentIntent ExpandPath
+	PaymentMethod ExpandPath
+	SetupIntent   ExpandPath
+	Source        ExpandPath
+}
+
+func newErrorExpandPaths3(path ExpandPath) ErrorExpandPaths3 {
+	return ErrorExpandPaths3{
+		PaymentIntent: path + ".payment_intent",
+		PaymentMethod: path + ".payment_method",
+		SetupIntent:   path + ".setup_intent",
+		Source:        path + ".source",
+	}
+}
+
+// PaymentIntentTransferDataExpandPaths3 are the paths of the expandable fields of a payment intent transfer data
+// at level 3 of a path.
+type PaymentIntentTransferDataExpandPaths3 struct {
+	Destination ExpandPath
+}
+
+func newPaymentIntentTransferDataExpandPaths3(path ExpandPath) PaymentIntentTransferDataExpandPaths3 {
+	return PaymentIntentTransferDataExpandPaths3{
+		Destination: path + ".destination",
+	}
+}
+
+// SetupIntentExpandPaths3 is the path of an expandable setup intent at level 3 of
+// a path, along with the paths of its own expandable fields.
+type SetupIntentExpandPaths3 struct {
+	ExpandPath
+
+	Application      ExpandPath
+	Customer         ExpandPath
+	Mandate          ExpandPath
+	OnBehalfOf       ExpandPath
+	PaymentMethod    ExpandPath
+	SingleUseMandate ExpandPath
+}
+
+func newSetupIntentExpandPaths3(path ExpandPath) SetupIntentExpandPaths3 {
+	return SetupIntentExpandPaths3{
+		ExpandPath:       path,
+		Application:      path + ".application",
+		Customer:         path + ".customer",
+		Mandate:          path + ".mandate",
+		OnBehalfOf:       path + ".on_behalf_of",
+		PaymentMethod:    path + ".payment_method",
+		SingleUseMandate: path + ".single_use_mandate",
+	}
+}
+
+// MandateExpandPaths3 is the path of an expandable mandate at level 3 of
+// a path, along with the paths of its own expandable fields.
+type MandateExpandPaths3 struct {
+	ExpandPath
+
+	PaymentMethod ExpandPath
+}
+
+func newMandateExpandPaths3(path ExpandPath) MandateExpandPaths3 {
+	return MandateExpandPaths3{
+		ExpandPath:    path,
+		PaymentMethod: path + ".payment_method",
+	}
+}
+
+// SubscriptionScheduleDefaultSettingsExpandPaths3 are the paths of the expandable fields of a subscription schedule default settings
+// at level 3 of a path.
+type SubscriptionScheduleDefaultSettingsExpandPaths3 struct {
+	DefaultPaymentMethod ExpandPath
+}
+
+func newSubscriptionScheduleDefaultSettingsExpandPaths3(path ExpandPath) SubscriptionScheduleDefaultSettingsExpandPaths3 {
+	return SubscriptionScheduleDefaultSettingsExpandPaths3{
+		DefaultPaymentMethod: path + ".default_payment_method",
+	}
+}
+
+// SubscriptionExpandPaths3 is the path of an expandable subscription at level 3 of
+// a path, along with the paths of its own expandable fields.
+type SubscriptionExpandPaths3 struct {
+	ExpandPath
+
+	Customer             ExpandPath
+	DefaultPaymentMethod ExpandPath
+	DefaultSource        ExpandPath
+	LatestInvoice        ExpandPath
+	OnBehalfOf           ExpandPath
+	PendingSetupIntent   ExpandPath
+	Plan                 ExpandPath
+	Schedule             ExpandPath
+}
+
+func newSubscriptionExpandPaths3(path ExpandPath) SubscriptionExpandPaths3 {
+	return SubscriptionExpandPaths3{
+		ExpandPath:           path,
+		Customer:             path + ".customer",
+		DefaultPaymentMethod: path + ".default_payment_method",
+		DefaultSource:        path + ".default_source",
+		LatestInvoice:        path + ".latest_invoice",
+		OnBehalfOf:           path + ".on_behalf_of",
+		PendingSetupIntent:   path + ".pending_setup_intent",
+		Plan:                 path + ".plan",
+		Schedule:             path + ".schedule",
+	}
+}
+
+// CustomerBalanceTransactionExpandPaths3 is the path of an expandable customer balance transaction at level 3 of
+// a path, along with the paths of its own expandable fields.
+type CustomerBalanceTransactionExpandPaths3 struct {
+	ExpandPath
+
+	CreditNote ExpandPath
+	Customer   ExpandPath
+	Invoice    ExpandPath
+}
+
+func newCustomerBalanceTransactionExpandPaths3(path ExpandPath) CustomerBalanceTransactionExpandPaths3 {
+	return CustomerBalanceTransactionExpandPaths3{
+		ExpandPath: path,
+		CreditNote: path + ".credit_note",
+		Customer:   path + ".customer",
+		Invoice:    path + ".invoice",
+	}
+}
+
+// RefundExpandPaths3 is the path of an expandable refund at level 3 of
+// a path, along with the paths of its own expandable fields.
+type RefundExpandPaths3 struct {
+	ExpandPath
+
+	BalanceTransaction        ExpandPath
+	Charge                    ExpandPath
+	FailureBalanceTransaction ExpandPath
+	PaymentIntent             ExpandPath
+	SourceTransferReversal    ExpandPath
+	TransferReversal          ExpandPath
+}
+
+func newRefundExpandPaths3(path ExpandPath) RefundExpandPaths3 {
+	return RefundExpandPaths3{
+		ExpandPath:                path,
+		BalanceTransaction:        path + ".balance_transaction",
+		Charge:                    path + ".charge",
+		FailureBalanceTransaction: path + ".failure_balance_transaction",
+		PaymentIntent:             path + ".payment_intent",
+		SourceTransferReversal:    path + ".source_transfer_reversal",
+		TransferReversal:          path + ".transfer_reversal",
+	}
+}
+
+// CreditNoteExpandPaths3 is the path of an expandable credit note at level 3 of
+// a path, along with the paths of its own expandable fields.
+type CreditNoteExpandPaths3 struct {
+	ExpandPath
+
+	Customer                   ExpandPath
+	CustomerBalanceTransaction ExpandPath
+	Invoice                    ExpandPath
+	Refund                     ExpandPath
+}
+
+func newCreditNoteExpandPaths3(path ExpandPath) CreditNoteExpandPaths3 {
+	return CreditNoteExpandPaths3{
+		ExpandPath:                 path,
+		Customer:                   path + ".customer",
+		CustomerBalanceTransaction: path + ".customer_balance_transaction",
+		Invoice:                    path + ".invoice",
+		Refund:                     path + ".refund",
+	}
+}
+
+// ReversalExpandPaths3 is the path of an expandable reversal at level 3 of
+// a path, along with the paths of its own expandable fields.
+type ReversalExpandPaths3 struct {
+	ExpandPath
+
+	BalanceTransaction       ExpandPath
+	DestinationPaymentRefund ExpandPath
+	SourceRefund             ExpandPath
+}
+
+func newReversalExpandPaths3(path ExpandPath) ReversalExpandPaths3 {
+	return ReversalExpandPaths3{
+		ExpandPath:               path,
+		BalanceTransaction:       path + ".balance_transaction",
+		DestinationPaymentRefund: path + ".destination_payment_refund",
+		SourceRefund:             path + ".source_refund",
+	}
+}
+
+// IssuingCardExpandPaths3 is the path of an expandable issuing card at level 3 of
+// a path, along with the paths of its own expandable fields.
+type IssuingCardExpandPaths3 struct {
+	ExpandPath
+
+	Cardholder     ExpandPath
+	ReplacementFor ExpandPath
+}
+
+func newIssuingCardExpandPaths3(path ExpandPath) IssuingCardExpandPaths3 {
+	return IssuingCardExpandPaths3{
+		ExpandPath:     path,
+		Cardholder:     path + ".cardholder",
+		ReplacementFor: path + ".replacement_for",
+	}
+}
+
+// IssuingCardholderIndividualVerificationDocumentExpandPaths3 are the paths of the expandable fields of an issuing cardholder individual verification document
+// at level 3 of a path.
+type IssuingCardholderIndividualVerificationDocumentExpandPaths3 struct {
+	Back  ExpandPath
+	Front ExpandPath
+}
+
+func newIssuingCardholderIndividualVerificationDocumentExpandPaths3(path ExpandPath) IssuingCardholderIndividualVerificationDocumentExpandPaths3 {
+	return IssuingCardholderIndividualVerificationDocumentExpandPaths3{
+		Back:  path + ".back",
+		Front: path + ".front",
+	}
+}
+
+// IssuingTransactionExpandPaths3 is the path of an expandable issuing transaction at level 3 of
+// a path, along with the paths of its own expandable fields.
+type IssuingTransactionExpandPaths3 struct {
+	ExpandPath
+
+	Authorization      ExpandPath
+	BalanceTransaction ExpandPath
+	Card               ExpandPath
+	Cardholder         ExpandPath
+	Dispute            ExpandPath
+}
+
+func newIssuingTransactionExpandPaths3(path ExpandPath) IssuingTransactionExpandPaths3 {
+	return IssuingTransactionExpandPaths3{
+		ExpandPath:         path,
+		Authorization:      path + ".authorization",
+		BalanceTransaction: path + ".balance_transaction",
+		Card:               path + ".card",
+		Cardholder:         path + ".cardholder",
+		Dispute:            path + ".dispute",
+	}
+}
+
+// IssuingDisputeEvidenceFraudulentExpandPaths3 are the paths of the expandable fields of an issuing dispute evidence fraudulent
+// at level 3 of a path.
+type IssuingDisputeEvidenceFraudulentExpandPaths3 struct {
+	UncategorizedFile ExpandPath
+}
+
+func newIssuingDisputeEvidenceFraudulentExpandPaths3(path ExpandPath) IssuingDisputeEvidenceFraudulentExpandPaths3 {
+	return IssuingDisputeEvidenceFraudulentExpandPaths3{
+		UncategorizedFile: path + ".uncategorized_file",
+	}
+}
+
+// IssuingDisputeEvidenceOtherExpandPaths3 are the paths of the expandable fields of an issuing dispute evidence other
+// at level 3 of a path.
+type IssuingDisputeEvidenceOtherExpandPaths3 struct {
+	UncategorizedFile ExpandPath
+}
+
+func newIssuingDisputeEvidenceOtherExpandPaths3(path ExpandPath) IssuingDisputeEvidenceOtherExpandPaths3 {
+	return IssuingDisputeEvidenceOtherExpandPaths3{
+		UncategorizedFile: path + ".uncategorized_file",
+	}
+}
+
+// IssuingAuthorizationExpandPaths3 is the path of an expandable issuing authorization at level 3 of
+// a path, along with the paths of its own expandable fields.
+type IssuingAuthorizationExpandPaths3 struct {
+	ExpandPath
+
+	Card       ExpandPath
+	Cardholder ExpandPath
+}
+
+func newIssuingAuthorizationExpandPaths3(path ExpandPath) IssuingAuthorizationExpandPaths3 {
+	return IssuingAuthorizationExpandPaths3{
+		ExpandPath: path,
+		Card:       path + ".card",
+		Cardholder: path + ".cardholder",
+	}
+}
+
+// IssuingDisputeExpandPaths3 is the path of an expandable issuing dispute at level 3 of
+// a path, along with the paths of its own expandable fields.
+type IssuingDisputeExpandPaths3 struct {
+	ExpandPath
+
+	Transaction ExpandPath
+}
+
+func newIssuingDisputeExpandPaths3(path ExpandPath) IssuingDisputeExpandPaths3 {
+	return IssuingDisputeExpandPaths3{
+		ExpandPath:  path,
+		Transaction: path + ".transaction",
+	}
+}
+
+// BankAccountExpandPaths3 is the path of an expandable bank account at level 3 of
+// a path, along with the paths of its own expandable fields.
+type BankAccountExpandPaths3 struct {
+	ExpandPath
+
+	Account  ExpandPath
+	Customer ExpandPath
+}
+
+func newBankAccountExpandPaths3(path ExpandPath) BankAccountExpandPaths3 {
+	return BankAccountExpandPaths3{
+		ExpandPath: path,
+		Account:    path + ".account",
+		Customer:   path + ".customer",
+	}
+}
+
+// CardExpandPaths3 is the path of an expandable card at level 3 of
+// a path, along with the paths of its own expandable fields.
+type CardExpandPaths3 struct {
+	ExpandPath
+
+	Customer  ExpandPath
+	Recipient ExpandPath
+}
+
+func newCardExpandPaths3(path ExpandPath) CardExpandPaths3 {
+	return CardExpandPaths3{
+		ExpandPath: path,
+		Customer:   path + ".customer",
+		Recipient:  path + ".recipient",
+	}
+}
+
+// PlanExpandPaths3 is the path of an expandable plan at level 3 of
+// a path, along with the paths of its own expandable fields.
+type PlanExpandPaths3 struct {
+	ExpandPath
+
+	Product ExpandPath
+}
+
+func newPlanExpandPaths3(path ExpandPath) PlanExpandPaths3 {
+	return PlanExpandPaths3{
+		ExpandPath: path,
+		Product:    path + ".product",
+	}
+}
+
+// SubscriptionScheduleExpandPaths3 is the path of an expandable subscription schedule at level 3 of
+// a path, along with the paths of its own expandable fields.
+type SubscriptionScheduleExpandPaths3 struct {
+	ExpandPath
+
+	Customer             ExpandPath
+	ReleasedSubscription ExpandPath
+	Subscription         ExpandPath
+}
+
+func newSubscriptionScheduleExpandPaths3(path ExpandPath) SubscriptionScheduleExpandPaths3 {
+	return SubscriptionScheduleExpandPaths3{
+		ExpandPath:           path,
+		Customer:             path + ".customer",
+		ReleasedSubscription: path + ".released_subscription",
+		Subscription:         path + ".subscription",
+	}
+}
+
+// SubscriptionTransferDataExpandPaths3 are the paths of the expandable fields of a subscription transfer data
+// at level 3 of a path.
+type SubscriptionTransferDataExpandPaths3 struct {
+	Destination ExpandPath
+}
+
+func newSubscriptionTransferDataExpandPaths3(path ExpandPath) SubscriptionTransferDataExpandPaths3 {
+	return SubscriptionTransferDataExpandPaths3{
+		Destination: path + ".destination",
+	}
+}
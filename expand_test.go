@@ -0,0 +1,83 @@
+package stripe
+
+import (
+	"encoding/json"
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestExpandPaths(t *testing.T) {
+	assert.Equal(t, ExpandPath("customer"), ChargeExpand.Customer.Path())
+	assert.Equal(t, ExpandPath("customer.default_source"), ChargeExpand.Customer.DefaultSource.Path())
+	assert.Equal(t, ExpandPath("outcome.rule"), ChargeExpand.Outcome.Rule.Path())
+	assert.Equal(t, ExpandPath("data.customer.default_source"), ChargeExpand.Customer.DefaultSource.InList())
+
+	// Paths end at the maximum depth of expansion
+	assert.Equal(t, ExpandPath("invoice.charge.customer.default_source"),
+		ChargeExpand.Invoice.Charge.Customer.DefaultSource)
+
+	params := &ChargeParams{}
+	params.AddExpandPaths(ChargeExpand.Customer, ChargeExpand.Invoice.Customer)
+	assert.Equal(t, []*string{String("customer"), String("invoice.customer")}, params.Expand)
+
+	listParams := &ChargeListParams{}
+	listParams.AddExpandPaths(ChargeExpand.Customer.InList())
+	assert.Equal(t, []*string{String("data.customer")}, listParams.Expand)
+}
+
+func TestFetch(t *testing.T) {
+	backend := &recordingBackend{}
+
+	var charge Charge
+	err := json.Unmarshal([]byte(`{"id":"ch_123","customer":"cus_123"}`), &charge)
+	assert.NoError(t, err)
+
+	params := &Params{}
+	params.SetStripeAccount("acct_123")
+	err = charge.Customer.Fetch(backend, "sk_test_123", params)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/v1/customers/cus_123"}, backend.paths)
+
+	// The request is made with the caller's key and params
+	assert.Equal(t, []string{"sk_test_123"}, backend.keys)
+	assert.Equal(t, "acct_123", *backend.params[0].GetParams().StripeAccount)
+
+	// Expanded objects aren't fetched again
+	backend.paths = nil
+	err = charge.Fetch(backend, "sk_test_123", nil)
+	assert.NoError(t, err)
+	assert.Empty(t, backend.paths)
+
+	err = (&Customer{}).Fetch(backend, "sk_test_123", nil)
+	assert.Error(t, err)
+	assert.Empty(t, backend.paths)
+}
+
+func TestFetch_DefaultKey(t *testing.T) {
+	key := Key
+	Key = "sk_test_global"
+	defer func() { Key = key }()
+
+	backend := &recordingBackend{}
+	customer := &Customer{ID: "cus_123"}
+	err := customer.Fetch(backend, "", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"sk_test_global"}, backend.keys)
+}
+
+func TestIsExpanded(t *testing.T) {
+	var charge Charge
+	err := json.Unmarshal([]byte(`{"id":"ch_123","customer":"cus_123","invoice":{"id":"in_123"}}`), &charge)
+	assert.NoError(t, err)
+
+	assert.True(t, charge.IsExpanded())
+	assert.False(t, charge.Customer.IsExpanded())
+	assert.Equal(t, "cus_123", charge.Customer.ID)
+	assert.True(t, charge.Invoice.IsExpanded())
+
+	// Missing objects and ones that weren't decoded from a response aren't
+	// expanded
+	assert.False(t, charge.Review.IsExpanded())
+	assert.False(t, (&Customer{ID: "cus_123"}).IsExpanded())
+}
@@ -33,6 +33,9 @@ type ApplicationFee struct {
 	OriginatingTransaction *Charge             `json:"originating_transaction"`
 	Refunded               bool                `json:"refunded"`
 	Refunds                *FeeRefundList      `json:"refunds"`
+
+	Extra    map[string]json.RawMessage `json:"-"`
+	expanded bool
 }
 
 //ApplicationFeeList is a list of application fees as retrieved from a list endpoint.
@@ -57,5 +60,6 @@ func (f *ApplicationFee) UnmarshalJSON(data []byte) error {
 	}
 
 	*f = ApplicationFee(v)
+	f.expanded = true
 	return nil
 }
@@ -29,6 +29,9 @@ type FeeRefund struct {
 	Fee                *ApplicationFee     `json:"fee"`
 	ID                 string              `json:"id"`
 	Metadata           map[string]string   `json:"metadata"`
+
+	Extra    map[string]json.RawMessage `json:"-"`
+	expanded bool
 }
 
 // FeeRefundList is a list object for application fee refunds.
@@ -53,5 +56,6 @@ func (r *FeeRefund) UnmarshalJSON(data []byte) error {
 	}
 
 	*r = FeeRefund(v)
+	r.expanded = true
 	return nil
 }
@@ -73,6 +73,9 @@ type File struct {
 	Size     int64         `json:"size"`
 	Type     string        `json:"type"`
 	URL      string        `json:"url"`
+
+	Extra    map[string]json.RawMessage `json:"-"`
+	expanded bool
 }
 
 // FileList is a list of files as retrieved from a list endpoint.
@@ -146,5 +149,6 @@ func (f *File) UnmarshalJSON(data []byte) error {
 	}
 
 	*f = File(v)
+	f.expanded = true
 	return nil
 }
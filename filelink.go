@@ -32,6 +32,9 @@ type FileLink struct {
 	Metadata  map[string]string `json:"metadata"`
 	Object    string            `json:"object"`
 	URL       string            `json:"url"`
+
+	Extra    map[string]json.RawMessage `json:"-"`
+	expanded bool
 }
 
 // UnmarshalJSON handles deserialization of a file link.
@@ -50,6 +53,7 @@ func (c *FileLink) UnmarshalJSON(data []byte) error {
 	}
 
 	*c = FileLink(v)
+	c.expanded = true
 	return nil
 }
 
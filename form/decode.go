@@ -0,0 +1,419 @@
+package form
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Decoder is the interface implemented by types that can decode themselves
+// from a collection of form values. It's the counterpart of Appender, and any
+// type with a custom AppendTo should implement DecodeFrom to undo it.
+type Decoder interface {
+	// DecodeFrom is invoked by the form package on any types found to
+	// implement Decoder so that they have a chance to decode themselves. Note
+	// that DecodeFrom is called before normal decoding, and that it should
+	// remove any values that it consumes (see Values.Del) so that they're not
+	// also decoded into one of the struct's tagged fields.
+	DecodeFrom(values *Values, keyParts []string) error
+}
+
+// Decode uses reflection to decode the given values collection into the
+// struct pointed to by v based off the form tags that it defines. It's the
+// inverse of AppendTo and understands the keys that it produces, including
+// indexed slices and arrays (`a[0][b]`), maps (`metadata[key]`), embedded
+// structs tagged with a wildcard, and the `empty` and `high_precision` tag
+// options.
+//
+// Keys that don't correspond to any field are ignored. Notably, this includes
+// anything encoded by an Appender that doesn't have a matching Decoder. The
+// given values collection isn't modified.
+func Decode(values *Values, v interface{}) error {
+	return DecodePrefixed(values.clone(), v, nil)
+}
+
+// DecodePrefixed is the same as Decode, but it allows a slice of key parts to
+// be specified under which v's values are found. Unlike Decode, it removes the
+// values that it decoded from the values collection, which makes it suitable
+// for use from DecodeFrom.
+//
+// v may also be a pointer to a pointer, in which case the inner pointer is
+// only set if there were values to decode into it.
+func DecodePrefixed(values *Values, v interface{}, keyParts []string) error {
+	reflectValue := reflect.ValueOf(v)
+	if reflectValue.Kind() != reflect.Ptr || reflectValue.IsNil() {
+		return fmt.Errorf("form: can only decode into a non-nil pointer, got %T", v)
+	}
+
+	return decodeValue(values, reflectValue.Elem(), keyParts, nil)
+}
+
+// ParseQuery parses a URL-encoded query string or request body into a values
+// collection, preserving the order of its parameters so that it can be
+// compared to one produced by AppendTo.
+func ParseQuery(query string) (*Values, error) {
+	values := &Values{}
+
+	for _, pair := range strings.Split(query, "&") {
+		if pair == "" {
+			continue
+		}
+
+		key, val := pair, ""
+		if i := strings.Index(pair, "="); i >= 0 {
+			key, val = pair[:i], pair[i+1:]
+		}
+
+		key, err := url.QueryUnescape(key)
+		if err != nil {
+			return nil, err
+		}
+
+		val, err = url.QueryUnescape(val)
+		if err != nil {
+			return nil, err
+		}
+
+		values.Add(key, val)
+	}
+
+	return values, nil
+}
+
+// ---
+
+var decoderType = reflect.TypeOf((*Decoder)(nil)).Elem()
+
+func decodeArrayOrSlice(values *Values, v reflect.Value, keyParts []string) error {
+	if len(keyParts) < 1 {
+		return nil
+	}
+	key := FormatKey(keyParts)
+
+	// An empty value for a slice is the encoding of an explicitly emptied
+	// slice. See `buildArrayOrSliceEncoder`.
+	if v.Kind() == reflect.Slice {
+		if vals := values.Get(key); len(vals) > 0 && vals[0] == "" {
+			values.take(key)
+			v.Set(reflect.MakeSlice(v.Type(), 0, 0))
+			return nil
+		}
+	}
+
+	length := 0
+	for _, subKey := range subKeys(values, key) {
+		i, err := strconv.Atoi(subKey)
+		if err != nil || i < 0 {
+			return fmt.Errorf("form: invalid index in %s[%s]", key, subKey)
+		}
+
+		// Indexes are contiguous when encoded, so one that's larger than
+		// the number of values can't be valid (and would be expensive to
+		// allocate for).
+		if i >= len(values.values) || (v.Kind() == reflect.Array && i >= v.Len()) {
+			return fmt.Errorf("form: index out of range in %s[%s]", key, subKey)
+		}
+
+		if i+1 > length {
+			length = i + 1
+		}
+	}
+
+	if length == 0 {
+		return nil
+	}
+
+	target := v
+	if v.Kind() == reflect.Slice {
+		target = reflect.MakeSlice(v.Type(), length, length)
+	}
+
+	for i := 0; i < length; i++ {
+		err := decodeValue(values, target.Index(i), appendKeyPart(keyParts, strconv.Itoa(i)), nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	v.Set(target)
+	return nil
+}
+
+func decodeInterface(values *Values, v reflect.Value, keyParts []string) error {
+	if len(keyParts) < 1 || !v.IsNil() {
+		return nil
+	}
+	key := FormatKey(keyParts)
+
+	// Without a concrete type to go by, a single value is decoded as a
+	// string and anything nested as a map.
+	if val, ok := values.take(key); ok {
+		v.Set(reflect.ValueOf(val))
+		return nil
+	}
+
+	if len(subKeys(values, key)) > 0 {
+		m := make(map[string]interface{})
+		if err := decodeValue(values, reflect.ValueOf(m), keyParts, nil); err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(m))
+	}
+
+	return nil
+}
+
+func decodeMap(values *Values, v reflect.Value, keyParts []string) error {
+	if len(keyParts) < 1 {
+		return nil
+	}
+
+	t := v.Type()
+	if t.Key().Kind() != reflect.String {
+		return fmt.Errorf("form: can't decode into a map with non-string keys (%s)", t)
+	}
+
+	mapKeys := subKeys(values, FormatKey(keyParts))
+	if len(mapKeys) == 0 {
+		return nil
+	}
+
+	if v.IsNil() {
+		v.Set(reflect.MakeMap(t))
+	}
+
+	for _, mapKey := range mapKeys {
+		elem := reflect.New(t.Elem()).Elem()
+		err := decodeValue(values, elem, appendKeyPart(keyParts, mapKey), nil)
+		if err != nil {
+			return err
+		}
+		v.SetMapIndex(reflect.ValueOf(mapKey).Convert(t.Key()), elem)
+	}
+
+	return nil
+}
+
+func decodePtr(values *Values, v reflect.Value, keyParts []string, options *formOptions) error {
+	if len(keyParts) > 0 && !hasKey(values, FormatKey(keyParts)) {
+		return nil
+	}
+
+	// A null for a scalar leaves its pointer nil. See `takeNull`.
+	if takeNull(values, v.Type().Elem(), keyParts, options) {
+		return nil
+	}
+
+	// Like with the encoder, a nil pointer means that the property wasn't
+	// set, so the pointer is only set if something was decoded into its
+	// value.
+	elem := reflect.New(v.Type().Elem())
+	numValues := len(values.values)
+
+	if err := decodeValue(values, elem.Elem(), keyParts, options); err != nil {
+		return err
+	}
+
+	if len(values.values) < numValues {
+		v.Set(elem)
+	}
+	return nil
+}
+
+func decodeScalar(values *Values, v reflect.Value, keyParts []string, options *formOptions) error {
+	if len(keyParts) < 1 {
+		return nil
+	}
+	key := FormatKey(keyParts)
+
+	if takeNull(values, v.Type(), keyParts, options) {
+		return nil
+	}
+
+	// Only the first instance of a key is consumed so that any others can be
+	// decoded into other fields with the same name.
+	val, ok := values.take(key)
+	if !ok {
+		return nil
+	}
+
+	var err error
+
+	switch v.Kind() {
+	case reflect.Bool:
+		if options != nil && options.Empty && val == "" {
+			v.SetBool(true)
+			break
+		}
+
+		var b bool
+		b, err = strconv.ParseBool(val)
+		v.SetBool(b)
+
+	case reflect.Float32, reflect.Float64:
+		var f float64
+		f, err = strconv.ParseFloat(val, v.Type().Bits())
+		v.SetFloat(f)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var i int64
+		i, err = strconv.ParseInt(val, 10, v.Type().Bits())
+		v.SetInt(i)
+
+	case reflect.String:
+		v.SetString(val)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var u uint64
+		u, err = strconv.ParseUint(val, 10, v.Type().Bits())
+		v.SetUint(u)
+	}
+
+	if err != nil {
+		return fmt.Errorf("form: can't decode %s=%q into %s: %v", key, val, v.Type(), err)
+	}
+	return nil
+}
+
+func decodeStruct(values *Values, v reflect.Value, keyParts []string) error {
+	t := v.Type()
+
+	if v.CanAddr() && reflect.PtrTo(t).Implements(decoderType) {
+		if err := v.Addr().Interface().(Decoder).DecodeFrom(values, keyParts); err != nil {
+			return err
+		}
+	}
+
+	// The struct encoder caches the information about fields and their tags
+	// that's needed to decode them as well.
+	se := getCachedOrBuildStructEncoder(t)
+
+	for _, f := range se.fields {
+		fieldV := v.Field(f.index)
+		if f.formName == "" || !fieldV.CanSet() {
+			continue
+		}
+
+		// See the comment on wildcards in `structEncoder.encode`.
+		fieldKeyParts := keyParts
+		if f.formName != "*" {
+			fieldKeyParts = appendKeyPart(keyParts, f.formName)
+		}
+
+		if err := decodeValue(values, fieldV, fieldKeyParts, f.options); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// decodeValue is the shared entry point of the decoding functions, which
+// dispatches based on the kind of v. It's the decoding equivalent of the
+// encoders built by makeTypeEncoder.
+func decodeValue(values *Values, v reflect.Value, keyParts []string, options *formOptions) error {
+	switch v.Kind() {
+	case reflect.Array, reflect.Slice:
+		return decodeArrayOrSlice(values, v, keyParts)
+
+	case reflect.Interface:
+		return decodeInterface(values, v, keyParts)
+
+	case reflect.Map:
+		return decodeMap(values, v, keyParts)
+
+	case reflect.Ptr:
+		return decodePtr(values, v, keyParts, options)
+
+	case reflect.Struct:
+		return decodeStruct(values, v, keyParts)
+	}
+
+	return decodeScalar(values, v, keyParts, options)
+}
+
+// appendKeyPart returns a copy of keyParts with part appended. Unlike a plain
+// append, the result never shares memory with keyParts, which matters here
+// because DecodeFrom implementations may keep appending to their keyParts.
+func appendKeyPart(keyParts []string, part string) []string {
+	parts := make([]string, len(keyParts), len(keyParts)+1)
+	copy(parts, keyParts)
+	return append(parts, part)
+}
+
+// takeNull consumes the value for the given key if it's null (see
+// Values.SetNull) and the type is one that can't represent an empty value
+// itself, like an integer, and returns true if it did. The field is left
+// unset; whether it was null can be checked on the values with IsNull.
+func takeNull(values *Values, t reflect.Type, keyParts []string, options *formOptions) bool {
+	if len(keyParts) < 1 {
+		return false
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		// An empty value is how the `empty` option encodes true
+		if options != nil && options.Empty {
+			return false
+		}
+
+	case reflect.Float32, reflect.Float64,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+
+	default:
+		return false
+	}
+
+	key := FormatKey(keyParts)
+	if vals := values.Get(key); len(vals) == 0 || vals[0] != "" {
+		return false
+	}
+
+	values.take(key)
+	return true
+}
+
+// hasKey returns true if values contains either the given key or any key
+// nested under it.
+func hasKey(values *Values, key string) bool {
+	prefix := key + "["
+	for _, v := range values.values {
+		if v.Key == key || strings.HasPrefix(v.Key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// subKeys returns the distinct key parts found immediately under the given
+// key (`b` for `a[b]` or `a[b][c]` given `a`), in the order they first
+// appear.
+func subKeys(values *Values, key string) []string {
+	prefix := key + "["
+
+	var keys []string
+	seen := make(map[string]bool)
+
+	for _, v := range values.values {
+		if !strings.HasPrefix(v.Key, prefix) {
+			continue
+		}
+
+		rest := v.Key[len(prefix):]
+		end := strings.Index(rest, "]")
+		if end < 0 {
+			continue
+		}
+
+		subKey := rest[:end]
+		if !seen[subKey] {
+			seen[subKey] = true
+			keys = append(keys, subKey)
+		}
+	}
+
+	return keys
+}
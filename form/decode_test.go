@@ -0,0 +1,204 @@
+package form
+
+import (
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+)
+
+type testDecoder struct {
+	String string `form:"-" json:"-"` // Value decoded manually
+}
+
+func (a *testDecoder) AppendTo(values *Values, keyParts []string) {
+	values.Add(FormatKey(append(keyParts, "special")), a.String)
+}
+
+func (a *testDecoder) DecodeFrom(values *Values, keyParts []string) error {
+	key := FormatKey(append(keyParts, "special"))
+	if vals := values.Get(key); len(vals) > 0 {
+		a.String = vals[0]
+		values.Del(key)
+	}
+	return nil
+}
+
+type testDecodeStruct struct {
+	Decoder  *testDecoder   `form:"decoder" json:"decoder"`
+	Decoders []*testDecoder `form:"decoders" json:"decoders"`
+
+	Int   int64  `form:"int" json:"int"`
+	Slice []*int `form:"slice" json:"slice"`
+}
+
+func TestDecode(t *testing.T) {
+	data := &testStruct{
+		Array:             [3]string{"1", "2", "3"},
+		ArrayPtr:          &[3]string{"4", "5", "6"},
+		Bool:              true,
+		BoolPtr:           boolPtr(false),
+		Emptied:           true,
+		Float32:           1.5,
+		Float32Ptr:        float32Ptr(0),
+		Float32Precise:    1.2345678,
+		Float32PrecisePtr: float32Ptr(1.2345678),
+		Float64:           1.5,
+		Float64Ptr:        float64Ptr(0),
+		Float64Precise:    1.23456789012345,
+		Float64PrecisePtr: float64Ptr(1.23456789012345),
+		Int:               -1,
+		IntPtr:            intPtr(0),
+		Int8:              -8,
+		Int16:             -16,
+		Int32:             -32,
+		Int64:             -64,
+		Int64Ptr:          int64Ptr(64),
+		Map: map[string]interface{}{
+			"foo": "bar",
+			"baz": map[string]interface{}{"qux": "quux"},
+		},
+		Slice:            []string{"1", "2"},
+		SlicePtr:         &[]string{},
+		String:           "foo",
+		StringPtr:        stringPtr(""),
+		SubStruct:        testSubStruct{SubSubStruct: testSubSubStruct{String: "bar"}},
+		SubStructPtr:     &testSubStruct{SubSubStruct: testSubSubStruct{String: "baz"}},
+		SubStructFlat:    testSubStruct{SubSubStruct: testSubSubStruct{String: "flat"}},
+		SubStructFlatPtr: &testSubStruct{SubSubStruct: testSubSubStruct{String: "flat"}},
+		Uuint:            1,
+		Uuint8:           8,
+		Uuint16:          16,
+		Uuint32:          32,
+		Uuint64:          64,
+		Uuint64Ptr:       uint64Ptr(0),
+	}
+
+	values := &Values{}
+	AppendTo(values, data)
+
+	decoded := &testStruct{}
+	err := Decode(values, decoded)
+	assert.NoError(t, err)
+
+	assert.Equal(t, data, decoded)
+}
+
+func TestDecode_Decoder(t *testing.T) {
+	data := &testDecodeStruct{
+		Decoder:  &testDecoder{String: "foo"},
+		Decoders: []*testDecoder{{String: "bar"}, {String: "baz"}},
+	}
+
+	values := &Values{}
+	AppendTo(values, data)
+
+	decoded := &testDecodeStruct{}
+	err := Decode(values, decoded)
+	assert.NoError(t, err)
+	assert.Equal(t, data, decoded)
+
+	// The original values are left untouched
+	assert.Equal(t, []string{"foo"}, values.Get("decoder[special]"))
+}
+
+func TestDecode_Errors(t *testing.T) {
+	values := &Values{}
+	values.Add("int", "foo")
+	err := Decode(values, &testDecodeStruct{})
+	assert.Error(t, err)
+
+	values = &Values{}
+	values.Add("slice[1000000000]", "1")
+	err = Decode(values, &testDecodeStruct{})
+	assert.Error(t, err)
+
+	values = &Values{}
+	values.Add("slice[foo]", "1")
+	err = Decode(values, &testDecodeStruct{})
+	assert.Error(t, err)
+
+	err = Decode(&Values{}, testDecodeStruct{})
+	assert.Error(t, err)
+}
+
+func TestDecode_UnknownKeys(t *testing.T) {
+	values := &Values{}
+	values.Add("int", "1")
+	values.Add("unknown", "foo")
+
+	decoded := &testDecodeStruct{}
+	err := Decode(values, decoded)
+	assert.NoError(t, err)
+	assert.Equal(t, &testDecodeStruct{Int: 1}, decoded)
+}
+
+func TestDecode_Null(t *testing.T) {
+	values := &Values{}
+	values.SetNull("bool_ptr")
+	values.SetNull("emptied")
+	values.SetNull("int")
+	values.SetNull("int64_ptr")
+	values.SetNull("string_ptr")
+
+	decoded := &testStruct{}
+	err := Decode(values, decoded)
+	assert.NoError(t, err)
+
+	// Fields that can't represent an empty value are left unset
+	assert.Equal(t, &testStruct{Emptied: true, StringPtr: stringPtr("")}, decoded)
+}
+
+func TestDecodePrefixed(t *testing.T) {
+	values := &Values{}
+	AppendToPrefixed(values, &testStruct{String: "foo"}, []string{"prefix"})
+
+	decoded := &testStruct{}
+	err := DecodePrefixed(values, decoded, []string{"prefix"})
+	assert.NoError(t, err)
+	assert.Equal(t, &testStruct{String: "foo"}, decoded)
+
+	// Decoded values are consumed
+	assert.True(t, values.Empty())
+}
+
+func TestParseQuery(t *testing.T) {
+	values := &Values{}
+	values.Add("foo", "bar")
+	values.Add("arr[0][baz]", "a b&c")
+	values.Add("foo", "")
+
+	parsed, err := ParseQuery(values.Encode())
+	assert.NoError(t, err)
+	assert.Equal(t, values, parsed)
+
+	_, err = ParseQuery("foo=%zz")
+	assert.Error(t, err)
+}
+
+//
+// Private functions
+//
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func float32Ptr(f float32) *float32 {
+	return &f
+}
+
+func float64Ptr(f float64) *float64 {
+	return &f
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func int64Ptr(i int64) *int64 {
+	return &i
+}
+
+func uint64Ptr(u uint64) *uint64 {
+	return &u
+}
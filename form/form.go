@@ -26,6 +26,10 @@ type Appender interface {
 	AppendTo(values *Values, keyParts []string)
 }
 
+// EncoderFunc encodes the form-tagged fields of the struct pointed to by v
+// into values without the use of reflection. See RegisterEncoder.
+type EncoderFunc func(values *Values, v interface{}, keyParts []string)
+
 // encoderFunc is used to encode any type from a request.
 //
 // A note about encodeZero: Since some types in the Stripe API are defaulted to
@@ -115,10 +119,16 @@ var structCache struct {
 	mu sync.RWMutex // for coordinating concurrent operations on m
 }
 
+var registeredEncoders struct {
+	m  map[reflect.Type]EncoderFunc
+	mu sync.RWMutex // for coordinating concurrent operations on m
+}
+
 // AppendTo uses reflection to form encode into the given values collection
-// based off the form tags that it defines.
+// based off the form tags that it defines. Types with an encoder registered
+// with RegisterEncoder are encoded with it instead.
 func AppendTo(values *Values, i interface{}) {
-	reflectValue(values, reflect.ValueOf(i), false, nil)
+	AppendToPrefixed(values, i, nil)
 }
 
 // AppendToPrefixed is the same as AppendTo, but it allows a slice of key parts
@@ -128,9 +138,37 @@ func AppendTo(values *Values, i interface{}) {
 // for recipients. Recipients is going away, and when it does, we can probably
 // remove it again.
 func AppendToPrefixed(values *Values, i interface{}, keyParts []string) {
+	if f := getRegisteredEncoder(reflect.TypeOf(i)); f != nil {
+		f(values, i, keyParts)
+		if appender, ok := i.(Appender); ok {
+			appender.AppendTo(values, keyParts)
+		}
+		return
+	}
+
 	reflectValue(values, reflect.ValueOf(i), false, keyParts)
 }
 
+// RegisterEncoder registers a function that AppendTo uses to encode values of
+// the given type, which should be a pointer to a struct, in place of
+// reflection. It's used by the encoders generated for the parameter types in
+// the stripe package by `scripts/generate_form_encoders`.
+//
+// The function must produce exactly the same values that reflection would for
+// the struct's fields. It shouldn't include whatever the type's own AppendTo
+// (if it has one) produces, which AppendTo still calls afterwards. Note that
+// the function is only used for exactly the given type, and not for structs
+// that embed it.
+func RegisterEncoder(t reflect.Type, f EncoderFunc) {
+	registeredEncoders.mu.Lock()
+	defer registeredEncoders.mu.Unlock()
+
+	if registeredEncoders.m == nil {
+		registeredEncoders.m = make(map[reflect.Type]EncoderFunc)
+	}
+	registeredEncoders.m[t] = f
+}
+
 // FormatKey takes a series of key parts that may be parameter keyParts, map keys,
 // or array indices and unifies them into a single key suitable for Stripe's
 // style of form encoding.
@@ -284,6 +322,13 @@ func getCachedOrBuildStructEncoder(t reflect.Type) *structEncoder {
 	return f
 }
 
+func getRegisteredEncoder(t reflect.Type) EncoderFunc {
+	registeredEncoders.mu.RLock()
+	defer registeredEncoders.mu.RUnlock()
+
+	return registeredEncoders.m[t]
+}
+
 // getCachedOrBuildTypeEncoder tries to get an encoderFunc for the type from
 // the cache, and falls back to building one if there wasn't a cached one
 // available. If an encoder is built, it's stored back to the cache.
@@ -530,11 +575,21 @@ func parseTag(tag string) (string, *formOptions) {
 // request that specifically allows for duplicate keys and encodes its entries
 // in the same order that they were added.
 type Values struct {
+	// index maps each key to the positions of its entries in values so that
+	// lookups don't need to scan the whole collection.
+	index map[string][]int
+
+	nulls  []string
 	values []formValue
 }
 
-// Add adds a key/value tuple to the form.
+// Add adds a key/value tuple to the form. It has no effect if the key, or one
+// that it's nested under, has been set to null with SetNull.
 func (f *Values) Add(key, val string) {
+	if f.isNulled(key) {
+		return
+	}
+	f.addIndex(key, len(f.values))
 	f.values = append(f.values, formValue{key, val})
 }
 
@@ -556,21 +611,48 @@ func (f *Values) Encode() string {
 	return buf.String()
 }
 
+// Del removes all instances of a parameter for the given key.
+//
+// Note that Del is O(n) and may be quite slow for a very large parameter list.
+func (f *Values) Del(key string) {
+	values := f.values[:0]
+	for _, v := range f.values {
+		if v.Key != key {
+			values = append(values, v)
+		}
+	}
+	f.values = values
+	f.reindex()
+}
+
 // Empty returns true if no parameters have been set.
 func (f *Values) Empty() bool {
 	return len(f.values) == 0
 }
 
+// IsNull returns true if the parameter for the given key is null, meaning
+// that it's present with only empty values. This is how the API expects a
+// field to be cleared, and is the result of SetNull.
+func (f *Values) IsNull(key string) bool {
+	vals := f.Get(key)
+	for _, v := range vals {
+		if v != "" {
+			return false
+		}
+	}
+	return len(vals) > 0
+}
+
 // Set sets the first instance of a parameter for the given key to the given
 // value. If no parameters exist with the key, a new one is added.
-//
-// Note that Set is O(n) and may be quite slow for a very large parameter list.
 func (f *Values) Set(key, val string) {
-	for i, v := range f.values {
-		if v.Key == key {
-			f.values[i].Value = val
-			return
-		}
+	if f.isNulled(key) {
+		return
+	}
+
+	if positions := f.index[key]; len(positions) > 0 {
+		f.values[positions[0]].Value = val
+		return
 	}
 
 	f.Add(key, val)
@@ -578,18 +660,38 @@ func (f *Values) Set(key, val string) {
 
 // Get retrieves the list of values for the given key.  If no values exist
 // for the key, nil will be returned.
-//
-// Note that Get is O(n) and may be quite slow for a very large parameter list.
 func (f *Values) Get(key string) []string {
 	var results []string
-	for i, v := range f.values {
-		if v.Key == key {
-			results = append(results, f.values[i].Value)
-		}
+	for _, i := range f.index[key] {
+		results = append(results, f.values[i].Value)
 	}
 	return results
 }
 
+// SetNull sets the parameter for the given key to null (an empty value),
+// which the API interprets as clearing it. Any existing values for the key or
+// nested under it are removed, and any added later are ignored, so that a
+// null takes precedence over the value of a field in a params struct
+// regardless of the order in which they're encoded.
+func (f *Values) SetNull(key string) {
+	if f.isNulled(key) {
+		return
+	}
+
+	prefix := key + "["
+
+	values := f.values[:0]
+	for _, v := range f.values {
+		if v.Key != key && !strings.HasPrefix(v.Key, prefix) {
+			values = append(values, v)
+		}
+	}
+	f.values = append(values, formValue{key, ""})
+	f.reindex()
+
+	f.nulls = append(f.nulls, key)
+}
+
 // ToValues converts an instance of Values into an instance of
 // url.Values. This can be useful in cases where it's useful to make an
 // unordered comparison of two sets of request values.
@@ -616,6 +718,63 @@ func (f *Values) ToValues() url.Values {
 	return values
 }
 
+// clone returns a copy of the values collection that can be modified without
+// affecting the original.
+func (f *Values) clone() *Values {
+	if f == nil {
+		return &Values{}
+	}
+	clone := &Values{
+		nulls:  append([]string(nil), f.nulls...),
+		values: append([]formValue(nil), f.values...),
+	}
+	clone.reindex()
+	return clone
+}
+
+// addIndex records that the entry at position i has the given key.
+func (f *Values) addIndex(key string, i int) {
+	if f.index == nil {
+		f.index = make(map[string][]int)
+	}
+	f.index[key] = append(f.index[key], i)
+}
+
+// isNulled returns true if the given key, or one that it's nested under, has
+// been set to null.
+func (f *Values) isNulled(key string) bool {
+	for _, null := range f.nulls {
+		if key == null || strings.HasPrefix(key, null+"[") {
+			return true
+		}
+	}
+	return false
+}
+
+// reindex rebuilds the index after entries have been removed from values,
+// which shifts the positions of those after them.
+func (f *Values) reindex() {
+	f.index = nil
+	for i, v := range f.values {
+		f.addIndex(v.Key, i)
+	}
+}
+
+// take removes the first instance of a parameter for the given key from values
+// and returns its value, or false if there was none.
+func (f *Values) take(key string) (string, bool) {
+	positions := f.index[key]
+	if len(positions) == 0 {
+		return "", false
+	}
+
+	i := positions[0]
+	val := f.values[i].Value
+	f.values = append(f.values[:i], f.values[i+1:]...)
+	f.reindex()
+	return val, true
+}
+
 // A key/value tuple for use in the Values type.
 type formValue struct {
 	Key   string
@@ -3,6 +3,7 @@ package form
 import (
 	"fmt"
 	"net/url"
+	"reflect"
 	"sync"
 	"testing"
 
@@ -89,6 +90,20 @@ func (a *testAppender) AppendTo(values *Values, keyParts []string) {
 	values.Add(FormatKey(keyParts), a.String)
 }
 
+type embeddingRegisteredStruct struct {
+	registeredStruct `form:"*" json:"*"`
+}
+
+// registeredStruct has an encoder registered for it, which names its field
+// differently than reflection would so that the two can be told apart.
+type registeredStruct struct {
+	String string `form:"string" json:"string"`
+}
+
+func (s *registeredStruct) AppendTo(values *Values, keyParts []string) {
+	values.Add(FormatKey(append(keyParts, "appended")), "true")
+}
+
 type testSubStruct struct {
 	SubSubStruct testSubSubStruct `form:"subsubstruct" json:"subsubstruct"`
 }
@@ -99,6 +114,10 @@ type testSubSubStruct struct {
 
 func init() {
 	Strict = true
+
+	RegisterEncoder(reflect.TypeOf((*registeredStruct)(nil)), func(values *Values, v interface{}, keyParts []string) {
+		values.Add(FormatKey(append(keyParts, "registered")), v.(*registeredStruct).String)
+	})
 }
 
 func BenchmarkAppendTo(b *testing.B) {
@@ -379,6 +398,22 @@ func TestAppendToPrefixed(t *testing.T) {
 	assert.Equal(t, []string{"foo"}, form.Get("prefix[string]"))
 }
 
+func TestAppendTo_RegisteredEncoder(t *testing.T) {
+	form := &Values{}
+	AppendTo(form, &registeredStruct{String: "foo"})
+	assert.Equal(t, "registered=foo&appended=true", form.Encode())
+
+	form = &Values{}
+	AppendToPrefixed(form, &registeredStruct{String: "foo"}, []string{"prefix"})
+	assert.Equal(t, "prefix[registered]=foo&prefix[appended]=true", form.Encode())
+
+	// Structs embedding a type with a registered encoder are still encoded
+	// with reflection.
+	form = &Values{}
+	AppendTo(form, &embeddingRegisteredStruct{registeredStruct{String: "foo"}})
+	assert.Equal(t, "string=foo&appended=true", form.Encode())
+}
+
 func TestFormatKey(t *testing.T) {
 	assert.Equal(t, "param", FormatKey([]string{"param"}))
 	assert.Equal(t, "param[key]", FormatKey([]string{"param", "key"}))
@@ -486,6 +521,40 @@ func TestValues(t *testing.T) {
 	assert.Equal(t, []string{"appended"}, values.Get("new"))
 
 	assert.Nil(t, values.Get("boguskey"))
+
+	values.Del("foo")
+
+	assert.Equal(t, "baz=bar&new=appended", values.Encode())
+	assert.Nil(t, values.Get("foo"))
+
+	values.Set("new", "set")
+
+	assert.Equal(t, "baz=bar&new=set", values.Encode())
+	assert.Equal(t, []string{"set"}, values.Get("new"))
+}
+
+func TestValues_SetNull(t *testing.T) {
+	values := &Values{}
+	values.Add("foo", "bar")
+	values.Add("baz[qux]", "bar")
+	values.Add("bazz", "bar")
+
+	values.SetNull("foo")
+	values.SetNull("baz")
+
+	// Values added after a null are ignored
+	values.Add("foo", "bar")
+	values.Add("baz[quux]", "bar")
+	values.Set("foo", "bar")
+
+	// A null nested under another is redundant
+	values.SetNull("baz[qux]")
+
+	assert.Equal(t, "bazz=bar&foo=&baz=", values.Encode())
+	assert.True(t, values.IsNull("foo"))
+	assert.True(t, values.IsNull("baz"))
+	assert.False(t, values.IsNull("bazz"))
+	assert.False(t, values.IsNull("boguskey"))
 }
 
 //
@@ -0,0 +1,73 @@
+package stripe
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"sync/atomic"
+)
+
+//
+// Public types
+//
+
+// IdempotencyKeyGenerator generates the idempotency keys sent with requests
+// writing data, like POST requests, that don't set one explicitly with
+// Params.IdempotencyKey. It can be set for a backend with
+// BackendConfig.IdempotencyKeyGenerator, or for the requests made with a
+// context with WithIdempotencyKeyGenerator.
+//
+// Implementations must be safe for concurrent use.
+type IdempotencyKeyGenerator interface {
+	NewIdempotencyKey() string
+}
+
+// DeterministicKeyGenerator generates idempotency keys from the name of an
+// operation and a sequence number counting the keys generated so far, like
+// "import-invoices-1", "import-invoices-2" and so on. See
+// DeterministicIdempotencyKey.
+//
+// Keys are the same from one run to the next, which makes recorded requests
+// comparable byte for byte. It also means that a retried operation resends
+// the same keys, so an operation name must identify a single run of an
+// operation in production.
+type DeterministicKeyGenerator struct {
+	// Operation is the name of the operation that keys are generated for.
+	Operation string
+
+	sequence int64
+}
+
+// NewDeterministicKeyGenerator returns a generator of the idempotency keys of
+// the given operation, starting at sequence number 1.
+func NewDeterministicKeyGenerator(operation string) *DeterministicKeyGenerator {
+	return &DeterministicKeyGenerator{Operation: operation}
+}
+
+// NewIdempotencyKey returns the key with the next sequence number.
+func (g *DeterministicKeyGenerator) NewIdempotencyKey() string {
+	return DeterministicIdempotencyKey(g.Operation, atomic.AddInt64(&g.sequence, 1))
+}
+
+//
+// Public functions
+//
+
+// DeterministicIdempotencyKey returns the idempotency key of the request
+// with the given sequence number in an operation.
+func DeterministicIdempotencyKey(operation string, sequence int64) string {
+	return operation + "-" + strconv.FormatInt(sequence, 10)
+}
+
+//
+// Private functions
+//
+
+// newIdempotencyKey generates a random idempotency key which starts with the
+// current time.
+func newIdempotencyKey(clock Clock, rand Rand) string {
+	now := clock.Now().UnixNano()
+	buf := make([]byte, 4)
+	rand.Read(buf)
+	return fmt.Sprintf("%v_%v", now, base64.URLEncoding.EncodeToString(buf)[:6])
+}
@@ -0,0 +1,81 @@
+package stripe
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestDeterministicKeyGenerator(t *testing.T) {
+	generator := NewDeterministicKeyGenerator("import-invoices")
+	assert.Equal(t, "import-invoices-1", generator.NewIdempotencyKey())
+	assert.Equal(t, "import-invoices-2", generator.NewIdempotencyKey())
+	assert.Equal(t, "import-invoices-3", DeterministicIdempotencyKey("import-invoices", 3))
+}
+
+func TestNewRequest_IdempotencyKeyGenerator(t *testing.T) {
+	backend := GetBackendWithConfig(APIBackend, &BackendConfig{
+		IdempotencyKeyGenerator: NewDeterministicKeyGenerator("backend"),
+	}).(*BackendImplementation)
+
+	newKey := func(params *Params) string {
+		req, err := backend.NewRequest(http.MethodPost, "/v1/customers", "sk_test_123",
+			"application/x-www-form-urlencoded", params)
+		assert.NoError(t, err)
+		return req.Header.Get("Idempotency-Key")
+	}
+
+	assert.Equal(t, "backend-1", newKey(&Params{}))
+	assert.Equal(t, "backend-2", newKey(&Params{}))
+
+	// A generator on the context takes precedence over the backend's
+	ctx := WithIdempotencyKeyGenerator(context.Background(), NewDeterministicKeyGenerator("operation"))
+	ctx = WithIdempotencyKeyPrefix(ctx, "tenant-")
+	assert.Equal(t, "tenant-operation-1", newKey(&Params{Context: ctx}))
+
+	// Explicit keys still take precedence over both
+	assert.Equal(t, "explicit", newKey(&Params{Context: ctx, IdempotencyKey: String("explicit")}))
+}
+
+func TestBackend_Deterministic(t *testing.T) {
+	newBackend := func() *BackendImplementation {
+		return GetBackendWithConfig(APIBackend, &BackendConfig{
+			Clock: &testClock{now: time.Unix(1500000000, 0)},
+			Rand:  NewDeterministicRand(42),
+		}).(*BackendImplementation)
+	}
+
+	// Two backends configured the same way behave the same way
+	var keys [2][]string
+	var sleeps [2][]time.Duration
+	for i, backend := range []*BackendImplementation{newBackend(), newBackend()} {
+		for retry := 0; retry < 3; retry++ {
+			req, err := backend.NewRequest(http.MethodPost, "/v1/customers", "sk_test_123",
+				"application/x-www-form-urlencoded", &Params{})
+			assert.NoError(t, err)
+
+			keys[i] = append(keys[i], req.Header.Get("Idempotency-Key"))
+			sleeps[i] = append(sleeps[i], backend.sleepTime(retry))
+		}
+	}
+
+	assert.Equal(t, keys[0], keys[1])
+	assert.Equal(t, sleeps[0], sleeps[1])
+	assert.Regexp(t, `^1500000000000000000_`, keys[0][0])
+	assert.NotEqual(t, keys[0][0], keys[0][1])
+}
+
+func TestNewIdempotencyKey_Rand(t *testing.T) {
+	defer func(original Clock) { DefaultClock = original }(DefaultClock)
+	defer func(original Rand) { DefaultRand = original }(DefaultRand)
+	DefaultClock = &testClock{now: time.Unix(1500000000, 0)}
+
+	DefaultRand = NewDeterministicRand(42)
+	key := NewIdempotencyKey()
+
+	DefaultRand = NewDeterministicRand(42)
+	assert.Equal(t, key, NewIdempotencyKey())
+}
@@ -145,6 +145,20 @@ func (p *InvoiceParams) AppendTo(body *form.Values, keyParts []string) {
 	}
 }
 
+// DecodeFrom implements custom decoding logic for InvoiceParams that undoes
+// its AppendTo.
+func (p *InvoiceParams) DecodeFrom(body *form.Values, keyParts []string) error {
+	if takeFormValue(body, append(keyParts, "subscription_billing_cycle_anchor"), "now") {
+		p.SubscriptionBillingCycleAnchorNow = Bool(true)
+	}
+
+	if takeFormValue(body, append(keyParts, "subscription_billing_cycle_anchor"), "unchanged") {
+		p.SubscriptionBillingCycleAnchorUnchanged = Bool(true)
+	}
+
+	return nil
+}
+
 // InvoiceListParams is the set of parameters that can be used when listing invoices.
 // For more details see https://stripe.com/docs/api#list_customer_invoices.
 type InvoiceListParams struct {
@@ -271,6 +285,9 @@ type Invoice struct {
 
 	// This field is deprecated and we recommend that you use TaxRates instead.
 	TaxPercent float64 `json:"tax_percent"`
+
+	Extra    map[string]json.RawMessage `json:"-"`
+	expanded bool
 }
 
 // InvoiceCustomField is a structure representing a custom field on an invoice.
@@ -332,6 +349,8 @@ type InvoiceLine struct {
 	TaxRates         []*TaxRate          `json:"tax_rates"`
 	Type             InvoiceLineType     `json:"type"`
 	UnifiedProration bool                `json:"unified_proration"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // InvoiceTransferData represents the information for the transfer_data associated with an invoice.
@@ -375,5 +394,6 @@ func (i *Invoice) UnmarshalJSON(data []byte) error {
 	}
 
 	*i = Invoice(v)
+	i.expanded = true
 	return nil
 }
@@ -0,0 +1,371 @@
+package invoice
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	stripe "github.com/stripe/stripe-go"
+)
+
+//
+// Public types
+//
+
+// Calculator computes the totals of an invoice locally, the way Stripe does,
+// from the parameters of its invoice items. This makes it possible to show
+// totals before any invoice item is created. Verify compares its results
+// with an invoice computed by Stripe.
+//
+// The coupon's discount is computed on the discountable items, and then
+// split between them in proportion to their amounts. Each item is taxed on
+// its amount after its share of the discount, with rounding to the smallest
+// currency unit for each of its tax rates. When an item has both inclusive
+// and exclusive tax rates, the exclusive ones apply to its amount net of
+// inclusive tax.
+type Calculator struct {
+	// Coupon is the coupon applied to the invoice, if any. Coupons which
+	// apply once are always applied, since they're removed after the invoice
+	// they apply to.
+	Coupon *stripe.Coupon
+
+	// CouponStart is the Unix time at which the coupon was applied. It's used
+	// to tell whether a repeating coupon still applies.
+	CouponStart int64
+
+	// Currency is the currency of the invoice.
+	Currency stripe.Currency
+
+	// DefaultTaxRates are the tax rates applied to the items that don't have
+	// tax rates of their own.
+	DefaultTaxRates []*stripe.TaxRate
+
+	// TaxRates are the tax rates referred to by ID in the items' TaxRates.
+	TaxRates []*stripe.TaxRate
+}
+
+// Calculation is the result of a Calculator. All amounts are in the smallest
+// unit of Currency.
+type Calculation struct {
+	// Currency is the currency of the invoice.
+	Currency stripe.Currency
+
+	// Discount is the amount taken off by the coupon.
+	Discount int64
+
+	// Lines are the results for each invoice item, in order.
+	Lines []*CalculationLine
+
+	// Subtotal is the total of the items' amounts, before discount and
+	// exclusive tax.
+	Subtotal int64
+
+	// Tax is the total tax, inclusive and exclusive.
+	Tax int64
+
+	// TaxAmounts are the total tax amounts for each tax rate, in the order
+	// the tax rates are first applied.
+	TaxAmounts []*stripe.InvoiceTaxAmount
+
+	// Total is the total of the invoice: its subtotal, minus the discount,
+	// plus exclusive tax.
+	Total int64
+}
+
+// CalculationLine is the result of a Calculator for an invoice item.
+type CalculationLine struct {
+	// Amount is the amount of the item before discount and exclusive tax.
+	Amount int64
+
+	// Discount is the item's share of the coupon's discount.
+	Discount int64
+
+	// Item is the invoice item.
+	Item *stripe.InvoiceItemParams
+
+	// Tax is the item's total tax, inclusive and exclusive.
+	Tax int64
+
+	// TaxAmounts are the item's tax amounts for each of its tax rates.
+	TaxAmounts []*stripe.InvoiceTaxAmount
+
+	// Total is the amount of the item, minus its discount, plus exclusive
+	// tax.
+	Total int64
+}
+
+// Divergence is a difference between a Calculation and an invoice computed
+// by Stripe.
+type Divergence struct {
+	// Calculated is the amount computed by the Calculator.
+	Calculated int64
+
+	// Field is the name of the diverging amount, like "subtotal" or
+	// "tax[txr_123]" for the amount of a tax rate.
+	Field string
+
+	// Invoiced is the amount computed by Stripe.
+	Invoiced int64
+}
+
+// String describes the divergence.
+func (d *Divergence) String() string {
+	return fmt.Sprintf("%s: calculated %d, invoiced %d", d.Field, d.Calculated, d.Invoiced)
+}
+
+//
+// Public functions
+//
+
+// Calculate computes the totals of an invoice with the given items, at the
+// given time. Items set either Amount, or UnitAmount or UnitAmountDecimal
+// along with an optional Quantity.
+func (c *Calculator) Calculate(items []*stripe.InvoiceItemParams, at time.Time) (*Calculation, error) {
+	calculation := &Calculation{Currency: c.Currency}
+
+	var discountable []int64
+	for i, item := range items {
+		if item.Currency != nil && stripe.Currency(*item.Currency) != c.Currency {
+			return nil, fmt.Errorf("invoice item %d is in %s, but the invoice is in %s",
+				i, *item.Currency, c.Currency)
+		}
+
+		amount, err := itemAmount(item)
+		if err != nil {
+			return nil, fmt.Errorf("invoice item %d: %v", i, err)
+		}
+		calculation.Lines = append(calculation.Lines, &CalculationLine{Amount: amount, Item: item})
+		calculation.Subtotal += amount
+
+		// Like the API, items are discountable by default unless they're
+		// credits
+		var ratio int64
+		if stripe.BoolValue(item.Discountable) || (item.Discountable == nil && amount > 0) {
+			ratio = amount
+		}
+		if ratio < 0 {
+			ratio = 0
+		}
+		discountable = append(discountable, ratio)
+	}
+
+	if err := c.discount(calculation, discountable, at); err != nil {
+		return nil, err
+	}
+
+	taxRates := make(map[string]*stripe.TaxRate)
+	for _, taxRate := range c.TaxRates {
+		taxRates[taxRate.ID] = taxRate
+	}
+	totals := make(map[string]*stripe.InvoiceTaxAmount)
+
+	for i, line := range calculation.Lines {
+		rates := c.DefaultTaxRates
+		if len(line.Item.TaxRates) > 0 {
+			rates = nil
+			for _, id := range line.Item.TaxRates {
+				taxRate, ok := taxRates[stripe.StringValue(id)]
+				if !ok {
+					return nil, fmt.Errorf("invoice item %d has unknown tax rate %s", i, stripe.StringValue(id))
+				}
+				rates = append(rates, taxRate)
+			}
+		}
+
+		var inclusive float64
+		for _, taxRate := range rates {
+			if taxRate.Inclusive {
+				inclusive += taxRate.Percentage
+			}
+		}
+
+		// Inclusive tax is part of the amount, so exclusive tax only applies
+		// to what's left of it
+		taxable := line.Amount - line.Discount
+		taxes := make([]int64, len(rates))
+		exclusiveTaxable := taxable
+		for j, taxRate := range rates {
+			if taxRate.Inclusive {
+				taxes[j] = stripe.RoundAmount(float64(taxable) * taxRate.Percentage / (100 + inclusive))
+				exclusiveTaxable -= taxes[j]
+			}
+		}
+		for j, taxRate := range rates {
+			if !taxRate.Inclusive {
+				taxes[j] = stripe.RoundAmount(float64(exclusiveTaxable) * taxRate.Percentage / 100)
+			}
+		}
+
+		line.Total = taxable
+		for j, taxRate := range rates {
+			tax := taxes[j]
+			if !taxRate.Inclusive {
+				line.Total += tax
+			}
+			line.Tax += tax
+			line.TaxAmounts = append(line.TaxAmounts, &stripe.InvoiceTaxAmount{
+				Amount:    tax,
+				Inclusive: taxRate.Inclusive,
+				TaxRate:   taxRate,
+			})
+
+			total, ok := totals[taxRate.ID]
+			if !ok {
+				total = &stripe.InvoiceTaxAmount{Inclusive: taxRate.Inclusive, TaxRate: taxRate}
+				totals[taxRate.ID] = total
+				calculation.TaxAmounts = append(calculation.TaxAmounts, total)
+			}
+			total.Amount += tax
+		}
+
+		calculation.Tax += line.Tax
+		calculation.Total += line.Total
+	}
+
+	return calculation, nil
+}
+
+// Verify compares a calculation with an invoice computed by Stripe, and
+// returns the amounts that differ.
+func Verify(calculation *Calculation, invoice *stripe.Invoice) []*Divergence {
+	var divergences []*Divergence
+	compare := func(field string, calculated, invoiced int64) {
+		if calculated != invoiced {
+			divergences = append(divergences, &Divergence{
+				Calculated: calculated,
+				Field:      field,
+				Invoiced:   invoiced,
+			})
+		}
+	}
+
+	exclusiveTax := invoice.Tax
+	if len(invoice.TotalTaxAmounts) > 0 {
+		exclusiveTax = 0
+		for _, taxAmount := range invoice.TotalTaxAmounts {
+			if !taxAmount.Inclusive {
+				exclusiveTax += taxAmount.Amount
+			}
+		}
+	}
+
+	compare("subtotal", calculation.Subtotal, invoice.Subtotal)
+	compare("discount", calculation.Discount, invoice.Subtotal+exclusiveTax-invoice.Total)
+	compare("tax", calculation.Tax, invoice.Tax)
+	compare("total", calculation.Total, invoice.Total)
+
+	invoiced := make(map[string]int64)
+	for _, taxAmount := range invoice.TotalTaxAmounts {
+		if taxAmount.TaxRate != nil {
+			invoiced[taxAmount.TaxRate.ID] += taxAmount.Amount
+		}
+	}
+	for _, taxAmount := range calculation.TaxAmounts {
+		id := taxAmount.TaxRate.ID
+		compare("tax["+id+"]", taxAmount.Amount, invoiced[id])
+		delete(invoiced, id)
+	}
+	for _, taxAmount := range invoice.TotalTaxAmounts {
+		if taxAmount.TaxRate == nil {
+			continue
+		}
+		if amount, ok := invoiced[taxAmount.TaxRate.ID]; ok {
+			compare("tax["+taxAmount.TaxRate.ID+"]", 0, amount)
+			delete(invoiced, taxAmount.TaxRate.ID)
+		}
+	}
+
+	return divergences
+}
+
+// VerifyNext compares a calculation with the upcoming invoice described by
+// the given parameters, and returns the amounts that differ.
+func VerifyNext(calculation *Calculation, params *stripe.InvoiceParams) ([]*Divergence, error) {
+	return getC().VerifyNext(calculation, params)
+}
+
+// VerifyNext compares a calculation with the upcoming invoice described by
+// the given parameters, and returns the amounts that differ.
+func (c Client) VerifyNext(calculation *Calculation, params *stripe.InvoiceParams) ([]*Divergence, error) {
+	invoice, err := c.GetNext(params)
+	if err != nil {
+		return nil, err
+	}
+	return Verify(calculation, invoice), nil
+}
+
+//
+// Private functions
+//
+
+// couponApplies returns whether the coupon applies to an invoice at the
+// given time.
+func (c *Calculator) couponApplies(at time.Time) bool {
+	if c.Coupon == nil {
+		return false
+	}
+	if c.Coupon.Duration != stripe.CouponDurationRepeating || c.CouponStart == 0 {
+		return true
+	}
+	end := time.Unix(c.CouponStart, 0).UTC().AddDate(0, int(c.Coupon.DurationInMonths), 0)
+	return at.Before(end)
+}
+
+// discount computes the coupon's discount and splits it between the lines
+// in proportion to the given ratios.
+func (c *Calculator) discount(calculation *Calculation, ratios []int64, at time.Time) error {
+	if !c.couponApplies(at) {
+		return nil
+	}
+
+	var base int64
+	for _, ratio := range ratios {
+		base += ratio
+	}
+	if base == 0 {
+		return nil
+	}
+
+	switch {
+	case c.Coupon.PercentOff > 0:
+		calculation.Discount = stripe.RoundAmount(float64(base) * c.Coupon.PercentOff / 100)
+	case c.Coupon.AmountOff > 0:
+		if c.Coupon.Currency != c.Currency {
+			return fmt.Errorf("coupon %s is in %s, but the invoice is in %s",
+				c.Coupon.ID, c.Coupon.Currency, c.Currency)
+		}
+		calculation.Discount = c.Coupon.AmountOff
+		if calculation.Discount > base {
+			calculation.Discount = base
+		}
+	}
+
+	shares, err := stripe.NewMoney(calculation.Discount, c.Currency).Allocate(ratios...)
+	if err != nil {
+		return err
+	}
+	for i, share := range shares {
+		calculation.Lines[i].Discount = share.Amount
+	}
+	return nil
+}
+
+// itemAmount returns the amount of an invoice item.
+func itemAmount(item *stripe.InvoiceItemParams) (int64, error) {
+	if item.Amount != nil {
+		return *item.Amount, nil
+	}
+
+	quantity := int64(1)
+	if item.Quantity != nil {
+		quantity = *item.Quantity
+	}
+
+	switch {
+	case item.UnitAmount != nil:
+		return *item.UnitAmount * quantity, nil
+	case item.UnitAmountDecimal != nil:
+		return stripe.RoundAmount(*item.UnitAmountDecimal * float64(quantity)), nil
+	}
+	return 0, errors.New("needs an amount or a unit amount")
+}
@@ -0,0 +1,198 @@
+package invoice
+
+import (
+	"testing"
+	"time"
+
+	assert "github.com/stretchr/testify/require"
+	stripe "github.com/stripe/stripe-go"
+	stripetesting "github.com/stripe/stripe-go/testing"
+)
+
+// upcomingBackend is a Backend returning an upcoming invoice.
+type upcomingBackend struct {
+	stripetesting.Backend
+
+	upcoming *stripe.Invoice
+}
+
+func (b *upcomingBackend) Call(method, path, key string, params stripe.ParamsContainer, v interface{}) error {
+	*v.(*stripe.Invoice) = *b.upcoming
+	return nil
+}
+
+var (
+	salesTax = &stripe.TaxRate{ID: "txr_sales", Percentage: 8.25}
+	stateTax = &stripe.TaxRate{ID: "txr_state", Percentage: 2}
+	vat      = &stripe.TaxRate{ID: "txr_vat", Inclusive: true, Percentage: 20}
+)
+
+func TestCalculator(t *testing.T) {
+	calculator := &Calculator{
+		Currency:        stripe.CurrencyUSD,
+		DefaultTaxRates: []*stripe.TaxRate{salesTax},
+		TaxRates:        []*stripe.TaxRate{salesTax, stateTax},
+	}
+
+	calculation, err := calculator.Calculate([]*stripe.InvoiceItemParams{
+		{Amount: stripe.Int64(1000)},
+		{UnitAmount: stripe.Int64(333), Quantity: stripe.Int64(3),
+			TaxRates: stripe.StringSlice([]string{"txr_sales", "txr_state"})},
+		{UnitAmountDecimal: stripe.Float64(0.5), Quantity: stripe.Int64(3)},
+	}, time.Unix(1500000000, 0))
+	assert.NoError(t, err)
+
+	assert.Equal(t, 3, len(calculation.Lines))
+	assert.Equal(t, int64(999), calculation.Lines[1].Amount)
+	assert.Equal(t, int64(82+20), calculation.Lines[1].Tax)
+	assert.Equal(t, int64(2), calculation.Lines[2].Amount)
+
+	assert.Equal(t, int64(2001), calculation.Subtotal)
+	assert.Equal(t, int64(0), calculation.Discount)
+	assert.Equal(t, int64(83+82+20), calculation.Tax)
+	assert.Equal(t, int64(2001+185), calculation.Total)
+	assert.Equal(t, 2, len(calculation.TaxAmounts))
+	assert.Equal(t, int64(165), calculation.TaxAmounts[0].Amount)
+	assert.Equal(t, int64(20), calculation.TaxAmounts[1].Amount)
+}
+
+func TestCalculator_InclusiveTax(t *testing.T) {
+	calculator := &Calculator{
+		Currency:        stripe.CurrencyEUR,
+		DefaultTaxRates: []*stripe.TaxRate{vat},
+	}
+
+	calculation, err := calculator.Calculate([]*stripe.InvoiceItemParams{
+		{Amount: stripe.Int64(1200)},
+	}, time.Unix(1500000000, 0))
+	assert.NoError(t, err)
+
+	// Inclusive tax is part of the amount, so it doesn't add to the total
+	assert.Equal(t, int64(200), calculation.Tax)
+	assert.Equal(t, int64(1200), calculation.Total)
+	assert.True(t, calculation.TaxAmounts[0].Inclusive)
+}
+
+func TestCalculator_MixedTax(t *testing.T) {
+	calculator := &Calculator{
+		Currency: stripe.CurrencyEUR,
+		TaxRates: []*stripe.TaxRate{stateTax, vat},
+	}
+
+	calculation, err := calculator.Calculate([]*stripe.InvoiceItemParams{
+		{Amount: stripe.Int64(1200), TaxRates: stripe.StringSlice([]string{"txr_state", "txr_vat"})},
+	}, time.Unix(1500000000, 0))
+	assert.NoError(t, err)
+
+	// Exclusive tax applies to the amount net of inclusive tax
+	assert.Equal(t, int64(20), calculation.Lines[0].TaxAmounts[0].Amount)
+	assert.Equal(t, int64(200), calculation.Lines[0].TaxAmounts[1].Amount)
+	assert.Equal(t, int64(220), calculation.Tax)
+	assert.Equal(t, int64(1220), calculation.Total)
+}
+
+func TestCalculator_Coupons(t *testing.T) {
+	items := []*stripe.InvoiceItemParams{
+		{Amount: stripe.Int64(1000)},
+		{Amount: stripe.Int64(2000)},
+		{Amount: stripe.Int64(500), Discountable: stripe.Bool(false)},
+		{Amount: stripe.Int64(-300)},
+	}
+	at := time.Unix(1500000000, 0)
+
+	calculator := &Calculator{
+		Coupon:          &stripe.Coupon{Duration: stripe.CouponDurationForever, PercentOff: 10},
+		Currency:        stripe.CurrencyUSD,
+		DefaultTaxRates: []*stripe.TaxRate{salesTax},
+	}
+	calculation, err := calculator.Calculate(items, at)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(300), calculation.Discount)
+	assert.Equal(t, int64(100), calculation.Lines[0].Discount)
+	assert.Equal(t, int64(200), calculation.Lines[1].Discount)
+	assert.Equal(t, int64(0), calculation.Lines[2].Discount)
+	assert.Equal(t, int64(74), calculation.Lines[0].Tax)
+	assert.Equal(t, int64(3200-300+74+149+41-25), calculation.Total)
+
+	// Amounts off can't take off more than the discountable amount
+	calculator.Coupon = &stripe.Coupon{AmountOff: 5000, Currency: stripe.CurrencyUSD, Duration: stripe.CouponDurationOnce}
+	calculation, err = calculator.Calculate(items, at)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3000), calculation.Discount)
+
+	calculator.Coupon = &stripe.Coupon{AmountOff: 100, Currency: stripe.CurrencyEUR, Duration: stripe.CouponDurationOnce}
+	_, err = calculator.Calculate(items, at)
+	assert.Error(t, err)
+
+	// Repeating coupons stop applying after their duration
+	calculator.Coupon = &stripe.Coupon{Duration: stripe.CouponDurationRepeating, DurationInMonths: 3, PercentOff: 10}
+	calculator.CouponStart = at.AddDate(0, -3, 0).Unix()
+	calculation, err = calculator.Calculate(items, at)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), calculation.Discount)
+
+	calculator.CouponStart = at.AddDate(0, -2, 0).Unix()
+	calculation, err = calculator.Calculate(items, at)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(300), calculation.Discount)
+}
+
+func TestCalculator_Invalid(t *testing.T) {
+	calculator := &Calculator{Currency: stripe.CurrencyUSD}
+	at := time.Unix(1500000000, 0)
+
+	_, err := calculator.Calculate([]*stripe.InvoiceItemParams{{}}, at)
+	assert.Error(t, err)
+
+	_, err = calculator.Calculate([]*stripe.InvoiceItemParams{
+		{Amount: stripe.Int64(100), Currency: stripe.String(string(stripe.CurrencyEUR))},
+	}, at)
+	assert.Error(t, err)
+
+	_, err = calculator.Calculate([]*stripe.InvoiceItemParams{
+		{Amount: stripe.Int64(100), TaxRates: stripe.StringSlice([]string{"txr_unknown"})},
+	}, at)
+	assert.Error(t, err)
+}
+
+func TestVerifyNext(t *testing.T) {
+	calculator := &Calculator{
+		Currency:        stripe.CurrencyUSD,
+		DefaultTaxRates: []*stripe.TaxRate{salesTax},
+	}
+	calculation, err := calculator.Calculate([]*stripe.InvoiceItemParams{
+		{Amount: stripe.Int64(1000)},
+	}, time.Unix(1500000000, 0))
+	assert.NoError(t, err)
+
+	backend := &upcomingBackend{upcoming: &stripe.Invoice{
+		Subtotal:        1000,
+		Tax:             83,
+		Total:           1083,
+		TotalTaxAmounts: []*stripe.InvoiceTaxAmount{{Amount: 83, TaxRate: salesTax}},
+	}}
+	c := Client{B: backend, Key: "sk_test_123"}
+
+	divergences, err := c.VerifyNext(calculation, &stripe.InvoiceParams{Customer: stripe.String("cus_123")})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(divergences))
+
+	backend.upcoming = &stripe.Invoice{
+		Subtotal: 1000,
+		Tax:      82,
+		Total:    1082,
+		TotalTaxAmounts: []*stripe.InvoiceTaxAmount{
+			{Amount: 80, TaxRate: salesTax},
+			{Amount: 2, TaxRate: stateTax},
+		},
+	}
+	divergences, err = c.VerifyNext(calculation, &stripe.InvoiceParams{Customer: stripe.String("cus_123")})
+	assert.NoError(t, err)
+	assert.Equal(t, []*Divergence{
+		{Calculated: 83, Field: "tax", Invoiced: 82},
+		{Calculated: 1083, Field: "total", Invoiced: 1082},
+		{Calculated: 83, Field: "tax[txr_sales]", Invoiced: 80},
+		{Calculated: 0, Field: "tax[txr_state]", Invoiced: 2},
+	}, divergences)
+	assert.Equal(t, "tax: calculated 83, invoiced 82", divergences[0].String())
+}
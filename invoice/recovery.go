@@ -0,0 +1,707 @@
+package invoice
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	stripe "github.com/stripe/stripe-go"
+	"github.com/stripe/stripe-go/paymentmethod"
+)
+
+//
+// Public constants
+//
+
+// DefaultRecoveryLockTTL is how long a RecoveryEngine holds the lock on an
+// invoice it's recovering unless configured otherwise.
+const DefaultRecoveryLockTTL time.Duration = 5 * time.Minute
+
+// DefaultRecoveryMaxPaymentMethods is how many of a customer's payment
+// methods a retry tries with RecoveryPolicy.TryPaymentMethods unless
+// configured otherwise.
+const DefaultRecoveryMaxPaymentMethods = 3
+
+// RecoveryEventType is the type of a RecoveryEvent.
+type RecoveryEventType string
+
+// List of values that RecoveryEventType can take.
+const (
+	RecoveryEventTypeAttemptFailed RecoveryEventType = "attempt_failed"
+	RecoveryEventTypeFinalAction   RecoveryEventType = "final_action"
+	RecoveryEventTypeRecovered     RecoveryEventType = "recovered"
+)
+
+// RecoveryFinalAction is what a RecoveryEngine does with an invoice once all
+// of its retries failed.
+type RecoveryFinalAction string
+
+// List of values that RecoveryFinalAction can take.
+const (
+	RecoveryFinalActionMarkUncollectible RecoveryFinalAction = "mark_uncollectible"
+	RecoveryFinalActionNone              RecoveryFinalAction = "none"
+	RecoveryFinalActionVoid              RecoveryFinalAction = "void"
+)
+
+// RecoveryStatus is the status of the recovery of an invoice.
+type RecoveryStatus string
+
+// List of values that RecoveryStatus can take.
+const (
+	RecoveryStatusExhausted     RecoveryStatus = "exhausted"
+	RecoveryStatusPending       RecoveryStatus = "pending"
+	RecoveryStatusRecovered     RecoveryStatus = "recovered"
+	RecoveryStatusUncollectible RecoveryStatus = "uncollectible"
+	RecoveryStatusVoided        RecoveryStatus = "voided"
+)
+
+//
+// Public variables
+//
+
+// ErrRecoveryLocked is returned when an invoice can't be recovered because
+// another worker holds its lock.
+var ErrRecoveryLocked = errors.New("invoice recovery is locked by another worker")
+
+//
+// Public types
+//
+
+// RecoveryEngine recovers invoices whose payment failed by retrying them on
+// a schedule, possibly with each of the customer's payment methods, and then
+// marking them uncollectible or voiding them if all retries failed.
+//
+// Invoices are tracked from the invoice.payment_failed events passed to
+// HandleEvent, or found by Scan among open invoices. Retries happen when
+// they're due, either as events are handled or when RunDue or Scan are
+// called, which is meant to be done periodically.
+//
+// The state of every recovery is kept in a RecoveryStore, which can be
+// shared by several workers. A worker locks an invoice in the store while
+// it's attempting to recover it, and payment attempts are made with
+// idempotency keys derived from the invoice, the payment method and the
+// retry number, so two workers can't pay the same invoice twice, even when a
+// worker takes over a lock that expired.
+type RecoveryEngine struct {
+	config RecoveryEngineConfig
+}
+
+// RecoveryEngineConfig is used to configure a new RecoveryEngine.
+type RecoveryEngineConfig struct {
+	// Client is the client used to get, list and pay invoices. Its backend and
+	// key are also used to list payment methods.
+	//
+	// If left unset, the package-level client is used.
+	Client *Client
+
+	// Clock is used to schedule retries and lock expirations.
+	//
+	// If left unset, stripe.DefaultClock is used.
+	Clock stripe.Clock
+
+	// LockTTL is how long a worker holds the lock on an invoice. It must be
+	// longer than it takes to attempt a payment with every payment method of
+	// a customer.
+	//
+	// Defaults to DefaultRecoveryLockTTL.
+	LockTTL time.Duration
+
+	// Policy is the recovery policy of invoices for which PolicyFor is unset
+	// or returns nil.
+	Policy *RecoveryPolicy
+
+	// PolicyFor returns the recovery policy of an invoice, which makes it
+	// possible to use different policies for different customers or amounts.
+	// It's called with the invoice every time it's attempted.
+	PolicyFor func(invoice *stripe.Invoice) *RecoveryPolicy
+
+	// Store is where the state of recoveries is kept.
+	//
+	// If left unset, a MemoryRecoveryStore is used, which can't be shared
+	// between processes.
+	Store RecoveryStore
+
+	// Worker identifies the worker in the locks it takes.
+	//
+	// If left unset, a random identifier is generated.
+	Worker string
+}
+
+// RecoveryEvent describes something that happened during the recovery of an
+// invoice. It's passed to RecoveryPolicy.Notify.
+type RecoveryEvent struct {
+	// Err is the error of the last payment that failed, for events of type
+	// RecoveryEventTypeAttemptFailed.
+	Err error
+
+	// Invoice is the invoice being recovered, as of the event.
+	Invoice *stripe.Invoice
+
+	// State is the state of the recovery after the event.
+	State *RecoveryState
+
+	// Type is the type of the event.
+	Type RecoveryEventType
+}
+
+// RecoveryPolicy configures how an invoice is recovered.
+type RecoveryPolicy struct {
+	// FinalAction is what's done with the invoice after its last retry
+	// failed.
+	//
+	// Defaults to RecoveryFinalActionNone, which leaves it open.
+	FinalAction RecoveryFinalAction
+
+	// MaxPaymentMethods is how many of the customer's payment methods are
+	// listed for each retry with TryPaymentMethods, which bounds how many
+	// payments a retry can make.
+	//
+	// Defaults to DefaultRecoveryMaxPaymentMethods.
+	MaxPaymentMethods int
+
+	// Notify is invoked after every failed retry, when the invoice is paid,
+	// and after the final action, for example to email the customer.
+	Notify func(event *RecoveryEvent)
+
+	// RetrySchedule are the delays after the first failed payment at which
+	// the invoice is retried, in increasing order. When it's empty, the final
+	// action is taken right away.
+	RetrySchedule []time.Duration
+
+	// TryPaymentMethods makes every retry try each of the customer's card
+	// payment methods in turn after the invoice's default one, until one
+	// succeeds. The default payment method isn't charged twice.
+	TryPaymentMethods bool
+}
+
+// RecoveryState is the state of the recovery of an invoice.
+type RecoveryState struct {
+	// Attempts is the number of retries made so far.
+	Attempts int `json:"attempts"`
+
+	// FailedAt is the Unix time at which the first failed payment was
+	// noticed.
+	FailedAt int64 `json:"failed_at"`
+
+	// Invoice is the ID of the invoice.
+	Invoice string `json:"invoice"`
+
+	// LastError is the message of the last error of a failed retry.
+	LastError string `json:"last_error"`
+
+	// NextAttemptAt is the Unix time at which the invoice is due to be
+	// retried, or at which the final action is due, while the recovery is
+	// pending.
+	NextAttemptAt int64 `json:"next_attempt_at"`
+
+	// Status is the status of the recovery.
+	Status RecoveryStatus `json:"status"`
+}
+
+// RecoveryStore keeps the state of recoveries. Implementations must be safe
+// for concurrent use, and Lock must be atomic across all the workers sharing
+// a store, like a conditional write in a database.
+type RecoveryStore interface {
+	// Due returns the pending recoveries whose next attempt is at or before
+	// the given time.
+	Due(now time.Time) ([]*RecoveryState, error)
+
+	// Get returns the state of the recovery of an invoice, or nil if it isn't
+	// tracked.
+	Get(invoice string) (*RecoveryState, error)
+
+	// Lock locks an invoice for a worker for the given duration from now. It
+	// returns false if another worker holds a lock on it that hasn't expired
+	// by now.
+	Lock(invoice, worker string, now time.Time, ttl time.Duration) (bool, error)
+
+	// Put saves the state of the recovery of an invoice.
+	Put(state *RecoveryState) error
+
+	// Unlock releases the lock of a worker on an invoice, if it still holds
+	// it.
+	Unlock(invoice, worker string) error
+}
+
+// MemoryRecoveryStore is a RecoveryStore keeping states in memory, for
+// workers running in a single process.
+type MemoryRecoveryStore struct {
+	mu     sync.Mutex
+	locks  map[string]recoveryLock
+	states map[string]RecoveryState
+}
+
+//
+// Public functions
+//
+
+// NewMemoryRecoveryStore returns an empty MemoryRecoveryStore.
+func NewMemoryRecoveryStore() *MemoryRecoveryStore {
+	return &MemoryRecoveryStore{
+		locks:  make(map[string]recoveryLock),
+		states: make(map[string]RecoveryState),
+	}
+}
+
+// Due returns the pending recoveries due at or before the given time, in the
+// order they're due.
+func (s *MemoryRecoveryStore) Due(now time.Time) ([]*RecoveryState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var due []*RecoveryState
+	for _, state := range s.states {
+		if state.Status == RecoveryStatusPending && state.NextAttemptAt <= now.Unix() {
+			state := state
+			due = append(due, &state)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool {
+		if due[i].NextAttemptAt != due[j].NextAttemptAt {
+			return due[i].NextAttemptAt < due[j].NextAttemptAt
+		}
+		return due[i].Invoice < due[j].Invoice
+	})
+	return due, nil
+}
+
+// Get returns a copy of the state of the recovery of an invoice.
+func (s *MemoryRecoveryStore) Get(invoice string) (*RecoveryState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, ok := s.states[invoice]
+	if !ok {
+		return nil, nil
+	}
+	return &state, nil
+}
+
+// Lock locks an invoice for a worker.
+func (s *MemoryRecoveryStore) Lock(invoice, worker string, now time.Time, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	lock, ok := s.locks[invoice]
+	if ok && lock.worker != worker && now.Before(lock.until) {
+		return false, nil
+	}
+	s.locks[invoice] = recoveryLock{until: now.Add(ttl), worker: worker}
+	return true, nil
+}
+
+// Put saves a copy of the state of the recovery of an invoice.
+func (s *MemoryRecoveryStore) Put(state *RecoveryState) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.states[state.Invoice] = *state
+	return nil
+}
+
+// Unlock releases the lock of a worker on an invoice.
+func (s *MemoryRecoveryStore) Unlock(invoice, worker string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if lock, ok := s.locks[invoice]; ok && lock.worker == worker {
+		delete(s.locks, invoice)
+	}
+	return nil
+}
+
+// NewRecoveryEngine returns a new recovery engine.
+func NewRecoveryEngine(config *RecoveryEngineConfig) (*RecoveryEngine, error) {
+	e := &RecoveryEngine{config: *config}
+
+	if e.config.Policy == nil && e.config.PolicyFor == nil {
+		return nil, errors.New("invoice recovery engine needs a policy")
+	}
+
+	if e.config.Client == nil {
+		client := getC()
+		e.config.Client = &client
+	}
+	if e.config.Clock == nil {
+		e.config.Clock = stripe.DefaultClock
+	}
+	if e.config.LockTTL <= 0 {
+		e.config.LockTTL = DefaultRecoveryLockTTL
+	}
+	if e.config.Store == nil {
+		e.config.Store = NewMemoryRecoveryStore()
+	}
+	if e.config.Worker == "" {
+		e.config.Worker = stripe.NewIdempotencyKey()
+	}
+
+	return e, nil
+}
+
+// HandleEvent updates the recoveries from an invoice event. An
+// invoice.payment_failed event starts tracking an invoice and attempts it
+// right away if its first retry is already due, while invoice.paid,
+// invoice.payment_succeeded, invoice.voided and invoice.marked_uncollectible
+// events end its recovery. Other events are ignored.
+//
+// It returns ErrRecoveryLocked when the invoice is being attempted by
+// another worker, in which case the event should be handled again later,
+// which is what happens when a webhook endpoint responds with an error.
+func (e *RecoveryEngine) HandleEvent(event *stripe.Event) error {
+	status, ok := recoveryEventStatuses[event.Type]
+	if !ok || event.Data == nil {
+		return nil
+	}
+
+	invoice := &stripe.Invoice{}
+	if err := json.Unmarshal(event.Data.Raw, invoice); err != nil {
+		return err
+	}
+
+	if status == RecoveryStatusPending {
+		if _, err := e.track(invoice); err != nil {
+			return err
+		}
+		_, err := e.Process(invoice.ID)
+		return err
+	}
+
+	return e.withLock(invoice.ID, func() error {
+		state, err := e.config.Store.Get(invoice.ID)
+		if err != nil || state == nil || state.Status != RecoveryStatusPending {
+			return err
+		}
+		return e.finish(state, invoice, status)
+	})
+}
+
+// Process attempts to recover an invoice if it's tracked and its next
+// attempt is due, and returns the state of its recovery, which is nil if it
+// isn't tracked.
+func (e *RecoveryEngine) Process(id string) (*RecoveryState, error) {
+	var state *RecoveryState
+	err := e.withLock(id, func() error {
+		var err error
+		state, err = e.config.Store.Get(id)
+		if err != nil || state == nil || state.Status != RecoveryStatusPending {
+			return err
+		}
+		if state.NextAttemptAt > e.config.Clock.Now().Unix() {
+			return nil
+		}
+
+		// The customer has the default payment method of invoices which
+		// don't have their own
+		params := &stripe.InvoiceParams{}
+		params.AddExpand("customer")
+		invoice, err := e.config.Client.Get(id, params)
+		if err != nil {
+			return err
+		}
+		if status := invoiceRecoveryStatus(invoice); status != RecoveryStatusPending {
+			return e.finish(state, invoice, status)
+		}
+
+		return e.attempt(state, invoice)
+	})
+	return state, err
+}
+
+// RunDue attempts all the invoices whose next attempt is due. Invoices locked
+// by other workers are skipped, and the first error met is returned after
+// all the other invoices were attempted.
+func (e *RecoveryEngine) RunDue() error {
+	due, err := e.config.Store.Due(e.config.Clock.Now())
+	if err != nil {
+		return err
+	}
+
+	var firstErr error
+	for _, state := range due {
+		if _, err := e.Process(state.Invoice); err != nil && err != ErrRecoveryLocked && firstErr == nil {
+			firstErr = fmt.Errorf("cannot recover invoice %s: %v", state.Invoice, err)
+		}
+	}
+	return firstErr
+}
+
+// Scan starts tracking the open invoices charged automatically whose payment
+// failed and which aren't tracked yet, like those whose events were missed,
+// and then runs the attempts that are due with RunDue.
+func (e *RecoveryEngine) Scan() error {
+	params := &stripe.InvoiceListParams{
+		CollectionMethod: stripe.String(string(stripe.InvoiceCollectionMethodChargeAutomatically)),
+		Status:           stripe.String(string(stripe.InvoiceStatusOpen)),
+	}
+
+	i := e.config.Client.List(params)
+	for i.Next() {
+		invoice := i.Invoice()
+		if !invoice.Attempted || invoice.Paid {
+			continue
+		}
+		if _, err := e.track(invoice); err != nil {
+			return err
+		}
+	}
+	if err := i.Err(); err != nil {
+		return err
+	}
+
+	return e.RunDue()
+}
+
+//
+// Private types
+//
+
+type recoveryLock struct {
+	until  time.Time
+	worker string
+}
+
+//
+// Private variables
+//
+
+// recoveryEventStatuses are the statuses of recoveries after each type of
+// event handled by RecoveryEngine.HandleEvent.
+var recoveryEventStatuses = map[string]RecoveryStatus{
+	"invoice.marked_uncollectible": RecoveryStatusUncollectible,
+	"invoice.paid":                 RecoveryStatusRecovered,
+	"invoice.payment_failed":       RecoveryStatusPending,
+	"invoice.payment_succeeded":    RecoveryStatusRecovered,
+	"invoice.voided":               RecoveryStatusVoided,
+}
+
+//
+// Private functions
+//
+
+// attempt retries an invoice with its default payment method and, depending
+// on the policy, each of the customer's payment methods, and takes the final
+// action if that was the last retry. It's called with the invoice locked.
+func (e *RecoveryEngine) attempt(state *RecoveryState, invoice *stripe.Invoice) error {
+	policy := e.policy(invoice)
+
+	if state.Attempts >= len(policy.RetrySchedule) {
+		return e.finalAction(state, invoice, policy)
+	}
+
+	paymentMethods := []string{""}
+	if policy.TryPaymentMethods && invoice.Customer != nil {
+		limit := policy.MaxPaymentMethods
+		if limit <= 0 {
+			limit = DefaultRecoveryMaxPaymentMethods
+		}
+		params := &stripe.PaymentMethodListParams{
+			Customer: stripe.String(invoice.Customer.ID),
+			Type:     stripe.String(string(stripe.PaymentMethodTypeCard)),
+		}
+		params.Limit = stripe.Int64(int64(limit))
+		params.Single = true
+
+		defaultPaymentMethod := invoiceDefaultPaymentMethod(invoice)
+		methods := paymentmethod.Client{B: e.config.Client.B, Key: e.config.Client.Key}
+		i := methods.List(params)
+		for i.Next() {
+			if id := i.PaymentMethod().ID; id != defaultPaymentMethod {
+				paymentMethods = append(paymentMethods, id)
+			}
+		}
+		if err := i.Err(); err != nil {
+			return err
+		}
+	}
+
+	var lastErr error
+	for _, paymentMethod := range paymentMethods {
+		operation := "recover-" + invoice.ID + "-default"
+		params := &stripe.InvoicePayParams{}
+		if paymentMethod != "" {
+			operation = "recover-" + invoice.ID + "-" + paymentMethod
+			params.PaymentMethod = stripe.String(paymentMethod)
+		}
+		params.SetIdempotencyKey(stripe.DeterministicIdempotencyKey(operation, int64(state.Attempts+1)))
+
+		paid, err := e.config.Client.Pay(invoice.ID, params)
+		if err == nil {
+			return e.finish(state, paid, RecoveryStatusRecovered)
+		}
+
+		// Only declined payments count as failed attempts. Other errors leave
+		// the attempt to be made again, with the same idempotency keys.
+		if stripeErr, ok := err.(*stripe.Error); !ok || stripeErr.Type != stripe.ErrorTypeCard {
+			return err
+		}
+		lastErr = err
+	}
+
+	state.Attempts++
+	state.LastError = lastErr.Error()
+	if state.Attempts < len(policy.RetrySchedule) {
+		state.NextAttemptAt = state.FailedAt + int64(policy.RetrySchedule[state.Attempts]/time.Second)
+	}
+	if err := e.config.Store.Put(state); err != nil {
+		return err
+	}
+	notify(policy, &RecoveryEvent{
+		Err:     lastErr,
+		Invoice: invoice,
+		State:   state,
+		Type:    RecoveryEventTypeAttemptFailed,
+	})
+
+	if state.Attempts >= len(policy.RetrySchedule) {
+		return e.finalAction(state, invoice, policy)
+	}
+	return nil
+}
+
+// finalAction takes the final action of the policy on an invoice whose
+// retries all failed.
+func (e *RecoveryEngine) finalAction(state *RecoveryState, invoice *stripe.Invoice, policy *RecoveryPolicy) error {
+	operation := "recover-" + invoice.ID + "-final"
+	status := RecoveryStatusExhausted
+	var err error
+
+	switch policy.FinalAction {
+	case RecoveryFinalActionMarkUncollectible:
+		params := &stripe.InvoiceMarkUncollectibleParams{}
+		params.SetIdempotencyKey(stripe.DeterministicIdempotencyKey(operation, 1))
+		invoice, err = e.config.Client.MarkUncollectible(invoice.ID, params)
+		status = RecoveryStatusUncollectible
+
+	case RecoveryFinalActionVoid:
+		params := &stripe.InvoiceVoidParams{}
+		params.SetIdempotencyKey(stripe.DeterministicIdempotencyKey(operation, 1))
+		invoice, err = e.config.Client.VoidInvoice(invoice.ID, params)
+		status = RecoveryStatusVoided
+	}
+	if err != nil {
+		return err
+	}
+
+	state.NextAttemptAt = 0
+	state.Status = status
+	if err := e.config.Store.Put(state); err != nil {
+		return err
+	}
+	notify(policy, &RecoveryEvent{
+		Invoice: invoice,
+		State:   state,
+		Type:    RecoveryEventTypeFinalAction,
+	})
+	return nil
+}
+
+// finish ends the recovery of an invoice with the given status.
+func (e *RecoveryEngine) finish(state *RecoveryState, invoice *stripe.Invoice, status RecoveryStatus) error {
+	state.NextAttemptAt = 0
+	state.Status = status
+	if err := e.config.Store.Put(state); err != nil {
+		return err
+	}
+
+	if status == RecoveryStatusRecovered {
+		notify(e.policy(invoice), &RecoveryEvent{
+			Invoice: invoice,
+			State:   state,
+			Type:    RecoveryEventTypeRecovered,
+		})
+	}
+	return nil
+}
+
+// invoiceDefaultPaymentMethod returns the ID of the payment method charged
+// when an invoice is paid without specifying one, or an empty string if it's
+// unknown.
+func invoiceDefaultPaymentMethod(invoice *stripe.Invoice) string {
+	if invoice.DefaultPaymentMethod != nil {
+		return invoice.DefaultPaymentMethod.ID
+	}
+	if invoice.Customer != nil && invoice.Customer.InvoiceSettings != nil &&
+		invoice.Customer.InvoiceSettings.DefaultPaymentMethod != nil {
+		return invoice.Customer.InvoiceSettings.DefaultPaymentMethod.ID
+	}
+	return ""
+}
+
+// invoiceRecoveryStatus returns the status of the recovery of an invoice
+// according to the invoice's own status.
+func invoiceRecoveryStatus(invoice *stripe.Invoice) RecoveryStatus {
+	switch invoice.Status {
+	case stripe.InvoiceStatusPaid:
+		return RecoveryStatusRecovered
+	case stripe.InvoiceStatusUncollectible:
+		return RecoveryStatusUncollectible
+	case stripe.InvoiceStatusVoid:
+		return RecoveryStatusVoided
+	}
+	return RecoveryStatusPending
+}
+
+func notify(policy *RecoveryPolicy, event *RecoveryEvent) {
+	if policy.Notify != nil {
+		policy.Notify(event)
+	}
+}
+
+func (e *RecoveryEngine) policy(invoice *stripe.Invoice) *RecoveryPolicy {
+	if e.config.PolicyFor != nil {
+		if policy := e.config.PolicyFor(invoice); policy != nil {
+			return policy
+		}
+	}
+	if e.config.Policy != nil {
+		return e.config.Policy
+	}
+	return &RecoveryPolicy{}
+}
+
+// track starts tracking the recovery of an invoice whose payment failed,
+// unless it's already tracked, and returns the state of its recovery.
+func (e *RecoveryEngine) track(invoice *stripe.Invoice) (*RecoveryState, error) {
+	var state *RecoveryState
+	err := e.withLock(invoice.ID, func() error {
+		var err error
+		state, err = e.config.Store.Get(invoice.ID)
+		if err != nil || state != nil {
+			return err
+		}
+
+		now := e.config.Clock.Now().Unix()
+		state = &RecoveryState{
+			FailedAt:      now,
+			Invoice:       invoice.ID,
+			NextAttemptAt: now,
+			Status:        RecoveryStatusPending,
+		}
+		if schedule := e.policy(invoice).RetrySchedule; len(schedule) > 0 {
+			state.NextAttemptAt = now + int64(schedule[0]/time.Second)
+		}
+		return e.config.Store.Put(state)
+	})
+	return state, err
+}
+
+// withLock runs fn with the invoice locked by the worker, or returns
+// ErrRecoveryLocked if another worker holds the lock.
+func (e *RecoveryEngine) withLock(invoice string, fn func() error) error {
+	ok, err := e.config.Store.Lock(invoice, e.config.Worker, e.config.Clock.Now(), e.config.LockTTL)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrRecoveryLocked
+	}
+
+	err = fn()
+	if unlockErr := e.config.Store.Unlock(invoice, e.config.Worker); err == nil {
+		err = unlockErr
+	}
+	return err
+}
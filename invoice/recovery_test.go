@@ -0,0 +1,318 @@
+package invoice
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	assert "github.com/stretchr/testify/require"
+	stripe "github.com/stripe/stripe-go"
+	"github.com/stripe/stripe-go/form"
+	stripetesting "github.com/stripe/stripe-go/testing"
+)
+
+// recoveryBackend is a Backend serving open invoices, which declines the
+// payments made with payment methods other than succeeding and records all
+// the calls made through it.
+type recoveryBackend struct {
+	stripetesting.Backend
+
+	mu sync.Mutex
+
+	invoices   []*stripe.Invoice
+	payErr     error
+	succeeding string
+
+	calls      []string
+	keys       []string
+	listLimits []string
+}
+
+func (b *recoveryBackend) Call(method, path, key string, params stripe.ParamsContainer, v interface{}) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.calls = append(b.calls, method+" "+path)
+	invoice := v.(*stripe.Invoice)
+	*invoice = *b.invoices[0]
+
+	switch path {
+	case "/v1/invoices/in_123/pay":
+		payParams := params.(*stripe.InvoicePayParams)
+		paymentMethod := stripe.StringValue(payParams.PaymentMethod)
+		b.keys = append(b.keys, stripe.StringValue(payParams.IdempotencyKey))
+		b.calls[len(b.calls)-1] += " " + paymentMethod
+
+		if b.payErr != nil {
+			return b.payErr
+		}
+		if b.succeeding == "" || paymentMethod != b.succeeding {
+			return &stripe.Error{Code: stripe.ErrorCodeCardDeclined, Type: stripe.ErrorTypeCard}
+		}
+		invoice.Paid = true
+		invoice.Status = stripe.InvoiceStatusPaid
+	case "/v1/invoices/in_123/mark_uncollectible":
+		invoice.Status = stripe.InvoiceStatusUncollectible
+	case "/v1/invoices/in_123/void":
+		invoice.Status = stripe.InvoiceStatusVoid
+	}
+	return nil
+}
+
+func (b *recoveryBackend) CallRaw(method, path, key string, body *form.Values, params *stripe.Params, v interface{}) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch list := v.(type) {
+	case *stripe.InvoiceList:
+		list.Data = b.invoices
+	case *stripe.PaymentMethodList:
+		b.listLimits = append(b.listLimits, body.Get("limit")...)
+		list.Data = []*stripe.PaymentMethod{{ID: "pm_1"}, {ID: "pm_2"}}
+	}
+	return nil
+}
+
+func (b *recoveryBackend) takeCalls() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	calls := b.calls
+	b.calls = nil
+	return calls
+}
+
+func newRecoveryBackend() *recoveryBackend {
+	return &recoveryBackend{invoices: []*stripe.Invoice{{
+		Attempted: true,
+		Customer:  &stripe.Customer{ID: "cus_123"},
+		ID:        "in_123",
+		Status:    stripe.InvoiceStatusOpen,
+	}}}
+}
+
+func newTestRecoveryEngine(t *testing.T, backend *recoveryBackend, policy *RecoveryPolicy) (*RecoveryEngine, *stripetesting.Clock) {
+	clock := stripetesting.NewClock(time.Unix(1500000000, 0))
+	engine, err := NewRecoveryEngine(&RecoveryEngineConfig{
+		Client: &Client{B: backend, Key: "sk_test_123"},
+		Clock:  clock,
+		Policy: policy,
+		Worker: "worker-1",
+	})
+	assert.NoError(t, err)
+	return engine, clock
+}
+
+func paymentFailedEvent() *stripe.Event {
+	return &stripe.Event{
+		Data: &stripe.EventData{Raw: []byte(`{"id":"in_123","customer":"cus_123","status":"open"}`)},
+		Type: "invoice.payment_failed",
+	}
+}
+
+func TestRecoveryEngine(t *testing.T) {
+	backend := newRecoveryBackend()
+	var events []*RecoveryEvent
+	engine, clock := newTestRecoveryEngine(t, backend, &RecoveryPolicy{
+		FinalAction:       RecoveryFinalActionVoid,
+		Notify:            func(event *RecoveryEvent) { events = append(events, event) },
+		RetrySchedule:     []time.Duration{24 * time.Hour, 72 * time.Hour},
+		TryPaymentMethods: true,
+	})
+
+	assert.NoError(t, engine.HandleEvent(paymentFailedEvent()))
+	assert.Equal(t, 0, len(backend.takeCalls()))
+
+	// Nothing is due before the first retry
+	clock.Sleep(23 * time.Hour)
+	assert.NoError(t, engine.RunDue())
+	assert.Equal(t, 0, len(backend.takeCalls()))
+
+	clock.Sleep(time.Hour)
+	assert.NoError(t, engine.RunDue())
+	assert.Equal(t, []string{
+		"GET /v1/invoices/in_123",
+		"POST /v1/invoices/in_123/pay ",
+		"POST /v1/invoices/in_123/pay pm_1",
+		"POST /v1/invoices/in_123/pay pm_2",
+	}, backend.takeCalls())
+	assert.Equal(t, []string{
+		"recover-in_123-default-1",
+		"recover-in_123-pm_1-1",
+		"recover-in_123-pm_2-1",
+	}, backend.keys)
+
+	state, err := engine.config.Store.Get("in_123")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, state.Attempts)
+	assert.Equal(t, RecoveryStatusPending, state.Status)
+	assert.Equal(t, int64(1500000000+72*3600), state.NextAttemptAt)
+	assert.Equal(t, 1, len(events))
+	assert.Equal(t, RecoveryEventTypeAttemptFailed, events[0].Type)
+
+	// The last retry fails too, and the invoice is voided
+	clock.Sleep(48 * time.Hour)
+	assert.NoError(t, engine.RunDue())
+	calls := backend.takeCalls()
+	assert.Equal(t, "POST /v1/invoices/in_123/void", calls[len(calls)-1])
+
+	state, err = engine.config.Store.Get("in_123")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, state.Attempts)
+	assert.Equal(t, RecoveryStatusVoided, state.Status)
+	assert.Equal(t, 3, len(events))
+	assert.Equal(t, RecoveryEventTypeFinalAction, events[2].Type)
+
+	// Nothing more happens once the recovery is over
+	clock.Sleep(48 * time.Hour)
+	assert.NoError(t, engine.RunDue())
+	assert.NoError(t, engine.HandleEvent(paymentFailedEvent()))
+	assert.Equal(t, 0, len(backend.takeCalls()))
+}
+
+func TestRecoveryEngine_Recovered(t *testing.T) {
+	backend := newRecoveryBackend()
+	backend.succeeding = "pm_1"
+	var events []*RecoveryEvent
+	engine, _ := newTestRecoveryEngine(t, backend, &RecoveryPolicy{
+		Notify:            func(event *RecoveryEvent) { events = append(events, event) },
+		RetrySchedule:     []time.Duration{0},
+		TryPaymentMethods: true,
+	})
+
+	// The first retry is due right away, so it's made as the event is handled
+	assert.NoError(t, engine.HandleEvent(paymentFailedEvent()))
+	calls := backend.takeCalls()
+	assert.Equal(t, "POST /v1/invoices/in_123/pay pm_1", calls[len(calls)-1])
+
+	state, err := engine.config.Store.Get("in_123")
+	assert.NoError(t, err)
+	assert.Equal(t, RecoveryStatusRecovered, state.Status)
+	assert.Equal(t, 1, len(events))
+	assert.Equal(t, RecoveryEventTypeRecovered, events[0].Type)
+	assert.True(t, events[0].Invoice.Paid)
+}
+
+func TestRecoveryEngine_DefaultPaymentMethod(t *testing.T) {
+	backend := newRecoveryBackend()
+	backend.invoices[0].Customer.InvoiceSettings = &stripe.CustomerInvoiceSettings{
+		DefaultPaymentMethod: &stripe.PaymentMethod{ID: "pm_1"},
+	}
+	engine, clock := newTestRecoveryEngine(t, backend, &RecoveryPolicy{
+		RetrySchedule:     []time.Duration{0, time.Hour},
+		TryPaymentMethods: true,
+	})
+
+	// The customer's default payment method is only charged once, and the
+	// number of payment methods tried is bounded
+	assert.NoError(t, engine.HandleEvent(paymentFailedEvent()))
+	assert.Equal(t, []string{
+		"GET /v1/invoices/in_123",
+		"POST /v1/invoices/in_123/pay ",
+		"POST /v1/invoices/in_123/pay pm_2",
+	}, backend.takeCalls())
+	assert.Equal(t, []string{strconv.Itoa(DefaultRecoveryMaxPaymentMethods)}, backend.listLimits)
+
+	// The invoice's own default payment method takes precedence
+	backend.invoices[0].DefaultPaymentMethod = &stripe.PaymentMethod{ID: "pm_2"}
+	engine.config.Policy.MaxPaymentMethods = 1
+	clock.Sleep(time.Hour)
+	_, err := engine.Process("in_123")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"GET /v1/invoices/in_123",
+		"POST /v1/invoices/in_123/pay ",
+		"POST /v1/invoices/in_123/pay pm_1",
+	}, backend.takeCalls())
+	assert.Equal(t, []string{strconv.Itoa(DefaultRecoveryMaxPaymentMethods), "1"}, backend.listLimits)
+}
+
+func TestRecoveryEngine_APIError(t *testing.T) {
+	backend := newRecoveryBackend()
+	backend.payErr = errors.New("connection reset")
+	engine, _ := newTestRecoveryEngine(t, backend, &RecoveryPolicy{
+		RetrySchedule: []time.Duration{0},
+	})
+
+	// An error other than a declined payment doesn't count as an attempt, and
+	// the retry is made again with the same idempotency key
+	assert.Error(t, engine.HandleEvent(paymentFailedEvent()))
+	backend.payErr = nil
+	assert.NoError(t, engine.RunDue())
+
+	assert.Equal(t, []string{"recover-in_123-default-1", "recover-in_123-default-1"}, backend.keys)
+	state, err := engine.config.Store.Get("in_123")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, state.Attempts)
+	assert.Equal(t, RecoveryStatusExhausted, state.Status)
+}
+
+func TestRecoveryEngine_Locked(t *testing.T) {
+	backend := newRecoveryBackend()
+	engine, clock := newTestRecoveryEngine(t, backend, &RecoveryPolicy{
+		RetrySchedule: []time.Duration{time.Hour},
+	})
+	assert.NoError(t, engine.HandleEvent(paymentFailedEvent()))
+	clock.Sleep(time.Hour)
+
+	// Another worker is attempting the invoice
+	ok, err := engine.config.Store.Lock("in_123", "worker-2", clock.Now(), time.Minute)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	_, err = engine.Process("in_123")
+	assert.Equal(t, ErrRecoveryLocked, err)
+	assert.NoError(t, engine.RunDue())
+	assert.Equal(t, 0, len(backend.takeCalls()))
+
+	// Its lock expires if it doesn't release it
+	clock.Sleep(time.Minute)
+	state, err := engine.Process("in_123")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, state.Attempts)
+}
+
+func TestRecoveryEngine_Scan(t *testing.T) {
+	backend := newRecoveryBackend()
+	backend.invoices = append(backend.invoices, &stripe.Invoice{ID: "in_456", Status: stripe.InvoiceStatusOpen})
+	engine, _ := newTestRecoveryEngine(t, backend, &RecoveryPolicy{
+		FinalAction: RecoveryFinalActionMarkUncollectible,
+	})
+
+	// Without retries, the final action is taken as soon as the invoice is
+	// found, and invoices that weren't attempted yet are left alone
+	assert.NoError(t, engine.Scan())
+	assert.Equal(t, []string{
+		"GET /v1/invoices/in_123",
+		"POST /v1/invoices/in_123/mark_uncollectible",
+	}, backend.takeCalls())
+
+	state, err := engine.config.Store.Get("in_123")
+	assert.NoError(t, err)
+	assert.Equal(t, RecoveryStatusUncollectible, state.Status)
+
+	state, err = engine.config.Store.Get("in_456")
+	assert.NoError(t, err)
+	assert.Nil(t, state)
+}
+
+func TestRecoveryEngine_PaidElsewhere(t *testing.T) {
+	backend := newRecoveryBackend()
+	engine, clock := newTestRecoveryEngine(t, backend, &RecoveryPolicy{
+		RetrySchedule: []time.Duration{time.Hour},
+	})
+	assert.NoError(t, engine.HandleEvent(paymentFailedEvent()))
+
+	assert.NoError(t, engine.HandleEvent(&stripe.Event{
+		Data: &stripe.EventData{Raw: []byte(`{"id":"in_123","status":"paid"}`)},
+		Type: "invoice.paid",
+	}))
+	state, err := engine.config.Store.Get("in_123")
+	assert.NoError(t, err)
+	assert.Equal(t, RecoveryStatusRecovered, state.Status)
+
+	clock.Sleep(time.Hour)
+	assert.NoError(t, engine.RunDue())
+	assert.Equal(t, 0, len(backend.takeCalls()))
+}
@@ -59,6 +59,9 @@ type InvoiceItem struct {
 	TaxRates          []*TaxRate        `json:"tax_rates"`
 	UnitAmount        int64             `json:"unit_amount"`
 	UnitAmountDecimal float64           `json:"unit_amount_decimal,string"`
+
+	Extra    map[string]json.RawMessage `json:"-"`
+	expanded bool
 }
 
 // InvoiceItemList is a list of invoice items as retrieved from a list endpoint.
@@ -83,5 +86,6 @@ func (i *InvoiceItem) UnmarshalJSON(data []byte) error {
 	}
 
 	*i = InvoiceItem(v)
+	i.expanded = true
 	return nil
 }
@@ -174,6 +174,9 @@ type IssuingAuthorization struct {
 	Transactions             []*IssuingTransaction                   `json:"transactions"`
 	VerificationData         *IssuingAuthorizationVerificationData   `json:"verification_data"`
 	WalletProvider           IssuingAuthorizationWalletProviderType  `json:"wallet_provider"`
+
+	Extra    map[string]json.RawMessage `json:"-"`
+	expanded bool
 }
 
 // IssuingMerchantData is the resource representing merchant data on Issuing APIs.
@@ -210,5 +213,6 @@ func (i *IssuingAuthorization) UnmarshalJSON(data []byte) error {
 	}
 
 	*i = IssuingAuthorization(v)
+	i.expanded = true
 	return nil
 }
@@ -159,6 +159,8 @@ type IssuingCardDetails struct {
 	ExpYear  *string      `form:"exp_year" json:"exp_year"`
 	Number   string       `json:"number"`
 	Object   string       `json:"object"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // IssuingAuthorizationControlsSpendingLimits is the resource representing spending limits
@@ -224,6 +226,9 @@ type IssuingCard struct {
 	Shipping              *IssuingCardShipping              `json:"shipping"`
 	Status                IssuingCardStatus                 `json:"status"`
 	Type                  IssuingCardType                   `json:"type"`
+
+	Extra    map[string]json.RawMessage `json:"-"`
+	expanded bool
 }
 
 // IssuingCardList is a list of issuing cards as retrieved from a list endpoint.
@@ -248,5 +253,6 @@ func (i *IssuingCard) UnmarshalJSON(data []byte) error {
 	}
 
 	*i = IssuingCard(v)
+	i.expanded = true
 	return nil
 }
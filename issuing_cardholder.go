@@ -166,6 +166,9 @@ type IssuingCardholder struct {
 	Requirements          *IssuingCardholderRequirements    `json:"requirements"`
 	Status                IssuingCardholderStatus           `json:"status"`
 	Type                  IssuingCardholderType             `json:"type"`
+
+	Extra    map[string]json.RawMessage `json:"-"`
+	expanded bool
 }
 
 // IssuingCardholderList is a list of issuing cardholders as retrieved from a list endpoint.
@@ -190,5 +193,6 @@ func (i *IssuingCardholder) UnmarshalJSON(data []byte) error {
 	}
 
 	*i = IssuingCardholder(v)
+	i.expanded = true
 	return nil
 }
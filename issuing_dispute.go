@@ -93,6 +93,9 @@ type IssuingDispute struct {
 	Reason      IssuingDisputeReason    `json:"reason"`
 	Status      IssuingDisputeStatus    `json:"status"`
 	Transaction *IssuingTransaction     `json:"transaction"`
+
+	Extra    map[string]json.RawMessage `json:"-"`
+	expanded bool
 }
 
 // IssuingDisputeList is a list of issuing disputes as retrieved from a list endpoint.
@@ -117,5 +120,6 @@ func (i *IssuingDispute) UnmarshalJSON(data []byte) error {
 	}
 
 	*i = IssuingDispute(v)
+	i.expanded = true
 	return nil
 }
@@ -46,6 +46,9 @@ type IssuingTransaction struct {
 	Metadata           map[string]string      `json:"metadata"`
 	Object             string                 `json:"object"`
 	Type               IssuingTransactionType `json:"type"`
+
+	Extra    map[string]json.RawMessage `json:"-"`
+	expanded bool
 }
 
 // IssuingTransactionList is a list of issuing transactions as retrieved from a list endpoint.
@@ -70,5 +73,6 @@ func (i *IssuingTransaction) UnmarshalJSON(data []byte) error {
 	}
 
 	*i = IssuingTransaction(v)
+	i.expanded = true
 	return nil
 }
@@ -0,0 +1,282 @@
+package stripe
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/stripe/stripe-go/form"
+)
+
+//
+// Public constants
+//
+
+// DefaultKeyFileCheckInterval is how often a FileKeyProvider checks its file
+// for changes by default.
+const DefaultKeyFileCheckInterval time.Duration = 10 * time.Second
+
+//
+// Public types
+//
+
+// KeyProvider provides the API key to use for a request. It's resolved on
+// every request with the request's context (see Params.Context), which makes
+// it possible to use a different key per tenant or to rotate keys without
+// recreating clients.
+//
+// It's plugged in with KeyProviderBackend, usually through
+// client.API.InitWithKeyProvider or `WithKeyProvider` on a resource client.
+type KeyProvider interface {
+	// Key returns the API key to use for a request made with the given
+	// context.
+	Key(ctx context.Context) (string, error)
+}
+
+// KeyRefresher is implemented by KeyProviders that can reload their key on
+// demand. KeyProviderBackend calls RefreshKey when a request fails because
+// its key expired, and retries the request once if that produced a new key.
+type KeyRefresher interface {
+	// RefreshKey reloads the provider's key.
+	RefreshKey(ctx context.Context) error
+}
+
+// EnvKeyProvider is a KeyProvider that reads the key from an environment
+// variable every time it's needed.
+type EnvKeyProvider struct {
+	// Name is the name of the environment variable holding the key.
+	Name string
+}
+
+// Key returns the value of the environment variable.
+func (p *EnvKeyProvider) Key(ctx context.Context) (string, error) {
+	key := strings.TrimSpace(os.Getenv(p.Name))
+	if key == "" {
+		return "", fmt.Errorf("environment variable %s holding the Stripe key is not set", p.Name)
+	}
+	return key, nil
+}
+
+// FileKeyProvider is a KeyProvider that reads the key from a file, like one
+// mounted from a secret store, and picks up changes to it.
+type FileKeyProvider struct {
+	// CheckInterval is the minimum time between checks of the file for
+	// changes.
+	//
+	// Defaults to DefaultKeyFileCheckInterval.
+	CheckInterval time.Duration
+
+	// Path is the path of the file holding the key. Leading and trailing
+	// whitespace in the file is ignored.
+	Path string
+
+	key       string
+	lastCheck time.Time
+	modTime   time.Time
+	mu        sync.Mutex
+	size      int64
+}
+
+// NewFileKeyProvider returns a FileKeyProvider for the file at the given path.
+// It fails if the key can't be read initially.
+func NewFileKeyProvider(path string) (*FileKeyProvider, error) {
+	p := &FileKeyProvider{Path: path}
+	if err := p.RefreshKey(context.Background()); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Key returns the key in the file, reloading it if the file changed since
+// the last check.
+func (p *FileKeyProvider) Key(ctx context.Context) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	interval := p.CheckInterval
+	if interval == 0 {
+		interval = DefaultKeyFileCheckInterval
+	}
+
+	if p.key != "" && time.Since(p.lastCheck) < interval {
+		return p.key, nil
+	}
+
+	info, err := os.Stat(p.Path)
+	if err != nil {
+		return "", err
+	}
+	p.lastCheck = time.Now()
+
+	if p.key != "" && info.ModTime().Equal(p.modTime) && info.Size() == p.size {
+		return p.key, nil
+	}
+
+	if err := p.load(); err != nil {
+		return "", err
+	}
+	return p.key, nil
+}
+
+// RefreshKey reloads the key from the file unconditionally.
+func (p *FileKeyProvider) RefreshKey(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.load()
+}
+
+// load reads the key file. p.mu must be held.
+func (p *FileKeyProvider) load() error {
+	info, err := os.Stat(p.Path)
+	if err != nil {
+		return err
+	}
+
+	data, err := ioutil.ReadFile(p.Path)
+	if err != nil {
+		return err
+	}
+
+	key := string(bytes.TrimSpace(data))
+	if key == "" {
+		return fmt.Errorf("file %s holding the Stripe key is empty", p.Path)
+	}
+
+	p.key = key
+	p.lastCheck = time.Now()
+	p.modTime = info.ModTime()
+	p.size = info.Size()
+	return nil
+}
+
+// KeyProviderBackend is a Backend that ignores the key given to it by
+// clients and resolves one from a KeyProvider for every request instead.
+type KeyProviderBackend struct {
+	// Backend is the backend that requests are forwarded to.
+	Backend Backend
+
+	// KeyProvider provides the key for each request.
+	KeyProvider KeyProvider
+}
+
+// NewKeyProviderBackend returns a Backend that makes every request through
+// the given backend with a key from the given provider.
+func NewKeyProviderBackend(backend Backend, provider KeyProvider) *KeyProviderBackend {
+	return &KeyProviderBackend{Backend: backend, KeyProvider: provider}
+}
+
+// Call is the Backend.Call implementation for KeyProviderBackend.
+func (b *KeyProviderBackend) Call(method, path, _ string, params ParamsContainer, v interface{}) error {
+	var commonParams *Params
+
+	// See the comment on BackendImplementation.Call for why reflect is
+	// needed to check for nil.
+	if params != nil {
+		reflectValue := reflect.ValueOf(params)
+
+		if reflectValue.Kind() == reflect.Ptr && !reflectValue.IsNil() {
+			commonParams = params.GetParams()
+		}
+	}
+
+	return b.withKey(commonParams, func(key string) error {
+		return b.Backend.Call(method, path, key, params, v)
+	})
+}
+
+// CallMultipart is the Backend.CallMultipart implementation for
+// KeyProviderBackend.
+func (b *KeyProviderBackend) CallMultipart(method, path, _, boundary string, body *bytes.Buffer, params *Params, v interface{}) error {
+	return b.withKey(params, func(key string) error {
+		return b.Backend.CallMultipart(method, path, key, boundary, body, params, v)
+	})
+}
+
+// CallRaw is the Backend.CallRaw implementation for KeyProviderBackend.
+func (b *KeyProviderBackend) CallRaw(method, path, _ string, body *form.Values, params *Params, v interface{}) error {
+	return b.withKey(params, func(key string) error {
+		return b.Backend.CallRaw(method, path, key, body, params, v)
+	})
+}
+
+// SetMaxNetworkRetries sets max number of retries on failed requests of the
+// underlying backend.
+func (b *KeyProviderBackend) SetMaxNetworkRetries(maxNetworkRetries int) {
+	b.Backend.SetMaxNetworkRetries(maxNetworkRetries)
+}
+
+// Unwrap returns the backend that requests are forwarded to.
+func (b *KeyProviderBackend) Unwrap() Backend {
+	return b.Backend
+}
+
+// withKey resolves a key and invokes call with it. If the call fails because
+// the key expired and the provider can refresh its key, it's retried once
+// with the refreshed key.
+func (b *KeyProviderBackend) withKey(params *Params, call func(key string) error) error {
+	ctx := context.Background()
+	if params != nil && params.Context != nil {
+		ctx = params.Context
+	}
+
+	key, err := b.KeyProvider.Key(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = call(key)
+	if err == nil || !isExpiredKeyError(err) {
+		return err
+	}
+
+	refresher, ok := b.KeyProvider.(KeyRefresher)
+	if !ok {
+		return err
+	}
+
+	if refreshErr := refresher.RefreshKey(ctx); refreshErr != nil {
+		return err
+	}
+
+	newKey, keyErr := b.KeyProvider.Key(ctx)
+	if keyErr != nil || newKey == key {
+		return err
+	}
+
+	return call(newKey)
+}
+
+// StaticKeyProvider is a KeyProvider that always returns the same key.
+type StaticKeyProvider string
+
+// Key returns the key.
+func (p StaticKeyProvider) Key(ctx context.Context) (string, error) {
+	return string(p), nil
+}
+
+//
+// Private functions
+//
+
+// isExpiredKeyError returns true if err is an authentication error reporting
+// that the key used for the request has expired.
+func isExpiredKeyError(err error) bool {
+	stripeErr, ok := err.(*Error)
+	if !ok {
+		return false
+	}
+
+	if _, ok := stripeErr.Err.(*AuthenticationError); !ok {
+		return false
+	}
+
+	return stripeErr.Code == ErrorCodeAPIKeyExpired ||
+		stripeErr.Code == ErrorCodePlatformAPIKeyExpired
+}
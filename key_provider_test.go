@@ -0,0 +1,161 @@
+package stripe
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	assert "github.com/stretchr/testify/require"
+)
+
+// rotatingKeyProvider is a KeyProvider whose key changes when it's refreshed.
+type rotatingKeyProvider struct {
+	keys []string
+}
+
+func (p *rotatingKeyProvider) Key(ctx context.Context) (string, error) {
+	return p.keys[0], nil
+}
+
+func (p *rotatingKeyProvider) RefreshKey(ctx context.Context) error {
+	if len(p.keys) > 1 {
+		p.keys = p.keys[1:]
+	}
+	return nil
+}
+
+// tenantKeyProvider is a KeyProvider that picks a key based on the context.
+type tenantKeyProvider map[string]string
+
+type tenantKey struct{}
+
+func (p tenantKeyProvider) Key(ctx context.Context) (string, error) {
+	tenant, _ := ctx.Value(tenantKey{}).(string)
+	return p[tenant], nil
+}
+
+// newKeyTestServer returns a server that rejects any key other than validKey
+// as expired and records the keys it sees.
+func newKeyTestServer(validKey string, keys *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get("Authorization")[len("Bearer "):]
+		*keys = append(*keys, key)
+
+		if key != validKey {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error":{"type":"authentication_error","code":"api_key_expired","message":"Expired API Key provided"}}`))
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+}
+
+func TestEnvKeyProvider(t *testing.T) {
+	provider := &EnvKeyProvider{Name: "STRIPE_GO_TEST_KEY"}
+
+	os.Unsetenv("STRIPE_GO_TEST_KEY")
+	_, err := provider.Key(context.Background())
+	assert.Error(t, err)
+
+	os.Setenv("STRIPE_GO_TEST_KEY", "sk_test_123\n")
+	defer os.Unsetenv("STRIPE_GO_TEST_KEY")
+
+	key, err := provider.Key(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "sk_test_123", key)
+}
+
+func TestFileKeyProvider(t *testing.T) {
+	dir, err := ioutil.TempDir("", "stripe-go")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "key")
+
+	_, err = NewFileKeyProvider(path)
+	assert.Error(t, err)
+
+	assert.NoError(t, ioutil.WriteFile(path, []byte("sk_test_123\n"), 0600))
+
+	provider, err := NewFileKeyProvider(path)
+	assert.NoError(t, err)
+
+	key, err := provider.Key(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "sk_test_123", key)
+
+	// Changes aren't picked up until the check interval elapses
+	assert.NoError(t, ioutil.WriteFile(path, []byte("sk_test_456789\n"), 0600))
+	key, err = provider.Key(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "sk_test_123", key)
+
+	provider.lastCheck = time.Now().Add(-DefaultKeyFileCheckInterval)
+	key, err = provider.Key(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "sk_test_456789", key)
+}
+
+func TestKeyProviderBackend(t *testing.T) {
+	var keys []string
+	testServer := newKeyTestServer("sk_test_b", &keys)
+	defer testServer.Close()
+
+	backend := NewKeyProviderBackend(GetBackendWithConfig(
+		APIBackend,
+		&BackendConfig{URL: testServer.URL},
+	), tenantKeyProvider{"a": "sk_test_a", "b": "sk_test_b"})
+
+	ctx := context.WithValue(context.Background(), tenantKey{}, "b")
+	err := backend.Call(http.MethodGet, "/v1/charges", "ignored", &ChargeParams{Params: Params{Context: ctx}}, nil)
+	assert.NoError(t, err)
+
+	ctx = context.WithValue(context.Background(), tenantKey{}, "a")
+	err = backend.CallRaw(http.MethodGet, "/v1/charges", "ignored", nil, &Params{Context: ctx}, nil)
+	assert.Error(t, err)
+
+	// The provider can't refresh, so there was no retry
+	assert.Equal(t, []string{"sk_test_b", "sk_test_a"}, keys)
+}
+
+func TestKeyProviderBackend_RetriesWithRefreshedKey(t *testing.T) {
+	var keys []string
+	testServer := newKeyTestServer("sk_test_new", &keys)
+	defer testServer.Close()
+
+	backend := NewKeyProviderBackend(GetBackendWithConfig(
+		APIBackend,
+		&BackendConfig{URL: testServer.URL},
+	), &rotatingKeyProvider{keys: []string{"sk_test_old", "sk_test_new"}})
+
+	err := backend.Call(http.MethodPost, "/v1/charges", "ignored", &ChargeParams{}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"sk_test_old", "sk_test_new"}, keys)
+}
+
+func TestKeyProviderBackend_RetriesOnlyOnce(t *testing.T) {
+	var keys []string
+	testServer := newKeyTestServer("sk_test_valid", &keys)
+	defer testServer.Close()
+
+	backend := NewKeyProviderBackend(GetBackendWithConfig(
+		APIBackend,
+		&BackendConfig{URL: testServer.URL},
+	), &rotatingKeyProvider{keys: []string{"sk_test_old", "sk_test_new", "sk_test_valid"}})
+
+	err := backend.Call(http.MethodPost, "/v1/charges", "ignored", &ChargeParams{}, nil)
+	assert.Error(t, err)
+	assert.Equal(t, ErrorCodeAPIKeyExpired, err.(*Error).Code)
+	assert.Equal(t, []string{"sk_test_old", "sk_test_new"}, keys)
+}
+
+func TestStaticKeyProvider(t *testing.T) {
+	key, err := StaticKeyProvider("sk_test_123").Key(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "sk_test_123", key)
+}
@@ -0,0 +1,236 @@
+package stripe
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+//
+// Public variables
+//
+
+// DefaultLivemodeGuard is the guard that backends created by default use, and
+// which ConstructEvent in the webhook package checks events against. It's
+// nil by default, which disables all checks.
+//
+// It will be overridden if a backend is created with GetBackendWithConfig
+// with a custom LivemodeGuard set.
+var DefaultLivemodeGuard *LivemodeGuard
+
+//
+// Public types
+//
+
+// KeyType is the type of an API key.
+type KeyType string
+
+// List of values that KeyType can take.
+const (
+	KeyTypePublishable KeyType = "publishable"
+	KeyTypeRestricted  KeyType = "restricted"
+	KeyTypeSecret      KeyType = "secret"
+)
+
+// KeyInfo describes an API key based on its prefix.
+type KeyInfo struct {
+	Livemode bool
+	Type     KeyType
+}
+
+// LivemodeGuard protects against accidentally using live keys or data in a
+// non-production environment (or test keys in production) by refusing
+// requests made with a key whose mode doesn't match the environment's.
+//
+// A guard is configured on a backend with BackendConfig.LivemodeGuard, or
+// globally with DefaultLivemodeGuard. Besides checking keys, it checks the
+// `livemode` flag of objects decoded from responses and of webhook events
+// constructed with the webhook package, and refuses publishable keys on
+// endpoints that require a secret key before a request is ever sent.
+//
+// All of its methods are safe to call on a nil guard, which allows
+// everything.
+type LivemodeGuard struct {
+	// Livemode declares whether the environment is a production environment
+	// that's expected to use live keys. When false, only test mode keys are
+	// allowed.
+	Livemode bool
+}
+
+// CheckKey returns an error if the given key shouldn't be used in the
+// environment or on the given endpoint. Keys with an unrecognized prefix are
+// always refused.
+func (g *LivemodeGuard) CheckKey(method, path, key string) error {
+	if g == nil {
+		return nil
+	}
+
+	info, ok := ParseKey(key)
+	if !ok {
+		return NewInvalidRequestError(ErrorCodeLivemodeMismatch, "",
+			"Refusing to use an API key with an unrecognized prefix")
+	}
+
+	if info.Livemode != g.Livemode {
+		return NewInvalidRequestError(ErrorCodeLivemodeMismatch, "",
+			fmt.Sprintf("Refusing to use a %s key in a %s environment",
+				modeName(info.Livemode), modeName(g.Livemode)))
+	}
+
+	if info.Type == KeyTypePublishable && !isPublishableEndpoint(method, path) {
+		return NewInvalidRequestError(ErrorCodeSecretKeyRequired, "",
+			fmt.Sprintf("Refusing to use a publishable key on %s %s, which requires a secret key",
+				method, path))
+	}
+
+	return nil
+}
+
+// CheckLivemode returns an error if an object's livemode flag doesn't match
+// the environment.
+func (g *LivemodeGuard) CheckLivemode(livemode bool) error {
+	if g == nil || livemode == g.Livemode {
+		return nil
+	}
+
+	return NewInvalidRequestError(ErrorCodeLivemodeMismatch, "",
+		fmt.Sprintf("Refusing a %s object in a %s environment",
+			modeName(livemode), modeName(g.Livemode)))
+}
+
+// CheckResponse returns an error if an object decoded from a response, or
+// any of the objects in a decoded list, has a livemode flag that doesn't
+// match the environment. Objects without a livemode flag are ignored.
+func (g *LivemodeGuard) CheckResponse(v interface{}) error {
+	if g == nil || v == nil {
+		return nil
+	}
+
+	val := reflect.ValueOf(v)
+	for val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return nil
+		}
+		val = val.Elem()
+	}
+
+	if val.Kind() != reflect.Struct {
+		return nil
+	}
+
+	if livemode := val.FieldByName("Livemode"); livemode.IsValid() && livemode.Kind() == reflect.Bool {
+		if err := g.CheckLivemode(livemode.Bool()); err != nil {
+			return err
+		}
+	}
+
+	if data := val.FieldByName("Data"); data.IsValid() && data.Kind() == reflect.Slice {
+		for i := 0; i < data.Len(); i++ {
+			if err := g.CheckResponse(data.Index(i).Interface()); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+//
+// Public functions
+//
+
+// ParseKey classifies an API key based on its prefix. The second return value
+// is false if the prefix isn't one of a secret (`sk_`), restricted (`rk_`) or
+// publishable (`pk_`) key followed by `test_` or `live_`.
+func ParseKey(key string) (KeyInfo, bool) {
+	parts := strings.SplitN(strings.TrimSpace(key), "_", 3)
+	if len(parts) != 3 || parts[2] == "" {
+		return KeyInfo{}, false
+	}
+
+	info := KeyInfo{}
+
+	switch parts[0] {
+	case "pk":
+		info.Type = KeyTypePublishable
+	case "rk":
+		info.Type = KeyTypeRestricted
+	case "sk":
+		info.Type = KeyTypeSecret
+	default:
+		return KeyInfo{}, false
+	}
+
+	switch parts[1] {
+	case "live":
+		info.Livemode = true
+	case "test":
+		info.Livemode = false
+	default:
+		return KeyInfo{}, false
+	}
+
+	return info, true
+}
+
+//
+// Private variables
+//
+
+// publishableEndpoints are the endpoints which may be called with a
+// publishable key. `*` matches any single path segment.
+var publishableEndpoints = []struct {
+	method string
+	path   string
+}{
+	{http.MethodPost, "/v1/payment_intents/*/confirm"},
+	{http.MethodGet, "/v1/payment_intents/*"},
+	{http.MethodPost, "/v1/payment_methods"},
+	{http.MethodPost, "/v1/setup_intents/*/confirm"},
+	{http.MethodGet, "/v1/setup_intents/*"},
+	{http.MethodPost, "/v1/sources"},
+	{http.MethodGet, "/v1/sources/*"},
+	{http.MethodPost, "/v1/tokens"},
+}
+
+//
+// Private functions
+//
+
+func isPublishableEndpoint(method, path string) bool {
+	for _, endpoint := range publishableEndpoints {
+		if endpoint.method == method && matchPath(endpoint.path, path) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchPath returns true if path, which may include a query string, matches
+// the given pattern, in which `*` matches any single path segment.
+func matchPath(pattern, path string) bool {
+	if i := strings.Index(path, "?"); i >= 0 {
+		path = path[:i]
+	}
+
+	segments := strings.Split(path, "/")
+	patternSegments := strings.Split(pattern, "/")
+	if len(patternSegments) != len(segments) {
+		return false
+	}
+
+	for i, segment := range patternSegments {
+		if segment != "*" && segment != segments[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func modeName(livemode bool) string {
+	if livemode {
+		return "live mode"
+	}
+	return "test mode"
+}
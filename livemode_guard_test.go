@@ -0,0 +1,108 @@
+package stripe
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestParseKey(t *testing.T) {
+	info, ok := ParseKey("sk_live_123")
+	assert.True(t, ok)
+	assert.Equal(t, KeyInfo{Livemode: true, Type: KeyTypeSecret}, info)
+
+	info, ok = ParseKey("rk_test_123")
+	assert.True(t, ok)
+	assert.Equal(t, KeyInfo{Livemode: false, Type: KeyTypeRestricted}, info)
+
+	info, ok = ParseKey("pk_test_123")
+	assert.True(t, ok)
+	assert.Equal(t, KeyInfo{Livemode: false, Type: KeyTypePublishable}, info)
+
+	_, ok = ParseKey("sk_prod_123")
+	assert.False(t, ok)
+
+	_, ok = ParseKey("xk_test_123")
+	assert.False(t, ok)
+
+	_, ok = ParseKey("sk_test_")
+	assert.False(t, ok)
+}
+
+func TestLivemodeGuard_CheckKey(t *testing.T) {
+	guard := &LivemodeGuard{Livemode: false}
+
+	assert.NoError(t, guard.CheckKey(http.MethodPost, "/v1/charges", "sk_test_123"))
+
+	err := guard.CheckKey(http.MethodPost, "/v1/charges", "sk_live_123")
+	assert.Error(t, err)
+	assert.Equal(t, ErrorCodeLivemodeMismatch, err.(*Error).Code)
+	assert.IsType(t, &InvalidRequestError{}, err.(*Error).Err)
+
+	err = guard.CheckKey(http.MethodPost, "/v1/charges", "123")
+	assert.Error(t, err)
+	assert.Equal(t, ErrorCodeLivemodeMismatch, err.(*Error).Code)
+
+	// Publishable keys are only allowed on some endpoints
+	assert.NoError(t, guard.CheckKey(http.MethodPost, "/v1/tokens", "pk_test_123"))
+	assert.NoError(t, guard.CheckKey(http.MethodPost, "/v1/payment_intents/pi_123/confirm", "pk_test_123"))
+
+	err = guard.CheckKey(http.MethodPost, "/v1/charges", "pk_test_123")
+	assert.Error(t, err)
+	assert.Equal(t, ErrorCodeSecretKeyRequired, err.(*Error).Code)
+
+	err = guard.CheckKey(http.MethodGet, "/v1/tokens", "pk_test_123")
+	assert.Error(t, err)
+	assert.Equal(t, ErrorCodeSecretKeyRequired, err.(*Error).Code)
+
+	// A nil guard allows everything
+	var nilGuard *LivemodeGuard
+	assert.NoError(t, nilGuard.CheckKey(http.MethodPost, "/v1/charges", "sk_live_123"))
+}
+
+func TestLivemodeGuard_CheckResponse(t *testing.T) {
+	guard := &LivemodeGuard{Livemode: true}
+
+	assert.NoError(t, guard.CheckResponse(&Charge{Livemode: true}))
+	assert.Error(t, guard.CheckResponse(&Charge{Livemode: false}))
+
+	list := &ChargeList{Data: []*Charge{{Livemode: true}, {Livemode: false}}}
+	assert.Error(t, guard.CheckResponse(list))
+
+	list = &ChargeList{Data: []*Charge{{Livemode: true}, nil}}
+	assert.NoError(t, guard.CheckResponse(list))
+
+	assert.NoError(t, guard.CheckResponse(&map[string]interface{}{}))
+}
+
+func TestLivemodeGuard_Backend(t *testing.T) {
+	requests := 0
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"id":"ch_123","object":"charge","livemode":true}`))
+	}))
+	defer testServer.Close()
+
+	backend := GetBackendWithConfig(
+		APIBackend,
+		&BackendConfig{
+			LivemodeGuard: &LivemodeGuard{Livemode: false},
+			URL:           testServer.URL,
+		},
+	)
+
+	// The request is refused before it's sent
+	var charge Charge
+	err := backend.Call(http.MethodGet, "/v1/charges/ch_123", "sk_live_123", nil, &charge)
+	assert.Error(t, err)
+	assert.Equal(t, ErrorCodeLivemodeMismatch, err.(*Error).Code)
+	assert.Equal(t, 0, requests)
+
+	// The request is sent, but the object in the response is refused
+	err = backend.Call(http.MethodGet, "/v1/charges/ch_123", "sk_test_123", nil, &charge)
+	assert.Error(t, err)
+	assert.Equal(t, ErrorCodeLivemodeMismatch, err.(*Error).Code)
+	assert.Equal(t, 1, requests)
+}
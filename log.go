@@ -1,6 +1,7 @@
 package stripe
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"log"
@@ -27,6 +28,44 @@ const (
 	// severe.
 	LevelDebug Level = 4
 
+	// LogAttrAccount is the key of the attribute holding the connected
+	// account a request was made for.
+	LogAttrAccount = "account"
+
+	// LogAttrBody is the key of the attribute holding the body of a response,
+	// with sensitive data redacted.
+	LogAttrBody = "body"
+
+	// LogAttrDuration is the key of the attribute holding the duration of a
+	// request.
+	LogAttrDuration = "duration"
+
+	// LogAttrError is the key of the attribute holding an error.
+	LogAttrError = "error"
+
+	// LogAttrMethod is the key of the attribute holding the HTTP method of a
+	// request.
+	LogAttrMethod = "method"
+
+	// LogAttrPath is the key of the attribute holding the path of a request.
+	LogAttrPath = "path"
+
+	// LogAttrRequestID is the key of the attribute holding the ID that Stripe
+	// assigned to a request.
+	LogAttrRequestID = "request_id"
+
+	// LogAttrRetries is the key of the attribute holding the number of times
+	// a request was retried so far.
+	LogAttrRetries = "retries"
+
+	// LogAttrSleep is the key of the attribute holding how long the backend
+	// waits before retrying a request.
+	LogAttrSleep = "sleep"
+
+	// LogAttrStatus is the key of the attribute holding the HTTP status of a
+	// response.
+	LogAttrStatus = "status"
+
 	// Older deprecated levels for Printfer-style logging.
 	printferLevelError = 1
 	printferLevelInfo  = 2
@@ -162,11 +201,33 @@ type LeveledLoggerInterface interface {
 	Warnf(format string, v ...interface{})
 }
 
+// LogAttr is an attribute of a structured log record, like the status of a
+// response. See the LogAttr* constants for the keys used by backends.
+type LogAttr struct {
+	Key   string
+	Value interface{}
+}
+
 // Printfer is an interface to be implemented by Logger.
 type Printfer interface {
 	Printf(format string, v ...interface{})
 }
 
+// StructuredLogger is a logger which receives records with attributes
+// instead of formatted messages. When a backend is configured with one, it's
+// used instead of its LeveledLogger to log requests.
+//
+// On Go 1.21 and later, SlogLogger implements it on top of a *slog.Logger.
+type StructuredLogger interface {
+	// Enabled reports whether records at the given level are logged, so that
+	// expensive attributes like response bodies are only prepared when
+	// they're needed.
+	Enabled(ctx context.Context, level Level) bool
+
+	// Log logs a record with the given attributes.
+	Log(ctx context.Context, level Level, msg string, attrs ...LogAttr)
+}
+
 //
 // Private types
 //
@@ -174,6 +235,77 @@ type Printfer interface {
 // Level represents a deprecated logging level.
 type printferLevel uint32
 
+// requestLog logs the progress of a request, either as formatted messages to
+// a leveled logger, or as records with attributes to a structured logger.
+type requestLog struct {
+	// attrs are attributes added to every record, like the request's method
+	// and path.
+	attrs []LogAttr
+
+	ctx        context.Context
+	leveled    LeveledLoggerInterface
+	redactor   *LogRedactor
+	structured StructuredLogger
+}
+
+// enabled reports whether messages at the given level are logged, which is
+// assumed for leveled loggers of unknown types.
+func (l *requestLog) enabled(level Level) bool {
+	if l.structured != nil {
+		return l.structured.Enabled(l.ctx, level)
+	}
+
+	switch logger := l.leveled.(type) {
+	case *LeveledLogger:
+		return logger.Level >= level
+	case *leveledLoggerPrintferShim:
+		switch level {
+		case LevelDebug:
+			return logger.level >= printferLevelDebug
+		case LevelInfo:
+			return logger.level >= printferLevelInfo
+		default:
+			return logger.level >= printferLevelError
+		}
+	}
+	return true
+}
+
+// log logs a message, which is formatted from format and v for leveled
+// loggers, while structured loggers get msg along with the attributes.
+func (l *requestLog) log(level Level, msg string, attrs []LogAttr, format string, v ...interface{}) {
+	if l.structured != nil {
+		if l.structured.Enabled(l.ctx, level) {
+			allAttrs := append(l.attrs[:len(l.attrs):len(l.attrs)], attrs...)
+			l.structured.Log(l.ctx, level, msg, allAttrs...)
+		}
+		return
+	}
+
+	switch level {
+	case LevelDebug:
+		l.leveled.Debugf(format, v...)
+	case LevelInfo:
+		l.leveled.Infof(format, v...)
+	case LevelWarn:
+		l.leveled.Warnf(format, v...)
+	default:
+		l.leveled.Errorf(format, v...)
+	}
+}
+
+// logBody logs the body of a response at the debug level, with its sensitive
+// data redacted.
+func (l *requestLog) logBody(attrs []LogAttr, body []byte) {
+	if !l.enabled(LevelDebug) {
+		return
+	}
+
+	redacted := string(l.redactor.Redact(body))
+	l.log(LevelDebug, "Response", append(attrs, LogAttr{LogAttrBody, redacted}),
+		"Response: %s\n", redacted)
+}
+
 type leveledLoggerPrintferShim struct {
 	level  printferLevel
 	logger Printfer
@@ -0,0 +1,200 @@
+package stripe
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"strings"
+)
+
+//
+// Public constants
+//
+
+// RedactedValue replaces the values masked by a LogRedactor.
+const RedactedValue = "[REDACTED]"
+
+//
+// Public variables
+//
+
+// DefaultRedactedFields are the fields that a LogRedactor masks in logged
+// bodies by default: personal information like emails, names and addresses,
+// card and bank account data, and secrets.
+//
+// A name without a dot matches a field with that name anywhere, while a name
+// with dots matches fields at the end of that path, like
+// `billing_details.name`.
+//
+// Fields named `number` are only masked under `card` or when they hold a card
+// number, like in Issuing card details, so that the numbers of invoices and
+// credit notes are still logged.
+var DefaultRedactedFields = []string{
+	"account_holder_name",
+	"account_number",
+	"address",
+	"billing_details.name",
+	"card.number",
+	"client_secret",
+	"customer_address",
+	"customer_email",
+	"customer_name",
+	"customer_phone",
+	"customer_shipping",
+	"cvc",
+	"dob",
+	"dynamic_last4",
+	"email",
+	"exp_month",
+	"exp_year",
+	"fingerprint",
+	"first_name",
+	"id_number",
+	"last4",
+	"last_name",
+	"maiden_name",
+	"owner.name",
+	"personal_id_number",
+	"phone",
+	"receipt_email",
+	"routing_number",
+	"secret",
+	"shipping.name",
+	"ssn_last_4",
+	"verified_address",
+	"verified_email",
+	"verified_name",
+	"verified_phone",
+}
+
+//
+// Public types
+//
+
+// LogRedactor masks sensitive data in the response bodies that backends log
+// at the debug level. Fields in DefaultRedactedFields and DenyFields have
+// their values replaced with RedactedValue, and API keys, webhook secrets and
+// client secrets are masked wherever they appear.
+//
+// Its methods are safe to call on a nil redactor, which only masks the
+// default fields.
+type LogRedactor struct {
+	// AllowFields are fields which are never masked, even when they're in
+	// DefaultRedactedFields or DenyFields. Names follow the same rules as in
+	// DefaultRedactedFields.
+	AllowFields []string
+
+	// DenyFields are fields which are masked in addition to
+	// DefaultRedactedFields.
+	DenyFields []string
+}
+
+// Redact returns body with its sensitive data masked. JSON bodies have their
+// fields checked against the allow and deny lists, while secrets are masked
+// in any body.
+func (r *LogRedactor) Redact(body []byte) []byte {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var v interface{}
+	if err := decoder.Decode(&v); err != nil {
+		return redactSecrets(body)
+	}
+
+	v = r.redactValue(v, nil)
+
+	redacted, err := json.Marshal(v)
+	if err != nil {
+		return redactSecrets(body)
+	}
+	return redactSecrets(redacted)
+}
+
+//
+// Private variables
+//
+
+// cardNumberPattern matches the values of fields holding full card numbers.
+var cardNumberPattern = regexp.MustCompile(`^[0-9]{12,19}$`)
+
+// secretPatterns match secrets wherever they appear in bodies, keeping the
+// prefix which indicates their kind.
+var secretPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`\b((?:sk|rk|pk)_(?:test|live)_)[0-9A-Za-z]+`),
+	regexp.MustCompile(`\b(whsec_)[0-9A-Za-z]+`),
+	regexp.MustCompile(`\b([a-z]+_[0-9A-Za-z]+_secret_)[0-9A-Za-z]+`),
+}
+
+//
+// Private functions
+//
+
+// isCardNumber returns true if a field holds a full card number.
+func isCardNumber(key string, value interface{}) bool {
+	str, ok := value.(string)
+	return ok && key == "number" && cardNumberPattern.MatchString(str)
+}
+
+// matchesField returns true if one of the field names matches the path.
+func matchesField(fields []string, path []string) bool {
+	for _, field := range fields {
+		parts := strings.Split(field, ".")
+		if len(parts) > len(path) {
+			continue
+		}
+
+		matched := true
+		for i, part := range parts {
+			if part != path[len(path)-len(parts)+i] {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+func redactSecrets(body []byte) []byte {
+	for _, pattern := range secretPatterns {
+		body = pattern.ReplaceAll(body, []byte("${1}"+RedactedValue))
+	}
+	return body
+}
+
+// redactValue masks the values of the denied fields in a decoded JSON value,
+// found at the given path. Array indexes aren't part of paths.
+func (r *LogRedactor) redactValue(v interface{}, path []string) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			fieldPath := append(path[:len(path):len(path)], key)
+			if value != nil && (r.denied(fieldPath) || isCardNumber(key, value)) {
+				v[key] = RedactedValue
+				continue
+			}
+			v[key] = r.redactValue(value, fieldPath)
+		}
+
+	case []interface{}:
+		for i, value := range v {
+			v[i] = r.redactValue(value, path)
+		}
+	}
+
+	return v
+}
+
+func (r *LogRedactor) denied(path []string) bool {
+	if r != nil && matchesField(r.AllowFields, path) {
+		return false
+	}
+
+	if matchesField(DefaultRedactedFields, path) {
+		return true
+	}
+
+	return r != nil && matchesField(r.DenyFields, path)
+}
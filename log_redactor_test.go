@@ -0,0 +1,72 @@
+package stripe
+
+import (
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestLogRedactor(t *testing.T) {
+	body := []byte(`{
+		"id": "ch_123",
+		"amount": 100,
+		"billing_details": {"email": "jenny@example.com", "name": "Jenny Rosen", "address": {"line1": "1 Main St"}},
+		"payment_method_details": {"card": {"brand": "visa", "fingerprint": "abc", "last4": "4242"}},
+		"metadata": {"name": "not personal"},
+		"receipt_email": null,
+		"refunds": {"data": [{"id": "re_123", "receipt_email": "jenny@example.com"}]}
+	}`)
+
+	var redactor *LogRedactor
+	assert.Equal(t, `{"amount":100,`+
+		`"billing_details":{"address":"[REDACTED]","email":"[REDACTED]","name":"[REDACTED]"},`+
+		`"id":"ch_123",`+
+		`"metadata":{"name":"not personal"},`+
+		`"payment_method_details":{"card":{"brand":"visa","fingerprint":"[REDACTED]","last4":"[REDACTED]"}},`+
+		`"receipt_email":null,`+
+		`"refunds":{"data":[{"id":"re_123","receipt_email":"[REDACTED]"}]}}`,
+		string(redactor.Redact(body)))
+
+	redactor = &LogRedactor{
+		AllowFields: []string{"card.last4"},
+		DenyFields:  []string{"metadata"},
+	}
+	assert.Equal(t, `{"amount":100,`+
+		`"billing_details":{"address":"[REDACTED]","email":"[REDACTED]","name":"[REDACTED]"},`+
+		`"id":"ch_123",`+
+		`"metadata":"[REDACTED]",`+
+		`"payment_method_details":{"card":{"brand":"visa","fingerprint":"[REDACTED]","last4":"4242"}},`+
+		`"receipt_email":null,`+
+		`"refunds":{"data":[{"id":"re_123","receipt_email":"[REDACTED]"}]}}`,
+		string(redactor.Redact(body)))
+}
+
+func TestLogRedactor_Numbers(t *testing.T) {
+	var redactor *LogRedactor
+
+	// Invoice and credit note numbers aren't sensitive
+	assert.Equal(t, `{"id":"in_123","number":"ABCD-0001"}`,
+		string(redactor.Redact([]byte(`{"id":"in_123","number":"ABCD-0001"}`))))
+	assert.Equal(t, `{"data":[{"id":"cn_123","number":"ABCD-0001-CN-01"}]}`,
+		string(redactor.Redact([]byte(`{"data":[{"id":"cn_123","number":"ABCD-0001-CN-01"}]}`))))
+
+	// But card numbers are
+	assert.Equal(t, `{"card":{"number":"[REDACTED]"}}`,
+		string(redactor.Redact([]byte(`{"card":{"number":"4242-4242"}}`))))
+	assert.Equal(t, `{"cvc":"[REDACTED]","number":"[REDACTED]","object":"issuing.card_details"}`,
+		string(redactor.Redact([]byte(`{"cvc":"123","number":"4242424242424242","object":"issuing.card_details"}`))))
+}
+
+func TestLogRedactor_Secrets(t *testing.T) {
+	var redactor *LogRedactor
+
+	assert.Equal(t, `{"description":"key sk_live_[REDACTED] and whsec_[REDACTED]"}`,
+		string(redactor.Redact([]byte(`{"description":"key sk_live_abc123 and whsec_abc123"}`))))
+
+	assert.Equal(t, `{"client_secret":"[REDACTED]","next_action":{"url":"https://example.com/?secret=pi_123_secret_[REDACTED]"}}`,
+		string(redactor.Redact([]byte(`{"client_secret":"pi_123_secret_abc","next_action":{"url":"https://example.com/?secret=pi_123_secret_abc"}}`))))
+
+	// Bodies that aren't JSON still have their secrets masked
+	assert.Equal(t, `not json rk_test_[REDACTED]`,
+		string(redactor.Redact([]byte(`not json rk_test_abc123`))))
+}
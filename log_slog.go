@@ -0,0 +1,85 @@
+//go:build go1.21
+// +build go1.21
+
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+//
+// Public types
+//
+
+// SlogLogger adapts a *slog.Logger for use by the library. It implements
+// StructuredLogger, so that records about requests carry their attributes,
+// as well as LeveledLoggerInterface for the library's other messages:
+//
+//	logger := stripe.NewSlogLogger(slog.Default())
+//	backend := stripe.GetBackendWithConfig(stripe.APIBackend, &stripe.BackendConfig{
+//		LeveledLogger:    logger,
+//		StructuredLogger: logger,
+//	})
+type SlogLogger struct {
+	// Logger is the logger that records are written to.
+	Logger *slog.Logger
+}
+
+// NewSlogLogger returns a SlogLogger writing to the given logger.
+func NewSlogLogger(logger *slog.Logger) *SlogLogger {
+	return &SlogLogger{Logger: logger}
+}
+
+// Debugf logs a debug message using Printf conventions.
+func (l *SlogLogger) Debugf(format string, v ...interface{}) {
+	l.Logger.Debug(fmt.Sprintf(format, v...))
+}
+
+// Enabled reports whether the logger handles records at the given level.
+func (l *SlogLogger) Enabled(ctx context.Context, level Level) bool {
+	return l.Logger.Enabled(ctx, slogLevel(level))
+}
+
+// Errorf logs an error message using Printf conventions.
+func (l *SlogLogger) Errorf(format string, v ...interface{}) {
+	l.Logger.Error(fmt.Sprintf(format, v...))
+}
+
+// Infof logs an informational message using Printf conventions.
+func (l *SlogLogger) Infof(format string, v ...interface{}) {
+	l.Logger.Info(fmt.Sprintf(format, v...))
+}
+
+// Log logs a record with the given attributes.
+func (l *SlogLogger) Log(ctx context.Context, level Level, msg string, attrs ...LogAttr) {
+	slogAttrs := make([]slog.Attr, len(attrs))
+	for i, attr := range attrs {
+		slogAttrs[i] = slog.Any(attr.Key, attr.Value)
+	}
+
+	l.Logger.LogAttrs(ctx, slogLevel(level), msg, slogAttrs...)
+}
+
+// Warnf logs a warning message using Printf conventions.
+func (l *SlogLogger) Warnf(format string, v ...interface{}) {
+	l.Logger.Warn(fmt.Sprintf(format, v...))
+}
+
+//
+// Private functions
+//
+
+func slogLevel(level Level) slog.Level {
+	switch level {
+	case LevelDebug:
+		return slog.LevelDebug
+	case LevelInfo:
+		return slog.LevelInfo
+	case LevelWarn:
+		return slog.LevelWarn
+	default:
+		return slog.LevelError
+	}
+}
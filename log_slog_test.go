@@ -0,0 +1,76 @@
+//go:build go1.21
+// +build go1.21
+
+package stripe
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestSlogLogger(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Request-Id", "req_123")
+		w.Write([]byte(`{"id": "cus_123", "email": "jenny@example.com"}`))
+	}))
+	defer testServer.Close()
+
+	var buf bytes.Buffer
+	logger := NewSlogLogger(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+
+	backend := GetBackendWithConfig(APIBackend, &BackendConfig{
+		LeveledLogger:    logger,
+		StructuredLogger: logger,
+		URL:              testServer.URL,
+	})
+
+	params := &CustomerParams{}
+	params.SetStripeAccount("acct_123")
+
+	var customer Customer
+	err := backend.Call(http.MethodGet, "/v1/customers/cus_123", "sk_test_123", params, &customer)
+	assert.NoError(t, err)
+
+	var records []map[string]interface{}
+	decoder := json.NewDecoder(&buf)
+	for decoder.More() {
+		var record map[string]interface{}
+		assert.NoError(t, decoder.Decode(&record))
+		records = append(records, record)
+	}
+	assert.Equal(t, 3, len(records))
+
+	for _, record := range records {
+		assert.Equal(t, http.MethodGet, record[LogAttrMethod])
+		assert.Equal(t, "/v1/customers/cus_123", record[LogAttrPath])
+		assert.Equal(t, "acct_123", record[LogAttrAccount])
+	}
+
+	assert.Equal(t, "Requesting", records[0]["msg"])
+
+	completed := records[1]
+	assert.Equal(t, "Request completed", completed["msg"])
+	assert.Equal(t, "INFO", completed["level"])
+	assert.Equal(t, "req_123", completed[LogAttrRequestID])
+	assert.Equal(t, float64(http.StatusOK), completed[LogAttrStatus])
+	assert.Equal(t, float64(0), completed[LogAttrRetries])
+	assert.Contains(t, completed, LogAttrDuration)
+
+	response := records[2]
+	assert.Equal(t, "Response", response["msg"])
+	assert.Equal(t, "DEBUG", response["level"])
+	assert.Equal(t, `{"email":"[REDACTED]","id":"cus_123"}`, response[LogAttrBody])
+
+	// Nothing is logged below the level of the logger
+	buf.Reset()
+	logger.Logger = slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelWarn}))
+	err = backend.Call(http.MethodGet, "/v1/customers/cus_123", "sk_test_123", params, &customer)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, buf.Len())
+}
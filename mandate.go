@@ -104,6 +104,9 @@ type Mandate struct {
 	SingleUse            *MandateSingleUse            `json:"single_use"`
 	Status               MandateStatus                `json:"status"`
 	Type                 MandateType                  `json:"type"`
+
+	Extra    map[string]json.RawMessage `json:"-"`
+	expanded bool
 }
 
 // UnmarshalJSON handles deserialization of a Mandate.
@@ -122,5 +125,6 @@ func (i *Mandate) UnmarshalJSON(data []byte) error {
 	}
 
 	*i = Mandate(v)
+	i.expanded = true
 	return nil
 }
@@ -0,0 +1,496 @@
+package stripe
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+//
+// Public constants
+//
+
+// MaxAmount is the largest amount that the API accepts, in the smallest unit
+// of a currency. Amounts are limited to eight digits, like 99999999 for a
+// charge of $999,999.99.
+const MaxAmount int64 = 99999999
+
+//
+// Public types
+//
+
+// Money is an amount of money in a currency. Its amount is in the smallest
+// unit of the currency like the amounts of the API, which is cents for USD,
+// yen for JPY (a zero-decimal currency) and fils for KWD (a three-decimal
+// currency).
+//
+// Arithmetic on Money is exact, and operations combining two amounts fail if
+// they're in different currencies.
+type Money struct {
+	Amount   int64
+	Currency Currency
+}
+
+// NewMoney returns an amount in the smallest unit of a currency as Money.
+func NewMoney(amount int64, currency Currency) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+// ParseMoney parses a decimal string like "12.34" as an amount of a currency.
+// It returns an error if the string has more decimal places than the currency
+// has, rather than rounding it.
+func ParseMoney(s string, currency Currency) (Money, error) {
+	decimals := CurrencyDecimals(currency)
+
+	str := strings.TrimSpace(s)
+	negative := strings.HasPrefix(str, "-")
+	if negative || strings.HasPrefix(str, "+") {
+		str = str[1:]
+	}
+
+	parts := strings.SplitN(str, ".", 2)
+	integer := parts[0]
+	var fraction string
+	if len(parts) == 2 {
+		fraction = parts[1]
+		if fraction == "" {
+			return Money{}, fmt.Errorf("stripe: invalid amount %q", s)
+		}
+	}
+
+	if len(fraction) > decimals {
+		return Money{}, fmt.Errorf("stripe: amount %q has more than %d decimal places for %s",
+			s, decimals, currencyCode(currency))
+	}
+	fraction += strings.Repeat("0", decimals-len(fraction))
+
+	for _, part := range []string{integer, fraction} {
+		if strings.Trim(part, "0123456789") != "" {
+			return Money{}, fmt.Errorf("stripe: invalid amount %q", s)
+		}
+	}
+	if integer == "" {
+		return Money{}, fmt.Errorf("stripe: invalid amount %q", s)
+	}
+
+	digits := integer + fraction
+	if negative {
+		digits = "-" + digits
+	}
+
+	amount, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil {
+		return Money{}, fmt.Errorf("stripe: amount %q is out of range", s)
+	}
+
+	return Money{Amount: amount, Currency: currency}, nil
+}
+
+// Add returns the sum of two amounts in the same currency.
+func (m Money) Add(other Money) (Money, error) {
+	if err := m.checkCurrency("add", other); err != nil {
+		return Money{}, err
+	}
+
+	if (other.Amount > 0 && m.Amount > math.MaxInt64-other.Amount) ||
+		(other.Amount < 0 && m.Amount < math.MinInt64-other.Amount) {
+		return Money{}, fmt.Errorf("stripe: sum of %v and %v is out of range", m, other)
+	}
+
+	return Money{Amount: m.Amount + other.Amount, Currency: m.Currency}, nil
+}
+
+// Allocate splits the amount into parts proportional to the given ratios,
+// without losing any of it. The units left over after dividing are given to
+// the first parts with a non-zero ratio, one each.
+//
+// Parts of amounts in three-decimal currencies are kept multiples of 10 when
+// the amount is one, as the API requires when charging in them.
+func (m Money) Allocate(ratios ...int64) ([]Money, error) {
+	total := big.NewInt(0)
+	for _, ratio := range ratios {
+		if ratio < 0 {
+			return nil, fmt.Errorf("stripe: cannot allocate with negative ratio %d", ratio)
+		}
+		total.Add(total, big.NewInt(ratio))
+	}
+	if total.Sign() == 0 {
+		return nil, fmt.Errorf("stripe: cannot allocate without a positive ratio")
+	}
+
+	unit := int64(1)
+	if CurrencyDecimals(m.Currency) == 3 && m.Amount%10 == 0 {
+		unit = 10
+	}
+	units := m.Amount / unit
+
+	parts := make([]Money, len(ratios))
+	remainder := units
+	for i, ratio := range ratios {
+		share := big.NewInt(units)
+		share.Mul(share, big.NewInt(ratio))
+		share.Quo(share, total)
+
+		parts[i] = Money{Amount: share.Int64(), Currency: m.Currency}
+		remainder -= share.Int64()
+	}
+
+	step := int64(1)
+	if remainder < 0 {
+		step = -1
+	}
+	for i := 0; remainder != 0; i++ {
+		if ratios[i] == 0 {
+			continue
+		}
+		parts[i].Amount += step
+		remainder -= step
+	}
+
+	for i := range parts {
+		parts[i].Amount *= unit
+	}
+
+	return parts, nil
+}
+
+// Cmp compares two amounts in the same currency, returning -1, 0 or 1 if the
+// amount is less than, equal to or greater than the other one.
+func (m Money) Cmp(other Money) (int, error) {
+	if err := m.checkCurrency("compare", other); err != nil {
+		return 0, err
+	}
+
+	switch {
+	case m.Amount < other.Amount:
+		return -1, nil
+	case m.Amount > other.Amount:
+		return 1, nil
+	default:
+		return 0, nil
+	}
+}
+
+// Decimal returns the amount as a decimal string in the currency's major
+// unit, like "12.34" for 1234 cents, with as many decimal places as the
+// currency has.
+func (m Money) Decimal() string {
+	integer, fraction := m.decimalParts()
+
+	s := integer
+	if fraction != "" {
+		s += "." + fraction
+	}
+	if m.Amount < 0 {
+		s = "-" + s
+	}
+	return s
+}
+
+// Format returns the amount formatted for display in the given locale, like
+// "$1,234.56" in "en-US" or "1.234,56 €" in "de-DE". Locales are matched by
+// language, with a few regional exceptions, and default to English.
+// Currencies without a well known symbol are shown with their code. Spaces
+// in the result are non-breaking.
+func (m Money) Format(locale string) string {
+	l := findMoneyLocale(locale)
+	integer, fraction := m.decimalParts()
+
+	var grouped []string
+	for len(integer) > 3 {
+		grouped = append([]string{integer[len(integer)-3:]}, grouped...)
+		integer = integer[:len(integer)-3]
+	}
+	grouped = append([]string{integer}, grouped...)
+
+	number := strings.Join(grouped, l.groupSeparator)
+	if fraction != "" {
+		number += l.decimalSeparator + fraction
+	}
+
+	symbol, ok := currencySymbols[Currency(strings.ToLower(string(m.Currency)))]
+	separator := ""
+	if !ok {
+		symbol = currencyCode(m.Currency)
+		separator = "\u00a0"
+	}
+	if l.symbolSeparator != "" {
+		separator = l.symbolSeparator
+	}
+
+	var s string
+	if l.symbolAfter {
+		s = number + "\u00a0" + symbol
+	} else {
+		s = symbol + separator + number
+	}
+
+	if m.Amount < 0 {
+		s = "-" + s
+	}
+	return s
+}
+
+// IsZero returns true if the amount is zero.
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+// Mul returns the amount multiplied by a factor, like a quantity.
+func (m Money) Mul(factor int64) (Money, error) {
+	product := big.NewInt(m.Amount)
+	product.Mul(product, big.NewInt(factor))
+	if !product.IsInt64() {
+		return Money{}, fmt.Errorf("stripe: product of %v and %d is out of range", m, factor)
+	}
+
+	return Money{Amount: product.Int64(), Currency: m.Currency}, nil
+}
+
+// Neg returns the amount with its sign reversed.
+func (m Money) Neg() Money {
+	return Money{Amount: -m.Amount, Currency: m.Currency}
+}
+
+// Split splits the amount into n parts that are as equal as possible. See
+// Allocate.
+func (m Money) Split(n int) ([]Money, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("stripe: cannot split into %d parts", n)
+	}
+
+	ratios := make([]int64, n)
+	for i := range ratios {
+		ratios[i] = 1
+	}
+	return m.Allocate(ratios...)
+}
+
+// String returns the amount as a decimal followed by its currency code, like
+// "12.34 USD".
+func (m Money) String() string {
+	return m.Decimal() + " " + currencyCode(m.Currency)
+}
+
+// Sub returns the difference between two amounts in the same currency.
+func (m Money) Sub(other Money) (Money, error) {
+	if err := m.checkCurrency("subtract", other); err != nil {
+		return Money{}, err
+	}
+
+	if (other.Amount < 0 && m.Amount > math.MaxInt64+other.Amount) ||
+		(other.Amount > 0 && m.Amount < math.MinInt64+other.Amount) {
+		return Money{}, fmt.Errorf("stripe: difference of %v and %v is out of range", m, other)
+	}
+
+	return Money{Amount: m.Amount - other.Amount, Currency: m.Currency}, nil
+}
+
+// Validate returns an error if the amount can't be charged: if it's below
+// the minimum charge amount of its currency (see MinimumChargeAmount) or
+// not positive, above MaxAmount, or not a multiple of 10 in a three-decimal
+// currency. Errors are shaped like the API's invalid request errors.
+func (m Money) Validate() error {
+	minimum, ok := MinimumChargeAmount(m.Currency)
+	if !ok {
+		minimum = 1
+	}
+
+	if m.Amount < minimum {
+		return NewInvalidRequestError(ErrorCodeAmountTooSmall, "amount",
+			fmt.Sprintf("Amount must be at least %v", NewMoney(minimum, m.Currency)))
+	}
+
+	if m.Amount > MaxAmount {
+		return NewInvalidRequestError(ErrorCodeAmountTooLarge, "amount",
+			fmt.Sprintf("Amount must be no more than %v", NewMoney(MaxAmount, m.Currency)))
+	}
+
+	if CurrencyDecimals(m.Currency) == 3 && m.Amount%10 != 0 {
+		return NewInvalidRequestError(ErrorCodeInvalidChargeAmount, "amount",
+			fmt.Sprintf("Amounts in %s must be a multiple of 10", currencyCode(m.Currency)))
+	}
+
+	return nil
+}
+
+//
+// Public functions
+//
+
+// CurrencyDecimals returns the number of decimal places of a currency, which
+// is 0 for zero-decimal currencies like JPY, 3 for three-decimal currencies
+// like KWD and 2 for all others.
+func CurrencyDecimals(currency Currency) int {
+	switch Currency(strings.ToLower(string(currency))) {
+	case CurrencyBIF, CurrencyCLP, CurrencyDJF, CurrencyGNF, CurrencyJPY,
+		CurrencyKMF, CurrencyKRW, CurrencyMGA, CurrencyPYG, CurrencyRWF,
+		CurrencyUGX, CurrencyVND, CurrencyVUV, CurrencyXAF, CurrencyXOF,
+		CurrencyXPF:
+		return 0
+	case CurrencyBHD, CurrencyJOD, CurrencyKWD, CurrencyOMR, CurrencyTND:
+		return 3
+	default:
+		return 2
+	}
+}
+
+// MinimumChargeAmount returns the minimum amount that can be charged in a
+// currency, in its smallest unit. The second return value is false for
+// currencies without a known minimum.
+//
+// Minimums apply to the currency that a charge is settled in, so a charge in
+// another currency must also convert to at least the minimum of the
+// settlement currency.
+func MinimumChargeAmount(currency Currency) (int64, bool) {
+	minimum, ok := minimumChargeAmounts[Currency(strings.ToLower(string(currency)))]
+	return minimum, ok
+}
+
+// RoundAmount rounds an amount computed in floating point, like a percentage
+// of another amount, to the nearest unit. Halves are rounded away from zero,
+// like math.Round which isn't available in all the supported versions of Go.
+func RoundAmount(v float64) int64 {
+	if v < 0 {
+		return -int64(math.Floor(-v + 0.5))
+	}
+	return int64(math.Floor(v + 0.5))
+}
+
+//
+// Private types
+//
+
+// moneyLocale describes how amounts are displayed in a locale.
+type moneyLocale struct {
+	decimalSeparator string
+	groupSeparator   string
+
+	// symbolAfter is true for locales where the currency symbol follows the
+	// amount, separated by a space.
+	symbolAfter bool
+
+	// symbolSeparator separates the currency symbol from an amount that it
+	// precedes.
+	symbolSeparator string
+}
+
+//
+// Private variables
+//
+
+// currencySymbols are the symbols of the currencies that have a well known
+// one.
+var currencySymbols = map[Currency]string{
+	CurrencyAUD: "A$",
+	CurrencyBRL: "R$",
+	CurrencyCAD: "CA$",
+	CurrencyCNY: "CN¥",
+	CurrencyEUR: "€",
+	CurrencyGBP: "£",
+	CurrencyHKD: "HK$",
+	CurrencyILS: "₪",
+	CurrencyINR: "₹",
+	CurrencyJPY: "¥",
+	CurrencyKRW: "₩",
+	CurrencyMXN: "MX$",
+	CurrencyNZD: "NZ$",
+	CurrencyUSD: "$",
+	CurrencyVND: "₫",
+}
+
+// minimumChargeAmounts are the minimum amounts that can be charged in each
+// settlement currency.
+var minimumChargeAmounts = map[Currency]int64{
+	CurrencyAED: 200,
+	CurrencyAUD: 50,
+	CurrencyBGN: 100,
+	CurrencyBRL: 50,
+	CurrencyCAD: 50,
+	CurrencyCHF: 50,
+	CurrencyCZK: 1500,
+	CurrencyDKK: 250,
+	CurrencyEUR: 50,
+	CurrencyGBP: 30,
+	CurrencyHKD: 400,
+	CurrencyHUF: 17500,
+	CurrencyINR: 50,
+	CurrencyJPY: 50,
+	CurrencyMXN: 1000,
+	CurrencyMYR: 200,
+	CurrencyNOK: 300,
+	CurrencyNZD: 50,
+	CurrencyPLN: 200,
+	CurrencyRON: 200,
+	CurrencySEK: 300,
+	CurrencySGD: 50,
+	CurrencyTHB: 1000,
+	CurrencyUSD: 50,
+}
+
+// moneyLocales are keyed by language, or by language and region for regions
+// that differ from their language.
+var moneyLocales = map[string]moneyLocale{
+	"de":    {decimalSeparator: ",", groupSeparator: ".", symbolAfter: true},
+	"de-ch": {decimalSeparator: ".", groupSeparator: "’", symbolSeparator: "\u00a0"},
+	"en":    {decimalSeparator: ".", groupSeparator: ","},
+	"es":    {decimalSeparator: ",", groupSeparator: ".", symbolAfter: true},
+	"fr":    {decimalSeparator: ",", groupSeparator: "\u00a0", symbolAfter: true},
+	"it":    {decimalSeparator: ",", groupSeparator: ".", symbolAfter: true},
+	"ja":    {decimalSeparator: ".", groupSeparator: ","},
+	"nl":    {decimalSeparator: ",", groupSeparator: ".", symbolSeparator: "\u00a0"},
+	"pt":    {decimalSeparator: ",", groupSeparator: ".", symbolSeparator: "\u00a0"},
+	"pt-pt": {decimalSeparator: ",", groupSeparator: "\u00a0", symbolAfter: true},
+	"sv":    {decimalSeparator: ",", groupSeparator: "\u00a0", symbolAfter: true},
+	"zh":    {decimalSeparator: ".", groupSeparator: ","},
+}
+
+//
+// Private functions
+//
+
+func currencyCode(currency Currency) string {
+	return strings.ToUpper(string(currency))
+}
+
+func findMoneyLocale(locale string) moneyLocale {
+	tag := strings.ToLower(strings.Replace(locale, "_", "-", -1))
+
+	if l, ok := moneyLocales[tag]; ok {
+		return l
+	}
+	if l, ok := moneyLocales[strings.SplitN(tag, "-", 2)[0]]; ok {
+		return l
+	}
+	return moneyLocales["en"]
+}
+
+func (m Money) checkCurrency(operation string, other Money) error {
+	if !strings.EqualFold(string(m.Currency), string(other.Currency)) {
+		return fmt.Errorf("stripe: cannot %s amounts in different currencies (%s and %s)",
+			operation, currencyCode(m.Currency), currencyCode(other.Currency))
+	}
+	return nil
+}
+
+// decimalParts returns the digits of the absolute amount before and after
+// the decimal point.
+func (m Money) decimalParts() (string, string) {
+	abs := uint64(m.Amount)
+	if m.Amount < 0 {
+		abs = uint64(-(m.Amount + 1)) + 1
+	}
+
+	digits := strconv.FormatUint(abs, 10)
+	decimals := CurrencyDecimals(m.Currency)
+	if decimals == 0 {
+		return digits, ""
+	}
+
+	if len(digits) <= decimals {
+		digits = strings.Repeat("0", decimals-len(digits)+1) + digits
+	}
+	return digits[:len(digits)-decimals], digits[len(digits)-decimals:]
+}
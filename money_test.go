@@ -0,0 +1,206 @@
+package stripe
+
+import (
+	"math"
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestParseMoney(t *testing.T) {
+	testCases := []struct {
+		s        string
+		currency Currency
+		want     int64
+	}{
+		{"12.34", CurrencyUSD, 1234},
+		{"12.3", CurrencyUSD, 1230},
+		{"12", CurrencyUSD, 1200},
+		{"0.05", CurrencyUSD, 5},
+		{"-1.50", CurrencyUSD, -150},
+		{"+1.50", CurrencyUSD, 150},
+		{"1234", CurrencyJPY, 1234},
+		{"1.234", CurrencyKWD, 1234},
+		{"1.5", Currency("KWD"), 1500},
+		{"92233720368547758.07", CurrencyUSD, math.MaxInt64},
+	}
+	for _, tc := range testCases {
+		m, err := ParseMoney(tc.s, tc.currency)
+		assert.NoError(t, err, tc.s)
+		assert.Equal(t, NewMoney(tc.want, tc.currency), m)
+	}
+
+	for _, tc := range []struct {
+		s        string
+		currency Currency
+	}{
+		{"12.345", CurrencyUSD},
+		{"12.5", CurrencyJPY},
+		{"12.", CurrencyUSD},
+		{".5", CurrencyUSD},
+		{"1,000", CurrencyUSD},
+		{"1e3", CurrencyUSD},
+		{"92233720368547758.08", CurrencyUSD},
+		{"", CurrencyUSD},
+		{"-", CurrencyUSD},
+		{"--5", CurrencyUSD},
+		{"-+5", CurrencyUSD},
+		{"+-+5", CurrencyUSD},
+		{"5-", CurrencyUSD},
+	} {
+		_, err := ParseMoney(tc.s, tc.currency)
+		assert.Error(t, err, tc.s)
+	}
+}
+
+func TestMoney_Arithmetic(t *testing.T) {
+	a := NewMoney(1050, CurrencyUSD)
+
+	sum, err := a.Add(NewMoney(250, CurrencyUSD))
+	assert.NoError(t, err)
+	assert.Equal(t, NewMoney(1300, CurrencyUSD), sum)
+
+	difference, err := a.Sub(NewMoney(2000, CurrencyUSD))
+	assert.NoError(t, err)
+	assert.Equal(t, NewMoney(-950, CurrencyUSD), difference)
+
+	product, err := a.Mul(3)
+	assert.NoError(t, err)
+	assert.Equal(t, NewMoney(3150, CurrencyUSD), product)
+
+	cmp, err := a.Cmp(NewMoney(1000, CurrencyUSD))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, cmp)
+
+	// Currencies are compared regardless of case
+	_, err = a.Add(NewMoney(1, Currency("USD")))
+	assert.NoError(t, err)
+
+	_, err = a.Add(NewMoney(1, CurrencyEUR))
+	assert.EqualError(t, err, "stripe: cannot add amounts in different currencies (USD and EUR)")
+	_, err = a.Sub(NewMoney(1, CurrencyEUR))
+	assert.Error(t, err)
+	_, err = a.Cmp(NewMoney(1, CurrencyEUR))
+	assert.Error(t, err)
+
+	_, err = NewMoney(math.MaxInt64, CurrencyUSD).Add(NewMoney(1, CurrencyUSD))
+	assert.Error(t, err)
+	_, err = NewMoney(0, CurrencyUSD).Sub(NewMoney(math.MinInt64, CurrencyUSD))
+	assert.Error(t, err)
+	_, err = NewMoney(math.MaxInt64/2+1, CurrencyUSD).Mul(2)
+	assert.Error(t, err)
+}
+
+func TestMoney_Allocate(t *testing.T) {
+	parts, err := NewMoney(100, CurrencyUSD).Allocate(1, 1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []Money{
+		NewMoney(34, CurrencyUSD),
+		NewMoney(33, CurrencyUSD),
+		NewMoney(33, CurrencyUSD),
+	}, parts)
+
+	// Remainders skip parts with a zero ratio
+	parts, err = NewMoney(5, CurrencyUSD).Allocate(0, 3, 7)
+	assert.NoError(t, err)
+	assert.Equal(t, []Money{
+		NewMoney(0, CurrencyUSD),
+		NewMoney(2, CurrencyUSD),
+		NewMoney(3, CurrencyUSD),
+	}, parts)
+
+	parts, err = NewMoney(-100, CurrencyUSD).Split(3)
+	assert.NoError(t, err)
+	assert.Equal(t, []Money{
+		NewMoney(-34, CurrencyUSD),
+		NewMoney(-33, CurrencyUSD),
+		NewMoney(-33, CurrencyUSD),
+	}, parts)
+
+	// Parts in three-decimal currencies stay multiples of 10
+	parts, err = NewMoney(1000, CurrencyKWD).Split(3)
+	assert.NoError(t, err)
+	assert.Equal(t, []Money{
+		NewMoney(340, CurrencyKWD),
+		NewMoney(330, CurrencyKWD),
+		NewMoney(330, CurrencyKWD),
+	}, parts)
+
+	_, err = NewMoney(100, CurrencyUSD).Allocate()
+	assert.Error(t, err)
+	_, err = NewMoney(100, CurrencyUSD).Allocate(0, 0)
+	assert.Error(t, err)
+	_, err = NewMoney(100, CurrencyUSD).Allocate(1, -1)
+	assert.Error(t, err)
+	_, err = NewMoney(100, CurrencyUSD).Split(0)
+	assert.Error(t, err)
+}
+
+func TestMoney_Format(t *testing.T) {
+	assert.Equal(t, "12.34", NewMoney(1234, CurrencyUSD).Decimal())
+	assert.Equal(t, "0.05", NewMoney(5, CurrencyUSD).Decimal())
+	assert.Equal(t, "-0.05", NewMoney(-5, CurrencyUSD).Decimal())
+	assert.Equal(t, "1234", NewMoney(1234, CurrencyJPY).Decimal())
+	assert.Equal(t, "1.234", NewMoney(1234, CurrencyKWD).Decimal())
+	assert.Equal(t, "-92233720368547758.08", NewMoney(math.MinInt64, CurrencyUSD).Decimal())
+	assert.Equal(t, "12.34 USD", NewMoney(1234, CurrencyUSD).String())
+
+	m := NewMoney(123456, CurrencyEUR)
+	assert.Equal(t, "€1,234.56", m.Format("en-US"))
+	assert.Equal(t, "1.234,56\u00a0€", m.Format("de-DE"))
+	assert.Equal(t, "1\u00a0234,56\u00a0€", m.Format("fr_FR"))
+	assert.Equal(t, "€\u00a01.234,56", m.Format("nl"))
+	assert.Equal(t, "€\u00a01’234.56", m.Format("de-CH"))
+	assert.Equal(t, "€1,234.56", m.Format("xx"))
+
+	assert.Equal(t, "-$1,234.56", NewMoney(-123456, CurrencyUSD).Format("en"))
+	assert.Equal(t, "¥1,234", NewMoney(1234, CurrencyJPY).Format("ja-JP"))
+	assert.Equal(t, "KWD\u00a01.234", NewMoney(1234, CurrencyKWD).Format("en"))
+	assert.Equal(t, "1,234\u00a0KWD", NewMoney(1234, CurrencyKWD).Format("de"))
+}
+
+func TestMoney_Validate(t *testing.T) {
+	assert.NoError(t, NewMoney(50, CurrencyUSD).Validate())
+	assert.NoError(t, NewMoney(MaxAmount, CurrencyUSD).Validate())
+	assert.NoError(t, NewMoney(1, CurrencyISK).Validate())
+	assert.NoError(t, NewMoney(1230, CurrencyKWD).Validate())
+
+	err := NewMoney(49, CurrencyUSD).Validate()
+	assert.Error(t, err)
+	stripeErr := err.(*Error)
+	assert.Equal(t, ErrorTypeInvalidRequest, stripeErr.Type)
+	assert.Equal(t, ErrorCodeAmountTooSmall, stripeErr.Code)
+	assert.Equal(t, "amount", stripeErr.Param)
+	assert.Equal(t, "Amount must be at least 0.50 USD", stripeErr.Msg)
+
+	err = NewMoney(0, CurrencyISK).Validate()
+	assert.Equal(t, ErrorCodeAmountTooSmall, err.(*Error).Code)
+
+	err = NewMoney(MaxAmount+1, CurrencyUSD).Validate()
+	assert.Equal(t, ErrorCodeAmountTooLarge, err.(*Error).Code)
+
+	err = NewMoney(1234, CurrencyKWD).Validate()
+	assert.Equal(t, ErrorCodeInvalidChargeAmount, err.(*Error).Code)
+}
+
+func TestCurrencyDecimals(t *testing.T) {
+	assert.Equal(t, 2, CurrencyDecimals(CurrencyUSD))
+	assert.Equal(t, 0, CurrencyDecimals(CurrencyJPY))
+	assert.Equal(t, 0, CurrencyDecimals(Currency("JPY")))
+	assert.Equal(t, 3, CurrencyDecimals(CurrencyBHD))
+
+	minimum, ok := MinimumChargeAmount(Currency("GBP"))
+	assert.True(t, ok)
+	assert.Equal(t, int64(30), minimum)
+
+	_, ok = MinimumChargeAmount(CurrencyISK)
+	assert.False(t, ok)
+}
+
+func TestRoundAmount(t *testing.T) {
+	assert.Equal(t, int64(2), RoundAmount(1.5))
+	assert.Equal(t, int64(1), RoundAmount(1.49))
+	assert.Equal(t, int64(-2), RoundAmount(-1.5))
+	assert.Equal(t, int64(-1), RoundAmount(-1.49))
+	assert.Equal(t, int64(0), RoundAmount(0))
+}
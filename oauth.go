@@ -9,6 +9,15 @@ const (
 	OAuthScopeTypeReadWrite OAuthScopeType = "read_write"
 )
 
+// OAuthGrantType is the type of grant used to request an OAuthToken.
+type OAuthGrantType string
+
+// List of possible values for OAuth grant types.
+const (
+	OAuthGrantTypeAuthorizationCode OAuthGrantType = "authorization_code"
+	OAuthGrantTypeRefreshToken      OAuthGrantType = "refresh_token"
+)
+
 // OAuthTokenType is the type of token. This will always be "bearer."
 type OAuthTokenType string
 
@@ -2,6 +2,7 @@
 package oauth
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 
@@ -30,7 +31,7 @@ func (c Client) AuthorizeURL(params *stripe.AuthorizeURLParams) string {
 	form.AppendTo(qs, params)
 	return fmt.Sprintf(
 		"%s%s/oauth/authorize?%s",
-		stripe.ConnectURL,
+		c.connectURL(),
 		express,
 		qs.Encode(),
 	)
@@ -43,17 +44,39 @@ func New(params *stripe.OAuthTokenParams) (*stripe.OAuthToken, error) {
 
 // New creates an OAuth token using a code after successful redirection back.
 func (c Client) New(params *stripe.OAuthTokenParams) (*stripe.OAuthToken, error) {
+	oauthToken := &stripe.OAuthToken{}
+
 	// client_secret is sent in the post body for this endpoint.
 	if stripe.StringValue(params.ClientSecret) == "" {
-		params.ClientSecret = stripe.String(stripe.Key)
+		secretKey, err := c.secretKey(params.Context)
+		if err != nil {
+			return oauthToken, err
+		}
+		params.ClientSecret = stripe.String(secretKey)
 	}
 
-	oauthToken := &stripe.OAuthToken{}
 	err := c.B.Call(http.MethodPost, "/oauth/token", c.Key, params, oauthToken)
 
 	return oauthToken, err
 }
 
+// Refresh uses a refresh token to create a new OAuth access token for a
+// connected account.
+func Refresh(refreshToken string, params *stripe.OAuthTokenParams) (*stripe.OAuthToken, error) {
+	return getC().Refresh(refreshToken, params)
+}
+
+// Refresh uses a refresh token to create a new OAuth access token for a
+// connected account.
+func (c Client) Refresh(refreshToken string, params *stripe.OAuthTokenParams) (*stripe.OAuthToken, error) {
+	if params == nil {
+		params = &stripe.OAuthTokenParams{}
+	}
+	params.GrantType = stripe.String(string(stripe.OAuthGrantTypeRefreshToken))
+	params.RefreshToken = stripe.String(refreshToken)
+	return c.New(params)
+}
+
 // Del deauthorizes a connected account.
 func Del(params *stripe.DeauthorizeParams) (*stripe.Deauthorize, error) {
 	return getC().Del(params)
@@ -72,6 +95,51 @@ func (c Client) Del(params *stripe.DeauthorizeParams) (*stripe.Deauthorize, erro
 	return deauthorization, err
 }
 
+// connectURL returns the base URL of the Connect backend that the client is
+// configured with so that authorize URLs point to the same place as the rest
+// of the OAuth API calls (e.g. a local stand-in during testing).
+func (c Client) connectURL() string {
+	for b := c.B; b != nil; b = unwrap(b) {
+		if impl, ok := b.(*stripe.BackendImplementation); ok && impl.URL != "" {
+			return impl.URL
+		}
+	}
+	return stripe.ConnectURL
+}
+
+// secretKey returns the key that should be sent as client_secret when one
+// wasn't given explicitly, which is the one of the first key provider found
+// through the wrapping backends.
+func (c Client) secretKey(ctx context.Context) (string, error) {
+	for b := c.B; b != nil; b = unwrap(b) {
+		if provider, ok := b.(*stripe.KeyProviderBackend); ok {
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			return provider.KeyProvider.Key(ctx)
+		}
+	}
+	if c.Key != "" {
+		return c.Key, nil
+	}
+	return stripe.Key, nil
+}
+
+// WithKeyProvider returns a copy of the client that resolves the API key to
+// use from the given provider for every request.
+func (c Client) WithKeyProvider(provider stripe.KeyProvider) *Client {
+	return &Client{B: stripe.NewKeyProviderBackend(c.B, provider), Key: c.Key}
+}
+
 func getC() Client {
 	return Client{stripe.GetBackend(stripe.ConnectBackend), stripe.Key}
 }
+
+// unwrap returns the backend that a wrapping backend like
+// stripe.AccountBackend forwards requests to, or nil if b doesn't wrap one.
+func unwrap(b stripe.Backend) stripe.Backend {
+	if wrapper, ok := b.(stripe.BackendWrapper); ok {
+		return wrapper.Unwrap()
+	}
+	return nil
+}
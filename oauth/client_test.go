@@ -200,6 +200,39 @@ func TestNewOAuthTokenWithCustomKey(t *testing.T) {
 	assert.NotNil(t, token)
 }
 
+func TestNewOAuthTokenWithWrappedKeyProvider(t *testing.T) {
+	stripe.Key = "sk_123"
+	httpClient := newTestClient(func(req *http.Request) *http.Response {
+		buf := new(bytes.Buffer)
+		buf.ReadFrom(req.Body)
+		assert.Contains(t, buf.String(), "client_secret=sk_provider")
+
+		return &http.Response{
+			StatusCode: 200,
+			Body:       ioutil.NopCloser(bytes.NewBufferString(`{}`)),
+			Header:     make(http.Header),
+		}
+	})
+	backend := stripe.GetBackendWithConfig(
+		stripe.ConnectBackend,
+		&stripe.BackendConfig{
+			URL:        "https://localhost:12113",
+			HTTPClient: httpClient,
+		},
+	)
+
+	// The key provider is found under other wrapping backends
+	client := Client{B: backend, Key: "sk_123"}.WithKeyProvider(stripe.StaticKeyProvider("sk_provider"))
+	client.B = stripe.NewValidatingBackend(client.B)
+
+	token, err := client.New(&stripe.OAuthTokenParams{
+		Code:      stripe.String("code"),
+		GrantType: stripe.String("authorization_code"),
+	})
+	assert.Nil(t, err)
+	assert.NotNil(t, token)
+}
+
 func TestNewOAuthTokenWithError(t *testing.T) {
 	stripe.Key = "sk_123"
 	// stripe-mock doesn't support connect URLs so this stubs out the server.
@@ -0,0 +1,310 @@
+package oauth
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	stripe "github.com/stripe/stripe-go"
+)
+
+//
+// Public constants
+//
+
+const (
+	// DefaultStateCookieName is the name of the cookie used to bind an OAuth
+	// state parameter to the browser that started the flow.
+	DefaultStateCookieName = "stripe_oauth_state"
+
+	// DefaultStateTTL is how long a state parameter generated by Flow remains
+	// valid.
+	DefaultStateTTL time.Duration = 10 * time.Minute
+)
+
+//
+// Public variables
+//
+
+// This block represents the list of errors that could be raised when
+// handling an OAuth callback with Flow.
+var (
+	ErrInvalidState = errors.New("oauth callback has an invalid state parameter")
+	ErrMissingCode  = errors.New("oauth callback has no authorization code")
+	ErrStateExpired = errors.New("oauth callback state has expired")
+)
+
+//
+// Public types
+//
+
+// Flow implements the user-facing part of the Connect OAuth flow as a pair of
+// HTTP handlers. StartHandler redirects the user to Stripe with a signed
+// `state` parameter and CallbackHandler verifies that state when Stripe
+// redirects back before exchanging the authorization code for a token.
+type Flow struct {
+	// AuthorizeParams are optional extra parameters for the authorize URL
+	// (e.g. Scope, StripeUser or Express). ClientID, RedirectURI and State
+	// are always overridden by the flow.
+	AuthorizeParams *stripe.AuthorizeURLParams
+
+	// Client is the OAuth client used to build authorize URLs and make API
+	// calls. If nil, the package-level client is used, which is configured
+	// from stripe.Key and the default Connect backend.
+	Client *Client
+
+	// ClientID is the platform's Connect client ID (`ca_...`).
+	ClientID string
+
+	// CookieName is the name of the cookie binding the state to the browser.
+	//
+	// Defaults to DefaultStateCookieName.
+	CookieName string
+
+	// OnError is invoked when a callback can't be completed, either because
+	// the state couldn't be verified, the user declined access (reported as a
+	// *stripe.Error with OAuthError set), or the code exchange failed.
+	//
+	// Defaults to responding with a 400.
+	OnError func(w http.ResponseWriter, r *http.Request, err error)
+
+	// OnToken is invoked after an authorization code was successfully
+	// exchanged for an OAuth token. It's responsible for writing the response.
+	//
+	// Defaults to responding with a 204.
+	OnToken func(w http.ResponseWriter, r *http.Request, token *stripe.OAuthToken)
+
+	// RedirectURI is the URI that Stripe will redirect back to. If empty, the
+	// redirect URI configured for the platform is used.
+	RedirectURI string
+
+	// StateSecret is the key used to sign state parameters. It's required and
+	// should be a long random value that's kept private.
+	StateSecret []byte
+
+	// StateTTL is how long a generated state remains valid.
+	//
+	// Defaults to DefaultStateTTL.
+	StateTTL time.Duration
+
+	// now is overridden in tests.
+	now func() time.Time
+}
+
+// CallbackHandler returns an http.Handler to use as the redirect URI of the
+// flow.
+func (f *Flow) CallbackHandler() http.Handler {
+	f.mustHaveSecret()
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, err := f.handleCallback(w, r)
+		if err != nil {
+			f.onError(w, r, err)
+			return
+		}
+
+		if f.OnToken == nil {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		f.OnToken(w, r, token)
+	})
+}
+
+// Deauthorize disconnects a connected account from the platform.
+func (f *Flow) Deauthorize(stripeUserID string) (*stripe.Deauthorize, error) {
+	return f.client().Del(&stripe.DeauthorizeParams{
+		ClientID:     stripe.String(f.ClientID),
+		StripeUserID: stripe.String(stripeUserID),
+	})
+}
+
+// Refresh uses a refresh token to create a new access token for a connected
+// account.
+func (f *Flow) Refresh(refreshToken string) (*stripe.OAuthToken, error) {
+	return f.client().Refresh(refreshToken, nil)
+}
+
+// StartHandler returns an http.Handler that redirects the user to Stripe's
+// authorize page.
+func (f *Flow) StartHandler() http.Handler {
+	f.mustHaveSecret()
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		nonce := make([]byte, stateNonceLength)
+		if _, err := rand.Read(nonce); err != nil {
+			f.onError(w, r, err)
+			return
+		}
+
+		http.SetCookie(w, &http.Cookie{
+			Name:     f.cookieName(),
+			Value:    base64.RawURLEncoding.EncodeToString(nonce),
+			Path:     "/",
+			MaxAge:   int(f.stateTTL() / time.Second),
+			HttpOnly: true,
+			Secure:   r.TLS != nil,
+		})
+
+		params := &stripe.AuthorizeURLParams{}
+		if f.AuthorizeParams != nil {
+			*params = *f.AuthorizeParams
+		}
+		params.ClientID = stripe.String(f.ClientID)
+		params.State = stripe.String(f.signState(nonce, f.currentTime()))
+		if f.RedirectURI != "" {
+			params.RedirectURI = stripe.String(f.RedirectURI)
+		}
+
+		http.Redirect(w, r, f.client().AuthorizeURL(params), http.StatusFound)
+	})
+}
+
+//
+// Private constants
+//
+
+// stateNonceLength is the number of random bytes in a state parameter.
+const stateNonceLength = 16
+
+//
+// Private functions
+//
+
+func (f *Flow) client() Client {
+	if f.Client != nil {
+		return *f.Client
+	}
+	return getC()
+}
+
+func (f *Flow) cookieName() string {
+	if f.CookieName != "" {
+		return f.CookieName
+	}
+	return DefaultStateCookieName
+}
+
+func (f *Flow) currentTime() time.Time {
+	if f.now != nil {
+		return f.now()
+	}
+	return time.Now()
+}
+
+func (f *Flow) handleCallback(w http.ResponseWriter, r *http.Request) (*stripe.OAuthToken, error) {
+	query := r.URL.Query()
+
+	err := f.verifyState(r, query.Get("state"))
+
+	// The state is single use regardless of whether it checked out.
+	http.SetCookie(w, &http.Cookie{
+		Name:   f.cookieName(),
+		Path:   "/",
+		MaxAge: -1,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if oauthErr := query.Get("error"); oauthErr != "" {
+		return nil, &stripe.Error{
+			OAuthError:            oauthErr,
+			OAuthErrorDescription: query.Get("error_description"),
+		}
+	}
+
+	code := query.Get("code")
+	if code == "" {
+		return nil, ErrMissingCode
+	}
+
+	params := &stripe.OAuthTokenParams{
+		Code:      stripe.String(code),
+		GrantType: stripe.String(string(stripe.OAuthGrantTypeAuthorizationCode)),
+	}
+	params.Context = r.Context()
+
+	return f.client().New(params)
+}
+
+func (f *Flow) mustHaveSecret() {
+	if len(f.StateSecret) == 0 {
+		panic("oauth: Flow.StateSecret must be set")
+	}
+}
+
+func (f *Flow) onError(w http.ResponseWriter, r *http.Request, err error) {
+	if f.OnError != nil {
+		f.OnError(w, r, err)
+		return
+	}
+	http.Error(w, err.Error(), http.StatusBadRequest)
+}
+
+// signState produces a state parameter of the form `payload.signature` where
+// the payload contains the nonce and the time at which it was issued.
+func (f *Flow) signState(nonce []byte, issued time.Time) string {
+	payload := make([]byte, len(nonce)+8)
+	copy(payload, nonce)
+	binary.BigEndian.PutUint64(payload[len(nonce):], uint64(issued.Unix()))
+
+	return base64.RawURLEncoding.EncodeToString(payload) + "." +
+		base64.RawURLEncoding.EncodeToString(f.stateMAC(payload))
+}
+
+func (f *Flow) stateMAC(payload []byte) []byte {
+	mac := hmac.New(sha256.New, f.StateSecret)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+func (f *Flow) stateTTL() time.Duration {
+	if f.StateTTL != 0 {
+		return f.StateTTL
+	}
+	return DefaultStateTTL
+}
+
+func (f *Flow) verifyState(r *http.Request, state string) error {
+	parts := strings.Split(state, ".")
+	if len(parts) != 2 {
+		return ErrInvalidState
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil || len(payload) != stateNonceLength+8 {
+		return ErrInvalidState
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil || !hmac.Equal(sig, f.stateMAC(payload)) {
+		return ErrInvalidState
+	}
+
+	// A valid signature only proves that we issued the state. Checking it
+	// against the cookie proves that it was issued to this browser, which is
+	// what protects against CSRF.
+	cookie, err := r.Cookie(f.cookieName())
+	if err != nil {
+		return ErrInvalidState
+	}
+	nonce, err := base64.RawURLEncoding.DecodeString(cookie.Value)
+	if err != nil || !hmac.Equal(nonce, payload[:stateNonceLength]) {
+		return ErrInvalidState
+	}
+
+	issued := time.Unix(int64(binary.BigEndian.Uint64(payload[stateNonceLength:])), 0)
+	if f.currentTime().Sub(issued) > f.stateTTL() {
+		return ErrStateExpired
+	}
+
+	return nil
+}
@@ -0,0 +1,217 @@
+package oauth
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	assert "github.com/stretchr/testify/require"
+	stripe "github.com/stripe/stripe-go"
+	_ "github.com/stripe/stripe-go/testing"
+)
+
+func newTestFlow(fn roundTripFunc) *Flow {
+	backend := stripe.GetBackendWithConfig(
+		stripe.ConnectBackend,
+		&stripe.BackendConfig{
+			URL:        "https://localhost:12113",
+			HTTPClient: newTestClient(fn),
+		},
+	)
+
+	return &Flow{
+		Client:      &Client{B: backend, Key: "sk_123"},
+		ClientID:    "ca_123",
+		RedirectURI: "https://example.com/callback",
+		StateSecret: []byte("secret"),
+	}
+}
+
+// startFlow runs the start handler and returns the state and cookie it
+// produced.
+func startFlow(t *testing.T, flow *Flow) (string, *http.Cookie) {
+	rec := httptest.NewRecorder()
+	flow.StartHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/connect", nil))
+
+	assert.Equal(t, http.StatusFound, rec.Code)
+	location, err := url.Parse(rec.Header().Get("Location"))
+	assert.NoError(t, err)
+
+	cookies := rec.Result().Cookies()
+	assert.Equal(t, 1, len(cookies))
+
+	return location.Query().Get("state"), cookies[0]
+}
+
+func callback(flow *Flow, query url.Values, cookie *http.Cookie) (*stripe.OAuthToken, error) {
+	var token *stripe.OAuthToken
+	var err error
+
+	flow.OnToken = func(w http.ResponseWriter, r *http.Request, t *stripe.OAuthToken) {
+		token = t
+	}
+	flow.OnError = func(w http.ResponseWriter, r *http.Request, e error) {
+		err = e
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/callback?"+query.Encode(), nil)
+	if cookie != nil {
+		req.AddCookie(cookie)
+	}
+	flow.CallbackHandler().ServeHTTP(httptest.NewRecorder(), req)
+
+	return token, err
+}
+
+func TestAuthorizeURLUsesBackendURL(t *testing.T) {
+	flow := newTestFlow(nil)
+	url := flow.Client.AuthorizeURL(&stripe.AuthorizeURLParams{})
+
+	assert.Equal(t, "https://localhost:12113/oauth/authorize?", url)
+}
+
+func TestAuthorizeURLUsesWrappedBackendURL(t *testing.T) {
+	flow := newTestFlow(nil)
+	client := &Client{
+		B:   stripe.NewAccountBackend(stripe.NewValidatingBackend(flow.Client.B), "acct_123"),
+		Key: "sk_123",
+	}
+	url := client.WithKeyProvider(stripe.StaticKeyProvider("sk_456")).AuthorizeURL(&stripe.AuthorizeURLParams{})
+
+	assert.Equal(t, "https://localhost:12113/oauth/authorize?", url)
+}
+
+func TestFlowStart(t *testing.T) {
+	flow := newTestFlow(nil)
+
+	rec := httptest.NewRecorder()
+	flow.StartHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/connect", nil))
+
+	location := rec.Header().Get("Location")
+	assert.Contains(t, location, "https://localhost:12113/oauth/authorize?")
+	assert.Contains(t, location, "client_id=ca_123")
+	assert.Contains(t, location, "redirect_uri=https%3A%2F%2Fexample.com%2Fcallback")
+	assert.Contains(t, location, "state=")
+}
+
+func TestFlowCallback(t *testing.T) {
+	flow := newTestFlow(func(req *http.Request) *http.Response {
+		buf := new(bytes.Buffer)
+		buf.ReadFrom(req.Body)
+		reqBody := buf.String()
+
+		assert.Contains(t, req.URL.String(), "https://localhost:12113/oauth/token")
+		assert.Contains(t, reqBody, "client_secret=sk_123")
+		assert.Contains(t, reqBody, "code=ac_123")
+		assert.Contains(t, reqBody, "grant_type=authorization_code")
+
+		return &http.Response{
+			StatusCode: 200,
+			Body:       ioutil.NopCloser(bytes.NewBufferString(`{"stripe_user_id":"acct_123"}`)),
+			Header:     make(http.Header),
+		}
+	})
+	state, cookie := startFlow(t, flow)
+
+	token, err := callback(flow, url.Values{"code": {"ac_123"}, "state": {state}}, cookie)
+	assert.NoError(t, err)
+	assert.Equal(t, "acct_123", token.StripeUserID)
+}
+
+func TestFlowCallbackWithOAuthError(t *testing.T) {
+	flow := newTestFlow(nil)
+	state, cookie := startFlow(t, flow)
+
+	_, err := callback(flow, url.Values{
+		"error":             {"access_denied"},
+		"error_description": {"The user denied your request"},
+		"state":             {state},
+	}, cookie)
+
+	stripeErr := err.(*stripe.Error)
+	assert.Equal(t, "access_denied", stripeErr.OAuthError)
+	assert.Equal(t, "The user denied your request", stripeErr.OAuthErrorDescription)
+}
+
+func TestFlowCallbackWithInvalidState(t *testing.T) {
+	flow := newTestFlow(nil)
+	state, cookie := startFlow(t, flow)
+
+	// Tampered state
+	_, err := callback(flow, url.Values{"code": {"ac_123"}, "state": {"x" + state}}, cookie)
+	assert.Equal(t, ErrInvalidState, err)
+
+	// Missing cookie
+	_, err = callback(flow, url.Values{"code": {"ac_123"}, "state": {state}}, nil)
+	assert.Equal(t, ErrInvalidState, err)
+
+	// State issued to another browser
+	_, otherCookie := startFlow(t, flow)
+	_, err = callback(flow, url.Values{"code": {"ac_123"}, "state": {state}}, otherCookie)
+	assert.Equal(t, ErrInvalidState, err)
+}
+
+func TestFlowCallbackWithExpiredState(t *testing.T) {
+	flow := newTestFlow(nil)
+	state, cookie := startFlow(t, flow)
+
+	flow.now = func() time.Time { return time.Now().Add(DefaultStateTTL + time.Minute) }
+
+	_, err := callback(flow, url.Values{"code": {"ac_123"}, "state": {state}}, cookie)
+	assert.Equal(t, ErrStateExpired, err)
+}
+
+func TestFlowCallbackWithMissingCode(t *testing.T) {
+	flow := newTestFlow(nil)
+	state, cookie := startFlow(t, flow)
+
+	_, err := callback(flow, url.Values{"state": {state}}, cookie)
+	assert.Equal(t, ErrMissingCode, err)
+}
+
+func TestFlowRefresh(t *testing.T) {
+	flow := newTestFlow(func(req *http.Request) *http.Response {
+		buf := new(bytes.Buffer)
+		buf.ReadFrom(req.Body)
+		reqBody := buf.String()
+
+		assert.Contains(t, reqBody, "grant_type=refresh_token")
+		assert.Contains(t, reqBody, "refresh_token=rt_123")
+
+		return &http.Response{
+			StatusCode: 200,
+			Body:       ioutil.NopCloser(bytes.NewBufferString(`{"refresh_token":"rt_456"}`)),
+			Header:     make(http.Header),
+		}
+	})
+
+	token, err := flow.Refresh("rt_123")
+	assert.NoError(t, err)
+	assert.Equal(t, "rt_456", token.RefreshToken)
+}
+
+func TestFlowDeauthorize(t *testing.T) {
+	flow := newTestFlow(func(req *http.Request) *http.Response {
+		buf := new(bytes.Buffer)
+		buf.ReadFrom(req.Body)
+		reqBody := buf.String()
+
+		assert.Contains(t, req.URL.String(), "https://localhost:12113/oauth/deauthorize")
+		assert.Contains(t, reqBody, "client_id=ca_123")
+		assert.Contains(t, reqBody, "stripe_user_id=acct_123")
+
+		return &http.Response{
+			StatusCode: 200,
+			Body:       ioutil.NopCloser(bytes.NewBufferString(`{"stripe_user_id":"acct_123"}`)),
+			Header:     make(http.Header),
+		}
+	})
+
+	deauthorization, err := flow.Deauthorize("acct_123")
+	assert.NoError(t, err)
+	assert.Equal(t, "acct_123", deauthorization.StripeUserID)
+}
@@ -54,6 +54,9 @@ type OrderItemParent struct {
 	ID   string              `json:"id"`
 	SKU  *SKU                `json:"-"`
 	Type OrderItemParentType `json:"object"`
+
+	Extra    map[string]json.RawMessage `json:"-"`
+	expanded bool
 }
 
 // OrderParams is the set of parameters that can be used when creating an order.
@@ -107,6 +110,8 @@ type ShippingMethod struct {
 	Currency         Currency          `json:"currency"`
 	DeliveryEstimate *DeliveryEstimate `json:"delivery_estimate"`
 	Description      string            `json:"description"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // DeliveryEstimate represent the properties available for a shipping method's
@@ -144,6 +149,9 @@ type Order struct {
 	StatusTransitions      StatusTransitions `json:"status_transitions"`
 	Updated                int64             `json:"updated"`
 	UpstreamID             string            `json:"upstream_id"`
+
+	Extra    map[string]json.RawMessage `json:"-"`
+	expanded bool
 }
 
 // OrderList is a list of orders as retrieved from a list endpoint.
@@ -238,6 +246,7 @@ func (p *OrderItemParent) UnmarshalJSON(data []byte) error {
 
 	var err error
 	*p = OrderItemParent(v)
+	p.expanded = true
 
 	switch p.Type {
 	case OrderItemParentTypeSKU:
@@ -268,5 +277,6 @@ func (o *Order) UnmarshalJSON(data []byte) error {
 	}
 
 	*o = Order(v)
+	o.expanded = true
 	return nil
 }
@@ -20,6 +20,9 @@ type OrderReturn struct {
 	Livemode bool         `json:"livemode"`
 	Order    *Order       `json:"order"`
 	Refund   *Refund      `json:"refund"`
+
+	Extra    map[string]json.RawMessage `json:"-"`
+	expanded bool
 }
 
 // OrderReturnList is a list of order returns as retrieved from a list endpoint.
@@ -52,5 +55,6 @@ func (r *OrderReturn) UnmarshalJSON(data []byte) error {
 	}
 
 	*r = OrderReturn(v)
+	r.expanded = true
 	return nil
 }
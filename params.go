@@ -1,10 +1,12 @@
+//go:generate go run scripts/generate_enum_values/main.go
+//go:generate go run scripts/generate_expand_paths/main.go
+//go:generate go run scripts/generate_form_encoders/main.go
+//go:generate go run scripts/generate_timestamps/main.go
+
 package stripe
 
 import (
 	"context"
-	"crypto/rand"
-	"encoding/base64"
-	"fmt"
 	"net/http"
 	"net/url"
 	"time"
@@ -31,6 +33,10 @@ const (
 // AppendTo implementation.
 type ExtraValues struct {
 	url.Values `form:"-" json:"-"` // See custom AppendTo implementation
+
+	// nulls are the key parts of parameters that are explicitly set to null.
+	// See Params.Unset.
+	nulls [][]string `form:"-" json:"-"`
 }
 
 // AppendTo implements custom form encoding for extra parameter values.
@@ -40,6 +46,10 @@ func (v ExtraValues) AppendTo(body *form.Values, keyParts []string) {
 			body.Add(form.FormatKey(append(keyParts, k)), v)
 		}
 	}
+
+	for _, nullKeyParts := range v.nulls {
+		body.SetNull(form.FormatKey(append(keyParts, nullKeyParts...)))
+	}
 }
 
 // Filters is a structure that contains a collection of filters for list-related APIs.
@@ -113,6 +123,14 @@ func (p *ListParams) AddExpand(f string) {
 	p.Expand = append(p.Expand, &f)
 }
 
+// AddExpandPaths appends new fields to expand, from typed paths like
+// ChargeExpand.Customer.
+func (p *ListParams) AddExpandPaths(paths ...Expansion) {
+	for _, path := range paths {
+		p.AddExpand(string(path.Path()))
+	}
+}
+
 // GetListParams returns a ListParams struct (itself). It exists because any
 // structs that embed ListParams will inherit it, and thus implement the
 // ListParamsContainer interface.
@@ -184,6 +202,14 @@ func (p *Params) AddExpand(f string) {
 	p.Expand = append(p.Expand, &f)
 }
 
+// AddExpandPaths appends new fields to expand, from typed paths like
+// ChargeExpand.Customer.
+func (p *Params) AddExpandPaths(paths ...Expansion) {
+	for _, path := range paths {
+		p.AddExpand(string(path.Path()))
+	}
+}
+
 // AddExtra adds a new arbitrary key-value pair to the request data
 func (p *Params) AddExtra(key, value string) {
 	if p.Extra == nil {
@@ -202,6 +228,22 @@ func (p *Params) AddMetadata(key, value string) {
 	p.Metadata[key] = value
 }
 
+// IsUnset returns true if the parameter with the given key parts has been
+// marked as explicitly null with Unset.
+func (p *Params) IsUnset(keyParts ...string) bool {
+	if p.Extra == nil {
+		return false
+	}
+
+	key := form.FormatKey(keyParts)
+	for _, nullKeyParts := range p.Extra.nulls {
+		if form.FormatKey(nullKeyParts) == key {
+			return true
+		}
+	}
+	return false
+}
+
 // GetParams returns a Params struct (itself). It exists because any structs
 // that embed Params will inherit it, and thus implement the ParamsContainer
 // interface.
@@ -219,6 +261,28 @@ func (p *Params) SetStripeAccount(val string) {
 	p.StripeAccount = &val
 }
 
+// Unset marks a parameter as explicitly null, which is how the API expects a
+// field to be cleared or a metadata key to be deleted. The parameter is
+// identified by its key parts as they appear in the request, so for example:
+//
+//	params.Unset("cancel_at")
+//	params.Unset("metadata", "order_id")
+//
+// A null takes precedence over any value set for the same parameter (or
+// nested under it) on the params struct, and is encoded as an empty value
+// (`cancel_at=`).
+func (p *Params) Unset(keyParts ...string) {
+	if len(keyParts) < 1 {
+		panic("Unset requires at least one key part")
+	}
+
+	if p.Extra == nil {
+		p.Extra = &ExtraValues{Values: make(url.Values)}
+	}
+
+	p.Extra.nulls = append(p.Extra.nulls, append([]string(nil), keyParts...))
+}
+
 // ParamsContainer is a general interface for which all parameter structs
 // should comply. They achieve this by embedding a Params struct and inheriting
 // its implementation of this interface.
@@ -251,11 +315,33 @@ type RangeQueryParams struct {
 
 // NewIdempotencyKey generates a new idempotency key that
 // can be used on a request.
+//
+// Keys are made of the time from DefaultClock and random bytes from
+// DefaultRand. See DeterministicKeyGenerator for keys which don't change from
+// one run to the next.
 func NewIdempotencyKey() string {
-	now := time.Now().UnixNano()
-	buf := make([]byte, 4)
-	rand.Read(buf)
-	return fmt.Sprintf("%v_%v", now, base64.URLEncoding.EncodeToString(buf)[:6])
+	return newIdempotencyKey(DefaultClock, DefaultRand)
+}
+
+// NewRangeQueryParams returns parameters filtering timestamps to those from
+// start, inclusive, until end, exclusive. Either bound can be the zero time
+// to leave the range open on that side, in which case it isn't sent, and nil
+// is returned when neither is set so that no filter is applied at all.
+//
+// Bounds are rounded up to the second, like the timestamps of the API.
+func NewRangeQueryParams(start, end time.Time) *RangeQueryParams {
+	if start.IsZero() && end.IsZero() {
+		return nil
+	}
+
+	p := &RangeQueryParams{}
+	if !start.IsZero() {
+		p.GreaterThanOrEqual = unixCeil(start)
+	}
+	if !end.IsZero() {
+		p.LesserThan = unixCeil(end)
+	}
+	return p
 }
 
 //
@@ -267,3 +353,27 @@ func NewIdempotencyKey() string {
 type filter struct {
 	Key, Op, Val string
 }
+
+//
+// Private functions
+//
+
+// takeFormValue removes one instance of the given value for the key made of
+// keyParts from body, and returns true if there was one. It's used by custom
+// DecodeFrom implementations to consume the special values added by their
+// AppendTo counterparts, like `now` for timestamps.
+func takeFormValue(body *form.Values, keyParts []string, val string) bool {
+	key := form.FormatKey(keyParts)
+	vals := body.Get(key)
+	body.Del(key)
+
+	found := false
+	for _, v := range vals {
+		if v == val && !found {
+			found = true
+			continue
+		}
+		body.Add(key, v)
+	}
+	return found
+}
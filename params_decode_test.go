@@ -0,0 +1,503 @@
+package stripe
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+	"github.com/stripe/stripe-go/form"
+)
+
+// allParams contains an instance of every params type in the package so that
+// the form encoding of all of them can be checked to round trip.
+var allParams = []interface{}{
+	&AccountAddressParams{},
+	&AccountBusinessProfileParams{},
+	&AccountCompanyParams{},
+	&AccountCompanyVerificationDocumentParams{},
+	&AccountCompanyVerificationParams{},
+	&AccountDeclineSettingsParams{},
+	&AccountExternalAccountParams{},
+	&AccountLinkParams{},
+	&AccountListParams{},
+	&AccountParams{},
+	&AccountRejectParams{},
+	&AccountSettingsBrandingParams{},
+	&AccountSettingsCardPaymentsParams{},
+	&AccountSettingsDashboardParams{},
+	&AccountSettingsParams{},
+	&AccountSettingsPaymentsParams{},
+	&AccountSettingsPayoutsParams{},
+	&AccountTOSAcceptanceParams{},
+	&AddressParams{},
+	&ApplePayDomainListParams{},
+	&ApplePayDomainParams{},
+	&ApplicationFeeListParams{},
+	&ApplicationFeeParams{},
+	&AuthorizationControlsParams{},
+	&AuthorizeURLParams{},
+	&BalanceParams{},
+	&BalanceTransactionListParams{},
+	&BalanceTransactionParams{},
+	&BankAccountListParams{},
+	&BankAccountParams{},
+	&BillingDetailsParams{},
+	&BitcoinReceiverListParams{},
+	&BitcoinTransactionListParams{},
+	&CapabilityListParams{},
+	&CapabilityParams{},
+	&CaptureParams{},
+	&CardListParams{},
+	&CardParams{},
+	&ChargeLevel3LineItemsParams{},
+	&ChargeLevel3Params{},
+	&ChargeListParams{},
+	&ChargeParams{},
+	&ChargeTransferDataParams{},
+	&CheckoutSessionLineItemParams{},
+	&CheckoutSessionParams{},
+	&CheckoutSessionPaymentIntentDataParams{},
+	&CheckoutSessionPaymentIntentDataTransferDataParams{},
+	&CheckoutSessionSetupIntentDataParams{},
+	&CheckoutSessionSubscriptionDataItemsParams{},
+	&CheckoutSessionSubscriptionDataParams{},
+	&CountrySpecListParams{},
+	&CountrySpecParams{},
+	&CouponListParams{},
+	&CouponParams{},
+	&CreditNoteLineItemListParams{},
+	&CreditNoteLineItemListPreviewParams{},
+	&CreditNoteLineParams{},
+	&CreditNoteListParams{},
+	&CreditNoteParams{},
+	&CreditNotePreviewParams{},
+	&CreditNoteVoidParams{},
+	&CustomerBalanceTransactionListParams{},
+	&CustomerBalanceTransactionParams{},
+	&CustomerInvoiceCustomFieldParams{},
+	&CustomerInvoiceSettingsParams{},
+	&CustomerListParams{},
+	&CustomerParams{},
+	&CustomerShippingDetailsParams{},
+	&CustomerSourceParams{},
+	&CustomerTaxIDDataParams{},
+	&DOBParams{},
+	&DeauthorizeParams{},
+	&DestinationParams{},
+	&DiscountParams{},
+	&DisputeEvidenceParams{},
+	&DisputeListParams{},
+	&DisputeParams{},
+	&EphemeralKeyParams{},
+	&EventListParams{},
+	&EventParams{},
+	&ExchangeRateListParams{},
+	&ExchangeRateParams{},
+	&FeeRefundListParams{},
+	&FeeRefundParams{},
+	&FileFileLinkDataParams{},
+	&FileLinkListParams{},
+	&FileLinkParams{},
+	&FileListParams{},
+	&FileParams{},
+	&FraudDetailsParams{},
+	&InventoryParams{},
+	&InvoiceCustomFieldParams{},
+	&InvoiceFinalizeParams{},
+	&InvoiceItemListParams{},
+	&InvoiceItemParams{},
+	&InvoiceItemPeriodParams{},
+	&InvoiceLineListParams{},
+	&InvoiceListParams{},
+	&InvoiceMarkUncollectibleParams{},
+	&InvoiceParams{},
+	&InvoicePayParams{},
+	&InvoiceSendParams{},
+	&InvoiceTransferDataParams{},
+	&InvoiceUpcomingInvoiceItemParams{},
+	&InvoiceUpcomingInvoiceItemPeriodParams{},
+	&InvoiceVoidParams{},
+	&IssuingAuthorizationControlsSpendingLimitsParams{},
+	&IssuingAuthorizationListParams{},
+	&IssuingAuthorizationParams{},
+	&IssuingBillingParams{},
+	&IssuingCardListParams{},
+	&IssuingCardParams{},
+	&IssuingCardShippingParams{},
+	&IssuingCardholderCompanyParams{},
+	&IssuingCardholderIndividualDOBParams{},
+	&IssuingCardholderIndividualParams{},
+	&IssuingCardholderIndividualVerificationDocumentParams{},
+	&IssuingCardholderIndividualVerificationParams{},
+	&IssuingCardholderListParams{},
+	&IssuingCardholderParams{},
+	&IssuingDisputeEvidenceFraudulentParams{},
+	&IssuingDisputeEvidenceOtherParams{},
+	&IssuingDisputeEvidenceParams{},
+	&IssuingDisputeListParams{},
+	&IssuingDisputeParams{},
+	&IssuingTransactionListParams{},
+	&IssuingTransactionParams{},
+	&ListParams{},
+	&LoginLinkParams{},
+	&MandateParams{},
+	&OAuthStripeUserParams{},
+	&OAuthTokenParams{},
+	&OrderItemParams{},
+	&OrderListParams{},
+	&OrderParams{},
+	&OrderPayParams{},
+	&OrderReturnListParams{},
+	&OrderReturnParams{},
+	&OrderUpdateParams{},
+	&OrderUpdateShippingParams{},
+	&PIIParams{},
+	&PackageDimensionsParams{},
+	&Params{},
+	&PaymentIntentCancelParams{},
+	&PaymentIntentCaptureParams{},
+	&PaymentIntentConfirmParams{},
+	&PaymentIntentListParams{},
+	&PaymentIntentMandateDataCustomerAcceptanceOfflineParams{},
+	&PaymentIntentMandateDataCustomerAcceptanceOnlineParams{},
+	&PaymentIntentMandateDataCustomerAcceptanceParams{},
+	&PaymentIntentMandateDataParams{},
+	&PaymentIntentParams{},
+	&PaymentIntentPaymentMethodOptionsCardInstallmentsParams{},
+	&PaymentIntentPaymentMethodOptionsCardInstallmentsPlanParams{},
+	&PaymentIntentPaymentMethodOptionsCardParams{},
+	&PaymentIntentPaymentMethodOptionsParams{},
+	&PaymentIntentTransferDataParams{},
+	&PaymentMethodAUBECSDebitParams{},
+	&PaymentMethodAttachParams{},
+	&PaymentMethodCardParams{},
+	&PaymentMethodDetachParams{},
+	&PaymentMethodFPXParams{},
+	&PaymentMethodIdealParams{},
+	&PaymentMethodListParams{},
+	&PaymentMethodParams{},
+	&PaymentMethodSepaDebitParams{},
+	&PayoutListParams{},
+	&PayoutParams{},
+	&PayoutScheduleParams{},
+	&PersonListParams{},
+	&PersonParams{},
+	&PersonVerificationDocumentParams{},
+	&PersonVerificationParams{},
+	&PlanListParams{},
+	&PlanParams{},
+	&PlanProductParams{},
+	&PlanTierParams{},
+	&PlanTransformUsageParams{},
+	&ProductListParams{},
+	&ProductParams{},
+	&RadarEarlyFraudWarningListParams{},
+	&RadarEarlyFraudWarningParams{},
+	&RadarValueListItemListParams{},
+	&RadarValueListItemParams{},
+	&RadarValueListListParams{},
+	&RadarValueListParams{},
+	&RangeQueryParams{},
+	&RecipientListParams{},
+	&RecipientParams{},
+	&RedirectParams{},
+	&RefundListParams{},
+	&RefundParams{},
+	&RelationshipListParams{},
+	&RelationshipParams{},
+	&ReportRunListParams{},
+	&ReportRunParametersParams{},
+	&ReportRunParams{},
+	&ReportTypeListParams{},
+	&ReportTypeParams{},
+	&ReversalListParams{},
+	&ReversalParams{},
+	&ReviewApproveParams{},
+	&ReviewListParams{},
+	&ReviewParams{},
+	&SKUListParams{},
+	&SKUParams{},
+	&SetupIntentCancelParams{},
+	&SetupIntentConfirmParams{},
+	&SetupIntentListParams{},
+	&SetupIntentMandateDataCustomerAcceptanceOfflineParams{},
+	&SetupIntentMandateDataCustomerAcceptanceOnlineParams{},
+	&SetupIntentMandateDataCustomerAcceptanceParams{},
+	&SetupIntentMandateDataParams{},
+	&SetupIntentParams{},
+	&SetupIntentPaymentMethodOptionsCardParams{},
+	&SetupIntentPaymentMethodOptionsParams{},
+	&SetupIntentSingleUseParams{},
+	&ShippingDetailsParams{},
+	&ShippingParams{},
+	&SigmaScheduledQueryRunListParams{},
+	&SigmaScheduledQueryRunParams{},
+	&SourceListParams{},
+	&SourceMandateAcceptanceOfflineParams{},
+	&SourceMandateAcceptanceOnlineParams{},
+	&SourceMandateAcceptanceParams{},
+	&SourceMandateParams{},
+	&SourceObjectDetachParams{},
+	&SourceObjectParams{},
+	&SourceOrderItemsParams{},
+	&SourceOrderParams{},
+	&SourceOwnerParams{},
+	&SourceParams{},
+	&SourceReceiverParams{},
+	&SourceTransactionListParams{},
+	&SourceVerifyParams{},
+	&StatusTransitionsFilterParams{},
+	&SubscriptionBillingThresholdsParams{},
+	&SubscriptionCancelParams{},
+	&SubscriptionItemBillingThresholdsParams{},
+	&SubscriptionItemListParams{},
+	&SubscriptionItemParams{},
+	&SubscriptionItemsParams{},
+	&SubscriptionListParams{},
+	&SubscriptionParams{},
+	&SubscriptionPendingInvoiceItemIntervalParams{},
+	&SubscriptionScheduleCancelParams{},
+	&SubscriptionScheduleDefaultSettingsParams{},
+	&SubscriptionScheduleInvoiceSettingsParams{},
+	&SubscriptionScheduleListParams{},
+	&SubscriptionScheduleParams{},
+	&SubscriptionSchedulePhaseItemParams{},
+	&SubscriptionSchedulePhaseParams{},
+	&SubscriptionScheduleReleaseParams{},
+	&SubscriptionTransferDataParams{},
+	&TaxIDListParams{},
+	&TaxIDParams{},
+	&TaxRateListParams{},
+	&TaxRateParams{},
+	&TaxRatePercentageRangeQueryParams{},
+	&TerminalConnectionTokenParams{},
+	&TerminalLocationListParams{},
+	&TerminalLocationParams{},
+	&TerminalReaderGetParams{},
+	&TerminalReaderListParams{},
+	&TerminalReaderParams{},
+	&ThreeDSecureParams{},
+	&TokenParams{},
+	&TopupListParams{},
+	&TopupParams{},
+	&TransferListParams{},
+	&TransferParams{},
+	&UsageRecordParams{},
+	&UsageRecordSummaryListParams{},
+	&WebhookEndpointListParams{},
+	&WebhookEndpointParams{},
+}
+
+// TestAllParams makes sure that allParams is kept up to date as params types
+// are added.
+func TestAllParams(t *testing.T) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, ".", func(info os.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go")
+	}, 0)
+	assert.NoError(t, err)
+
+	var want []string
+	for _, file := range pkgs["stripe"].Files {
+		for _, decl := range file.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range genDecl.Specs {
+				typeSpec := spec.(*ast.TypeSpec)
+				if _, ok := typeSpec.Type.(*ast.StructType); ok && strings.HasSuffix(typeSpec.Name.Name, "Params") {
+					want = append(want, typeSpec.Name.Name)
+				}
+			}
+		}
+	}
+	sort.Strings(want)
+
+	var got []string
+	for _, params := range allParams {
+		got = append(got, reflect.TypeOf(params).Elem().Name())
+	}
+	sort.Strings(got)
+
+	assert.Equal(t, want, got)
+}
+
+func TestParamsFormRoundTrip(t *testing.T) {
+	// Params are encoded under a prefix so that the few types that are only
+	// valid when nested in another (like AccountExternalAccountParams) can be
+	// checked as well.
+	keyParts := []string{"params"}
+
+	for _, params := range allParams {
+		// Files are uploaded as multipart forms instead
+		if _, ok := params.(*FileParams); ok {
+			continue
+		}
+
+		typ := reflect.TypeOf(params).Elem()
+
+		t.Run(typ.Name(), func(t *testing.T) {
+			original := reflect.New(typ)
+			fillParams(original.Elem(), 0)
+
+			body := &form.Values{}
+			form.AppendToPrefixed(body, original.Interface(), keyParts)
+
+			encoded := &form.Values{}
+			form.AppendToPrefixed(encoded, original.Interface(), keyParts)
+
+			decoded := reflect.New(typ)
+			assert.NoError(t, form.DecodePrefixed(encoded, decoded.Interface(), keyParts))
+
+			roundTripped := &form.Values{}
+			form.AppendToPrefixed(roundTripped, decoded.Interface(), keyParts)
+
+			assert.Equal(t, body.ToValues(), roundTripped.ToValues())
+		})
+	}
+}
+
+func TestParamsFormDecode_CustomDecoders(t *testing.T) {
+	testCases := []struct {
+		params  interface{}
+		decoded interface{}
+	}{
+		{
+			&AccountParams{ExternalAccount: &AccountExternalAccountParams{Token: String("btok_123")}},
+			&AccountParams{},
+		},
+		{
+			&AccountParams{ExternalAccount: &AccountExternalAccountParams{AccountNumber: String("000123456789")}},
+			&AccountParams{},
+		},
+		{
+			&AccountSettingsPayoutsParams{Schedule: &PayoutScheduleParams{DelayDaysMinimum: Bool(true)}},
+			&AccountSettingsPayoutsParams{},
+		},
+		{
+			&InvoiceParams{SubscriptionBillingCycleAnchorUnchanged: Bool(true)},
+			&InvoiceParams{},
+		},
+		{
+			&PlanParams{Tiers: []*PlanTierParams{
+				{UnitAmount: Int64(500), UpTo: Int64(10)},
+				{UnitAmount: Int64(400), UpToInf: Bool(true)},
+			}},
+			&PlanParams{},
+		},
+		{
+			&RecipientParams{BankAccount: &BankAccountParams{Token: String("btok_123")}},
+			&RecipientParams{},
+		},
+		{
+			&RecipientParams{BankAccount: &BankAccountParams{AccountNumber: String("000123456789")}},
+			&RecipientParams{},
+		},
+		{
+			&SourceObjectParams{Type: String("ach_credit_transfer"), TypeData: map[string]string{"refund_account_number": "000123456789"}},
+			&SourceObjectParams{},
+		},
+		{
+			&SubscriptionParams{BillingCycleAnchorNow: Bool(true), TrialEndNow: Bool(true)},
+			&SubscriptionParams{},
+		},
+		{
+			&SubscriptionScheduleParams{StartDateNow: Bool(true)},
+			&SubscriptionScheduleParams{},
+		},
+	}
+
+	for _, tc := range testCases {
+		body := &form.Values{}
+		form.AppendTo(body, tc.params)
+
+		assert.NoError(t, form.Decode(body, tc.decoded))
+		assert.Equal(t, tc.params, tc.decoded)
+	}
+}
+
+func TestParamsFormDecode_CardSource(t *testing.T) {
+	params := &CustomerSourceParams{
+		Source: &SourceParams{Card: &CardParams{
+			CVC:      String("123"),
+			ExpMonth: String("10"),
+			ExpYear:  String("2030"),
+			Number:   String("4242424242424242"),
+		}},
+	}
+
+	body := &form.Values{}
+	form.AppendTo(body, params)
+
+	decoded := &CustomerSourceParams{}
+	assert.NoError(t, form.Decode(body, decoded))
+	assert.Equal(t, params.Source.Card, decoded.Source.Card)
+}
+
+//
+// Private functions
+//
+
+// fillParams sets every form encoded field of v to a non-zero value.
+func fillParams(v reflect.Value, depth int) {
+	if depth > 10 {
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			fillParams(v.Index(i), depth+1)
+		}
+
+	case reflect.Bool:
+		v.SetBool(true)
+
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(1.5)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(1)
+
+	case reflect.Map:
+		v.Set(reflect.MakeMap(v.Type()))
+		// A single key keeps the encoding deterministic so that it can be
+		// compared exactly.
+		elem := reflect.New(v.Type().Elem()).Elem()
+		fillParams(elem, depth+1)
+		v.SetMapIndex(reflect.ValueOf("key").Convert(v.Type().Key()), elem)
+
+	case reflect.Ptr:
+		v.Set(reflect.New(v.Type().Elem()))
+		fillParams(v.Elem(), depth+1)
+
+	case reflect.Slice:
+		v.Set(reflect.MakeSlice(v.Type(), 2, 2))
+		for i := 0; i < v.Len(); i++ {
+			fillParams(v.Index(i), depth+1)
+		}
+
+	case reflect.String:
+		v.SetString("value")
+
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			name := strings.Split(field.Tag.Get("form"), ",")[0]
+			if name == "" || name == "-" || field.PkgPath != "" {
+				continue
+			}
+			fillParams(v.Field(i), depth+1)
+		}
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v.SetUint(1)
+	}
+}
@@ -0,0 +1,6089 @@
+// Code generated by scripts/generate_form_encoders. DO NOT EDIT.
+
+package stripe
+
+import (
+	"reflect"
+	"strconv"
+
+	"github.com/stripe/stripe-go/form"
+)
+
+func init() {
+	for t, f := range formEncoders {
+		form.RegisterEncoder(t, f)
+	}
+}
+
+// formEncoders are the generated encoders of each type, which are used by
+// the form package in place of reflection.
+var formEncoders = map[reflect.Type]form.EncoderFunc{
+	reflect.TypeOf((*AccountAddressParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*AccountAddressParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*AccountBusinessProfileParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*AccountBusinessProfileParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*AccountCompanyParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*AccountCompanyParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*AccountCompanyVerificationDocumentParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*AccountCompanyVerificationDocumentParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*AccountCompanyVerificationParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*AccountCompanyVerificationParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*AccountDeclineSettingsParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*AccountDeclineSettingsParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*AccountExternalAccountParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*AccountExternalAccountParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*AccountLinkParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*AccountLinkParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*AccountListParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*AccountListParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*AccountParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*AccountParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*AccountRejectParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*AccountRejectParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*AccountSettingsBrandingParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*AccountSettingsBrandingParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*AccountSettingsCardPaymentsParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*AccountSettingsCardPaymentsParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*AccountSettingsDashboardParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*AccountSettingsDashboardParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*AccountSettingsParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*AccountSettingsParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*AccountSettingsPaymentsParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*AccountSettingsPaymentsParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*AccountSettingsPayoutsParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*AccountSettingsPayoutsParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*AccountTOSAcceptanceParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*AccountTOSAcceptanceParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*AddressParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*AddressParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*ApplePayDomainListParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*ApplePayDomainListParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*ApplePayDomainParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*ApplePayDomainParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*ApplicationFeeListParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*ApplicationFeeListParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*ApplicationFeeParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*ApplicationFeeParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*AuthorizationControlsParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*AuthorizationControlsParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*AuthorizeURLParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*AuthorizeURLParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*BalanceParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*BalanceParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*BalanceTransactionListParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*BalanceTransactionListParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*BalanceTransactionParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*BalanceTransactionParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*BankAccountListParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*BankAccountListParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*BankAccountParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*BankAccountParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*BillingDetailsParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*BillingDetailsParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*BitcoinReceiverListParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*BitcoinReceiverListParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*BitcoinTransactionListParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*BitcoinTransactionListParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*CapabilityListParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*CapabilityListParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*CapabilityParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*CapabilityParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*CaptureParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*CaptureParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*CardListParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*CardListParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*CardParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*CardParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*ChargeLevel3LineItemsParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*ChargeLevel3LineItemsParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*ChargeLevel3Params)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*ChargeLevel3Params).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*ChargeListParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*ChargeListParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*ChargeParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*ChargeParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*ChargeTransferDataParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*ChargeTransferDataParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*CheckoutSessionLineItemParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*CheckoutSessionLineItemParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*CheckoutSessionParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*CheckoutSessionParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*CheckoutSessionPaymentIntentDataParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*CheckoutSessionPaymentIntentDataParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*CheckoutSessionPaymentIntentDataTransferDataParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*CheckoutSessionPaymentIntentDataTransferDataParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*CheckoutSessionSetupIntentDataParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*CheckoutSessionSetupIntentDataParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*CheckoutSessionSubscriptionDataItemsParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*CheckoutSessionSubscriptionDataItemsParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*CheckoutSessionSubscriptionDataParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*CheckoutSessionSubscriptionDataParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*CountrySpecListParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*CountrySpecListParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*CountrySpecParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*CountrySpecParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*CouponListParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*CouponListParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*CouponParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*CouponParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*CreditNoteLineItemListParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*CreditNoteLineItemListParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*CreditNoteLineItemListPreviewParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*CreditNoteLineItemListPreviewParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*CreditNoteLineParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*CreditNoteLineParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*CreditNoteListParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*CreditNoteListParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*CreditNoteParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*CreditNoteParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*CreditNotePreviewParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*CreditNotePreviewParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*CreditNoteVoidParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*CreditNoteVoidParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*CustomerBalanceTransactionListParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*CustomerBalanceTransactionListParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*CustomerBalanceTransactionParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*CustomerBalanceTransactionParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*CustomerInvoiceCustomFieldParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*CustomerInvoiceCustomFieldParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*CustomerInvoiceSettingsParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*CustomerInvoiceSettingsParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*CustomerListParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*CustomerListParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*CustomerParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*CustomerParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*CustomerShippingDetailsParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*CustomerShippingDetailsParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*CustomerSourceParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*CustomerSourceParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*CustomerTaxIDDataParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*CustomerTaxIDDataParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*DOBParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*DOBParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*DeauthorizeParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*DeauthorizeParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*DestinationParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*DestinationParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*DiscountParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*DiscountParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*DisputeEvidenceParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*DisputeEvidenceParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*DisputeListParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*DisputeListParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*DisputeParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*DisputeParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*EphemeralKeyParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*EphemeralKeyParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*EventListParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*EventListParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*EventParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*EventParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*ExchangeRateListParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*ExchangeRateListParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*ExchangeRateParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*ExchangeRateParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*ExtraValues)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*ExtraValues).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*FeeRefundListParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*FeeRefundListParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*FeeRefundParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*FeeRefundParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*FileFileLinkDataParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*FileFileLinkDataParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*FileLinkListParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*FileLinkListParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*FileLinkParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*FileLinkParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*FileListParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*FileListParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*Filters)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*Filters).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*FraudDetailsParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*FraudDetailsParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*InventoryParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*InventoryParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*InvoiceCustomFieldParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*InvoiceCustomFieldParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*InvoiceFinalizeParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*InvoiceFinalizeParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*InvoiceItemListParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*InvoiceItemListParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*InvoiceItemParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*InvoiceItemParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*InvoiceItemPeriodParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*InvoiceItemPeriodParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*InvoiceLineListParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*InvoiceLineListParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*InvoiceListParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*InvoiceListParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*InvoiceMarkUncollectibleParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*InvoiceMarkUncollectibleParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*InvoiceParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*InvoiceParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*InvoicePayParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*InvoicePayParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*InvoiceSendParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*InvoiceSendParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*InvoiceTransferDataParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*InvoiceTransferDataParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*InvoiceUpcomingInvoiceItemParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*InvoiceUpcomingInvoiceItemParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*InvoiceUpcomingInvoiceItemPeriodParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*InvoiceUpcomingInvoiceItemPeriodParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*InvoiceVoidParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*InvoiceVoidParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*IssuingAuthorizationControlsSpendingLimitsParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*IssuingAuthorizationControlsSpendingLimitsParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*IssuingAuthorizationListParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*IssuingAuthorizationListParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*IssuingAuthorizationParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*IssuingAuthorizationParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*IssuingBillingParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*IssuingBillingParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*IssuingCardListParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*IssuingCardListParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*IssuingCardParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*IssuingCardParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*IssuingCardShippingParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*IssuingCardShippingParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*IssuingCardholderCompanyParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*IssuingCardholderCompanyParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*IssuingCardholderIndividualDOBParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*IssuingCardholderIndividualDOBParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*IssuingCardholderIndividualParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*IssuingCardholderIndividualParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*IssuingCardholderIndividualVerificationDocumentParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*IssuingCardholderIndividualVerificationDocumentParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*IssuingCardholderIndividualVerificationParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*IssuingCardholderIndividualVerificationParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*IssuingCardholderListParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*IssuingCardholderListParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*IssuingCardholderParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*IssuingCardholderParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*IssuingDisputeEvidenceFraudulentParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*IssuingDisputeEvidenceFraudulentParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*IssuingDisputeEvidenceOtherParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*IssuingDisputeEvidenceOtherParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*IssuingDisputeEvidenceParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*IssuingDisputeEvidenceParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*IssuingDisputeListParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*IssuingDisputeListParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*IssuingDisputeParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*IssuingDisputeParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*IssuingTransactionListParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*IssuingTransactionListParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*IssuingTransactionParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*IssuingTransactionParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*ListParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*ListParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*LoginLinkParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*LoginLinkParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*MandateParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*MandateParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*OAuthStripeUserParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*OAuthStripeUserParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*OAuthTokenParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*OAuthTokenParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*OrderItemParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*OrderItemParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*OrderListParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*OrderListParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*OrderParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*OrderParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*OrderPayParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*OrderPayParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*OrderReturnListParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*OrderReturnListParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*OrderReturnParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*OrderReturnParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*OrderUpdateParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*OrderUpdateParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*OrderUpdateShippingParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*OrderUpdateShippingParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*PIIParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*PIIParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*PackageDimensionsParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*PackageDimensionsParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*Params)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*Params).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*PaymentIntentCancelParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*PaymentIntentCancelParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*PaymentIntentCaptureParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*PaymentIntentCaptureParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*PaymentIntentConfirmParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*PaymentIntentConfirmParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*PaymentIntentListParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*PaymentIntentListParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*PaymentIntentMandateDataCustomerAcceptanceOfflineParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*PaymentIntentMandateDataCustomerAcceptanceOfflineParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*PaymentIntentMandateDataCustomerAcceptanceOnlineParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*PaymentIntentMandateDataCustomerAcceptanceOnlineParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*PaymentIntentMandateDataCustomerAcceptanceParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*PaymentIntentMandateDataCustomerAcceptanceParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*PaymentIntentMandateDataParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*PaymentIntentMandateDataParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*PaymentIntentParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*PaymentIntentParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*PaymentIntentPaymentMethodOptionsCardInstallmentsParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*PaymentIntentPaymentMethodOptionsCardInstallmentsParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*PaymentIntentPaymentMethodOptionsCardInstallmentsPlanParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*PaymentIntentPaymentMethodOptionsCardInstallmentsPlanParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*PaymentIntentPaymentMethodOptionsCardParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*PaymentIntentPaymentMethodOptionsCardParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*PaymentIntentPaymentMethodOptionsParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*PaymentIntentPaymentMethodOptionsParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*PaymentIntentTransferDataParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*PaymentIntentTransferDataParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*PaymentMethodAUBECSDebitParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*PaymentMethodAUBECSDebitParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*PaymentMethodAttachParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*PaymentMethodAttachParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*PaymentMethodCardParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*PaymentMethodCardParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*PaymentMethodDetachParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*PaymentMethodDetachParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*PaymentMethodFPXParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*PaymentMethodFPXParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*PaymentMethodIdealParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*PaymentMethodIdealParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*PaymentMethodListParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*PaymentMethodListParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*PaymentMethodParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*PaymentMethodParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*PaymentMethodSepaDebitParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*PaymentMethodSepaDebitParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*PayoutListParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*PayoutListParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*PayoutParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*PayoutParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*PayoutScheduleParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*PayoutScheduleParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*PersonListParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*PersonListParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*PersonParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*PersonParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*PersonVerificationDocumentParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*PersonVerificationDocumentParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*PersonVerificationParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*PersonVerificationParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*PlanListParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*PlanListParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*PlanParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*PlanParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*PlanProductParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*PlanProductParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*PlanTierParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*PlanTierParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*PlanTransformUsageParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*PlanTransformUsageParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*ProductListParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*ProductListParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*ProductParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*ProductParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*RadarEarlyFraudWarningListParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*RadarEarlyFraudWarningListParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*RadarEarlyFraudWarningParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*RadarEarlyFraudWarningParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*RadarValueListItemListParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*RadarValueListItemListParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*RadarValueListItemParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*RadarValueListItemParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*RadarValueListListParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*RadarValueListListParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*RadarValueListParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*RadarValueListParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*RangeQueryParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*RangeQueryParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*RecipientListParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*RecipientListParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*RecipientParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*RecipientParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*RedirectParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*RedirectParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*RefundListParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*RefundListParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*RefundParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*RefundParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*RelationshipListParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*RelationshipListParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*RelationshipParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*RelationshipParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*ReportRunListParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*ReportRunListParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*ReportRunParametersParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*ReportRunParametersParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*ReportRunParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*ReportRunParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*ReportTypeListParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*ReportTypeListParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*ReportTypeParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*ReportTypeParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*ReversalListParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*ReversalListParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*ReversalParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*ReversalParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*ReviewApproveParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*ReviewApproveParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*ReviewListParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*ReviewListParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*ReviewParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*ReviewParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*SKUListParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*SKUListParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*SKUParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*SKUParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*SetupIntentCancelParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*SetupIntentCancelParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*SetupIntentConfirmParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*SetupIntentConfirmParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*SetupIntentListParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*SetupIntentListParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*SetupIntentMandateDataCustomerAcceptanceOfflineParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*SetupIntentMandateDataCustomerAcceptanceOfflineParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*SetupIntentMandateDataCustomerAcceptanceOnlineParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*SetupIntentMandateDataCustomerAcceptanceOnlineParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*SetupIntentMandateDataCustomerAcceptanceParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*SetupIntentMandateDataCustomerAcceptanceParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*SetupIntentMandateDataParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*SetupIntentMandateDataParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*SetupIntentParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*SetupIntentParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*SetupIntentPaymentMethodOptionsCardParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*SetupIntentPaymentMethodOptionsCardParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*SetupIntentPaymentMethodOptionsParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*SetupIntentPaymentMethodOptionsParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*SetupIntentSingleUseParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*SetupIntentSingleUseParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*ShippingDetailsParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*ShippingDetailsParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*ShippingParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*ShippingParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*SigmaScheduledQueryRunListParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*SigmaScheduledQueryRunListParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*SigmaScheduledQueryRunParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*SigmaScheduledQueryRunParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*SourceListParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*SourceListParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*SourceMandateAcceptanceOfflineParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*SourceMandateAcceptanceOfflineParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*SourceMandateAcceptanceOnlineParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*SourceMandateAcceptanceOnlineParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*SourceMandateAcceptanceParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*SourceMandateAcceptanceParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*SourceMandateParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*SourceMandateParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*SourceObjectDetachParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*SourceObjectDetachParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*SourceObjectParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*SourceObjectParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*SourceOrderItemsParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*SourceOrderItemsParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*SourceOrderParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*SourceOrderParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*SourceOwnerParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*SourceOwnerParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*SourceParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*SourceParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*SourceReceiverParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*SourceReceiverParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*SourceTransactionListParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*SourceTransactionListParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*SourceVerifyParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*SourceVerifyParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*StatusTransitionsFilterParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*StatusTransitionsFilterParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*SubscriptionBillingThresholdsParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*SubscriptionBillingThresholdsParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*SubscriptionCancelParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*SubscriptionCancelParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*SubscriptionItemBillingThresholdsParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*SubscriptionItemBillingThresholdsParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*SubscriptionItemListParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*SubscriptionItemListParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*SubscriptionItemParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*SubscriptionItemParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*SubscriptionItemsParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*SubscriptionItemsParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*SubscriptionListParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*SubscriptionListParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*SubscriptionParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*SubscriptionParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*SubscriptionPendingInvoiceItemIntervalParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*SubscriptionPendingInvoiceItemIntervalParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*SubscriptionScheduleCancelParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*SubscriptionScheduleCancelParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*SubscriptionScheduleDefaultSettingsParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*SubscriptionScheduleDefaultSettingsParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*SubscriptionScheduleInvoiceSettingsParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*SubscriptionScheduleInvoiceSettingsParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*SubscriptionScheduleListParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*SubscriptionScheduleListParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*SubscriptionScheduleParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*SubscriptionScheduleParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*SubscriptionSchedulePhaseItemParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*SubscriptionSchedulePhaseItemParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*SubscriptionSchedulePhaseParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*SubscriptionSchedulePhaseParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*SubscriptionScheduleReleaseParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*SubscriptionScheduleReleaseParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*SubscriptionTransferDataParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*SubscriptionTransferDataParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*TaxIDListParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*TaxIDListParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*TaxIDParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*TaxIDParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*TaxRateListParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*TaxRateListParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*TaxRateParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*TaxRateParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*TaxRatePercentageRangeQueryParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*TaxRatePercentageRangeQueryParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*TerminalConnectionTokenParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*TerminalConnectionTokenParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*TerminalLocationListParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*TerminalLocationListParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*TerminalLocationParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*TerminalLocationParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*TerminalReaderGetParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*TerminalReaderGetParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*TerminalReaderListParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*TerminalReaderListParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*TerminalReaderParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*TerminalReaderParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*ThreeDSecureParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*ThreeDSecureParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*TokenParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*TokenParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*TopupListParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*TopupListParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*TopupParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*TopupParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*TransferListParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*TransferListParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*TransferParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*TransferParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*UsageRecordParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*UsageRecordParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*UsageRecordSummaryListParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*UsageRecordSummaryListParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*WebhookEndpointListParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*WebhookEndpointListParams).encodeForm(body, keyParts)
+	},
+	reflect.TypeOf((*WebhookEndpointParams)(nil)): func(body *form.Values, v interface{}, keyParts []string) {
+		v.(*WebhookEndpointParams).encodeForm(body, keyParts)
+	},
+}
+
+func (p *AccountAddressParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.City != nil {
+		body.Add(form.FormatKey(append(keyParts, "city")), *p.City)
+	}
+	if p.Country != nil {
+		body.Add(form.FormatKey(append(keyParts, "country")), *p.Country)
+	}
+	if p.Line1 != nil {
+		body.Add(form.FormatKey(append(keyParts, "line1")), *p.Line1)
+	}
+	if p.Line2 != nil {
+		body.Add(form.FormatKey(append(keyParts, "line2")), *p.Line2)
+	}
+	if p.PostalCode != nil {
+		body.Add(form.FormatKey(append(keyParts, "postal_code")), *p.PostalCode)
+	}
+	if p.State != nil {
+		body.Add(form.FormatKey(append(keyParts, "state")), *p.State)
+	}
+	if p.Town != nil {
+		body.Add(form.FormatKey(append(keyParts, "town")), *p.Town)
+	}
+}
+
+func (p *AccountBusinessProfileParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.MCC != nil {
+		body.Add(form.FormatKey(append(keyParts, "mcc")), *p.MCC)
+	}
+	if p.Name != nil {
+		body.Add(form.FormatKey(append(keyParts, "name")), *p.Name)
+	}
+	if p.ProductDescription != nil {
+		body.Add(form.FormatKey(append(keyParts, "product_description")), *p.ProductDescription)
+	}
+	if p.SupportEmail != nil {
+		body.Add(form.FormatKey(append(keyParts, "support_email")), *p.SupportEmail)
+	}
+	if p.SupportPhone != nil {
+		body.Add(form.FormatKey(append(keyParts, "support_phone")), *p.SupportPhone)
+	}
+	if p.SupportURL != nil {
+		body.Add(form.FormatKey(append(keyParts, "support_url")), *p.SupportURL)
+	}
+	if p.URL != nil {
+		body.Add(form.FormatKey(append(keyParts, "url")), *p.URL)
+	}
+}
+
+func (p *AccountCompanyParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Address.encodeForm(body, append(keyParts, "address"))
+	p.AddressKana.encodeForm(body, append(keyParts, "address_kana"))
+	p.AddressKanji.encodeForm(body, append(keyParts, "address_kanji"))
+	if p.DirectorsProvided != nil {
+		body.Add(form.FormatKey(append(keyParts, "directors_provided")), strconv.FormatBool(*p.DirectorsProvided))
+	}
+	if p.ExecutivesProvided != nil {
+		body.Add(form.FormatKey(append(keyParts, "executives_provided")), strconv.FormatBool(*p.ExecutivesProvided))
+	}
+	if p.Name != nil {
+		body.Add(form.FormatKey(append(keyParts, "name")), *p.Name)
+	}
+	if p.NameKana != nil {
+		body.Add(form.FormatKey(append(keyParts, "name_kana")), *p.NameKana)
+	}
+	if p.NameKanji != nil {
+		body.Add(form.FormatKey(append(keyParts, "name_kanji")), *p.NameKanji)
+	}
+	if p.OwnersProvided != nil {
+		body.Add(form.FormatKey(append(keyParts, "owners_provided")), strconv.FormatBool(*p.OwnersProvided))
+	}
+	if p.Structure != nil {
+		body.Add(form.FormatKey(append(keyParts, "structure")), *p.Structure)
+	}
+	if p.Phone != nil {
+		body.Add(form.FormatKey(append(keyParts, "phone")), *p.Phone)
+	}
+	if p.TaxID != nil {
+		body.Add(form.FormatKey(append(keyParts, "tax_id")), *p.TaxID)
+	}
+	if p.TaxIDRegistrar != nil {
+		body.Add(form.FormatKey(append(keyParts, "tax_id_registrar")), *p.TaxIDRegistrar)
+	}
+	if p.VATID != nil {
+		body.Add(form.FormatKey(append(keyParts, "vat_id")), *p.VATID)
+	}
+	p.Verification.encodeForm(body, append(keyParts, "verification"))
+}
+
+func (p *AccountCompanyVerificationDocumentParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.Back != nil {
+		body.Add(form.FormatKey(append(keyParts, "back")), *p.Back)
+	}
+	if p.Front != nil {
+		body.Add(form.FormatKey(append(keyParts, "front")), *p.Front)
+	}
+}
+
+func (p *AccountCompanyVerificationParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Document.encodeForm(body, append(keyParts, "document"))
+}
+
+func (p *AccountDeclineSettingsParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.AVSFailure != nil {
+		body.Add(form.FormatKey(append(keyParts, "avs_failure")), strconv.FormatBool(*p.AVSFailure))
+	}
+	if p.CVCFailure != nil {
+		body.Add(form.FormatKey(append(keyParts, "cvc_failure")), strconv.FormatBool(*p.CVCFailure))
+	}
+}
+
+func (p *AccountExternalAccountParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	if p.AccountNumber != nil {
+		body.Add(form.FormatKey(append(keyParts, "account_number")), *p.AccountNumber)
+	}
+	if p.AccountHolderName != nil {
+		body.Add(form.FormatKey(append(keyParts, "account_holder_name")), *p.AccountHolderName)
+	}
+	if p.AccountHolderType != nil {
+		body.Add(form.FormatKey(append(keyParts, "account_holder_type")), *p.AccountHolderType)
+	}
+	if p.Country != nil {
+		body.Add(form.FormatKey(append(keyParts, "country")), *p.Country)
+	}
+	if p.Currency != nil {
+		body.Add(form.FormatKey(append(keyParts, "currency")), *p.Currency)
+	}
+	if p.RoutingNumber != nil {
+		body.Add(form.FormatKey(append(keyParts, "routing_number")), *p.RoutingNumber)
+	}
+	if p.Token != nil {
+		body.Add(form.FormatKey(append(keyParts, "token")), *p.Token)
+	}
+}
+
+func (p *AccountLinkParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	if p.Account != nil {
+		body.Add(form.FormatKey(append(keyParts, "account")), *p.Account)
+	}
+	if p.Collect != nil {
+		body.Add(form.FormatKey(append(keyParts, "collect")), *p.Collect)
+	}
+	if p.FailureURL != nil {
+		body.Add(form.FormatKey(append(keyParts, "failure_url")), *p.FailureURL)
+	}
+	if p.SuccessURL != nil {
+		body.Add(form.FormatKey(append(keyParts, "success_url")), *p.SuccessURL)
+	}
+	if p.Type != nil {
+		body.Add(form.FormatKey(append(keyParts, "type")), *p.Type)
+	}
+}
+
+func (p *AccountListParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.ListParams.encodeForm(body, keyParts)
+}
+
+func (p *AccountParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	if p.AccountToken != nil {
+		body.Add(form.FormatKey(append(keyParts, "account_token")), *p.AccountToken)
+	}
+	p.BusinessProfile.encodeForm(body, append(keyParts, "business_profile"))
+	if p.BusinessType != nil {
+		body.Add(form.FormatKey(append(keyParts, "business_type")), *p.BusinessType)
+	}
+	p.Company.encodeForm(body, append(keyParts, "company"))
+	if p.Country != nil {
+		body.Add(form.FormatKey(append(keyParts, "country")), *p.Country)
+	}
+	if p.DefaultCurrency != nil {
+		body.Add(form.FormatKey(append(keyParts, "default_currency")), *p.DefaultCurrency)
+	}
+	if p.Email != nil {
+		body.Add(form.FormatKey(append(keyParts, "email")), *p.Email)
+	}
+	p.ExternalAccount.encodeForm(body, append(keyParts, "external_account"))
+	if p.ExternalAccount != nil {
+		p.ExternalAccount.AppendTo(body, append(keyParts, "external_account"))
+	}
+	p.Individual.encodeForm(body, append(keyParts, "individual"))
+	if p.RequestedCapabilities != nil && len(p.RequestedCapabilities) == 0 {
+		body.Add(form.FormatKey(append(keyParts, "requested_capabilities")), "")
+	} else {
+		for i1, v1 := range p.RequestedCapabilities {
+			if v1 != nil {
+				body.Add(form.FormatKey(append(keyParts, "requested_capabilities", strconv.Itoa(i1))), *v1)
+			}
+		}
+	}
+	p.Settings.encodeForm(body, append(keyParts, "settings"))
+	p.TOSAcceptance.encodeForm(body, append(keyParts, "tos_acceptance"))
+	if p.Type != nil {
+		body.Add(form.FormatKey(append(keyParts, "type")), *p.Type)
+	}
+}
+
+func (p *AccountRejectParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	if p.Reason != nil {
+		body.Add(form.FormatKey(append(keyParts, "reason")), *p.Reason)
+	}
+}
+
+func (p *AccountSettingsBrandingParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.Icon != nil {
+		body.Add(form.FormatKey(append(keyParts, "icon")), *p.Icon)
+	}
+	if p.Logo != nil {
+		body.Add(form.FormatKey(append(keyParts, "logo")), *p.Logo)
+	}
+	if p.PrimaryColor != nil {
+		body.Add(form.FormatKey(append(keyParts, "primary_color")), *p.PrimaryColor)
+	}
+}
+
+func (p *AccountSettingsCardPaymentsParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.DeclineOn.encodeForm(body, append(keyParts, "decline_on"))
+	if p.StatementDescriptorPrefix != nil {
+		body.Add(form.FormatKey(append(keyParts, "statement_descriptor_prefix")), *p.StatementDescriptorPrefix)
+	}
+}
+
+func (p *AccountSettingsDashboardParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.DisplayName != nil {
+		body.Add(form.FormatKey(append(keyParts, "display_name")), *p.DisplayName)
+	}
+	if p.Timezone != nil {
+		body.Add(form.FormatKey(append(keyParts, "timezone")), *p.Timezone)
+	}
+}
+
+func (p *AccountSettingsParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Branding.encodeForm(body, append(keyParts, "branding"))
+	p.CardPayments.encodeForm(body, append(keyParts, "card_payments"))
+	p.Dashboard.encodeForm(body, append(keyParts, "dashboard"))
+	p.Payments.encodeForm(body, append(keyParts, "payments"))
+	p.Payouts.encodeForm(body, append(keyParts, "payouts"))
+}
+
+func (p *AccountSettingsPaymentsParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.StatementDescriptor != nil {
+		body.Add(form.FormatKey(append(keyParts, "statement_descriptor")), *p.StatementDescriptor)
+	}
+	if p.StatementDescriptorKana != nil {
+		body.Add(form.FormatKey(append(keyParts, "statement_descriptor_kana")), *p.StatementDescriptorKana)
+	}
+	if p.StatementDescriptorKanji != nil {
+		body.Add(form.FormatKey(append(keyParts, "statement_descriptor_kanji")), *p.StatementDescriptorKanji)
+	}
+}
+
+func (p *AccountSettingsPayoutsParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.DebitNegativeBalances != nil {
+		body.Add(form.FormatKey(append(keyParts, "debit_negative_balances")), strconv.FormatBool(*p.DebitNegativeBalances))
+	}
+	p.Schedule.encodeForm(body, append(keyParts, "schedule"))
+	if p.Schedule != nil {
+		p.Schedule.AppendTo(body, append(keyParts, "schedule"))
+	}
+	if p.StatementDescriptor != nil {
+		body.Add(form.FormatKey(append(keyParts, "statement_descriptor")), *p.StatementDescriptor)
+	}
+}
+
+func (p *AccountTOSAcceptanceParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.Date != nil {
+		body.Add(form.FormatKey(append(keyParts, "date")), strconv.FormatInt(*p.Date, 10))
+	}
+	if p.IP != nil {
+		body.Add(form.FormatKey(append(keyParts, "ip")), *p.IP)
+	}
+	if p.UserAgent != nil {
+		body.Add(form.FormatKey(append(keyParts, "user_agent")), *p.UserAgent)
+	}
+}
+
+func (p *AddressParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.City != nil {
+		body.Add(form.FormatKey(append(keyParts, "city")), *p.City)
+	}
+	if p.Country != nil {
+		body.Add(form.FormatKey(append(keyParts, "country")), *p.Country)
+	}
+	if p.Line1 != nil {
+		body.Add(form.FormatKey(append(keyParts, "line1")), *p.Line1)
+	}
+	if p.Line2 != nil {
+		body.Add(form.FormatKey(append(keyParts, "line2")), *p.Line2)
+	}
+	if p.PostalCode != nil {
+		body.Add(form.FormatKey(append(keyParts, "postal_code")), *p.PostalCode)
+	}
+	if p.State != nil {
+		body.Add(form.FormatKey(append(keyParts, "state")), *p.State)
+	}
+}
+
+func (p *ApplePayDomainListParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.ListParams.encodeForm(body, keyParts)
+}
+
+func (p *ApplePayDomainParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	if p.DomainName != nil {
+		body.Add(form.FormatKey(append(keyParts, "domain_name")), *p.DomainName)
+	}
+}
+
+func (p *ApplicationFeeListParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.ListParams.encodeForm(body, keyParts)
+	if p.Charge != nil {
+		body.Add(form.FormatKey(append(keyParts, "charge")), *p.Charge)
+	}
+	if p.Created != nil {
+		body.Add(form.FormatKey(append(keyParts, "created")), strconv.FormatInt(*p.Created, 10))
+	}
+	p.CreatedRange.encodeForm(body, append(keyParts, "created"))
+}
+
+func (p *ApplicationFeeParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+}
+
+func (p *AuthorizationControlsParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.AllowedCategories != nil && len(p.AllowedCategories) == 0 {
+		body.Add(form.FormatKey(append(keyParts, "allowed_categories")), "")
+	} else {
+		for i1, v1 := range p.AllowedCategories {
+			if v1 != nil {
+				body.Add(form.FormatKey(append(keyParts, "allowed_categories", strconv.Itoa(i1))), *v1)
+			}
+		}
+	}
+	if p.BlockedCategories != nil && len(p.BlockedCategories) == 0 {
+		body.Add(form.FormatKey(append(keyParts, "blocked_categories")), "")
+	} else {
+		for i2, v2 := range p.BlockedCategories {
+			if v2 != nil {
+				body.Add(form.FormatKey(append(keyParts, "blocked_categories", strconv.Itoa(i2))), *v2)
+			}
+		}
+	}
+	if p.MaxApprovals != nil {
+		body.Add(form.FormatKey(append(keyParts, "max_approvals")), strconv.FormatInt(*p.MaxApprovals, 10))
+	}
+	if p.SpendingLimits != nil && len(p.SpendingLimits) == 0 {
+		body.Add(form.FormatKey(append(keyParts, "spending_limits")), "")
+	} else {
+		for i3, v3 := range p.SpendingLimits {
+			v3.encodeForm(body, append(keyParts, "spending_limits", strconv.Itoa(i3)))
+		}
+	}
+	if p.SpendingLimitsCurrency != nil {
+		body.Add(form.FormatKey(append(keyParts, "spending_limits_currency")), *p.SpendingLimitsCurrency)
+	}
+	if p.MaxAmount != nil {
+		body.Add(form.FormatKey(append(keyParts, "max_amount")), strconv.FormatInt(*p.MaxAmount, 10))
+	}
+}
+
+func (p *AuthorizeURLParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	if p.AlwaysPrompt != nil {
+		body.Add(form.FormatKey(append(keyParts, "always_prompt")), strconv.FormatBool(*p.AlwaysPrompt))
+	}
+	if p.ClientID != nil {
+		body.Add(form.FormatKey(append(keyParts, "client_id")), *p.ClientID)
+	}
+	if p.RedirectURI != nil {
+		body.Add(form.FormatKey(append(keyParts, "redirect_uri")), *p.RedirectURI)
+	}
+	if p.ResponseType != nil {
+		body.Add(form.FormatKey(append(keyParts, "response_type")), *p.ResponseType)
+	}
+	if p.Scope != nil {
+		body.Add(form.FormatKey(append(keyParts, "scope")), *p.Scope)
+	}
+	if p.State != nil {
+		body.Add(form.FormatKey(append(keyParts, "state")), *p.State)
+	}
+	if p.StripeLanding != nil {
+		body.Add(form.FormatKey(append(keyParts, "stripe_landing")), *p.StripeLanding)
+	}
+	p.StripeUser.encodeForm(body, append(keyParts, "stripe_user"))
+	if p.SuggestedCapabilities != nil && len(p.SuggestedCapabilities) == 0 {
+		body.Add(form.FormatKey(append(keyParts, "suggested_capabilities")), "")
+	} else {
+		for i1, v1 := range p.SuggestedCapabilities {
+			if v1 != nil {
+				body.Add(form.FormatKey(append(keyParts, "suggested_capabilities", strconv.Itoa(i1))), *v1)
+			}
+		}
+	}
+}
+
+func (p *BalanceParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+}
+
+func (p *BalanceTransactionListParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.ListParams.encodeForm(body, keyParts)
+	if p.AvailableOn != nil {
+		body.Add(form.FormatKey(append(keyParts, "available_on")), strconv.FormatInt(*p.AvailableOn, 10))
+	}
+	p.AvailableOnRange.encodeForm(body, append(keyParts, "available_on"))
+	if p.Created != nil {
+		body.Add(form.FormatKey(append(keyParts, "created")), strconv.FormatInt(*p.Created, 10))
+	}
+	p.CreatedRange.encodeForm(body, append(keyParts, "created"))
+	if p.Currency != nil {
+		body.Add(form.FormatKey(append(keyParts, "currency")), *p.Currency)
+	}
+	if p.Payout != nil {
+		body.Add(form.FormatKey(append(keyParts, "payout")), *p.Payout)
+	}
+	if p.Source != nil {
+		body.Add(form.FormatKey(append(keyParts, "source")), *p.Source)
+	}
+	if p.Type != nil {
+		body.Add(form.FormatKey(append(keyParts, "type")), *p.Type)
+	}
+}
+
+func (p *BalanceTransactionParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+}
+
+func (p *BankAccountListParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.ListParams.encodeForm(body, keyParts)
+}
+
+func (p *BankAccountParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	if p.AccountHolderName != nil {
+		body.Add(form.FormatKey(append(keyParts, "account_holder_name")), *p.AccountHolderName)
+	}
+	if p.AccountHolderType != nil {
+		body.Add(form.FormatKey(append(keyParts, "account_holder_type")), *p.AccountHolderType)
+	}
+	if p.AccountNumber != nil {
+		body.Add(form.FormatKey(append(keyParts, "account_number")), *p.AccountNumber)
+	}
+	if p.Country != nil {
+		body.Add(form.FormatKey(append(keyParts, "country")), *p.Country)
+	}
+	if p.Currency != nil {
+		body.Add(form.FormatKey(append(keyParts, "currency")), *p.Currency)
+	}
+	if p.DefaultForCurrency != nil {
+		body.Add(form.FormatKey(append(keyParts, "default_for_currency")), strconv.FormatBool(*p.DefaultForCurrency))
+	}
+	if p.RoutingNumber != nil {
+		body.Add(form.FormatKey(append(keyParts, "routing_number")), *p.RoutingNumber)
+	}
+	if p.ID != nil {
+		body.Add(form.FormatKey(keyParts), *p.ID)
+	}
+}
+
+func (p *BillingDetailsParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Address.encodeForm(body, append(keyParts, "address"))
+	if p.Email != nil {
+		body.Add(form.FormatKey(append(keyParts, "email")), *p.Email)
+	}
+	if p.Name != nil {
+		body.Add(form.FormatKey(append(keyParts, "name")), *p.Name)
+	}
+	if p.Phone != nil {
+		body.Add(form.FormatKey(append(keyParts, "phone")), *p.Phone)
+	}
+}
+
+func (p *BitcoinReceiverListParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.ListParams.encodeForm(body, keyParts)
+	if p.Active != nil {
+		body.Add(form.FormatKey(append(keyParts, "active")), strconv.FormatBool(*p.Active))
+	}
+	if p.Filled != nil {
+		body.Add(form.FormatKey(append(keyParts, "filled")), strconv.FormatBool(*p.Filled))
+	}
+	if p.UncapturedFunds != nil {
+		body.Add(form.FormatKey(append(keyParts, "uncaptured_funds")), strconv.FormatBool(*p.UncapturedFunds))
+	}
+}
+
+func (p *BitcoinTransactionListParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.ListParams.encodeForm(body, keyParts)
+	if p.Customer != nil {
+		body.Add(form.FormatKey(append(keyParts, "customer")), *p.Customer)
+	}
+}
+
+func (p *CapabilityListParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.ListParams.encodeForm(body, keyParts)
+}
+
+func (p *CapabilityParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	if p.Requested != nil {
+		body.Add(form.FormatKey(append(keyParts, "requested")), strconv.FormatBool(*p.Requested))
+	}
+}
+
+func (p *CaptureParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	if p.Amount != nil {
+		body.Add(form.FormatKey(append(keyParts, "amount")), strconv.FormatInt(*p.Amount, 10))
+	}
+	if p.ApplicationFeeAmount != nil {
+		body.Add(form.FormatKey(append(keyParts, "application_fee_amount")), strconv.FormatInt(*p.ApplicationFeeAmount, 10))
+	}
+	if p.ExchangeRate != nil {
+		body.Add(form.FormatKey(append(keyParts, "exchange_rate")), strconv.FormatFloat(*p.ExchangeRate, 'f', 4, 64))
+	}
+	if p.ReceiptEmail != nil {
+		body.Add(form.FormatKey(append(keyParts, "receipt_email")), *p.ReceiptEmail)
+	}
+	if p.StatementDescriptor != nil {
+		body.Add(form.FormatKey(append(keyParts, "statement_descriptor")), *p.StatementDescriptor)
+	}
+	if p.StatementDescriptorSuffix != nil {
+		body.Add(form.FormatKey(append(keyParts, "statement_descriptor_suffix")), *p.StatementDescriptorSuffix)
+	}
+	if p.TransferGroup != nil {
+		body.Add(form.FormatKey(append(keyParts, "transfer_group")), *p.TransferGroup)
+	}
+	p.TransferData.encodeForm(body, append(keyParts, "transfer_data"))
+}
+
+func (p *CardListParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.ListParams.encodeForm(body, keyParts)
+}
+
+func (p *CardParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	if p.AddressCity != nil {
+		body.Add(form.FormatKey(append(keyParts, "address_city")), *p.AddressCity)
+	}
+	if p.AddressCountry != nil {
+		body.Add(form.FormatKey(append(keyParts, "address_country")), *p.AddressCountry)
+	}
+	if p.AddressLine1 != nil {
+		body.Add(form.FormatKey(append(keyParts, "address_line1")), *p.AddressLine1)
+	}
+	if p.AddressLine2 != nil {
+		body.Add(form.FormatKey(append(keyParts, "address_line2")), *p.AddressLine2)
+	}
+	if p.AddressState != nil {
+		body.Add(form.FormatKey(append(keyParts, "address_state")), *p.AddressState)
+	}
+	if p.AddressZip != nil {
+		body.Add(form.FormatKey(append(keyParts, "address_zip")), *p.AddressZip)
+	}
+	if p.CVC != nil {
+		body.Add(form.FormatKey(append(keyParts, "cvc")), *p.CVC)
+	}
+	if p.Currency != nil {
+		body.Add(form.FormatKey(append(keyParts, "currency")), *p.Currency)
+	}
+	if p.DefaultForCurrency != nil {
+		body.Add(form.FormatKey(append(keyParts, "default_for_currency")), strconv.FormatBool(*p.DefaultForCurrency))
+	}
+	if p.ExpMonth != nil {
+		body.Add(form.FormatKey(append(keyParts, "exp_month")), *p.ExpMonth)
+	}
+	if p.ExpYear != nil {
+		body.Add(form.FormatKey(append(keyParts, "exp_year")), *p.ExpYear)
+	}
+	if p.Name != nil {
+		body.Add(form.FormatKey(append(keyParts, "name")), *p.Name)
+	}
+	if p.Number != nil {
+		body.Add(form.FormatKey(append(keyParts, "number")), *p.Number)
+	}
+	if !(p.ID == "") {
+		body.Add(form.FormatKey(keyParts), p.ID)
+	}
+}
+
+func (p *ChargeLevel3LineItemsParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.DiscountAmount != nil {
+		body.Add(form.FormatKey(append(keyParts, "discount_amount")), strconv.FormatInt(*p.DiscountAmount, 10))
+	}
+	if p.ProductCode != nil {
+		body.Add(form.FormatKey(append(keyParts, "product_code")), *p.ProductCode)
+	}
+	if p.ProductDescription != nil {
+		body.Add(form.FormatKey(append(keyParts, "product_description")), *p.ProductDescription)
+	}
+	if p.Quantity != nil {
+		body.Add(form.FormatKey(append(keyParts, "quantity")), strconv.FormatInt(*p.Quantity, 10))
+	}
+	if p.TaxAmount != nil {
+		body.Add(form.FormatKey(append(keyParts, "tax_amount")), strconv.FormatInt(*p.TaxAmount, 10))
+	}
+	if p.UnitCost != nil {
+		body.Add(form.FormatKey(append(keyParts, "unit_cost")), strconv.FormatInt(*p.UnitCost, 10))
+	}
+}
+
+func (p *ChargeLevel3Params) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.CustomerReference != nil {
+		body.Add(form.FormatKey(append(keyParts, "customer_reference")), *p.CustomerReference)
+	}
+	if p.LineItems != nil && len(p.LineItems) == 0 {
+		body.Add(form.FormatKey(append(keyParts, "line_items")), "")
+	} else {
+		for i1, v1 := range p.LineItems {
+			v1.encodeForm(body, append(keyParts, "line_items", strconv.Itoa(i1)))
+		}
+	}
+	if p.MerchantReference != nil {
+		body.Add(form.FormatKey(append(keyParts, "merchant_reference")), *p.MerchantReference)
+	}
+	if p.ShippingAddressZip != nil {
+		body.Add(form.FormatKey(append(keyParts, "shipping_address_zip")), *p.ShippingAddressZip)
+	}
+	if p.ShippingFromZip != nil {
+		body.Add(form.FormatKey(append(keyParts, "shipping_from_zip")), *p.ShippingFromZip)
+	}
+	if p.ShippingAmount != nil {
+		body.Add(form.FormatKey(append(keyParts, "shipping_amount")), strconv.FormatInt(*p.ShippingAmount, 10))
+	}
+}
+
+func (p *ChargeListParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.ListParams.encodeForm(body, keyParts)
+	if p.Created != nil {
+		body.Add(form.FormatKey(append(keyParts, "created")), strconv.FormatInt(*p.Created, 10))
+	}
+	p.CreatedRange.encodeForm(body, append(keyParts, "created"))
+	if p.Customer != nil {
+		body.Add(form.FormatKey(append(keyParts, "customer")), *p.Customer)
+	}
+	if p.PaymentIntent != nil {
+		body.Add(form.FormatKey(append(keyParts, "payment_intent")), *p.PaymentIntent)
+	}
+	if p.TransferGroup != nil {
+		body.Add(form.FormatKey(append(keyParts, "transfer_group")), *p.TransferGroup)
+	}
+}
+
+func (p *ChargeParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	if p.Amount != nil {
+		body.Add(form.FormatKey(append(keyParts, "amount")), strconv.FormatInt(*p.Amount, 10))
+	}
+	if p.ApplicationFeeAmount != nil {
+		body.Add(form.FormatKey(append(keyParts, "application_fee_amount")), strconv.FormatInt(*p.ApplicationFeeAmount, 10))
+	}
+	if p.Capture != nil {
+		body.Add(form.FormatKey(append(keyParts, "capture")), strconv.FormatBool(*p.Capture))
+	}
+	if p.Currency != nil {
+		body.Add(form.FormatKey(append(keyParts, "currency")), *p.Currency)
+	}
+	if p.Customer != nil {
+		body.Add(form.FormatKey(append(keyParts, "customer")), *p.Customer)
+	}
+	if p.Description != nil {
+		body.Add(form.FormatKey(append(keyParts, "description")), *p.Description)
+	}
+	p.Destination.encodeForm(body, append(keyParts, "destination"))
+	if p.ExchangeRate != nil {
+		body.Add(form.FormatKey(append(keyParts, "exchange_rate")), strconv.FormatFloat(*p.ExchangeRate, 'f', 4, 64))
+	}
+	p.FraudDetails.encodeForm(body, append(keyParts, "fraud_details"))
+	p.Level3.encodeForm(body, append(keyParts, "level3"))
+	if p.OnBehalfOf != nil {
+		body.Add(form.FormatKey(append(keyParts, "on_behalf_of")), *p.OnBehalfOf)
+	}
+	if p.ReceiptEmail != nil {
+		body.Add(form.FormatKey(append(keyParts, "receipt_email")), *p.ReceiptEmail)
+	}
+	p.Shipping.encodeForm(body, append(keyParts, "shipping"))
+	p.Source.encodeForm(body, keyParts)
+	if p.Source != nil {
+		p.Source.AppendTo(body, keyParts)
+	}
+	if p.StatementDescriptor != nil {
+		body.Add(form.FormatKey(append(keyParts, "statement_descriptor")), *p.StatementDescriptor)
+	}
+	if p.StatementDescriptorSuffix != nil {
+		body.Add(form.FormatKey(append(keyParts, "statement_descriptor_suffix")), *p.StatementDescriptorSuffix)
+	}
+	p.TransferData.encodeForm(body, append(keyParts, "transfer_data"))
+	if p.TransferGroup != nil {
+		body.Add(form.FormatKey(append(keyParts, "transfer_group")), *p.TransferGroup)
+	}
+}
+
+func (p *ChargeTransferDataParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.Amount != nil {
+		body.Add(form.FormatKey(append(keyParts, "amount")), strconv.FormatInt(*p.Amount, 10))
+	}
+	if p.Destination != nil {
+		body.Add(form.FormatKey(append(keyParts, "destination")), *p.Destination)
+	}
+}
+
+func (p *CheckoutSessionLineItemParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.Amount != nil {
+		body.Add(form.FormatKey(append(keyParts, "amount")), strconv.FormatInt(*p.Amount, 10))
+	}
+	if p.Currency != nil {
+		body.Add(form.FormatKey(append(keyParts, "currency")), *p.Currency)
+	}
+	if p.Description != nil {
+		body.Add(form.FormatKey(append(keyParts, "description")), *p.Description)
+	}
+	if p.Images != nil && len(p.Images) == 0 {
+		body.Add(form.FormatKey(append(keyParts, "images")), "")
+	} else {
+		for i1, v1 := range p.Images {
+			if v1 != nil {
+				body.Add(form.FormatKey(append(keyParts, "images", strconv.Itoa(i1))), *v1)
+			}
+		}
+	}
+	if p.Name != nil {
+		body.Add(form.FormatKey(append(keyParts, "name")), *p.Name)
+	}
+	if p.Quantity != nil {
+		body.Add(form.FormatKey(append(keyParts, "quantity")), strconv.FormatInt(*p.Quantity, 10))
+	}
+}
+
+func (p *CheckoutSessionParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	if p.BillingAddressCollection != nil {
+		body.Add(form.FormatKey(append(keyParts, "billing_address_collection")), *p.BillingAddressCollection)
+	}
+	if p.CancelURL != nil {
+		body.Add(form.FormatKey(append(keyParts, "cancel_url")), *p.CancelURL)
+	}
+	if p.ClientReferenceID != nil {
+		body.Add(form.FormatKey(append(keyParts, "client_reference_id")), *p.ClientReferenceID)
+	}
+	if p.Customer != nil {
+		body.Add(form.FormatKey(append(keyParts, "customer")), *p.Customer)
+	}
+	if p.CustomerEmail != nil {
+		body.Add(form.FormatKey(append(keyParts, "customer_email")), *p.CustomerEmail)
+	}
+	if p.LineItems != nil && len(p.LineItems) == 0 {
+		body.Add(form.FormatKey(append(keyParts, "line_items")), "")
+	} else {
+		for i1, v1 := range p.LineItems {
+			v1.encodeForm(body, append(keyParts, "line_items", strconv.Itoa(i1)))
+		}
+	}
+	if p.Locale != nil {
+		body.Add(form.FormatKey(append(keyParts, "locale")), *p.Locale)
+	}
+	if p.Mode != nil {
+		body.Add(form.FormatKey(append(keyParts, "mode")), *p.Mode)
+	}
+	p.PaymentIntentData.encodeForm(body, append(keyParts, "payment_intent_data"))
+	if p.PaymentMethodTypes != nil && len(p.PaymentMethodTypes) == 0 {
+		body.Add(form.FormatKey(append(keyParts, "payment_method_types")), "")
+	} else {
+		for i2, v2 := range p.PaymentMethodTypes {
+			if v2 != nil {
+				body.Add(form.FormatKey(append(keyParts, "payment_method_types", strconv.Itoa(i2))), *v2)
+			}
+		}
+	}
+	p.SetupIntentData.encodeForm(body, append(keyParts, "setup_intent_data"))
+	p.SubscriptionData.encodeForm(body, append(keyParts, "subscription_data"))
+	if p.SubmitType != nil {
+		body.Add(form.FormatKey(append(keyParts, "submit_type")), *p.SubmitType)
+	}
+	if p.SuccessURL != nil {
+		body.Add(form.FormatKey(append(keyParts, "success_url")), *p.SuccessURL)
+	}
+}
+
+func (p *CheckoutSessionPaymentIntentDataParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	if p.ApplicationFeeAmount != nil {
+		body.Add(form.FormatKey(append(keyParts, "application_fee_amount")), strconv.FormatInt(*p.ApplicationFeeAmount, 10))
+	}
+	if p.CaptureMethod != nil {
+		body.Add(form.FormatKey(append(keyParts, "capture_method")), *p.CaptureMethod)
+	}
+	if p.Description != nil {
+		body.Add(form.FormatKey(append(keyParts, "description")), *p.Description)
+	}
+	if p.OnBehalfOf != nil {
+		body.Add(form.FormatKey(append(keyParts, "on_behalf_of")), *p.OnBehalfOf)
+	}
+	if p.ReceiptEmail != nil {
+		body.Add(form.FormatKey(append(keyParts, "receipt_email")), *p.ReceiptEmail)
+	}
+	if p.SetupFutureUsage != nil {
+		body.Add(form.FormatKey(append(keyParts, "setup_future_usage")), *p.SetupFutureUsage)
+	}
+	p.Shipping.encodeForm(body, append(keyParts, "shipping"))
+	if p.StatementDescriptor != nil {
+		body.Add(form.FormatKey(append(keyParts, "statement_descriptor")), *p.StatementDescriptor)
+	}
+	p.TransferData.encodeForm(body, append(keyParts, "transfer_data"))
+}
+
+func (p *CheckoutSessionPaymentIntentDataTransferDataParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.Destination != nil {
+		body.Add(form.FormatKey(append(keyParts, "destination")), *p.Destination)
+	}
+}
+
+func (p *CheckoutSessionSetupIntentDataParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	if p.Description != nil {
+		body.Add(form.FormatKey(append(keyParts, "description")), *p.Description)
+	}
+	if p.OnBehalfOf != nil {
+		body.Add(form.FormatKey(append(keyParts, "on_behalf_of")), *p.OnBehalfOf)
+	}
+}
+
+func (p *CheckoutSessionSubscriptionDataItemsParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.Plan != nil {
+		body.Add(form.FormatKey(append(keyParts, "plan")), *p.Plan)
+	}
+	if p.Quantity != nil {
+		body.Add(form.FormatKey(append(keyParts, "quantity")), strconv.FormatInt(*p.Quantity, 10))
+	}
+}
+
+func (p *CheckoutSessionSubscriptionDataParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	if p.ApplicationFeePercent != nil {
+		body.Add(form.FormatKey(append(keyParts, "application_fee_percent")), strconv.FormatFloat(*p.ApplicationFeePercent, 'f', 4, 64))
+	}
+	if p.Items != nil && len(p.Items) == 0 {
+		body.Add(form.FormatKey(append(keyParts, "items")), "")
+	} else {
+		for i1, v1 := range p.Items {
+			v1.encodeForm(body, append(keyParts, "items", strconv.Itoa(i1)))
+		}
+	}
+	if p.TrialEnd != nil {
+		body.Add(form.FormatKey(append(keyParts, "trial_end")), strconv.FormatInt(*p.TrialEnd, 10))
+	}
+	if p.TrialFromPlan != nil {
+		body.Add(form.FormatKey(append(keyParts, "trial_from_plan")), strconv.FormatBool(*p.TrialFromPlan))
+	}
+	if p.TrialPeriodDays != nil {
+		body.Add(form.FormatKey(append(keyParts, "trial_period_days")), strconv.FormatInt(*p.TrialPeriodDays, 10))
+	}
+}
+
+func (p *CountrySpecListParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.ListParams.encodeForm(body, keyParts)
+}
+
+func (p *CountrySpecParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+}
+
+func (p *CouponListParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.ListParams.encodeForm(body, keyParts)
+	if p.Created != nil {
+		body.Add(form.FormatKey(append(keyParts, "created")), strconv.FormatInt(*p.Created, 10))
+	}
+	p.CreatedRange.encodeForm(body, append(keyParts, "created"))
+}
+
+func (p *CouponParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	if p.AmountOff != nil {
+		body.Add(form.FormatKey(append(keyParts, "amount_off")), strconv.FormatInt(*p.AmountOff, 10))
+	}
+	if p.Currency != nil {
+		body.Add(form.FormatKey(append(keyParts, "currency")), *p.Currency)
+	}
+	if p.Duration != nil {
+		body.Add(form.FormatKey(append(keyParts, "duration")), *p.Duration)
+	}
+	if p.DurationInMonths != nil {
+		body.Add(form.FormatKey(append(keyParts, "duration_in_months")), strconv.FormatInt(*p.DurationInMonths, 10))
+	}
+	if p.ID != nil {
+		body.Add(form.FormatKey(append(keyParts, "id")), *p.ID)
+	}
+	if p.MaxRedemptions != nil {
+		body.Add(form.FormatKey(append(keyParts, "max_redemptions")), strconv.FormatInt(*p.MaxRedemptions, 10))
+	}
+	if p.Name != nil {
+		body.Add(form.FormatKey(append(keyParts, "name")), *p.Name)
+	}
+	if p.PercentOff != nil {
+		body.Add(form.FormatKey(append(keyParts, "percent_off")), strconv.FormatFloat(*p.PercentOff, 'f', 4, 64))
+	}
+	if p.RedeemBy != nil {
+		body.Add(form.FormatKey(append(keyParts, "redeem_by")), strconv.FormatInt(*p.RedeemBy, 10))
+	}
+}
+
+func (p *CreditNoteLineItemListParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.ListParams.encodeForm(body, keyParts)
+}
+
+func (p *CreditNoteLineItemListPreviewParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.ListParams.encodeForm(body, keyParts)
+	if p.Amount != nil {
+		body.Add(form.FormatKey(append(keyParts, "amount")), strconv.FormatInt(*p.Amount, 10))
+	}
+	if p.CreditAmount != nil {
+		body.Add(form.FormatKey(append(keyParts, "credit_amount")), strconv.FormatInt(*p.CreditAmount, 10))
+	}
+	if p.Invoice != nil {
+		body.Add(form.FormatKey(append(keyParts, "invoice")), *p.Invoice)
+	}
+	if p.Lines != nil && len(p.Lines) == 0 {
+		body.Add(form.FormatKey(append(keyParts, "lines")), "")
+	} else {
+		for i1, v1 := range p.Lines {
+			v1.encodeForm(body, append(keyParts, "lines", strconv.Itoa(i1)))
+		}
+	}
+	if p.Memo != nil {
+		body.Add(form.FormatKey(append(keyParts, "memo")), *p.Memo)
+	}
+	if p.OutOfBandAmount != nil {
+		body.Add(form.FormatKey(append(keyParts, "out_of_band_amount")), strconv.FormatInt(*p.OutOfBandAmount, 10))
+	}
+	if p.Reason != nil {
+		body.Add(form.FormatKey(append(keyParts, "reason")), *p.Reason)
+	}
+	if p.Refund != nil {
+		body.Add(form.FormatKey(append(keyParts, "refund")), *p.Refund)
+	}
+	if p.RefundAmount != nil {
+		body.Add(form.FormatKey(append(keyParts, "refund_amount")), strconv.FormatInt(*p.RefundAmount, 10))
+	}
+}
+
+func (p *CreditNoteLineParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.Amount != nil {
+		body.Add(form.FormatKey(append(keyParts, "amount")), strconv.FormatInt(*p.Amount, 10))
+	}
+	if p.Description != nil {
+		body.Add(form.FormatKey(append(keyParts, "description")), *p.Description)
+	}
+	if p.InvoiceLineItem != nil {
+		body.Add(form.FormatKey(append(keyParts, "invoice_line_item")), *p.InvoiceLineItem)
+	}
+	if p.Quantity != nil {
+		body.Add(form.FormatKey(append(keyParts, "quantity")), strconv.FormatInt(*p.Quantity, 10))
+	}
+	if p.TaxRates != nil && len(p.TaxRates) == 0 {
+		body.Add(form.FormatKey(append(keyParts, "tax_rates")), "")
+	} else {
+		for i1, v1 := range p.TaxRates {
+			if v1 != nil {
+				body.Add(form.FormatKey(append(keyParts, "tax_rates", strconv.Itoa(i1))), *v1)
+			}
+		}
+	}
+	if p.UnitAmount != nil {
+		body.Add(form.FormatKey(append(keyParts, "unit_amount")), strconv.FormatInt(*p.UnitAmount, 10))
+	}
+	if p.UnitAmountDecimal != nil {
+		body.Add(form.FormatKey(append(keyParts, "unit_amount_decimal")), strconv.FormatFloat(*p.UnitAmountDecimal, 'f', -1, 64))
+	}
+	if p.Type != nil {
+		body.Add(form.FormatKey(append(keyParts, "type")), *p.Type)
+	}
+}
+
+func (p *CreditNoteListParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.ListParams.encodeForm(body, keyParts)
+	if p.Customer != nil {
+		body.Add(form.FormatKey(append(keyParts, "customer")), *p.Customer)
+	}
+	if p.Invoice != nil {
+		body.Add(form.FormatKey(append(keyParts, "invoice")), *p.Invoice)
+	}
+}
+
+func (p *CreditNoteParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	if p.Amount != nil {
+		body.Add(form.FormatKey(append(keyParts, "amount")), strconv.FormatInt(*p.Amount, 10))
+	}
+	if p.CreditAmount != nil {
+		body.Add(form.FormatKey(append(keyParts, "credit_amount")), strconv.FormatInt(*p.CreditAmount, 10))
+	}
+	if p.Invoice != nil {
+		body.Add(form.FormatKey(append(keyParts, "invoice")), *p.Invoice)
+	}
+	if p.Lines != nil && len(p.Lines) == 0 {
+		body.Add(form.FormatKey(append(keyParts, "lines")), "")
+	} else {
+		for i1, v1 := range p.Lines {
+			v1.encodeForm(body, append(keyParts, "lines", strconv.Itoa(i1)))
+		}
+	}
+	if p.Memo != nil {
+		body.Add(form.FormatKey(append(keyParts, "memo")), *p.Memo)
+	}
+	if p.OutOfBandAmount != nil {
+		body.Add(form.FormatKey(append(keyParts, "out_of_band_amount")), strconv.FormatInt(*p.OutOfBandAmount, 10))
+	}
+	if p.Reason != nil {
+		body.Add(form.FormatKey(append(keyParts, "reason")), *p.Reason)
+	}
+	if p.Refund != nil {
+		body.Add(form.FormatKey(append(keyParts, "refund")), *p.Refund)
+	}
+	if p.RefundAmount != nil {
+		body.Add(form.FormatKey(append(keyParts, "refund_amount")), strconv.FormatInt(*p.RefundAmount, 10))
+	}
+}
+
+func (p *CreditNotePreviewParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	if p.Amount != nil {
+		body.Add(form.FormatKey(append(keyParts, "amount")), strconv.FormatInt(*p.Amount, 10))
+	}
+	if p.CreditAmount != nil {
+		body.Add(form.FormatKey(append(keyParts, "credit_amount")), strconv.FormatInt(*p.CreditAmount, 10))
+	}
+	if p.Invoice != nil {
+		body.Add(form.FormatKey(append(keyParts, "invoice")), *p.Invoice)
+	}
+	if p.Lines != nil && len(p.Lines) == 0 {
+		body.Add(form.FormatKey(append(keyParts, "lines")), "")
+	} else {
+		for i1, v1 := range p.Lines {
+			v1.encodeForm(body, append(keyParts, "lines", strconv.Itoa(i1)))
+		}
+	}
+	if p.Memo != nil {
+		body.Add(form.FormatKey(append(keyParts, "memo")), *p.Memo)
+	}
+	if p.OutOfBandAmount != nil {
+		body.Add(form.FormatKey(append(keyParts, "out_of_band_amount")), strconv.FormatInt(*p.OutOfBandAmount, 10))
+	}
+	if p.Reason != nil {
+		body.Add(form.FormatKey(append(keyParts, "reason")), *p.Reason)
+	}
+	if p.Refund != nil {
+		body.Add(form.FormatKey(append(keyParts, "refund")), *p.Refund)
+	}
+	if p.RefundAmount != nil {
+		body.Add(form.FormatKey(append(keyParts, "refund_amount")), strconv.FormatInt(*p.RefundAmount, 10))
+	}
+}
+
+func (p *CreditNoteVoidParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+}
+
+func (p *CustomerBalanceTransactionListParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.ListParams.encodeForm(body, keyParts)
+}
+
+func (p *CustomerBalanceTransactionParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	if p.Amount != nil {
+		body.Add(form.FormatKey(append(keyParts, "amount")), strconv.FormatInt(*p.Amount, 10))
+	}
+	if p.Currency != nil {
+		body.Add(form.FormatKey(append(keyParts, "currency")), *p.Currency)
+	}
+	if p.Description != nil {
+		body.Add(form.FormatKey(append(keyParts, "description")), *p.Description)
+	}
+}
+
+func (p *CustomerInvoiceCustomFieldParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.Name != nil {
+		body.Add(form.FormatKey(append(keyParts, "name")), *p.Name)
+	}
+	if p.Value != nil {
+		body.Add(form.FormatKey(append(keyParts, "value")), *p.Value)
+	}
+}
+
+func (p *CustomerInvoiceSettingsParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.CustomFields != nil && len(p.CustomFields) == 0 {
+		body.Add(form.FormatKey(append(keyParts, "custom_fields")), "")
+	} else {
+		for i1, v1 := range p.CustomFields {
+			v1.encodeForm(body, append(keyParts, "custom_fields", strconv.Itoa(i1)))
+		}
+	}
+	if p.DefaultPaymentMethod != nil {
+		body.Add(form.FormatKey(append(keyParts, "default_payment_method")), *p.DefaultPaymentMethod)
+	}
+	if p.Footer != nil {
+		body.Add(form.FormatKey(append(keyParts, "footer")), *p.Footer)
+	}
+}
+
+func (p *CustomerListParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.ListParams.encodeForm(body, keyParts)
+	if p.Created != nil {
+		body.Add(form.FormatKey(append(keyParts, "created")), strconv.FormatInt(*p.Created, 10))
+	}
+	p.CreatedRange.encodeForm(body, append(keyParts, "created"))
+	if p.Email != nil {
+		body.Add(form.FormatKey(append(keyParts, "email")), *p.Email)
+	}
+}
+
+func (p *CustomerParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	p.Address.encodeForm(body, append(keyParts, "address"))
+	if p.Balance != nil {
+		body.Add(form.FormatKey(append(keyParts, "balance")), strconv.FormatInt(*p.Balance, 10))
+	}
+	if p.Coupon != nil {
+		body.Add(form.FormatKey(append(keyParts, "coupon")), *p.Coupon)
+	}
+	if p.DefaultSource != nil {
+		body.Add(form.FormatKey(append(keyParts, "default_source")), *p.DefaultSource)
+	}
+	if p.Description != nil {
+		body.Add(form.FormatKey(append(keyParts, "description")), *p.Description)
+	}
+	if p.Email != nil {
+		body.Add(form.FormatKey(append(keyParts, "email")), *p.Email)
+	}
+	if p.InvoicePrefix != nil {
+		body.Add(form.FormatKey(append(keyParts, "invoice_prefix")), *p.InvoicePrefix)
+	}
+	p.InvoiceSettings.encodeForm(body, append(keyParts, "invoice_settings"))
+	if p.Name != nil {
+		body.Add(form.FormatKey(append(keyParts, "name")), *p.Name)
+	}
+	if p.PaymentMethod != nil {
+		body.Add(form.FormatKey(append(keyParts, "payment_method")), *p.PaymentMethod)
+	}
+	if p.Phone != nil {
+		body.Add(form.FormatKey(append(keyParts, "phone")), *p.Phone)
+	}
+	if p.PreferredLocales != nil && len(p.PreferredLocales) == 0 {
+		body.Add(form.FormatKey(append(keyParts, "preferred_locales")), "")
+	} else {
+		for i1, v1 := range p.PreferredLocales {
+			if v1 != nil {
+				body.Add(form.FormatKey(append(keyParts, "preferred_locales", strconv.Itoa(i1))), *v1)
+			}
+		}
+	}
+	p.Shipping.encodeForm(body, append(keyParts, "shipping"))
+	p.Source.encodeForm(body, keyParts)
+	if p.Source != nil {
+		p.Source.AppendTo(body, keyParts)
+	}
+	if p.TaxExempt != nil {
+		body.Add(form.FormatKey(append(keyParts, "tax_exempt")), *p.TaxExempt)
+	}
+	if p.TaxIDData != nil && len(p.TaxIDData) == 0 {
+		body.Add(form.FormatKey(append(keyParts, "tax_id_data")), "")
+	} else {
+		for i2, v2 := range p.TaxIDData {
+			v2.encodeForm(body, append(keyParts, "tax_id_data", strconv.Itoa(i2)))
+		}
+	}
+	if p.Plan != nil {
+		body.Add(form.FormatKey(append(keyParts, "plan")), *p.Plan)
+	}
+	if p.Quantity != nil {
+		body.Add(form.FormatKey(append(keyParts, "quantity")), strconv.FormatInt(*p.Quantity, 10))
+	}
+	if p.TaxPercent != nil {
+		body.Add(form.FormatKey(append(keyParts, "tax_percent")), strconv.FormatFloat(*p.TaxPercent, 'f', 4, 64))
+	}
+	if p.TrialEnd != nil {
+		body.Add(form.FormatKey(append(keyParts, "trial_end")), strconv.FormatInt(*p.TrialEnd, 10))
+	}
+}
+
+func (p *CustomerShippingDetailsParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Address.encodeForm(body, append(keyParts, "address"))
+	if p.Name != nil {
+		body.Add(form.FormatKey(append(keyParts, "name")), *p.Name)
+	}
+	if p.Phone != nil {
+		body.Add(form.FormatKey(append(keyParts, "phone")), *p.Phone)
+	}
+}
+
+func (p *CustomerSourceParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	p.Source.encodeForm(body, keyParts)
+	if p.Source != nil {
+		p.Source.AppendTo(body, keyParts)
+	}
+}
+
+func (p *CustomerTaxIDDataParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.Type != nil {
+		body.Add(form.FormatKey(append(keyParts, "type")), *p.Type)
+	}
+	if p.Value != nil {
+		body.Add(form.FormatKey(append(keyParts, "value")), *p.Value)
+	}
+}
+
+func (p *DOBParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.Day != nil {
+		body.Add(form.FormatKey(append(keyParts, "day")), strconv.FormatInt(*p.Day, 10))
+	}
+	if p.Month != nil {
+		body.Add(form.FormatKey(append(keyParts, "month")), strconv.FormatInt(*p.Month, 10))
+	}
+	if p.Year != nil {
+		body.Add(form.FormatKey(append(keyParts, "year")), strconv.FormatInt(*p.Year, 10))
+	}
+}
+
+func (p *DeauthorizeParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	if p.ClientID != nil {
+		body.Add(form.FormatKey(append(keyParts, "client_id")), *p.ClientID)
+	}
+	if p.StripeUserID != nil {
+		body.Add(form.FormatKey(append(keyParts, "stripe_user_id")), *p.StripeUserID)
+	}
+}
+
+func (p *DestinationParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.Account != nil {
+		body.Add(form.FormatKey(append(keyParts, "account")), *p.Account)
+	}
+	if p.Amount != nil {
+		body.Add(form.FormatKey(append(keyParts, "amount")), strconv.FormatInt(*p.Amount, 10))
+	}
+}
+
+func (p *DiscountParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+}
+
+func (p *DisputeEvidenceParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.AccessActivityLog != nil {
+		body.Add(form.FormatKey(append(keyParts, "access_activity_log")), *p.AccessActivityLog)
+	}
+	if p.BillingAddress != nil {
+		body.Add(form.FormatKey(append(keyParts, "billing_address")), *p.BillingAddress)
+	}
+	if p.CancellationPolicy != nil {
+		body.Add(form.FormatKey(append(keyParts, "cancellation_policy")), *p.CancellationPolicy)
+	}
+	if p.CancellationPolicyDisclosure != nil {
+		body.Add(form.FormatKey(append(keyParts, "cancellation_policy_disclosure")), *p.CancellationPolicyDisclosure)
+	}
+	if p.CancellationRebuttal != nil {
+		body.Add(form.FormatKey(append(keyParts, "cancellation_rebuttal")), *p.CancellationRebuttal)
+	}
+	if p.CustomerCommunication != nil {
+		body.Add(form.FormatKey(append(keyParts, "customer_communication")), *p.CustomerCommunication)
+	}
+	if p.CustomerEmailAddress != nil {
+		body.Add(form.FormatKey(append(keyParts, "customer_email_address")), *p.CustomerEmailAddress)
+	}
+	if p.CustomerName != nil {
+		body.Add(form.FormatKey(append(keyParts, "customer_name")), *p.CustomerName)
+	}
+	if p.CustomerPurchaseIP != nil {
+		body.Add(form.FormatKey(append(keyParts, "customer_purchase_ip")), *p.CustomerPurchaseIP)
+	}
+	if p.CustomerSignature != nil {
+		body.Add(form.FormatKey(append(keyParts, "customer_signature")), *p.CustomerSignature)
+	}
+	if p.DuplicateChargeDocumentation != nil {
+		body.Add(form.FormatKey(append(keyParts, "duplicate_charge_documentation")), *p.DuplicateChargeDocumentation)
+	}
+	if p.DuplicateChargeExplanation != nil {
+		body.Add(form.FormatKey(append(keyParts, "duplicate_charge_explanation")), *p.DuplicateChargeExplanation)
+	}
+	if p.DuplicateChargeID != nil {
+		body.Add(form.FormatKey(append(keyParts, "duplicate_charge_id")), *p.DuplicateChargeID)
+	}
+	if p.ProductDescription != nil {
+		body.Add(form.FormatKey(append(keyParts, "product_description")), *p.ProductDescription)
+	}
+	if p.Receipt != nil {
+		body.Add(form.FormatKey(append(keyParts, "receipt")), *p.Receipt)
+	}
+	if p.RefundPolicy != nil {
+		body.Add(form.FormatKey(append(keyParts, "refund_policy")), *p.RefundPolicy)
+	}
+	if p.RefundPolicyDisclosure != nil {
+		body.Add(form.FormatKey(append(keyParts, "refund_policy_disclosure")), *p.RefundPolicyDisclosure)
+	}
+	if p.RefundRefusalExplanation != nil {
+		body.Add(form.FormatKey(append(keyParts, "refund_refusal_explanation")), *p.RefundRefusalExplanation)
+	}
+	if p.ServiceDate != nil {
+		body.Add(form.FormatKey(append(keyParts, "service_date")), *p.ServiceDate)
+	}
+	if p.ServiceDocumentation != nil {
+		body.Add(form.FormatKey(append(keyParts, "service_documentation")), *p.ServiceDocumentation)
+	}
+	if p.ShippingAddress != nil {
+		body.Add(form.FormatKey(append(keyParts, "shipping_address")), *p.ShippingAddress)
+	}
+	if p.ShippingCarrier != nil {
+		body.Add(form.FormatKey(append(keyParts, "shipping_carrier")), *p.ShippingCarrier)
+	}
+	if p.ShippingDate != nil {
+		body.Add(form.FormatKey(append(keyParts, "shipping_date")), *p.ShippingDate)
+	}
+	if p.ShippingDocumentation != nil {
+		body.Add(form.FormatKey(append(keyParts, "shipping_documentation")), *p.ShippingDocumentation)
+	}
+	if p.ShippingTrackingNumber != nil {
+		body.Add(form.FormatKey(append(keyParts, "shipping_tracking_number")), *p.ShippingTrackingNumber)
+	}
+	if p.UncategorizedFile != nil {
+		body.Add(form.FormatKey(append(keyParts, "uncategorized_file")), *p.UncategorizedFile)
+	}
+	if p.UncategorizedText != nil {
+		body.Add(form.FormatKey(append(keyParts, "uncategorized_text")), *p.UncategorizedText)
+	}
+}
+
+func (p *DisputeListParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.ListParams.encodeForm(body, keyParts)
+	if p.Charge != nil {
+		body.Add(form.FormatKey(append(keyParts, "charge")), *p.Charge)
+	}
+	if p.Created != nil {
+		body.Add(form.FormatKey(append(keyParts, "created")), strconv.FormatInt(*p.Created, 10))
+	}
+	p.CreatedRange.encodeForm(body, append(keyParts, "created"))
+	if p.PaymentIntent != nil {
+		body.Add(form.FormatKey(append(keyParts, "payment_intent")), *p.PaymentIntent)
+	}
+}
+
+func (p *DisputeParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	p.Evidence.encodeForm(body, append(keyParts, "evidence"))
+	if p.Submit != nil {
+		body.Add(form.FormatKey(append(keyParts, "submit")), strconv.FormatBool(*p.Submit))
+	}
+}
+
+func (p *EphemeralKeyParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	if p.Customer != nil {
+		body.Add(form.FormatKey(append(keyParts, "customer")), *p.Customer)
+	}
+	if p.IssuingCard != nil {
+		body.Add(form.FormatKey(append(keyParts, "issuing_card")), *p.IssuingCard)
+	}
+}
+
+func (p *EventListParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.ListParams.encodeForm(body, keyParts)
+	if p.Created != nil {
+		body.Add(form.FormatKey(append(keyParts, "created")), strconv.FormatInt(*p.Created, 10))
+	}
+	p.CreatedRange.encodeForm(body, append(keyParts, "created"))
+	if p.DeliverySuccess != nil {
+		body.Add(form.FormatKey(append(keyParts, "delivery_success")), strconv.FormatBool(*p.DeliverySuccess))
+	}
+	if p.Type != nil {
+		body.Add(form.FormatKey(append(keyParts, "type")), *p.Type)
+	}
+	if p.Types != nil && len(p.Types) == 0 {
+		body.Add(form.FormatKey(append(keyParts, "types")), "")
+	} else {
+		for i1, v1 := range p.Types {
+			if v1 != nil {
+				body.Add(form.FormatKey(append(keyParts, "types", strconv.Itoa(i1))), *v1)
+			}
+		}
+	}
+}
+
+func (p *EventParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+}
+
+func (p *ExchangeRateListParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.ListParams.encodeForm(body, keyParts)
+}
+
+func (p *ExchangeRateParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+}
+
+func (p *ExtraValues) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+}
+
+func (p *FeeRefundListParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.ListParams.encodeForm(body, keyParts)
+}
+
+func (p *FeeRefundParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	if p.Amount != nil {
+		body.Add(form.FormatKey(append(keyParts, "amount")), strconv.FormatInt(*p.Amount, 10))
+	}
+}
+
+func (p *FileFileLinkDataParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	if p.Create != nil {
+		body.Add(form.FormatKey(append(keyParts, "create")), strconv.FormatBool(*p.Create))
+	}
+	if p.ExpiresAt != nil {
+		body.Add(form.FormatKey(append(keyParts, "expires_at")), strconv.FormatInt(*p.ExpiresAt, 10))
+	}
+}
+
+func (p *FileLinkListParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.ListParams.encodeForm(body, keyParts)
+	if p.Created != nil {
+		body.Add(form.FormatKey(append(keyParts, "created")), strconv.FormatInt(*p.Created, 10))
+	}
+	p.CreatedRange.encodeForm(body, append(keyParts, "created"))
+	if p.Expired != nil {
+		body.Add(form.FormatKey(append(keyParts, "expired")), strconv.FormatBool(*p.Expired))
+	}
+	if p.File != nil {
+		body.Add(form.FormatKey(append(keyParts, "file")), *p.File)
+	}
+}
+
+func (p *FileLinkParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	if p.ExpiresAt != nil {
+		body.Add(form.FormatKey(append(keyParts, "expires_at")), strconv.FormatInt(*p.ExpiresAt, 10))
+	}
+	if p.File != nil {
+		body.Add(form.FormatKey(append(keyParts, "file")), *p.File)
+	}
+}
+
+func (p *FileListParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.ListParams.encodeForm(body, keyParts)
+	if p.Created != nil {
+		body.Add(form.FormatKey(append(keyParts, "created")), strconv.FormatInt(*p.Created, 10))
+	}
+	p.CreatedRange.encodeForm(body, append(keyParts, "created"))
+	if p.Purpose != nil {
+		body.Add(form.FormatKey(append(keyParts, "purpose")), *p.Purpose)
+	}
+}
+
+func (p *Filters) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+}
+
+func (p *FraudDetailsParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.UserReport != nil {
+		body.Add(form.FormatKey(append(keyParts, "user_report")), *p.UserReport)
+	}
+}
+
+func (p *InventoryParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.Quantity != nil {
+		body.Add(form.FormatKey(append(keyParts, "quantity")), strconv.FormatInt(*p.Quantity, 10))
+	}
+	if p.Type != nil {
+		body.Add(form.FormatKey(append(keyParts, "type")), *p.Type)
+	}
+	if p.Value != nil {
+		body.Add(form.FormatKey(append(keyParts, "value")), *p.Value)
+	}
+}
+
+func (p *InvoiceCustomFieldParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.Name != nil {
+		body.Add(form.FormatKey(append(keyParts, "name")), *p.Name)
+	}
+	if p.Value != nil {
+		body.Add(form.FormatKey(append(keyParts, "value")), *p.Value)
+	}
+}
+
+func (p *InvoiceFinalizeParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	if p.AutoAdvance != nil {
+		body.Add(form.FormatKey(append(keyParts, "auto_advance")), strconv.FormatBool(*p.AutoAdvance))
+	}
+}
+
+func (p *InvoiceItemListParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.ListParams.encodeForm(body, keyParts)
+	if p.Created != nil {
+		body.Add(form.FormatKey(append(keyParts, "created")), strconv.FormatInt(*p.Created, 10))
+	}
+	p.CreatedRange.encodeForm(body, append(keyParts, "created"))
+	if p.Customer != nil {
+		body.Add(form.FormatKey(append(keyParts, "customer")), *p.Customer)
+	}
+	if p.Invoice != nil {
+		body.Add(form.FormatKey(append(keyParts, "invoice")), *p.Invoice)
+	}
+	if p.Pending != nil {
+		body.Add(form.FormatKey(append(keyParts, "pending")), strconv.FormatBool(*p.Pending))
+	}
+}
+
+func (p *InvoiceItemParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	if p.Amount != nil {
+		body.Add(form.FormatKey(append(keyParts, "amount")), strconv.FormatInt(*p.Amount, 10))
+	}
+	if p.Currency != nil {
+		body.Add(form.FormatKey(append(keyParts, "currency")), *p.Currency)
+	}
+	if p.Customer != nil {
+		body.Add(form.FormatKey(append(keyParts, "customer")), *p.Customer)
+	}
+	if p.Description != nil {
+		body.Add(form.FormatKey(append(keyParts, "description")), *p.Description)
+	}
+	if p.Discountable != nil {
+		body.Add(form.FormatKey(append(keyParts, "discountable")), strconv.FormatBool(*p.Discountable))
+	}
+	if p.Invoice != nil {
+		body.Add(form.FormatKey(append(keyParts, "invoice")), *p.Invoice)
+	}
+	p.Period.encodeForm(body, append(keyParts, "period"))
+	if p.Quantity != nil {
+		body.Add(form.FormatKey(append(keyParts, "quantity")), strconv.FormatInt(*p.Quantity, 10))
+	}
+	if p.Subscription != nil {
+		body.Add(form.FormatKey(append(keyParts, "subscription")), *p.Subscription)
+	}
+	if p.TaxRates != nil && len(p.TaxRates) == 0 {
+		body.Add(form.FormatKey(append(keyParts, "tax_rates")), "")
+	} else {
+		for i1, v1 := range p.TaxRates {
+			if v1 != nil {
+				body.Add(form.FormatKey(append(keyParts, "tax_rates", strconv.Itoa(i1))), *v1)
+			}
+		}
+	}
+	if p.UnitAmount != nil {
+		body.Add(form.FormatKey(append(keyParts, "unit_amount")), strconv.FormatInt(*p.UnitAmount, 10))
+	}
+	if p.UnitAmountDecimal != nil {
+		body.Add(form.FormatKey(append(keyParts, "unit_amount_decimal")), strconv.FormatFloat(*p.UnitAmountDecimal, 'f', -1, 64))
+	}
+}
+
+func (p *InvoiceItemPeriodParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.End != nil {
+		body.Add(form.FormatKey(append(keyParts, "end")), strconv.FormatInt(*p.End, 10))
+	}
+	if p.Start != nil {
+		body.Add(form.FormatKey(append(keyParts, "start")), strconv.FormatInt(*p.Start, 10))
+	}
+}
+
+func (p *InvoiceLineListParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.ListParams.encodeForm(body, keyParts)
+	if p.Customer != nil {
+		body.Add(form.FormatKey(append(keyParts, "customer")), *p.Customer)
+	}
+	if p.Subscription != nil {
+		body.Add(form.FormatKey(append(keyParts, "subscription")), *p.Subscription)
+	}
+}
+
+func (p *InvoiceListParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.ListParams.encodeForm(body, keyParts)
+	if p.CollectionMethod != nil {
+		body.Add(form.FormatKey(append(keyParts, "collection_method")), *p.CollectionMethod)
+	}
+	if p.Customer != nil {
+		body.Add(form.FormatKey(append(keyParts, "customer")), *p.Customer)
+	}
+	if p.Created != nil {
+		body.Add(form.FormatKey(append(keyParts, "created")), strconv.FormatInt(*p.Created, 10))
+	}
+	p.CreatedRange.encodeForm(body, append(keyParts, "created"))
+	if p.DueDate != nil {
+		body.Add(form.FormatKey(append(keyParts, "due_date")), strconv.FormatInt(*p.DueDate, 10))
+	}
+	p.DueDateRange.encodeForm(body, append(keyParts, "due_date"))
+	if p.Status != nil {
+		body.Add(form.FormatKey(append(keyParts, "status")), *p.Status)
+	}
+	if p.Subscription != nil {
+		body.Add(form.FormatKey(append(keyParts, "subscription")), *p.Subscription)
+	}
+}
+
+func (p *InvoiceMarkUncollectibleParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+}
+
+func (p *InvoiceParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	if p.AutoAdvance != nil {
+		body.Add(form.FormatKey(append(keyParts, "auto_advance")), strconv.FormatBool(*p.AutoAdvance))
+	}
+	if p.ApplicationFeeAmount != nil {
+		body.Add(form.FormatKey(append(keyParts, "application_fee_amount")), strconv.FormatInt(*p.ApplicationFeeAmount, 10))
+	}
+	if p.CollectionMethod != nil {
+		body.Add(form.FormatKey(append(keyParts, "collection_method")), *p.CollectionMethod)
+	}
+	if p.CustomFields != nil && len(p.CustomFields) == 0 {
+		body.Add(form.FormatKey(append(keyParts, "custom_fields")), "")
+	} else {
+		for i1, v1 := range p.CustomFields {
+			v1.encodeForm(body, append(keyParts, "custom_fields", strconv.Itoa(i1)))
+		}
+	}
+	if p.Customer != nil {
+		body.Add(form.FormatKey(append(keyParts, "customer")), *p.Customer)
+	}
+	if p.DaysUntilDue != nil {
+		body.Add(form.FormatKey(append(keyParts, "days_until_due")), strconv.FormatInt(*p.DaysUntilDue, 10))
+	}
+	if p.DefaultPaymentMethod != nil {
+		body.Add(form.FormatKey(append(keyParts, "default_payment_method")), *p.DefaultPaymentMethod)
+	}
+	if p.DefaultSource != nil {
+		body.Add(form.FormatKey(append(keyParts, "default_source")), *p.DefaultSource)
+	}
+	if p.DefaultTaxRates != nil && len(p.DefaultTaxRates) == 0 {
+		body.Add(form.FormatKey(append(keyParts, "default_tax_rates")), "")
+	} else {
+		for i2, v2 := range p.DefaultTaxRates {
+			if v2 != nil {
+				body.Add(form.FormatKey(append(keyParts, "default_tax_rates", strconv.Itoa(i2))), *v2)
+			}
+		}
+	}
+	if p.Description != nil {
+		body.Add(form.FormatKey(append(keyParts, "description")), *p.Description)
+	}
+	if p.DueDate != nil {
+		body.Add(form.FormatKey(append(keyParts, "due_date")), strconv.FormatInt(*p.DueDate, 10))
+	}
+	if p.Footer != nil {
+		body.Add(form.FormatKey(append(keyParts, "footer")), *p.Footer)
+	}
+	if p.Paid != nil {
+		body.Add(form.FormatKey(append(keyParts, "paid")), strconv.FormatBool(*p.Paid))
+	}
+	if p.StatementDescriptor != nil {
+		body.Add(form.FormatKey(append(keyParts, "statement_descriptor")), *p.StatementDescriptor)
+	}
+	if p.Subscription != nil {
+		body.Add(form.FormatKey(append(keyParts, "subscription")), *p.Subscription)
+	}
+	p.TransferData.encodeForm(body, append(keyParts, "transfer_data"))
+	if p.Coupon != nil {
+		body.Add(form.FormatKey(append(keyParts, "coupon")), *p.Coupon)
+	}
+	if p.InvoiceItems != nil && len(p.InvoiceItems) == 0 {
+		body.Add(form.FormatKey(append(keyParts, "invoice_items")), "")
+	} else {
+		for i3, v3 := range p.InvoiceItems {
+			v3.encodeForm(body, append(keyParts, "invoice_items", strconv.Itoa(i3)))
+		}
+	}
+	if p.SubscriptionBillingCycleAnchor != nil {
+		body.Add(form.FormatKey(append(keyParts, "subscription_billing_cycle_anchor")), strconv.FormatInt(*p.SubscriptionBillingCycleAnchor, 10))
+	}
+	if p.SubscriptionCancelAt != nil {
+		body.Add(form.FormatKey(append(keyParts, "subscription_cancel_at")), strconv.FormatInt(*p.SubscriptionCancelAt, 10))
+	}
+	if p.SubscriptionCancelAtPeriodEnd != nil {
+		body.Add(form.FormatKey(append(keyParts, "subscription_cancel_at_period_end")), strconv.FormatBool(*p.SubscriptionCancelAtPeriodEnd))
+	}
+	if p.SubscriptionCancelNow != nil {
+		body.Add(form.FormatKey(append(keyParts, "subscription_cancel_now")), strconv.FormatBool(*p.SubscriptionCancelNow))
+	}
+	if p.SubscriptionDefaultTaxRates != nil && len(p.SubscriptionDefaultTaxRates) == 0 {
+		body.Add(form.FormatKey(append(keyParts, "subscription_default_tax_rates")), "")
+	} else {
+		for i4, v4 := range p.SubscriptionDefaultTaxRates {
+			if v4 != nil {
+				body.Add(form.FormatKey(append(keyParts, "subscription_default_tax_rates", strconv.Itoa(i4))), *v4)
+			}
+		}
+	}
+	if p.SubscriptionItems != nil && len(p.SubscriptionItems) == 0 {
+		body.Add(form.FormatKey(append(keyParts, "subscription_items")), "")
+	} else {
+		for i5, v5 := range p.SubscriptionItems {
+			v5.encodeForm(body, append(keyParts, "subscription_items", strconv.Itoa(i5)))
+		}
+	}
+	if p.SubscriptionPlan != nil {
+		body.Add(form.FormatKey(append(keyParts, "subscription_plan")), *p.SubscriptionPlan)
+	}
+	if p.SubscriptionProrate != nil {
+		body.Add(form.FormatKey(append(keyParts, "subscription_prorate")), strconv.FormatBool(*p.SubscriptionProrate))
+	}
+	if p.SubscriptionProrationBehavior != nil {
+		body.Add(form.FormatKey(append(keyParts, "subscription_proration_behavior")), *p.SubscriptionProrationBehavior)
+	}
+	if p.SubscriptionProrationDate != nil {
+		body.Add(form.FormatKey(append(keyParts, "subscription_proration_date")), strconv.FormatInt(*p.SubscriptionProrationDate, 10))
+	}
+	if p.SubscriptionQuantity != nil {
+		body.Add(form.FormatKey(append(keyParts, "subscription_quantity")), strconv.FormatInt(*p.SubscriptionQuantity, 10))
+	}
+	if p.SubscriptionTrialEnd != nil {
+		body.Add(form.FormatKey(append(keyParts, "subscription_trial_end")), strconv.FormatInt(*p.SubscriptionTrialEnd, 10))
+	}
+	if p.SubscriptionTrialFromPlan != nil {
+		body.Add(form.FormatKey(append(keyParts, "subscription_trial_from_plan")), strconv.FormatBool(*p.SubscriptionTrialFromPlan))
+	}
+	if p.TaxPercent != nil {
+		body.Add(form.FormatKey(append(keyParts, "tax_percent")), strconv.FormatFloat(*p.TaxPercent, 'f', 4, 64))
+	}
+	if p.SubscriptionTaxPercent != nil {
+		body.Add(form.FormatKey(append(keyParts, "subscription_tax_percent")), strconv.FormatFloat(*p.SubscriptionTaxPercent, 'f', 4, 64))
+	}
+}
+
+func (p *InvoicePayParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	if p.Forgive != nil {
+		body.Add(form.FormatKey(append(keyParts, "forgive")), strconv.FormatBool(*p.Forgive))
+	}
+	if p.OffSession != nil {
+		body.Add(form.FormatKey(append(keyParts, "off_session")), strconv.FormatBool(*p.OffSession))
+	}
+	if p.PaidOutOfBand != nil {
+		body.Add(form.FormatKey(append(keyParts, "paid_out_of_band")), strconv.FormatBool(*p.PaidOutOfBand))
+	}
+	if p.PaymentMethod != nil {
+		body.Add(form.FormatKey(append(keyParts, "payment_method")), *p.PaymentMethod)
+	}
+	if p.Source != nil {
+		body.Add(form.FormatKey(append(keyParts, "source")), *p.Source)
+	}
+}
+
+func (p *InvoiceSendParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+}
+
+func (p *InvoiceTransferDataParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.Destination != nil {
+		body.Add(form.FormatKey(append(keyParts, "destination")), *p.Destination)
+	}
+}
+
+func (p *InvoiceUpcomingInvoiceItemParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.Amount != nil {
+		body.Add(form.FormatKey(append(keyParts, "amount")), strconv.FormatInt(*p.Amount, 10))
+	}
+	if p.Currency != nil {
+		body.Add(form.FormatKey(append(keyParts, "currency")), *p.Currency)
+	}
+	if p.Description != nil {
+		body.Add(form.FormatKey(append(keyParts, "description")), *p.Description)
+	}
+	if p.Discountable != nil {
+		body.Add(form.FormatKey(append(keyParts, "discountable")), strconv.FormatBool(*p.Discountable))
+	}
+	if p.InvoiceItem != nil {
+		body.Add(form.FormatKey(append(keyParts, "invoiceitem")), *p.InvoiceItem)
+	}
+	p.Period.encodeForm(body, append(keyParts, "period"))
+	if p.Quantity != nil {
+		body.Add(form.FormatKey(append(keyParts, "quantity")), strconv.FormatInt(*p.Quantity, 10))
+	}
+	if p.Schedule != nil {
+		body.Add(form.FormatKey(append(keyParts, "schedule")), *p.Schedule)
+	}
+	if p.TaxRates != nil && len(p.TaxRates) == 0 {
+		body.Add(form.FormatKey(append(keyParts, "tax_rates")), "")
+	} else {
+		for i1, v1 := range p.TaxRates {
+			if v1 != nil {
+				body.Add(form.FormatKey(append(keyParts, "tax_rates", strconv.Itoa(i1))), *v1)
+			}
+		}
+	}
+	if p.UnitAmount != nil {
+		body.Add(form.FormatKey(append(keyParts, "unit_amount")), strconv.FormatInt(*p.UnitAmount, 10))
+	}
+	if p.UnitAmountDecimal != nil {
+		body.Add(form.FormatKey(append(keyParts, "unit_amount_decimal")), strconv.FormatFloat(*p.UnitAmountDecimal, 'f', -1, 64))
+	}
+}
+
+func (p *InvoiceUpcomingInvoiceItemPeriodParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.End != nil {
+		body.Add(form.FormatKey(append(keyParts, "end")), strconv.FormatInt(*p.End, 10))
+	}
+	if p.Start != nil {
+		body.Add(form.FormatKey(append(keyParts, "start")), strconv.FormatInt(*p.Start, 10))
+	}
+}
+
+func (p *InvoiceVoidParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+}
+
+func (p *IssuingAuthorizationControlsSpendingLimitsParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.Amount != nil {
+		body.Add(form.FormatKey(append(keyParts, "amount")), strconv.FormatInt(*p.Amount, 10))
+	}
+	if p.Categories != nil && len(p.Categories) == 0 {
+		body.Add(form.FormatKey(append(keyParts, "categories")), "")
+	} else {
+		for i1, v1 := range p.Categories {
+			if v1 != nil {
+				body.Add(form.FormatKey(append(keyParts, "categories", strconv.Itoa(i1))), *v1)
+			}
+		}
+	}
+	if p.Interval != nil {
+		body.Add(form.FormatKey(append(keyParts, "interval")), *p.Interval)
+	}
+}
+
+func (p *IssuingAuthorizationListParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.ListParams.encodeForm(body, keyParts)
+	if p.Card != nil {
+		body.Add(form.FormatKey(append(keyParts, "card")), *p.Card)
+	}
+	if p.Cardholder != nil {
+		body.Add(form.FormatKey(append(keyParts, "cardholder")), *p.Cardholder)
+	}
+	if p.Created != nil {
+		body.Add(form.FormatKey(append(keyParts, "created")), strconv.FormatInt(*p.Created, 10))
+	}
+	p.CreatedRange.encodeForm(body, append(keyParts, "created"))
+	if p.Status != nil {
+		body.Add(form.FormatKey(append(keyParts, "status")), *p.Status)
+	}
+}
+
+func (p *IssuingAuthorizationParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	if p.HeldAmount != nil {
+		body.Add(form.FormatKey(append(keyParts, "held_amount")), strconv.FormatInt(*p.HeldAmount, 10))
+	}
+}
+
+func (p *IssuingBillingParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Address.encodeForm(body, append(keyParts, "address"))
+	if p.Name != nil {
+		body.Add(form.FormatKey(append(keyParts, "name")), *p.Name)
+	}
+}
+
+func (p *IssuingCardListParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.ListParams.encodeForm(body, keyParts)
+	if p.Cardholder != nil {
+		body.Add(form.FormatKey(append(keyParts, "cardholder")), *p.Cardholder)
+	}
+	if p.Created != nil {
+		body.Add(form.FormatKey(append(keyParts, "created")), strconv.FormatInt(*p.Created, 10))
+	}
+	p.CreatedRange.encodeForm(body, append(keyParts, "created"))
+	if p.ExpMonth != nil {
+		body.Add(form.FormatKey(append(keyParts, "exp_month")), strconv.FormatInt(*p.ExpMonth, 10))
+	}
+	if p.ExpYear != nil {
+		body.Add(form.FormatKey(append(keyParts, "exp_year")), strconv.FormatInt(*p.ExpYear, 10))
+	}
+	if p.Name != nil {
+		body.Add(form.FormatKey(append(keyParts, "name")), *p.Name)
+	}
+	if p.Last4 != nil {
+		body.Add(form.FormatKey(append(keyParts, "last4")), *p.Last4)
+	}
+	if p.Source != nil {
+		body.Add(form.FormatKey(append(keyParts, "source")), *p.Source)
+	}
+	if p.Status != nil {
+		body.Add(form.FormatKey(append(keyParts, "status")), *p.Status)
+	}
+	if p.Type != nil {
+		body.Add(form.FormatKey(append(keyParts, "type")), *p.Type)
+	}
+}
+
+func (p *IssuingCardParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	p.AuthorizationControls.encodeForm(body, append(keyParts, "authorization_controls"))
+	p.Billing.encodeForm(body, append(keyParts, "billing"))
+	if p.Cardholder != nil {
+		body.Add(form.FormatKey(append(keyParts, "cardholder")), *p.Cardholder)
+	}
+	if p.Currency != nil {
+		body.Add(form.FormatKey(append(keyParts, "currency")), *p.Currency)
+	}
+	if p.Name != nil {
+		body.Add(form.FormatKey(append(keyParts, "name")), *p.Name)
+	}
+	if p.ReplacementFor != nil {
+		body.Add(form.FormatKey(append(keyParts, "replacement_for")), *p.ReplacementFor)
+	}
+	if p.ReplacementReason != nil {
+		body.Add(form.FormatKey(append(keyParts, "replacement_reason")), *p.ReplacementReason)
+	}
+	if p.Status != nil {
+		body.Add(form.FormatKey(append(keyParts, "status")), *p.Status)
+	}
+	p.Shipping.encodeForm(body, append(keyParts, "shipping"))
+	if p.Type != nil {
+		body.Add(form.FormatKey(append(keyParts, "type")), *p.Type)
+	}
+}
+
+func (p *IssuingCardShippingParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Address.encodeForm(body, append(keyParts, "address"))
+	if !(p.Name == "") {
+		body.Add(form.FormatKey(append(keyParts, "name")), p.Name)
+	}
+	if p.Speed != nil {
+		body.Add(form.FormatKey(append(keyParts, "speed")), *p.Speed)
+	}
+	if p.Type != nil {
+		body.Add(form.FormatKey(append(keyParts, "type")), *p.Type)
+	}
+}
+
+func (p *IssuingCardholderCompanyParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.TaxID != nil {
+		body.Add(form.FormatKey(append(keyParts, "tax_id")), *p.TaxID)
+	}
+}
+
+func (p *IssuingCardholderIndividualDOBParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.Day != nil {
+		body.Add(form.FormatKey(append(keyParts, "day")), strconv.FormatInt(*p.Day, 10))
+	}
+	if p.Month != nil {
+		body.Add(form.FormatKey(append(keyParts, "month")), strconv.FormatInt(*p.Month, 10))
+	}
+	if p.Year != nil {
+		body.Add(form.FormatKey(append(keyParts, "year")), strconv.FormatInt(*p.Year, 10))
+	}
+}
+
+func (p *IssuingCardholderIndividualParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.DOB.encodeForm(body, append(keyParts, "dob"))
+	if p.FirstName != nil {
+		body.Add(form.FormatKey(append(keyParts, "first_name")), *p.FirstName)
+	}
+	if p.LastName != nil {
+		body.Add(form.FormatKey(append(keyParts, "last_name")), *p.LastName)
+	}
+	p.Verification.encodeForm(body, append(keyParts, "verification"))
+}
+
+func (p *IssuingCardholderIndividualVerificationDocumentParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.Back != nil {
+		body.Add(form.FormatKey(append(keyParts, "back")), *p.Back)
+	}
+	if p.Front != nil {
+		body.Add(form.FormatKey(append(keyParts, "front")), *p.Front)
+	}
+}
+
+func (p *IssuingCardholderIndividualVerificationParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Document.encodeForm(body, append(keyParts, "document"))
+}
+
+func (p *IssuingCardholderListParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.ListParams.encodeForm(body, keyParts)
+	if p.Created != nil {
+		body.Add(form.FormatKey(append(keyParts, "created")), strconv.FormatInt(*p.Created, 10))
+	}
+	p.CreatedRange.encodeForm(body, append(keyParts, "created"))
+	if p.Email != nil {
+		body.Add(form.FormatKey(append(keyParts, "email")), *p.Email)
+	}
+	if p.IsDefault != nil {
+		body.Add(form.FormatKey(append(keyParts, "is_default")), strconv.FormatBool(*p.IsDefault))
+	}
+	if p.PhoneNumber != nil {
+		body.Add(form.FormatKey(append(keyParts, "phone_number")), *p.PhoneNumber)
+	}
+	if p.Status != nil {
+		body.Add(form.FormatKey(append(keyParts, "status")), *p.Status)
+	}
+	if p.Type != nil {
+		body.Add(form.FormatKey(append(keyParts, "type")), *p.Type)
+	}
+}
+
+func (p *IssuingCardholderParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	p.AuthorizationControls.encodeForm(body, append(keyParts, "authorization_controls"))
+	p.Billing.encodeForm(body, append(keyParts, "billing"))
+	p.Company.encodeForm(body, append(keyParts, "company"))
+	if p.Email != nil {
+		body.Add(form.FormatKey(append(keyParts, "email")), *p.Email)
+	}
+	p.Individual.encodeForm(body, append(keyParts, "individual"))
+	if p.IsDefault != nil {
+		body.Add(form.FormatKey(append(keyParts, "is_default")), strconv.FormatBool(*p.IsDefault))
+	}
+	if p.Name != nil {
+		body.Add(form.FormatKey(append(keyParts, "name")), *p.Name)
+	}
+	if p.PhoneNumber != nil {
+		body.Add(form.FormatKey(append(keyParts, "phone_number")), *p.PhoneNumber)
+	}
+	if p.Status != nil {
+		body.Add(form.FormatKey(append(keyParts, "status")), *p.Status)
+	}
+	if p.Type != nil {
+		body.Add(form.FormatKey(append(keyParts, "type")), *p.Type)
+	}
+}
+
+func (p *IssuingDisputeEvidenceFraudulentParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.DisputeExplanation != nil {
+		body.Add(form.FormatKey(append(keyParts, "dispute_explanation")), *p.DisputeExplanation)
+	}
+	if p.UncategorizedFile != nil {
+		body.Add(form.FormatKey(append(keyParts, "uncategorized_file")), *p.UncategorizedFile)
+	}
+}
+
+func (p *IssuingDisputeEvidenceOtherParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.DisputeExplanation != nil {
+		body.Add(form.FormatKey(append(keyParts, "dispute_explanation")), *p.DisputeExplanation)
+	}
+	if p.UncategorizedFile != nil {
+		body.Add(form.FormatKey(append(keyParts, "uncategorized_file")), *p.UncategorizedFile)
+	}
+}
+
+func (p *IssuingDisputeEvidenceParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Fraudulent.encodeForm(body, append(keyParts, "fraudulent"))
+	p.Other.encodeForm(body, append(keyParts, "other"))
+}
+
+func (p *IssuingDisputeListParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.ListParams.encodeForm(body, keyParts)
+	if p.Created != nil {
+		body.Add(form.FormatKey(append(keyParts, "created")), strconv.FormatInt(*p.Created, 10))
+	}
+	p.CreatedRange.encodeForm(body, append(keyParts, "created"))
+	if p.DisputedTransaction != nil {
+		body.Add(form.FormatKey(append(keyParts, "disputed_transaction")), *p.DisputedTransaction)
+	}
+	if p.Transaction != nil {
+		body.Add(form.FormatKey(append(keyParts, "transaction")), *p.Transaction)
+	}
+}
+
+func (p *IssuingDisputeParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	if p.Amount != nil {
+		body.Add(form.FormatKey(append(keyParts, "amount")), strconv.FormatInt(*p.Amount, 10))
+	}
+	p.Evidence.encodeForm(body, append(keyParts, "evidence"))
+	if p.Reason != nil {
+		body.Add(form.FormatKey(append(keyParts, "reason")), *p.Reason)
+	}
+	if p.DisputedTransaction != nil {
+		body.Add(form.FormatKey(append(keyParts, "disputed_transaction")), *p.DisputedTransaction)
+	}
+}
+
+func (p *IssuingTransactionListParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.ListParams.encodeForm(body, keyParts)
+	if p.Card != nil {
+		body.Add(form.FormatKey(append(keyParts, "card")), *p.Card)
+	}
+	if p.Cardholder != nil {
+		body.Add(form.FormatKey(append(keyParts, "cardholder")), *p.Cardholder)
+	}
+	if p.Created != nil {
+		body.Add(form.FormatKey(append(keyParts, "created")), strconv.FormatInt(*p.Created, 10))
+	}
+	p.CreatedRange.encodeForm(body, append(keyParts, "created"))
+	if p.Dispute != nil {
+		body.Add(form.FormatKey(append(keyParts, "dispute")), *p.Dispute)
+	}
+}
+
+func (p *IssuingTransactionParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+}
+
+func (p *ListParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.EndingBefore != nil {
+		body.Add(form.FormatKey(append(keyParts, "ending_before")), *p.EndingBefore)
+	}
+	if p.Expand != nil && len(p.Expand) == 0 {
+		body.Add(form.FormatKey(append(keyParts, "expand")), "")
+	} else {
+		for i1, v1 := range p.Expand {
+			if v1 != nil {
+				body.Add(form.FormatKey(append(keyParts, "expand", strconv.Itoa(i1))), *v1)
+			}
+		}
+	}
+	p.Filters.encodeForm(body, keyParts)
+	p.Filters.AppendTo(body, keyParts)
+	if p.Limit != nil {
+		body.Add(form.FormatKey(append(keyParts, "limit")), strconv.FormatInt(*p.Limit, 10))
+	}
+	if p.StartingAfter != nil {
+		body.Add(form.FormatKey(append(keyParts, "starting_after")), *p.StartingAfter)
+	}
+}
+
+func (p *LoginLinkParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	if p.RedirectURL != nil {
+		body.Add(form.FormatKey(append(keyParts, "redirect_url")), *p.RedirectURL)
+	}
+}
+
+func (p *MandateParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+}
+
+func (p *OAuthStripeUserParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.BlockKana != nil {
+		body.Add(form.FormatKey(append(keyParts, "block_kana")), *p.BlockKana)
+	}
+	if p.BlockKanji != nil {
+		body.Add(form.FormatKey(append(keyParts, "block_kanji")), *p.BlockKanji)
+	}
+	if p.BuildingKana != nil {
+		body.Add(form.FormatKey(append(keyParts, "building_kana")), *p.BuildingKana)
+	}
+	if p.BuildingKanji != nil {
+		body.Add(form.FormatKey(append(keyParts, "building_kanji")), *p.BuildingKanji)
+	}
+	if p.BusinessName != nil {
+		body.Add(form.FormatKey(append(keyParts, "business_name")), *p.BusinessName)
+	}
+	if p.BusinessType != nil {
+		body.Add(form.FormatKey(append(keyParts, "business_type")), *p.BusinessType)
+	}
+	if p.City != nil {
+		body.Add(form.FormatKey(append(keyParts, "city")), *p.City)
+	}
+	if p.Country != nil {
+		body.Add(form.FormatKey(append(keyParts, "country")), *p.Country)
+	}
+	if p.Currency != nil {
+		body.Add(form.FormatKey(append(keyParts, "currency")), *p.Currency)
+	}
+	if p.DOBDay != nil {
+		body.Add(form.FormatKey(append(keyParts, "dob_day")), strconv.FormatInt(*p.DOBDay, 10))
+	}
+	if p.DOBMonth != nil {
+		body.Add(form.FormatKey(append(keyParts, "dob_month")), strconv.FormatInt(*p.DOBMonth, 10))
+	}
+	if p.DOBYear != nil {
+		body.Add(form.FormatKey(append(keyParts, "dob_year")), strconv.FormatInt(*p.DOBYear, 10))
+	}
+	if p.Email != nil {
+		body.Add(form.FormatKey(append(keyParts, "email")), *p.Email)
+	}
+	if p.FirstName != nil {
+		body.Add(form.FormatKey(append(keyParts, "first_name")), *p.FirstName)
+	}
+	if p.FirstNameKana != nil {
+		body.Add(form.FormatKey(append(keyParts, "first_name_kana")), *p.FirstNameKana)
+	}
+	if p.FirstNameKanji != nil {
+		body.Add(form.FormatKey(append(keyParts, "first_name_kanji")), *p.FirstNameKanji)
+	}
+	if p.Gender != nil {
+		body.Add(form.FormatKey(append(keyParts, "gender")), *p.Gender)
+	}
+	if p.LastName != nil {
+		body.Add(form.FormatKey(append(keyParts, "last_name")), *p.LastName)
+	}
+	if p.LastNameKana != nil {
+		body.Add(form.FormatKey(append(keyParts, "last_name_kana")), *p.LastNameKana)
+	}
+	if p.LastNameKanji != nil {
+		body.Add(form.FormatKey(append(keyParts, "last_name_kanji")), *p.LastNameKanji)
+	}
+	if p.PhoneNumber != nil {
+		body.Add(form.FormatKey(append(keyParts, "phone_number")), *p.PhoneNumber)
+	}
+	if p.PhysicalProduct != nil {
+		body.Add(form.FormatKey(append(keyParts, "physical_product")), strconv.FormatBool(*p.PhysicalProduct))
+	}
+	if p.ProductDescription != nil {
+		body.Add(form.FormatKey(append(keyParts, "product_description")), *p.ProductDescription)
+	}
+	if p.State != nil {
+		body.Add(form.FormatKey(append(keyParts, "state")), *p.State)
+	}
+	if p.StreetAddress != nil {
+		body.Add(form.FormatKey(append(keyParts, "street_address")), *p.StreetAddress)
+	}
+	if p.URL != nil {
+		body.Add(form.FormatKey(append(keyParts, "url")), *p.URL)
+	}
+	if p.Zip != nil {
+		body.Add(form.FormatKey(append(keyParts, "zip")), *p.Zip)
+	}
+}
+
+func (p *OAuthTokenParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	if p.AssertCapabilities != nil && len(p.AssertCapabilities) == 0 {
+		body.Add(form.FormatKey(append(keyParts, "assert_capabilities")), "")
+	} else {
+		for i1, v1 := range p.AssertCapabilities {
+			if v1 != nil {
+				body.Add(form.FormatKey(append(keyParts, "assert_capabilities", strconv.Itoa(i1))), *v1)
+			}
+		}
+	}
+	if p.ClientSecret != nil {
+		body.Add(form.FormatKey(append(keyParts, "client_secret")), *p.ClientSecret)
+	}
+	if p.Code != nil {
+		body.Add(form.FormatKey(append(keyParts, "code")), *p.Code)
+	}
+	if p.GrantType != nil {
+		body.Add(form.FormatKey(append(keyParts, "grant_type")), *p.GrantType)
+	}
+	if p.RefreshToken != nil {
+		body.Add(form.FormatKey(append(keyParts, "refresh_token")), *p.RefreshToken)
+	}
+	if p.Scope != nil {
+		body.Add(form.FormatKey(append(keyParts, "scope")), *p.Scope)
+	}
+}
+
+func (p *OrderItemParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.Amount != nil {
+		body.Add(form.FormatKey(append(keyParts, "amount")), strconv.FormatInt(*p.Amount, 10))
+	}
+	if p.Currency != nil {
+		body.Add(form.FormatKey(append(keyParts, "currency")), *p.Currency)
+	}
+	if p.Description != nil {
+		body.Add(form.FormatKey(append(keyParts, "description")), *p.Description)
+	}
+	if p.Parent != nil {
+		body.Add(form.FormatKey(append(keyParts, "parent")), *p.Parent)
+	}
+	if p.Quantity != nil {
+		body.Add(form.FormatKey(append(keyParts, "quantity")), strconv.FormatInt(*p.Quantity, 10))
+	}
+	if p.Type != nil {
+		body.Add(form.FormatKey(append(keyParts, "type")), *p.Type)
+	}
+}
+
+func (p *OrderListParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.ListParams.encodeForm(body, keyParts)
+	if p.Created != nil {
+		body.Add(form.FormatKey(append(keyParts, "created")), strconv.FormatInt(*p.Created, 10))
+	}
+	p.CreatedRange.encodeForm(body, append(keyParts, "created"))
+	if p.Customer != nil {
+		body.Add(form.FormatKey(append(keyParts, "customer")), *p.Customer)
+	}
+	if p.IDs != nil && len(p.IDs) == 0 {
+		body.Add(form.FormatKey(append(keyParts, "ids")), "")
+	} else {
+		for i1, v1 := range p.IDs {
+			if v1 != nil {
+				body.Add(form.FormatKey(append(keyParts, "ids", strconv.Itoa(i1))), *v1)
+			}
+		}
+	}
+	if p.Status != nil {
+		body.Add(form.FormatKey(append(keyParts, "status")), *p.Status)
+	}
+	p.StatusTransitions.encodeForm(body, append(keyParts, "status_transitions"))
+	if p.UpstreamIDs != nil && len(p.UpstreamIDs) == 0 {
+		body.Add(form.FormatKey(append(keyParts, "upstream_ids")), "")
+	} else {
+		for i2, v2 := range p.UpstreamIDs {
+			if v2 != nil {
+				body.Add(form.FormatKey(append(keyParts, "upstream_ids", strconv.Itoa(i2))), *v2)
+			}
+		}
+	}
+}
+
+func (p *OrderParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	if p.Coupon != nil {
+		body.Add(form.FormatKey(append(keyParts, "coupon")), *p.Coupon)
+	}
+	if p.Currency != nil {
+		body.Add(form.FormatKey(append(keyParts, "currency")), *p.Currency)
+	}
+	if p.Customer != nil {
+		body.Add(form.FormatKey(append(keyParts, "customer")), *p.Customer)
+	}
+	if p.Email != nil {
+		body.Add(form.FormatKey(append(keyParts, "email")), *p.Email)
+	}
+	if p.Items != nil && len(p.Items) == 0 {
+		body.Add(form.FormatKey(append(keyParts, "items")), "")
+	} else {
+		for i1, v1 := range p.Items {
+			v1.encodeForm(body, append(keyParts, "items", strconv.Itoa(i1)))
+		}
+	}
+	p.Shipping.encodeForm(body, append(keyParts, "shipping"))
+}
+
+func (p *OrderPayParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	if p.ApplicationFee != nil {
+		body.Add(form.FormatKey(append(keyParts, "application_fee")), strconv.FormatInt(*p.ApplicationFee, 10))
+	}
+	if p.Customer != nil {
+		body.Add(form.FormatKey(append(keyParts, "customer")), *p.Customer)
+	}
+	if p.Email != nil {
+		body.Add(form.FormatKey(append(keyParts, "email")), *p.Email)
+	}
+	p.Source.encodeForm(body, keyParts)
+	if p.Source != nil {
+		p.Source.AppendTo(body, keyParts)
+	}
+}
+
+func (p *OrderReturnListParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.ListParams.encodeForm(body, keyParts)
+	if p.Created != nil {
+		body.Add(form.FormatKey(append(keyParts, "created")), strconv.FormatInt(*p.Created, 10))
+	}
+	p.CreatedRange.encodeForm(body, append(keyParts, "created"))
+	if p.Order != nil {
+		body.Add(form.FormatKey(append(keyParts, "order")), *p.Order)
+	}
+}
+
+func (p *OrderReturnParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	if p.Items != nil && len(p.Items) == 0 {
+		body.Add(form.FormatKey(append(keyParts, "items")), "")
+	} else {
+		for i1, v1 := range p.Items {
+			v1.encodeForm(body, append(keyParts, "items", strconv.Itoa(i1)))
+		}
+	}
+}
+
+func (p *OrderUpdateParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	if p.Coupon != nil {
+		body.Add(form.FormatKey(append(keyParts, "coupon")), *p.Coupon)
+	}
+	if p.SelectedShippingMethod != nil {
+		body.Add(form.FormatKey(append(keyParts, "selected_shipping_method")), *p.SelectedShippingMethod)
+	}
+	p.Shipping.encodeForm(body, append(keyParts, "shipping"))
+	if p.Status != nil {
+		body.Add(form.FormatKey(append(keyParts, "status")), *p.Status)
+	}
+}
+
+func (p *OrderUpdateShippingParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.Carrier != nil {
+		body.Add(form.FormatKey(append(keyParts, "carrier")), *p.Carrier)
+	}
+	if p.TrackingNumber != nil {
+		body.Add(form.FormatKey(append(keyParts, "tracking_number")), *p.TrackingNumber)
+	}
+}
+
+func (p *PIIParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	if p.IDNumber != nil {
+		body.Add(form.FormatKey(append(keyParts, "id_number")), *p.IDNumber)
+	}
+}
+
+func (p *PackageDimensionsParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.Height != nil {
+		body.Add(form.FormatKey(append(keyParts, "height")), strconv.FormatFloat(*p.Height, 'f', 4, 64))
+	}
+	if p.Length != nil {
+		body.Add(form.FormatKey(append(keyParts, "length")), strconv.FormatFloat(*p.Length, 'f', 4, 64))
+	}
+	if p.Weight != nil {
+		body.Add(form.FormatKey(append(keyParts, "weight")), strconv.FormatFloat(*p.Weight, 'f', 4, 64))
+	}
+	if p.Width != nil {
+		body.Add(form.FormatKey(append(keyParts, "width")), strconv.FormatFloat(*p.Width, 'f', 4, 64))
+	}
+}
+
+func (p *Params) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.Expand != nil && len(p.Expand) == 0 {
+		body.Add(form.FormatKey(append(keyParts, "expand")), "")
+	} else {
+		for i1, v1 := range p.Expand {
+			if v1 != nil {
+				body.Add(form.FormatKey(append(keyParts, "expand", strconv.Itoa(i1))), *v1)
+			}
+		}
+	}
+	p.Extra.encodeForm(body, keyParts)
+	if p.Extra != nil {
+		p.Extra.AppendTo(body, keyParts)
+	}
+	for k2, v2 := range p.Metadata {
+		body.Add(form.FormatKey(append(keyParts, "metadata", k2)), v2)
+	}
+}
+
+func (p *PaymentIntentCancelParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	if p.CancellationReason != nil {
+		body.Add(form.FormatKey(append(keyParts, "cancellation_reason")), *p.CancellationReason)
+	}
+}
+
+func (p *PaymentIntentCaptureParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	if p.AmountToCapture != nil {
+		body.Add(form.FormatKey(append(keyParts, "amount_to_capture")), strconv.FormatInt(*p.AmountToCapture, 10))
+	}
+	if p.ApplicationFeeAmount != nil {
+		body.Add(form.FormatKey(append(keyParts, "application_fee_amount")), strconv.FormatInt(*p.ApplicationFeeAmount, 10))
+	}
+	if p.StatementDescriptor != nil {
+		body.Add(form.FormatKey(append(keyParts, "statement_descriptor")), *p.StatementDescriptor)
+	}
+	if p.StatementDescriptorSuffix != nil {
+		body.Add(form.FormatKey(append(keyParts, "statement_descriptor_suffix")), *p.StatementDescriptorSuffix)
+	}
+	p.TransferData.encodeForm(body, append(keyParts, "transfer_data"))
+}
+
+func (p *PaymentIntentConfirmParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	if p.ErrorOnRequiresAction != nil {
+		body.Add(form.FormatKey(append(keyParts, "error_on_requires_action")), strconv.FormatBool(*p.ErrorOnRequiresAction))
+	}
+	if p.Mandate != nil {
+		body.Add(form.FormatKey(append(keyParts, "mandate")), *p.Mandate)
+	}
+	p.MandateData.encodeForm(body, append(keyParts, "mandate_data"))
+	if p.OffSession != nil {
+		body.Add(form.FormatKey(append(keyParts, "off_session")), strconv.FormatBool(*p.OffSession))
+	}
+	if p.PaymentMethod != nil {
+		body.Add(form.FormatKey(append(keyParts, "payment_method")), *p.PaymentMethod)
+	}
+	p.PaymentMethodOptions.encodeForm(body, append(keyParts, "payment_method_options"))
+	if p.PaymentMethodTypes != nil && len(p.PaymentMethodTypes) == 0 {
+		body.Add(form.FormatKey(append(keyParts, "payment_method_types")), "")
+	} else {
+		for i1, v1 := range p.PaymentMethodTypes {
+			if v1 != nil {
+				body.Add(form.FormatKey(append(keyParts, "payment_method_types", strconv.Itoa(i1))), *v1)
+			}
+		}
+	}
+	if p.ReceiptEmail != nil {
+		body.Add(form.FormatKey(append(keyParts, "receipt_email")), *p.ReceiptEmail)
+	}
+	if p.ReturnURL != nil {
+		body.Add(form.FormatKey(append(keyParts, "return_url")), *p.ReturnURL)
+	}
+	if p.SavePaymentMethod != nil {
+		body.Add(form.FormatKey(append(keyParts, "save_payment_method")), strconv.FormatBool(*p.SavePaymentMethod))
+	}
+	if p.SetupFutureUsage != nil {
+		body.Add(form.FormatKey(append(keyParts, "setup_future_usage")), *p.SetupFutureUsage)
+	}
+	p.Shipping.encodeForm(body, append(keyParts, "shipping"))
+	if p.Source != nil {
+		body.Add(form.FormatKey(append(keyParts, "source")), *p.Source)
+	}
+	if p.UseStripeSDK != nil {
+		body.Add(form.FormatKey(append(keyParts, "use_stripe_sdk")), strconv.FormatBool(*p.UseStripeSDK))
+	}
+}
+
+func (p *PaymentIntentListParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.ListParams.encodeForm(body, keyParts)
+	if p.Created != nil {
+		body.Add(form.FormatKey(append(keyParts, "created")), strconv.FormatInt(*p.Created, 10))
+	}
+	p.CreatedRange.encodeForm(body, append(keyParts, "created"))
+	if p.Customer != nil {
+		body.Add(form.FormatKey(append(keyParts, "customer")), *p.Customer)
+	}
+}
+
+func (p *PaymentIntentMandateDataCustomerAcceptanceOfflineParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+}
+
+func (p *PaymentIntentMandateDataCustomerAcceptanceOnlineParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.IPAddress != nil {
+		body.Add(form.FormatKey(append(keyParts, "ip_address")), *p.IPAddress)
+	}
+	if p.UserAgent != nil {
+		body.Add(form.FormatKey(append(keyParts, "user_agent")), *p.UserAgent)
+	}
+}
+
+func (p *PaymentIntentMandateDataCustomerAcceptanceParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if !(p.AcceptedAt == 0) {
+		body.Add(form.FormatKey(append(keyParts, "accepted_at")), strconv.FormatInt(p.AcceptedAt, 10))
+	}
+	p.Offline.encodeForm(body, append(keyParts, "offline"))
+	p.Online.encodeForm(body, append(keyParts, "online"))
+	if !(p.Type == "") {
+		body.Add(form.FormatKey(append(keyParts, "type")), string(p.Type))
+	}
+}
+
+func (p *PaymentIntentMandateDataParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.CustomerAcceptance.encodeForm(body, append(keyParts, "customer_acceptance"))
+}
+
+func (p *PaymentIntentParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	if p.Amount != nil {
+		body.Add(form.FormatKey(append(keyParts, "amount")), strconv.FormatInt(*p.Amount, 10))
+	}
+	if p.ApplicationFeeAmount != nil {
+		body.Add(form.FormatKey(append(keyParts, "application_fee_amount")), strconv.FormatInt(*p.ApplicationFeeAmount, 10))
+	}
+	if p.CaptureMethod != nil {
+		body.Add(form.FormatKey(append(keyParts, "capture_method")), *p.CaptureMethod)
+	}
+	if p.Confirm != nil {
+		body.Add(form.FormatKey(append(keyParts, "confirm")), strconv.FormatBool(*p.Confirm))
+	}
+	if p.ConfirmationMethod != nil {
+		body.Add(form.FormatKey(append(keyParts, "confirmation_method")), *p.ConfirmationMethod)
+	}
+	if p.Currency != nil {
+		body.Add(form.FormatKey(append(keyParts, "currency")), *p.Currency)
+	}
+	if p.Customer != nil {
+		body.Add(form.FormatKey(append(keyParts, "customer")), *p.Customer)
+	}
+	if p.Description != nil {
+		body.Add(form.FormatKey(append(keyParts, "description")), *p.Description)
+	}
+	if p.Mandate != nil {
+		body.Add(form.FormatKey(append(keyParts, "mandate")), *p.Mandate)
+	}
+	p.MandateData.encodeForm(body, append(keyParts, "mandate_data"))
+	if p.OnBehalfOf != nil {
+		body.Add(form.FormatKey(append(keyParts, "on_behalf_of")), *p.OnBehalfOf)
+	}
+	if p.PaymentMethod != nil {
+		body.Add(form.FormatKey(append(keyParts, "payment_method")), *p.PaymentMethod)
+	}
+	p.PaymentMethodOptions.encodeForm(body, append(keyParts, "payment_method_options"))
+	if p.PaymentMethodTypes != nil && len(p.PaymentMethodTypes) == 0 {
+		body.Add(form.FormatKey(append(keyParts, "payment_method_types")), "")
+	} else {
+		for i1, v1 := range p.PaymentMethodTypes {
+			if v1 != nil {
+				body.Add(form.FormatKey(append(keyParts, "payment_method_types", strconv.Itoa(i1))), *v1)
+			}
+		}
+	}
+	if p.ReceiptEmail != nil {
+		body.Add(form.FormatKey(append(keyParts, "receipt_email")), *p.ReceiptEmail)
+	}
+	if p.ReturnURL != nil {
+		body.Add(form.FormatKey(append(keyParts, "return_url")), *p.ReturnURL)
+	}
+	if p.SavePaymentMethod != nil {
+		body.Add(form.FormatKey(append(keyParts, "save_payment_method")), strconv.FormatBool(*p.SavePaymentMethod))
+	}
+	if p.SetupFutureUsage != nil {
+		body.Add(form.FormatKey(append(keyParts, "setup_future_usage")), *p.SetupFutureUsage)
+	}
+	p.Shipping.encodeForm(body, append(keyParts, "shipping"))
+	if p.Source != nil {
+		body.Add(form.FormatKey(append(keyParts, "source")), *p.Source)
+	}
+	if p.StatementDescriptor != nil {
+		body.Add(form.FormatKey(append(keyParts, "statement_descriptor")), *p.StatementDescriptor)
+	}
+	if p.StatementDescriptorSuffix != nil {
+		body.Add(form.FormatKey(append(keyParts, "statement_descriptor_suffix")), *p.StatementDescriptorSuffix)
+	}
+	p.TransferData.encodeForm(body, append(keyParts, "transfer_data"))
+	if p.TransferGroup != nil {
+		body.Add(form.FormatKey(append(keyParts, "transfer_group")), *p.TransferGroup)
+	}
+	if p.ErrorOnRequiresAction != nil {
+		body.Add(form.FormatKey(append(keyParts, "error_on_requires_action")), strconv.FormatBool(*p.ErrorOnRequiresAction))
+	}
+	if p.OffSession != nil {
+		body.Add(form.FormatKey(append(keyParts, "off_session")), strconv.FormatBool(*p.OffSession))
+	}
+	if p.UseStripeSDK != nil {
+		body.Add(form.FormatKey(append(keyParts, "use_stripe_sdk")), strconv.FormatBool(*p.UseStripeSDK))
+	}
+}
+
+func (p *PaymentIntentPaymentMethodOptionsCardInstallmentsParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.Enabled != nil {
+		body.Add(form.FormatKey(append(keyParts, "enabled")), strconv.FormatBool(*p.Enabled))
+	}
+	p.Plan.encodeForm(body, append(keyParts, "plan"))
+}
+
+func (p *PaymentIntentPaymentMethodOptionsCardInstallmentsPlanParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.Count != nil {
+		body.Add(form.FormatKey(append(keyParts, "count")), strconv.FormatInt(*p.Count, 10))
+	}
+	if p.Interval != nil {
+		body.Add(form.FormatKey(append(keyParts, "interval")), *p.Interval)
+	}
+	if p.Type != nil {
+		body.Add(form.FormatKey(append(keyParts, "type")), *p.Type)
+	}
+}
+
+func (p *PaymentIntentPaymentMethodOptionsCardParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Installments.encodeForm(body, append(keyParts, "installments"))
+	if p.MOTO != nil {
+		body.Add(form.FormatKey(append(keyParts, "moto")), strconv.FormatBool(*p.MOTO))
+	}
+	if p.RequestThreeDSecure != nil {
+		body.Add(form.FormatKey(append(keyParts, "request_three_d_secure")), *p.RequestThreeDSecure)
+	}
+}
+
+func (p *PaymentIntentPaymentMethodOptionsParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Card.encodeForm(body, append(keyParts, "card"))
+}
+
+func (p *PaymentIntentTransferDataParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.Amount != nil {
+		body.Add(form.FormatKey(append(keyParts, "amount")), strconv.FormatInt(*p.Amount, 10))
+	}
+	if p.Destination != nil {
+		body.Add(form.FormatKey(append(keyParts, "destination")), *p.Destination)
+	}
+}
+
+func (p *PaymentMethodAUBECSDebitParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.AccountNumber != nil {
+		body.Add(form.FormatKey(append(keyParts, "account_number")), *p.AccountNumber)
+	}
+	if p.BSBNumber != nil {
+		body.Add(form.FormatKey(append(keyParts, "bsb_number")), *p.BSBNumber)
+	}
+}
+
+func (p *PaymentMethodAttachParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	if p.Customer != nil {
+		body.Add(form.FormatKey(append(keyParts, "customer")), *p.Customer)
+	}
+}
+
+func (p *PaymentMethodCardParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.CVC != nil {
+		body.Add(form.FormatKey(append(keyParts, "cvc")), *p.CVC)
+	}
+	if p.ExpMonth != nil {
+		body.Add(form.FormatKey(append(keyParts, "exp_month")), *p.ExpMonth)
+	}
+	if p.ExpYear != nil {
+		body.Add(form.FormatKey(append(keyParts, "exp_year")), *p.ExpYear)
+	}
+	if p.Number != nil {
+		body.Add(form.FormatKey(append(keyParts, "number")), *p.Number)
+	}
+	if p.Token != nil {
+		body.Add(form.FormatKey(append(keyParts, "token")), *p.Token)
+	}
+}
+
+func (p *PaymentMethodDetachParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+}
+
+func (p *PaymentMethodFPXParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.AccountHolderType != nil {
+		body.Add(form.FormatKey(append(keyParts, "account_holder_type")), *p.AccountHolderType)
+	}
+	if p.Bank != nil {
+		body.Add(form.FormatKey(append(keyParts, "bank")), *p.Bank)
+	}
+}
+
+func (p *PaymentMethodIdealParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.Bank != nil {
+		body.Add(form.FormatKey(append(keyParts, "bank")), *p.Bank)
+	}
+}
+
+func (p *PaymentMethodListParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.ListParams.encodeForm(body, keyParts)
+	if p.Customer != nil {
+		body.Add(form.FormatKey(append(keyParts, "customer")), *p.Customer)
+	}
+	if p.Type != nil {
+		body.Add(form.FormatKey(append(keyParts, "type")), *p.Type)
+	}
+}
+
+func (p *PaymentMethodParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	p.AUBECSDebit.encodeForm(body, append(keyParts, "au_becs_debit"))
+	p.BillingDetails.encodeForm(body, append(keyParts, "billing_details"))
+	p.Card.encodeForm(body, append(keyParts, "card"))
+	p.FPX.encodeForm(body, append(keyParts, "fpx"))
+	p.SepaDebit.encodeForm(body, append(keyParts, "sepa_debit"))
+	if p.Type != nil {
+		body.Add(form.FormatKey(append(keyParts, "type")), *p.Type)
+	}
+	if p.Customer != nil {
+		body.Add(form.FormatKey(append(keyParts, "customer")), *p.Customer)
+	}
+	if p.PaymentMethod != nil {
+		body.Add(form.FormatKey(append(keyParts, "payment_method")), *p.PaymentMethod)
+	}
+}
+
+func (p *PaymentMethodSepaDebitParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.Iban != nil {
+		body.Add(form.FormatKey(append(keyParts, "iban")), *p.Iban)
+	}
+}
+
+func (p *PayoutListParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.ListParams.encodeForm(body, keyParts)
+	if p.ArrivalDate != nil {
+		body.Add(form.FormatKey(append(keyParts, "arrival_date")), strconv.FormatInt(*p.ArrivalDate, 10))
+	}
+	p.ArrivalDateRange.encodeForm(body, append(keyParts, "arrival_date"))
+	if p.Created != nil {
+		body.Add(form.FormatKey(append(keyParts, "created")), strconv.FormatInt(*p.Created, 10))
+	}
+	p.CreatedRange.encodeForm(body, append(keyParts, "created"))
+	if p.Destination != nil {
+		body.Add(form.FormatKey(append(keyParts, "destination")), *p.Destination)
+	}
+	if p.Status != nil {
+		body.Add(form.FormatKey(append(keyParts, "status")), *p.Status)
+	}
+}
+
+func (p *PayoutParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	if p.Amount != nil {
+		body.Add(form.FormatKey(append(keyParts, "amount")), strconv.FormatInt(*p.Amount, 10))
+	}
+	if p.Currency != nil {
+		body.Add(form.FormatKey(append(keyParts, "currency")), *p.Currency)
+	}
+	if p.Description != nil {
+		body.Add(form.FormatKey(append(keyParts, "description")), *p.Description)
+	}
+	if p.Destination != nil {
+		body.Add(form.FormatKey(append(keyParts, "destination")), *p.Destination)
+	}
+	if p.Method != nil {
+		body.Add(form.FormatKey(append(keyParts, "method")), *p.Method)
+	}
+	if p.SourceType != nil {
+		body.Add(form.FormatKey(append(keyParts, "source_type")), *p.SourceType)
+	}
+	if p.StatementDescriptor != nil {
+		body.Add(form.FormatKey(append(keyParts, "statement_descriptor")), *p.StatementDescriptor)
+	}
+}
+
+func (p *PayoutScheduleParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.DelayDays != nil {
+		body.Add(form.FormatKey(append(keyParts, "delay_days")), strconv.FormatInt(*p.DelayDays, 10))
+	}
+	if p.Interval != nil {
+		body.Add(form.FormatKey(append(keyParts, "interval")), *p.Interval)
+	}
+	if p.MonthlyAnchor != nil {
+		body.Add(form.FormatKey(append(keyParts, "monthly_anchor")), strconv.FormatInt(*p.MonthlyAnchor, 10))
+	}
+	if p.WeeklyAnchor != nil {
+		body.Add(form.FormatKey(append(keyParts, "weekly_anchor")), *p.WeeklyAnchor)
+	}
+}
+
+func (p *PersonListParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.ListParams.encodeForm(body, keyParts)
+	p.Relationship.encodeForm(body, append(keyParts, "relationship"))
+}
+
+func (p *PersonParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	p.Address.encodeForm(body, append(keyParts, "address"))
+	p.AddressKana.encodeForm(body, append(keyParts, "address_kana"))
+	p.AddressKanji.encodeForm(body, append(keyParts, "address_kanji"))
+	p.DOB.encodeForm(body, append(keyParts, "dob"))
+	if p.Email != nil {
+		body.Add(form.FormatKey(append(keyParts, "email")), *p.Email)
+	}
+	if p.FirstName != nil {
+		body.Add(form.FormatKey(append(keyParts, "first_name")), *p.FirstName)
+	}
+	if p.FirstNameKana != nil {
+		body.Add(form.FormatKey(append(keyParts, "first_name_kana")), *p.FirstNameKana)
+	}
+	if p.FirstNameKanji != nil {
+		body.Add(form.FormatKey(append(keyParts, "first_name_kanji")), *p.FirstNameKanji)
+	}
+	if p.Gender != nil {
+		body.Add(form.FormatKey(append(keyParts, "gender")), *p.Gender)
+	}
+	if p.IDNumber != nil {
+		body.Add(form.FormatKey(append(keyParts, "id_number")), *p.IDNumber)
+	}
+	if p.LastName != nil {
+		body.Add(form.FormatKey(append(keyParts, "last_name")), *p.LastName)
+	}
+	if p.LastNameKana != nil {
+		body.Add(form.FormatKey(append(keyParts, "last_name_kana")), *p.LastNameKana)
+	}
+	if p.LastNameKanji != nil {
+		body.Add(form.FormatKey(append(keyParts, "last_name_kanji")), *p.LastNameKanji)
+	}
+	if p.MaidenName != nil {
+		body.Add(form.FormatKey(append(keyParts, "maiden_name")), *p.MaidenName)
+	}
+	if p.PersonToken != nil {
+		body.Add(form.FormatKey(append(keyParts, "person_token")), *p.PersonToken)
+	}
+	if p.Phone != nil {
+		body.Add(form.FormatKey(append(keyParts, "phone")), *p.Phone)
+	}
+	p.Relationship.encodeForm(body, append(keyParts, "relationship"))
+	if p.SSNLast4 != nil {
+		body.Add(form.FormatKey(append(keyParts, "ssn_last_4")), *p.SSNLast4)
+	}
+	p.Verification.encodeForm(body, append(keyParts, "verification"))
+}
+
+func (p *PersonVerificationDocumentParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.Back != nil {
+		body.Add(form.FormatKey(append(keyParts, "back")), *p.Back)
+	}
+	if p.Front != nil {
+		body.Add(form.FormatKey(append(keyParts, "front")), *p.Front)
+	}
+}
+
+func (p *PersonVerificationParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.AdditionalDocument.encodeForm(body, append(keyParts, "additional_document"))
+	p.Document.encodeForm(body, append(keyParts, "document"))
+}
+
+func (p *PlanListParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.ListParams.encodeForm(body, keyParts)
+	if p.Active != nil {
+		body.Add(form.FormatKey(append(keyParts, "active")), strconv.FormatBool(*p.Active))
+	}
+	if p.Created != nil {
+		body.Add(form.FormatKey(append(keyParts, "created")), strconv.FormatInt(*p.Created, 10))
+	}
+	p.CreatedRange.encodeForm(body, append(keyParts, "created"))
+	if p.Product != nil {
+		body.Add(form.FormatKey(append(keyParts, "product")), *p.Product)
+	}
+}
+
+func (p *PlanParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	if p.Active != nil {
+		body.Add(form.FormatKey(append(keyParts, "active")), strconv.FormatBool(*p.Active))
+	}
+	if p.AggregateUsage != nil {
+		body.Add(form.FormatKey(append(keyParts, "aggregate_usage")), *p.AggregateUsage)
+	}
+	if p.Amount != nil {
+		body.Add(form.FormatKey(append(keyParts, "amount")), strconv.FormatInt(*p.Amount, 10))
+	}
+	if p.AmountDecimal != nil {
+		body.Add(form.FormatKey(append(keyParts, "amount_decimal")), strconv.FormatFloat(*p.AmountDecimal, 'f', -1, 64))
+	}
+	if p.BillingScheme != nil {
+		body.Add(form.FormatKey(append(keyParts, "billing_scheme")), *p.BillingScheme)
+	}
+	if p.Currency != nil {
+		body.Add(form.FormatKey(append(keyParts, "currency")), *p.Currency)
+	}
+	if p.ID != nil {
+		body.Add(form.FormatKey(append(keyParts, "id")), *p.ID)
+	}
+	if p.Interval != nil {
+		body.Add(form.FormatKey(append(keyParts, "interval")), *p.Interval)
+	}
+	if p.IntervalCount != nil {
+		body.Add(form.FormatKey(append(keyParts, "interval_count")), strconv.FormatInt(*p.IntervalCount, 10))
+	}
+	if p.Nickname != nil {
+		body.Add(form.FormatKey(append(keyParts, "nickname")), *p.Nickname)
+	}
+	p.Product.encodeForm(body, append(keyParts, "product"))
+	if p.ProductID != nil {
+		body.Add(form.FormatKey(append(keyParts, "product")), *p.ProductID)
+	}
+	if p.Tiers != nil && len(p.Tiers) == 0 {
+		body.Add(form.FormatKey(append(keyParts, "tiers")), "")
+	} else {
+		for i1, v1 := range p.Tiers {
+			v1.encodeForm(body, append(keyParts, "tiers", strconv.Itoa(i1)))
+			if v1 != nil {
+				v1.AppendTo(body, append(keyParts, "tiers", strconv.Itoa(i1)))
+			}
+		}
+	}
+	if p.TiersMode != nil {
+		body.Add(form.FormatKey(append(keyParts, "tiers_mode")), *p.TiersMode)
+	}
+	p.TransformUsage.encodeForm(body, append(keyParts, "transform_usage"))
+	if p.TrialPeriodDays != nil {
+		body.Add(form.FormatKey(append(keyParts, "trial_period_days")), strconv.FormatInt(*p.TrialPeriodDays, 10))
+	}
+	if p.UsageType != nil {
+		body.Add(form.FormatKey(append(keyParts, "usage_type")), *p.UsageType)
+	}
+}
+
+func (p *PlanProductParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.Active != nil {
+		body.Add(form.FormatKey(append(keyParts, "active")), strconv.FormatBool(*p.Active))
+	}
+	if p.ID != nil {
+		body.Add(form.FormatKey(append(keyParts, "id")), *p.ID)
+	}
+	if p.Name != nil {
+		body.Add(form.FormatKey(append(keyParts, "name")), *p.Name)
+	}
+	for k1, v1 := range p.Metadata {
+		body.Add(form.FormatKey(append(keyParts, "metadata", k1)), v1)
+	}
+	if p.StatementDescriptor != nil {
+		body.Add(form.FormatKey(append(keyParts, "statement_descriptor")), *p.StatementDescriptor)
+	}
+	if p.UnitLabel != nil {
+		body.Add(form.FormatKey(append(keyParts, "unit_label")), *p.UnitLabel)
+	}
+}
+
+func (p *PlanTierParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	if p.FlatAmount != nil {
+		body.Add(form.FormatKey(append(keyParts, "flat_amount")), strconv.FormatInt(*p.FlatAmount, 10))
+	}
+	if p.FlatAmountDecimal != nil {
+		body.Add(form.FormatKey(append(keyParts, "flat_amount_decimal")), strconv.FormatFloat(*p.FlatAmountDecimal, 'f', -1, 64))
+	}
+	if p.UnitAmount != nil {
+		body.Add(form.FormatKey(append(keyParts, "unit_amount")), strconv.FormatInt(*p.UnitAmount, 10))
+	}
+	if p.UnitAmountDecimal != nil {
+		body.Add(form.FormatKey(append(keyParts, "unit_amount_decimal")), strconv.FormatFloat(*p.UnitAmountDecimal, 'f', -1, 64))
+	}
+}
+
+func (p *PlanTransformUsageParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.DivideBy != nil {
+		body.Add(form.FormatKey(append(keyParts, "divide_by")), strconv.FormatInt(*p.DivideBy, 10))
+	}
+	if p.Round != nil {
+		body.Add(form.FormatKey(append(keyParts, "round")), *p.Round)
+	}
+}
+
+func (p *ProductListParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.ListParams.encodeForm(body, keyParts)
+	if p.Active != nil {
+		body.Add(form.FormatKey(append(keyParts, "active")), strconv.FormatBool(*p.Active))
+	}
+	if p.Created != nil {
+		body.Add(form.FormatKey(append(keyParts, "created")), strconv.FormatInt(*p.Created, 10))
+	}
+	p.CreatedRange.encodeForm(body, append(keyParts, "created"))
+	if p.IDs != nil && len(p.IDs) == 0 {
+		body.Add(form.FormatKey(append(keyParts, "ids")), "")
+	} else {
+		for i1, v1 := range p.IDs {
+			if v1 != nil {
+				body.Add(form.FormatKey(append(keyParts, "ids", strconv.Itoa(i1))), *v1)
+			}
+		}
+	}
+	if p.Shippable != nil {
+		body.Add(form.FormatKey(append(keyParts, "shippable")), strconv.FormatBool(*p.Shippable))
+	}
+	if p.URL != nil {
+		body.Add(form.FormatKey(append(keyParts, "url")), *p.URL)
+	}
+	if p.Type != nil {
+		body.Add(form.FormatKey(append(keyParts, "type")), *p.Type)
+	}
+}
+
+func (p *ProductParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	if p.Active != nil {
+		body.Add(form.FormatKey(append(keyParts, "active")), strconv.FormatBool(*p.Active))
+	}
+	if p.Attributes != nil && len(p.Attributes) == 0 {
+		body.Add(form.FormatKey(append(keyParts, "attributes")), "")
+	} else {
+		for i1, v1 := range p.Attributes {
+			if v1 != nil {
+				body.Add(form.FormatKey(append(keyParts, "attributes", strconv.Itoa(i1))), *v1)
+			}
+		}
+	}
+	if p.Caption != nil {
+		body.Add(form.FormatKey(append(keyParts, "caption")), *p.Caption)
+	}
+	if p.DeactivateOn != nil && len(p.DeactivateOn) == 0 {
+		body.Add(form.FormatKey(append(keyParts, "deactivate_on")), "")
+	} else {
+		for i2, v2 := range p.DeactivateOn {
+			if v2 != nil {
+				body.Add(form.FormatKey(append(keyParts, "deactivate_on", strconv.Itoa(i2))), *v2)
+			}
+		}
+	}
+	if p.Description != nil {
+		body.Add(form.FormatKey(append(keyParts, "description")), *p.Description)
+	}
+	if p.ID != nil {
+		body.Add(form.FormatKey(append(keyParts, "id")), *p.ID)
+	}
+	if p.Images != nil && len(p.Images) == 0 {
+		body.Add(form.FormatKey(append(keyParts, "images")), "")
+	} else {
+		for i3, v3 := range p.Images {
+			if v3 != nil {
+				body.Add(form.FormatKey(append(keyParts, "images", strconv.Itoa(i3))), *v3)
+			}
+		}
+	}
+	if p.Name != nil {
+		body.Add(form.FormatKey(append(keyParts, "name")), *p.Name)
+	}
+	p.PackageDimensions.encodeForm(body, append(keyParts, "package_dimensions"))
+	if p.Shippable != nil {
+		body.Add(form.FormatKey(append(keyParts, "shippable")), strconv.FormatBool(*p.Shippable))
+	}
+	if p.StatementDescriptor != nil {
+		body.Add(form.FormatKey(append(keyParts, "statement_descriptor")), *p.StatementDescriptor)
+	}
+	if p.Type != nil {
+		body.Add(form.FormatKey(append(keyParts, "type")), *p.Type)
+	}
+	if p.UnitLabel != nil {
+		body.Add(form.FormatKey(append(keyParts, "unit_label")), *p.UnitLabel)
+	}
+	if p.URL != nil {
+		body.Add(form.FormatKey(append(keyParts, "url")), *p.URL)
+	}
+}
+
+func (p *RadarEarlyFraudWarningListParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.ListParams.encodeForm(body, keyParts)
+	if p.Charge != nil {
+		body.Add(form.FormatKey(append(keyParts, "charge")), *p.Charge)
+	}
+}
+
+func (p *RadarEarlyFraudWarningParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+}
+
+func (p *RadarValueListItemListParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.ListParams.encodeForm(body, keyParts)
+	if p.Created != nil {
+		body.Add(form.FormatKey(append(keyParts, "created")), strconv.FormatInt(*p.Created, 10))
+	}
+	p.CreatedRange.encodeForm(body, append(keyParts, "created"))
+	if p.RadarValueList != nil {
+		body.Add(form.FormatKey(append(keyParts, "value_list")), *p.RadarValueList)
+	}
+	if p.Value != nil {
+		body.Add(form.FormatKey(append(keyParts, "value")), *p.Value)
+	}
+}
+
+func (p *RadarValueListItemParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	if p.Value != nil {
+		body.Add(form.FormatKey(append(keyParts, "value")), *p.Value)
+	}
+	if p.RadarValueList != nil {
+		body.Add(form.FormatKey(append(keyParts, "value_list")), *p.RadarValueList)
+	}
+}
+
+func (p *RadarValueListListParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.ListParams.encodeForm(body, keyParts)
+	if p.Alias != nil {
+		body.Add(form.FormatKey(append(keyParts, "alias")), *p.Alias)
+	}
+	if p.Contains != nil {
+		body.Add(form.FormatKey(append(keyParts, "contains")), *p.Contains)
+	}
+	if p.Created != nil {
+		body.Add(form.FormatKey(append(keyParts, "created")), strconv.FormatInt(*p.Created, 10))
+	}
+	p.CreatedRange.encodeForm(body, append(keyParts, "created"))
+}
+
+func (p *RadarValueListParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	if p.Alias != nil {
+		body.Add(form.FormatKey(append(keyParts, "alias")), *p.Alias)
+	}
+	if p.ItemType != nil {
+		body.Add(form.FormatKey(append(keyParts, "item_type")), *p.ItemType)
+	}
+	if p.Name != nil {
+		body.Add(form.FormatKey(append(keyParts, "name")), *p.Name)
+	}
+}
+
+func (p *RangeQueryParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if !(p.GreaterThan == 0) {
+		body.Add(form.FormatKey(append(keyParts, "gt")), strconv.FormatInt(p.GreaterThan, 10))
+	}
+	if !(p.GreaterThanOrEqual == 0) {
+		body.Add(form.FormatKey(append(keyParts, "gte")), strconv.FormatInt(p.GreaterThanOrEqual, 10))
+	}
+	if !(p.LesserThan == 0) {
+		body.Add(form.FormatKey(append(keyParts, "lt")), strconv.FormatInt(p.LesserThan, 10))
+	}
+	if !(p.LesserThanOrEqual == 0) {
+		body.Add(form.FormatKey(append(keyParts, "lte")), strconv.FormatInt(p.LesserThanOrEqual, 10))
+	}
+}
+
+func (p *RecipientListParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.ListParams.encodeForm(body, keyParts)
+	if p.Verified != nil {
+		body.Add(form.FormatKey(append(keyParts, "verified")), strconv.FormatBool(*p.Verified))
+	}
+}
+
+func (p *RecipientParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	p.Card.encodeForm(body, append(keyParts, "card"))
+	if p.DefaultCard != nil {
+		body.Add(form.FormatKey(append(keyParts, "default_card")), *p.DefaultCard)
+	}
+	if p.Description != nil {
+		body.Add(form.FormatKey(append(keyParts, "description")), *p.Description)
+	}
+	if p.Email != nil {
+		body.Add(form.FormatKey(append(keyParts, "email")), *p.Email)
+	}
+	if p.Name != nil {
+		body.Add(form.FormatKey(append(keyParts, "name")), *p.Name)
+	}
+	if p.TaxID != nil {
+		body.Add(form.FormatKey(append(keyParts, "tax_id")), *p.TaxID)
+	}
+	if p.Token != nil {
+		body.Add(form.FormatKey(append(keyParts, "card")), *p.Token)
+	}
+}
+
+func (p *RedirectParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.ReturnURL != nil {
+		body.Add(form.FormatKey(append(keyParts, "return_url")), *p.ReturnURL)
+	}
+}
+
+func (p *RefundListParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.ListParams.encodeForm(body, keyParts)
+	if p.Charge != nil {
+		body.Add(form.FormatKey(append(keyParts, "charge")), *p.Charge)
+	}
+	if p.Created != nil {
+		body.Add(form.FormatKey(append(keyParts, "created")), strconv.FormatInt(*p.Created, 10))
+	}
+	p.CreatedRange.encodeForm(body, append(keyParts, "created"))
+	if p.PaymentIntent != nil {
+		body.Add(form.FormatKey(append(keyParts, "payment_intent")), *p.PaymentIntent)
+	}
+}
+
+func (p *RefundParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	if p.Amount != nil {
+		body.Add(form.FormatKey(append(keyParts, "amount")), strconv.FormatInt(*p.Amount, 10))
+	}
+	if p.Charge != nil {
+		body.Add(form.FormatKey(append(keyParts, "charge")), *p.Charge)
+	}
+	if p.PaymentIntent != nil {
+		body.Add(form.FormatKey(append(keyParts, "payment_intent")), *p.PaymentIntent)
+	}
+	if p.Reason != nil {
+		body.Add(form.FormatKey(append(keyParts, "reason")), *p.Reason)
+	}
+	if p.RefundApplicationFee != nil {
+		body.Add(form.FormatKey(append(keyParts, "refund_application_fee")), strconv.FormatBool(*p.RefundApplicationFee))
+	}
+	if p.ReverseTransfer != nil {
+		body.Add(form.FormatKey(append(keyParts, "reverse_transfer")), strconv.FormatBool(*p.ReverseTransfer))
+	}
+}
+
+func (p *RelationshipListParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.Director != nil {
+		body.Add(form.FormatKey(append(keyParts, "director")), strconv.FormatBool(*p.Director))
+	}
+	if p.Executive != nil {
+		body.Add(form.FormatKey(append(keyParts, "executive")), strconv.FormatBool(*p.Executive))
+	}
+	if p.Owner != nil {
+		body.Add(form.FormatKey(append(keyParts, "owner")), strconv.FormatBool(*p.Owner))
+	}
+	if p.Representative != nil {
+		body.Add(form.FormatKey(append(keyParts, "representative")), strconv.FormatBool(*p.Representative))
+	}
+}
+
+func (p *RelationshipParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.Director != nil {
+		body.Add(form.FormatKey(append(keyParts, "director")), strconv.FormatBool(*p.Director))
+	}
+	if p.Executive != nil {
+		body.Add(form.FormatKey(append(keyParts, "executive")), strconv.FormatBool(*p.Executive))
+	}
+	if p.Owner != nil {
+		body.Add(form.FormatKey(append(keyParts, "owner")), strconv.FormatBool(*p.Owner))
+	}
+	if p.PercentOwnership != nil {
+		body.Add(form.FormatKey(append(keyParts, "percent_ownership")), strconv.FormatFloat(*p.PercentOwnership, 'f', 4, 64))
+	}
+	if p.Representative != nil {
+		body.Add(form.FormatKey(append(keyParts, "representative")), strconv.FormatBool(*p.Representative))
+	}
+	if p.Title != nil {
+		body.Add(form.FormatKey(append(keyParts, "title")), *p.Title)
+	}
+}
+
+func (p *ReportRunListParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.ListParams.encodeForm(body, keyParts)
+	if p.Created != nil {
+		body.Add(form.FormatKey(append(keyParts, "created")), strconv.FormatInt(*p.Created, 10))
+	}
+	p.CreatedRange.encodeForm(body, append(keyParts, "created"))
+}
+
+func (p *ReportRunParametersParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.Columns != nil && len(p.Columns) == 0 {
+		body.Add(form.FormatKey(append(keyParts, "columns")), "")
+	} else {
+		for i1, v1 := range p.Columns {
+			if v1 != nil {
+				body.Add(form.FormatKey(append(keyParts, "columns", strconv.Itoa(i1))), *v1)
+			}
+		}
+	}
+	if p.ConnectedAccount != nil {
+		body.Add(form.FormatKey(append(keyParts, "connected_account")), *p.ConnectedAccount)
+	}
+	if p.Currency != nil {
+		body.Add(form.FormatKey(append(keyParts, "currency")), *p.Currency)
+	}
+	if p.IntervalEnd != nil {
+		body.Add(form.FormatKey(append(keyParts, "interval_end")), strconv.FormatInt(*p.IntervalEnd, 10))
+	}
+	if p.IntervalStart != nil {
+		body.Add(form.FormatKey(append(keyParts, "interval_start")), strconv.FormatInt(*p.IntervalStart, 10))
+	}
+	if p.Payout != nil {
+		body.Add(form.FormatKey(append(keyParts, "payout")), *p.Payout)
+	}
+	if p.ReportingCategory != nil {
+		body.Add(form.FormatKey(append(keyParts, "reporting_category")), *p.ReportingCategory)
+	}
+}
+
+func (p *ReportRunParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	p.Parameters.encodeForm(body, append(keyParts, "parameters"))
+	if p.ReportType != nil {
+		body.Add(form.FormatKey(append(keyParts, "report_type")), *p.ReportType)
+	}
+}
+
+func (p *ReportTypeListParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.ListParams.encodeForm(body, keyParts)
+}
+
+func (p *ReportTypeParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+}
+
+func (p *ReversalListParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.ListParams.encodeForm(body, keyParts)
+}
+
+func (p *ReversalParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	if p.Amount != nil {
+		body.Add(form.FormatKey(append(keyParts, "amount")), strconv.FormatInt(*p.Amount, 10))
+	}
+	if p.Description != nil {
+		body.Add(form.FormatKey(append(keyParts, "description")), *p.Description)
+	}
+	if p.RefundApplicationFee != nil {
+		body.Add(form.FormatKey(append(keyParts, "refund_application_fee")), strconv.FormatBool(*p.RefundApplicationFee))
+	}
+}
+
+func (p *ReviewApproveParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+}
+
+func (p *ReviewListParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.ListParams.encodeForm(body, keyParts)
+	if p.Created != nil {
+		body.Add(form.FormatKey(append(keyParts, "created")), strconv.FormatInt(*p.Created, 10))
+	}
+	p.CreatedRange.encodeForm(body, append(keyParts, "created"))
+}
+
+func (p *ReviewParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+}
+
+func (p *SKUListParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.ListParams.encodeForm(body, keyParts)
+	if p.Active != nil {
+		body.Add(form.FormatKey(append(keyParts, "active")), strconv.FormatBool(*p.Active))
+	}
+	for k1, v1 := range p.Attributes {
+		body.Add(form.FormatKey(append(keyParts, "attributes", k1)), v1)
+	}
+	if p.IDs != nil && len(p.IDs) == 0 {
+		body.Add(form.FormatKey(append(keyParts, "ids")), "")
+	} else {
+		for i2, v2 := range p.IDs {
+			if v2 != nil {
+				body.Add(form.FormatKey(append(keyParts, "ids", strconv.Itoa(i2))), *v2)
+			}
+		}
+	}
+	if p.InStock != nil {
+		body.Add(form.FormatKey(append(keyParts, "in_stock")), strconv.FormatBool(*p.InStock))
+	}
+	if p.Product != nil {
+		body.Add(form.FormatKey(append(keyParts, "product")), *p.Product)
+	}
+}
+
+func (p *SKUParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	if p.Active != nil {
+		body.Add(form.FormatKey(append(keyParts, "active")), strconv.FormatBool(*p.Active))
+	}
+	for k1, v1 := range p.Attributes {
+		body.Add(form.FormatKey(append(keyParts, "attributes", k1)), v1)
+	}
+	if p.Currency != nil {
+		body.Add(form.FormatKey(append(keyParts, "currency")), *p.Currency)
+	}
+	if p.Description != nil {
+		body.Add(form.FormatKey(append(keyParts, "description")), *p.Description)
+	}
+	if p.ID != nil {
+		body.Add(form.FormatKey(append(keyParts, "id")), *p.ID)
+	}
+	if p.Image != nil {
+		body.Add(form.FormatKey(append(keyParts, "image")), *p.Image)
+	}
+	p.Inventory.encodeForm(body, append(keyParts, "inventory"))
+	p.PackageDimensions.encodeForm(body, append(keyParts, "package_dimensions"))
+	if p.Price != nil {
+		body.Add(form.FormatKey(append(keyParts, "price")), strconv.FormatInt(*p.Price, 10))
+	}
+	if p.Product != nil {
+		body.Add(form.FormatKey(append(keyParts, "product")), *p.Product)
+	}
+}
+
+func (p *SetupIntentCancelParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	if p.CancellationReason != nil {
+		body.Add(form.FormatKey(append(keyParts, "cancellation_reason")), *p.CancellationReason)
+	}
+}
+
+func (p *SetupIntentConfirmParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	p.MandateData.encodeForm(body, append(keyParts, "mandate_data"))
+	if p.PaymentMethod != nil {
+		body.Add(form.FormatKey(append(keyParts, "payment_method")), *p.PaymentMethod)
+	}
+	p.PaymentMethodOptions.encodeForm(body, append(keyParts, "payment_method_options"))
+	if p.ReturnURL != nil {
+		body.Add(form.FormatKey(append(keyParts, "return_url")), *p.ReturnURL)
+	}
+}
+
+func (p *SetupIntentListParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.ListParams.encodeForm(body, keyParts)
+	if p.Created != nil {
+		body.Add(form.FormatKey(append(keyParts, "created")), strconv.FormatInt(*p.Created, 10))
+	}
+	p.CreatedRange.encodeForm(body, append(keyParts, "created"))
+	if p.Customer != nil {
+		body.Add(form.FormatKey(append(keyParts, "customer")), *p.Customer)
+	}
+	if p.PaymentMethod != nil {
+		body.Add(form.FormatKey(append(keyParts, "payment_method")), *p.PaymentMethod)
+	}
+}
+
+func (p *SetupIntentMandateDataCustomerAcceptanceOfflineParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+}
+
+func (p *SetupIntentMandateDataCustomerAcceptanceOnlineParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.IPAddress != nil {
+		body.Add(form.FormatKey(append(keyParts, "ip_address")), *p.IPAddress)
+	}
+	if p.UserAgent != nil {
+		body.Add(form.FormatKey(append(keyParts, "user_agent")), *p.UserAgent)
+	}
+}
+
+func (p *SetupIntentMandateDataCustomerAcceptanceParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if !(p.AcceptedAt == 0) {
+		body.Add(form.FormatKey(append(keyParts, "accepted_at")), strconv.FormatInt(p.AcceptedAt, 10))
+	}
+	p.Offline.encodeForm(body, append(keyParts, "offline"))
+	p.Online.encodeForm(body, append(keyParts, "online"))
+	if !(p.Type == "") {
+		body.Add(form.FormatKey(append(keyParts, "type")), string(p.Type))
+	}
+}
+
+func (p *SetupIntentMandateDataParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.CustomerAcceptance.encodeForm(body, append(keyParts, "customer_acceptance"))
+}
+
+func (p *SetupIntentParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	if p.Confirm != nil {
+		body.Add(form.FormatKey(append(keyParts, "confirm")), strconv.FormatBool(*p.Confirm))
+	}
+	if p.Customer != nil {
+		body.Add(form.FormatKey(append(keyParts, "customer")), *p.Customer)
+	}
+	if p.Description != nil {
+		body.Add(form.FormatKey(append(keyParts, "description")), *p.Description)
+	}
+	p.MandateData.encodeForm(body, append(keyParts, "mandate_data"))
+	if p.OnBehalfOf != nil {
+		body.Add(form.FormatKey(append(keyParts, "on_behalf_of")), *p.OnBehalfOf)
+	}
+	if p.PaymentMethod != nil {
+		body.Add(form.FormatKey(append(keyParts, "payment_method")), *p.PaymentMethod)
+	}
+	p.PaymentMethodOptions.encodeForm(body, append(keyParts, "payment_method_options"))
+	if p.PaymentMethodTypes != nil && len(p.PaymentMethodTypes) == 0 {
+		body.Add(form.FormatKey(append(keyParts, "payment_method_types")), "")
+	} else {
+		for i1, v1 := range p.PaymentMethodTypes {
+			if v1 != nil {
+				body.Add(form.FormatKey(append(keyParts, "payment_method_types", strconv.Itoa(i1))), *v1)
+			}
+		}
+	}
+	if p.ReturnURL != nil {
+		body.Add(form.FormatKey(append(keyParts, "return_url")), *p.ReturnURL)
+	}
+	p.SingleUse.encodeForm(body, append(keyParts, "single_use"))
+	if p.Usage != nil {
+		body.Add(form.FormatKey(append(keyParts, "usage")), *p.Usage)
+	}
+}
+
+func (p *SetupIntentPaymentMethodOptionsCardParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.MOTO != nil {
+		body.Add(form.FormatKey(append(keyParts, "moto")), strconv.FormatBool(*p.MOTO))
+	}
+	if p.RequestThreeDSecure != nil {
+		body.Add(form.FormatKey(append(keyParts, "request_three_d_secure")), *p.RequestThreeDSecure)
+	}
+}
+
+func (p *SetupIntentPaymentMethodOptionsParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Card.encodeForm(body, append(keyParts, "card"))
+}
+
+func (p *SetupIntentSingleUseParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.Amount != nil {
+		body.Add(form.FormatKey(append(keyParts, "amount")), strconv.FormatInt(*p.Amount, 10))
+	}
+	if p.Currency != nil {
+		body.Add(form.FormatKey(append(keyParts, "currency")), *p.Currency)
+	}
+}
+
+func (p *ShippingDetailsParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Address.encodeForm(body, append(keyParts, "address"))
+	if p.Carrier != nil {
+		body.Add(form.FormatKey(append(keyParts, "carrier")), *p.Carrier)
+	}
+	if p.Name != nil {
+		body.Add(form.FormatKey(append(keyParts, "name")), *p.Name)
+	}
+	if p.Phone != nil {
+		body.Add(form.FormatKey(append(keyParts, "phone")), *p.Phone)
+	}
+	if p.TrackingNumber != nil {
+		body.Add(form.FormatKey(append(keyParts, "tracking_number")), *p.TrackingNumber)
+	}
+}
+
+func (p *ShippingParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Address.encodeForm(body, append(keyParts, "address"))
+	if p.Name != nil {
+		body.Add(form.FormatKey(append(keyParts, "name")), *p.Name)
+	}
+	if p.Phone != nil {
+		body.Add(form.FormatKey(append(keyParts, "phone")), *p.Phone)
+	}
+}
+
+func (p *SigmaScheduledQueryRunListParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.ListParams.encodeForm(body, keyParts)
+}
+
+func (p *SigmaScheduledQueryRunParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+}
+
+func (p *SourceListParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.ListParams.encodeForm(body, keyParts)
+}
+
+func (p *SourceMandateAcceptanceOfflineParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.ContactEmail != nil {
+		body.Add(form.FormatKey(append(keyParts, "contact_email")), *p.ContactEmail)
+	}
+}
+
+func (p *SourceMandateAcceptanceOnlineParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.Date != nil {
+		body.Add(form.FormatKey(append(keyParts, "date")), strconv.FormatInt(*p.Date, 10))
+	}
+	if p.IP != nil {
+		body.Add(form.FormatKey(append(keyParts, "ip")), *p.IP)
+	}
+	if p.UserAgent != nil {
+		body.Add(form.FormatKey(append(keyParts, "user_agent")), *p.UserAgent)
+	}
+}
+
+func (p *SourceMandateAcceptanceParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.Date != nil {
+		body.Add(form.FormatKey(append(keyParts, "date")), strconv.FormatInt(*p.Date, 10))
+	}
+	if p.IP != nil {
+		body.Add(form.FormatKey(append(keyParts, "ip")), *p.IP)
+	}
+	p.Offline.encodeForm(body, append(keyParts, "offline"))
+	p.Online.encodeForm(body, append(keyParts, "online"))
+	if p.Status != nil {
+		body.Add(form.FormatKey(append(keyParts, "status")), *p.Status)
+	}
+	if p.Type != nil {
+		body.Add(form.FormatKey(append(keyParts, "type")), *p.Type)
+	}
+	if p.UserAgent != nil {
+		body.Add(form.FormatKey(append(keyParts, "user_agent")), *p.UserAgent)
+	}
+}
+
+func (p *SourceMandateParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.Amount != nil {
+		body.Add(form.FormatKey(append(keyParts, "amount")), strconv.FormatInt(*p.Amount, 10))
+	}
+	p.Acceptance.encodeForm(body, append(keyParts, "acceptance"))
+	if p.Currency != nil {
+		body.Add(form.FormatKey(append(keyParts, "currency")), *p.Currency)
+	}
+	if p.Interval != nil {
+		body.Add(form.FormatKey(append(keyParts, "interval")), *p.Interval)
+	}
+	if p.NotificationMethod != nil {
+		body.Add(form.FormatKey(append(keyParts, "notification_method")), *p.NotificationMethod)
+	}
+}
+
+func (p *SourceObjectDetachParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+}
+
+func (p *SourceObjectParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	if p.Amount != nil {
+		body.Add(form.FormatKey(append(keyParts, "amount")), strconv.FormatInt(*p.Amount, 10))
+	}
+	if p.Currency != nil {
+		body.Add(form.FormatKey(append(keyParts, "currency")), *p.Currency)
+	}
+	if p.Customer != nil {
+		body.Add(form.FormatKey(append(keyParts, "customer")), *p.Customer)
+	}
+	if p.Flow != nil {
+		body.Add(form.FormatKey(append(keyParts, "flow")), *p.Flow)
+	}
+	p.Mandate.encodeForm(body, append(keyParts, "mandate"))
+	if p.OriginalSource != nil {
+		body.Add(form.FormatKey(append(keyParts, "original_source")), *p.OriginalSource)
+	}
+	p.Owner.encodeForm(body, append(keyParts, "owner"))
+	p.Receiver.encodeForm(body, append(keyParts, "receiver"))
+	p.Redirect.encodeForm(body, append(keyParts, "redirect"))
+	p.SourceOrder.encodeForm(body, append(keyParts, "source_order"))
+	if p.StatementDescriptor != nil {
+		body.Add(form.FormatKey(append(keyParts, "statement_descriptor")), *p.StatementDescriptor)
+	}
+	if p.Token != nil {
+		body.Add(form.FormatKey(append(keyParts, "token")), *p.Token)
+	}
+	if p.Type != nil {
+		body.Add(form.FormatKey(append(keyParts, "type")), *p.Type)
+	}
+	if p.Usage != nil {
+		body.Add(form.FormatKey(append(keyParts, "usage")), *p.Usage)
+	}
+}
+
+func (p *SourceOrderItemsParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.Amount != nil {
+		body.Add(form.FormatKey(append(keyParts, "amount")), strconv.FormatInt(*p.Amount, 10))
+	}
+	if p.Currency != nil {
+		body.Add(form.FormatKey(append(keyParts, "currency")), *p.Currency)
+	}
+	if p.Description != nil {
+		body.Add(form.FormatKey(append(keyParts, "description")), *p.Description)
+	}
+	if p.Parent != nil {
+		body.Add(form.FormatKey(append(keyParts, "parent")), *p.Parent)
+	}
+	if p.Quantity != nil {
+		body.Add(form.FormatKey(append(keyParts, "quantity")), strconv.FormatInt(*p.Quantity, 10))
+	}
+	if p.Type != nil {
+		body.Add(form.FormatKey(append(keyParts, "type")), *p.Type)
+	}
+}
+
+func (p *SourceOrderParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.Items != nil && len(p.Items) == 0 {
+		body.Add(form.FormatKey(append(keyParts, "items")), "")
+	} else {
+		for i1, v1 := range p.Items {
+			v1.encodeForm(body, append(keyParts, "items", strconv.Itoa(i1)))
+		}
+	}
+	p.Shipping.encodeForm(body, append(keyParts, "shipping"))
+}
+
+func (p *SourceOwnerParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Address.encodeForm(body, append(keyParts, "address"))
+	if p.Email != nil {
+		body.Add(form.FormatKey(append(keyParts, "email")), *p.Email)
+	}
+	if p.Name != nil {
+		body.Add(form.FormatKey(append(keyParts, "name")), *p.Name)
+	}
+	if p.Phone != nil {
+		body.Add(form.FormatKey(append(keyParts, "phone")), *p.Phone)
+	}
+}
+
+func (p *SourceParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.Token != nil {
+		body.Add(form.FormatKey(append(keyParts, "source")), *p.Token)
+	}
+}
+
+func (p *SourceReceiverParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.RefundAttributesMethod != nil {
+		body.Add(form.FormatKey(append(keyParts, "refund_attributes_method")), *p.RefundAttributesMethod)
+	}
+}
+
+func (p *SourceTransactionListParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.ListParams.encodeForm(body, keyParts)
+}
+
+func (p *SourceVerifyParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	for i1, v1 := range p.Amounts {
+		if !(v1 == 0) {
+			body.Add(form.FormatKey(append(keyParts, "amounts", strconv.Itoa(i1))), strconv.FormatInt(v1, 10))
+		}
+	}
+	if p.Values != nil && len(p.Values) == 0 {
+		body.Add(form.FormatKey(append(keyParts, "values")), "")
+	} else {
+		for i2, v2 := range p.Values {
+			if v2 != nil {
+				body.Add(form.FormatKey(append(keyParts, "values", strconv.Itoa(i2))), *v2)
+			}
+		}
+	}
+}
+
+func (p *StatusTransitionsFilterParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.Canceled != nil {
+		body.Add(form.FormatKey(append(keyParts, "canceled")), strconv.FormatInt(*p.Canceled, 10))
+	}
+	p.CanceledRange.encodeForm(body, append(keyParts, "canceled"))
+	if p.Fulfilled != nil {
+		body.Add(form.FormatKey(append(keyParts, "fulfilled")), strconv.FormatInt(*p.Fulfilled, 10))
+	}
+	p.FulfilledRange.encodeForm(body, append(keyParts, "fulfilled"))
+	if p.Paid != nil {
+		body.Add(form.FormatKey(append(keyParts, "paid")), strconv.FormatInt(*p.Paid, 10))
+	}
+	p.PaidRange.encodeForm(body, append(keyParts, "paid"))
+	if p.Returned != nil {
+		body.Add(form.FormatKey(append(keyParts, "returned")), strconv.FormatInt(*p.Returned, 10))
+	}
+	p.ReturnedRange.encodeForm(body, append(keyParts, "returned"))
+}
+
+func (p *SubscriptionBillingThresholdsParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.AmountGTE != nil {
+		body.Add(form.FormatKey(append(keyParts, "amount_gte")), strconv.FormatInt(*p.AmountGTE, 10))
+	}
+	if p.ResetBillingCycleAnchor != nil {
+		body.Add(form.FormatKey(append(keyParts, "reset_billing_cycle_anchor")), strconv.FormatBool(*p.ResetBillingCycleAnchor))
+	}
+}
+
+func (p *SubscriptionCancelParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	if p.InvoiceNow != nil {
+		body.Add(form.FormatKey(append(keyParts, "invoice_now")), strconv.FormatBool(*p.InvoiceNow))
+	}
+	if p.Prorate != nil {
+		body.Add(form.FormatKey(append(keyParts, "prorate")), strconv.FormatBool(*p.Prorate))
+	}
+}
+
+func (p *SubscriptionItemBillingThresholdsParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.UsageGTE != nil {
+		body.Add(form.FormatKey(append(keyParts, "usage_gte")), strconv.FormatInt(*p.UsageGTE, 10))
+	}
+}
+
+func (p *SubscriptionItemListParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.ListParams.encodeForm(body, keyParts)
+	if p.Subscription != nil {
+		body.Add(form.FormatKey(append(keyParts, "subscription")), *p.Subscription)
+	}
+}
+
+func (p *SubscriptionItemParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	p.BillingThresholds.encodeForm(body, append(keyParts, "billing_thresholds"))
+	if p.ClearUsage != nil {
+		body.Add(form.FormatKey(append(keyParts, "clear_usage")), strconv.FormatBool(*p.ClearUsage))
+	}
+	if p.PaymentBehavior != nil {
+		body.Add(form.FormatKey(append(keyParts, "payment_behavior")), *p.PaymentBehavior)
+	}
+	if p.Plan != nil {
+		body.Add(form.FormatKey(append(keyParts, "plan")), *p.Plan)
+	}
+	if p.Prorate != nil {
+		body.Add(form.FormatKey(append(keyParts, "prorate")), strconv.FormatBool(*p.Prorate))
+	}
+	if p.ProrationDate != nil {
+		body.Add(form.FormatKey(append(keyParts, "proration_date")), strconv.FormatInt(*p.ProrationDate, 10))
+	}
+	if p.ProrationBehavior != nil {
+		body.Add(form.FormatKey(append(keyParts, "proration_behavior")), *p.ProrationBehavior)
+	}
+	if p.Quantity != nil {
+		body.Add(form.FormatKey(append(keyParts, "quantity")), strconv.FormatInt(*p.Quantity, 10))
+	}
+	if p.Subscription != nil {
+		body.Add(form.FormatKey(append(keyParts, "subscription")), *p.Subscription)
+	}
+	if p.TaxRates != nil && len(p.TaxRates) == 0 {
+		body.Add(form.FormatKey(append(keyParts, "tax_rates")), "")
+	} else {
+		for i1, v1 := range p.TaxRates {
+			if v1 != nil {
+				body.Add(form.FormatKey(append(keyParts, "tax_rates", strconv.Itoa(i1))), *v1)
+			}
+		}
+	}
+	if p.OffSession != nil {
+		body.Add(form.FormatKey(append(keyParts, "off_session")), strconv.FormatBool(*p.OffSession))
+	}
+}
+
+func (p *SubscriptionItemsParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	p.BillingThresholds.encodeForm(body, append(keyParts, "billing_thresholds"))
+	if p.ClearUsage != nil {
+		body.Add(form.FormatKey(append(keyParts, "clear_usage")), strconv.FormatBool(*p.ClearUsage))
+	}
+	if p.Deleted != nil {
+		body.Add(form.FormatKey(append(keyParts, "deleted")), strconv.FormatBool(*p.Deleted))
+	}
+	if p.ID != nil {
+		body.Add(form.FormatKey(append(keyParts, "id")), *p.ID)
+	}
+	if p.Plan != nil {
+		body.Add(form.FormatKey(append(keyParts, "plan")), *p.Plan)
+	}
+	if p.Quantity != nil {
+		body.Add(form.FormatKey(append(keyParts, "quantity")), strconv.FormatInt(*p.Quantity, 10))
+	}
+	if p.TaxRates != nil && len(p.TaxRates) == 0 {
+		body.Add(form.FormatKey(append(keyParts, "tax_rates")), "")
+	} else {
+		for i1, v1 := range p.TaxRates {
+			if v1 != nil {
+				body.Add(form.FormatKey(append(keyParts, "tax_rates", strconv.Itoa(i1))), *v1)
+			}
+		}
+	}
+}
+
+func (p *SubscriptionListParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.ListParams.encodeForm(body, keyParts)
+	if p.CollectionMethod != nil {
+		body.Add(form.FormatKey(append(keyParts, "collection_method")), *p.CollectionMethod)
+	}
+	if !(p.Created == 0) {
+		body.Add(form.FormatKey(append(keyParts, "created")), strconv.FormatInt(p.Created, 10))
+	}
+	p.CreatedRange.encodeForm(body, append(keyParts, "created"))
+	if p.CurrentPeriodEnd != nil {
+		body.Add(form.FormatKey(append(keyParts, "current_period_end")), strconv.FormatInt(*p.CurrentPeriodEnd, 10))
+	}
+	p.CurrentPeriodEndRange.encodeForm(body, append(keyParts, "current_period_end"))
+	if p.CurrentPeriodStart != nil {
+		body.Add(form.FormatKey(append(keyParts, "current_period_start")), strconv.FormatInt(*p.CurrentPeriodStart, 10))
+	}
+	p.CurrentPeriodStartRange.encodeForm(body, append(keyParts, "current_period_start"))
+	if !(p.Customer == "") {
+		body.Add(form.FormatKey(append(keyParts, "customer")), p.Customer)
+	}
+	if !(p.Plan == "") {
+		body.Add(form.FormatKey(append(keyParts, "plan")), p.Plan)
+	}
+	if !(p.Status == "") {
+		body.Add(form.FormatKey(append(keyParts, "status")), p.Status)
+	}
+}
+
+func (p *SubscriptionParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	if p.ApplicationFeePercent != nil {
+		body.Add(form.FormatKey(append(keyParts, "application_fee_percent")), strconv.FormatFloat(*p.ApplicationFeePercent, 'f', 4, 64))
+	}
+	if p.BackdateStartDate != nil {
+		body.Add(form.FormatKey(append(keyParts, "backdate_start_date")), strconv.FormatInt(*p.BackdateStartDate, 10))
+	}
+	if p.BillingCycleAnchor != nil {
+		body.Add(form.FormatKey(append(keyParts, "billing_cycle_anchor")), strconv.FormatInt(*p.BillingCycleAnchor, 10))
+	}
+	p.BillingThresholds.encodeForm(body, append(keyParts, "billing_thresholds"))
+	if p.CancelAt != nil {
+		body.Add(form.FormatKey(append(keyParts, "cancel_at")), strconv.FormatInt(*p.CancelAt, 10))
+	}
+	if p.CancelAtPeriodEnd != nil {
+		body.Add(form.FormatKey(append(keyParts, "cancel_at_period_end")), strconv.FormatBool(*p.CancelAtPeriodEnd))
+	}
+	p.Card.encodeForm(body, append(keyParts, "card"))
+	if p.CollectionMethod != nil {
+		body.Add(form.FormatKey(append(keyParts, "collection_method")), *p.CollectionMethod)
+	}
+	if p.Coupon != nil {
+		body.Add(form.FormatKey(append(keyParts, "coupon")), *p.Coupon)
+	}
+	if p.Customer != nil {
+		body.Add(form.FormatKey(append(keyParts, "customer")), *p.Customer)
+	}
+	if p.DaysUntilDue != nil {
+		body.Add(form.FormatKey(append(keyParts, "days_until_due")), strconv.FormatInt(*p.DaysUntilDue, 10))
+	}
+	if p.DefaultPaymentMethod != nil {
+		body.Add(form.FormatKey(append(keyParts, "default_payment_method")), *p.DefaultPaymentMethod)
+	}
+	if p.DefaultSource != nil {
+		body.Add(form.FormatKey(append(keyParts, "default_source")), *p.DefaultSource)
+	}
+	if p.DefaultTaxRates != nil && len(p.DefaultTaxRates) == 0 {
+		body.Add(form.FormatKey(append(keyParts, "default_tax_rates")), "")
+	} else {
+		for i1, v1 := range p.DefaultTaxRates {
+			if v1 != nil {
+				body.Add(form.FormatKey(append(keyParts, "default_tax_rates", strconv.Itoa(i1))), *v1)
+			}
+		}
+	}
+	if p.Items != nil && len(p.Items) == 0 {
+		body.Add(form.FormatKey(append(keyParts, "items")), "")
+	} else {
+		for i2, v2 := range p.Items {
+			v2.encodeForm(body, append(keyParts, "items", strconv.Itoa(i2)))
+		}
+	}
+	if p.OffSession != nil {
+		body.Add(form.FormatKey(append(keyParts, "off_session")), strconv.FormatBool(*p.OffSession))
+	}
+	if p.OnBehalfOf != nil {
+		body.Add(form.FormatKey(append(keyParts, "on_behalf_of")), *p.OnBehalfOf)
+	}
+	if p.PaymentBehavior != nil {
+		body.Add(form.FormatKey(append(keyParts, "payment_behavior")), *p.PaymentBehavior)
+	}
+	p.PendingInvoiceItemInterval.encodeForm(body, append(keyParts, "pending_invoice_item_interval"))
+	if p.Plan != nil {
+		body.Add(form.FormatKey(append(keyParts, "plan")), *p.Plan)
+	}
+	if p.Prorate != nil {
+		body.Add(form.FormatKey(append(keyParts, "prorate")), strconv.FormatBool(*p.Prorate))
+	}
+	if p.ProrationBehavior != nil {
+		body.Add(form.FormatKey(append(keyParts, "proration_behavior")), *p.ProrationBehavior)
+	}
+	if p.ProrationDate != nil {
+		body.Add(form.FormatKey(append(keyParts, "proration_date")), strconv.FormatInt(*p.ProrationDate, 10))
+	}
+	if p.Quantity != nil {
+		body.Add(form.FormatKey(append(keyParts, "quantity")), strconv.FormatInt(*p.Quantity, 10))
+	}
+	if p.TrialEnd != nil {
+		body.Add(form.FormatKey(append(keyParts, "trial_end")), strconv.FormatInt(*p.TrialEnd, 10))
+	}
+	p.TransferData.encodeForm(body, append(keyParts, "transfer_data"))
+	if p.TrialFromPlan != nil {
+		body.Add(form.FormatKey(append(keyParts, "trial_from_plan")), strconv.FormatBool(*p.TrialFromPlan))
+	}
+	if p.TrialPeriodDays != nil {
+		body.Add(form.FormatKey(append(keyParts, "trial_period_days")), strconv.FormatInt(*p.TrialPeriodDays, 10))
+	}
+	if p.TaxPercent != nil {
+		body.Add(form.FormatKey(append(keyParts, "tax_percent")), strconv.FormatFloat(*p.TaxPercent, 'f', 4, 64))
+	}
+}
+
+func (p *SubscriptionPendingInvoiceItemIntervalParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.Interval != nil {
+		body.Add(form.FormatKey(append(keyParts, "interval")), *p.Interval)
+	}
+	if p.IntervalCount != nil {
+		body.Add(form.FormatKey(append(keyParts, "interval_count")), strconv.FormatInt(*p.IntervalCount, 10))
+	}
+}
+
+func (p *SubscriptionScheduleCancelParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	if p.InvoiceNow != nil {
+		body.Add(form.FormatKey(append(keyParts, "invoice_now")), strconv.FormatBool(*p.InvoiceNow))
+	}
+	if p.Prorate != nil {
+		body.Add(form.FormatKey(append(keyParts, "prorate")), strconv.FormatBool(*p.Prorate))
+	}
+}
+
+func (p *SubscriptionScheduleDefaultSettingsParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	p.BillingThresholds.encodeForm(body, append(keyParts, "billing_thresholds"))
+	if p.CollectionMethod != nil {
+		body.Add(form.FormatKey(append(keyParts, "collection_method")), *p.CollectionMethod)
+	}
+	if p.DefaultPaymentMethod != nil {
+		body.Add(form.FormatKey(append(keyParts, "default_payment_method")), *p.DefaultPaymentMethod)
+	}
+	p.InvoiceSettings.encodeForm(body, append(keyParts, "invoice_settings"))
+}
+
+func (p *SubscriptionScheduleInvoiceSettingsParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.DaysUntilDue != nil {
+		body.Add(form.FormatKey(append(keyParts, "days_until_due")), strconv.FormatInt(*p.DaysUntilDue, 10))
+	}
+}
+
+func (p *SubscriptionScheduleListParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.ListParams.encodeForm(body, keyParts)
+	if !(p.CanceledAt == 0) {
+		body.Add(form.FormatKey(append(keyParts, "canceled_at")), strconv.FormatInt(p.CanceledAt, 10))
+	}
+	p.CanceledAtRange.encodeForm(body, append(keyParts, "canceled_at"))
+	if !(p.CompletedAt == 0) {
+		body.Add(form.FormatKey(append(keyParts, "completed_at")), strconv.FormatInt(p.CompletedAt, 10))
+	}
+	p.CompletedAtRange.encodeForm(body, append(keyParts, "completed_at"))
+	if !(p.Created == 0) {
+		body.Add(form.FormatKey(append(keyParts, "created")), strconv.FormatInt(p.Created, 10))
+	}
+	p.CreatedRange.encodeForm(body, append(keyParts, "created"))
+	if !(p.Customer == "") {
+		body.Add(form.FormatKey(append(keyParts, "customer")), p.Customer)
+	}
+	if !(p.ReleasedAt == 0) {
+		body.Add(form.FormatKey(append(keyParts, "released_at")), strconv.FormatInt(p.ReleasedAt, 10))
+	}
+	p.ReleasedAtRange.encodeForm(body, append(keyParts, "released_at"))
+	if p.Scheduled != nil {
+		body.Add(form.FormatKey(append(keyParts, "scheduled")), strconv.FormatBool(*p.Scheduled))
+	}
+}
+
+func (p *SubscriptionScheduleParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	if p.Customer != nil {
+		body.Add(form.FormatKey(append(keyParts, "customer")), *p.Customer)
+	}
+	p.DefaultSettings.encodeForm(body, append(keyParts, "default_settings"))
+	if p.EndBehavior != nil {
+		body.Add(form.FormatKey(append(keyParts, "end_behavior")), *p.EndBehavior)
+	}
+	if p.FromSubscription != nil {
+		body.Add(form.FormatKey(append(keyParts, "from_subscription")), *p.FromSubscription)
+	}
+	if p.Phases != nil && len(p.Phases) == 0 {
+		body.Add(form.FormatKey(append(keyParts, "phases")), "")
+	} else {
+		for i1, v1 := range p.Phases {
+			v1.encodeForm(body, append(keyParts, "phases", strconv.Itoa(i1)))
+		}
+	}
+	if p.Prorate != nil {
+		body.Add(form.FormatKey(append(keyParts, "prorate")), strconv.FormatBool(*p.Prorate))
+	}
+	if p.StartDate != nil {
+		body.Add(form.FormatKey(append(keyParts, "start_date")), strconv.FormatInt(*p.StartDate, 10))
+	}
+}
+
+func (p *SubscriptionSchedulePhaseItemParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.BillingThresholds.encodeForm(body, append(keyParts, "billing_thresholds"))
+	if p.Plan != nil {
+		body.Add(form.FormatKey(append(keyParts, "plan")), *p.Plan)
+	}
+	if p.Quantity != nil {
+		body.Add(form.FormatKey(append(keyParts, "quantity")), strconv.FormatInt(*p.Quantity, 10))
+	}
+	if p.TaxRates != nil && len(p.TaxRates) == 0 {
+		body.Add(form.FormatKey(append(keyParts, "tax_rates")), "")
+	} else {
+		for i1, v1 := range p.TaxRates {
+			if v1 != nil {
+				body.Add(form.FormatKey(append(keyParts, "tax_rates", strconv.Itoa(i1))), *v1)
+			}
+		}
+	}
+}
+
+func (p *SubscriptionSchedulePhaseParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.ApplicationFeePercent != nil {
+		body.Add(form.FormatKey(append(keyParts, "application_fee_percent")), strconv.FormatInt(*p.ApplicationFeePercent, 10))
+	}
+	p.BillingThresholds.encodeForm(body, append(keyParts, "billing_thresholds"))
+	if p.CollectionMethod != nil {
+		body.Add(form.FormatKey(append(keyParts, "collection_method")), *p.CollectionMethod)
+	}
+	if p.Coupon != nil {
+		body.Add(form.FormatKey(append(keyParts, "coupon")), *p.Coupon)
+	}
+	if p.DefaultPaymentMethod != nil {
+		body.Add(form.FormatKey(append(keyParts, "default_payment_method")), *p.DefaultPaymentMethod)
+	}
+	if p.DefaultTaxRates != nil && len(p.DefaultTaxRates) == 0 {
+		body.Add(form.FormatKey(append(keyParts, "default_tax_rates")), "")
+	} else {
+		for i1, v1 := range p.DefaultTaxRates {
+			if v1 != nil {
+				body.Add(form.FormatKey(append(keyParts, "default_tax_rates", strconv.Itoa(i1))), *v1)
+			}
+		}
+	}
+	if p.EndDate != nil {
+		body.Add(form.FormatKey(append(keyParts, "end_date")), strconv.FormatInt(*p.EndDate, 10))
+	}
+	p.InvoiceSettings.encodeForm(body, append(keyParts, "invoice_settings"))
+	if p.Iterations != nil {
+		body.Add(form.FormatKey(append(keyParts, "iterations")), strconv.FormatInt(*p.Iterations, 10))
+	}
+	if p.Plans != nil && len(p.Plans) == 0 {
+		body.Add(form.FormatKey(append(keyParts, "plans")), "")
+	} else {
+		for i2, v2 := range p.Plans {
+			v2.encodeForm(body, append(keyParts, "plans", strconv.Itoa(i2)))
+		}
+	}
+	if p.StartDate != nil {
+		body.Add(form.FormatKey(append(keyParts, "start_date")), strconv.FormatInt(*p.StartDate, 10))
+	}
+	if p.Trial != nil {
+		body.Add(form.FormatKey(append(keyParts, "trial")), strconv.FormatBool(*p.Trial))
+	}
+	if p.TrialEnd != nil {
+		body.Add(form.FormatKey(append(keyParts, "trial_end")), strconv.FormatInt(*p.TrialEnd, 10))
+	}
+	if p.TaxPercent != nil {
+		body.Add(form.FormatKey(append(keyParts, "tax_percent")), strconv.FormatFloat(*p.TaxPercent, 'f', 4, 64))
+	}
+}
+
+func (p *SubscriptionScheduleReleaseParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	if p.PreserveCancelDate != nil {
+		body.Add(form.FormatKey(append(keyParts, "preserve_cancel_date")), strconv.FormatBool(*p.PreserveCancelDate))
+	}
+}
+
+func (p *SubscriptionTransferDataParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if p.Destination != nil {
+		body.Add(form.FormatKey(append(keyParts, "destination")), *p.Destination)
+	}
+}
+
+func (p *TaxIDListParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.ListParams.encodeForm(body, keyParts)
+}
+
+func (p *TaxIDParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	if p.Type != nil {
+		body.Add(form.FormatKey(append(keyParts, "type")), *p.Type)
+	}
+	if p.Value != nil {
+		body.Add(form.FormatKey(append(keyParts, "value")), *p.Value)
+	}
+}
+
+func (p *TaxRateListParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.ListParams.encodeForm(body, keyParts)
+	if p.Active != nil {
+		body.Add(form.FormatKey(append(keyParts, "active")), strconv.FormatBool(*p.Active))
+	}
+	if p.Created != nil {
+		body.Add(form.FormatKey(append(keyParts, "created")), strconv.FormatInt(*p.Created, 10))
+	}
+	p.CreatedRange.encodeForm(body, append(keyParts, "created"))
+	if p.Inclusive != nil {
+		body.Add(form.FormatKey(append(keyParts, "inclusive")), strconv.FormatBool(*p.Inclusive))
+	}
+	if p.Percentage != nil {
+		body.Add(form.FormatKey(append(keyParts, "percentage")), strconv.FormatFloat(*p.Percentage, 'f', 4, 64))
+	}
+	p.PercentageRange.encodeForm(body, append(keyParts, "percentage"))
+}
+
+func (p *TaxRateParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	if p.Active != nil {
+		body.Add(form.FormatKey(append(keyParts, "active")), strconv.FormatBool(*p.Active))
+	}
+	if p.Description != nil {
+		body.Add(form.FormatKey(append(keyParts, "description")), *p.Description)
+	}
+	if p.DisplayName != nil {
+		body.Add(form.FormatKey(append(keyParts, "display_name")), *p.DisplayName)
+	}
+	if p.Inclusive != nil {
+		body.Add(form.FormatKey(append(keyParts, "inclusive")), strconv.FormatBool(*p.Inclusive))
+	}
+	if p.Jurisdiction != nil {
+		body.Add(form.FormatKey(append(keyParts, "jurisdiction")), *p.Jurisdiction)
+	}
+	if p.Percentage != nil {
+		body.Add(form.FormatKey(append(keyParts, "percentage")), strconv.FormatFloat(*p.Percentage, 'f', 4, 64))
+	}
+}
+
+func (p *TaxRatePercentageRangeQueryParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	if !(p.GreaterThan == 0) {
+		body.Add(form.FormatKey(append(keyParts, "gt")), strconv.FormatFloat(p.GreaterThan, 'f', 4, 64))
+	}
+	if !(p.GreaterThanOrEqual == 0) {
+		body.Add(form.FormatKey(append(keyParts, "gte")), strconv.FormatFloat(p.GreaterThanOrEqual, 'f', 4, 64))
+	}
+	if !(p.LesserThan == 0) {
+		body.Add(form.FormatKey(append(keyParts, "lt")), strconv.FormatFloat(p.LesserThan, 'f', 4, 64))
+	}
+	if !(p.LesserThanOrEqual == 0) {
+		body.Add(form.FormatKey(append(keyParts, "lte")), strconv.FormatFloat(p.LesserThanOrEqual, 'f', 4, 64))
+	}
+}
+
+func (p *TerminalConnectionTokenParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	if !(p.Location == "") {
+		body.Add(form.FormatKey(append(keyParts, "location")), p.Location)
+	}
+}
+
+func (p *TerminalLocationListParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.ListParams.encodeForm(body, keyParts)
+}
+
+func (p *TerminalLocationParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	p.Address.encodeForm(body, append(keyParts, "address"))
+	if p.DisplayName != nil {
+		body.Add(form.FormatKey(append(keyParts, "display_name")), *p.DisplayName)
+	}
+}
+
+func (p *TerminalReaderGetParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+}
+
+func (p *TerminalReaderListParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.ListParams.encodeForm(body, keyParts)
+	if p.DeviceType != nil {
+		body.Add(form.FormatKey(append(keyParts, "device_type")), *p.DeviceType)
+	}
+	if p.Location != nil {
+		body.Add(form.FormatKey(append(keyParts, "location")), *p.Location)
+	}
+	if p.Status != nil {
+		body.Add(form.FormatKey(append(keyParts, "status")), *p.Status)
+	}
+}
+
+func (p *TerminalReaderParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	if p.Label != nil {
+		body.Add(form.FormatKey(append(keyParts, "label")), *p.Label)
+	}
+	if p.Location != nil {
+		body.Add(form.FormatKey(append(keyParts, "location")), *p.Location)
+	}
+	if p.RegistrationCode != nil {
+		body.Add(form.FormatKey(append(keyParts, "registration_code")), *p.RegistrationCode)
+	}
+}
+
+func (p *ThreeDSecureParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	if p.Amount != nil {
+		body.Add(form.FormatKey(append(keyParts, "amount")), strconv.FormatInt(*p.Amount, 10))
+	}
+	if p.Card != nil {
+		body.Add(form.FormatKey(append(keyParts, "card")), *p.Card)
+	}
+	if p.Currency != nil {
+		body.Add(form.FormatKey(append(keyParts, "currency")), *p.Currency)
+	}
+	if p.Customer != nil {
+		body.Add(form.FormatKey(append(keyParts, "customer")), *p.Customer)
+	}
+	if p.ReturnURL != nil {
+		body.Add(form.FormatKey(append(keyParts, "return_url")), *p.ReturnURL)
+	}
+}
+
+func (p *TokenParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	p.BankAccount.encodeForm(body, append(keyParts, "bank_account"))
+	p.Card.encodeForm(body, append(keyParts, "card"))
+	if p.Customer != nil {
+		body.Add(form.FormatKey(append(keyParts, "customer")), *p.Customer)
+	}
+	if p.Email != nil {
+		body.Add(form.FormatKey(append(keyParts, "email")), *p.Email)
+	}
+	p.PII.encodeForm(body, append(keyParts, "pii"))
+}
+
+func (p *TopupListParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.ListParams.encodeForm(body, keyParts)
+	if p.Created != nil {
+		body.Add(form.FormatKey(append(keyParts, "created")), strconv.FormatInt(*p.Created, 10))
+	}
+	p.CreatedRange.encodeForm(body, append(keyParts, "created"))
+}
+
+func (p *TopupParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	if p.Amount != nil {
+		body.Add(form.FormatKey(append(keyParts, "amount")), strconv.FormatInt(*p.Amount, 10))
+	}
+	if p.Currency != nil {
+		body.Add(form.FormatKey(append(keyParts, "currency")), *p.Currency)
+	}
+	if p.Description != nil {
+		body.Add(form.FormatKey(append(keyParts, "description")), *p.Description)
+	}
+	p.Source.encodeForm(body, keyParts)
+	if p.Source != nil {
+		p.Source.AppendTo(body, keyParts)
+	}
+	if p.StatementDescriptor != nil {
+		body.Add(form.FormatKey(append(keyParts, "statement_descriptor")), *p.StatementDescriptor)
+	}
+	if p.TransferGroup != nil {
+		body.Add(form.FormatKey(append(keyParts, "transfer_group")), *p.TransferGroup)
+	}
+}
+
+func (p *TransferListParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.ListParams.encodeForm(body, keyParts)
+	if p.Created != nil {
+		body.Add(form.FormatKey(append(keyParts, "created")), strconv.FormatInt(*p.Created, 10))
+	}
+	p.CreatedRange.encodeForm(body, append(keyParts, "created"))
+	if p.Destination != nil {
+		body.Add(form.FormatKey(append(keyParts, "destination")), *p.Destination)
+	}
+	if p.TransferGroup != nil {
+		body.Add(form.FormatKey(append(keyParts, "transfer_group")), *p.TransferGroup)
+	}
+}
+
+func (p *TransferParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	if p.Amount != nil {
+		body.Add(form.FormatKey(append(keyParts, "amount")), strconv.FormatInt(*p.Amount, 10))
+	}
+	if p.Currency != nil {
+		body.Add(form.FormatKey(append(keyParts, "currency")), *p.Currency)
+	}
+	if p.Description != nil {
+		body.Add(form.FormatKey(append(keyParts, "description")), *p.Description)
+	}
+	if p.Destination != nil {
+		body.Add(form.FormatKey(append(keyParts, "destination")), *p.Destination)
+	}
+	if p.SourceTransaction != nil {
+		body.Add(form.FormatKey(append(keyParts, "source_transaction")), *p.SourceTransaction)
+	}
+	if p.SourceType != nil {
+		body.Add(form.FormatKey(append(keyParts, "source_type")), *p.SourceType)
+	}
+	if p.TransferGroup != nil {
+		body.Add(form.FormatKey(append(keyParts, "transfer_group")), *p.TransferGroup)
+	}
+}
+
+func (p *UsageRecordParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	if p.Action != nil {
+		body.Add(form.FormatKey(append(keyParts, "action")), *p.Action)
+	}
+	if p.Quantity != nil {
+		body.Add(form.FormatKey(append(keyParts, "quantity")), strconv.FormatInt(*p.Quantity, 10))
+	}
+	if p.Timestamp != nil {
+		body.Add(form.FormatKey(append(keyParts, "timestamp")), strconv.FormatInt(*p.Timestamp, 10))
+	}
+}
+
+func (p *UsageRecordSummaryListParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.ListParams.encodeForm(body, keyParts)
+}
+
+func (p *WebhookEndpointListParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.ListParams.encodeForm(body, keyParts)
+	if p.Created != nil {
+		body.Add(form.FormatKey(append(keyParts, "created")), strconv.FormatInt(*p.Created, 10))
+	}
+	p.CreatedRange.encodeForm(body, append(keyParts, "created"))
+}
+
+func (p *WebhookEndpointParams) encodeForm(body *form.Values, keyParts []string) {
+	if p == nil {
+		return
+	}
+	p.Params.encodeForm(body, keyParts)
+	if p.Connect != nil {
+		body.Add(form.FormatKey(append(keyParts, "connect")), strconv.FormatBool(*p.Connect))
+	}
+	if p.Disabled != nil {
+		body.Add(form.FormatKey(append(keyParts, "disabled")), strconv.FormatBool(*p.Disabled))
+	}
+	if p.EnabledEvents != nil && len(p.EnabledEvents) == 0 {
+		body.Add(form.FormatKey(append(keyParts, "enabled_events")), "")
+	} else {
+		for i1, v1 := range p.EnabledEvents {
+			if v1 != nil {
+				body.Add(form.FormatKey(append(keyParts, "enabled_events", strconv.Itoa(i1))), *v1)
+			}
+		}
+	}
+	if p.URL != nil {
+		body.Add(form.FormatKey(append(keyParts, "url")), *p.URL)
+	}
+	if p.APIVersion != nil {
+		body.Add(form.FormatKey(append(keyParts, "api_version")), *p.APIVersion)
+	}
+}
@@ -0,0 +1,113 @@
+package stripe
+
+import (
+	"reflect"
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+	"github.com/stripe/stripe-go/form"
+)
+
+func TestParamsEncodeTo(t *testing.T) {
+	keyParts := []string{"params"}
+
+	fills := map[string]func(v reflect.Value){
+		"Empty":  func(v reflect.Value) {},
+		"Filled": func(v reflect.Value) { fillParams(v, 0) },
+		"Zeroed": func(v reflect.Value) { zeroParams(v, 0) },
+	}
+
+	for _, params := range allParams {
+		// Files are uploaded as multipart forms instead
+		if _, ok := params.(*FileParams); ok {
+			continue
+		}
+
+		typ := reflect.TypeOf(params).Elem()
+
+		t.Run(typ.Name(), func(t *testing.T) {
+			_, ok := formEncoders[reflect.TypeOf(params)]
+			assert.True(t, ok, "%s has no generated encoder; run `go generate`", typ.Name())
+
+			for name, fill := range fills {
+				v := reflect.New(typ)
+				fill(v.Elem())
+
+				generated := &form.Values{}
+				form.AppendToPrefixed(generated, v.Interface(), keyParts)
+
+				reflected := &form.Values{}
+				form.AppendToPrefixed(reflected, reflectOnly(v), keyParts)
+
+				assert.Equal(t, reflected.Encode(), generated.Encode(), name)
+			}
+		})
+	}
+}
+
+func BenchmarkParamsEncodeTo(b *testing.B) {
+	params := &ChargeParams{}
+	fillParams(reflect.ValueOf(params).Elem(), 0)
+
+	b.Run("Generated", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			form.AppendTo(&form.Values{}, params)
+		}
+	})
+
+	b.Run("Reflection", func(b *testing.B) {
+		wrapped := reflectOnly(reflect.ValueOf(params))
+		for i := 0; i < b.N; i++ {
+			form.AppendTo(&form.Values{}, wrapped)
+		}
+	})
+}
+
+//
+// Private functions
+//
+
+// reflectOnly wraps the params pointed to by v in a struct which doesn't have
+// a registered encoder, so that they're encoded with reflection.
+func reflectOnly(v reflect.Value) interface{} {
+	wrapperType := reflect.StructOf([]reflect.StructField{
+		{Name: "Params", Type: v.Type(), Tag: `form:"*"`},
+	})
+
+	wrapper := reflect.New(wrapperType)
+	wrapper.Elem().Field(0).Set(v)
+	return wrapper.Interface()
+}
+
+// zeroParams sets every form encoded pointer of v to a zero value, and every
+// slice to an empty one, which are encoded differently than unset fields.
+func zeroParams(v reflect.Value, depth int) {
+	if depth > 10 {
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Map:
+		v.Set(reflect.MakeMap(v.Type()))
+		elem := reflect.New(v.Type().Elem()).Elem()
+		zeroParams(elem, depth+1)
+		v.SetMapIndex(reflect.ValueOf("key").Convert(v.Type().Key()), elem)
+
+	case reflect.Ptr:
+		v.Set(reflect.New(v.Type().Elem()))
+		zeroParams(v.Elem(), depth+1)
+
+	case reflect.Slice:
+		v.Set(reflect.MakeSlice(v.Type(), 0, 0))
+
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			name := field.Tag.Get("form")
+			if name == "" || name == "-" || field.PkgPath != "" {
+				continue
+			}
+			zeroParams(v.Field(i), depth+1)
+		}
+	}
+}
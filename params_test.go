@@ -3,6 +3,7 @@ package stripe_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	assert "github.com/stretchr/testify/require"
 	stripe "github.com/stripe/stripe-go"
@@ -67,6 +68,26 @@ func TestRangeQueryParamsAppendTo(t *testing.T) {
 	}
 }
 
+func TestNewRangeQueryParams(t *testing.T) {
+	start := time.Unix(1500000000, 0)
+	end := time.Unix(1500086400, 500)
+
+	body := &form.Values{}
+	form.AppendTo(body, &stripe.SubscriptionScheduleListParams{
+		CreatedRange: stripe.NewRangeQueryParams(start, end),
+	})
+	assert.Equal(t, "created[gte]=1500000000&created[lt]=1500086401", body.Encode())
+
+	// Bounds which aren't set aren't encoded
+	body = &form.Values{}
+	form.AppendTo(body, &stripe.SubscriptionScheduleListParams{
+		CreatedRange: stripe.NewRangeQueryParams(time.Time{}, end),
+	})
+	assert.Equal(t, "created[lt]=1500086401", body.Encode())
+
+	assert.Nil(t, stripe.NewRangeQueryParams(time.Time{}, time.Time{}))
+}
+
 type testListParams struct {
 	stripe.ListParams `form:"*" json:"*"`
 	Field             string `form:"field" json:"field"`
@@ -170,6 +191,37 @@ func TestParams_AppendTo_Nested(t *testing.T) {
 	}), body)
 }
 
+func TestParams_Unset(t *testing.T) {
+	params := &testParams{
+		Params: stripe.Params{
+			Metadata: map[string]string{
+				"foo": "bar",
+				"baz": "qux",
+			},
+		},
+		SubParams: &testSubParams{
+			SubField: "sub_field_value",
+		},
+	}
+	params.Unset("field")
+	params.Unset("metadata", "foo")
+	params.Unset("sub_params")
+
+	// Nulls take precedence over values set on fields
+	params.Field = "field_value"
+
+	assert.True(t, params.IsUnset("metadata", "foo"))
+	assert.False(t, params.IsUnset("metadata", "baz"))
+
+	body := &form.Values{}
+	form.AppendTo(body, params)
+
+	assert.Equal(t, "field=&metadata[foo]=&sub_params=&metadata[baz]=qux", body.Encode())
+	assert.True(t, body.IsNull("field"))
+	assert.True(t, body.IsNull("metadata[foo]"))
+	assert.False(t, body.IsNull("metadata[baz]"))
+}
+
 func TestListParams_Filters(t *testing.T) {
 	p := &testListParams{}
 	p.Filters.AddFilter("created", "gt", "123")
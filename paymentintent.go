@@ -327,6 +327,9 @@ type PaymentIntent struct {
 	Status                    PaymentIntentStatus                `json:"status"`
 	TransferData              *PaymentIntentTransferData         `json:"transfer_data"`
 	TransferGroup             string                             `json:"transfer_group"`
+
+	Extra    map[string]json.RawMessage `json:"-"`
+	expanded bool
 }
 
 // PaymentIntentList is a list of payment intents as retrieved from a list endpoint.
@@ -351,5 +354,6 @@ func (p *PaymentIntent) UnmarshalJSON(data []byte) error {
 	}
 
 	*p = PaymentIntent(v)
+	p.expanded = true
 	return nil
 }
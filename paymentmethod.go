@@ -248,6 +248,9 @@ type PaymentMethod struct {
 	Object         string                    `json:"object"`
 	SepaDebit      *PaymentMethodSepaDebit   `json:"sepa_debit"`
 	Type           PaymentMethodType         `json:"type"`
+
+	Extra    map[string]json.RawMessage `json:"-"`
+	expanded bool
 }
 
 // PaymentMethodList is a list of PaymentMethods as retrieved from a list endpoint.
@@ -272,5 +275,6 @@ func (i *PaymentMethod) UnmarshalJSON(data []byte) error {
 	}
 
 	*i = PaymentMethod(v)
+	i.expanded = true
 	return nil
 }
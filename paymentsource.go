@@ -33,6 +33,31 @@ func (p *SourceParams) AppendTo(body *form.Values, keyParts []string) {
 	}
 }
 
+// DecodeFrom implements custom decoding logic for SourceParams that undoes
+// its AppendTo for cards given as a hash of card details. Other parameters
+// of the card that are encoded alongside those of the containing struct
+// aren't recovered.
+func (p *SourceParams) DecodeFrom(body *form.Values, keyParts []string) error {
+	sourceKeyParts := append(keyParts, cardSource)
+	if !takeFormValue(body, append(sourceKeyParts, "object"), "card") {
+		return nil
+	}
+
+	// The card's own wildcard fields would otherwise consume the source's
+	// token.
+	sourceKey := form.FormatKey(sourceKeyParts)
+	token := body.Get(sourceKey)
+	body.Del(sourceKey)
+
+	p.Card = &CardParams{}
+	err := form.DecodePrefixed(body, p.Card, sourceKeyParts)
+
+	for _, t := range token {
+		body.Add(sourceKey, t)
+	}
+	return err
+}
+
 // CustomerSourceParams are used to manipulate a given Stripe
 // Customer object's payment sources.
 // For more details see https://stripe.com/docs/api#sources
@@ -94,6 +119,9 @@ type PaymentSource struct {
 	ID              string            `json:"id"`
 	SourceObject    *Source           `json:"-"`
 	Type            PaymentSourceType `json:"object"`
+
+	Extra    map[string]json.RawMessage `json:"-"`
+	expanded bool
 }
 
 // SourceList is a list object for cards.
@@ -126,6 +154,7 @@ func (s *PaymentSource) UnmarshalJSON(data []byte) error {
 
 	var err error
 	*s = PaymentSource(v)
+	s.expanded = true
 
 	switch s.Type {
 	case PaymentSourceTypeBankAccount:
@@ -78,6 +78,9 @@ type PayoutDestination struct {
 	Card        *Card                 `json:"-"`
 	ID          string                `json:"id"`
 	Type        PayoutDestinationType `json:"object"`
+
+	Extra    map[string]json.RawMessage `json:"-"`
+	expanded bool
 }
 
 // PayoutParams is the set of parameters that can be used when creating or updating a payout.
@@ -129,6 +132,9 @@ type Payout struct {
 	StatementDescriptor       string              `json:"statement_descriptor"`
 	Status                    PayoutStatus        `json:"status"`
 	Type                      PayoutType          `json:"type"`
+
+	Extra    map[string]json.RawMessage `json:"-"`
+	expanded bool
 }
 
 // PayoutList is a list of payouts as retrieved from a list endpoint.
@@ -153,6 +159,7 @@ func (p *Payout) UnmarshalJSON(data []byte) error {
 	}
 
 	*p = Payout(v)
+	p.expanded = true
 	return nil
 }
 
@@ -173,6 +180,7 @@ func (d *PayoutDestination) UnmarshalJSON(data []byte) error {
 
 	var err error
 	*d = PayoutDestination(v)
+	d.expanded = true
 
 	switch d.Type {
 	case PayoutDestinationTypeBankAccount:
@@ -187,6 +187,9 @@ type Person struct {
 	Requirements     *Requirements       `json:"requirements"`
 	SSNLast4Provided bool                `json:"ssn_last_4_provided"`
 	Verification     *PersonVerification `json:"verification"`
+
+	Extra    map[string]json.RawMessage `json:"-"`
+	expanded bool
 }
 
 // PersonList is a list of persons as retrieved from a list endpoint.
@@ -211,5 +214,6 @@ func (c *Person) UnmarshalJSON(data []byte) error {
 	}
 
 	*c = Person(v)
+	c.expanded = true
 	return nil
 }
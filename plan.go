@@ -88,6 +88,9 @@ type Plan struct {
 	TransformUsage  *PlanTransformUsage `json:"transform_usage"`
 	TrialPeriodDays int64               `json:"trial_period_days"`
 	UsageType       PlanUsageType       `json:"usage_type"`
+
+	Extra    map[string]json.RawMessage `json:"-"`
+	expanded bool
 }
 
 // PlanList is a list of plans as returned from a list endpoint.
@@ -170,6 +173,17 @@ func (p *PlanTierParams) AppendTo(body *form.Values, keyParts []string) {
 	}
 }
 
+// DecodeFrom implements custom up_to deserialisation logic for tiers
+// configuration.
+func (p *PlanTierParams) DecodeFrom(body *form.Values, keyParts []string) error {
+	if takeFormValue(body, append(keyParts, "up_to"), "inf") {
+		p.UpToInf = Bool(true)
+		return nil
+	}
+
+	return form.DecodePrefixed(body, &p.UpTo, append(keyParts, "up_to"))
+}
+
 // PlanProductParams is the set of parameters that can be used when creating a product inside a plan
 // This can only be used on plan creation and won't work on plan update.
 // For more details see https://stripe.com/docs/api#create_plan-product and https://stripe.com/docs/api#update_plan-product
@@ -198,5 +212,6 @@ func (s *Plan) UnmarshalJSON(data []byte) error {
 	}
 
 	*s = Plan(v)
+	s.expanded = true
 	return nil
 }
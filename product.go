@@ -69,6 +69,9 @@ type Product struct {
 	UnitLabel           string             `json:"unit_label"`
 	URL                 string             `json:"url"`
 	Updated             int64              `json:"updated"`
+
+	Extra    map[string]json.RawMessage `json:"-"`
+	expanded bool
 }
 
 // ProductList is a list of products as retrieved from a list endpoint.
@@ -105,5 +108,6 @@ func (p *Product) UnmarshalJSON(data []byte) error {
 	}
 
 	*p = Product(v)
+	p.expanded = true
 	return nil
 }
@@ -1,5 +1,7 @@
 package stripe
 
+import "encoding/json"
+
 // RadarEarlyFraudWarningFraudType are strings that map to the type of fraud labelled by the issuer.
 type RadarEarlyFraudWarningFraudType string
 
@@ -45,4 +47,6 @@ type RadarEarlyFraudWarning struct {
 	FraudType  RadarEarlyFraudWarningFraudType `json:"fraud_type"`
 	ID         string                          `json:"id"`
 	Livemode   bool                            `json:"livemode"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
@@ -1,5 +1,7 @@
 package stripe
 
+import "encoding/json"
+
 // RadarValueListItemType is the possible values for a type of value list items.
 type RadarValueListItemType string
 
@@ -46,6 +48,8 @@ type RadarValueList struct {
 	Object    string                  `json:"object"`
 	Updated   int64                   `json:"updated"`
 	UpdatedBy string                  `json:"updated_by"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // RadarValueListList is a list of value lists as retrieved from a list endpoint.
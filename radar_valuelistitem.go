@@ -1,5 +1,7 @@
 package stripe
 
+import "encoding/json"
+
 // RadarValueListItemParams is the set of parameters that can be used when creating a value list item.
 type RadarValueListItemParams struct {
 	Params         `form:"*" json:"*"`
@@ -27,6 +29,8 @@ type RadarValueListItem struct {
 	Object         string `json:"object"`
 	Value          string `json:"value"`
 	RadarValueList string `json:"value_list"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // RadarValueListItemList is a list of value list items as retrieved from a list endpoint.
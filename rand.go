@@ -0,0 +1,77 @@
+package stripe
+
+import (
+	cryptorand "crypto/rand"
+	"math/rand"
+	"sync"
+)
+
+//
+// Public types
+//
+
+// Rand is a source of randomness. The library uses one for the random part
+// of generated idempotency keys and for the jitter added to the time slept
+// between retries, so that both can be made deterministic by replacing it.
+// See NewDeterministicRand.
+//
+// Implementations must be safe for concurrent use.
+type Rand interface {
+	// Int63n returns a non-negative pseudo-random number in [0, n).
+	Int63n(n int64) int64
+
+	// Read fills p with random bytes.
+	Read(p []byte) (int, error)
+}
+
+//
+// Public variables
+//
+
+// DefaultRand is the source of randomness used by package-level functions
+// like NewIdempotencyKey and by backends configured without one of their
+// own. It reads bytes from crypto/rand and numbers from math/rand.
+var DefaultRand Rand = systemRand{}
+
+//
+// Public functions
+//
+
+// NewDeterministicRand returns a Rand producing the same sequence every time
+// for a given seed, which makes recorded requests comparable across test
+// runs. It's not suitable for anything else.
+func NewDeterministicRand(seed int64) Rand {
+	return &lockedRand{rand: rand.New(rand.NewSource(seed))}
+}
+
+//
+// Private types
+//
+
+// lockedRand guards a *rand.Rand, which isn't safe for concurrent use.
+type lockedRand struct {
+	mu   sync.Mutex
+	rand *rand.Rand
+}
+
+func (r *lockedRand) Int63n(n int64) int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rand.Int63n(n)
+}
+
+func (r *lockedRand) Read(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rand.Read(p)
+}
+
+type systemRand struct{}
+
+func (systemRand) Int63n(n int64) int64 {
+	return rand.Int63n(n)
+}
+
+func (systemRand) Read(p []byte) (int, error) {
+	return cryptorand.Read(p)
+}
@@ -44,6 +44,20 @@ func (p *RecipientParams) AppendTo(body *form.Values, keyParts []string) {
 	}
 }
 
+// DecodeFrom implements custom decoding logic for RecipientParams that undoes
+// its AppendTo.
+func (p *RecipientParams) DecodeFrom(body *form.Values, keyParts []string) error {
+	bankAccountKeyParts := append(keyParts, "bank_account")
+
+	if token := body.Get(form.FormatKey(bankAccountKeyParts)); len(token) > 0 {
+		body.Del(form.FormatKey(bankAccountKeyParts))
+		p.BankAccount = &BankAccountParams{Token: String(token[0])}
+		return nil
+	}
+
+	return form.DecodePrefixed(body, &p.BankAccount, bankAccountKeyParts)
+}
+
 // RecipientListParams is the set of parameters that can be used when listing recipients.
 // For more details see https://stripe.com/docs/api#list_recipients.
 type RecipientListParams struct {
@@ -67,6 +81,9 @@ type Recipient struct {
 	MigratedTo    *Account          `json:"migrated_to"`
 	Name          string            `json:"name"`
 	Type          RecipientType     `json:"type"`
+
+	Extra    map[string]json.RawMessage `json:"-"`
+	expanded bool
 }
 
 // RecipientList is a list of recipients as retrieved from a list endpoint.
@@ -91,5 +108,6 @@ func (r *Recipient) UnmarshalJSON(data []byte) error {
 	}
 
 	*r = Recipient(v)
+	r.expanded = true
 	return nil
 }
@@ -76,6 +76,9 @@ type RecipientTransferDestination struct {
 	Card        *Card                            `json:"-"`
 	ID          string                           `json:"id"`
 	Type        RecipientTransferDestinationType `json:"object"`
+
+	Extra    map[string]json.RawMessage `json:"-"`
+	expanded bool
 }
 
 // RecipientTransfer is the resource representing a Stripe recipient_transfer.
@@ -105,6 +108,9 @@ type RecipientTransfer struct {
 	StatementDescriptor string                       `json:"statement_descriptor"`
 	Status              RecipientTransferStatus      `json:"status"`
 	Type                RecipientTransferType        `json:"type"`
+
+	Extra    map[string]json.RawMessage `json:"-"`
+	expanded bool
 }
 
 // UnmarshalJSON handles deserialization of a RecipientTransfer.
@@ -123,6 +129,7 @@ func (t *RecipientTransfer) UnmarshalJSON(data []byte) error {
 	}
 
 	*t = RecipientTransfer(v)
+	t.expanded = true
 	return nil
 }
 
@@ -143,6 +150,7 @@ func (d *RecipientTransferDestination) UnmarshalJSON(data []byte) error {
 
 	var err error
 	*d = RecipientTransferDestination(v)
+	d.expanded = true
 
 	switch d.Type {
 	case RecipientTransferDestinationBankAccount:
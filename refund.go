@@ -75,6 +75,9 @@ type Refund struct {
 	SourceTransferReversal    *Reversal           `json:"source_transfer_reversal"`
 	Status                    RefundStatus        `json:"status"`
 	TransferReversal          *Reversal           `json:"transfer_reversal"`
+
+	Extra    map[string]json.RawMessage `json:"-"`
+	expanded bool
 }
 
 // RefundList is a list object for refunds.
@@ -99,5 +102,6 @@ func (r *Refund) UnmarshalJSON(data []byte) error {
 	}
 
 	*r = Refund(v)
+	r.expanded = true
 	return nil
 }
@@ -1,5 +1,7 @@
 package stripe
 
+import "encoding/json"
+
 // ReportRunStatus is the possible values for status on a report run.
 type ReportRunStatus string
 
@@ -58,6 +60,8 @@ type ReportRun struct {
 	Result      *File                `json:"result"`
 	Status      ReportRunStatus      `json:"status"`
 	SucceededAt int64                `json:"succeeded_at"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // ReportRunList is a list of report runs as retrieved from a list endpoint.
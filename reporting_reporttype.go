@@ -1,5 +1,7 @@
 package stripe
 
+import "encoding/json"
+
 // ReportTypeListParams is the set of parameters that can be used when listing report types.
 type ReportTypeListParams struct {
 	ListParams `form:"*" json:"*"`
@@ -21,6 +23,8 @@ type ReportType struct {
 	Object             string   `json:"object"`
 	Updated            int64    `json:"updated"`
 	Version            int64    `json:"version"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // ReportTypeList is a list of report types as retrieved from a list endpoint.
@@ -0,0 +1,152 @@
+package stripe
+
+import (
+	"context"
+	"net/http"
+)
+
+// This file contains functions for carrying request options on a
+// context.Context. Options set this way apply to any request made with a
+// params struct whose Context is (or is derived from) that context, which
+// makes it possible to set something like the connected account for a tenant
+// once in an HTTP middleware instead of on every single params struct.
+//
+// When the same option is given in more than one place, precedence is as
+// follows (from highest to lowest):
+//
+//     1. Fields set explicitly on Params (StripeAccount, IdempotencyKey,
+//        Headers), including the account of a client from WithAccount.
+//     2. Options carried on the params' Context.
+//     3. Backend defaults.
+
+//
+// Public functions
+//
+
+// WithHeader returns a copy of ctx that adds an extra header line to
+// requests made with it. Adding the same key more than once sends all of its
+// values. Headers set on Params with the same key take precedence.
+//
+// Headers that the library derives from keys and other options, like
+// Authorization and Stripe-Account, can't be set this way and are ignored;
+// use WithStripeAccount or WithStripeVersion instead.
+func WithHeader(ctx context.Context, key, value string) context.Context {
+	return withRequestOptions(ctx, func(opts *requestOptions) {
+		if opts.headers == nil {
+			opts.headers = make(http.Header)
+		}
+		opts.headers.Add(key, value)
+	})
+}
+
+// WithIdempotencyKeyGenerator returns a copy of ctx that generates the
+// idempotency keys of requests made with it with the given generator, like a
+// DeterministicKeyGenerator for a single operation. It's not applied to keys
+// set explicitly with Params.IdempotencyKey, and a prefix set with
+// WithIdempotencyKeyPrefix is still prepended.
+func WithIdempotencyKeyGenerator(ctx context.Context, generator IdempotencyKeyGenerator) context.Context {
+	return withRequestOptions(ctx, func(opts *requestOptions) {
+		opts.idempotencyKeyGenerator = generator
+	})
+}
+
+// WithIdempotencyKeyPrefix returns a copy of ctx that prefixes idempotency
+// keys generated for requests made with it. It's not applied to keys set
+// explicitly with Params.IdempotencyKey.
+func WithIdempotencyKeyPrefix(ctx context.Context, prefix string) context.Context {
+	return withRequestOptions(ctx, func(opts *requestOptions) {
+		opts.idempotencyKeyPrefix = prefix
+	})
+}
+
+// WithLeveledLogger returns a copy of ctx that makes requests made with it
+// log to the given logger instead of the backend's.
+func WithLeveledLogger(ctx context.Context, logger LeveledLoggerInterface) context.Context {
+	return withRequestOptions(ctx, func(opts *requestOptions) {
+		opts.leveledLogger = logger
+	})
+}
+
+// WithStripeAccount returns a copy of ctx that makes requests made with it on
+// behalf of the given connected account, unless Params.StripeAccount is set.
+func WithStripeAccount(ctx context.Context, account string) context.Context {
+	return withRequestOptions(ctx, func(opts *requestOptions) {
+		opts.stripeAccount = account
+	})
+}
+
+// WithStripeVersion returns a copy of ctx that overrides the API version sent
+// with requests made with it.
+//
+// Be careful with this: the library's types are modeled after APIVersion and
+// responses from other versions may not deserialize properly.
+func WithStripeVersion(ctx context.Context, version string) context.Context {
+	return withRequestOptions(ctx, func(opts *requestOptions) {
+		opts.stripeVersion = version
+	})
+}
+
+//
+// Private variables
+//
+
+// reservedHeaders are the headers which WithHeader can't set, because
+// they're derived from the key, the params or other options.
+var reservedHeaders = map[string]bool{
+	"Authorization":   true,
+	"Content-Type":    true,
+	"Idempotency-Key": true,
+	"Stripe-Account":  true,
+	"Stripe-Version":  true,
+}
+
+//
+// Private types
+//
+
+// requestOptions are the options carried on a context. A context's options
+// are never modified once set; a new copy is made every time one changes so
+// that contexts derived from a common parent don't affect each other.
+type requestOptions struct {
+	headers                 http.Header
+	idempotencyKeyGenerator IdempotencyKeyGenerator
+	idempotencyKeyPrefix    string
+	leveledLogger           LeveledLoggerInterface
+	stripeAccount           string
+	stripeVersion           string
+}
+
+// requestOptionsKey is the context key for requestOptions.
+type requestOptionsKey struct{}
+
+//
+// Private functions
+//
+
+// getRequestOptions returns the options carried on ctx, or nil if there are
+// none.
+func getRequestOptions(ctx context.Context) *requestOptions {
+	if ctx == nil {
+		return nil
+	}
+	opts, _ := ctx.Value(requestOptionsKey{}).(*requestOptions)
+	return opts
+}
+
+func withRequestOptions(ctx context.Context, fn func(opts *requestOptions)) context.Context {
+	opts := &requestOptions{}
+	if existing := getRequestOptions(ctx); existing != nil {
+		*opts = *existing
+
+		if existing.headers != nil {
+			opts.headers = make(http.Header, len(existing.headers))
+			for k, v := range existing.headers {
+				opts.headers[k] = append([]string(nil), v...)
+			}
+		}
+	}
+
+	fn(opts)
+
+	return context.WithValue(ctx, requestOptionsKey{}, opts)
+}
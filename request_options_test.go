@@ -0,0 +1,150 @@
+package stripe
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestRequestOptions_StripeAccount(t *testing.T) {
+	c := GetBackend(APIBackend).(*BackendImplementation)
+	ctx := WithStripeAccount(context.Background(), "acct_123")
+
+	req, err := c.NewRequest("", "", "", "", &Params{Context: ctx})
+	assert.NoError(t, err)
+	assert.Equal(t, "acct_123", req.Header.Get("Stripe-Account"))
+
+	// An explicit account on params takes precedence
+	p := &Params{Context: ctx}
+	p.SetStripeAccount("acct_456")
+	req, err = c.NewRequest("", "", "", "", p)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"acct_456"}, req.Header["Stripe-Account"])
+}
+
+func TestRequestOptions_IdempotencyKeyPrefix(t *testing.T) {
+	c := GetBackend(APIBackend).(*BackendImplementation)
+	ctx := WithIdempotencyKeyPrefix(context.Background(), "job-42-")
+
+	req, err := c.NewRequest(http.MethodPost, "", "", "", &Params{Context: ctx})
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(req.Header.Get("Idempotency-Key"), "job-42-"))
+
+	// The prefix doesn't apply to explicit keys
+	p := &Params{Context: ctx, IdempotencyKey: String("idempotency-key")}
+	req, err = c.NewRequest(http.MethodPost, "", "", "", p)
+	assert.NoError(t, err)
+	assert.Equal(t, "idempotency-key", req.Header.Get("Idempotency-Key"))
+
+	// Nor is a key generated for reads
+	req, err = c.NewRequest(http.MethodGet, "", "", "", &Params{Context: ctx})
+	assert.NoError(t, err)
+	assert.Equal(t, "", req.Header.Get("Idempotency-Key"))
+
+	// Generated keys are still subject to the length limit
+	ctx = WithIdempotencyKeyPrefix(context.Background(), strings.Repeat("x", 255))
+	_, err = c.NewRequest(http.MethodPost, "", "", "", &Params{Context: ctx})
+	assert.Error(t, err)
+}
+
+func TestRequestOptions_StripeVersion(t *testing.T) {
+	c := GetBackend(APIBackend).(*BackendImplementation)
+
+	req, err := c.NewRequest("", "", "", "", &Params{Context: context.Background()})
+	assert.NoError(t, err)
+	assert.Equal(t, APIVersion, req.Header.Get("Stripe-Version"))
+
+	ctx := WithStripeVersion(context.Background(), "2019-11-05")
+	req, err = c.NewRequest("", "", "", "", &Params{Context: ctx})
+	assert.NoError(t, err)
+	assert.Equal(t, "2019-11-05", req.Header.Get("Stripe-Version"))
+}
+
+func TestRequestOptions_Headers(t *testing.T) {
+	c := GetBackend(APIBackend).(*BackendImplementation)
+
+	parent := WithHeader(context.Background(), "X-Tenant", "tenant_1")
+	ctx := WithHeader(parent, "X-Trace", "trace_1")
+
+	p := &Params{Context: ctx, Headers: http.Header{"X-Tenant": {"tenant_2"}}}
+	req, err := c.NewRequest("", "", "", "", p)
+	assert.NoError(t, err)
+	assert.Equal(t, "trace_1", req.Header.Get("X-Trace"))
+
+	// Headers on params take precedence
+	assert.Equal(t, "tenant_2", req.Header.Get("X-Tenant"))
+
+	// Deriving a context doesn't modify its parent
+	req, err = c.NewRequest("", "", "", "", &Params{Context: parent})
+	assert.NoError(t, err)
+	assert.Equal(t, "", req.Header.Get("X-Trace"))
+}
+
+func TestRequestOptions_HeadersMultipleValues(t *testing.T) {
+	c := GetBackend(APIBackend).(*BackendImplementation)
+
+	ctx := WithHeader(context.Background(), "X-Tag", "a")
+	ctx = WithHeader(ctx, "X-Tag", "b")
+	ctx = WithHeader(ctx, "User-Agent", "custom")
+
+	req, err := c.NewRequest("", "", "", "", &Params{Context: ctx})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, req.Header["X-Tag"])
+
+	// Defaults other than reserved headers can be replaced
+	assert.Equal(t, []string{"custom"}, req.Header["User-Agent"])
+}
+
+func TestRequestOptions_HeadersPrecedence(t *testing.T) {
+	c := GetBackend(APIBackend).(*BackendImplementation)
+
+	ctx := WithHeader(context.Background(), "Stripe-Account", "acct_ctx")
+	ctx = WithHeader(ctx, "Authorization", "Bearer sk_ctx")
+	ctx = WithHeader(ctx, "Idempotency-Key", "ctx-key")
+	ctx = WithHeader(ctx, "Stripe-Version", "2000-01-01")
+
+	p := &Params{Context: ctx, IdempotencyKey: String("explicit-key")}
+	p.SetStripeAccount("acct_explicit")
+	req, err := c.NewRequest(http.MethodPost, "", "sk_test_123", "", p)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"acct_explicit"}, req.Header["Stripe-Account"])
+	assert.Equal(t, []string{"Bearer sk_test_123"}, req.Header["Authorization"])
+	assert.Equal(t, []string{"explicit-key"}, req.Header["Idempotency-Key"])
+	assert.Equal(t, []string{APIVersion}, req.Header["Stripe-Version"])
+
+	// Reserved headers are ignored even without explicit values
+	req, err = c.NewRequest(http.MethodGet, "", "sk_test_123", "", &Params{Context: ctx})
+	assert.NoError(t, err)
+	assert.Equal(t, "", req.Header.Get("Stripe-Account"))
+	assert.Equal(t, "", req.Header.Get("Idempotency-Key"))
+}
+
+func TestRequestOptions_LeveledLogger(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer testServer.Close()
+
+	var backendOut, requestOut bytes.Buffer
+	backend := GetBackendWithConfig(
+		APIBackend,
+		&BackendConfig{
+			LeveledLogger: &LeveledLogger{Level: LevelInfo, stdoutOverride: &backendOut},
+			URL:           testServer.URL,
+		},
+	).(*BackendImplementation)
+
+	requestLogger := &LeveledLogger{Level: LevelInfo, stdoutOverride: &requestOut}
+	ctx := WithLeveledLogger(context.Background(), requestLogger)
+
+	err := backend.Call(http.MethodGet, "/v1/charges", "sk_test_123", &ChargeParams{Params: Params{Context: ctx}}, nil)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "", backendOut.String())
+	assert.Contains(t, requestOut.String(), "Requesting GET")
+}
@@ -29,6 +29,9 @@ type Reversal struct {
 	Metadata                 map[string]string   `json:"metadata"`
 	SourceRefund             *Refund             `json:"source_refund"`
 	Transfer                 string              `json:"transfer"`
+
+	Extra    map[string]json.RawMessage `json:"-"`
+	expanded bool
 }
 
 // ReversalList is a list of object for reversals.
@@ -53,5 +56,6 @@ func (r *Reversal) UnmarshalJSON(data []byte) error {
 	}
 
 	*r = Reversal(v)
+	r.expanded = true
 	return nil
 }
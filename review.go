@@ -43,6 +43,9 @@ type Review struct {
 	Open          bool             `json:"open"`
 	PaymentIntent *PaymentIntent   `json:"payment_intent"`
 	Reason        ReviewReasonType `json:"reason"`
+
+	Extra    map[string]json.RawMessage `json:"-"`
+	expanded bool
 }
 
 // ReviewList is a list of reviews as retrieved from a list endpoint.
@@ -67,5 +70,6 @@ func (r *Review) UnmarshalJSON(data []byte) error {
 	}
 
 	*r = Review(v)
+	r.expanded = true
 	return nil
 }
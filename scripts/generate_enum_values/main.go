@@ -0,0 +1,185 @@
+// A script that generates a table of the values of all the typed string
+// constants in the stripe package, like the ones listing the values that
+// SubscriptionStatus can take, keyed by the name of their type. The table is
+// what parameter validation checks enum parameters against.
+//
+// Run it from the root of the repository:
+//
+//	go run scripts/generate_enum_values/main.go
+//
+// Or with `-check` to fail if the generated file isn't up to date instead of
+// writing it.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+func main() {
+	check := flag.Bool("check", false,
+		"Fail if the generated file isn't up to date instead of writing it")
+	flag.Parse()
+
+	enums, err := findEnums(".")
+	if err != nil {
+		exitWithError(err)
+	}
+
+	source, err := generate(enums)
+	if err != nil {
+		exitWithError(err)
+	}
+
+	if *check {
+		existing, err := ioutil.ReadFile(outputPath)
+		if err != nil {
+			exitWithError(err)
+		}
+
+		if !bytes.Equal(existing, source) {
+			exitWithError(fmt.Errorf("%s is out of date; run `go generate` to regenerate it",
+				outputPath))
+		}
+		return
+	}
+
+	if err := ioutil.WriteFile(outputPath, source, 0644); err != nil {
+		exitWithError(err)
+	}
+}
+
+//
+// Private
+//
+
+// Name of the file containing the generated table.
+const outputPath = "enum_values.go"
+
+func exitWithError(err error) {
+	fmt.Fprintf(os.Stderr, "%v\n", err)
+	os.Exit(1)
+}
+
+// findEnums returns the values of the constants of each exported string type
+// in the stripe package found in dir, keyed by the name of the type.
+func findEnums(dir string) (map[string][]string, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(info os.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go") &&
+			info.Name() != filepath.Base(outputPath)
+	}, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	pkg, ok := pkgs["stripe"]
+	if !ok {
+		return nil, fmt.Errorf("no stripe package found in %s "+
+			"(maybe check the working directory?)", dir)
+	}
+
+	stringTypes := make(map[string]bool)
+	var constSpecs []*ast.ValueSpec
+
+	for _, f := range pkg.Files {
+		for _, decl := range f.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok {
+				continue
+			}
+
+			for _, spec := range genDecl.Specs {
+				switch spec := spec.(type) {
+				case *ast.TypeSpec:
+					if ident, ok := spec.Type.(*ast.Ident); ok && ident.Name == "string" && spec.Name.IsExported() {
+						stringTypes[spec.Name.Name] = true
+					}
+
+				case *ast.ValueSpec:
+					if genDecl.Tok == token.CONST && spec.Type != nil {
+						constSpecs = append(constSpecs, spec)
+					}
+				}
+			}
+		}
+	}
+
+	enums := make(map[string][]string)
+
+	for _, spec := range constSpecs {
+		ident, ok := spec.Type.(*ast.Ident)
+		if !ok || !stringTypes[ident.Name] {
+			continue
+		}
+
+		for i, name := range spec.Names {
+			if !name.IsExported() {
+				continue
+			}
+
+			lit, ok := spec.Values[i].(*ast.BasicLit)
+			if !ok || lit.Kind != token.STRING {
+				return nil, fmt.Errorf("%s: value of %s isn't a string literal",
+					fset.Position(name.Pos()), name.Name)
+			}
+
+			val, err := strconv.Unquote(lit.Value)
+			if err != nil {
+				return nil, err
+			}
+
+			enums[ident.Name] = append(enums[ident.Name], val)
+		}
+	}
+
+	return enums, nil
+}
+
+// generate produces the formatted source of the generated file.
+func generate(enums map[string][]string) ([]byte, error) {
+	var names []string
+	for name := range enums {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var out bytes.Buffer
+	out.WriteString("// Code generated by scripts/generate_enum_values. DO NOT EDIT.\n\n")
+	out.WriteString("package stripe\n\n")
+	out.WriteString("// enumValues are the values of the constants of each of the package's\n")
+	out.WriteString("// string types, keyed by the name of the type.\n")
+	out.WriteString("var enumValues = map[string][]string{\n")
+
+	for _, name := range names {
+		values := enums[name]
+		sort.Strings(values)
+
+		var quoted []string
+		seen := make(map[string]bool)
+		for _, val := range values {
+			if seen[val] {
+				continue
+			}
+			seen[val] = true
+			quoted = append(quoted, strconv.Quote(val))
+		}
+
+		fmt.Fprintf(&out, "%s: {%s},\n", strconv.Quote(name), strings.Join(quoted, ", "))
+	}
+
+	out.WriteString("}\n")
+
+	return format.Source(out.Bytes())
+}
@@ -0,0 +1,575 @@
+// A script that generates typed paths for the fields that can be expanded in
+// API responses, like ChargeExpand.Customer.DefaultSource, along with the
+// IsExpanded and Fetch methods of the resources that can be returned as either
+// an ID or a full object.
+//
+// A resource is expandable when its UnmarshalJSON method handles a bare ID
+// with ParseID. Paths are generated for every field holding one, including
+// those nested in other structs, up to the maximum depth of expansion that the
+// API allows. Fetch is generated for the resources that have a `Get` method
+// in a resource package retrieving them by ID alone.
+//
+// Run it from the root of the repository:
+//
+//	go run scripts/generate_expand_paths/main.go
+//
+// Or with `-check` to fail if the generated file isn't up to date instead of
+// writing it.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+func main() {
+	check := flag.Bool("check", false,
+		"Fail if the generated file isn't up to date instead of writing it")
+	flag.Parse()
+
+	g, err := newGenerator(".")
+	if err != nil {
+		exitWithError(err)
+	}
+
+	source, err := g.generate()
+	if err != nil {
+		exitWithError(err)
+	}
+
+	if *check {
+		existing, err := ioutil.ReadFile(outputPath)
+		if err != nil {
+			exitWithError(err)
+		}
+
+		if !bytes.Equal(existing, source) {
+			exitWithError(fmt.Errorf("%s is out of date; run `go generate` to regenerate it",
+				outputPath))
+		}
+		return
+	}
+
+	if err := ioutil.WriteFile(outputPath, source, 0644); err != nil {
+		exitWithError(err)
+	}
+}
+
+//
+// Private
+//
+
+const (
+	// Name of the file containing the generated code.
+	outputPath = "expand_paths.go"
+
+	// Maximum number of levels of a path to expand, as enforced by the API.
+	maxDepth = 4
+)
+
+// reservedFieldNames are the names that can't be used for the fields of
+// generated paths because they clash with the embedded ExpandPath.
+var reservedFieldNames = map[string]bool{
+	"ExpandPath": true,
+	"InList":     true,
+	"Path":       true,
+}
+
+// field is an expandable field, or a struct containing some, found in a
+// struct of the stripe package.
+type field struct {
+	jsonName string
+	name     string
+	typeName string
+}
+
+// generator keeps track of the types found in the stripe package and the
+// code generated for them.
+type generator struct {
+	buf bytes.Buffer
+
+	// expandables maps each expandable type to the name of the receiver of
+	// its UnmarshalJSON method.
+	expandables map[string]string
+
+	// fetchPaths maps expandable types to the path to retrieve them from.
+	fetchPaths map[string]string
+
+	// nodes memoizes the fields of a type that lead to expandable fields,
+	// keyed by type name and depth.
+	nodes map[nodeKey][]field
+
+	// pending is the queue of types to generate at each depth.
+	pending []nodeKey
+	seen    map[nodeKey]bool
+
+	structs map[string]*ast.StructType
+}
+
+type nodeKey struct {
+	depth    int
+	typeName string
+}
+
+func exitWithError(err error) {
+	fmt.Fprintf(os.Stderr, "%v\n", err)
+	os.Exit(1)
+}
+
+func newGenerator(dir string) (*generator, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(info os.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go") &&
+			info.Name() != filepath.Base(outputPath)
+	}, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	pkg, ok := pkgs["stripe"]
+	if !ok {
+		return nil, fmt.Errorf("no stripe package found in %s "+
+			"(maybe check the working directory?)", dir)
+	}
+
+	g := &generator{
+		expandables: make(map[string]string),
+		fetchPaths:  make(map[string]string),
+		nodes:       make(map[nodeKey][]field),
+		seen:        make(map[nodeKey]bool),
+		structs:     make(map[string]*ast.StructType),
+	}
+
+	for _, f := range pkg.Files {
+		for _, decl := range f.Decls {
+			switch decl := decl.(type) {
+			case *ast.FuncDecl:
+				if typeName, recv, ok := parseIDUnmarshaler(decl); ok {
+					g.expandables[typeName] = recv
+				}
+
+			case *ast.GenDecl:
+				for _, spec := range decl.Specs {
+					typeSpec, ok := spec.(*ast.TypeSpec)
+					if !ok {
+						continue
+					}
+					if structType, ok := typeSpec.Type.(*ast.StructType); ok {
+						g.structs[typeSpec.Name.Name] = structType
+					}
+				}
+			}
+		}
+	}
+
+	if err := g.findFetchPaths(dir); err != nil {
+		return nil, err
+	}
+
+	return g, nil
+}
+
+// findFetchPaths looks for `Get` methods retrieving a resource by ID alone in
+// the resource packages under dir.
+func (g *generator) findFetchPaths(dir string) error {
+	return filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			if info.Name() == "scripts" || info.Name() == "vendor" ||
+				(strings.HasPrefix(info.Name(), ".") && path != dir) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if info.Name() != "client.go" {
+			return nil
+		}
+
+		f, err := parser.ParseFile(token.NewFileSet(), path, nil, 0)
+		if err != nil {
+			return err
+		}
+
+		for _, decl := range f.Decls {
+			funcDecl, ok := decl.(*ast.FuncDecl)
+			if !ok {
+				continue
+			}
+
+			typeName, urlPath, ok := parseGetMethod(funcDecl)
+			if !ok {
+				continue
+			}
+
+			if existing, ok := g.fetchPaths[typeName]; ok && existing != urlPath {
+				return fmt.Errorf("%s: %s is retrieved from both %s and %s",
+					path, typeName, existing, urlPath)
+			}
+			g.fetchPaths[typeName] = urlPath
+		}
+
+		return nil
+	})
+}
+
+// fields returns the fields of a type leading to expandable fields at the
+// given depth, where the type itself is at depth - 1.
+func (g *generator) fields(typeName string, depth int) []field {
+	key := nodeKey{depth: depth, typeName: typeName}
+	if fields, ok := g.nodes[key]; ok {
+		return fields
+	}
+
+	// Types can refer to each other, but the recursion ends because each
+	// level is one deeper than the last.
+	var fields []field
+	if depth <= maxDepth {
+		structType := g.structs[typeName]
+		for _, f := range structType.Fields.List {
+			if len(f.Names) != 1 || !f.Names[0].IsExported() || f.Tag == nil {
+				continue
+			}
+
+			jsonName := jsonFieldName(f.Tag)
+			if jsonName == "" || jsonName == "-" {
+				continue
+			}
+
+			fieldTypeName := structTypeName(f.Type)
+			if _, ok := g.structs[fieldTypeName]; !ok {
+				continue
+			}
+
+			_, expandable := g.expandables[fieldTypeName]
+			if !expandable && len(g.fields(fieldTypeName, depth+1)) == 0 {
+				continue
+			}
+
+			fields = append(fields, field{
+				jsonName: jsonName,
+				name:     f.Names[0].Name,
+				typeName: fieldTypeName,
+			})
+		}
+	}
+
+	g.nodes[key] = fields
+	return fields
+}
+
+func (g *generator) generate() ([]byte, error) {
+	var typeNames []string
+	for typeName := range g.expandables {
+		if _, ok := g.structs[typeName]; !ok {
+			return nil, fmt.Errorf("no struct found for expandable type %s", typeName)
+		}
+		typeNames = append(typeNames, typeName)
+	}
+	sort.Strings(typeNames)
+
+	g.buf.WriteString("// Code generated by scripts/generate_expand_paths. DO NOT EDIT.\n\n")
+	g.buf.WriteString("package stripe\n\n")
+
+	for _, typeName := range typeNames {
+		recv := g.expandables[typeName]
+		name := readableName(typeName)
+
+		fmt.Fprintf(&g.buf, "// IsExpanded returns whether the %s was returned as a full\n", name)
+		g.buf.WriteString("// object, rather than only its ID.\n")
+		fmt.Fprintf(&g.buf, "func (%s *%s) IsExpanded() bool {\n", recv, typeName)
+		fmt.Fprintf(&g.buf, "return %s != nil && %s.expanded\n", recv, recv)
+		g.buf.WriteString("}\n\n")
+
+		if urlPath, ok := g.fetchPaths[typeName]; ok {
+			fmt.Fprintf(&g.buf, "// Fetch retrieves the full %s into itself unless it was\n", name)
+			g.buf.WriteString("// already expanded, with the given key and params. The default API backend\n")
+			g.buf.WriteString("// is used when backend is nil, and stripe.Key when key is empty.\n")
+			fmt.Fprintf(&g.buf, "func (%s *%s) Fetch(backend Backend, key string, params *Params) error {\n", recv, typeName)
+			fmt.Fprintf(&g.buf, "return fetchExpandable(backend, key, params, %s, %s, %s.ID)\n",
+				recv, strconv.Quote(urlPath), recv)
+			g.buf.WriteString("}\n\n")
+		}
+	}
+
+	for _, typeName := range typeNames {
+		fields := g.fields(typeName, 1)
+		if len(fields) == 0 {
+			continue
+		}
+
+		name := readableName(typeName)
+		pathsType := typeName + "ExpandPaths"
+
+		fmt.Fprintf(&g.buf, "// %s are the paths of the fields that can be expanded in\n", pathsType)
+		fmt.Fprintf(&g.buf, "// %s.\n", withArticle(name))
+		fmt.Fprintf(&g.buf, "type %s struct {\n", pathsType)
+		if err := g.writeFields(fields, 1); err != nil {
+			return nil, err
+		}
+		g.buf.WriteString("}\n\n")
+
+		fmt.Fprintf(&g.buf, "// %sExpand contains the paths of the fields that can be expanded in\n", typeName)
+		fmt.Fprintf(&g.buf, "// %s.\n", withArticle(name))
+		fmt.Fprintf(&g.buf, "var %sExpand = %s{\n", typeName, pathsType)
+		g.writeFieldValues(fields, 1, "")
+		g.buf.WriteString("}\n\n")
+	}
+
+	for len(g.pending) > 0 {
+		key := g.pending[0]
+		g.pending = g.pending[1:]
+
+		pathsType := nestedPathsType(key.typeName, key.depth)
+		_, expandable := g.expandables[key.typeName]
+
+		if expandable {
+			fmt.Fprintf(&g.buf, "// %s is the path of an expandable %s at level %d of\n",
+				pathsType, readableName(key.typeName), key.depth)
+			g.buf.WriteString("// a path, along with the paths of its own expandable fields.\n")
+		} else {
+			fmt.Fprintf(&g.buf, "// %s are the paths of the expandable fields of %s\n",
+				pathsType, withArticle(readableName(key.typeName)))
+			fmt.Fprintf(&g.buf, "// at level %d of a path.\n", key.depth)
+		}
+		fmt.Fprintf(&g.buf, "type %s struct {\n", pathsType)
+		if expandable {
+			g.buf.WriteString("ExpandPath\n\n")
+		}
+		if err := g.writeFields(g.fields(key.typeName, key.depth+1), key.depth+1); err != nil {
+			return nil, err
+		}
+		g.buf.WriteString("}\n\n")
+
+		fmt.Fprintf(&g.buf, "func new%s(path ExpandPath) %s {\n", pathsType, pathsType)
+		fmt.Fprintf(&g.buf, "return %s{\n", pathsType)
+		if expandable {
+			g.buf.WriteString("ExpandPath: path,\n")
+		}
+		g.writeFieldValues(g.fields(key.typeName, key.depth+1), key.depth+1, "path + ")
+		g.buf.WriteString("}\n")
+		g.buf.WriteString("}\n\n")
+	}
+
+	return format.Source(g.buf.Bytes())
+}
+
+// fieldType returns the type of the generated path of a field at the given
+// depth, queuing its generation if it's a struct.
+func (g *generator) fieldType(f field, depth int) string {
+	if len(g.fields(f.typeName, depth+1)) == 0 {
+		return "ExpandPath"
+	}
+
+	key := nodeKey{depth: depth, typeName: f.typeName}
+	if !g.seen[key] {
+		g.seen[key] = true
+		g.pending = append(g.pending, key)
+	}
+	return nestedPathsType(f.typeName, depth)
+}
+
+func (g *generator) writeFields(fields []field, depth int) error {
+	for _, f := range fields {
+		if reservedFieldNames[f.name] {
+			return fmt.Errorf("field %s of type %s clashes with the methods of ExpandPath",
+				f.name, f.typeName)
+		}
+		fmt.Fprintf(&g.buf, "%s %s\n", f.name, g.fieldType(f, depth))
+	}
+	return nil
+}
+
+// writeFieldValues writes the values of the generated paths of fields,
+// which are relative to the path of their parent unless it's the root.
+func (g *generator) writeFieldValues(fields []field, depth int, parent string) {
+	for _, f := range fields {
+		path := strconv.Quote(f.jsonName)
+		if parent != "" {
+			path = parent + strconv.Quote("."+f.jsonName)
+		}
+
+		typ := g.fieldType(f, depth)
+		if typ == "ExpandPath" {
+			fmt.Fprintf(&g.buf, "%s: %s,\n", f.name, path)
+		} else {
+			fmt.Fprintf(&g.buf, "%s: new%s(%s),\n", f.name, typ, path)
+		}
+	}
+}
+
+// jsonFieldName returns the name in the JSON tag of a struct field.
+func jsonFieldName(tag *ast.BasicLit) string {
+	unquoted, err := strconv.Unquote(tag.Value)
+	if err != nil {
+		return ""
+	}
+	return strings.Split(reflect.StructTag(unquoted).Get("json"), ",")[0]
+}
+
+// nestedPathsType is the name of the type of the generated paths of a type
+// nested at the given depth.
+func nestedPathsType(typeName string, depth int) string {
+	return fmt.Sprintf("%sExpandPaths%d", typeName, depth)
+}
+
+// parseGetMethod returns the name of the type retrieved by a `Get` method of
+// a resource package client, and the path it's retrieved from, when it's
+// retrieved by ID alone.
+func parseGetMethod(decl *ast.FuncDecl) (string, string, bool) {
+	if decl.Name.Name != "Get" || decl.Recv == nil || decl.Body == nil {
+		return "", "", false
+	}
+
+	if ident, ok := decl.Recv.List[0].Type.(*ast.Ident); !ok || ident.Name != "Client" {
+		return "", "", false
+	}
+
+	params := decl.Type.Params.List
+	if len(params) != 2 || len(params[0].Names) != 1 || params[0].Names[0].Name != "id" {
+		return "", "", false
+	}
+
+	results := decl.Type.Results.List
+	star, ok := results[0].Type.(*ast.StarExpr)
+	if !ok {
+		return "", "", false
+	}
+	sel, ok := star.X.(*ast.SelectorExpr)
+	if !ok {
+		return "", "", false
+	}
+
+	var urlPath string
+	ast.Inspect(decl.Body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+
+		fun, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || fun.Sel.Name != "FormatURLPath" || len(call.Args) != 2 {
+			return true
+		}
+
+		lit, ok := call.Args[0].(*ast.BasicLit)
+		if !ok || lit.Kind != token.STRING {
+			return true
+		}
+
+		if ident, ok := call.Args[1].(*ast.Ident); !ok || ident.Name != "id" {
+			return true
+		}
+
+		urlPath, _ = strconv.Unquote(lit.Value)
+		return false
+	})
+
+	if urlPath == "" || strings.Count(urlPath, "%s") != 1 {
+		return "", "", false
+	}
+
+	return sel.Sel.Name, urlPath, true
+}
+
+// parseIDUnmarshaler returns the type and receiver name of an UnmarshalJSON
+// method that starts by checking for a bare ID with ParseID.
+func parseIDUnmarshaler(decl *ast.FuncDecl) (string, string, bool) {
+	if decl.Name.Name != "UnmarshalJSON" || decl.Recv == nil || decl.Body == nil ||
+		len(decl.Body.List) == 0 {
+		return "", "", false
+	}
+
+	recv := decl.Recv.List[0]
+	star, ok := recv.Type.(*ast.StarExpr)
+	if !ok || len(recv.Names) != 1 {
+		return "", "", false
+	}
+	ident, ok := star.X.(*ast.Ident)
+	if !ok {
+		return "", "", false
+	}
+
+	ifStmt, ok := decl.Body.List[0].(*ast.IfStmt)
+	if !ok {
+		return "", "", false
+	}
+	assign, ok := ifStmt.Init.(*ast.AssignStmt)
+	if !ok || len(assign.Rhs) != 1 {
+		return "", "", false
+	}
+	call, ok := assign.Rhs[0].(*ast.CallExpr)
+	if !ok {
+		return "", "", false
+	}
+	if fun, ok := call.Fun.(*ast.Ident); !ok || fun.Name != "ParseID" {
+		return "", "", false
+	}
+
+	return ident.Name, recv.Names[0].Name, true
+}
+
+// readableName turns a type name like PaymentIntent into "payment intent"
+// for use in comments.
+func readableName(typeName string) string {
+	var words []string
+	start := 0
+	for i := 1; i <= len(typeName); i++ {
+		if i < len(typeName) && !isUpper(typeName[i]) {
+			continue
+		}
+		// Keep runs of capitals like the ones in SKU or TaxID together
+		if i < len(typeName) && isUpper(typeName[i-1]) &&
+			(i+1 == len(typeName) || isUpper(typeName[i+1])) {
+			continue
+		}
+		words = append(words, typeName[start:i])
+		start = i
+	}
+
+	for i, word := range words {
+		if len(word) == 1 || !isUpper(word[1]) {
+			words[i] = strings.ToLower(word)
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// withArticle prefixes a readable name with an indefinite article.
+func withArticle(name string) string {
+	if strings.ContainsAny(name[:1], "aeiou") {
+		return "an " + name
+	}
+	return "a " + name
+}
+
+func isUpper(c byte) bool {
+	return c >= 'A' && c <= 'Z'
+}
+
+// structTypeName returns the name of the type of a struct field if it's a
+// named type or a pointer to one.
+func structTypeName(expr ast.Expr) string {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	if ident, ok := expr.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
@@ -0,0 +1,661 @@
+// A script that generates form encoders for all the parameter structs in the
+// stripe package so that they can be encoded without the use of reflection.
+// Each struct gets an `encodeForm` method, which is registered with
+// form.RegisterEncoder.
+//
+// The generated code must produce exactly the same values as the reflective
+// encoder in the form package, so the rules for every kind of field here
+// mirror the encoders found there. A test in the stripe package compares the
+// two for every parameter struct.
+//
+// Run it from the root of the repository:
+//
+//	go run scripts/generate_form_encoders/main.go
+//
+// Or with `-check` to fail if the generated file isn't up to date instead of
+// writing it.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+func main() {
+	check := flag.Bool("check", false,
+		"Fail if the generated file isn't up to date instead of writing it")
+	flag.Parse()
+
+	g, err := newGenerator(".")
+	if err != nil {
+		exitWithError(err)
+	}
+
+	source, err := g.generate()
+	if err != nil {
+		exitWithError(err)
+	}
+
+	if *check {
+		existing, err := ioutil.ReadFile(outputPath)
+		if err != nil {
+			exitWithError(err)
+		}
+
+		if !bytes.Equal(existing, source) {
+			exitWithError(fmt.Errorf("%s is out of date; run `go generate` to regenerate it",
+				outputPath))
+		}
+		return
+	}
+
+	if err := ioutil.WriteFile(outputPath, source, 0644); err != nil {
+		exitWithError(err)
+	}
+}
+
+//
+// Private
+//
+
+const (
+	// Name of the file containing the generated encoders.
+	outputPath = "params_encoders.go"
+
+	// Name of the generated methods.
+	methodName = "encodeForm"
+
+	// Name of the receiver variable in generated methods.
+	receiverName = "p"
+)
+
+// receiverKind indicates whether a type's AppendTo method has a value or a
+// pointer receiver, which determines whether the type itself, or only a
+// pointer to it, is a form.Appender.
+type receiverKind int
+
+const (
+	receiverNone receiverKind = iota
+	receiverPointer
+	receiverValue
+)
+
+// generator keeps track of the types found in the stripe package and the
+// code generated for them.
+type generator struct {
+	appenders map[string]receiverKind
+	buf       bytes.Buffer
+	pending   []string
+	seen      map[string]bool
+	types     map[string]*ast.TypeSpec
+
+	// vars is used to produce unique names for loop variables.
+	vars int
+}
+
+// keyExpr is an expression for the key parts of a value in generated code,
+// expressed as a base slice with some extra parts appended to it.
+type keyExpr struct {
+	base  string
+	parts []string
+}
+
+func (k keyExpr) String() string {
+	if len(k.parts) == 0 {
+		return k.base
+	}
+	return fmt.Sprintf("append(%s, %s)", k.base, strings.Join(k.parts, ", "))
+}
+
+func (k keyExpr) with(part string) keyExpr {
+	return keyExpr{
+		base:  k.base,
+		parts: append(append([]string(nil), k.parts...), part),
+	}
+}
+
+// tagOptions mirrors the options of a form tag.
+type tagOptions struct {
+	empty         bool
+	highPrecision bool
+}
+
+func exitWithError(err error) {
+	fmt.Fprintf(os.Stderr, "%v\n", err)
+	os.Exit(1)
+}
+
+func newGenerator(dir string) (*generator, error) {
+	g := &generator{
+		appenders: make(map[string]receiverKind),
+		seen:      make(map[string]bool),
+		types:     make(map[string]*ast.TypeSpec),
+	}
+
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(info os.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go") &&
+			info.Name() != filepath.Base(outputPath)
+	}, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	pkg, ok := pkgs["stripe"]
+	if !ok {
+		return nil, fmt.Errorf("no stripe package found in %s "+
+			"(maybe check the working directory?)", dir)
+	}
+
+	for _, f := range pkg.Files {
+		for _, decl := range f.Decls {
+			switch decl := decl.(type) {
+			case *ast.FuncDecl:
+				if decl.Name.Name != "AppendTo" || decl.Recv == nil {
+					continue
+				}
+
+				switch recv := decl.Recv.List[0].Type.(type) {
+				case *ast.Ident:
+					g.appenders[recv.Name] = receiverValue
+				case *ast.StarExpr:
+					g.appenders[recv.X.(*ast.Ident).Name] = receiverPointer
+				}
+
+			case *ast.GenDecl:
+				if decl.Tok != token.TYPE {
+					continue
+				}
+
+				for _, spec := range decl.Specs {
+					typeSpec := spec.(*ast.TypeSpec)
+					g.types[typeSpec.Name.Name] = typeSpec
+				}
+			}
+		}
+	}
+
+	return g, nil
+}
+
+// generate produces the formatted source of the generated file.
+func (g *generator) generate() ([]byte, error) {
+	var names []string
+	for name, typeSpec := range g.types {
+		if !strings.HasSuffix(name, "Params") {
+			continue
+		}
+
+		structType, ok := typeSpec.Type.(*ast.StructType)
+		if !ok || !allFieldsTagged(structType) {
+			continue
+		}
+
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		g.enqueue(name)
+	}
+
+	// Generating an encoder may enqueue other struct types that are used in
+	// fields, so keep going until there's nothing left.
+	encoders := make(map[string]string)
+	for len(g.pending) > 0 {
+		name := g.pending[0]
+		g.pending = g.pending[1:]
+
+		if err := g.generateEncoder(name); err != nil {
+			return nil, err
+		}
+		encoders[name] = g.buf.String()
+		g.buf.Reset()
+	}
+
+	names = names[:0]
+	for name := range encoders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var body string
+	for _, name := range names {
+		body += encoders[name]
+	}
+
+	var out bytes.Buffer
+	out.WriteString("// Code generated by scripts/generate_form_encoders. DO NOT EDIT.\n\n")
+	out.WriteString("package stripe\n\n")
+	out.WriteString("import (\n")
+	out.WriteString("\t\"reflect\"\n")
+	if strings.Contains(body, "strconv.") {
+		out.WriteString("\t\"strconv\"\n")
+	}
+	out.WriteString("\n\t\"github.com/stripe/stripe-go/form\"\n")
+	out.WriteString(")\n\n")
+
+	out.WriteString("func init() {\n")
+	out.WriteString("for t, f := range formEncoders {\n")
+	out.WriteString("form.RegisterEncoder(t, f)\n")
+	out.WriteString("}\n")
+	out.WriteString("}\n\n")
+
+	out.WriteString("// formEncoders are the generated encoders of each type, which are used by\n")
+	out.WriteString("// the form package in place of reflection.\n")
+	out.WriteString("var formEncoders = map[reflect.Type]form.EncoderFunc{\n")
+	for _, name := range names {
+		fmt.Fprintf(&out, "reflect.TypeOf((*%s)(nil)): func(body *form.Values, v interface{}, keyParts []string) {\n", name)
+		fmt.Fprintf(&out, "v.(*%s).%s(body, keyParts)\n", name, methodName)
+		out.WriteString("},\n")
+	}
+	out.WriteString("}\n")
+
+	out.WriteString(body)
+
+	source, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("generated code doesn't compile: %v\n%s", err, out.String())
+	}
+	return source, nil
+}
+
+func (g *generator) enqueue(name string) {
+	if g.seen[name] {
+		return
+	}
+	g.seen[name] = true
+	g.pending = append(g.pending, name)
+}
+
+// generateEncoder generates the encodeForm method of a single struct type.
+func (g *generator) generateEncoder(name string) error {
+	structType, ok := g.types[name].Type.(*ast.StructType)
+	if !ok {
+		return fmt.Errorf("%s is not a struct", name)
+	}
+
+	g.vars = 0
+
+	g.printf("\nfunc (%s *%s) %s(body *form.Values, keyParts []string) {\n", receiverName, name, methodName)
+	g.printf("if %s == nil {\nreturn\n}\n", receiverName)
+
+	for _, fld := range structType.Fields.List {
+		formName, options, err := parseTag(fld)
+		if err != nil {
+			return fmt.Errorf("%s: %v", name, err)
+		}
+
+		if formName == "-" {
+			continue
+		}
+
+		var fieldNames []string
+		for _, ident := range fld.Names {
+			fieldNames = append(fieldNames, ident.Name)
+		}
+		if len(fieldNames) == 0 {
+			fieldNames = []string{embeddedName(fld.Type)}
+		}
+
+		for _, fieldName := range fieldNames {
+			// See the comment on wildcards in the form package.
+			key := keyExpr{base: "keyParts"}
+			if formName != "*" {
+				key = key.with(strconv.Quote(formName))
+			}
+
+			x := receiverName + "." + fieldName
+			_, isPtr := fld.Type.(*ast.StarExpr)
+
+			if err := g.encodeValue(x, fld.Type, key, isPtr, options); err != nil {
+				return fmt.Errorf("%s.%s: %v", name, fieldName, err)
+			}
+
+			if g.isAppender(fld.Type) {
+				if isPtr {
+					g.printf("if %s != nil {\n%s.AppendTo(body, %s)\n}\n", x, x, key)
+				} else {
+					g.printf("%s.AppendTo(body, %s)\n", x, key)
+				}
+			}
+		}
+	}
+
+	g.printf("}\n")
+	return nil
+}
+
+// encodeValue generates code that encodes the value of expression x of the
+// given type. It's the equivalent of the form package's type encoders.
+func (g *generator) encodeValue(x string, typ ast.Expr, key keyExpr, encodeZero bool, options *tagOptions) error {
+	switch typ := typ.(type) {
+	case *ast.ArrayType:
+		return g.encodeArrayOrSlice(x, typ, key)
+
+	case *ast.Ident:
+		if kind, ok := scalarKinds[typ.Name]; ok {
+			g.encodeScalar(x, typ.Name, kind, key, encodeZero, options)
+			return nil
+		}
+
+		typeSpec, ok := g.types[typ.Name]
+		if !ok {
+			return fmt.Errorf("unknown type %s", typ.Name)
+		}
+
+		switch underlying := typeSpec.Type.(type) {
+		case *ast.Ident:
+			kind, ok := scalarKinds[underlying.Name]
+			if !ok {
+				return fmt.Errorf("unsupported type %s", typ.Name)
+			}
+			g.encodeScalar(x, typ.Name, kind, key, encodeZero, options)
+			return nil
+
+		case *ast.StructType:
+			if !allFieldsTagged(underlying) {
+				return fmt.Errorf("%s has fields without a form tag", typ.Name)
+			}
+			g.enqueue(typ.Name)
+			g.printf("%s.%s(body, %s)\n", x, methodName, key)
+			return nil
+		}
+
+		return g.encodeValue(x, typeSpec.Type, key, encodeZero, options)
+
+	case *ast.MapType:
+		return g.encodeMap(x, typ, key)
+
+	case *ast.StarExpr:
+		return g.encodePtr(x, typ, key, options)
+	}
+
+	return fmt.Errorf("unsupported type %T", typ)
+}
+
+func (g *generator) encodeArrayOrSlice(x string, typ *ast.ArrayType, key keyExpr) error {
+	isSlice := typ.Len == nil
+
+	// See the comment on zeroing arrays in the form package.
+	if isSlice {
+		g.printf("if %s != nil && len(%s) == 0 {\n", x, x)
+		g.printf("body.Add(form.FormatKey(%s), \"\")\n", key)
+		g.printf("} else {\n")
+	}
+
+	n := g.nextVar()
+	i, elem := "i"+n, "v"+n
+	elemKey := key.with("strconv.Itoa(" + i + ")")
+	_, isPtr := typ.Elt.(*ast.StarExpr)
+
+	g.printf("for %s, %s := range %s {\n", i, elem, x)
+	if err := g.encodeValue(elem, typ.Elt, elemKey, isPtr, nil); err != nil {
+		return err
+	}
+	if isPtr && g.isAppender(typ.Elt) {
+		g.printf("if %s != nil {\n%s.AppendTo(body, %s)\n}\n", elem, elem, elemKey)
+	}
+	g.printf("}\n")
+
+	if isSlice {
+		g.printf("}\n")
+	}
+	return nil
+}
+
+func (g *generator) encodeMap(x string, typ *ast.MapType, key keyExpr) error {
+	keyType, ok := typ.Key.(*ast.Ident)
+	if !ok || g.kindOf(keyType.Name) != reflect.String {
+		return fmt.Errorf("unsupported map key type %v", typ.Key)
+	}
+
+	n := g.nextVar()
+	k, elem := "k"+n, "v"+n
+	elemKey := key.with(k)
+	if keyType.Name != "string" {
+		elemKey = key.with("string(" + k + ")")
+	}
+
+	// Any value found in a map has been explicitly set, so it's always
+	// encoded, even if it's a zero value.
+	g.printf("for %s, %s := range %s {\n", k, elem, x)
+	if err := g.encodeValue(elem, typ.Value, elemKey, true, nil); err != nil {
+		return err
+	}
+	if g.isAppender(typ.Value) {
+		g.printf("%s.AppendTo(body, %s)\n", elem, elemKey)
+	}
+	g.printf("}\n")
+	return nil
+}
+
+func (g *generator) encodePtr(x string, typ *ast.StarExpr, key keyExpr, options *tagOptions) error {
+	// Generated encoders are safe to call on nil, so there's no need to check
+	// pointers to structs.
+	if ident, ok := typ.X.(*ast.Ident); ok && g.isStruct(ident.Name) {
+		return g.encodeValue(x, typ.X, key, true, options)
+	}
+
+	g.printf("if %s != nil {\n", x)
+
+	// See the comment on zeroing arrays stored as a pointer to a slice in the
+	// form package.
+	if array, ok := typ.X.(*ast.ArrayType); ok && array.Len == nil {
+		g.printf("if len(*%s) == 0 {\n", x)
+		g.printf("body.Add(form.FormatKey(%s), \"\")\n", key)
+		g.printf("} else {\n")
+		if err := g.encodeValue("*"+x, typ.X, key, true, options); err != nil {
+			return err
+		}
+		g.printf("}\n")
+	} else {
+		if err := g.encodeValue("*"+x, typ.X, key, true, options); err != nil {
+			return err
+		}
+	}
+
+	g.printf("}\n")
+	return nil
+}
+
+func (g *generator) encodeScalar(x, typeName string, kind reflect.Kind, key keyExpr, encodeZero bool, options *tagOptions) {
+	var zero, val string
+
+	switch kind {
+	case reflect.Bool:
+		// Booleans are only ever encoded as an empty string when they have
+		// any options, and otherwise not at all.
+		if options != nil && !options.empty {
+			return
+		}
+
+		zero = "!" + x
+		val = "strconv.FormatBool(" + convert(x, typeName, "bool") + ")"
+		if options != nil {
+			val = `""`
+		}
+
+	case reflect.Float32, reflect.Float64:
+		bits := 64
+		if kind == reflect.Float32 {
+			bits = 32
+		}
+
+		prec := 4
+		if options != nil && options.highPrecision {
+			prec = -1
+		}
+
+		zero = x + " == 0"
+		val = fmt.Sprintf("strconv.FormatFloat(%s, 'f', %d, %d)",
+			convert(x, typeName, "float64"), prec, bits)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		zero = x + " == 0"
+		val = "strconv.FormatInt(" + convert(x, typeName, "int64") + ", 10)"
+
+	case reflect.String:
+		zero = x + ` == ""`
+		val = convert(x, typeName, "string")
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		zero = x + " == 0"
+		val = "strconv.FormatUint(" + convert(x, typeName, "uint64") + ", 10)"
+	}
+
+	if encodeZero {
+		g.printf("body.Add(form.FormatKey(%s), %s)\n", key, val)
+		return
+	}
+
+	g.printf("if !(%s) {\n", zero)
+	g.printf("body.Add(form.FormatKey(%s), %s)\n", key, val)
+	g.printf("}\n")
+}
+
+// isAppender returns true if a value of the given type is a form.Appender.
+func (g *generator) isAppender(typ ast.Expr) bool {
+	switch typ := typ.(type) {
+	case *ast.Ident:
+		return g.appenders[typ.Name] == receiverValue
+
+	case *ast.StarExpr:
+		ident, ok := typ.X.(*ast.Ident)
+		return ok && g.appenders[ident.Name] != receiverNone
+	}
+	return false
+}
+
+// isStruct returns true if name is a struct type in the stripe package.
+func (g *generator) isStruct(name string) bool {
+	typeSpec, ok := g.types[name]
+	if !ok {
+		return false
+	}
+	_, ok = typeSpec.Type.(*ast.StructType)
+	return ok
+}
+
+// kindOf returns the kind of a named scalar type, or reflect.Invalid if it
+// isn't one.
+func (g *generator) kindOf(name string) reflect.Kind {
+	if kind, ok := scalarKinds[name]; ok {
+		return kind
+	}
+
+	if typeSpec, ok := g.types[name]; ok {
+		if underlying, ok := typeSpec.Type.(*ast.Ident); ok {
+			return scalarKinds[underlying.Name]
+		}
+	}
+
+	return reflect.Invalid
+}
+
+// nextVar returns a suffix for the names of the variables of a new loop.
+func (g *generator) nextVar() string {
+	g.vars++
+	return strconv.Itoa(g.vars)
+}
+
+func (g *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+// allFieldsTagged returns true if every field of the struct has a form tag,
+// which is required for the generated encoder to know what to do with it.
+func allFieldsTagged(structType *ast.StructType) bool {
+	for _, fld := range structType.Fields.List {
+		if formTag(fld) == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// convert wraps x in a conversion to the given type unless it's already of
+// that type.
+func convert(x, typeName, to string) string {
+	if typeName == to {
+		return x
+	}
+	return to + "(" + x + ")"
+}
+
+func embeddedName(typ ast.Expr) string {
+	switch typ := typ.(type) {
+	case *ast.Ident:
+		return typ.Name
+	case *ast.SelectorExpr:
+		return typ.Sel.Name
+	case *ast.StarExpr:
+		return embeddedName(typ.X)
+	}
+	panic(fmt.Sprintf("unexpected embedded field type %T", typ))
+}
+
+func formTag(fld *ast.Field) string {
+	if fld.Tag == nil {
+		return ""
+	}
+
+	tag, err := strconv.Unquote(fld.Tag.Value)
+	if err != nil {
+		return ""
+	}
+
+	return reflect.StructTag(tag).Get("form")
+}
+
+// parseTag mirrors the form package's parsing of a field's form tag.
+func parseTag(fld *ast.Field) (string, *tagOptions, error) {
+	parts := strings.Split(formTag(fld), ",")
+
+	var options *tagOptions
+	for _, part := range parts[1:] {
+		if options == nil {
+			options = &tagOptions{}
+		}
+
+		switch part {
+		case "empty":
+			options.empty = true
+		case "high_precision":
+			options.highPrecision = true
+		default:
+			return "", nil, fmt.Errorf("unknown form tag option %q", part)
+		}
+	}
+
+	return parts[0], options, nil
+}
+
+var scalarKinds = map[string]reflect.Kind{
+	"bool":    reflect.Bool,
+	"float32": reflect.Float32,
+	"float64": reflect.Float64,
+	"int":     reflect.Int,
+	"int8":    reflect.Int8,
+	"int16":   reflect.Int16,
+	"int32":   reflect.Int32,
+	"int64":   reflect.Int64,
+	"string":  reflect.String,
+	"uint":    reflect.Uint,
+	"uint8":   reflect.Uint8,
+	"uint16":  reflect.Uint16,
+	"uint32":  reflect.Uint32,
+	"uint64":  reflect.Uint64,
+}
@@ -0,0 +1,291 @@
+// A script that generates accessors returning the Unix timestamps of
+// resources as time.Time, like Charge.CreatedTime for Charge.Created.
+//
+// A field is a timestamp when it's an int64 whose JSON name is one that the
+// API uses for timestamps, like `created` or any name ending in `_at`. Zero
+// timestamps, which the API uses for timestamps that aren't set, are
+// returned as the zero time.
+//
+// Run it from the root of the repository:
+//
+//	go run scripts/generate_timestamps/main.go
+//
+// Or with `-check` to fail if the generated file isn't up to date instead of
+// writing it.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+func main() {
+	check := flag.Bool("check", false,
+		"Fail if the generated file isn't up to date instead of writing it")
+	flag.Parse()
+
+	g, err := newGenerator(".")
+	if err != nil {
+		exitWithError(err)
+	}
+
+	source, err := g.generate()
+	if err != nil {
+		exitWithError(err)
+	}
+
+	if *check {
+		existing, err := ioutil.ReadFile(outputPath)
+		if err != nil {
+			exitWithError(err)
+		}
+
+		if !bytes.Equal(existing, source) {
+			exitWithError(fmt.Errorf("%s is out of date; run `go generate` to regenerate it",
+				outputPath))
+		}
+		return
+	}
+
+	if err := ioutil.WriteFile(outputPath, source, 0644); err != nil {
+		exitWithError(err)
+	}
+}
+
+//
+// Private
+//
+
+// Name of the file containing the generated code.
+const outputPath = "timestamps.go"
+
+// timestampNames are the JSON names of timestamps that don't end in one of
+// timestampSuffixes.
+var timestampNames = map[string]bool{
+	"available_on":                      true,
+	"billing_cycle_anchor":              true,
+	"canceled":                          true,
+	"created":                           true,
+	"current_deadline":                  true,
+	"data_load_time":                    true,
+	"date":                              true,
+	"due_by":                            true,
+	"end":                               true,
+	"eta":                               true,
+	"expires":                           true,
+	"fulfilled":                         true,
+	"next_payment_attempt":              true,
+	"next_pending_invoice_item_invoice": true,
+	"paid":                              true,
+	"redeem_by":                         true,
+	"returned":                          true,
+	"start":                             true,
+	"timestamp":                         true,
+	"updated":                           true,
+}
+
+// timestampSuffixes are the suffixes of the JSON names of timestamps.
+var timestampSuffixes = []string{"_at", "_date", "_end", "_start", "_until"}
+
+// generator keeps track of the types found in the stripe package.
+type generator struct {
+	buf bytes.Buffer
+
+	// members are the names of the fields and methods of each type, which
+	// generated accessors mustn't clash with.
+	members map[string]map[string]bool
+
+	// receivers maps types to the receiver name of their existing methods.
+	receivers map[string]string
+
+	structs map[string]*ast.StructType
+}
+
+func exitWithError(err error) {
+	fmt.Fprintf(os.Stderr, "%v\n", err)
+	os.Exit(1)
+}
+
+func newGenerator(dir string) (*generator, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(info os.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go") &&
+			info.Name() != filepath.Base(outputPath)
+	}, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	pkg, ok := pkgs["stripe"]
+	if !ok {
+		return nil, fmt.Errorf("no stripe package found in %s "+
+			"(maybe check the working directory?)", dir)
+	}
+
+	g := &generator{
+		members:   make(map[string]map[string]bool),
+		receivers: make(map[string]string),
+		structs:   make(map[string]*ast.StructType),
+	}
+
+	// Files are visited in order so that the receiver names picked are
+	// stable
+	var fileNames []string
+	for fileName := range pkg.Files {
+		fileNames = append(fileNames, fileName)
+	}
+	sort.Strings(fileNames)
+
+	for _, fileName := range fileNames {
+		for _, decl := range pkg.Files[fileName].Decls {
+			switch decl := decl.(type) {
+			case *ast.FuncDecl:
+				typeName, recv := receiver(decl)
+				if typeName == "" {
+					continue
+				}
+				g.addMember(typeName, decl.Name.Name)
+				if _, ok := g.receivers[typeName]; !ok && recv != "" && recv != "_" {
+					g.receivers[typeName] = recv
+				}
+
+			case *ast.GenDecl:
+				for _, spec := range decl.Specs {
+					typeSpec, ok := spec.(*ast.TypeSpec)
+					if !ok {
+						continue
+					}
+					structType, ok := typeSpec.Type.(*ast.StructType)
+					if !ok {
+						continue
+					}
+
+					g.structs[typeSpec.Name.Name] = structType
+					for _, field := range structType.Fields.List {
+						for _, name := range field.Names {
+							g.addMember(typeSpec.Name.Name, name.Name)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return g, nil
+}
+
+func (g *generator) addMember(typeName, name string) {
+	if g.members[typeName] == nil {
+		g.members[typeName] = make(map[string]bool)
+	}
+	g.members[typeName][name] = true
+}
+
+func (g *generator) generate() ([]byte, error) {
+	var typeNames []string
+	for typeName := range g.structs {
+		// Parameters are skipped since they're sent rather than received
+		if !ast.IsExported(typeName) || strings.HasSuffix(typeName, "Params") {
+			continue
+		}
+		typeNames = append(typeNames, typeName)
+	}
+	sort.Strings(typeNames)
+
+	g.buf.WriteString("// Code generated by scripts/generate_timestamps. DO NOT EDIT.\n\n")
+	g.buf.WriteString("package stripe\n\n")
+	g.buf.WriteString("import \"time\"\n\n")
+
+	for _, typeName := range typeNames {
+		for _, f := range g.structs[typeName].Fields.List {
+			if len(f.Names) != 1 || !f.Names[0].IsExported() || f.Tag == nil {
+				continue
+			}
+			if ident, ok := f.Type.(*ast.Ident); !ok || ident.Name != "int64" {
+				continue
+			}
+			if !isTimestamp(jsonFieldName(f.Tag)) {
+				continue
+			}
+
+			name := f.Names[0].Name
+			method := name + "Time"
+			if g.members[typeName][method] {
+				return nil, fmt.Errorf("accessor %s of type %s clashes with an existing field or method",
+					method, typeName)
+			}
+
+			recv, ok := g.receivers[typeName]
+			if !ok {
+				recv = string(unicode.ToLower(rune(typeName[0])))
+			}
+
+			fmt.Fprintf(&g.buf, "// %s returns %s as a time.Time, or the zero time if it isn't\n", method, name)
+			g.buf.WriteString("// set.\n")
+			fmt.Fprintf(&g.buf, "func (%s *%s) %s() time.Time {\n", recv, typeName, method)
+			fmt.Fprintf(&g.buf, "return unixTime(%s.%s)\n", recv, name)
+			g.buf.WriteString("}\n\n")
+		}
+	}
+
+	return format.Source(g.buf.Bytes())
+}
+
+// isTimestamp returns whether a field with the given JSON name holds a
+// timestamp if it's an integer.
+func isTimestamp(jsonName string) bool {
+	if timestampNames[jsonName] {
+		return true
+	}
+	for _, suffix := range timestampSuffixes {
+		if strings.HasSuffix(jsonName, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonFieldName returns the name in the JSON tag of a struct field.
+func jsonFieldName(tag *ast.BasicLit) string {
+	unquoted, err := strconv.Unquote(tag.Value)
+	if err != nil {
+		return ""
+	}
+	return strings.Split(reflect.StructTag(unquoted).Get("json"), ",")[0]
+}
+
+// receiver returns the name of the type of a method's receiver and the name
+// of the receiver, or an empty type name if the function isn't a method.
+func receiver(decl *ast.FuncDecl) (string, string) {
+	if decl.Recv == nil || len(decl.Recv.List) != 1 {
+		return "", ""
+	}
+
+	recv := decl.Recv.List[0]
+	expr := recv.Type
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	ident, ok := expr.(*ast.Ident)
+	if !ok {
+		return "", ""
+	}
+
+	if len(recv.Names) != 1 {
+		return ident.Name, ""
+	}
+	return ident.Name, recv.Names[0].Name
+}
@@ -190,6 +190,9 @@ type SetupIntent struct {
 	SingleUseMandate     *Mandate                         `json:"single_use_mandate"`
 	Status               SetupIntentStatus                `json:"status"`
 	Usage                SetupIntentUsage                 `json:"usage"`
+
+	Extra    map[string]json.RawMessage `json:"-"`
+	expanded bool
 }
 
 // SetupIntentList is a list of setup intents as retrieved from a list endpoint.
@@ -214,5 +217,6 @@ func (p *SetupIntent) UnmarshalJSON(data []byte) error {
 	}
 
 	*p = SetupIntent(v)
+	p.expanded = true
 	return nil
 }
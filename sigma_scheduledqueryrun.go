@@ -36,6 +36,9 @@ type SigmaScheduledQueryRun struct {
 	SQL                  string                       `json:"sql"`
 	Status               SigmaScheduledQueryRunStatus `json:"status"`
 	Query                string                       `json:"query"`
+
+	Extra    map[string]json.RawMessage `json:"-"`
+	expanded bool
 }
 
 // SigmaScheduledQueryRunList is a list of scheduled query runs as retrieved from a list endpoint.
@@ -60,5 +63,6 @@ func (i *SigmaScheduledQueryRun) UnmarshalJSON(data []byte) error {
 	}
 
 	*i = SigmaScheduledQueryRun(v)
+	i.expanded = true
 	return nil
 }
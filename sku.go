@@ -68,6 +68,9 @@ type SKU struct {
 	Price             int64              `json:"price"`
 	Product           *Product           `json:"product"`
 	Updated           int64              `json:"updated"`
+
+	Extra    map[string]json.RawMessage `json:"-"`
+	expanded bool
 }
 
 // SKUList is a list of SKUs as returned from a list endpoint.
@@ -102,5 +105,6 @@ func (s *SKU) UnmarshalJSON(data []byte) error {
 	}
 
 	*s = SKU(v)
+	s.expanded = true
 	return nil
 }
@@ -312,6 +312,8 @@ type Source struct {
 	Type                string                `json:"type"`
 	TypeData            map[string]interface{}
 	Usage               SourceUsage `json:"usage"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // AppendTo implements custom encoding logic for SourceObjectParams so that the special
@@ -326,6 +328,16 @@ func (p *SourceObjectParams) AppendTo(body *form.Values, keyParts []string) {
 	}
 }
 
+// DecodeFrom implements custom decoding logic for SourceObjectParams that
+// undoes its AppendTo.
+func (p *SourceObjectParams) DecodeFrom(body *form.Values, keyParts []string) error {
+	typ := body.Get(form.FormatKey(append(keyParts, "type")))
+	if len(typ) == 0 {
+		return nil
+	}
+	return form.DecodePrefixed(body, &p.TypeData, append(keyParts, typ[0]))
+}
+
 // UnmarshalJSON handles deserialization of an Source. This custom unmarshaling
 // is needed to extract the type specific data (accessible under `TypeData`)
 // but stored in JSON under a hash named after the `type` of the source.
@@ -25,6 +25,8 @@ type SourceTransaction struct {
 	Source       string   `json:"source"`
 	Type         string   `json:"type"`
 	TypeData     map[string]interface{}
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // UnmarshalJSON handles deserialization of a SourceTransaction. This custom
@@ -9,7 +9,6 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
-	"math/rand"
 	"net/http"
 	"net/url"
 	"os/exec"
@@ -115,11 +114,33 @@ type BackendConfig struct {
 	// Defaults to false.
 	EnableTelemetry bool
 
+	// Clock is the clock that the backend uses to sleep between retries and
+	// to generate idempotency keys.
+	//
+	// If left unset, DefaultClock is used.
+	Clock Clock
+
 	// HTTPClient is an HTTP client instance to use when making API requests.
 	//
 	// If left unset, it'll be set to a default HTTP client for the package.
 	HTTPClient *http.Client
 
+	// IdempotencyKeyGenerator generates the idempotency keys of requests that
+	// don't set one explicitly. A generator carried on a request's context
+	// with WithIdempotencyKeyGenerator takes precedence.
+	//
+	// If left unset, keys are generated from the backend's Clock and Rand.
+	IdempotencyKeyGenerator IdempotencyKeyGenerator
+
+	// KeepUnknownFields makes the backend keep the fields of responses that
+	// the library doesn't know about in the Extra field of resources. It takes
+	// a second pass over every response, so it's only worth enabling when
+	// those fields are used. See Unmarshal.
+	//
+	// Defaults to false, in which case responses are decoded once and Extra
+	// fields are left nil.
+	KeepUnknownFields bool
+
 	// LeveledLogger is the logger that the backend will use to log errors,
 	// warnings, and informational messages.
 	//
@@ -143,6 +164,20 @@ type BackendConfig struct {
 	// Deprecated: Logging should be configured with LeveledLogger instead.
 	LogLevel int
 
+	// LivemodeGuard makes the backend refuse requests made with a key whose
+	// mode doesn't match the environment's, as well as responses containing
+	// objects from the wrong mode. See LivemodeGuard for details.
+	//
+	// If left unset, it'll be set to DefaultLivemodeGuard when the backend is
+	// created by GetBackend.
+	LivemodeGuard *LivemodeGuard
+
+	// LogRedactor configures which fields are masked in the response bodies
+	// logged at the debug level.
+	//
+	// If left unset, the fields in DefaultRedactedFields are masked.
+	LogRedactor *LogRedactor
+
 	// Logger is where this backend will write its logs.
 	//
 	// If left unset, it'll be set to Logger.
@@ -157,6 +192,33 @@ type BackendConfig struct {
 	// Defaults to 0.
 	MaxNetworkRetries int
 
+	// Rand is the source of randomness that the backend uses to generate
+	// idempotency keys and to add jitter to the time slept between retries.
+	// Together with Clock and IdempotencyKeyGenerator, it makes the requests
+	// of the backend reproducible. See NewDeterministicRand.
+	//
+	// If left unset, DefaultRand is used.
+	Rand Rand
+
+	// StrictDecoding makes the backend return a *StrictDecodingError when a
+	// response contains fields that the library doesn't know about or whose
+	// type doesn't match the library's. It's meant for contract tests which
+	// should notice drift between the API and the library.
+	//
+	// Unknown fields are also kept in the Extra field of resources, like
+	// with KeepUnknownFields.
+	//
+	// Defaults to false.
+	StrictDecoding bool
+
+	// StructuredLogger is a logger which receives the backend's records about
+	// requests with attributes like their method, path, request ID, status,
+	// duration, number of retries and connected account. When it's set, it's
+	// used instead of LeveledLogger to log requests.
+	//
+	// On Go 1.21 and later, NewSlogLogger adapts a *slog.Logger for use here.
+	StructuredLogger StructuredLogger
+
 	// URL is the base URL to use for API paths.
 	//
 	// If left empty, it'll be set to the default for the SupportedBackend.
@@ -169,11 +231,19 @@ type BackendConfig struct {
 // The public use of this struct is deprecated. It will be unexported in a
 // future version.
 type BackendImplementation struct {
-	Type              SupportedBackend
-	URL               string
-	HTTPClient        *http.Client
-	LeveledLogger     LeveledLoggerInterface
-	MaxNetworkRetries int
+	Type                    SupportedBackend
+	URL                     string
+	Clock                   Clock
+	HTTPClient              *http.Client
+	IdempotencyKeyGenerator IdempotencyKeyGenerator
+	KeepUnknownFields       bool
+	LeveledLogger           LeveledLoggerInterface
+	LivemodeGuard           *LivemodeGuard
+	LogRedactor             *LogRedactor
+	MaxNetworkRetries       int
+	Rand                    Rand
+	StrictDecoding          bool
+	StructuredLogger        StructuredLogger
 
 	enableTelemetry bool
 
@@ -261,23 +331,59 @@ func (s *BackendImplementation) NewRequest(method, path, key, contentType string
 		path = "/" + path
 	}
 
+	if err := s.LivemodeGuard.CheckKey(method, path, key); err != nil {
+		return nil, err
+	}
+
 	path = s.URL + path
 
+	// Options carried on the context, which explicit values on params take
+	// precedence over. See request_options.go.
+	var opts *requestOptions
+	if params != nil {
+		opts = getRequestOptions(params.Context)
+	}
+	if opts == nil {
+		opts = &requestOptions{}
+	}
+
+	logger := s.LeveledLogger
+	if opts.leveledLogger != nil {
+		logger = opts.leveledLogger
+	}
+
 	// Body is set later by `Do`.
 	req, err := http.NewRequest(method, path, nil)
 	if err != nil {
-		s.LeveledLogger.Errorf("Cannot create Stripe request: %v", err)
+		logger.Errorf("Cannot create Stripe request: %v", err)
 		return nil, err
 	}
 
 	authorization := "Bearer " + key
 
+	stripeVersion := APIVersion
+	if opts.stripeVersion != "" {
+		stripeVersion = opts.stripeVersion
+	}
+
 	req.Header.Add("Authorization", authorization)
 	req.Header.Add("Content-Type", contentType)
-	req.Header.Add("Stripe-Version", APIVersion)
+	req.Header.Add("Stripe-Version", stripeVersion)
 	req.Header.Add("User-Agent", encodedUserAgent)
 	req.Header.Add("X-Stripe-Client-User-Agent", encodedStripeUserAgent)
 
+	// Context headers replace defaults like User-Agent, but can't replace the
+	// headers set from keys and explicit params below.
+	for k, v := range opts.headers {
+		if reservedHeaders[k] {
+			continue
+		}
+		req.Header.Del(k)
+		for _, line := range v {
+			req.Header.Add(k, line)
+		}
+	}
+
 	if params != nil {
 		if params.Context != nil {
 			req = req.WithContext(params.Context)
@@ -291,11 +397,18 @@ func (s *BackendImplementation) NewRequest(method, path, key, contentType string
 
 			req.Header.Add("Idempotency-Key", idempotencyKey)
 		} else if isHTTPWriteMethod(method) {
-			req.Header.Add("Idempotency-Key", NewIdempotencyKey())
+			idempotencyKey := opts.idempotencyKeyPrefix + s.newIdempotencyKey(opts)
+			if len(idempotencyKey) > 255 {
+				return nil, errors.New("cannot use an idempotency key longer than 255 characters")
+			}
+
+			req.Header.Add("Idempotency-Key", idempotencyKey)
 		}
 
 		if params.StripeAccount != nil {
 			req.Header.Add("Stripe-Account", strings.TrimSpace(*params.StripeAccount))
+		} else if opts.stripeAccount != "" {
+			req.Header.Add("Stripe-Account", strings.TrimSpace(opts.stripeAccount))
 		}
 
 		for k, v := range params.Headers {
@@ -313,7 +426,10 @@ func (s *BackendImplementation) NewRequest(method, path, key, contentType string
 // the backend's HTTP client to execute the request and unmarshals the response
 // into v. It also handles unmarshaling errors returned by the API.
 func (s *BackendImplementation) Do(req *http.Request, body *bytes.Buffer, v interface{}) error {
-	s.LeveledLogger.Infof("Requesting %v %v%v\n", req.Method, req.URL.Host, req.URL.Path)
+	logger := s.newRequestLog(req)
+
+	logger.log(LevelInfo, "Requesting", nil,
+		"Requesting %v %v%v\n", req.Method, req.URL.Host, req.URL.Path)
 
 	if s.enableTelemetry {
 		select {
@@ -322,7 +438,8 @@ func (s *BackendImplementation) Do(req *http.Request, body *bytes.Buffer, v inte
 			if err == nil {
 				req.Header.Set("X-Stripe-Client-Telemetry", string(metricsJSON))
 			} else {
-				s.LeveledLogger.Warnf("Unable to encode client telemetry: %v", err)
+				logger.log(LevelWarn, "Unable to encode client telemetry", []LogAttr{{LogAttrError, err}},
+					"Unable to encode client telemetry: %v", err)
 			}
 		default:
 			// There are no metrics available, so don't send any.
@@ -382,7 +499,15 @@ func (s *BackendImplementation) Do(req *http.Request, body *bytes.Buffer, v inte
 		res, err = s.HTTPClient.Do(req)
 
 		requestDuration = time.Since(start)
-		s.LeveledLogger.Infof("Request completed in %v (retry: %v)", requestDuration, retry)
+
+		attrs := []LogAttr{{LogAttrDuration, requestDuration}, {LogAttrRetries, retry}}
+		if res != nil {
+			attrs = append(attrs,
+				LogAttr{LogAttrRequestID, res.Header.Get("Request-Id")},
+				LogAttr{LogAttrStatus, res.StatusCode})
+		}
+		logger.log(LevelInfo, "Request completed", attrs,
+			"Request completed in %v (retry: %v)", requestDuration, retry)
 
 		if err == nil {
 			resBody, err = ioutil.ReadAll(res.Body)
@@ -390,7 +515,8 @@ func (s *BackendImplementation) Do(req *http.Request, body *bytes.Buffer, v inte
 		}
 
 		if err != nil {
-			s.LeveledLogger.Errorf("Request failed with error: %v", err)
+			logger.log(LevelError, "Request failed", append(attrs, LogAttr{LogAttrError, err}),
+				"Request failed with error: %v", err)
 		} else if res.StatusCode >= 400 {
 			err = s.ResponseToError(res, resBody)
 
@@ -409,14 +535,20 @@ func (s *BackendImplementation) Do(req *http.Request, body *bytes.Buffer, v inte
 				// Stripe API doesn't comply to the letter of the specification
 				// and uses it in a broader sense.
 				if res.StatusCode == 402 {
-					s.LeveledLogger.Infof("User-compelled request error from Stripe (status %v): %v",
+					logger.log(LevelInfo, "User-compelled request error from Stripe",
+						append(attrs, LogAttr{LogAttrError, stripeErr}),
+						"User-compelled request error from Stripe (status %v): %v",
 						res.StatusCode, stripeErr)
 				} else {
-					s.LeveledLogger.Errorf("Request error from Stripe (status %v): %v",
+					logger.log(LevelError, "Request error from Stripe",
+						append(attrs, LogAttr{LogAttrError, stripeErr}),
+						"Request error from Stripe (status %v): %v",
 						res.StatusCode, stripeErr)
 				}
 			} else {
-				s.LeveledLogger.Errorf("Error decoding error from Stripe: %v", err)
+				logger.log(LevelError, "Error decoding error from Stripe",
+					append(attrs, LogAttr{LogAttrError, err}),
+					"Error decoding error from Stripe: %v", err)
 			}
 		}
 
@@ -429,10 +561,12 @@ func (s *BackendImplementation) Do(req *http.Request, body *bytes.Buffer, v inte
 		sleepDuration := s.sleepTime(retry)
 		retry++
 
-		s.LeveledLogger.Warnf("Initiating retry %v for request %v %v%v after sleeping %v",
+		logger.log(LevelWarn, "Initiating retry",
+			[]LogAttr{{LogAttrRetries, retry}, {LogAttrSleep, sleepDuration}},
+			"Initiating retry %v for request %v %v%v after sleeping %v",
 			retry, req.Method, req.URL.Host, req.URL.Path, sleepDuration)
 
-		time.Sleep(sleepDuration)
+		s.clock().Sleep(sleepDuration)
 	}
 
 	if s.enableTelemetry && res != nil {
@@ -456,10 +590,17 @@ func (s *BackendImplementation) Do(req *http.Request, body *bytes.Buffer, v inte
 		return err
 	}
 
-	s.LeveledLogger.Debugf("Response: %s\n", string(resBody))
+	logger.logBody([]LogAttr{
+		{LogAttrRequestID, res.Header.Get("Request-Id")},
+		{LogAttrStatus, res.StatusCode},
+	}, resBody)
 
 	if v != nil {
-		return s.UnmarshalJSONVerbose(res.StatusCode, resBody, v)
+		if err := s.unmarshalResponse(res.StatusCode, resBody, v); err != nil {
+			return err
+		}
+
+		return s.LivemodeGuard.CheckResponse(v)
 	}
 
 	return nil
@@ -621,6 +762,61 @@ func (s *BackendImplementation) shouldRetry(err error, req *http.Request, resp *
 	return false
 }
 
+// newRequestLog returns the log of a request, with the attributes common to
+// all its records.
+func (s *BackendImplementation) newRequestLog(req *http.Request) *requestLog {
+	attrs := []LogAttr{{LogAttrMethod, req.Method}, {LogAttrPath, req.URL.Path}}
+	if account := req.Header.Get("Stripe-Account"); account != "" {
+		attrs = append(attrs, LogAttr{LogAttrAccount, account})
+	}
+
+	return &requestLog{
+		attrs:      attrs,
+		ctx:        req.Context(),
+		leveled:    s.requestLogger(req),
+		redactor:   s.LogRedactor,
+		structured: s.StructuredLogger,
+	}
+}
+
+// requestLogger returns the logger to use for a request, which is one carried
+// on the request's context if there is one, and the backend's otherwise.
+func (s *BackendImplementation) requestLogger(req *http.Request) LeveledLoggerInterface {
+	if opts := getRequestOptions(req.Context()); opts != nil && opts.leveledLogger != nil {
+		return opts.leveledLogger
+	}
+	return s.LeveledLogger
+}
+
+// clock returns the clock of the backend, or the default one if it has none.
+func (s *BackendImplementation) clock() Clock {
+	if s.Clock != nil {
+		return s.Clock
+	}
+	return DefaultClock
+}
+
+// newIdempotencyKey generates the idempotency key of a request which doesn't
+// set one explicitly.
+func (s *BackendImplementation) newIdempotencyKey(opts *requestOptions) string {
+	if opts.idempotencyKeyGenerator != nil {
+		return opts.idempotencyKeyGenerator.NewIdempotencyKey()
+	}
+	if s.IdempotencyKeyGenerator != nil {
+		return s.IdempotencyKeyGenerator.NewIdempotencyKey()
+	}
+	return newIdempotencyKey(s.clock(), s.rand())
+}
+
+// rand returns the source of randomness of the backend, or the default one
+// if it has none.
+func (s *BackendImplementation) rand() Rand {
+	if s.Rand != nil {
+		return s.Rand
+	}
+	return DefaultRand
+}
+
 // sleepTime calculates sleeping/delay time in milliseconds between failure and a new one request.
 func (s *BackendImplementation) sleepTime(numRetries int) time.Duration {
 	// We disable sleeping in some cases for tests.
@@ -638,7 +834,7 @@ func (s *BackendImplementation) sleepTime(numRetries int) time.Duration {
 	}
 
 	// Apply some jitter by randomizing the value in the range of 75%-100%.
-	jitter := rand.Int63n(int64(delay / 4))
+	jitter := s.rand().Int63n(int64(delay / 4))
 	delay -= time.Duration(jitter)
 
 	// But never sleep less than the base sleep seconds.
@@ -655,6 +851,15 @@ type Backends struct {
 	mu                    sync.RWMutex
 }
 
+// BackendWrapper is implemented by Backends that forward requests to another
+// Backend, like AccountBackend or KeyProviderBackend, so that the backend
+// they wrap can be reached. For example, the OAuth client walks the chain to
+// find the URL of the underlying BackendImplementation.
+type BackendWrapper interface {
+	// Unwrap returns the backend that requests are forwarded to.
+	Unwrap() Backend
+}
+
 // SupportedBackend is an enumeration of supported Stripe endpoints.
 // Currently supported values are "api" and "uploads".
 type SupportedBackend string
@@ -756,6 +961,7 @@ func GetBackend(backendType SupportedBackend) Backend {
 		&BackendConfig{
 			HTTPClient:        httpClient,
 			LeveledLogger:     DefaultLeveledLogger,
+			LivemodeGuard:     DefaultLivemodeGuard,
 			LogLevel:          LogLevel,
 			Logger:            Logger,
 			MaxNetworkRetries: 0,
@@ -933,6 +1139,22 @@ func StringSlice(v []string) []*string {
 	return out
 }
 
+// Timestamp returns a pointer to the Unix timestamp of the time passed in,
+// for use in parameters like UsageRecordParams.Timestamp.
+func Timestamp(v time.Time) *int64 {
+	timestamp := v.Unix()
+	return &timestamp
+}
+
+// TimestampValue returns the time of the Unix timestamp pointer passed in or
+// the zero time if the pointer is nil.
+func TimestampValue(v *int64) time.Time {
+	if v != nil {
+		return time.Unix(*v, 0)
+	}
+	return time.Time{}
+}
+
 //
 // Private constants
 //
@@ -1113,14 +1335,22 @@ func newBackendImplementation(backendType SupportedBackend, config *BackendConfi
 	}
 
 	return &BackendImplementation{
-		HTTPClient:           config.HTTPClient,
-		LeveledLogger:        config.LeveledLogger,
-		MaxNetworkRetries:    config.MaxNetworkRetries,
-		Type:                 backendType,
-		URL:                  config.URL,
-		enableTelemetry:      enableTelemetry,
-		networkRetriesSleep:  true,
-		requestMetricsBuffer: requestMetricsBuffer,
+		Clock:                   config.Clock,
+		HTTPClient:              config.HTTPClient,
+		IdempotencyKeyGenerator: config.IdempotencyKeyGenerator,
+		KeepUnknownFields:       config.KeepUnknownFields,
+		LeveledLogger:           config.LeveledLogger,
+		LivemodeGuard:           config.LivemodeGuard,
+		LogRedactor:             config.LogRedactor,
+		MaxNetworkRetries:       config.MaxNetworkRetries,
+		Rand:                    config.Rand,
+		StrictDecoding:          config.StrictDecoding,
+		StructuredLogger:        config.StructuredLogger,
+		Type:                    backendType,
+		URL:                     config.URL,
+		enableTelemetry:         enableTelemetry,
+		networkRetriesSleep:     true,
+		requestMetricsBuffer:    requestMetricsBuffer,
 	}
 }
 
@@ -146,6 +146,24 @@ func (p *SubscriptionParams) AppendTo(body *form.Values, keyParts []string) {
 	}
 }
 
+// DecodeFrom implements custom decoding logic for SubscriptionParams that
+// undoes its AppendTo.
+func (p *SubscriptionParams) DecodeFrom(body *form.Values, keyParts []string) error {
+	if takeFormValue(body, append(keyParts, "billing_cycle_anchor"), "now") {
+		p.BillingCycleAnchorNow = Bool(true)
+	}
+
+	if takeFormValue(body, append(keyParts, "billing_cycle_anchor"), "unchanged") {
+		p.BillingCycleAnchorUnchanged = Bool(true)
+	}
+
+	if takeFormValue(body, append(keyParts, "trial_end"), "now") {
+		p.TrialEndNow = Bool(true)
+	}
+
+	return nil
+}
+
 // SubscriptionItemsParams is the set of parameters that can be used when creating or updating a subscription item on a subscription
 // For more details see https://stripe.com/docs/api#create_subscription and https://stripe.com/docs/api#update_subscription.
 type SubscriptionItemsParams struct {
@@ -238,6 +256,9 @@ type Subscription struct {
 
 	// This field is deprecated and we recommend that you use TaxRates instead.
 	TaxPercent float64 `json:"tax_percent"`
+
+	Extra    map[string]json.RawMessage `json:"-"`
+	expanded bool
 }
 
 // SubscriptionBillingThresholds is a structure representing the billing thresholds for a subscription.
@@ -268,5 +289,6 @@ func (s *Subscription) UnmarshalJSON(data []byte) error {
 	}
 
 	*s = Subscription(v)
+	s.expanded = true
 	return nil
 }
@@ -0,0 +1,326 @@
+package sub
+
+import (
+	"errors"
+	"fmt"
+
+	stripe "github.com/stripe/stripe-go"
+	"github.com/stripe/stripe-go/invoice"
+)
+
+//
+// Public types
+//
+
+// Change is a change to a subscription that can be previewed with Preview
+// and then applied with Apply. At least one of its fields must be set.
+type Change struct {
+	// AddItems are new items to add to the subscription. Each must set Plan,
+	// and may set Quantity and TaxRates.
+	AddItems []*stripe.SubscriptionItemsParams
+
+	// BillingCycleAnchorNow resets the billing cycle anchor of the
+	// subscription to the time of the change, which invoices it immediately.
+	BillingCycleAnchorNow bool
+
+	// Item is the ID of the subscription item that Plan and Quantity change.
+	//
+	// If left unset, defaults to the only item of the subscription, and
+	// setting either Plan or Quantity on a subscription with several items
+	// is an error.
+	Item string
+
+	// Plan is the ID of the plan to swap the item to.
+	Plan string
+
+	// ProrationBehavior determines how prorations are handled.
+	//
+	// Defaults to stripe.SubscriptionProrationBehaviorCreateProrations.
+	ProrationBehavior stripe.SubscriptionProrationBehavior
+
+	// ProrationDate is the Unix time at which prorations are computed.
+	//
+	// If left unset, defaults to the current time of stripe.DefaultClock.
+	// Apply reuses the date of the preview either way.
+	ProrationDate int64
+
+	// Quantity is the new quantity of the item.
+	Quantity *int64
+
+	// RemoveItems are the IDs of subscription items to remove.
+	RemoveItems []string
+}
+
+// ChangePreview is the breakdown of what a subscription change will cost,
+// computed from the upcoming invoice of the subscription as if the change was
+// applied. All amounts are in the smallest unit of Currency. Credits are
+// negative.
+type ChangePreview struct {
+	// Change is the previewed change, with ProrationBehavior and
+	// ProrationDate set to the values used in the preview.
+	Change *Change
+
+	// CreditAmount is the total of Credits, which is zero or negative.
+	CreditAmount int64
+
+	// Credits are the proration lines crediting the unused time on the
+	// subscription's items before the change.
+	Credits []*stripe.InvoiceLine
+
+	// Currency is the currency of the amounts of the preview.
+	Currency stripe.Currency
+
+	// DebitAmount is the total of Debits.
+	DebitAmount int64
+
+	// Debits are the proration lines charging for the remaining time on the
+	// subscription's items after the change.
+	Debits []*stripe.InvoiceLine
+
+	// Discount is the amount taken off by the discounts of the customer and
+	// the subscription.
+	Discount int64
+
+	// ImmediateAmount is the amount invoiced as soon as the change is
+	// applied. It's the whole upcoming invoice when the billing cycle anchor
+	// is reset, the prorations and their tax for
+	// SubscriptionProrationBehaviorAlwaysInvoice, and zero otherwise. When
+	// only the prorations are invoiced immediately, the amount doesn't
+	// include discounts, which Stripe also applies to that invoice.
+	ImmediateAmount int64
+
+	// Invoice is the upcoming invoice the preview was computed from. Its
+	// first page of lines is used to find prorations, so an upcoming invoice
+	// with more lines than that has Lines.HasMore set and some prorations
+	// missing from Credits and Debits. Its totals are always complete.
+	Invoice *stripe.Invoice
+
+	// NextInvoiceAmount is the amount due on the subscription's next regular
+	// invoice, after the customer's balance is applied, or zero when the
+	// billing cycle anchor is reset.
+	NextInvoiceAmount int64
+
+	// Subscription is the subscription before the change.
+	Subscription *stripe.Subscription
+
+	// Tax is the total tax of the upcoming invoice, inclusive and exclusive.
+	Tax int64
+}
+
+// Params returns the parameters updating the subscription with the
+// previewed change, with the same proration date as the preview so that the
+// change costs what was previewed.
+func (p *ChangePreview) Params() *stripe.SubscriptionParams {
+	params := &stripe.SubscriptionParams{
+		Items:             p.Change.items(),
+		ProrationBehavior: stripe.String(string(p.Change.ProrationBehavior)),
+		ProrationDate:     stripe.Int64(p.Change.ProrationDate),
+	}
+	if p.Change.BillingCycleAnchorNow {
+		params.BillingCycleAnchorNow = stripe.Bool(true)
+	}
+	return params
+}
+
+//
+// Public functions
+//
+
+// Preview previews the given change to a subscription, without applying it.
+func Preview(id string, change *Change) (*ChangePreview, error) {
+	return getC().Preview(id, change)
+}
+
+// Preview previews the given change to a subscription, without applying it.
+func (c Client) Preview(id string, change *Change) (*ChangePreview, error) {
+	subscription, err := c.Get(id, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	change, err = resolveChange(subscription, change)
+	if err != nil {
+		return nil, err
+	}
+
+	params := &stripe.InvoiceParams{
+		Subscription:                  stripe.String(subscription.ID),
+		SubscriptionItems:             change.items(),
+		SubscriptionProrationBehavior: stripe.String(string(change.ProrationBehavior)),
+		SubscriptionProrationDate:     stripe.Int64(change.ProrationDate),
+	}
+	if subscription.Customer != nil {
+		params.Customer = stripe.String(subscription.Customer.ID)
+	}
+	if change.BillingCycleAnchorNow {
+		params.SubscriptionBillingCycleAnchorNow = stripe.Bool(true)
+	}
+
+	invoices := invoice.Client{B: c.B, Key: c.Key}
+	upcoming, err := invoices.GetNext(params)
+	if err != nil {
+		return nil, err
+	}
+
+	return newChangePreview(subscription, change, upcoming), nil
+}
+
+// Apply updates a subscription with a previewed change.
+func Apply(preview *ChangePreview) (*stripe.Subscription, error) {
+	return getC().Apply(preview)
+}
+
+// Apply updates a subscription with a previewed change.
+func (c Client) Apply(preview *ChangePreview) (*stripe.Subscription, error) {
+	return c.Update(preview.Subscription.ID, preview.Params())
+}
+
+//
+// Private functions
+//
+
+// items returns the item parameters applying the change, which must have been
+// resolved.
+func (change *Change) items() []*stripe.SubscriptionItemsParams {
+	var items []*stripe.SubscriptionItemsParams
+
+	if change.Plan != "" || change.Quantity != nil {
+		item := &stripe.SubscriptionItemsParams{
+			ID:       stripe.String(change.Item),
+			Quantity: change.Quantity,
+		}
+		if change.Plan != "" {
+			item.Plan = stripe.String(change.Plan)
+		}
+		items = append(items, item)
+	}
+
+	items = append(items, change.AddItems...)
+
+	for _, id := range change.RemoveItems {
+		items = append(items, &stripe.SubscriptionItemsParams{
+			Deleted: stripe.Bool(true),
+			ID:      stripe.String(id),
+		})
+	}
+
+	return items
+}
+
+func newChangePreview(subscription *stripe.Subscription, change *Change, upcoming *stripe.Invoice) *ChangePreview {
+	p := &ChangePreview{
+		Change:       change,
+		Currency:     upcoming.Currency,
+		Invoice:      upcoming,
+		Subscription: subscription,
+		Tax:          upcoming.Tax,
+	}
+
+	exclusiveTax := upcoming.Tax
+	if len(upcoming.TotalTaxAmounts) > 0 {
+		exclusiveTax = exclusiveTaxAmount(upcoming.TotalTaxAmounts)
+	}
+	p.Discount = upcoming.Subtotal + exclusiveTax - upcoming.Total
+
+	var prorationTax int64
+	if upcoming.Lines != nil {
+		for _, line := range upcoming.Lines.Data {
+			if !line.Proration {
+				continue
+			}
+
+			if line.Amount < 0 {
+				p.Credits = append(p.Credits, line)
+				p.CreditAmount += line.Amount
+			} else {
+				p.Debits = append(p.Debits, line)
+				p.DebitAmount += line.Amount
+			}
+			prorationTax += exclusiveTaxAmount(line.TaxAmounts)
+		}
+	}
+
+	switch {
+	case change.BillingCycleAnchorNow:
+		p.ImmediateAmount = upcoming.AmountDue
+	case change.ProrationBehavior == stripe.SubscriptionProrationBehaviorAlwaysInvoice:
+		p.ImmediateAmount = p.CreditAmount + p.DebitAmount + prorationTax
+		p.NextInvoiceAmount = upcoming.AmountDue - p.ImmediateAmount
+	default:
+		p.NextInvoiceAmount = upcoming.AmountDue
+	}
+
+	return p
+}
+
+// exclusiveTaxAmount returns the total of the tax amounts that are added on
+// top of the amounts they're computed from.
+func exclusiveTaxAmount(taxAmounts []*stripe.InvoiceTaxAmount) int64 {
+	var total int64
+	for _, taxAmount := range taxAmounts {
+		if !taxAmount.Inclusive {
+			total += taxAmount.Amount
+		}
+	}
+	return total
+}
+
+// resolveChange checks a change against the subscription it's made to and
+// returns a copy with its defaults filled in.
+func resolveChange(subscription *stripe.Subscription, change *Change) (*Change, error) {
+	if change == nil {
+		return nil, errors.New("cannot preview a subscription change without a change")
+	}
+
+	resolved := *change
+
+	var items []*stripe.SubscriptionItem
+	if subscription.Items != nil {
+		items = subscription.Items.Data
+	}
+	hasItem := func(id string) bool {
+		for _, item := range items {
+			if item.ID == id {
+				return true
+			}
+		}
+		return false
+	}
+
+	if change.Plan != "" || change.Quantity != nil {
+		if resolved.Item == "" {
+			if len(items) != 1 {
+				return nil, fmt.Errorf("subscription %s has %d items, so the item to change must be set",
+					subscription.ID, len(items))
+			}
+			resolved.Item = items[0].ID
+		} else if !hasItem(resolved.Item) {
+			return nil, fmt.Errorf("subscription %s has no item %s", subscription.ID, resolved.Item)
+		}
+	}
+
+	for _, id := range change.RemoveItems {
+		if !hasItem(id) {
+			return nil, fmt.Errorf("subscription %s has no item %s", subscription.ID, id)
+		}
+	}
+
+	for _, item := range change.AddItems {
+		if item == nil || stripe.StringValue(item.Plan) == "" {
+			return nil, errors.New("cannot add a subscription item without a plan")
+		}
+	}
+
+	if len(resolved.items()) == 0 && !change.BillingCycleAnchorNow {
+		return nil, errors.New("cannot preview an empty subscription change")
+	}
+
+	if resolved.ProrationBehavior == "" {
+		resolved.ProrationBehavior = stripe.SubscriptionProrationBehaviorCreateProrations
+	}
+	if resolved.ProrationDate == 0 {
+		resolved.ProrationDate = stripe.DefaultClock.Now().Unix()
+	}
+
+	return &resolved, nil
+}
@@ -0,0 +1,163 @@
+package sub
+
+import (
+	"net/http"
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+	stripe "github.com/stripe/stripe-go"
+	stripetesting "github.com/stripe/stripe-go/testing"
+)
+
+// previewBackend is a Backend serving a subscription and its upcoming
+// invoice, and recording the parameters it receives.
+type previewBackend struct {
+	stripetesting.Backend
+
+	subscription *stripe.Subscription
+	upcoming     *stripe.Invoice
+
+	invoiceParams *stripe.InvoiceParams
+	updateParams  *stripe.SubscriptionParams
+}
+
+func (b *previewBackend) Call(method, path, key string, params stripe.ParamsContainer, v interface{}) error {
+	switch {
+	case path == "/v1/invoices/upcoming":
+		b.invoiceParams = params.(*stripe.InvoiceParams)
+		*v.(*stripe.Invoice) = *b.upcoming
+	case method == http.MethodPost:
+		b.updateParams = params.(*stripe.SubscriptionParams)
+		*v.(*stripe.Subscription) = *b.subscription
+	default:
+		*v.(*stripe.Subscription) = *b.subscription
+	}
+	return nil
+}
+
+func newPreviewBackend(upcoming *stripe.Invoice) *previewBackend {
+	return &previewBackend{
+		subscription: &stripe.Subscription{
+			Customer: &stripe.Customer{ID: "cus_123"},
+			ID:       "sub_123",
+			Items: &stripe.SubscriptionItemList{Data: []*stripe.SubscriptionItem{
+				{ID: "si_123", Plan: &stripe.Plan{ID: "basic"}, Quantity: 1},
+			}},
+		},
+		upcoming: upcoming,
+	}
+}
+
+func TestPreview(t *testing.T) {
+	backend := newPreviewBackend(&stripe.Invoice{
+		AmountDue: 3300,
+		Currency:  stripe.CurrencyUSD,
+		Lines: &stripe.InvoiceLineList{Data: []*stripe.InvoiceLine{
+			{Amount: -500, Proration: true, TaxAmounts: []*stripe.InvoiceTaxAmount{{Amount: -50}}},
+			{Amount: 1500, Proration: true, TaxAmounts: []*stripe.InvoiceTaxAmount{{Amount: 150}}},
+			{Amount: 2000, TaxAmounts: []*stripe.InvoiceTaxAmount{{Amount: 200}}},
+		}},
+		Subtotal:        3000,
+		Tax:             300,
+		Total:           3300,
+		TotalTaxAmounts: []*stripe.InvoiceTaxAmount{{Amount: 300}},
+	})
+	c := Client{B: backend, Key: "sk_test_123"}
+
+	preview, err := c.Preview("sub_123", &Change{
+		Plan:          "pro",
+		ProrationDate: 1500000000,
+	})
+	assert.NoError(t, err)
+
+	params := backend.invoiceParams
+	assert.Equal(t, "cus_123", stripe.StringValue(params.Customer))
+	assert.Equal(t, "sub_123", stripe.StringValue(params.Subscription))
+	assert.Equal(t, int64(1500000000), stripe.Int64Value(params.SubscriptionProrationDate))
+	assert.Equal(t, "create_prorations", stripe.StringValue(params.SubscriptionProrationBehavior))
+	assert.Equal(t, 1, len(params.SubscriptionItems))
+	assert.Equal(t, "si_123", stripe.StringValue(params.SubscriptionItems[0].ID))
+	assert.Equal(t, "pro", stripe.StringValue(params.SubscriptionItems[0].Plan))
+
+	assert.Equal(t, 1, len(preview.Credits))
+	assert.Equal(t, int64(-500), preview.CreditAmount)
+	assert.Equal(t, 1, len(preview.Debits))
+	assert.Equal(t, int64(1500), preview.DebitAmount)
+	assert.Equal(t, int64(0), preview.Discount)
+	assert.Equal(t, int64(0), preview.ImmediateAmount)
+	assert.Equal(t, int64(3300), preview.NextInvoiceAmount)
+	assert.Equal(t, int64(300), preview.Tax)
+
+	// Applying the change reuses the proration date of the preview
+	_, err = c.Apply(preview)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1500000000), stripe.Int64Value(backend.updateParams.ProrationDate))
+	assert.Equal(t, "pro", stripe.StringValue(backend.updateParams.Items[0].Plan))
+}
+
+func TestPreview_AlwaysInvoice(t *testing.T) {
+	backend := newPreviewBackend(&stripe.Invoice{
+		AmountDue: 2400,
+		Lines: &stripe.InvoiceLineList{Data: []*stripe.InvoiceLine{
+			{Amount: -500, Proration: true},
+			{Amount: 1500, Proration: true},
+			{Amount: 2000},
+		}},
+		Subtotal: 3000,
+		Total:    2400,
+	})
+	c := Client{B: backend, Key: "sk_test_123"}
+
+	preview, err := c.Preview("sub_123", &Change{
+		AddItems:          []*stripe.SubscriptionItemsParams{{Plan: stripe.String("addon")}},
+		ProrationBehavior: stripe.SubscriptionProrationBehaviorAlwaysInvoice,
+		RemoveItems:       []string{"si_123"},
+	})
+	assert.NoError(t, err)
+	assert.NotZero(t, preview.Change.ProrationDate)
+
+	items := backend.invoiceParams.SubscriptionItems
+	assert.Equal(t, 2, len(items))
+	assert.Equal(t, "addon", stripe.StringValue(items[0].Plan))
+	assert.Equal(t, "si_123", stripe.StringValue(items[1].ID))
+	assert.True(t, stripe.BoolValue(items[1].Deleted))
+
+	assert.Equal(t, int64(600), preview.Discount)
+	assert.Equal(t, int64(1000), preview.ImmediateAmount)
+	assert.Equal(t, int64(1400), preview.NextInvoiceAmount)
+}
+
+func TestPreview_BillingCycleAnchorNow(t *testing.T) {
+	backend := newPreviewBackend(&stripe.Invoice{AmountDue: 2000, Subtotal: 2000, Total: 2000})
+	c := Client{B: backend, Key: "sk_test_123"}
+
+	preview, err := c.Preview("sub_123", &Change{BillingCycleAnchorNow: true})
+	assert.NoError(t, err)
+	assert.True(t, stripe.BoolValue(backend.invoiceParams.SubscriptionBillingCycleAnchorNow))
+	assert.Equal(t, int64(2000), preview.ImmediateAmount)
+	assert.Equal(t, int64(0), preview.NextInvoiceAmount)
+	assert.True(t, stripe.BoolValue(preview.Params().BillingCycleAnchorNow))
+}
+
+func TestPreview_InvalidChange(t *testing.T) {
+	backend := newPreviewBackend(&stripe.Invoice{})
+	c := Client{B: backend, Key: "sk_test_123"}
+
+	_, err := c.Preview("sub_123", &Change{})
+	assert.Error(t, err)
+
+	_, err = c.Preview("sub_123", &Change{Item: "si_456", Plan: "pro"})
+	assert.Error(t, err)
+
+	_, err = c.Preview("sub_123", &Change{RemoveItems: []string{"si_456"}})
+	assert.Error(t, err)
+
+	_, err = c.Preview("sub_123", &Change{AddItems: []*stripe.SubscriptionItemsParams{{}}})
+	assert.Error(t, err)
+
+	backend.subscription.Items.Data = append(backend.subscription.Items.Data, &stripe.SubscriptionItem{ID: "si_456"})
+	_, err = c.Preview("sub_123", &Change{Quantity: stripe.Int64(2)})
+	assert.Error(t, err)
+
+	assert.Nil(t, backend.invoiceParams)
+}
@@ -1,5 +1,7 @@
 package stripe
 
+import "encoding/json"
+
 // SubscriptionItemParams is the set of parameters that can be used when creating or updating a subscription item.
 // For more details see https://stripe.com/docs/api#create_subscription_item and https://stripe.com/docs/api#update_subscription_item.
 type SubscriptionItemParams struct {
@@ -45,6 +47,8 @@ type SubscriptionItem struct {
 	Quantity          int64                             `json:"quantity"`
 	Subscription      string                            `json:"subscription"`
 	TaxRates          []*TaxRate                        `json:"tax_rates"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // SubscriptionItemBillingThresholds is a structure representing the billing thresholds for a
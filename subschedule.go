@@ -95,6 +95,15 @@ func (p *SubscriptionScheduleParams) AppendTo(body *form.Values, keyParts []stri
 	}
 }
 
+// DecodeFrom implements custom decoding logic for SubscriptionScheduleParams
+// that undoes its AppendTo.
+func (p *SubscriptionScheduleParams) DecodeFrom(body *form.Values, keyParts []string) error {
+	if takeFormValue(body, append(keyParts, "start_date"), "now") {
+		p.StartDateNow = Bool(true)
+	}
+	return nil
+}
+
 // SubscriptionScheduleCancelParams is the set of parameters that can be used when canceling a
 // subscription schedule.
 type SubscriptionScheduleCancelParams struct {
@@ -197,6 +206,9 @@ type SubscriptionSchedule struct {
 	RenewalInterval      *SubscriptionScheduleRenewalInterval `json:"renewal_interval"`
 	Status               SubscriptionScheduleStatus           `json:"status"`
 	Subscription         *Subscription                        `json:"subscription"`
+
+	Extra    map[string]json.RawMessage `json:"-"`
+	expanded bool
 }
 
 // SubscriptionScheduleList is a list object for subscription schedules.
@@ -221,5 +233,6 @@ func (s *SubscriptionSchedule) UnmarshalJSON(data []byte) error {
 	}
 
 	*s = SubscriptionSchedule(v)
+	s.expanded = true
 	return nil
 }
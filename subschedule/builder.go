@@ -0,0 +1,508 @@
+package subschedule
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"time"
+
+	stripe "github.com/stripe/stripe-go"
+)
+
+//
+// Public types
+//
+
+// Builder composes the phases of a subscription schedule, like a trial, then
+// an introductory plan for three months, then a standard plan. Params checks
+// that the phases follow each other without gaps or overlaps and that their
+// plans are consistent before anything is sent to Stripe, and Timeline
+// describes the resulting schedule.
+//
+// A Builder is started with NewBuilder for a new schedule, FromSubscription
+// for a schedule taking over an existing subscription, or FromSchedule to
+// edit an existing schedule, and is saved with Save.
+type Builder struct {
+	customer     string
+	endBehavior  stripe.SubscriptionScheduleEndBehavior
+	phases       []*PhaseBuilder
+	schedule     string
+	subscription string
+}
+
+// PhaseBuilder composes one phase of a subscription schedule. A phase starts
+// where the previous one ends, or when the schedule starts for the first
+// one, and lasts until its end date, for a number of iterations of its plans'
+// interval, or for a single iteration if it's the last phase and sets
+// neither.
+type PhaseBuilder struct {
+	builder    *Builder
+	coupon     string
+	endDate    int64
+	items      []*phaseItem
+	iterations *int64
+	startDate  int64
+	trial      bool
+}
+
+//
+// Public functions
+//
+
+// NewBuilder returns a builder for a new schedule for the given customer.
+func NewBuilder(customer string) *Builder {
+	return &Builder{customer: customer}
+}
+
+// FromSubscription returns a builder for a schedule taking over the given
+// subscription, with a first phase covering the subscription's current
+// period with its current items and coupon. Phases added to the builder
+// follow that one.
+//
+// The subscription's items must have their plans, which they do unless the
+// subscription was built by hand.
+func FromSubscription(s *stripe.Subscription) *Builder {
+	b := &Builder{subscription: s.ID}
+	if s.Customer != nil {
+		b.customer = s.Customer.ID
+	}
+
+	phase := b.Phase().
+		StartAt(time.Unix(s.CurrentPeriodStart, 0)).
+		EndAt(time.Unix(s.CurrentPeriodEnd, 0))
+	if s.Items != nil {
+		for _, item := range s.Items.Data {
+			phase.Plan(item.Plan, item.Quantity)
+		}
+	}
+	if s.Discount != nil && s.Discount.Coupon != nil {
+		phase.Coupon(s.Discount.Coupon.ID)
+	}
+	if s.Status == stripe.SubscriptionStatusTrialing {
+		phase.Trial()
+	}
+
+	return b
+}
+
+// FromSchedule returns a builder to edit the given schedule, with its current
+// phases and end behavior. Its phases must have their plans expanded.
+func FromSchedule(s *stripe.SubscriptionSchedule) *Builder {
+	b := &Builder{
+		endBehavior: s.EndBehavior,
+		schedule:    s.ID,
+	}
+	if s.Customer != nil {
+		b.customer = s.Customer.ID
+	}
+
+	for _, p := range s.Phases {
+		phase := b.Phase().
+			StartAt(time.Unix(p.StartDate, 0)).
+			EndAt(time.Unix(p.EndDate, 0))
+		for _, item := range p.Plans {
+			phase.Plan(item.Plan, item.Quantity)
+		}
+		if p.Coupon != nil {
+			phase.Coupon(p.Coupon.ID)
+		}
+		if p.TrialEnd != 0 && p.TrialEnd >= p.EndDate {
+			phase.Trial()
+		}
+	}
+
+	return b
+}
+
+// Save creates or updates the schedule composed by a builder.
+func Save(b *Builder) (*stripe.SubscriptionSchedule, error) {
+	return getC().Save(b)
+}
+
+// Save creates or updates the schedule composed by a builder.
+//
+// A schedule taking over a subscription is created from the subscription
+// and then updated with the builder's phases. If the update fails, the
+// created schedule is left mirroring the subscription, and is returned along
+// with the error.
+func (c Client) Save(b *Builder) (*stripe.SubscriptionSchedule, error) {
+	params, err := b.Params()
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case b.schedule != "":
+		return c.Update(b.schedule, params)
+
+	case b.subscription != "":
+		created, err := c.New(&stripe.SubscriptionScheduleParams{
+			FromSubscription: stripe.String(b.subscription),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		updated, err := c.Update(created.ID, params)
+		if err != nil {
+			return created, err
+		}
+		return updated, nil
+
+	default:
+		return c.New(params)
+	}
+}
+
+// EndBehavior sets what happens to the subscription when the schedule ends:
+// either it's released and keeps going on the last phase's plans, or it's
+// canceled. It must be set, because the two are easily confused.
+func (b *Builder) EndBehavior(behavior stripe.SubscriptionScheduleEndBehavior) *Builder {
+	b.endBehavior = behavior
+	return b
+}
+
+// Params returns the parameters creating or updating the schedule, or an
+// error describing the first problem found with its phases. Errors are
+// *stripe.Error values of type invalid_request_error, like the errors that
+// Stripe would have returned, with Param set to the offending parameter.
+func (b *Builder) Params() (*stripe.SubscriptionScheduleParams, error) {
+	if _, err := b.resolve(); err != nil {
+		return nil, err
+	}
+
+	params := &stripe.SubscriptionScheduleParams{
+		EndBehavior: stripe.String(string(b.endBehavior)),
+	}
+
+	existing := b.schedule != "" || b.subscription != ""
+	if !existing {
+		params.Customer = stripe.String(b.customer)
+		if start := b.phases[0].startDate; start != 0 {
+			params.StartDate = stripe.Int64(start)
+		} else {
+			params.StartDateNow = stripe.Bool(true)
+		}
+	}
+
+	for i, phase := range b.phases {
+		phaseParams := &stripe.SubscriptionSchedulePhaseParams{
+			Iterations: phase.iterations,
+		}
+		if i == 0 && existing {
+			phaseParams.StartDate = stripe.Int64(phase.startDate)
+		}
+		if phase.endDate != 0 {
+			phaseParams.EndDate = stripe.Int64(phase.endDate)
+		}
+		if phase.coupon != "" {
+			phaseParams.Coupon = stripe.String(phase.coupon)
+		}
+		if phase.trial {
+			phaseParams.Trial = stripe.Bool(true)
+		}
+		for _, item := range phase.items {
+			itemParams := &stripe.SubscriptionSchedulePhaseItemParams{Plan: stripe.String(item.plan.ID)}
+
+			// Metered plans are billed on usage, and don't accept a quantity
+			if !item.metered() {
+				itemParams.Quantity = stripe.Int64(item.quantity)
+			}
+			phaseParams.Plans = append(phaseParams.Plans, itemParams)
+		}
+		params.Phases = append(params.Phases, phaseParams)
+	}
+
+	return params, nil
+}
+
+// Phase adds a phase after the last phase of the schedule.
+func (b *Builder) Phase() *PhaseBuilder {
+	phase := &PhaseBuilder{builder: b}
+	b.phases = append(b.phases, phase)
+	return phase
+}
+
+// Timeline returns a human-readable description of the schedule, with a line
+// per phase giving its dates in UTC, its plans and its coupon, followed by
+// what happens when the schedule ends. It returns the same errors as Params.
+func (b *Builder) Timeline() (string, error) {
+	periods, err := b.resolve()
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	for i, phase := range b.phases {
+		fmt.Fprintf(&buf, "%s to %s",
+			formatDate(periods[i].start), formatDate(periods[i].end))
+		if phase.trial {
+			buf.WriteString(" trial")
+		}
+		buf.WriteString(":")
+
+		for j, item := range phase.items {
+			if j > 0 {
+				buf.WriteString(",")
+			}
+			if item.metered() {
+				fmt.Fprintf(&buf, " metered %s (%s)", item.plan.ID, formatPrice(item.plan))
+			} else {
+				fmt.Fprintf(&buf, " %d x %s (%s)", item.quantity, item.plan.ID, formatPrice(item.plan))
+			}
+		}
+		if phase.coupon != "" {
+			fmt.Fprintf(&buf, ", coupon %s", phase.coupon)
+		}
+		buf.WriteString("\n")
+	}
+
+	switch b.endBehavior {
+	case stripe.SubscriptionScheduleEndBehaviorCancel:
+		buf.WriteString("then the subscription is canceled\n")
+	default:
+		buf.WriteString("then the subscription is released\n")
+	}
+
+	return buf.String(), nil
+}
+
+// Coupon sets the coupon applied during the phase.
+func (p *PhaseBuilder) Coupon(id string) *PhaseBuilder {
+	p.coupon = id
+	return p
+}
+
+// EndAt sets the time at which the phase ends. It can't be combined with
+// Iterations.
+func (p *PhaseBuilder) EndAt(t time.Time) *PhaseBuilder {
+	p.endDate = t.Unix()
+	return p
+}
+
+// Iterations sets the number of intervals of the phase's plans that the
+// phase lasts. It can't be combined with EndAt.
+func (p *PhaseBuilder) Iterations(n int64) *PhaseBuilder {
+	p.iterations = stripe.Int64(n)
+	return p
+}
+
+// Phase adds a phase after the last phase of the schedule, which makes it
+// possible to chain the phases of a schedule.
+func (p *PhaseBuilder) Phase() *PhaseBuilder {
+	return p.builder.Phase()
+}
+
+// Plan adds a plan to the phase. All the plans of a schedule must be in the
+// same currency, and the plans of a phase must have the same interval.
+// Metered plans are billed on usage, so their quantity must be zero.
+func (p *PhaseBuilder) Plan(plan *stripe.Plan, quantity int64) *PhaseBuilder {
+	p.items = append(p.items, &phaseItem{plan: plan, quantity: quantity})
+	return p
+}
+
+// StartAt sets the time at which the phase starts. For the first phase of a
+// new schedule, that's when the schedule starts, which otherwise defaults to
+// when it's created. For other phases, it's only checked against the end of
+// the previous phase.
+func (p *PhaseBuilder) StartAt(t time.Time) *PhaseBuilder {
+	p.startDate = t.Unix()
+	return p
+}
+
+// Trial makes the phase a trial, during which its plans aren't charged.
+func (p *PhaseBuilder) Trial() *PhaseBuilder {
+	p.trial = true
+	return p
+}
+
+//
+// Private types
+//
+
+type phaseItem struct {
+	plan     *stripe.Plan
+	quantity int64
+}
+
+// period is the span of time covered by a phase, as Unix times.
+type period struct {
+	start int64
+	end   int64
+}
+
+//
+// Private functions
+//
+
+// addInterval adds n intervals to t, clamping to the end of the month when
+// adding months or years overflows it, like Stripe does when it bills.
+func addInterval(t time.Time, interval stripe.PlanInterval, n int64) time.Time {
+	t = t.UTC()
+
+	switch interval {
+	case stripe.PlanIntervalDay:
+		return t.AddDate(0, 0, int(n))
+	case stripe.PlanIntervalWeek:
+		return t.AddDate(0, 0, 7*int(n))
+	case stripe.PlanIntervalYear:
+		n *= 12
+	}
+
+	year, month, day := t.Date()
+	firstOfMonth := time.Date(year, month+time.Month(n), 1,
+		t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	if lastDay := firstOfMonth.AddDate(0, 1, -1).Day(); day > lastDay {
+		day = lastDay
+	}
+	return firstOfMonth.AddDate(0, 0, day-1)
+}
+
+func formatDate(t int64) string {
+	return time.Unix(t, 0).UTC().Format("2006-01-02")
+}
+
+// formatPrice describes what a plan charges, like "10.00 USD per month".
+func formatPrice(plan *stripe.Plan) string {
+	price := "tiered"
+	if plan.BillingScheme != stripe.PlanBillingSchemeTiered {
+		price = stripe.NewMoney(plan.Amount, plan.Currency).String()
+	}
+
+	if plan.IntervalCount > 1 {
+		return price + " every " + strconv.FormatInt(plan.IntervalCount, 10) + " " + string(plan.Interval) + "s"
+	}
+	return price + " per " + string(plan.Interval)
+}
+
+// intervalCount returns the number of intervals between two billings of a
+// plan.
+func intervalCount(plan *stripe.Plan) int64 {
+	if plan.IntervalCount == 0 {
+		return 1
+	}
+	return plan.IntervalCount
+}
+
+// metered returns whether the item's plan is billed on usage.
+func (i *phaseItem) metered() bool {
+	return i.plan.UsageType == stripe.PlanUsageTypeMetered
+}
+
+// resolve validates the schedule and returns the period covered by each of
+// its phases.
+func (b *Builder) resolve() ([]period, error) {
+	if b.schedule == "" && b.subscription == "" && b.customer == "" {
+		return nil, stripe.NewInvalidRequestError("", "customer", "a new schedule must have a customer")
+	}
+
+	switch b.endBehavior {
+	case stripe.SubscriptionScheduleEndBehaviorCancel, stripe.SubscriptionScheduleEndBehaviorRelease:
+	case "":
+		return nil, stripe.NewInvalidRequestError("", "end_behavior",
+			"the schedule must set whether the subscription is released or canceled when it ends")
+	default:
+		return nil, stripe.NewInvalidRequestError("", "end_behavior",
+			fmt.Sprintf("unknown end behavior %q", b.endBehavior))
+	}
+
+	if len(b.phases) == 0 {
+		return nil, stripe.NewInvalidRequestError("", "phases", "the schedule has no phases")
+	}
+
+	var currency stripe.Currency
+	var periods []period
+
+	for i, phase := range b.phases {
+		param := "phases[" + strconv.Itoa(i) + "]"
+
+		if len(phase.items) == 0 {
+			return nil, stripe.NewInvalidRequestError("", param+"[plans]",
+				fmt.Sprintf("phase %d has no plans", i+1))
+		}
+
+		first := phase.items[0].plan
+		for j, item := range phase.items {
+			itemParam := param + "[plans][" + strconv.Itoa(j) + "]"
+
+			if item.plan == nil || item.plan.ID == "" {
+				return nil, stripe.NewInvalidRequestError("", itemParam+"[plan]",
+					fmt.Sprintf("phase %d has a plan without an ID", i+1))
+			}
+			if item.quantity < 0 {
+				return nil, stripe.NewInvalidRequestError("", itemParam+"[quantity]",
+					fmt.Sprintf("phase %d has a negative quantity of plan %s", i+1, item.plan.ID))
+			}
+			if item.quantity != 0 && item.metered() {
+				return nil, stripe.NewInvalidRequestError("", itemParam+"[quantity]",
+					fmt.Sprintf("phase %d has a quantity of metered plan %s, which is billed on usage", i+1, item.plan.ID))
+			}
+
+			if currency == "" {
+				currency = item.plan.Currency
+			} else if item.plan.Currency != currency {
+				return nil, stripe.NewInvalidRequestError("", itemParam+"[plan]",
+					fmt.Sprintf("plan %s of phase %d is in %s, but the schedule's plans are in %s",
+						item.plan.ID, i+1, item.plan.Currency, currency))
+			}
+
+			if item.plan.Interval != first.Interval || item.plan.IntervalCount != first.IntervalCount {
+				return nil, stripe.NewInvalidRequestError("", itemParam+"[plan]",
+					fmt.Sprintf("plan %s of phase %d bills every %d %s, but plan %s of the same phase bills every %d %s",
+						item.plan.ID, i+1, item.plan.IntervalCount, item.plan.Interval,
+						first.ID, first.IntervalCount, first.Interval))
+			}
+		}
+
+		var start int64
+		switch {
+		case i > 0:
+			start = periods[i-1].end
+			if phase.startDate != 0 && phase.startDate != start {
+				problem := "leaving a gap"
+				if phase.startDate < start {
+					problem = "overlapping it"
+				}
+				return nil, stripe.NewInvalidRequestError("", param+"[start_date]",
+					fmt.Sprintf("phase %d starts on %s, but phase %d ends on %s, %s",
+						i+1, formatDate(phase.startDate), i, formatDate(start), problem))
+			}
+		case phase.startDate != 0:
+			start = phase.startDate
+		default:
+			start = stripe.DefaultClock.Now().Unix()
+		}
+
+		var end int64
+		switch {
+		case phase.iterations != nil && phase.endDate != 0:
+			return nil, stripe.NewInvalidRequestError("", param+"[iterations]",
+				fmt.Sprintf("phase %d sets both iterations and an end date, but can only set one", i+1))
+
+		case phase.iterations != nil:
+			if *phase.iterations < 1 {
+				return nil, stripe.NewInvalidRequestError("", param+"[iterations]",
+					fmt.Sprintf("phase %d must last at least one iteration", i+1))
+			}
+			end = addInterval(time.Unix(start, 0), first.Interval, *phase.iterations*intervalCount(first)).Unix()
+
+		case phase.endDate != 0:
+			end = phase.endDate
+			if end <= start {
+				return nil, stripe.NewInvalidRequestError("", param+"[end_date]",
+					fmt.Sprintf("phase %d ends on %s, before it starts on %s", i+1, formatDate(end), formatDate(start)))
+			}
+
+		case i == len(b.phases)-1:
+			end = addInterval(time.Unix(start, 0), first.Interval, intervalCount(first)).Unix()
+
+		default:
+			return nil, stripe.NewInvalidRequestError("", param+"[end_date]",
+				fmt.Sprintf("phase %d must set iterations or an end date, since it's followed by another phase", i+1))
+		}
+
+		periods = append(periods, period{start: start, end: end})
+	}
+
+	return periods, nil
+}
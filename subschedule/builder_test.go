@@ -0,0 +1,252 @@
+package subschedule
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	assert "github.com/stretchr/testify/require"
+	stripe "github.com/stripe/stripe-go"
+	stripetesting "github.com/stripe/stripe-go/testing"
+)
+
+// scheduleBackend is a Backend recording the schedules created and updated
+// through it.
+type scheduleBackend struct {
+	stripetesting.Backend
+
+	created []*stripe.SubscriptionScheduleParams
+	updated []*stripe.SubscriptionScheduleParams
+}
+
+func (b *scheduleBackend) Call(method, path, key string, params stripe.ParamsContainer, v interface{}) error {
+	if path == "/v1/subscription_schedules" {
+		b.created = append(b.created, params.(*stripe.SubscriptionScheduleParams))
+		v.(*stripe.SubscriptionSchedule).ID = "sub_sched_123"
+	} else if method == http.MethodPost {
+		b.updated = append(b.updated, params.(*stripe.SubscriptionScheduleParams))
+	}
+	return nil
+}
+
+var (
+	basicPlan    = &stripe.Plan{ID: "basic", Amount: 1000, Currency: stripe.CurrencyUSD, Interval: stripe.PlanIntervalMonth, IntervalCount: 1}
+	introPlan    = &stripe.Plan{ID: "intro", Amount: 500, Currency: stripe.CurrencyUSD, Interval: stripe.PlanIntervalMonth, IntervalCount: 1}
+	annualPlan   = &stripe.Plan{ID: "annual", Amount: 10000, Currency: stripe.CurrencyUSD, Interval: stripe.PlanIntervalYear, IntervalCount: 1}
+	euroPlan     = &stripe.Plan{ID: "euro", Amount: 900, Currency: stripe.CurrencyEUR, Interval: stripe.PlanIntervalMonth, IntervalCount: 1}
+	quarterlyFee = &stripe.Plan{ID: "fee", Amount: 300, Currency: stripe.CurrencyUSD, Interval: stripe.PlanIntervalMonth, IntervalCount: 3}
+	apiCalls     = &stripe.Plan{ID: "api_calls", Amount: 1, Currency: stripe.CurrencyUSD, Interval: stripe.PlanIntervalMonth, IntervalCount: 1, UsageType: stripe.PlanUsageTypeMetered}
+)
+
+func TestBuilder(t *testing.T) {
+	start := time.Date(2020, time.January, 31, 0, 0, 0, 0, time.UTC)
+
+	b := NewBuilder("cus_123").EndBehavior(stripe.SubscriptionScheduleEndBehaviorRelease)
+	b.Phase().Trial().Plan(basicPlan, 1).StartAt(start).EndAt(start.AddDate(0, 0, 14)).
+		Phase().Plan(introPlan, 1).Coupon("WELCOME").Iterations(3).
+		Phase().Plan(basicPlan, 2).Plan(quarterlyFee, 1).Iterations(1)
+
+	_, err := b.Params()
+	assert.Error(t, err)
+	assert.Equal(t, "phases[2][plans][1][plan]", err.(*stripe.Error).Param)
+
+	b.phases[2].items = b.phases[2].items[:1]
+	params, err := b.Params()
+	assert.NoError(t, err)
+	assert.Equal(t, "cus_123", stripe.StringValue(params.Customer))
+	assert.Equal(t, "release", stripe.StringValue(params.EndBehavior))
+	assert.Equal(t, start.Unix(), stripe.Int64Value(params.StartDate))
+	assert.Equal(t, 3, len(params.Phases))
+	assert.True(t, stripe.BoolValue(params.Phases[0].Trial))
+	assert.Nil(t, params.Phases[0].StartDate)
+	assert.Equal(t, start.AddDate(0, 0, 14).Unix(), stripe.Int64Value(params.Phases[0].EndDate))
+	assert.Equal(t, "WELCOME", stripe.StringValue(params.Phases[1].Coupon))
+	assert.Equal(t, int64(3), stripe.Int64Value(params.Phases[1].Iterations))
+	assert.Equal(t, int64(2), stripe.Int64Value(params.Phases[2].Plans[0].Quantity))
+
+	timeline, err := b.Timeline()
+	assert.NoError(t, err)
+	assert.Equal(t, ""+
+		"2020-01-31 to 2020-02-14 trial: 1 x basic (10.00 USD per month)\n"+
+		"2020-02-14 to 2020-05-14: 1 x intro (5.00 USD per month), coupon WELCOME\n"+
+		"2020-05-14 to 2020-06-14: 2 x basic (10.00 USD per month)\n"+
+		"then the subscription is released\n", timeline)
+}
+
+func TestBuilder_Invalid(t *testing.T) {
+	start := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		build func() *Builder
+		param string
+	}{
+		{func() *Builder {
+			b := NewBuilder("")
+			b.Phase().Plan(basicPlan, 1)
+			return b.EndBehavior(stripe.SubscriptionScheduleEndBehaviorCancel)
+		}, "customer"},
+		{func() *Builder {
+			b := NewBuilder("cus_123")
+			b.Phase().Plan(basicPlan, 1)
+			return b
+		}, "end_behavior"},
+		{func() *Builder {
+			return NewBuilder("cus_123").EndBehavior(stripe.SubscriptionScheduleEndBehaviorCancel)
+		}, "phases"},
+		{func() *Builder {
+			b := NewBuilder("cus_123").EndBehavior(stripe.SubscriptionScheduleEndBehaviorCancel)
+			b.Phase().Plan(basicPlan, 1).Phase().Plan(euroPlan, 1)
+			return b
+		}, "phases[0][end_date]"},
+		{func() *Builder {
+			b := NewBuilder("cus_123").EndBehavior(stripe.SubscriptionScheduleEndBehaviorCancel)
+			b.Phase().Plan(basicPlan, 1).Iterations(1).Phase().Plan(euroPlan, 1)
+			return b
+		}, "phases[1][plans][0][plan]"},
+		{func() *Builder {
+			b := NewBuilder("cus_123").EndBehavior(stripe.SubscriptionScheduleEndBehaviorCancel)
+			b.Phase().Plan(basicPlan, 1).Iterations(1).EndAt(start)
+			return b
+		}, "phases[0][iterations]"},
+		{func() *Builder {
+			b := NewBuilder("cus_123").EndBehavior(stripe.SubscriptionScheduleEndBehaviorCancel)
+			b.Phase().Plan(basicPlan, 1).StartAt(start).EndAt(start.AddDate(0, 1, 0)).
+				Phase().Plan(annualPlan, 1).StartAt(start.AddDate(0, 2, 0))
+			return b
+		}, "phases[1][start_date]"},
+		{func() *Builder {
+			b := NewBuilder("cus_123").EndBehavior(stripe.SubscriptionScheduleEndBehaviorCancel)
+			b.Phase().Plan(basicPlan, 1).StartAt(start).EndAt(start.AddDate(0, 0, -1))
+			return b
+		}, "phases[0][end_date]"},
+		{func() *Builder {
+			b := NewBuilder("cus_123").EndBehavior(stripe.SubscriptionScheduleEndBehaviorCancel)
+			b.Phase().Plan(basicPlan, 1).Iterations(0)
+			return b
+		}, "phases[0][iterations]"},
+		{func() *Builder {
+			b := NewBuilder("cus_123").EndBehavior(stripe.SubscriptionScheduleEndBehaviorCancel)
+			b.Phase()
+			return b
+		}, "phases[0][plans]"},
+		{func() *Builder {
+			b := NewBuilder("cus_123").EndBehavior(stripe.SubscriptionScheduleEndBehaviorCancel)
+			b.Phase().Plan(basicPlan, 1).Plan(apiCalls, 1)
+			return b
+		}, "phases[0][plans][1][quantity]"},
+	}
+
+	for _, testCase := range testCases {
+		_, err := testCase.build().Params()
+		assert.Error(t, err)
+		assert.Equal(t, stripe.ErrorTypeInvalidRequest, err.(*stripe.Error).Type)
+		assert.Equal(t, testCase.param, err.(*stripe.Error).Param, err.Error())
+		assert.IsType(t, &stripe.InvalidRequestError{}, err.(*stripe.Error).Err)
+	}
+}
+
+func TestFromSubscription(t *testing.T) {
+	periodStart := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	periodEnd := periodStart.AddDate(0, 1, 0)
+
+	b := FromSubscription(&stripe.Subscription{
+		Customer:           &stripe.Customer{ID: "cus_123"},
+		CurrentPeriodEnd:   periodEnd.Unix(),
+		CurrentPeriodStart: periodStart.Unix(),
+		ID:                 "sub_123",
+		Items: &stripe.SubscriptionItemList{Data: []*stripe.SubscriptionItem{
+			{Plan: basicPlan, Quantity: 2},
+		}},
+	}).EndBehavior(stripe.SubscriptionScheduleEndBehaviorCancel)
+	b.Phase().Plan(annualPlan, 1).Iterations(1)
+
+	timeline, err := b.Timeline()
+	assert.NoError(t, err)
+	assert.Equal(t, ""+
+		"2020-01-01 to 2020-02-01: 2 x basic (10.00 USD per month)\n"+
+		"2020-02-01 to 2021-02-01: 1 x annual (100.00 USD per year)\n"+
+		"then the subscription is canceled\n", timeline)
+
+	backend := &scheduleBackend{}
+	c := Client{B: backend, Key: "sk_test_123"}
+	_, err = c.Save(b)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 1, len(backend.created))
+	assert.Equal(t, "sub_123", stripe.StringValue(backend.created[0].FromSubscription))
+	assert.Equal(t, 1, len(backend.updated))
+	params := backend.updated[0]
+	assert.Nil(t, params.Customer)
+	assert.Equal(t, periodStart.Unix(), stripe.Int64Value(params.Phases[0].StartDate))
+	assert.Equal(t, "annual", stripe.StringValue(params.Phases[1].Plans[0].Plan))
+}
+
+func TestFromSubscription_Metered(t *testing.T) {
+	periodStart := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	b := FromSubscription(&stripe.Subscription{
+		Customer:           &stripe.Customer{ID: "cus_123"},
+		CurrentPeriodEnd:   periodStart.AddDate(0, 1, 0).Unix(),
+		CurrentPeriodStart: periodStart.Unix(),
+		ID:                 "sub_123",
+		Items: &stripe.SubscriptionItemList{Data: []*stripe.SubscriptionItem{
+			{Plan: basicPlan, Quantity: 1},
+			{Plan: apiCalls},
+		}},
+	}).EndBehavior(stripe.SubscriptionScheduleEndBehaviorRelease)
+
+	timeline, err := b.Timeline()
+	assert.NoError(t, err)
+	assert.Equal(t, ""+
+		"2020-01-01 to 2020-02-01: 1 x basic (10.00 USD per month), metered api_calls (0.01 USD per month)\n"+
+		"then the subscription is released\n", timeline)
+
+	// Metered plans are sent without a quantity
+	params, err := b.Params()
+	assert.NoError(t, err)
+	plans := params.Phases[0].Plans
+	assert.Equal(t, int64(1), stripe.Int64Value(plans[0].Quantity))
+	assert.Equal(t, "api_calls", stripe.StringValue(plans[1].Plan))
+	assert.Nil(t, plans[1].Quantity)
+}
+
+func TestFromSchedule(t *testing.T) {
+	start := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	b := FromSchedule(&stripe.SubscriptionSchedule{
+		EndBehavior: stripe.SubscriptionScheduleEndBehaviorRelease,
+		ID:          "sub_sched_123",
+		Phases: []*stripe.SubscriptionSchedulePhase{
+			{
+				EndDate:   start.AddDate(0, 1, 0).Unix(),
+				Plans:     []*stripe.SubscriptionSchedulePhaseItem{{Plan: basicPlan, Quantity: 1}},
+				StartDate: start.Unix(),
+				TrialEnd:  start.AddDate(0, 1, 0).Unix(),
+			},
+		},
+	})
+	b.Phase().Plan(basicPlan, 1)
+
+	timeline, err := b.Timeline()
+	assert.NoError(t, err)
+	assert.Equal(t, ""+
+		"2020-01-01 to 2020-02-01 trial: 1 x basic (10.00 USD per month)\n"+
+		"2020-02-01 to 2020-03-01: 1 x basic (10.00 USD per month)\n"+
+		"then the subscription is released\n", timeline)
+
+	backend := &scheduleBackend{}
+	c := Client{B: backend, Key: "sk_test_123"}
+	_, err = c.Save(b)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(backend.created))
+	assert.Equal(t, 1, len(backend.updated))
+	assert.True(t, stripe.BoolValue(backend.updated[0].Phases[0].Trial))
+}
+
+func TestAddInterval(t *testing.T) {
+	jan31 := time.Date(2020, time.January, 31, 12, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2020, time.February, 29, 12, 0, 0, 0, time.UTC), addInterval(jan31, stripe.PlanIntervalMonth, 1))
+	assert.Equal(t, time.Date(2020, time.March, 31, 12, 0, 0, 0, time.UTC), addInterval(jan31, stripe.PlanIntervalMonth, 2))
+	assert.Equal(t, time.Date(2021, time.January, 31, 12, 0, 0, 0, time.UTC), addInterval(jan31, stripe.PlanIntervalYear, 1))
+	assert.Equal(t, time.Date(2020, time.February, 14, 12, 0, 0, 0, time.UTC), addInterval(jan31, stripe.PlanIntervalWeek, 2))
+}
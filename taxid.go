@@ -72,6 +72,9 @@ type TaxID struct {
 	Type         TaxIDType          `json:"type"`
 	Value        string             `json:"value"`
 	Verification *TaxIDVerification `json:"verification"`
+
+	Extra    map[string]json.RawMessage `json:"-"`
+	expanded bool
 }
 
 // TaxIDList is a list of tax ids as retrieved from a list endpoint.
@@ -96,5 +99,6 @@ func (c *TaxID) UnmarshalJSON(data []byte) error {
 	}
 
 	*c = TaxID(v)
+	c.expanded = true
 	return nil
 }
@@ -48,6 +48,9 @@ type TaxRate struct {
 	Metadata     map[string]string `json:"metadata"`
 	Object       string            `json:"object"`
 	Percentage   float64           `json:"percentage"`
+
+	Extra    map[string]json.RawMessage `json:"-"`
+	expanded bool
 }
 
 // TaxRateList is a list of tax rates as retrieved from a list endpoint.
@@ -72,5 +75,6 @@ func (c *TaxRate) UnmarshalJSON(data []byte) error {
 	}
 
 	*c = TaxRate(v)
+	c.expanded = true
 	return nil
 }
@@ -1,5 +1,7 @@
 package stripe
 
+import "encoding/json"
+
 // TerminalConnectionTokenParams is the set of parameters that can be used when creating a terminal connection token.
 type TerminalConnectionTokenParams struct {
 	Params   `form:"*" json:"*"`
@@ -11,4 +13,6 @@ type TerminalConnectionToken struct {
 	Location string `json:"location"`
 	Object   string `json:"object"`
 	Secret   string `json:"secret"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
@@ -1,5 +1,7 @@
 package stripe
 
+import "encoding/json"
+
 // TerminalLocationParams is the set of parameters that can be used when creating or updating a terminal location.
 type TerminalLocationParams struct {
 	Params      `form:"*" json:"*"`
@@ -21,6 +23,8 @@ type TerminalLocation struct {
 	Livemode    bool                  `json:"livemode"`
 	Metadata    map[string]string     `json:"metadata"`
 	Object      string                `json:"object"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // TerminalLocationList is a list of terminal readers as retrieved from a list endpoint.
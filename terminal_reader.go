@@ -1,5 +1,7 @@
 package stripe
 
+import "encoding/json"
+
 // TerminalReaderParams is the set of parameters that can be used for creating or updating a terminal reader.
 type TerminalReaderParams struct {
 	Params           `form:"*" json:"*"`
@@ -35,6 +37,8 @@ type TerminalReader struct {
 	Object          string            `json:"object"`
 	SerialNumber    string            `json:"serial_number"`
 	Status          string            `json:"status"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // TerminalReaderList is a list of terminal readers as retrieved from a list endpoint.
@@ -1,12 +1,15 @@
 package testing
 
 import (
+	"bytes"
 	"crypto/tls"
 	"fmt"
 	"net/http"
 	"os"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	stripe "github.com/stripe/stripe-go"
 	"github.com/stripe/stripe-go/form"
@@ -32,6 +35,68 @@ const (
 	TestMerchantID = "acct_123"
 )
 
+// Backend is a stripe.Backend for tests which doesn't make requests: every
+// request succeeds without filling in its response. It's meant to be
+// embedded in fakes which override the methods they care about, usually Call
+// and CallRaw (which list iterators use).
+type Backend struct{}
+
+// Call succeeds without doing anything.
+func (b *Backend) Call(method, path, key string, params stripe.ParamsContainer, v interface{}) error {
+	return nil
+}
+
+// CallMultipart succeeds without doing anything.
+func (b *Backend) CallMultipart(method, path, key, boundary string, body *bytes.Buffer, params *stripe.Params, v interface{}) error {
+	return nil
+}
+
+// CallRaw succeeds without doing anything.
+func (b *Backend) CallRaw(method, path, key string, body *form.Values, params *stripe.Params, v interface{}) error {
+	return nil
+}
+
+// SetMaxNetworkRetries does nothing.
+func (b *Backend) SetMaxNetworkRetries(maxNetworkRetries int) {}
+
+// Clock is a stripe.Clock for tests which only moves forward when told to,
+// by Sleep or Add. It's safe for concurrent use.
+type Clock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewClock returns a new Clock stopped at the given time.
+func NewClock(now time.Time) *Clock {
+	return &Clock{now: now}
+}
+
+// Add moves the clock forward by d.
+func (c *Clock) Add(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// Now returns the time the clock is at.
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Set moves the clock to the given time.
+func (c *Clock) Set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = now
+}
+
+// Sleep moves the clock forward by d instead of waiting.
+func (c *Clock) Sleep(d time.Duration) {
+	c.Add(d)
+}
+
 func init() {
 	// Enable strict mode on form encoding so that we'll panic if any kind of
 	// malformed param struct is detected
@@ -2,10 +2,25 @@ package testing
 
 import (
 	"testing"
+	"time"
 
 	assert "github.com/stretchr/testify/require"
+	stripe "github.com/stripe/stripe-go"
 )
 
+// Both fakes implement the interfaces they stand in for.
+var _ stripe.Backend = &Backend{}
+var _ stripe.Clock = &Clock{}
+
+func TestClock(t *testing.T) {
+	c := NewClock(time.Unix(1500000000, 0))
+	c.Sleep(time.Minute)
+	assert.Equal(t, time.Unix(1500000060, 0), c.Now())
+
+	c.Set(time.Unix(1500000000, 0))
+	assert.Equal(t, time.Unix(1500000000, 0), c.Now())
+}
+
 func TestCompareVersions(t *testing.T) {
 	assert.Equal(t, 0, compareVersions("1.2.3", "1.2.3"))
 
@@ -1,5 +1,7 @@
 package stripe
 
+import "encoding/json"
+
 // ThreeDSecureStatus represents the possible statuses of a ThreeDSecure object.
 type ThreeDSecureStatus string
 
@@ -26,4 +28,6 @@ type ThreeDSecure struct {
 	RedirectURL   string             `json:"redirect_url"`
 	Status        ThreeDSecureStatus `json:"status"`
 	Supported     string             `json:"supported"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
@@ -0,0 +1,779 @@
+// Code generated by scripts/generate_timestamps. DO NOT EDIT.
+
+package stripe
+
+import "time"
+
+// CreatedTime returns Created as a time.Time, or the zero time if it isn't
+// set.
+func (a *Account) CreatedTime() time.Time {
+	return unixTime(a.Created)
+}
+
+// CreatedTime returns Created as a time.Time, or the zero time if it isn't
+// set.
+func (a *AccountLink) CreatedTime() time.Time {
+	return unixTime(a.Created)
+}
+
+// ExpiresAtTime returns ExpiresAt as a time.Time, or the zero time if it isn't
+// set.
+func (a *AccountLink) ExpiresAtTime() time.Time {
+	return unixTime(a.ExpiresAt)
+}
+
+// CurrentDeadlineTime returns CurrentDeadline as a time.Time, or the zero time if it isn't
+// set.
+func (a *AccountRequirements) CurrentDeadlineTime() time.Time {
+	return unixTime(a.CurrentDeadline)
+}
+
+// DateTime returns Date as a time.Time, or the zero time if it isn't
+// set.
+func (a *AccountTOSAcceptance) DateTime() time.Time {
+	return unixTime(a.Date)
+}
+
+// CreatedTime returns Created as a time.Time, or the zero time if it isn't
+// set.
+func (a *ApplePayDomain) CreatedTime() time.Time {
+	return unixTime(a.Created)
+}
+
+// CreatedTime returns Created as a time.Time, or the zero time if it isn't
+// set.
+func (f *ApplicationFee) CreatedTime() time.Time {
+	return unixTime(f.Created)
+}
+
+// AvailableOnTime returns AvailableOn as a time.Time, or the zero time if it isn't
+// set.
+func (t *BalanceTransaction) AvailableOnTime() time.Time {
+	return unixTime(t.AvailableOn)
+}
+
+// CreatedTime returns Created as a time.Time, or the zero time if it isn't
+// set.
+func (t *BalanceTransaction) CreatedTime() time.Time {
+	return unixTime(t.Created)
+}
+
+// CreatedTime returns Created as a time.Time, or the zero time if it isn't
+// set.
+func (r *BitcoinReceiver) CreatedTime() time.Time {
+	return unixTime(r.Created)
+}
+
+// CreatedTime returns Created as a time.Time, or the zero time if it isn't
+// set.
+func (bt *BitcoinTransaction) CreatedTime() time.Time {
+	return unixTime(bt.Created)
+}
+
+// RequestedAtTime returns RequestedAt as a time.Time, or the zero time if it isn't
+// set.
+func (c *Capability) RequestedAtTime() time.Time {
+	return unixTime(c.RequestedAt)
+}
+
+// CurrentDeadlineTime returns CurrentDeadline as a time.Time, or the zero time if it isn't
+// set.
+func (c *CapabilityRequirements) CurrentDeadlineTime() time.Time {
+	return unixTime(c.CurrentDeadline)
+}
+
+// CreatedTime returns Created as a time.Time, or the zero time if it isn't
+// set.
+func (c *Charge) CreatedTime() time.Time {
+	return unixTime(c.Created)
+}
+
+// CreatedTime returns Created as a time.Time, or the zero time if it isn't
+// set.
+func (c *Coupon) CreatedTime() time.Time {
+	return unixTime(c.Created)
+}
+
+// RedeemByTime returns RedeemBy as a time.Time, or the zero time if it isn't
+// set.
+func (c *Coupon) RedeemByTime() time.Time {
+	return unixTime(c.RedeemBy)
+}
+
+// CreatedTime returns Created as a time.Time, or the zero time if it isn't
+// set.
+func (i *CreditNote) CreatedTime() time.Time {
+	return unixTime(i.Created)
+}
+
+// VoidedAtTime returns VoidedAt as a time.Time, or the zero time if it isn't
+// set.
+func (i *CreditNote) VoidedAtTime() time.Time {
+	return unixTime(i.VoidedAt)
+}
+
+// CreatedTime returns Created as a time.Time, or the zero time if it isn't
+// set.
+func (c *Customer) CreatedTime() time.Time {
+	return unixTime(c.Created)
+}
+
+// CreatedTime returns Created as a time.Time, or the zero time if it isn't
+// set.
+func (c *CustomerBalanceTransaction) CreatedTime() time.Time {
+	return unixTime(c.Created)
+}
+
+// EndTime returns End as a time.Time, or the zero time if it isn't
+// set.
+func (d *Discount) EndTime() time.Time {
+	return unixTime(d.End)
+}
+
+// StartTime returns Start as a time.Time, or the zero time if it isn't
+// set.
+func (d *Discount) StartTime() time.Time {
+	return unixTime(d.Start)
+}
+
+// CreatedTime returns Created as a time.Time, or the zero time if it isn't
+// set.
+func (d *Dispute) CreatedTime() time.Time {
+	return unixTime(d.Created)
+}
+
+// CreatedTime returns Created as a time.Time, or the zero time if it isn't
+// set.
+func (e *EphemeralKey) CreatedTime() time.Time {
+	return unixTime(e.Created)
+}
+
+// ExpiresTime returns Expires as a time.Time, or the zero time if it isn't
+// set.
+func (e *EphemeralKey) ExpiresTime() time.Time {
+	return unixTime(e.Expires)
+}
+
+// CreatedTime returns Created as a time.Time, or the zero time if it isn't
+// set.
+func (e *Event) CreatedTime() time.Time {
+	return unixTime(e.Created)
+}
+
+// DueByTime returns DueBy as a time.Time, or the zero time if it isn't
+// set.
+func (e *EvidenceDetails) DueByTime() time.Time {
+	return unixTime(e.DueBy)
+}
+
+// CreatedTime returns Created as a time.Time, or the zero time if it isn't
+// set.
+func (r *FeeRefund) CreatedTime() time.Time {
+	return unixTime(r.Created)
+}
+
+// CreatedTime returns Created as a time.Time, or the zero time if it isn't
+// set.
+func (f *File) CreatedTime() time.Time {
+	return unixTime(f.Created)
+}
+
+// CreatedTime returns Created as a time.Time, or the zero time if it isn't
+// set.
+func (c *FileLink) CreatedTime() time.Time {
+	return unixTime(c.Created)
+}
+
+// ExpiresAtTime returns ExpiresAt as a time.Time, or the zero time if it isn't
+// set.
+func (c *FileLink) ExpiresAtTime() time.Time {
+	return unixTime(c.ExpiresAt)
+}
+
+// CreatedTime returns Created as a time.Time, or the zero time if it isn't
+// set.
+func (i *Invoice) CreatedTime() time.Time {
+	return unixTime(i.Created)
+}
+
+// DueDateTime returns DueDate as a time.Time, or the zero time if it isn't
+// set.
+func (i *Invoice) DueDateTime() time.Time {
+	return unixTime(i.DueDate)
+}
+
+// NextPaymentAttemptTime returns NextPaymentAttempt as a time.Time, or the zero time if it isn't
+// set.
+func (i *Invoice) NextPaymentAttemptTime() time.Time {
+	return unixTime(i.NextPaymentAttempt)
+}
+
+// PeriodEndTime returns PeriodEnd as a time.Time, or the zero time if it isn't
+// set.
+func (i *Invoice) PeriodEndTime() time.Time {
+	return unixTime(i.PeriodEnd)
+}
+
+// PeriodStartTime returns PeriodStart as a time.Time, or the zero time if it isn't
+// set.
+func (i *Invoice) PeriodStartTime() time.Time {
+	return unixTime(i.PeriodStart)
+}
+
+// SubscriptionProrationDateTime returns SubscriptionProrationDate as a time.Time, or the zero time if it isn't
+// set.
+func (i *Invoice) SubscriptionProrationDateTime() time.Time {
+	return unixTime(i.SubscriptionProrationDate)
+}
+
+// WebhooksDeliveredAtTime returns WebhooksDeliveredAt as a time.Time, or the zero time if it isn't
+// set.
+func (i *Invoice) WebhooksDeliveredAtTime() time.Time {
+	return unixTime(i.WebhooksDeliveredAt)
+}
+
+// DateTime returns Date as a time.Time, or the zero time if it isn't
+// set.
+func (i *InvoiceItem) DateTime() time.Time {
+	return unixTime(i.Date)
+}
+
+// FinalizedAtTime returns FinalizedAt as a time.Time, or the zero time if it isn't
+// set.
+func (i *InvoiceStatusTransitions) FinalizedAtTime() time.Time {
+	return unixTime(i.FinalizedAt)
+}
+
+// MarkedUncollectibleAtTime returns MarkedUncollectibleAt as a time.Time, or the zero time if it isn't
+// set.
+func (i *InvoiceStatusTransitions) MarkedUncollectibleAtTime() time.Time {
+	return unixTime(i.MarkedUncollectibleAt)
+}
+
+// PaidAtTime returns PaidAt as a time.Time, or the zero time if it isn't
+// set.
+func (i *InvoiceStatusTransitions) PaidAtTime() time.Time {
+	return unixTime(i.PaidAt)
+}
+
+// VoidedAtTime returns VoidedAt as a time.Time, or the zero time if it isn't
+// set.
+func (i *InvoiceStatusTransitions) VoidedAtTime() time.Time {
+	return unixTime(i.VoidedAt)
+}
+
+// CreatedTime returns Created as a time.Time, or the zero time if it isn't
+// set.
+func (i *IssuingAuthorization) CreatedTime() time.Time {
+	return unixTime(i.Created)
+}
+
+// CreatedTime returns Created as a time.Time, or the zero time if it isn't
+// set.
+func (i *IssuingAuthorizationRequestHistory) CreatedTime() time.Time {
+	return unixTime(i.Created)
+}
+
+// CreatedTime returns Created as a time.Time, or the zero time if it isn't
+// set.
+func (i *IssuingCard) CreatedTime() time.Time {
+	return unixTime(i.Created)
+}
+
+// ETATime returns ETA as a time.Time, or the zero time if it isn't
+// set.
+func (i *IssuingCardShipping) ETATime() time.Time {
+	return unixTime(i.ETA)
+}
+
+// CreatedTime returns Created as a time.Time, or the zero time if it isn't
+// set.
+func (i *IssuingCardholder) CreatedTime() time.Time {
+	return unixTime(i.Created)
+}
+
+// CreatedTime returns Created as a time.Time, or the zero time if it isn't
+// set.
+func (i *IssuingDispute) CreatedTime() time.Time {
+	return unixTime(i.Created)
+}
+
+// CreatedTime returns Created as a time.Time, or the zero time if it isn't
+// set.
+func (i *IssuingTransaction) CreatedTime() time.Time {
+	return unixTime(i.Created)
+}
+
+// CreatedTime returns Created as a time.Time, or the zero time if it isn't
+// set.
+func (l *LoginLink) CreatedTime() time.Time {
+	return unixTime(l.Created)
+}
+
+// AcceptedAtTime returns AcceptedAt as a time.Time, or the zero time if it isn't
+// set.
+func (m *MandateCustomerAcceptance) AcceptedAtTime() time.Time {
+	return unixTime(m.AcceptedAt)
+}
+
+// CreatedTime returns Created as a time.Time, or the zero time if it isn't
+// set.
+func (o *Order) CreatedTime() time.Time {
+	return unixTime(o.Created)
+}
+
+// UpdatedTime returns Updated as a time.Time, or the zero time if it isn't
+// set.
+func (o *Order) UpdatedTime() time.Time {
+	return unixTime(o.Updated)
+}
+
+// CreatedTime returns Created as a time.Time, or the zero time if it isn't
+// set.
+func (r *OrderReturn) CreatedTime() time.Time {
+	return unixTime(r.Created)
+}
+
+// CanceledAtTime returns CanceledAt as a time.Time, or the zero time if it isn't
+// set.
+func (p *PaymentIntent) CanceledAtTime() time.Time {
+	return unixTime(p.CanceledAt)
+}
+
+// CreatedTime returns Created as a time.Time, or the zero time if it isn't
+// set.
+func (p *PaymentIntent) CreatedTime() time.Time {
+	return unixTime(p.Created)
+}
+
+// CreatedTime returns Created as a time.Time, or the zero time if it isn't
+// set.
+func (i *PaymentMethod) CreatedTime() time.Time {
+	return unixTime(i.Created)
+}
+
+// ArrivalDateTime returns ArrivalDate as a time.Time, or the zero time if it isn't
+// set.
+func (p *Payout) ArrivalDateTime() time.Time {
+	return unixTime(p.ArrivalDate)
+}
+
+// CreatedTime returns Created as a time.Time, or the zero time if it isn't
+// set.
+func (p *Payout) CreatedTime() time.Time {
+	return unixTime(p.Created)
+}
+
+// EndTime returns End as a time.Time, or the zero time if it isn't
+// set.
+func (p *Period) EndTime() time.Time {
+	return unixTime(p.End)
+}
+
+// StartTime returns Start as a time.Time, or the zero time if it isn't
+// set.
+func (p *Period) StartTime() time.Time {
+	return unixTime(p.Start)
+}
+
+// CreatedTime returns Created as a time.Time, or the zero time if it isn't
+// set.
+func (s *Plan) CreatedTime() time.Time {
+	return unixTime(s.Created)
+}
+
+// CreatedTime returns Created as a time.Time, or the zero time if it isn't
+// set.
+func (p *Product) CreatedTime() time.Time {
+	return unixTime(p.Created)
+}
+
+// UpdatedTime returns Updated as a time.Time, or the zero time if it isn't
+// set.
+func (p *Product) UpdatedTime() time.Time {
+	return unixTime(p.Updated)
+}
+
+// CreatedTime returns Created as a time.Time, or the zero time if it isn't
+// set.
+func (r *RadarEarlyFraudWarning) CreatedTime() time.Time {
+	return unixTime(r.Created)
+}
+
+// CreatedTime returns Created as a time.Time, or the zero time if it isn't
+// set.
+func (r *RadarValueList) CreatedTime() time.Time {
+	return unixTime(r.Created)
+}
+
+// UpdatedTime returns Updated as a time.Time, or the zero time if it isn't
+// set.
+func (r *RadarValueList) UpdatedTime() time.Time {
+	return unixTime(r.Updated)
+}
+
+// CreatedTime returns Created as a time.Time, or the zero time if it isn't
+// set.
+func (r *RadarValueListItem) CreatedTime() time.Time {
+	return unixTime(r.Created)
+}
+
+// CreatedTime returns Created as a time.Time, or the zero time if it isn't
+// set.
+func (r *Recipient) CreatedTime() time.Time {
+	return unixTime(r.Created)
+}
+
+// CreatedTime returns Created as a time.Time, or the zero time if it isn't
+// set.
+func (t *RecipientTransfer) CreatedTime() time.Time {
+	return unixTime(t.Created)
+}
+
+// DateTime returns Date as a time.Time, or the zero time if it isn't
+// set.
+func (t *RecipientTransfer) DateTime() time.Time {
+	return unixTime(t.Date)
+}
+
+// CreatedTime returns Created as a time.Time, or the zero time if it isn't
+// set.
+func (r *Refund) CreatedTime() time.Time {
+	return unixTime(r.Created)
+}
+
+// CreatedTime returns Created as a time.Time, or the zero time if it isn't
+// set.
+func (r *ReportRun) CreatedTime() time.Time {
+	return unixTime(r.Created)
+}
+
+// SucceededAtTime returns SucceededAt as a time.Time, or the zero time if it isn't
+// set.
+func (r *ReportRun) SucceededAtTime() time.Time {
+	return unixTime(r.SucceededAt)
+}
+
+// IntervalEndTime returns IntervalEnd as a time.Time, or the zero time if it isn't
+// set.
+func (r *ReportRunParameters) IntervalEndTime() time.Time {
+	return unixTime(r.IntervalEnd)
+}
+
+// IntervalStartTime returns IntervalStart as a time.Time, or the zero time if it isn't
+// set.
+func (r *ReportRunParameters) IntervalStartTime() time.Time {
+	return unixTime(r.IntervalStart)
+}
+
+// CreatedTime returns Created as a time.Time, or the zero time if it isn't
+// set.
+func (r *ReportType) CreatedTime() time.Time {
+	return unixTime(r.Created)
+}
+
+// DataAvailableEndTime returns DataAvailableEnd as a time.Time, or the zero time if it isn't
+// set.
+func (r *ReportType) DataAvailableEndTime() time.Time {
+	return unixTime(r.DataAvailableEnd)
+}
+
+// DataAvailableStartTime returns DataAvailableStart as a time.Time, or the zero time if it isn't
+// set.
+func (r *ReportType) DataAvailableStartTime() time.Time {
+	return unixTime(r.DataAvailableStart)
+}
+
+// UpdatedTime returns Updated as a time.Time, or the zero time if it isn't
+// set.
+func (r *ReportType) UpdatedTime() time.Time {
+	return unixTime(r.Updated)
+}
+
+// CreatedTime returns Created as a time.Time, or the zero time if it isn't
+// set.
+func (r *Reversal) CreatedTime() time.Time {
+	return unixTime(r.Created)
+}
+
+// CreatedTime returns Created as a time.Time, or the zero time if it isn't
+// set.
+func (r *Review) CreatedTime() time.Time {
+	return unixTime(r.Created)
+}
+
+// CreatedTime returns Created as a time.Time, or the zero time if it isn't
+// set.
+func (s *SKU) CreatedTime() time.Time {
+	return unixTime(s.Created)
+}
+
+// UpdatedTime returns Updated as a time.Time, or the zero time if it isn't
+// set.
+func (s *SKU) UpdatedTime() time.Time {
+	return unixTime(s.Updated)
+}
+
+// CreatedTime returns Created as a time.Time, or the zero time if it isn't
+// set.
+func (p *SetupIntent) CreatedTime() time.Time {
+	return unixTime(p.Created)
+}
+
+// CreatedTime returns Created as a time.Time, or the zero time if it isn't
+// set.
+func (i *SigmaScheduledQueryRun) CreatedTime() time.Time {
+	return unixTime(i.Created)
+}
+
+// DataLoadTimeTime returns DataLoadTime as a time.Time, or the zero time if it isn't
+// set.
+func (i *SigmaScheduledQueryRun) DataLoadTimeTime() time.Time {
+	return unixTime(i.DataLoadTime)
+}
+
+// ResultAvailableUntilTime returns ResultAvailableUntil as a time.Time, or the zero time if it isn't
+// set.
+func (i *SigmaScheduledQueryRun) ResultAvailableUntilTime() time.Time {
+	return unixTime(i.ResultAvailableUntil)
+}
+
+// CreatedTime returns Created as a time.Time, or the zero time if it isn't
+// set.
+func (s *Source) CreatedTime() time.Time {
+	return unixTime(s.Created)
+}
+
+// DateTime returns Date as a time.Time, or the zero time if it isn't
+// set.
+func (s *SourceMandateAcceptance) DateTime() time.Time {
+	return unixTime(s.Date)
+}
+
+// CreatedTime returns Created as a time.Time, or the zero time if it isn't
+// set.
+func (t *SourceTransaction) CreatedTime() time.Time {
+	return unixTime(t.Created)
+}
+
+// CanceledTime returns Canceled as a time.Time, or the zero time if it isn't
+// set.
+func (s *StatusTransitions) CanceledTime() time.Time {
+	return unixTime(s.Canceled)
+}
+
+// FulfilledTime returns Fulfilled as a time.Time, or the zero time if it isn't
+// set.
+func (s *StatusTransitions) FulfilledTime() time.Time {
+	return unixTime(s.Fulfilled)
+}
+
+// PaidTime returns Paid as a time.Time, or the zero time if it isn't
+// set.
+func (s *StatusTransitions) PaidTime() time.Time {
+	return unixTime(s.Paid)
+}
+
+// ReturnedTime returns Returned as a time.Time, or the zero time if it isn't
+// set.
+func (s *StatusTransitions) ReturnedTime() time.Time {
+	return unixTime(s.Returned)
+}
+
+// BillingCycleAnchorTime returns BillingCycleAnchor as a time.Time, or the zero time if it isn't
+// set.
+func (s *Subscription) BillingCycleAnchorTime() time.Time {
+	return unixTime(s.BillingCycleAnchor)
+}
+
+// CancelAtTime returns CancelAt as a time.Time, or the zero time if it isn't
+// set.
+func (s *Subscription) CancelAtTime() time.Time {
+	return unixTime(s.CancelAt)
+}
+
+// CanceledAtTime returns CanceledAt as a time.Time, or the zero time if it isn't
+// set.
+func (s *Subscription) CanceledAtTime() time.Time {
+	return unixTime(s.CanceledAt)
+}
+
+// CreatedTime returns Created as a time.Time, or the zero time if it isn't
+// set.
+func (s *Subscription) CreatedTime() time.Time {
+	return unixTime(s.Created)
+}
+
+// CurrentPeriodEndTime returns CurrentPeriodEnd as a time.Time, or the zero time if it isn't
+// set.
+func (s *Subscription) CurrentPeriodEndTime() time.Time {
+	return unixTime(s.CurrentPeriodEnd)
+}
+
+// CurrentPeriodStartTime returns CurrentPeriodStart as a time.Time, or the zero time if it isn't
+// set.
+func (s *Subscription) CurrentPeriodStartTime() time.Time {
+	return unixTime(s.CurrentPeriodStart)
+}
+
+// EndedAtTime returns EndedAt as a time.Time, or the zero time if it isn't
+// set.
+func (s *Subscription) EndedAtTime() time.Time {
+	return unixTime(s.EndedAt)
+}
+
+// NextPendingInvoiceItemInvoiceTime returns NextPendingInvoiceItemInvoice as a time.Time, or the zero time if it isn't
+// set.
+func (s *Subscription) NextPendingInvoiceItemInvoiceTime() time.Time {
+	return unixTime(s.NextPendingInvoiceItemInvoice)
+}
+
+// StartDateTime returns StartDate as a time.Time, or the zero time if it isn't
+// set.
+func (s *Subscription) StartDateTime() time.Time {
+	return unixTime(s.StartDate)
+}
+
+// TrialEndTime returns TrialEnd as a time.Time, or the zero time if it isn't
+// set.
+func (s *Subscription) TrialEndTime() time.Time {
+	return unixTime(s.TrialEnd)
+}
+
+// TrialStartTime returns TrialStart as a time.Time, or the zero time if it isn't
+// set.
+func (s *Subscription) TrialStartTime() time.Time {
+	return unixTime(s.TrialStart)
+}
+
+// CreatedTime returns Created as a time.Time, or the zero time if it isn't
+// set.
+func (s *SubscriptionItem) CreatedTime() time.Time {
+	return unixTime(s.Created)
+}
+
+// BillingCycleAnchorTime returns BillingCycleAnchor as a time.Time, or the zero time if it isn't
+// set.
+func (s *SubscriptionPendingUpdate) BillingCycleAnchorTime() time.Time {
+	return unixTime(s.BillingCycleAnchor)
+}
+
+// ExpiresAtTime returns ExpiresAt as a time.Time, or the zero time if it isn't
+// set.
+func (s *SubscriptionPendingUpdate) ExpiresAtTime() time.Time {
+	return unixTime(s.ExpiresAt)
+}
+
+// TrialEndTime returns TrialEnd as a time.Time, or the zero time if it isn't
+// set.
+func (s *SubscriptionPendingUpdate) TrialEndTime() time.Time {
+	return unixTime(s.TrialEnd)
+}
+
+// CanceledAtTime returns CanceledAt as a time.Time, or the zero time if it isn't
+// set.
+func (s *SubscriptionSchedule) CanceledAtTime() time.Time {
+	return unixTime(s.CanceledAt)
+}
+
+// CompletedAtTime returns CompletedAt as a time.Time, or the zero time if it isn't
+// set.
+func (s *SubscriptionSchedule) CompletedAtTime() time.Time {
+	return unixTime(s.CompletedAt)
+}
+
+// CreatedTime returns Created as a time.Time, or the zero time if it isn't
+// set.
+func (s *SubscriptionSchedule) CreatedTime() time.Time {
+	return unixTime(s.Created)
+}
+
+// EndDateTime returns EndDate as a time.Time, or the zero time if it isn't
+// set.
+func (s *SubscriptionScheduleCurrentPhase) EndDateTime() time.Time {
+	return unixTime(s.EndDate)
+}
+
+// StartDateTime returns StartDate as a time.Time, or the zero time if it isn't
+// set.
+func (s *SubscriptionScheduleCurrentPhase) StartDateTime() time.Time {
+	return unixTime(s.StartDate)
+}
+
+// EndDateTime returns EndDate as a time.Time, or the zero time if it isn't
+// set.
+func (s *SubscriptionSchedulePhase) EndDateTime() time.Time {
+	return unixTime(s.EndDate)
+}
+
+// StartDateTime returns StartDate as a time.Time, or the zero time if it isn't
+// set.
+func (s *SubscriptionSchedulePhase) StartDateTime() time.Time {
+	return unixTime(s.StartDate)
+}
+
+// TrialEndTime returns TrialEnd as a time.Time, or the zero time if it isn't
+// set.
+func (s *SubscriptionSchedulePhase) TrialEndTime() time.Time {
+	return unixTime(s.TrialEnd)
+}
+
+// CreatedTime returns Created as a time.Time, or the zero time if it isn't
+// set.
+func (c *TaxID) CreatedTime() time.Time {
+	return unixTime(c.Created)
+}
+
+// CreatedTime returns Created as a time.Time, or the zero time if it isn't
+// set.
+func (c *TaxRate) CreatedTime() time.Time {
+	return unixTime(c.Created)
+}
+
+// CreatedTime returns Created as a time.Time, or the zero time if it isn't
+// set.
+func (t *ThreeDSecure) CreatedTime() time.Time {
+	return unixTime(t.Created)
+}
+
+// CreatedTime returns Created as a time.Time, or the zero time if it isn't
+// set.
+func (t *Token) CreatedTime() time.Time {
+	return unixTime(t.Created)
+}
+
+// ArrivalDateTime returns ArrivalDate as a time.Time, or the zero time if it isn't
+// set.
+func (t *Topup) ArrivalDateTime() time.Time {
+	return unixTime(t.ArrivalDate)
+}
+
+// CreatedTime returns Created as a time.Time, or the zero time if it isn't
+// set.
+func (t *Topup) CreatedTime() time.Time {
+	return unixTime(t.Created)
+}
+
+// ExpectedAvailabilityDateTime returns ExpectedAvailabilityDate as a time.Time, or the zero time if it isn't
+// set.
+func (t *Topup) ExpectedAvailabilityDateTime() time.Time {
+	return unixTime(t.ExpectedAvailabilityDate)
+}
+
+// CreatedTime returns Created as a time.Time, or the zero time if it isn't
+// set.
+func (t *Transfer) CreatedTime() time.Time {
+	return unixTime(t.Created)
+}
+
+// TimestampTime returns Timestamp as a time.Time, or the zero time if it isn't
+// set.
+func (u *UsageRecord) TimestampTime() time.Time {
+	return unixTime(u.Timestamp)
+}
+
+// CreatedTime returns Created as a time.Time, or the zero time if it isn't
+// set.
+func (c *WebhookEndpoint) CreatedTime() time.Time {
+	return unixTime(c.Created)
+}
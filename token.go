@@ -1,5 +1,7 @@
 package stripe
 
+import "encoding/json"
+
 // TokenType is the list of allowed values for a token's type.
 type TokenType string
 
@@ -42,6 +44,8 @@ type Token struct {
 	Livemode bool      `json:"livemode"`
 	Type     TokenType `json:"type"`
 	Used     bool      `json:"used"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // PIIParams are parameters for personal identifiable information (PII).
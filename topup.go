@@ -1,5 +1,7 @@
 package stripe
 
+import "encoding/json"
+
 // TopupParams is the set of parameters that can be used when creating or updating a top-up.
 // For more details see https://stripe.com/docs/api#create_topup and https://stripe.com/docs/api#update_topup.
 type TopupParams struct {
@@ -52,4 +54,6 @@ type Topup struct {
 	StatementDescriptor      string              `json:"statement_descriptor"`
 	Status                   string              `json:"status"`
 	TransferGroup            string              `json:"transfer_group"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
@@ -20,6 +20,9 @@ const (
 type TransferDestination struct {
 	Account *Account `json:"-"`
 	ID      string   `json:"id"`
+
+	Extra    map[string]json.RawMessage `json:"-"`
+	expanded bool
 }
 
 // TransferParams is the set of parameters that can be used when creating or updating a transfer.
@@ -64,6 +67,9 @@ type Transfer struct {
 	SourceTransaction  *BalanceTransactionSource `json:"source_transaction"`
 	SourceType         TransferSourceType        `json:"source_type"`
 	TransferGroup      string                    `json:"transfer_group"`
+
+	Extra    map[string]json.RawMessage `json:"-"`
+	expanded bool
 }
 
 // TransferList is a list of transfers as retrieved from a list endpoint.
@@ -88,6 +94,7 @@ func (t *Transfer) UnmarshalJSON(data []byte) error {
 	}
 
 	*t = Transfer(v)
+	t.expanded = true
 	return nil
 }
 
@@ -107,5 +114,6 @@ func (d *TransferDestination) UnmarshalJSON(data []byte) error {
 	}
 
 	*d = TransferDestination(v)
+	d.expanded = true
 	return json.Unmarshal(data, &d.Account)
 }
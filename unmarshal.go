@@ -0,0 +1,392 @@
+package stripe
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+//
+// Public types
+//
+
+// StrictDecodingError is returned by UnmarshalStrict, and by backends with
+// StrictDecoding enabled, when a response contains fields that the library
+// doesn't know about or whose type doesn't match the library's.
+//
+// Like with a json.UnmarshalTypeError, the value that the response was
+// decoded into may only be partially populated when fields are mismatched.
+type StrictDecodingError struct {
+	// MismatchedFields are the paths of the fields whose JSON type doesn't
+	// match the type of the field they're decoded into, like
+	// `data[0].amount`.
+	MismatchedFields []string
+
+	// UnknownFields are the paths of the fields which don't exist in the
+	// structs they're decoded into, like `data[0].outcome.new_field`.
+	UnknownFields []string
+}
+
+// Error serializes the error object to a string.
+func (e *StrictDecodingError) Error() string {
+	var problems []string
+	if len(e.UnknownFields) > 0 {
+		problems = append(problems, "unknown fields: "+strings.Join(e.UnknownFields, ", "))
+	}
+	if len(e.MismatchedFields) > 0 {
+		problems = append(problems, "mismatched fields: "+strings.Join(e.MismatchedFields, ", "))
+	}
+	return "stripe: " + strings.Join(problems, "; ")
+}
+
+//
+// Public functions
+//
+
+// Unmarshal decodes JSON from the API into v like json.Unmarshal, and also
+// keeps the fields that the library doesn't know about in the Extra field of
+// the resources containing them, raw.
+//
+// Backends decode responses with it when KeepUnknownFields is set on their
+// BackendConfig, and it's also useful to decode the data of webhook events:
+//
+//	var charge stripe.Charge
+//	err := stripe.Unmarshal(event.Data.Raw, &charge)
+//	// charge.Extra["new_field"] is set if Stripe added new_field to charges
+func Unmarshal(data []byte, v interface{}) error {
+	if err := json.Unmarshal(data, v); err != nil {
+		return err
+	}
+
+	walkUnknownFields(data, v)
+	return nil
+}
+
+// UnmarshalStrict is like Unmarshal, but returns a *StrictDecodingError if
+// the JSON contains fields that the library doesn't know about or whose type
+// doesn't match, which reveals drift between the API and the version of the
+// library. Unlike json.Unmarshal, it reports all the mismatched fields rather
+// than only the first one.
+func UnmarshalStrict(data []byte, v interface{}) error {
+	err := json.Unmarshal(data, v)
+	typeErr, isTypeErr := err.(*json.UnmarshalTypeError)
+	if err != nil && !isTypeErr {
+		return err
+	}
+
+	decodingErr := walkUnknownFields(data, v)
+
+	// Some mismatches are only noticed by custom UnmarshalJSON methods
+	if isTypeErr && len(decodingErr.MismatchedFields) == 0 {
+		decodingErr.MismatchedFields = []string{typeErr.Field}
+	}
+
+	if len(decodingErr.MismatchedFields) == 0 && len(decodingErr.UnknownFields) == 0 {
+		return nil
+	}
+	return decodingErr
+}
+
+//
+// Private types
+//
+
+// jsonStructInfo describes how JSON objects are decoded into a struct type.
+type jsonStructInfo struct {
+	// extra is the index of the struct's Extra field, or nil if it doesn't
+	// have one.
+	extra []int
+
+	// fields are the indexes of the struct's fields keyed by their lowercased
+	// JSON names, since encoding/json matches names case insensitively.
+	fields map[string][]int
+
+	// variants are the indexes of fields that are excluded from JSON but hold
+	// a struct decoded from the same object, like the Card of a
+	// PaymentSource.
+	variants [][]int
+}
+
+// unknownFieldsWalker compares decoded values with the JSON they were decoded
+// from.
+type unknownFieldsWalker struct {
+	mismatched []string
+	unknown    []string
+}
+
+//
+// Private variables
+//
+
+var extraType = reflect.TypeOf(map[string]json.RawMessage{})
+
+var jsonStructInfos struct {
+	m  map[reflect.Type]*jsonStructInfo
+	mu sync.RWMutex
+}
+
+var jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+//
+// Private functions
+//
+
+func getJSONStructInfo(t reflect.Type) *jsonStructInfo {
+	jsonStructInfos.mu.RLock()
+	info, ok := jsonStructInfos.m[t]
+	jsonStructInfos.mu.RUnlock()
+	if ok {
+		return info
+	}
+
+	info = &jsonStructInfo{fields: make(map[string][]int)}
+	addJSONStructFields(info, t, nil)
+
+	jsonStructInfos.mu.Lock()
+	if jsonStructInfos.m == nil {
+		jsonStructInfos.m = make(map[reflect.Type]*jsonStructInfo)
+	}
+	jsonStructInfos.m[t] = info
+	jsonStructInfos.mu.Unlock()
+
+	return info
+}
+
+func addJSONStructFields(info *jsonStructInfo, t reflect.Type, index []int) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		fieldIndex := append(append([]int(nil), index...), i)
+
+		tag := field.Tag.Get("json")
+		name := strings.Split(tag, ",")[0]
+
+		// Embedded structs like ListMeta have their fields promoted
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			addJSONStructFields(info, field.Type, fieldIndex)
+			continue
+		}
+
+		if field.PkgPath != "" {
+			continue
+		}
+
+		if tag == "-" {
+			if field.Name == "Extra" && field.Type == extraType {
+				info.extra = fieldIndex
+			} else if field.Type.Kind() == reflect.Ptr && field.Type.Elem().Kind() == reflect.Struct {
+				info.variants = append(info.variants, fieldIndex)
+			}
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+		info.fields[strings.ToLower(name)] = fieldIndex
+	}
+}
+
+// walkUnknownFields sets the Extra fields of the structs that data was
+// decoded into in v, and returns the fields that were unknown or mismatched.
+func walkUnknownFields(data []byte, v interface{}) *StrictDecodingError {
+	w := &unknownFieldsWalker{}
+	w.walk(data, reflect.ValueOf(v), "")
+
+	return &StrictDecodingError{
+		MismatchedFields: uniqueStrings(w.mismatched),
+		UnknownFields:    uniqueStrings(w.unknown),
+	}
+}
+
+func (w *unknownFieldsWalker) walk(data []byte, v reflect.Value, path string) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || bytes.Equal(data, []byte("null")) {
+		return
+	}
+
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			// Nothing was decoded, which happens when a custom UnmarshalJSON
+			// method fails on a mismatched field, but its fields are still
+			// checked against a zero value
+			v = reflect.New(v.Type().Elem())
+		}
+		v = v.Elem()
+	}
+
+	// Types decoding themselves, like expandable objects returned as an ID,
+	// may accept any JSON, but objects decoded into structs are still checked
+	// field by field
+	if v.CanAddr() && v.Addr().Type().Implements(jsonUnmarshalerType) &&
+		(v.Kind() != reflect.Struct || data[0] != '{') {
+		return
+	}
+
+	var ok bool
+	switch v.Kind() {
+	case reflect.Struct:
+		ok = data[0] == '{'
+		if ok {
+			w.walkStruct(data, v, path, nil, true)
+		}
+
+	case reflect.Map:
+		ok = data[0] == '{'
+
+	case reflect.Array, reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 && data[0] == '"' {
+			// Byte slices are encoded as base64 strings
+			ok = true
+			break
+		}
+
+		ok = data[0] == '['
+		if ok {
+			var elems []json.RawMessage
+			if err := json.Unmarshal(data, &elems); err != nil {
+				return
+			}
+			for i, elem := range elems {
+				// Like above, elements that weren't decoded are checked
+				// against a zero value
+				elemValue := reflect.New(v.Type().Elem()).Elem()
+				if i < v.Len() {
+					elemValue = v.Index(i)
+				}
+				w.walk(elem, elemValue, path+"["+strconv.Itoa(i)+"]")
+			}
+		}
+
+	case reflect.String:
+		ok = data[0] == '"'
+
+	case reflect.Bool:
+		ok = data[0] == 't' || data[0] == 'f'
+
+	case reflect.Float32, reflect.Float64,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		ok = data[0] == '-' || (data[0] >= '0' && data[0] <= '9')
+
+	default:
+		ok = true
+	}
+
+	if !ok {
+		w.mismatched = append(w.mismatched, path)
+	}
+}
+
+// walkStruct compares the fields of an object with those of a struct. The
+// fields of the struct owning a variant are known to the variant as well,
+// but only the owner reports the fields unknown to both.
+func (w *unknownFieldsWalker) walkStruct(data []byte, v reflect.Value, path string, owner *jsonStructInfo, reportUnknown bool) {
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return
+	}
+
+	info := getJSONStructInfo(v.Type())
+
+	var variants []reflect.Value
+	for _, index := range info.variants {
+		if variant := v.FieldByIndex(index); !variant.IsNil() {
+			variants = append(variants, variant.Elem())
+		}
+	}
+
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var extra map[string]json.RawMessage
+	for _, key := range keys {
+		lowerKey := strings.ToLower(key)
+
+		if index, ok := info.fields[lowerKey]; ok {
+			w.walk(obj[key], v.FieldByIndex(index), joinJSONPath(path, key))
+			continue
+		}
+
+		// The type of every object is already implied by the struct it's
+		// decoded into, so not all structs have a field for it
+		if lowerKey == "object" {
+			continue
+		}
+
+		known := owner != nil && owner.fields[lowerKey] != nil
+		for _, variant := range variants {
+			known = known || getJSONStructInfo(variant.Type()).fields[lowerKey] != nil
+		}
+		if known {
+			continue
+		}
+
+		if extra == nil {
+			extra = make(map[string]json.RawMessage)
+		}
+		extra[key] = obj[key]
+
+		if reportUnknown {
+			w.unknown = append(w.unknown, joinJSONPath(path, key))
+		}
+	}
+
+	if info.extra != nil {
+		v.FieldByIndex(info.extra).Set(reflect.ValueOf(extra))
+	}
+
+	for _, variant := range variants {
+		w.walkStruct(data, variant, path, info, false)
+	}
+}
+
+func joinJSONPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// uniqueStrings sorts strings and removes duplicates from them.
+func uniqueStrings(strs []string) []string {
+	sort.Strings(strs)
+
+	var unique []string
+	for i, s := range strs {
+		if i == 0 || s != strs[i-1] {
+			unique = append(unique, s)
+		}
+	}
+	return unique
+}
+
+// unmarshalResponse decodes the body of a successful response into v,
+// reporting any drift from the API when the backend is strict. Unknown fields
+// are only walked for when the backend keeps them, since it takes a second
+// pass over the response.
+func (s *BackendImplementation) unmarshalResponse(statusCode int, body []byte, v interface{}) error {
+	if s.StrictDecoding {
+		if err := UnmarshalStrict(body, v); err != nil {
+			s.LeveledLogger.Errorf("Response from Stripe (status %v) didn't match the library: %v",
+				statusCode, err)
+			return err
+		}
+		return nil
+	}
+
+	if err := s.UnmarshalJSONVerbose(statusCode, body, v); err != nil {
+		return err
+	}
+
+	if s.KeepUnknownFields {
+		walkUnknownFields(body, v)
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package stripe
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestUnmarshal(t *testing.T) {
+	data := []byte(`{
+		"id": "ch_123",
+		"object": "charge",
+		"amount": 100,
+		"new_field": {"nested": true},
+		"customer": {"id": "cus_123", "object": "customer", "new_field": "value"},
+		"invoice": "in_123",
+		"outcome": {"type": "authorized", "new_outcome_field": 1}
+	}`)
+
+	var charge Charge
+	err := Unmarshal(data, &charge)
+	assert.NoError(t, err)
+
+	assert.Equal(t, int64(100), charge.Amount)
+	assert.Equal(t, map[string]json.RawMessage{"new_field": json.RawMessage(`{"nested": true}`)}, charge.Extra)
+	assert.Equal(t, map[string]json.RawMessage{"new_field": json.RawMessage(`"value"`)}, charge.Customer.Extra)
+	assert.Nil(t, charge.Invoice.Extra)
+
+	// Decoding again resets fields that are no longer unknown
+	err = Unmarshal([]byte(`{"id": "ch_123"}`), &charge)
+	assert.NoError(t, err)
+	assert.Nil(t, charge.Extra)
+}
+
+func TestUnmarshal_List(t *testing.T) {
+	var list ChargeList
+	err := Unmarshal([]byte(`{"object": "list", "data": [{"id": "ch_1"}, {"id": "ch_2", "new_field": 1}], "has_more": false}`), &list)
+	assert.NoError(t, err)
+
+	assert.Nil(t, list.Data[0].Extra)
+	assert.Equal(t, map[string]json.RawMessage{"new_field": json.RawMessage(`1`)}, list.Data[1].Extra)
+}
+
+func TestUnmarshal_Variants(t *testing.T) {
+	var source PaymentSource
+	err := Unmarshal([]byte(`{"id": "card_123", "object": "card", "brand": "Visa", "new_field": 1}`), &source)
+	assert.NoError(t, err)
+
+	// Fields known to the variant aren't extra
+	assert.Equal(t, CardBrandVisa, source.Card.Brand)
+	assert.Equal(t, map[string]json.RawMessage{"new_field": json.RawMessage(`1`)}, source.Extra)
+	assert.Equal(t, map[string]json.RawMessage{"new_field": json.RawMessage(`1`)}, source.Card.Extra)
+}
+
+func TestUnmarshalStrict(t *testing.T) {
+	var charge Charge
+	err := UnmarshalStrict([]byte(`{"id": "ch_123", "object": "charge", "customer": "cus_123", "amount": 100}`), &charge)
+	assert.NoError(t, err)
+
+	err = UnmarshalStrict([]byte(`{
+		"id": "ch_123",
+		"amount": "100",
+		"paid": "yes",
+		"new_field": 1,
+		"outcome": {"new_outcome_field": 1},
+		"refunds": {"data": [{"id": "re_123", "new_refund_field": 1}]}
+	}`), &charge)
+	assert.Error(t, err)
+
+	decodingErr, ok := err.(*StrictDecodingError)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"amount", "paid"}, decodingErr.MismatchedFields)
+	assert.Equal(t, []string{"new_field", "outcome.new_outcome_field", "refunds.data[0].new_refund_field"},
+		decodingErr.UnknownFields)
+	assert.Equal(t, "stripe: unknown fields: new_field, outcome.new_outcome_field, "+
+		"refunds.data[0].new_refund_field; mismatched fields: amount, paid", err.Error())
+
+	// Errors other than mismatches are returned as is
+	err = UnmarshalStrict([]byte(`{`), &charge)
+	_, ok = err.(*StrictDecodingError)
+	assert.False(t, ok)
+}
+
+func TestStrictDecoding(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id": "cus_123", "new_field": 1}`))
+	}))
+	defer testServer.Close()
+
+	for _, strict := range []bool{false, true} {
+		backend := GetBackendWithConfig(APIBackend, &BackendConfig{
+			KeepUnknownFields: !strict,
+			StrictDecoding:    strict,
+			URL:               testServer.URL,
+		})
+
+		var customer Customer
+		err := backend.Call(http.MethodGet, "/v1/customers/cus_123", "sk_test_123", nil, &customer)
+		assert.Equal(t, "cus_123", customer.ID)
+		assert.Equal(t, map[string]json.RawMessage{"new_field": json.RawMessage(`1`)}, customer.Extra)
+
+		if strict {
+			assert.Equal(t, &StrictDecodingError{UnknownFields: []string{"new_field"}}, err)
+		} else {
+			assert.NoError(t, err)
+		}
+	}
+}
+
+func TestStrictDecoding_UnknownFieldsNotKept(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id": "cus_123", "new_field": 1}`))
+	}))
+	defer testServer.Close()
+
+	// Responses are only decoded once by default
+	backend := GetBackendWithConfig(APIBackend, &BackendConfig{URL: testServer.URL})
+
+	var customer Customer
+	err := backend.Call(http.MethodGet, "/v1/customers/cus_123", "sk_test_123", nil, &customer)
+	assert.NoError(t, err)
+	assert.Equal(t, "cus_123", customer.ID)
+	assert.Nil(t, customer.Extra)
+}
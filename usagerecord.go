@@ -1,5 +1,7 @@
 package stripe
 
+import "encoding/json"
+
 // Possible values for the action parameter on usage record creation.
 const (
 	UsageRecordActionIncrement string = "increment"
@@ -14,6 +16,8 @@ type UsageRecord struct {
 	Quantity         int64  `json:"quantity"`
 	SubscriptionItem string `json:"subscription_item"`
 	Timestamp        int64  `json:"timestamp"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // UsageRecordParams create a usage record for a specified subscription item
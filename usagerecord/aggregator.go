@@ -0,0 +1,736 @@
+package usagerecord
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	stripe "github.com/stripe/stripe-go"
+	"github.com/stripe/stripe-go/usagerecordsummary"
+)
+
+//
+// Public constants
+//
+
+const (
+	// DefaultFlushInterval is how often an Aggregator flushes buffered usage
+	// unless configured otherwise.
+	DefaultFlushInterval time.Duration = time.Minute
+
+	// DefaultMaxBufferedItems is the number of subscription items with
+	// buffered usage which makes an Aggregator flush early unless configured
+	// otherwise.
+	DefaultMaxBufferedItems = 1000
+)
+
+//
+// Public variables
+//
+
+// ErrAggregatorClosed is returned when usage is added to an Aggregator after
+// it was closed.
+var ErrAggregatorClosed = errors.New("usage record aggregator is closed")
+
+//
+// Public types
+//
+
+// Aggregator buffers the usage of subscription items in memory and reports
+// it with one usage record per subscription item every time it's flushed,
+// instead of making a request for every usage event.
+//
+// Usage is flushed every FlushInterval, as soon as MaxBufferedItems
+// subscription items have buffered usage, and when the aggregator is closed.
+// Each usage record is created with an idempotency key derived from its
+// subscription item and the time it was flushed, so a record that failed is
+// retried with the same key by the next flush and can't be counted twice.
+//
+// When WALPath is set, usage is also written to a write-ahead file before
+// it's buffered, and usage that hadn't been reported when the process
+// stopped is restored from it by NewAggregator.
+type Aggregator struct {
+	config AggregatorConfig
+
+	// flushMu ensures that only one flush happens at a time.
+	flushMu sync.Mutex
+
+	// mu guards the fields below.
+	mu sync.Mutex
+
+	// batches are usage records which were taken from the buffer but haven't
+	// been created yet, in the order they were taken.
+	batches []*usageBatch
+	closed  bool
+
+	// lastWindows are the last windows that each subscription item was
+	// flushed in.
+	lastWindows map[string]int64
+
+	pending map[string]*pendingUsage
+	wal     *os.File
+
+	done     chan struct{}
+	flushNow chan struct{}
+	stopped  chan struct{}
+}
+
+// AggregatorConfig is used to configure a new Aggregator.
+type AggregatorConfig struct {
+	// Client is the client used to create usage records and list usage record
+	// summaries.
+	//
+	// If left unset, the package-level client is used.
+	Client *Client
+
+	// Clock is used to timestamp usage records.
+	//
+	// If left unset, stripe.DefaultClock is used.
+	Clock stripe.Clock
+
+	// DisableWALSync makes adding usage return as soon as it's written to the
+	// write-ahead file, without waiting for it to be synced to disk. It makes
+	// adding usage much faster, but usage added shortly before the machine
+	// crashes may be lost, although not when only the process stops.
+	//
+	// Defaults to false, in which case usage is synced to disk before being
+	// acknowledged.
+	DisableWALSync bool
+
+	// FlushInterval is how often buffered usage is flushed.
+	//
+	// Defaults to DefaultFlushInterval.
+	FlushInterval time.Duration
+
+	// MaxBufferedItems is the number of subscription items with buffered
+	// usage which makes the aggregator flush before the end of the interval.
+	//
+	// Defaults to DefaultMaxBufferedItems.
+	MaxBufferedItems int
+
+	// OnError is invoked with the errors of the flushes happening in the
+	// background.
+	//
+	// Defaults to logging them with stripe.DefaultLeveledLogger.
+	OnError func(err error)
+
+	// WALPath is the path of the write-ahead file in which usage is kept
+	// until it's reported. It's created if it doesn't exist.
+	//
+	// If left empty, buffered usage is lost if the process stops without
+	// closing the aggregator.
+	WALPath string
+}
+
+// FlushError is returned when the usage of some subscription items couldn't
+// be reported by a flush.
+type FlushError struct {
+	Failures []*FlushFailure
+}
+
+// Error returns the errors of all the failures.
+func (e *FlushError) Error() string {
+	messages := make([]string, len(e.Failures))
+	for i, failure := range e.Failures {
+		messages[i] = failure.Error()
+	}
+	return "usage records couldn't be created: " + strings.Join(messages, "; ")
+}
+
+// FlushFailure is a usage record which couldn't be created by a flush.
+type FlushFailure struct {
+	// Dropped is true when the usage was discarded because retrying the
+	// request couldn't succeed, like for an invalid request error. Otherwise
+	// it's retried by the next flush.
+	Dropped bool
+
+	// Err is the error returned when creating the usage record.
+	Err error
+
+	// Params are the parameters of the usage record.
+	Params *stripe.UsageRecordParams
+}
+
+// Error returns the error along with the usage that it concerns.
+func (f *FlushFailure) Error() string {
+	msg := fmt.Sprintf("%s %d for %s: %v", stripe.StringValue(f.Params.Action),
+		stripe.Int64Value(f.Params.Quantity), stripe.StringValue(f.Params.SubscriptionItem), f.Err)
+	if f.Dropped {
+		msg += " (dropped)"
+	}
+	return msg
+}
+
+// Reconciliation compares the usage of a subscription item that Stripe knows
+// about with the usage that an Aggregator hasn't reported yet.
+type Reconciliation struct {
+	// Pending is the usage incremented in the aggregator which hasn't been
+	// reported yet.
+	Pending int64
+
+	// Reported is the total usage of the current period according to Stripe.
+	Reported int64
+
+	// SubscriptionItem is the ID of the subscription item.
+	SubscriptionItem string
+
+	// Summary is the usage record summary of the current period, or nil if
+	// there's none yet.
+	Summary *stripe.UsageRecordSummary
+}
+
+// NewAggregator returns a new aggregator, restoring the usage that wasn't
+// reported from its write-ahead file if it has one, and starts flushing it in
+// the background. It must be closed with Close to report the last of the
+// usage.
+func NewAggregator(config *AggregatorConfig) (*Aggregator, error) {
+	a := &Aggregator{
+		config:      *config,
+		done:        make(chan struct{}),
+		flushNow:    make(chan struct{}, 1),
+		lastWindows: make(map[string]int64),
+		pending:     make(map[string]*pendingUsage),
+		stopped:     make(chan struct{}),
+	}
+
+	if a.config.Client == nil {
+		client := getC()
+		a.config.Client = &client
+	}
+	if a.config.Clock == nil {
+		a.config.Clock = stripe.DefaultClock
+	}
+	if a.config.FlushInterval <= 0 {
+		a.config.FlushInterval = DefaultFlushInterval
+	}
+	if a.config.MaxBufferedItems <= 0 {
+		a.config.MaxBufferedItems = DefaultMaxBufferedItems
+	}
+
+	if a.config.WALPath != "" {
+		if err := a.replay(); err != nil {
+			return nil, err
+		}
+		if err := a.compact(); err != nil {
+			return nil, err
+		}
+	}
+
+	go a.run()
+	return a, nil
+}
+
+// Close stops the background flushes, flushes the remaining usage and closes
+// the write-ahead file. Usage which couldn't be reported is kept in the file
+// for the next aggregator using it.
+func (a *Aggregator) Close() error {
+	a.mu.Lock()
+	if a.closed {
+		a.mu.Unlock()
+		return nil
+	}
+	a.closed = true
+	a.mu.Unlock()
+
+	close(a.done)
+	<-a.stopped
+
+	err := a.flush()
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.wal != nil {
+		if closeErr := a.wal.Close(); err == nil {
+			err = closeErr
+		}
+		a.wal = nil
+	}
+	return err
+}
+
+// Flush reports the buffered usage, as well as the usage that previous
+// flushes failed to report, with one usage record per subscription item. It
+// returns a *FlushError if some of it couldn't be reported.
+func (a *Aggregator) Flush() error {
+	a.mu.Lock()
+	closed := a.closed
+	a.mu.Unlock()
+	if closed {
+		return ErrAggregatorClosed
+	}
+
+	return a.flush()
+}
+
+// Increment adds to the usage of a subscription item.
+func (a *Aggregator) Increment(subscriptionItem string, quantity int64) error {
+	if quantity < 0 {
+		return fmt.Errorf("cannot increment usage by negative quantity %d", quantity)
+	}
+	return a.add(&walRecord{
+		Op:               stripe.UsageRecordActionIncrement,
+		Quantity:         quantity,
+		SubscriptionItem: subscriptionItem,
+	})
+}
+
+// Reconcile compares the usage of the current period of a subscription item
+// according to its usage record summaries with the usage that the aggregator
+// hasn't reported yet. See Reconciliation.Missing.
+func (a *Aggregator) Reconcile(subscriptionItem string) (*Reconciliation, error) {
+	summaries := usagerecordsummary.Client{B: a.config.Client.B, Key: a.config.Client.Key}
+
+	params := &stripe.UsageRecordSummaryListParams{
+		SubscriptionItem: stripe.String(subscriptionItem),
+	}
+	params.Limit = stripe.Int64(1)
+	params.Single = true
+
+	r := &Reconciliation{SubscriptionItem: subscriptionItem}
+
+	// Summaries are listed from the most recent period
+	iter := summaries.List(params)
+	if iter.Next() {
+		r.Summary = iter.UsageRecordSummary()
+		r.Reported = r.Summary.TotalUsage
+	}
+	if err := iter.Err(); err != nil {
+		return nil, err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for _, batch := range a.batches {
+		if batch.SubscriptionItem == subscriptionItem && batch.Action == stripe.UsageRecordActionIncrement {
+			r.Pending += batch.Quantity
+		}
+	}
+	if usage, ok := a.pending[subscriptionItem]; ok && usage.action == stripe.UsageRecordActionIncrement {
+		r.Pending += usage.quantity
+	}
+
+	return r, nil
+}
+
+// Set sets the usage of a subscription item at the time of the next flush,
+// replacing the usage buffered so far. Usage incremented after it's set is
+// added to it.
+func (a *Aggregator) Set(subscriptionItem string, quantity int64) error {
+	if quantity < 0 {
+		return fmt.Errorf("cannot set usage to negative quantity %d", quantity)
+	}
+	return a.add(&walRecord{
+		Op:               stripe.UsageRecordActionSet,
+		Quantity:         quantity,
+		SubscriptionItem: subscriptionItem,
+	})
+}
+
+// Missing returns how much of the expected usage of the current period
+// hasn't been reported to Stripe nor buffered, which can be made up for by
+// incrementing the usage by it. A negative value means that more usage than
+// expected was reported.
+//
+// This only holds for usage which is incremented and aggregated by summing
+// it, since the total usage of a period doesn't otherwise reflect the usage
+// reported.
+func (r *Reconciliation) Missing(expected int64) int64 {
+	return expected - r.Reported - r.Pending
+}
+
+//
+// Private constants
+//
+
+// Operations recorded in the write-ahead file, along with the actions of
+// usage records for buffered usage.
+const (
+	walOpDone = "done"
+	walOpSeal = "seal"
+)
+
+//
+// Private types
+//
+
+// pendingUsage is the usage of a subscription item buffered since the last
+// flush.
+type pendingUsage struct {
+	action   string
+	quantity int64
+}
+
+// usageBatch is a usage record taken from the buffer to be created.
+type usageBatch struct {
+	Action           string `json:"action"`
+	Key              string `json:"key"`
+	Quantity         int64  `json:"quantity"`
+	SubscriptionItem string `json:"subscription_item"`
+	Timestamp        int64  `json:"timestamp"`
+	Window           int64  `json:"window"`
+}
+
+// walRecord is a line of the write-ahead file.
+type walRecord struct {
+	Op string `json:"op"`
+
+	Key              string `json:"key,omitempty"`
+	Quantity         int64  `json:"quantity,omitempty"`
+	SubscriptionItem string `json:"subscription_item,omitempty"`
+
+	// Batch is set for the records sealing buffered usage.
+	Batch *usageBatch `json:"batch,omitempty"`
+}
+
+//
+// Private functions
+//
+
+// add buffers the usage of an increment or set record.
+func (a *Aggregator) add(record *walRecord) error {
+	if record.SubscriptionItem == "" {
+		return errors.New("cannot add usage without a subscription item")
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.closed {
+		return ErrAggregatorClosed
+	}
+
+	if err := a.writeWAL(record); err != nil {
+		return err
+	}
+	if a.wal != nil && !a.config.DisableWALSync {
+		if err := a.wal.Sync(); err != nil {
+			return err
+		}
+	}
+	a.apply(record)
+
+	if len(a.pending) >= a.config.MaxBufferedItems {
+		select {
+		case a.flushNow <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+// apply applies a record of the write-ahead file to the state of the
+// aggregator.
+func (a *Aggregator) apply(record *walRecord) {
+	switch record.Op {
+	case stripe.UsageRecordActionIncrement:
+		usage, ok := a.pending[record.SubscriptionItem]
+		if !ok {
+			usage = &pendingUsage{action: stripe.UsageRecordActionIncrement}
+			a.pending[record.SubscriptionItem] = usage
+		}
+		usage.quantity += record.Quantity
+
+	case stripe.UsageRecordActionSet:
+		a.pending[record.SubscriptionItem] = &pendingUsage{
+			action:   stripe.UsageRecordActionSet,
+			quantity: record.Quantity,
+		}
+
+	case walOpSeal:
+		batch := record.Batch
+		delete(a.pending, batch.SubscriptionItem)
+		a.batches = append(a.batches, batch)
+		if batch.Window > a.lastWindows[batch.SubscriptionItem] {
+			a.lastWindows[batch.SubscriptionItem] = batch.Window
+		}
+
+	case walOpDone:
+		for _, batch := range a.batches {
+			if batch.Key == record.Key {
+				a.removeBatch(batch)
+				break
+			}
+		}
+	}
+}
+
+// compact rewrites the write-ahead file with only the records needed to
+// restore the current state, replacing the old file atomically. It must be
+// called with mu held.
+func (a *Aggregator) compact() error {
+	if a.config.WALPath == "" {
+		return nil
+	}
+
+	tmpPath := a.config.WALPath + ".tmp"
+	f, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+
+	w := bufio.NewWriter(f)
+	encoder := json.NewEncoder(w)
+	for _, batch := range a.batches {
+		if err := encoder.Encode(&walRecord{Op: walOpSeal, Batch: batch}); err != nil {
+			f.Close()
+			return err
+		}
+	}
+	for _, item := range a.pendingItems() {
+		usage := a.pending[item]
+		record := &walRecord{Op: usage.action, Quantity: usage.quantity, SubscriptionItem: item}
+		if err := encoder.Encode(record); err != nil {
+			f.Close()
+			return err
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpPath, a.config.WALPath); err != nil {
+		return err
+	}
+
+	if a.wal != nil {
+		a.wal.Close()
+	}
+	a.wal, err = os.OpenFile(a.config.WALPath, os.O_APPEND|os.O_WRONLY, 0600)
+	return err
+}
+
+// flush reports buffered usage, which Close does after the aggregator is
+// closed.
+func (a *Aggregator) flush() error {
+	a.flushMu.Lock()
+	defer a.flushMu.Unlock()
+
+	a.mu.Lock()
+	err := a.seal()
+	batches := append([]*usageBatch(nil), a.batches...)
+	a.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	var failures []*FlushFailure
+	failedItems := make(map[string]bool)
+
+	for _, batch := range batches {
+		// Records of a subscription item are created in order, so that a set
+		// isn't overtaken by the increments that followed it
+		if failedItems[batch.SubscriptionItem] {
+			continue
+		}
+
+		params := batch.params()
+		_, err := a.config.Client.New(params)
+
+		dropped := err != nil && !isRetryable(err)
+		if err != nil {
+			failures = append(failures, &FlushFailure{Dropped: dropped, Err: err, Params: params})
+			if !dropped {
+				failedItems[batch.SubscriptionItem] = true
+				continue
+			}
+		}
+
+		a.mu.Lock()
+		a.removeBatch(batch)
+		walErr := a.writeWAL(&walRecord{Op: walOpDone, Key: batch.Key})
+		a.mu.Unlock()
+		if walErr != nil {
+			return walErr
+		}
+	}
+
+	a.mu.Lock()
+	err = a.compact()
+	a.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if len(failures) > 0 {
+		return &FlushError{Failures: failures}
+	}
+	return nil
+}
+
+func (a *Aggregator) onError(err error) {
+	if a.config.OnError != nil {
+		a.config.OnError(err)
+		return
+	}
+	if stripe.DefaultLeveledLogger != nil {
+		stripe.DefaultLeveledLogger.Errorf("Error flushing usage records: %v", err)
+	}
+}
+
+// pendingItems returns the subscription items with buffered usage in order.
+func (a *Aggregator) pendingItems() []string {
+	items := make([]string, 0, len(a.pending))
+	for item := range a.pending {
+		items = append(items, item)
+	}
+	sort.Strings(items)
+	return items
+}
+
+func (a *Aggregator) removeBatch(batch *usageBatch) {
+	for i, b := range a.batches {
+		if b == batch {
+			a.batches = append(a.batches[:i], a.batches[i+1:]...)
+			return
+		}
+	}
+}
+
+// replay restores the state of the aggregator from its write-ahead file.
+func (a *Aggregator) replay() error {
+	f, err := os.Open(a.config.WALPath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	reader := bufio.NewReader(f)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			// A last line without a newline was cut short by a crash while it
+			// was written, so the usage it recorded was never acknowledged
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		var record walRecord
+		if err := json.Unmarshal(line, &record); err != nil {
+			return fmt.Errorf("cannot read usage record write-ahead file %s: %v",
+				a.config.WALPath, err)
+		}
+		if record.Op == walOpSeal && record.Batch == nil {
+			return fmt.Errorf("cannot read usage record write-ahead file %s: seal without batch",
+				a.config.WALPath)
+		}
+		a.apply(&record)
+	}
+}
+
+// run flushes usage in the background until the aggregator is closed.
+func (a *Aggregator) run() {
+	defer close(a.stopped)
+
+	ticker := time.NewTicker(a.config.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-a.done:
+			return
+		case <-ticker.C:
+		case <-a.flushNow:
+		}
+
+		if err := a.Flush(); err != nil {
+			a.onError(err)
+		}
+	}
+}
+
+// seal takes the buffered usage of every subscription item as a usage record
+// to create. It must be called with mu held.
+func (a *Aggregator) seal() error {
+	now := a.config.Clock.Now()
+
+	for _, item := range a.pendingItems() {
+		usage := a.pending[item]
+
+		// The window of a record identifies it among the records of its
+		// subscription item, so it must never be reused even if the clock
+		// doesn't move forward
+		window := now.UnixNano()
+		if last := a.lastWindows[item]; window <= last {
+			window = last + 1
+		}
+
+		batch := &usageBatch{
+			Action:           usage.action,
+			Key:              stripe.DeterministicIdempotencyKey("usage-"+item, window),
+			Quantity:         usage.quantity,
+			SubscriptionItem: item,
+			Timestamp:        now.Unix(),
+			Window:           window,
+		}
+		record := &walRecord{Op: walOpSeal, Batch: batch}
+		if err := a.writeWAL(record); err != nil {
+			return err
+		}
+		a.apply(record)
+	}
+
+	if a.wal != nil {
+		return a.wal.Sync()
+	}
+	return nil
+}
+
+// writeWAL appends a record to the write-ahead file. It must be called with
+// mu held.
+func (a *Aggregator) writeWAL(record *walRecord) error {
+	if a.wal == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	_, err = a.wal.Write(append(data, '\n'))
+	return err
+}
+
+func (b *usageBatch) params() *stripe.UsageRecordParams {
+	params := &stripe.UsageRecordParams{
+		Action:           stripe.String(b.Action),
+		Quantity:         stripe.Int64(b.Quantity),
+		SubscriptionItem: stripe.String(b.SubscriptionItem),
+		Timestamp:        stripe.Int64(b.Timestamp),
+	}
+	params.SetIdempotencyKey(b.Key)
+	return params
+}
+
+// isRetryable returns whether creating a usage record could succeed if the
+// request that returned the given error is retried.
+func isRetryable(err error) bool {
+	stripeErr, ok := err.(*stripe.Error)
+	if !ok {
+		return true
+	}
+	return stripeErr.Type != stripe.ErrorTypeInvalidRequest ||
+		stripeErr.HTTPStatusCode == http.StatusConflict ||
+		stripeErr.HTTPStatusCode == http.StatusTooManyRequests
+}
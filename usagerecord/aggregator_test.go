@@ -0,0 +1,252 @@
+package usagerecord
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	assert "github.com/stretchr/testify/require"
+	stripe "github.com/stripe/stripe-go"
+	"github.com/stripe/stripe-go/form"
+	stripetesting "github.com/stripe/stripe-go/testing"
+)
+
+// usageBackend is a Backend recording the usage records created through it,
+// which fails with err while it's set.
+type usageBackend struct {
+	stripetesting.Backend
+
+	mu      sync.Mutex
+	created []*stripe.UsageRecordParams
+	err     error
+	summary *stripe.UsageRecordSummary
+
+	calls chan struct{}
+}
+
+func (b *usageBackend) Call(method, path, key string, params stripe.ParamsContainer, v interface{}) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.calls != nil {
+		defer func() { b.calls <- struct{}{} }()
+	}
+	if b.err != nil {
+		return b.err
+	}
+	b.created = append(b.created, params.(*stripe.UsageRecordParams))
+	return nil
+}
+
+func (b *usageBackend) CallRaw(method, path, key string, body *form.Values, params *stripe.Params, v interface{}) error {
+	list := v.(*stripe.UsageRecordSummaryList)
+	if b.summary != nil {
+		list.Data = []*stripe.UsageRecordSummary{b.summary}
+	}
+	return nil
+}
+
+func (b *usageBackend) records() []*stripe.UsageRecordParams {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]*stripe.UsageRecordParams(nil), b.created...)
+}
+
+func (b *usageBackend) setErr(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.err = err
+}
+
+func newTestAggregator(t *testing.T, backend *usageBackend, walPath string) *Aggregator {
+	a, err := NewAggregator(&AggregatorConfig{
+		Client:        &Client{B: backend, Key: "sk_test_123"},
+		Clock:         stripetesting.NewClock(time.Unix(1500000000, 0)),
+		FlushInterval: time.Hour,
+		WALPath:       walPath,
+	})
+	assert.NoError(t, err)
+	return a
+}
+
+func TestAggregator_Flush(t *testing.T) {
+	backend := &usageBackend{}
+	a := newTestAggregator(t, backend, "")
+	defer a.Close()
+
+	assert.NoError(t, a.Increment("si_1", 3))
+	assert.NoError(t, a.Increment("si_1", 4))
+	assert.NoError(t, a.Increment("si_2", 1))
+	assert.NoError(t, a.Set("si_2", 5))
+	assert.NoError(t, a.Increment("si_2", 2))
+	assert.Error(t, a.Increment("si_1", -1))
+	assert.Error(t, a.Increment("", 1))
+
+	assert.NoError(t, a.Flush())
+
+	records := backend.records()
+	assert.Equal(t, 2, len(records))
+
+	assert.Equal(t, "si_1", stripe.StringValue(records[0].SubscriptionItem))
+	assert.Equal(t, stripe.UsageRecordActionIncrement, stripe.StringValue(records[0].Action))
+	assert.Equal(t, int64(7), stripe.Int64Value(records[0].Quantity))
+	assert.Equal(t, int64(1500000000), stripe.Int64Value(records[0].Timestamp))
+	assert.Equal(t, "usage-si_1-1500000000000000000", stripe.StringValue(records[0].IdempotencyKey))
+
+	assert.Equal(t, "si_2", stripe.StringValue(records[1].SubscriptionItem))
+	assert.Equal(t, stripe.UsageRecordActionSet, stripe.StringValue(records[1].Action))
+	assert.Equal(t, int64(7), stripe.Int64Value(records[1].Quantity))
+
+	// Nothing is left to flush, and the next window of an item gets a new key
+	// even if the clock hasn't moved
+	assert.NoError(t, a.Flush())
+	assert.Equal(t, 2, len(backend.records()))
+
+	assert.NoError(t, a.Increment("si_1", 1))
+	assert.NoError(t, a.Flush())
+	records = backend.records()
+	assert.Equal(t, "usage-si_1-1500000000000000001", stripe.StringValue(records[2].IdempotencyKey))
+}
+
+func TestAggregator_FlushRetry(t *testing.T) {
+	backend := &usageBackend{err: errors.New("connection reset")}
+	a := newTestAggregator(t, backend, "")
+	defer a.Close()
+
+	assert.NoError(t, a.Increment("si_1", 3))
+	err := a.Flush()
+	assert.Error(t, err)
+
+	flushErr := err.(*FlushError)
+	assert.Equal(t, 1, len(flushErr.Failures))
+	assert.False(t, flushErr.Failures[0].Dropped)
+
+	// Usage added after a failure goes in a record of its own, created after
+	// the one that's retried with the same key
+	assert.NoError(t, a.Increment("si_1", 2))
+	backend.setErr(nil)
+	assert.NoError(t, a.Flush())
+
+	records := backend.records()
+	assert.Equal(t, 2, len(records))
+	assert.Equal(t, "usage-si_1-1500000000000000000", stripe.StringValue(records[0].IdempotencyKey))
+	assert.Equal(t, int64(3), stripe.Int64Value(records[0].Quantity))
+	assert.Equal(t, "usage-si_1-1500000000000000001", stripe.StringValue(records[1].IdempotencyKey))
+	assert.Equal(t, int64(2), stripe.Int64Value(records[1].Quantity))
+}
+
+func TestAggregator_FlushDropped(t *testing.T) {
+	backend := &usageBackend{err: &stripe.Error{
+		HTTPStatusCode: 404,
+		Msg:            "No such subscription item",
+		Type:           stripe.ErrorTypeInvalidRequest,
+	}}
+	a := newTestAggregator(t, backend, "")
+	defer a.Close()
+
+	assert.NoError(t, a.Increment("si_1", 3))
+	err := a.Flush()
+	assert.Error(t, err)
+	assert.True(t, err.(*FlushError).Failures[0].Dropped)
+
+	backend.setErr(nil)
+	assert.NoError(t, a.Flush())
+	assert.Equal(t, 0, len(backend.records()))
+}
+
+func TestAggregator_MaxBufferedItems(t *testing.T) {
+	backend := &usageBackend{calls: make(chan struct{}, 2)}
+	a, err := NewAggregator(&AggregatorConfig{
+		Client:           &Client{B: backend, Key: "sk_test_123"},
+		FlushInterval:    time.Hour,
+		MaxBufferedItems: 2,
+	})
+	assert.NoError(t, err)
+	defer a.Close()
+
+	assert.NoError(t, a.Increment("si_1", 1))
+	assert.NoError(t, a.Increment("si_2", 1))
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-backend.calls:
+		case <-time.After(5 * time.Second):
+			t.Fatal("usage wasn't flushed")
+		}
+	}
+	assert.Equal(t, 2, len(backend.records()))
+}
+
+func TestAggregator_WAL(t *testing.T) {
+	dir, err := ioutil.TempDir("", "usagerecord")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	walPath := filepath.Join(dir, "usage.wal")
+
+	// Usage that was sealed but failed, and usage that was only buffered,
+	// are both restored after a crash
+	backend := &usageBackend{err: errors.New("connection reset")}
+	crashed := newTestAggregator(t, backend, walPath)
+	assert.NoError(t, crashed.Increment("si_1", 3))
+	assert.Error(t, crashed.Flush())
+	assert.NoError(t, crashed.Increment("si_1", 2))
+	assert.NoError(t, crashed.Set("si_2", 10))
+
+	backend = &usageBackend{}
+	a := newTestAggregator(t, backend, walPath)
+	assert.NoError(t, a.Close())
+
+	records := backend.records()
+	assert.Equal(t, 3, len(records))
+	assert.Equal(t, "usage-si_1-1500000000000000000", stripe.StringValue(records[0].IdempotencyKey))
+	assert.Equal(t, int64(3), stripe.Int64Value(records[0].Quantity))
+	assert.Equal(t, "usage-si_1-1500000000000000001", stripe.StringValue(records[1].IdempotencyKey))
+	assert.Equal(t, int64(2), stripe.Int64Value(records[1].Quantity))
+	assert.Equal(t, stripe.UsageRecordActionSet, stripe.StringValue(records[2].Action))
+	assert.Equal(t, int64(10), stripe.Int64Value(records[2].Quantity))
+
+	// Nothing is left once everything was reported
+	data, err := ioutil.ReadFile(walPath)
+	assert.NoError(t, err)
+	assert.Equal(t, "", string(data))
+
+	// A line cut short by a crash is ignored
+	err = ioutil.WriteFile(walPath,
+		[]byte(`{"op":"increment","quantity":1,"subscription_item":"si_1"}`+"\n"+`{"op":"incr`), 0600)
+	assert.NoError(t, err)
+
+	backend = &usageBackend{}
+	a = newTestAggregator(t, backend, walPath)
+	assert.NoError(t, a.Close())
+	assert.Equal(t, 1, len(backend.records()))
+	assert.Equal(t, int64(1), stripe.Int64Value(backend.records()[0].Quantity))
+}
+
+func TestAggregator_Reconcile(t *testing.T) {
+	backend := &usageBackend{summary: &stripe.UsageRecordSummary{
+		SubscriptionItem: "si_1",
+		TotalUsage:       10,
+	}}
+	a := newTestAggregator(t, backend, "")
+	defer a.Close()
+
+	assert.NoError(t, a.Increment("si_1", 3))
+
+	r, err := a.Reconcile("si_1")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10), r.Reported)
+	assert.Equal(t, int64(3), r.Pending)
+	assert.Equal(t, int64(7), r.Missing(20))
+}
+
+func TestAggregator_Closed(t *testing.T) {
+	a := newTestAggregator(t, &usageBackend{}, "")
+	assert.NoError(t, a.Close())
+	assert.NoError(t, a.Close())
+	assert.Equal(t, ErrAggregatorClosed, a.Increment("si_1", 1))
+	assert.Equal(t, ErrAggregatorClosed, a.Flush())
+}
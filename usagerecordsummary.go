@@ -1,5 +1,7 @@
 package stripe
 
+import "encoding/json"
+
 // UsageRecordSummary represents a usage record summary.
 // See https://stripe.com/docs/api#usage_records
 type UsageRecordSummary struct {
@@ -10,6 +12,8 @@ type UsageRecordSummary struct {
 	Period           *Period `json:"period"`
 	SubscriptionItem string  `json:"subscription_item"`
 	TotalUsage       int64   `json:"total_usage"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // UsageRecordSummaryListParams is the set of parameters that can be used when listing charges.
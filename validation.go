@@ -0,0 +1,552 @@
+package stripe
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/stripe/stripe-go/form"
+)
+
+//
+// Public constants
+//
+
+// Limits on the metadata that can be set on an object, which are checked by
+// ParamsValidator.
+const (
+	MetadataMaxKeyLength   int = 40
+	MetadataMaxKeys        int = 50
+	MetadataMaxValueLength int = 500
+)
+
+//
+// Public variables
+//
+
+// DefaultParamsRules are the rules that a ParamsValidator checks parameters
+// against unless it's configured with its own. They're meant to catch the most
+// common mistakes on the most commonly used endpoints rather than to be an
+// exhaustive copy of the API's own validation.
+var DefaultParamsRules = []*EndpointRules{
+	{Method: http.MethodGet, Params: []*ParamRule{
+		{Param: "limit", Min: Float64(1), Max: Float64(100)},
+	}},
+
+	{Method: http.MethodPost, Path: "/v1/charges", Params: []*ParamRule{
+		{Param: "amount", Required: true, Min: Float64(1)},
+		{Param: "application_fee_amount", Min: Float64(0)},
+		{Param: "currency", Required: true},
+		{Param: "shipping[address]", Required: true},
+		{Param: "shipping[address][line1]", Required: true},
+		{Param: "shipping[name]", Required: true},
+	}},
+	{Method: http.MethodPost, Path: "/v1/charges/*/capture", Params: []*ParamRule{
+		{Param: "amount", Min: Float64(1)},
+		{Param: "application_fee_amount", Min: Float64(0)},
+	}},
+
+	{Method: http.MethodPost, Path: "/v1/coupons", Params: []*ParamRule{
+		{Param: "amount_off", Min: Float64(1)},
+		{Param: "duration", Required: true, Enum: "CouponDuration"},
+		{Param: "duration_in_months", Min: Float64(1)},
+		{Param: "max_redemptions", Min: Float64(1)},
+		{Param: "percent_off", Min: Float64(0), Max: Float64(100)},
+	}},
+
+	{Method: http.MethodPost, Path: "/v1/customers", Params: []*ParamRule{
+		{Param: "tax_exempt", Enum: "CustomerTaxExempt"},
+	}},
+	{Method: http.MethodPost, Path: "/v1/customers/*", Params: []*ParamRule{
+		{Param: "tax_exempt", Enum: "CustomerTaxExempt"},
+	}},
+
+	{Method: http.MethodPost, Path: "/v1/invoices", Params: []*ParamRule{
+		{Param: "collection_method", Enum: "InvoiceCollectionMethod"},
+		{Param: "customer", Required: true},
+		{Param: "days_until_due", Min: Float64(0)},
+	}},
+	{Method: http.MethodGet, Path: "/v1/invoices", Params: []*ParamRule{
+		{Param: "collection_method", Enum: "InvoiceCollectionMethod"},
+		{Param: "status", Enum: "InvoiceStatus"},
+	}},
+	{Method: http.MethodGet, Path: "/v1/invoices/upcoming", Params: []*ParamRule{
+		{Param: "subscription_proration_behavior", Enum: "SubscriptionProrationBehavior"},
+	}},
+
+	{Method: http.MethodPost, Path: "/v1/payment_intents", Params: []*ParamRule{
+		{Param: "amount", Required: true, Min: Float64(1)},
+		{Param: "application_fee_amount", Min: Float64(0)},
+		{Param: "capture_method", Enum: "PaymentIntentCaptureMethod"},
+		{Param: "confirmation_method", Enum: "PaymentIntentConfirmationMethod"},
+		{Param: "currency", Required: true},
+		{Param: "setup_future_usage", Enum: "PaymentIntentSetupFutureUsage"},
+	}},
+	{Method: http.MethodPost, Path: "/v1/payment_intents/*", Params: []*ParamRule{
+		{Param: "amount", Min: Float64(1)},
+		{Param: "application_fee_amount", Min: Float64(0)},
+		{Param: "setup_future_usage", Enum: "PaymentIntentSetupFutureUsage"},
+	}},
+	{Method: http.MethodPost, Path: "/v1/payment_intents/*/cancel", Params: []*ParamRule{
+		{Param: "cancellation_reason", Enum: "PaymentIntentCancellationReason"},
+	}},
+
+	{Method: http.MethodPost, Path: "/v1/payouts", Params: []*ParamRule{
+		{Param: "amount", Required: true, Min: Float64(1)},
+		{Param: "currency", Required: true},
+		{Param: "method", Enum: "PayoutMethodType"},
+		{Param: "source_type", Enum: "PayoutSourceType"},
+	}},
+
+	{Method: http.MethodPost, Path: "/v1/plans", Params: []*ParamRule{
+		{Param: "aggregate_usage", Enum: "PlanAggregateUsage"},
+		{Param: "amount", Min: Float64(0)},
+		{Param: "billing_scheme", Enum: "PlanBillingScheme"},
+		{Param: "currency", Required: true},
+		{Param: "interval", Required: true, Enum: "PlanInterval"},
+		{Param: "interval_count", Min: Float64(1)},
+		{Param: "tiers[*][unit_amount]", Min: Float64(0)},
+		{Param: "tiers_mode", Enum: "PlanTiersMode"},
+		{Param: "trial_period_days", Min: Float64(0)},
+		{Param: "usage_type", Enum: "PlanUsageType"},
+	}},
+
+	{Method: http.MethodPost, Path: "/v1/products", Params: []*ParamRule{
+		{Param: "name", Required: true},
+		{Param: "type", Enum: "ProductType"},
+	}},
+
+	{Method: http.MethodPost, Path: "/v1/refunds", Params: []*ParamRule{
+		{Param: "amount", Min: Float64(1)},
+		{Param: "reason", Enum: "RefundReason"},
+	}},
+
+	{Method: http.MethodPost, Path: "/v1/subscription_items", Params: []*ParamRule{
+		{Param: "payment_behavior", Enum: "SubscriptionPaymentBehavior"},
+		{Param: "proration_behavior", Enum: "SubscriptionProrationBehavior"},
+		{Param: "quantity", Min: Float64(0)},
+		{Param: "subscription", Required: true},
+	}},
+	{Method: http.MethodPost, Path: "/v1/subscription_items/*", Params: []*ParamRule{
+		{Param: "payment_behavior", Enum: "SubscriptionPaymentBehavior"},
+		{Param: "proration_behavior", Enum: "SubscriptionProrationBehavior"},
+		{Param: "quantity", Min: Float64(0)},
+	}},
+
+	{Method: http.MethodPost, Path: "/v1/subscriptions", Params: []*ParamRule{
+		{Param: "application_fee_percent", Min: Float64(0), Max: Float64(100)},
+		{Param: "collection_method", Enum: "SubscriptionCollectionMethod"},
+		{Param: "customer", Required: true},
+		{Param: "days_until_due", Min: Float64(0)},
+		{Param: "items[*][quantity]", Min: Float64(0)},
+		{Param: "payment_behavior", Enum: "SubscriptionPaymentBehavior"},
+		{Param: "proration_behavior", Enum: "SubscriptionProrationBehavior"},
+		{Param: "tax_percent", Min: Float64(0), Max: Float64(100)},
+		{Param: "trial_period_days", Min: Float64(0)},
+	}},
+	{Method: http.MethodPost, Path: "/v1/subscriptions/*", Params: []*ParamRule{
+		{Param: "application_fee_percent", Min: Float64(0), Max: Float64(100)},
+		{Param: "collection_method", Enum: "SubscriptionCollectionMethod"},
+		{Param: "days_until_due", Min: Float64(0)},
+		{Param: "items[*][quantity]", Min: Float64(0)},
+		{Param: "payment_behavior", Enum: "SubscriptionPaymentBehavior"},
+		{Param: "proration_behavior", Enum: "SubscriptionProrationBehavior"},
+		{Param: "tax_percent", Min: Float64(0), Max: Float64(100)},
+	}},
+	{Method: http.MethodGet, Path: "/v1/subscriptions", Params: []*ParamRule{
+		{Param: "collection_method", Enum: "SubscriptionCollectionMethod"},
+		{Param: "status", Enum: "SubscriptionStatus"},
+	}},
+
+	{Method: http.MethodPost, Path: "/v1/tax_rates", Params: []*ParamRule{
+		{Param: "display_name", Required: true},
+		{Param: "inclusive", Required: true},
+		{Param: "percentage", Required: true, Min: Float64(0), Max: Float64(100)},
+	}},
+
+	{Method: http.MethodPost, Path: "/v1/transfers", Params: []*ParamRule{
+		{Param: "amount", Min: Float64(1)},
+		{Param: "currency", Required: true},
+		{Param: "destination", Required: true},
+	}},
+}
+
+//
+// Public types
+//
+
+// EndpointRules are the validation rules for the parameters of an endpoint.
+type EndpointRules struct {
+	// Method is the HTTP method of the endpoint.
+	Method string
+
+	// Params are the rules for the endpoint's parameters.
+	Params []*ParamRule
+
+	// Path is the path of the endpoint, in which `*` matches any single path
+	// segment (like an object's ID). An empty path matches all endpoints with
+	// the given method.
+	Path string
+}
+
+// ParamRule describes the constraints on a single parameter.
+type ParamRule struct {
+	// Enum is the name of a string type in this package, like
+	// `SubscriptionProrationBehavior`, whose constants are the only values
+	// that the parameter may take.
+	Enum string
+
+	// Max is the maximum value of a numeric parameter.
+	Max *float64
+
+	// Min is the minimum value of a numeric parameter.
+	Min *float64
+
+	// Param is the name of the parameter as it's form encoded (see
+	// form.FormatKey), like `shipping[name]`. A `*` in place of one of its
+	// parts matches any array index or map key, like in `items[*][quantity]`.
+	Param string
+
+	// Required indicates that the parameter must be present. A nested
+	// parameter is only required when its parent is present.
+	Required bool
+}
+
+// ParamsValidator checks parameters against a set of rules before they're
+// sent to the API, so that a request that would be rejected by the API fails
+// without a network round trip. Its errors are shaped like the ones returned
+// by the API for the same problems: a *Error of type ErrorTypeInvalidRequest
+// with Param set to the form encoded name of the invalid parameter.
+//
+// Besides the rules, metadata is always checked against the API's limits on
+// its number of keys (MetadataMaxKeys) and the length of its keys
+// (MetadataMaxKeyLength) and values (MetadataMaxValueLength).
+//
+// Validation is opt-in and is usually plugged in with ValidatingBackend.
+type ParamsValidator struct {
+	// Rules are the rules that parameters are checked against. All rules of
+	// all endpoints matching a request are checked.
+	//
+	// Defaults to DefaultParamsRules.
+	Rules []*EndpointRules
+}
+
+// Validate returns an error if the given parameters break any of the rules
+// for the endpoint at the given method and path.
+func (v *ParamsValidator) Validate(method, path string, params ParamsContainer) error {
+	body := &form.Values{}
+
+	// See the comment on BackendImplementation.Call for why reflect is
+	// needed to check for nil.
+	if params != nil {
+		reflectValue := reflect.ValueOf(params)
+
+		if reflectValue.Kind() == reflect.Ptr && !reflectValue.IsNil() {
+			form.AppendTo(body, params)
+		}
+	}
+
+	return v.ValidateValues(method, path, body)
+}
+
+// ValidateValues is the same as Validate, but for parameters that have
+// already been form encoded.
+func (v *ParamsValidator) ValidateValues(method, path string, body *form.Values) error {
+	values := body.ToValues()
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	keyParts := make(map[string][]string, len(keys))
+	for _, key := range keys {
+		keyParts[key] = parseFormKey(key)
+	}
+
+	if err := validateMetadata(keys, keyParts, values); err != nil {
+		return err
+	}
+
+	rules := DefaultParamsRules
+	if v != nil && v.Rules != nil {
+		rules = v.Rules
+	}
+
+	for _, endpoint := range rules {
+		if endpoint.Method != method || (endpoint.Path != "" && !matchPath(endpoint.Path, path)) {
+			continue
+		}
+
+		for _, rule := range endpoint.Params {
+			if err := rule.validate(keys, keyParts, values); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// ValidatingBackend is a Backend that validates the parameters of every
+// request with a ParamsValidator before forwarding it to another backend,
+// and returns the validation error instead if they're invalid.
+type ValidatingBackend struct {
+	// Backend is the backend that valid requests are forwarded to.
+	Backend Backend
+
+	// Validator is the validator that parameters are checked with.
+	//
+	// Defaults to a ParamsValidator with the default rules.
+	Validator *ParamsValidator
+}
+
+// NewValidatingBackend returns a Backend that validates parameters with the
+// default rules before making requests through the given backend.
+func NewValidatingBackend(backend Backend) *ValidatingBackend {
+	return &ValidatingBackend{Backend: backend}
+}
+
+// Call is the Backend.Call implementation for ValidatingBackend.
+func (b *ValidatingBackend) Call(method, path, key string, params ParamsContainer, v interface{}) error {
+	if err := b.Validator.Validate(method, path, params); err != nil {
+		return err
+	}
+	return b.Backend.Call(method, path, key, params, v)
+}
+
+// CallMultipart is the Backend.CallMultipart implementation for
+// ValidatingBackend. Multipart requests aren't validated.
+func (b *ValidatingBackend) CallMultipart(method, path, key, boundary string, body *bytes.Buffer, params *Params, v interface{}) error {
+	return b.Backend.CallMultipart(method, path, key, boundary, body, params, v)
+}
+
+// CallRaw is the Backend.CallRaw implementation for ValidatingBackend.
+func (b *ValidatingBackend) CallRaw(method, path, key string, body *form.Values, params *Params, v interface{}) error {
+	if err := b.Validator.ValidateValues(method, path, body); err != nil {
+		return err
+	}
+	return b.Backend.CallRaw(method, path, key, body, params, v)
+}
+
+// SetMaxNetworkRetries sets max number of retries on failed requests of the
+// underlying backend.
+func (b *ValidatingBackend) SetMaxNetworkRetries(maxNetworkRetries int) {
+	b.Backend.SetMaxNetworkRetries(maxNetworkRetries)
+}
+
+// Unwrap returns the backend that requests are forwarded to.
+func (b *ValidatingBackend) Unwrap() Backend {
+	return b.Backend
+}
+
+//
+// Private functions
+//
+
+// validate checks all the values of the parameters matching the rule.
+func (r *ParamRule) validate(keys []string, keyParts map[string][]string, values map[string][]string) error {
+	pattern := parseFormKey(r.Param)
+	last := len(pattern) - 1
+
+	if r.Required {
+		for _, parent := range matchingParents(pattern[:last], keys, keyParts) {
+			param := form.FormatKey(append(parent, pattern[last]))
+			if !hasFormKey(param, keys) {
+				return NewInvalidRequestError(ErrorCodeParameterMissing, param,
+					fmt.Sprintf("Missing required param: %s.", param))
+			}
+		}
+	}
+
+	if r.Enum == "" && r.Min == nil && r.Max == nil {
+		return nil
+	}
+
+	for _, key := range keys {
+		if !matchFormKey(pattern, keyParts[key]) {
+			continue
+		}
+
+		for _, val := range values[key] {
+			// Empty values are used to unset parameters.
+			if val == "" {
+				continue
+			}
+
+			if err := r.validateValue(key, val); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (r *ParamRule) validateValue(param, val string) error {
+	if r.Enum != "" {
+		allowed, ok := enumValues[r.Enum]
+		if !ok {
+			return fmt.Errorf("stripe: unknown enum type %s in rule for %s", r.Enum, r.Param)
+		}
+
+		if !containsString(allowed, val) {
+			return NewInvalidRequestError("", param,
+				fmt.Sprintf("Invalid %s: must be one of %s", param, formatChoices(allowed)))
+		}
+	}
+
+	if r.Min == nil && r.Max == nil {
+		return nil
+	}
+
+	number, err := strconv.ParseFloat(val, 64)
+	if err != nil {
+		return NewInvalidRequestError("", param,
+			fmt.Sprintf("Invalid %s: must be a number", param))
+	}
+
+	if r.Min != nil && number < *r.Min {
+		return NewInvalidRequestError("", param,
+			fmt.Sprintf("Invalid %s: must be greater than or equal to %s",
+				param, strconv.FormatFloat(*r.Min, 'f', -1, 64)))
+	}
+
+	if r.Max != nil && number > *r.Max {
+		return NewInvalidRequestError("", param,
+			fmt.Sprintf("Invalid %s: must be less than or equal to %s",
+				param, strconv.FormatFloat(*r.Max, 'f', -1, 64)))
+	}
+
+	return nil
+}
+
+func containsString(values []string, val string) bool {
+	for _, v := range values {
+		if v == val {
+			return true
+		}
+	}
+	return false
+}
+
+// formatChoices formats a list of values like the API does in its error
+// messages: `a, b, or c`.
+func formatChoices(values []string) string {
+	if len(values) == 1 {
+		return values[0]
+	}
+	return strings.Join(values[:len(values)-1], ", ") + ", or " + values[len(values)-1]
+}
+
+// hasFormKey returns true if key, or any key nested under it, is present.
+func hasFormKey(key string, keys []string) bool {
+	prefix := key + "["
+	for _, k := range keys {
+		if k == key || strings.HasPrefix(k, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchFormKey returns true if the parts of a key match the parts of a rule's
+// pattern, in which `*` matches any part.
+func matchFormKey(pattern, parts []string) bool {
+	if len(pattern) != len(parts) {
+		return false
+	}
+
+	for i, part := range pattern {
+		if part != "*" && part != parts[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// matchingParents returns the distinct keys (as parts) that are present and
+// match the given pattern, and under which other keys are nested. An empty
+// pattern is the root, which is always present.
+func matchingParents(pattern []string, keys []string, keyParts map[string][]string) [][]string {
+	if len(pattern) == 0 {
+		return [][]string{nil}
+	}
+
+	var parents [][]string
+	seen := make(map[string]bool)
+
+	for _, key := range keys {
+		parts := keyParts[key]
+		if len(parts) <= len(pattern) || !matchFormKey(pattern, parts[:len(pattern)]) {
+			continue
+		}
+
+		parent := parts[:len(pattern):len(pattern)]
+		if parentKey := form.FormatKey(parent); !seen[parentKey] {
+			seen[parentKey] = true
+			parents = append(parents, parent)
+		}
+	}
+
+	return parents
+}
+
+// parseFormKey splits a key produced by form.FormatKey back into its parts.
+func parseFormKey(key string) []string {
+	i := strings.Index(key, "[")
+	if i < 0 || !strings.HasSuffix(key, "]") {
+		return []string{key}
+	}
+
+	parts := []string{key[:i]}
+	return append(parts, strings.Split(key[i+1:len(key)-1], "][")...)
+}
+
+// validateMetadata checks all the metadata in the given values, which may be
+// nested in other parameters, against the API's limits.
+func validateMetadata(keys []string, keyParts map[string][]string, values map[string][]string) error {
+	counts := make(map[string]int)
+
+	for _, key := range keys {
+		parts := keyParts[key]
+
+		for i := 0; i < len(parts)-1; i++ {
+			if parts[i] != "metadata" {
+				continue
+			}
+
+			param := form.FormatKey(parts[:i+1])
+			metadataKey := parts[i+1]
+
+			counts[param]++
+			if counts[param] > MetadataMaxKeys {
+				return NewInvalidRequestError("", param,
+					fmt.Sprintf("Invalid %s: metadata can have up to %d keys", param, MetadataMaxKeys))
+			}
+
+			if len(metadataKey) > MetadataMaxKeyLength {
+				return NewInvalidRequestError("", key,
+					fmt.Sprintf("Invalid %s: metadata keys can be up to %d characters long",
+						param, MetadataMaxKeyLength))
+			}
+
+			for _, val := range values[key] {
+				if len(val) > MetadataMaxValueLength {
+					return NewInvalidRequestError("", key,
+						fmt.Sprintf("Invalid %s: metadata values can be up to %d characters long",
+							param, MetadataMaxValueLength))
+				}
+			}
+
+			break
+		}
+	}
+
+	return nil
+}
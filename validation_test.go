@@ -0,0 +1,244 @@
+package stripe
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+	"github.com/stripe/stripe-go/form"
+)
+
+// recordingBackend is a Backend that records the paths of the requests made
+// through it.
+type recordingBackend struct {
+	keys   []string
+	params []ParamsContainer
+	paths  []string
+}
+
+func (b *recordingBackend) Call(method, path, key string, params ParamsContainer, v interface{}) error {
+	b.keys = append(b.keys, key)
+	b.params = append(b.params, params)
+	b.paths = append(b.paths, path)
+	return nil
+}
+
+func (b *recordingBackend) CallMultipart(method, path, key, boundary string, body *bytes.Buffer, params *Params, v interface{}) error {
+	b.paths = append(b.paths, path)
+	return nil
+}
+
+func (b *recordingBackend) CallRaw(method, path, key string, body *form.Values, params *Params, v interface{}) error {
+	b.paths = append(b.paths, path)
+	return nil
+}
+
+func (b *recordingBackend) SetMaxNetworkRetries(maxNetworkRetries int) {}
+
+func TestDefaultParamsRules(t *testing.T) {
+	for _, endpoint := range DefaultParamsRules {
+		for _, rule := range endpoint.Params {
+			if rule.Enum == "" {
+				continue
+			}
+			_, ok := enumValues[rule.Enum]
+			assert.True(t, ok, "unknown enum %s for %s %s", rule.Enum, endpoint.Path, rule.Param)
+		}
+	}
+}
+
+func TestParamsValidator_Enum(t *testing.T) {
+	validator := &ParamsValidator{}
+
+	err := validator.Validate(http.MethodPost, "/v1/subscriptions/sub_123", &SubscriptionParams{
+		ProrationBehavior: String(string(SubscriptionProrationBehaviorNone)),
+	})
+	assert.NoError(t, err)
+
+	err = validator.Validate(http.MethodPost, "/v1/subscriptions/sub_123", &SubscriptionParams{
+		ProrationBehavior: String("sometimes"),
+	})
+	assertInvalidParam(t, err, "", "proration_behavior")
+	assert.Equal(t, "Invalid proration_behavior: must be one of always_invoice, create_prorations, or none",
+		err.(*Error).Msg)
+}
+
+func TestParamsValidator_Metadata(t *testing.T) {
+	validator := &ParamsValidator{}
+
+	params := &CustomerParams{}
+	for i := 0; i < MetadataMaxKeys; i++ {
+		params.AddMetadata(fmt.Sprintf("key%d", i), "value")
+	}
+	assert.NoError(t, validator.Validate(http.MethodPost, "/v1/customers", params))
+
+	params.AddMetadata("one_too_many", "value")
+	err := validator.Validate(http.MethodPost, "/v1/customers", params)
+	assertInvalidParam(t, err, "", "metadata")
+
+	params = &CustomerParams{}
+	params.AddMetadata(strings.Repeat("k", MetadataMaxKeyLength+1), "value")
+	err = validator.Validate(http.MethodPost, "/v1/customers", params)
+	assertInvalidParam(t, err, "", "metadata["+strings.Repeat("k", MetadataMaxKeyLength+1)+"]")
+
+	// Metadata nested in other parameters is checked too
+	err = validator.Validate(http.MethodPost, "/v1/subscriptions", &SubscriptionParams{
+		Customer: String("cus_123"),
+		Items: []*SubscriptionItemsParams{
+			{Plan: String("plan_123"), Params: Params{Metadata: map[string]string{"key": strings.Repeat("v", MetadataMaxValueLength+1)}}},
+		},
+	})
+	assertInvalidParam(t, err, "", "items[0][metadata][key]")
+}
+
+func TestParamsValidator_Numbers(t *testing.T) {
+	validator := &ParamsValidator{}
+
+	err := validator.Validate(http.MethodPost, "/v1/charges", &ChargeParams{
+		Amount:   Int64(-100),
+		Currency: String(string(CurrencyUSD)),
+	})
+	assertInvalidParam(t, err, "", "amount")
+	assert.Equal(t, "Invalid amount: must be greater than or equal to 1", err.(*Error).Msg)
+
+	err = validator.Validate(http.MethodPost, "/v1/coupons", &CouponParams{
+		Duration:   String(string(CouponDurationOnce)),
+		PercentOff: Float64(100.5),
+	})
+	assertInvalidParam(t, err, "", "percent_off")
+
+	err = validator.Validate(http.MethodPost, "/v1/subscriptions", &SubscriptionParams{
+		Customer: String("cus_123"),
+		Items: []*SubscriptionItemsParams{
+			{Plan: String("plan_123"), Quantity: Int64(1)},
+			{Plan: String("plan_456"), Quantity: Int64(-1)},
+		},
+	})
+	assertInvalidParam(t, err, "", "items[1][quantity]")
+
+	err = validator.Validate(http.MethodGet, "/v1/charges", &ChargeListParams{
+		ListParams: ListParams{Limit: Int64(1000)},
+	})
+	assertInvalidParam(t, err, "", "limit")
+}
+
+func TestParamsValidator_Required(t *testing.T) {
+	validator := &ParamsValidator{}
+
+	err := validator.Validate(http.MethodPost, "/v1/charges", &ChargeParams{
+		Amount: Int64(100),
+	})
+	assertInvalidParam(t, err, ErrorCodeParameterMissing, "currency")
+	assert.Equal(t, "Missing required param: currency.", err.(*Error).Msg)
+
+	// Updates don't require the same parameters as creates
+	err = validator.Validate(http.MethodPost, "/v1/charges/ch_123", &ChargeParams{
+		Description: String("updated"),
+	})
+	assert.NoError(t, err)
+
+	// Nested parameters are only required when their parent is present
+	params := &ChargeParams{
+		Amount:   Int64(100),
+		Currency: String(string(CurrencyUSD)),
+	}
+	assert.NoError(t, validator.Validate(http.MethodPost, "/v1/charges", params))
+
+	params.Shipping = &ShippingDetailsParams{Name: String("Jenny Rosen")}
+	err = validator.Validate(http.MethodPost, "/v1/charges", params)
+	assertInvalidParam(t, err, ErrorCodeParameterMissing, "shipping[address]")
+
+	params.Shipping.Address = &AddressParams{City: String("San Francisco")}
+	err = validator.Validate(http.MethodPost, "/v1/charges", params)
+	assertInvalidParam(t, err, ErrorCodeParameterMissing, "shipping[address][line1]")
+
+	params.Shipping.Address.Line1 = String("1234 Main Street")
+	assert.NoError(t, validator.Validate(http.MethodPost, "/v1/charges", params))
+}
+
+func TestParamsValidator_Rules(t *testing.T) {
+	validator := &ParamsValidator{Rules: []*EndpointRules{
+		{Method: http.MethodPost, Path: "/v1/customers/*", Params: []*ParamRule{
+			{Param: "tax_exempt", Enum: "CustomerTaxExempt"},
+		}},
+	}}
+
+	// Only the given rules are checked
+	err := validator.Validate(http.MethodPost, "/v1/charges", &ChargeParams{})
+	assert.NoError(t, err)
+
+	err = validator.Validate(http.MethodPost, "/v1/customers/cus_123", &CustomerParams{
+		TaxExempt: String("sometimes"),
+	})
+	assertInvalidParam(t, err, "", "tax_exempt")
+
+	// A rule with an unknown enum is an error in itself
+	validator.Rules[0].Params[0].Enum = "CustomerTaxExemptions"
+	err = validator.Validate(http.MethodPost, "/v1/customers/cus_123", &CustomerParams{
+		TaxExempt: String("sometimes"),
+	})
+	assert.Error(t, err)
+	_, ok := err.(*Error)
+	assert.False(t, ok)
+}
+
+func TestParamsValidator_Unset(t *testing.T) {
+	params := &SubscriptionParams{}
+	params.Unset("tax_percent")
+
+	err := (&ParamsValidator{}).Validate(http.MethodPost, "/v1/subscriptions/sub_123", params)
+	assert.NoError(t, err)
+}
+
+func TestParseFormKey(t *testing.T) {
+	assert.Equal(t, []string{"amount"}, parseFormKey("amount"))
+	assert.Equal(t, []string{"items", "0", "metadata", "key"}, parseFormKey("items[0][metadata][key]"))
+	assert.Equal(t, []string{"metadata", ""}, parseFormKey("metadata[]"))
+}
+
+func TestValidatingBackend(t *testing.T) {
+	recorder := &recordingBackend{}
+	backend := NewValidatingBackend(recorder)
+
+	err := backend.Call(http.MethodPost, "/v1/charges", "sk_test_123", &ChargeParams{Amount: Int64(-1)}, nil)
+	assertInvalidParam(t, err, "", "amount")
+
+	body := &form.Values{}
+	body.Add("amount", "100")
+	err = backend.CallRaw(http.MethodPost, "/v1/charges", "sk_test_123", body, nil, nil)
+	assertInvalidParam(t, err, ErrorCodeParameterMissing, "currency")
+
+	// Invalid requests never reach the underlying backend
+	assert.Empty(t, recorder.paths)
+
+	err = backend.Call(http.MethodPost, "/v1/charges", "sk_test_123", &ChargeParams{
+		Amount:   Int64(100),
+		Currency: String(string(CurrencyUSD)),
+	}, nil)
+	assert.NoError(t, err)
+
+	err = backend.Call(http.MethodGet, "/v1/charges/ch_123", "sk_test_123", nil, nil)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"/v1/charges", "/v1/charges/ch_123"}, recorder.paths)
+}
+
+//
+// Private functions
+//
+
+func assertInvalidParam(t *testing.T, err error, code ErrorCode, param string) {
+	assert.Error(t, err)
+
+	stripeErr, ok := err.(*Error)
+	assert.True(t, ok, "expected a *Error, got %T", err)
+	assert.Equal(t, ErrorTypeInvalidRequest, stripeErr.Type)
+	assert.Equal(t, code, stripeErr.Code)
+	assert.Equal(t, param, stripeErr.Param)
+
+	_, ok = stripeErr.Err.(*InvalidRequestError)
+	assert.True(t, ok)
+}
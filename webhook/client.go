@@ -4,7 +4,6 @@ import (
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"strconv"
@@ -59,6 +58,9 @@ func ComputeSignature(t time.Time, payload []byte, secret string) []byte {
 // signature doesn't match, or if the timestamp for the signature is older than
 // DefaultTolerance.
 //
+// If stripe.DefaultLivemodeGuard is set, an error is also returned for events
+// whose livemode doesn't match the environment's.
+//
 // NOTE: Stripe will only send Webhook signing headers after you have retrieved
 // your signing secret from the Stripe dashboard:
 // https://dashboard.stripe.com/webhooks
@@ -153,10 +155,14 @@ func constructEvent(payload []byte, sigHeader string, secret string, tolerance t
 		return e, err
 	}
 
-	if err := json.Unmarshal(payload, &e); err != nil {
+	if err := stripe.Unmarshal(payload, &e); err != nil {
 		return e, fmt.Errorf("Failed to parse webhook body json: %s", err.Error())
 	}
 
+	if err := stripe.DefaultLivemodeGuard.CheckLivemode(e.Livemode); err != nil {
+		return e, err
+	}
+
 	return e, nil
 
 }
@@ -212,7 +218,7 @@ func validatePayload(payload []byte, sigHeader string, secret string, tolerance
 	}
 
 	expectedSignature := ComputeSignature(header.timestamp, payload, secret)
-	expiredTimestamp := time.Since(header.timestamp) > tolerance
+	expiredTimestamp := stripe.DefaultClock.Now().Sub(header.timestamp) > tolerance
 	if enforceTolerance && expiredTimestamp {
 		return ErrTooOld
 	}
@@ -5,6 +5,9 @@ import (
 	"fmt"
 	"testing"
 	"time"
+
+	"github.com/stripe/stripe-go"
+	stripetesting "github.com/stripe/stripe-go/testing"
 )
 
 var testPayload = []byte(`{
@@ -182,3 +185,44 @@ func TestTokenNew(t *testing.T) {
 		t.Errorf("Received %v error when timestamp outside window but no tolerance specified", err)
 	}
 }
+
+func TestConstructEvent_LivemodeGuard(t *testing.T) {
+	stripe.DefaultLivemodeGuard = &stripe.LivemodeGuard{Livemode: true}
+	defer func() { stripe.DefaultLivemodeGuard = nil }()
+
+	p := newSignedPayload()
+	_, err := ConstructEvent(p.payload, p.header, p.secret)
+	if err == nil {
+		t.Errorf("Expected an error from a test mode event in a live mode environment")
+	}
+
+	p = newSignedPayload(func(p *SignedPayload) {
+		p.payload = []byte(`{"id": "evt_test_webhook", "object": "event", "livemode": true}`)
+	})
+	_, err = ConstructEvent(p.payload, p.header, p.secret)
+	if err != nil {
+		t.Errorf("Received unexpected %v error for a live mode event", err)
+	}
+}
+
+func TestValidatePayload_Clock(t *testing.T) {
+	clock := stripetesting.NewClock(time.Unix(1500000000, 0))
+	defer func(original stripe.Clock) { stripe.DefaultClock = original }(stripe.DefaultClock)
+	stripe.DefaultClock = clock
+
+	p := newSignedPayload(func(p *SignedPayload) {
+		p.timestamp = clock.Now()
+	})
+
+	clock.Sleep(DefaultTolerance)
+	err := ValidatePayload(p.payload, p.header, p.secret)
+	if err != nil {
+		t.Errorf("Received %v error when validating timestamp at the end of the allowed timing window", err)
+	}
+
+	clock.Sleep(time.Second)
+	err = ValidatePayload(p.payload, p.header, p.secret)
+	if err != ErrTooOld {
+		t.Errorf("Received %v error when validating timestamp after the allowed timing window", err)
+	}
+}
@@ -39,6 +39,9 @@ type WebhookEndpoint struct {
 	Secret        string   `json:"secret"`
 	Status        string   `json:"status"`
 	URL           string   `json:"url"`
+
+	Extra    map[string]json.RawMessage `json:"-"`
+	expanded bool
 }
 
 // WebhookEndpointList is a list of webhook endpoints as retrieved from a list endpoint.
@@ -63,5 +66,6 @@ func (c *WebhookEndpoint) UnmarshalJSON(data []byte) error {
 	}
 
 	*c = WebhookEndpoint(v)
+	c.expanded = true
 	return nil
 }